@@ -24,6 +24,8 @@ import (
 
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime/plugin"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/signals"
 
@@ -40,6 +42,7 @@ func main() {
 	ctx := signals.SetupSignalContext()
 	ctx, logger := log.InitFlags(ctx, flagset)
 
+	config.InitArchFlag(flagset)
 	ctx, err := config.InitFlags(ctx, flagset)
 	if err != nil {
 		_, _ = os.Stderr.Write([]byte(flagset.FlagUsages()))
@@ -47,6 +50,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	err = plugin.Register(ctx, runtime.DefaultRegistry, config.PluginsDir)
+	if err != nil {
+		logger.Warn("Failed to register the runtime plugins", "err", err)
+	}
+
 	command := cmd.NewCommand(ctx)
 	command.PersistentFlags().AddFlagSet(flagset)
 	err = command.ExecuteContext(ctx)
@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package main is a tool to generate the documentation for the kwok and kwokctl commands.
+// Package main is a tool to generate the documentation for the kwok, kwokctl and kubectl-kwok commands.
 package main
 
 import (
@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	kubectlkwokcmd "sigs.k8s.io/kwok/pkg/kubectlkwok/cmd"
 	kwokcmd "sigs.k8s.io/kwok/pkg/kwok/cmd"
 	kwokctlcmd "sigs.k8s.io/kwok/pkg/kwokctl/cmd"
 	"sigs.k8s.io/kwok/pkg/log"
@@ -69,6 +70,11 @@ func main() {
 		logger.Error("Generate kwokctl docs", err)
 		os.Exit(1)
 	}
+	err = genKubectlKwok(ctx, flagset, basePath)
+	if err != nil {
+		logger.Error("Generate kubectl-kwok docs", err)
+		os.Exit(1)
+	}
 }
 
 func genKwok(ctx context.Context, flags *pflag.FlagSet, basePath string) error {
@@ -81,6 +87,16 @@ func genKwok(ctx context.Context, flags *pflag.FlagSet, basePath string) error {
 func genKwokctl(ctx context.Context, flags *pflag.FlagSet, basePath string) error {
 	rootCmd := kwokctlcmd.NewCommand(ctx)
 	rootCmd.PersistentFlags().AddFlagSet(flags)
+	kwokctlFlags := pflag.NewFlagSet("kwokctl", pflag.ContinueOnError)
+	config.InitArchFlag(kwokctlFlags)
+	rootCmd.PersistentFlags().AddFlagSet(kwokctlFlags)
+	rootCmd.DisableAutoGenTag = true
+	return doc.GenMarkdownTree(rootCmd, basePath)
+}
+
+func genKubectlKwok(ctx context.Context, flags *pflag.FlagSet, basePath string) error {
+	rootCmd := kubectlkwokcmd.NewCommand(ctx)
+	rootCmd.PersistentFlags().AddFlagSet(flags)
 	rootCmd.DisableAutoGenTag = true
 	return doc.GenMarkdownTree(rootCmd, basePath)
 }
@@ -61,4 +61,12 @@ var (
 	// Metric is the custom resource definition for metrics.
 	//go:embed bases/kwok.x-k8s.io_metrics.yaml
 	Metric []byte
+
+	// ResourceUsage is the custom resource definition for resource usages.
+	//go:embed bases/kwok.x-k8s.io_resourceusages.yaml
+	ResourceUsage []byte
+
+	// ClusterResourceUsage is the custom resource definition for cluster resource usages.
+	//go:embed bases/kwok.x-k8s.io_clusterresourceusages.yaml
+	ClusterResourceUsage []byte
 )
@@ -0,0 +1,36 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package patterns contains the resource usages and the metrics modeling the common workloads for kwok.
+package patterns
+
+import (
+	_ "embed"
+)
+
+var (
+	// WebService is the web service pattern yaml.
+	//go:embed web-service.yaml
+	WebService string
+
+	// Batch is the batch pattern yaml.
+	//go:embed batch.yaml
+	Batch string
+
+	// MLTraining is the ml training pattern yaml.
+	//go:embed ml-training.yaml
+	MLTraining string
+)
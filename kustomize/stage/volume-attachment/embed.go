@@ -0,0 +1,32 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package volumeattachment contains the stages of the volume attachments for kwok.
+package volumeattachment
+
+import (
+	_ "embed"
+)
+
+var (
+	// DefaultVolumeAttachmentAttach is the default volume attachment attach yaml.
+	//go:embed volume-attachment-attach.yaml
+	DefaultVolumeAttachmentAttach string
+
+	// DefaultVolumeAttachmentDetach is the default volume attachment detach yaml.
+	//go:embed volume-attachment-detach.yaml
+	DefaultVolumeAttachmentDetach string
+)
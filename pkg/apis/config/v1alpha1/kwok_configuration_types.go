@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -45,11 +46,13 @@ type KwokConfigurationOptions struct {
 	EnableCRDs []string `json:"enableCRDs,omitempty"`
 
 	// The default IP assigned to the Pod on maintained Nodes.
+	// The CIDRs of the IPv4 and IPv6 families are separated by comma for dual-stack.
 	// is the default value for flag --cidr
 	// +default="10.0.0.1/24"
 	CIDR string `json:"cidr,omitempty"`
 
 	// The ip of all nodes maintained by the Kwok
+	// The IPs of the IPv4 and IPv6 families are separated by comma for dual-stack.
 	// is the default value for flag --node-ip
 	NodeIP string `json:"nodeIP,omitempty"`
 
@@ -99,6 +102,13 @@ type KwokConfigurationOptions struct {
 	// this is a no-op.
 	ManageNodesWithLabelSelector string `json:"manageNodesWithLabelSelector,omitempty"`
 
+	// ManageNodeRules is the rules to decide whether the nodes are managed,
+	// they are checked in order and the first rule matching the node decides it,
+	// the nodes matching none of the rules are not managed.
+	// Note: it is conflicted with `manage-all-nodes`, `manage-single-node`,
+	// `manage-nodes-with-annotation-selector` and `manage-nodes-with-label-selector`.
+	ManageNodeRules []ManageNodeRule `json:"manageNodeRules,omitempty"`
+
 	// If a Node/Pod is on a managed Node and has this annotation status will not be modified
 	// is the default value for flag --disregard-status-with-annotation-selector
 	DisregardStatusWithAnnotationSelector string `json:"disregardStatusWithAnnotationSelector,omitempty"`
@@ -137,10 +147,175 @@ type KwokConfigurationOptions struct {
 	// +default=4
 	NodePlayStageParallelism uint `json:"nodePlayStageParallelism,omitempty"`
 
+	// EnableStageForRefs is a list of resources other than Pod and Node whose lifecycle is driven by the stages,
+	// in the format of <kind>.<version>.<group>, e.g. VolumeSnapshot.v1.snapshot.storage.k8s.io.
+	// The resources referenced by the stages in the --config are enabled automatically.
+	EnableStageForRefs []string `json:"enableStageForRefs,omitempty"`
+
+	// CustomPlayStageParallelism is the number of PlayStages of the resources other than Pod and Node that are allowed to run in parallel.
+	// +default=4
+	CustomPlayStageParallelism uint `json:"customPlayStageParallelism,omitempty"`
+
+	// StageMaxChainDepth is the max number of stages that are played one after another by immediateNextStage,
+	// the chain is broken with an event on the resource once exceeded, to avoid the stages forming a loop spinning the controller.
+	// is the default value for flag --stage-max-chain-depth
+	// +default=16
+	StageMaxChainDepth uint `json:"stageMaxChainDepth,omitempty"`
+
+	// TimeScale is the factor that all delays of the stages are divided by,
+	// e.g. 60 plays a scenario of 1 hour in 1 minute, and 0.5 plays it at half speed.
+	// The ages in the expressions of the stages are multiplied by it accordingly.
+	// is the default value for flag --time-scale
+	// +default=1
+	TimeScale float64 `json:"timeScale,omitempty"`
+
+	// EnableStageNextAnnotations means the resources are annotated with the next stage planned for them and the time of it.
+	// is the default value for flag --enable-stage-next-annotations
+	// +default=false
+	EnableStageNextAnnotations *bool `json:"enableStageNextAnnotations,omitempty"`
+
+	// EnableNodePressure means the MemoryPressure, DiskPressure and PIDPressure conditions of the nodes
+	// are flipped by the requests of the pods on them against the allocatable of the nodes.
+	// is the default value for flag --enable-node-pressure
+	// +default=false
+	EnableNodePressure *bool `json:"enableNodePressure,omitempty"`
+
+	// NodePressureThreshold is the ratio of the requests of the pods to the allocatable of the node,
+	// at or above which the node is under pressure.
+	// is the default value for flag --node-pressure-threshold
+	// +default=0.9
+	NodePressureThreshold float64 `json:"nodePressureThreshold,omitempty"`
+
 	// NodeLeaseDurationSeconds is the duration the Kubelet will set on its corresponding Lease.
 	NodeLeaseDurationSeconds uint `json:"nodeLeaseDurationSeconds,omitempty"`
 
 	// NodeLeaseParallelism is the number of NodeLeases that are allowed to be processed in parallel.
 	// +default=4
 	NodeLeaseParallelism uint `json:"nodeLeaseParallelism,omitempty"`
+
+	// NodeLeaseOnly means the status of the nodes is not updated if only the heartbeat times of the conditions would change,
+	// the nodes are kept alive by their leases only, to reduce the writes to the apiserver at extreme scale.
+	// It takes effect only when the node leases are enabled, and the node with the annotation
+	// kwok.x-k8s.io/node-lease-only: "false" opts out of it.
+	// is the default value for flag --node-lease-only
+	// +default=false
+	NodeLeaseOnly *bool `json:"nodeLeaseOnly,omitempty"`
+
+	// StaticPodPath is the directory of the manifests of the static pods, one pod for each file,
+	// the mirror pods of them are created on all managed nodes,
+	// and the ones in the subdirectory named after a node are created on that node only.
+	// is the default value for flag --static-pod-path
+	StaticPodPath string `json:"staticPodPath,omitempty"`
+
+	// FlapNodesWithLabelSelector is the label selector of the nodes whose Ready condition is flipped
+	// between True and False periodically, to test the controllers reacting on the nodes going down and up.
+	// is the default value for flag --flap-nodes-with-label-selector
+	FlapNodesWithLabelSelector string `json:"flapNodesWithLabelSelector,omitempty"`
+
+	// NodeFlappingUpSeconds is the seconds the flapping nodes are Ready for in each period.
+	// is the default value for flag --node-flapping-up-seconds
+	// +default=300
+	NodeFlappingUpSeconds uint `json:"nodeFlappingUpSeconds,omitempty"`
+
+	// NodeFlappingDownSeconds is the seconds the flapping nodes are NotReady for in each period.
+	// is the default value for flag --node-flapping-down-seconds
+	// +default=60
+	NodeFlappingDownSeconds uint `json:"nodeFlappingDownSeconds,omitempty"`
+
+	// EnableNodeVolumes means the volumes of the bound PersistentVolumeClaims of the pods on the nodes
+	// are reported in the volumesInUse of the nodes, as the kubelet does once it mounts them.
+	// is the default value for flag --enable-node-volumes
+	// +default=false
+	EnableNodeVolumes *bool `json:"enableNodeVolumes,omitempty"`
+
+	// PodAdmissionResources is the resources of the allocatable of the nodes that the pods are admitted against,
+	// e.g. pods, cpu and memory, the pods not fitting the nodes are failed with the reason like OutOfpods as the kubelet does.
+	// is the default value for flag --pod-admission-resources
+	PodAdmissionResources []string `json:"podAdmissionResources,omitempty"`
+
+	// Shards is the number of shards that the nodes are split into by the hash of their names,
+	// each shard is managed by the replica of kwok holding the lease of it, 0 means no sharding.
+	// is the default value for flag --shards
+	Shards uint `json:"shards,omitempty"`
+
+	// MaxShardsPerReplica is the max number of shards held by a replica, 0 means no limit.
+	// is the default value for flag --max-shards-per-replica
+	MaxShardsPerReplica uint `json:"maxShardsPerReplica,omitempty"`
+
+	// EnableNodeLifecycleEvents means the Events of the lifecycle of the nodes are recorded,
+	// e.g. Starting, NodeReady and NodeNotReady from the kubelet, and RegisteredNode from the node-controller.
+	// is the default value for flag --enable-node-lifecycle-events
+	// +default=false
+	EnableNodeLifecycleEvents *bool `json:"enableNodeLifecycleEvents,omitempty"`
+
+	// ImageSimulation simulates the pulling of the images of the pods from a fake registry,
+	// which is exposed to the stages of Pod by the $imagePull variable.
+	ImageSimulation *ImageSimulation `json:"imageSimulation,omitempty"`
+
+	// CRISocketDir is the directory of the fake CRI endpoints of the managed nodes,
+	// the endpoint of a node is served on the unix socket <criSocketDir>/<node>.sock,
+	// which reflects the pods on the node for the tools talking CRI, e.g. crictl.
+	// It is disabled if empty.
+	// is the default value for flag --cri-socket-dir
+	CRISocketDir string `json:"criSocketDir,omitempty"`
+
+	// MetricsMaxSeries is the max number of the series of a Metric on a node updated in a scrape,
+	// the metrics exceeding it are dropped with an error logged, to keep a Metric expanding without bound from exhausting the memory.
+	// is the default value for flag --metrics-max-series
+	// +default=100000
+	MetricsMaxSeries uint `json:"metricsMaxSeries,omitempty"`
+
+	// MetricsMaxLabelValues is the max number of the values of a label of a metric of a Metric on a node in a scrape,
+	// the metrics exceeding it are dropped with an error logged.
+	// is the default value for flag --metrics-max-label-values
+	// +default=10000
+	MetricsMaxLabelValues uint `json:"metricsMaxLabelValues,omitempty"`
+
+	// MetricsScrapeTimeoutSeconds is the seconds a scrape of a Metric is allowed to evaluate the series for,
+	// the series not evaluated in time keep their last values.
+	// is the default value for flag --metrics-scrape-timeout-seconds
+	// +default=10
+	MetricsScrapeTimeoutSeconds uint `json:"metricsScrapeTimeoutSeconds,omitempty"`
+
+	// RandSeed is the seed of the SeededRand of the CEL expressions of the ResourceUsages and the Metrics,
+	// which is combined with the UID of the object, so the same seed simulates the same values across runs.
+	// is the default value for flag --rand-seed
+	RandSeed int64 `json:"randSeed,omitempty"`
+
+	// StatsCacheTTLSeconds is the seconds the usages of the containers and the stats of the pods are cached for,
+	// for the metrics API and the summary API, a pod is recomputed before it once it changes.
+	// is the default value for flag --stats-cache-ttl-seconds
+	// +default=5
+	StatsCacheTTLSeconds uint `json:"statsCacheTTLSeconds,omitempty"`
+}
+
+// ManageNodeRule is a rule to decide whether the nodes are managed,
+// it matches the nodes matching all of the set fields.
+type ManageNodeRule struct {
+	// Names is the names of the nodes.
+	Names []string `json:"names,omitempty"`
+	// LabelSelector is the label selector of the nodes.
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// AnnotationSelector is the annotation selector of the nodes.
+	AnnotationSelector string `json:"annotationSelector,omitempty"`
+	// Exclude means the nodes matching the rule are not managed.
+	Exclude bool `json:"exclude,omitempty"`
+}
+
+// ImageSimulation holds the images of the fake registry and the bandwidth of the nodes to pull them.
+type ImageSimulation struct {
+	// Images is the images in the registry, the pulling of the other images fails.
+	Images []SimulatedImage `json:"images,omitempty"`
+	// Bandwidth is the bytes per second that the nodes pull the images at,
+	// the node annotation kwok.x-k8s.io/image-pull-bandwidth overrides it for the node.
+	// It is 100Mi if not set.
+	Bandwidth *resource.Quantity `json:"bandwidth,omitempty"`
+}
+
+// SimulatedImage is an image in the fake registry.
+type SimulatedImage struct {
+	// Name is the reference of the image used by the containers, e.g. nginx:1.25.
+	Name string `json:"name"`
+	// Size is the size of the image in bytes.
+	Size resource.Quantity `json:"size"`
 }
@@ -62,6 +62,31 @@ type ExtraArgs struct {
 	Value string `json:"value"`
 }
 
+// BinaryVerification holds information about the verification of a downloaded binary.
+type BinaryVerification struct {
+	// URL is the url of the binary, or of the archive the binary is extracted from.
+	URL string `json:"url"`
+	// Sha256 is the sha256 checksum of the file.
+	// +optional
+	Sha256 string `json:"sha256,omitempty"`
+	// Signature is the url or path of the cosign signature of the file.
+	// +optional
+	Signature string `json:"signature,omitempty"`
+	// Key is the path of the public key the file is signed with,
+	// it is required if the file is not signed with the keyless signing.
+	// +optional
+	Key string `json:"key,omitempty"`
+	// Certificate is the url or path of the certificate of the keyless signing.
+	// +optional
+	Certificate string `json:"certificate,omitempty"`
+	// CertificateIdentity is the identity expected in the certificate of the keyless signing.
+	// +optional
+	CertificateIdentity string `json:"certificateIdentity,omitempty"`
+	// CertificateOIDCIssuer is the OIDC issuer expected in the certificate of the keyless signing.
+	// +optional
+	CertificateOIDCIssuer string `json:"certificateOIDCIssuer,omitempty"`
+}
+
 // ComponentPatches holds information about the component patches.
 type ComponentPatches struct {
 	// Name is the name of the component.
@@ -101,6 +126,10 @@ type KwokctlConfigurationOptions struct {
 	// is the default value for flag --jaeger-port and env KWOK_JAEGER_PORT
 	JaegerPort uint32 `json:"jaegerPort,omitempty"`
 
+	// GrafanaPort is the port to expose Grafana UI.
+	// is the default value for flag --grafana-port and env KWOK_GRAFANA_PORT
+	GrafanaPort uint32 `json:"grafanaPort,omitempty"`
+
 	// JaegerOtlpGrpcPort is the port to expose OTLP GRPC collector.
 	JaegerOtlpGrpcPort uint32 `json:"jaegerOtlpGrpcPort,omitempty"`
 
@@ -127,6 +156,10 @@ type KwokctlConfigurationOptions struct {
 	// is the default value for env KWOK_JAEGER_VERSION
 	JaegerVersion string `json:"jaegerVersion,omitempty"`
 
+	// GrafanaVersion is the version of Grafana to use.
+	// is the default value for env KWOK_GRAFANA_VERSION
+	GrafanaVersion string `json:"grafanaVersion,omitempty"`
+
 	// DockerComposeVersion is the version of docker-compose to use.
 	// is the default value for env KWOK_DOCKER_COMPOSE_VERSION
 	// Deprecated: docker compose will be removed in a future release
@@ -146,6 +179,12 @@ type KwokctlConfigurationOptions struct {
 	// +default=false
 	QuietPull *bool `json:"quietPull,omitempty"`
 
+	// Offline is the flag to create the cluster without the network,
+	// the images and binaries are only got from the local or the cache.
+	// is the default value for flag --offline and env KWOK_OFFLINE
+	// +default=false
+	Offline *bool `json:"offline,omitempty"`
+
 	// KubeSchedulerConfig is the configuration path for kube-scheduler.
 	// is the default value for flag --kube-scheduler-config and env KWOK_KUBE_SCHEDULER_CONFIG
 	KubeSchedulerConfig string `json:"kubeSchedulerConfig,omitempty"`
@@ -160,6 +199,38 @@ type KwokctlConfigurationOptions struct {
 	// +default=false
 	DisableKubeControllerManager *bool `json:"disableKubeControllerManager,omitempty"`
 
+	// EnableMetricsServer is the flag to serve the metrics API (metrics.k8s.io) from the usages simulated by the kwok-controller.
+	// is the default value for flag --enable-metrics-server and env KWOK_ENABLE_METRICS_SERVER
+	// +default=false
+	EnableMetricsServer *bool `json:"enableMetricsServer,omitempty"`
+
+	// EnableCustomMetrics is the flag to serve the custom metrics API (custom.metrics.k8s.io)
+	// and the external metrics API (external.metrics.k8s.io) from the Metrics by the kwok-controller.
+	// is the default value for flag --enable-custom-metrics and env KWOK_ENABLE_CUSTOM_METRICS
+	// +default=false
+	EnableCustomMetrics *bool `json:"enableCustomMetrics,omitempty"`
+
+	// EnableGrafana is the flag to deploy Grafana with the dashboards of the cluster,
+	// the Grafana and the Prometheus are given random ports if their ports are not set.
+	// is the default value for flag --enable-grafana and env KWOK_ENABLE_GRAFANA
+	// +default=false
+	EnableGrafana *bool `json:"enableGrafana,omitempty"`
+
+	// KubeApiserverReplicas is the number of kube-apiserver instances, the replicas share the etcd.
+	// is the default value for flag --kube-apiserver-replicas and env KWOK_KUBE_APISERVER_REPLICAS
+	// +default=1
+	KubeApiserverReplicas uint32 `json:"kubeApiserverReplicas,omitempty"`
+
+	// KubeControllerManagerReplicas is the number of kube-controller-manager instances, the replicas elect a leader.
+	// is the default value for flag --kube-controller-manager-replicas and env KWOK_KUBE_CONTROLLER_MANAGER_REPLICAS
+	// +default=1
+	KubeControllerManagerReplicas uint32 `json:"kubeControllerManagerReplicas,omitempty"`
+
+	// KubeSchedulerReplicas is the number of kube-scheduler instances, the replicas elect a leader.
+	// is the default value for flag --kube-scheduler-replicas and env KWOK_KUBE_SCHEDULER_REPLICAS
+	// +default=1
+	KubeSchedulerReplicas uint32 `json:"kubeSchedulerReplicas,omitempty"`
+
 	// KubeImagePrefix is the prefix of the kubernetes image.
 	// is the default value for env KWOK_KUBE_IMAGE_PREFIX
 	//+k8s:conversion-gen=false
@@ -189,6 +260,11 @@ type KwokctlConfigurationOptions struct {
 	//+k8s:conversion-gen=false
 	JaegerImagePrefix string `json:"jaegerImagePrefix,omitempty"`
 
+	// GrafanaImagePrefix is the prefix of the Grafana image.
+	// is the default value for env KWOK_GRAFANA_IMAGE_PREFIX
+	//+k8s:conversion-gen=false
+	GrafanaImagePrefix string `json:"grafanaImagePrefix,omitempty"`
+
 	// EtcdImage is the image of etcd.
 	// is the default value for flag --etcd-image and env KWOK_ETCD_IMAGE
 	EtcdImage string `json:"etcdImage,omitempty"`
@@ -220,6 +296,10 @@ type KwokctlConfigurationOptions struct {
 	// is the default value for flag --jaeger-image and env KWOK_JAEGER_IMAGE
 	JaegerImage string `json:"jaegerImage,omitempty"`
 
+	// GrafanaImage is the image of Grafana.
+	// is the default value for flag --grafana-image and env KWOK_GRAFANA_IMAGE
+	GrafanaImage string `json:"grafanaImage,omitempty"`
+
 	// KindNodeImagePrefix is the prefix of the kind node image.
 	// is the default value for env KWOK_KIND_NODE_IMAGE_PREFIX
 	//+k8s:conversion-gen=false
@@ -229,6 +309,19 @@ type KwokctlConfigurationOptions struct {
 	// is the default value for flag --kind-node-image and env KWOK_KIND_NODE_IMAGE
 	KindNodeImage string `json:"kindNodeImage,omitempty"`
 
+	// KindClusterName is the name of an existing kind cluster adopted by kwokctl,
+	// it is set by `kwokctl adopt` and the kind cluster is kept when the cluster is deleted.
+	KindClusterName string `json:"kindClusterName,omitempty"`
+
+	// Arch is the architecture of the binaries and images of the components, the architecture of the host by default.
+	// is the default value for flag --arch and env KWOK_ARCH
+	Arch string `json:"arch,omitempty"`
+
+	// BinaryVerifications is the list of the verifications of the downloaded binaries,
+	// the binary is refused if it does not match the checksum or the signature of it.
+	// +optional
+	BinaryVerifications []BinaryVerification `json:"binaryVerifications,omitempty"`
+
 	// BinSuffix is the suffix of the all binary.
 	// On Windows is .exe
 	BinSuffix string `json:"binSuffix,omitempty"`
@@ -346,6 +439,11 @@ type KwokctlConfigurationOptions struct {
 	// is the default value for flag --kube-audit-policy and env KWOK_KUBE_AUDIT_POLICY
 	KubeAuditPolicy string `json:"kubeAuditPolicy,omitempty"`
 
+	// KubeAuditWebhook is path to the kubeconfig formatted file that defines the audit webhook configuration,
+	// it only takes effect with the audit policy.
+	// is the default value for flag --kube-audit-webhook and env KWOK_KUBE_AUDIT_WEBHOOK
+	KubeAuditWebhook string `json:"kubeAuditWebhook,omitempty"`
+
 	// KubeAuthorization is the flag to enable authorization on secure port.
 	// is the default value for flag --kube-authorization and env KWOK_KUBE_AUTHORIZATION
 	KubeAuthorization *bool `json:"kubeAuthorization,omitempty"`
@@ -360,6 +458,40 @@ type KwokctlConfigurationOptions struct {
 	// EtcdPort is etcd port in the binary runtime
 	EtcdPort uint32 `json:"etcdPort,omitempty"`
 
+	// EtcdServers is the list of the servers of the external etcd, the etcd is not created if it is set.
+	// is the default value for flag --etcd-servers
+	EtcdServers []string `json:"etcdServers,omitempty"`
+
+	// EtcdCaFile is the CA file to secure the communication with the external etcd.
+	// is the default value for flag --etcd-cafile and env KWOK_ETCD_CAFILE
+	EtcdCaFile string `json:"etcdCaFile,omitempty"`
+
+	// EtcdCertFile is the client certificate file of the external etcd.
+	// is the default value for flag --etcd-certfile and env KWOK_ETCD_CERTFILE
+	EtcdCertFile string `json:"etcdCertFile,omitempty"`
+
+	// EtcdKeyFile is the client key file of the external etcd.
+	// is the default value for flag --etcd-keyfile and env KWOK_ETCD_KEYFILE
+	EtcdKeyFile string `json:"etcdKeyFile,omitempty"`
+
+	// EtcdPrefix is the prefix of the keys of the cluster in etcd,
+	// the clusters sharing an external etcd have their own prefixes.
+	// is the default value for flag --etcd-prefix and env KWOK_ETCD_PREFIX
+	// +default="/registry"
+	EtcdPrefix string `json:"etcdPrefix,omitempty"`
+
+	// EtcdAutoCompactionMode is the mode of the auto compaction of etcd, periodic or revision.
+	// is the default value for flag --etcd-auto-compaction-mode and env KWOK_ETCD_AUTO_COMPACTION_MODE
+	// +default="periodic"
+	EtcdAutoCompactionMode string `json:"etcdAutoCompactionMode,omitempty"`
+
+	// EtcdAutoCompactionRetention is the retention of the auto compaction of etcd,
+	// a duration, e.g. 1h or 1 for hours, in the periodic mode, and a number of revisions in the revision mode,
+	// 0 disables the auto compaction.
+	// is the default value for flag --etcd-auto-compaction-retention and env KWOK_ETCD_AUTO_COMPACTION_RETENTION
+	// +default="1"
+	EtcdAutoCompactionRetention string `json:"etcdAutoCompactionRetention,omitempty"`
+
 	// KubeControllerManagerPort is kube-controller-manager port in the binary runtime
 	KubeControllerManagerPort uint32 `json:"kubeControllerManagerPort,omitempty"`
 
@@ -376,6 +508,11 @@ type KwokctlConfigurationOptions struct {
 	// CacheDir is the directory of the cache.
 	CacheDir string `json:"cacheDir,omitempty"`
 
+	// CacheMaxSize is the maximum size of the cache in the quantity of bytes, e.g. 10Gi,
+	// the least recently used files not used by the clusters are removed once the cache is larger.
+	// is the default value for flag --cache-max-size and env KWOK_CACHE_MAX_SIZE
+	CacheMaxSize string `json:"cacheMaxSize,omitempty"`
+
 	// KubeControllerManagerNodeMonitorPeriodMilliseconds is --node-monitor-period for kube-controller-manager.
 	// +default=600000
 	KubeControllerManagerNodeMonitorPeriodMilliseconds int64 `json:"kubeControllerManagerNodeMonitorPeriodMilliseconds,omitempty"`
@@ -402,6 +539,43 @@ type KwokctlConfigurationOptions struct {
 	// DisableQPSLimits specifies whether to disable QPS limits for components.
 	// +default=false
 	DisableQPSLimits *bool `json:"disableQPSLimits,omitempty"`
+
+	// Nodes is the number of the nodes to create after the cluster is started.
+	// is the default value for flag --nodes and env KWOK_NODES
+	Nodes uint32 `json:"nodes,omitempty"`
+
+	// PortRange is the range of the ports picked at random, in the form of <min>-<max>,
+	// the ports are reserved for the cluster until it is deleted, so the clusters created in parallel do not conflict.
+	// is the default value for flag --port-range and env KWOK_PORT_RANGE
+	PortRange string `json:"portRange,omitempty"`
+
+	// ComponentsCPULimit is the CPU limit of each of the components in the quantity of cores, e.g. 1 or 500m,
+	// the containers are limited by the container runtime, and the processes of the binary runtime by GOMAXPROCS.
+	// is the default value for flag --components-cpu-limit and env KWOK_COMPONENTS_CPU_LIMIT
+	ComponentsCPULimit string `json:"componentsCPULimit,omitempty"`
+
+	// ComponentsMemoryLimit is the memory limit of each of the components in the quantity of bytes, e.g. 512Mi,
+	// the containers are limited by the container runtime, and the processes of the binary runtime by GOMEMLIMIT as a soft limit.
+	// is the default value for flag --components-memory-limit and env KWOK_COMPONENTS_MEMORY_LIMIT
+	ComponentsMemoryLimit string `json:"componentsMemoryLimit,omitempty"`
+
+	// ComponentsRestartBackoff is the maximum backoff of the restarts of the crashed components in the binary runtime,
+	// a duration, e.g. 5m, the backoff starts at 1s and doubles after each crash, 0 disables the restarts.
+	// is the default value for flag --components-restart-backoff and env KWOK_COMPONENTS_RESTART_BACKOFF
+	// +default="5m"
+	ComponentsRestartBackoff string `json:"componentsRestartBackoff,omitempty"`
+
+	// KubeconfigContext is the flag to add the context of the cluster to the kubeconfig of --kubeconfig
+	// and set it to the current context when the cluster is created.
+	// is the default value for flag --kubeconfig-context and env KWOK_KUBECONFIG_CONTEXT
+	// +default=true
+	KubeconfigContext *bool `json:"kubeconfigContext,omitempty"`
+
+	// EnableIPv6 is the flag to enable IPv6 in the network of the components,
+	// the components resolve each other by the names of the containers over IPv4 and IPv6.
+	// is the default value for flag --enable-ipv6 and env KWOK_ENABLE_IPV6
+	// +default=false
+	EnableIPv6 *bool `json:"enableIPv6,omitempty"`
 }
 
 // Component is a component of the cluster.
@@ -454,8 +628,61 @@ type Component struct {
 	// Version is the version of the component.
 	// +optional
 	Version string `json:"version,omitempty"`
+
+	// ReadinessProbe is the probe of whether the component is ready.
+	// +optional
+	ReadinessProbe *Probe `json:"readinessProbe,omitempty"`
+
+	// LivenessProbe is the probe of whether the component is alive, the component is restarted if it fails.
+	// +optional
+	LivenessProbe *Probe `json:"livenessProbe,omitempty"`
 }
 
+// Probe describes a health check to be performed against a component.
+type Probe struct {
+	// HTTPGet specifies the http request to perform.
+	HTTPGet *HTTPGetAction `json:"httpGet,omitempty"`
+	// InitialDelaySeconds is the number of seconds after the component has started before the probe is initiated.
+	// +optional
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+	// PeriodSeconds is how often to perform the probe.
+	// +optional
+	// +default=10
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	// +optional
+	// +default=1
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// FailureThreshold is the minimum consecutive failures for the probe to be considered failed.
+	// +optional
+	// +default=3
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// HTTPGetAction describes an action based on HTTP Get requests.
+type HTTPGetAction struct {
+	// Path to access on the HTTP server.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Port is the port the component listens on, which is the port in the container if the component runs in a container.
+	Port uint32 `json:"port"`
+	// Scheme to use for connecting to the component.
+	// +optional
+	// +default="HTTP"
+	Scheme URIScheme `json:"scheme,omitempty"`
+}
+
+// URIScheme identifies the scheme used for connection to a component for Get actions
+// +enum
+type URIScheme string
+
+const (
+	// URISchemeHTTP means that the scheme used will be http://
+	URISchemeHTTP URIScheme = "HTTP"
+	// URISchemeHTTPS means that the scheme used will be https://
+	URISchemeHTTPS URIScheme = "HTTPS"
+)
+
 // Env represents an environment variable present in a Container.
 type Env struct {
 	// Name of the environment variable.
@@ -27,6 +27,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BinaryVerification) DeepCopyInto(out *BinaryVerification) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinaryVerification.
+func (in *BinaryVerification) DeepCopy() *BinaryVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(BinaryVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Component) DeepCopyInto(out *Component) {
 	*out = *in
@@ -62,6 +78,16 @@ func (in *Component) DeepCopyInto(out *Component) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(Probe)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -140,6 +166,50 @@ func (in *ExtraArgs) DeepCopy() *ExtraArgs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPGetAction) DeepCopyInto(out *HTTPGetAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPGetAction.
+func (in *HTTPGetAction) DeepCopy() *HTTPGetAction {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPGetAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSimulation) DeepCopyInto(out *ImageSimulation) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]SimulatedImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSimulation.
+func (in *ImageSimulation) DeepCopy() *ImageSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KwokConfiguration) DeepCopyInto(out *KwokConfiguration) {
 	*out = *in
@@ -180,6 +250,13 @@ func (in *KwokConfigurationOptions) DeepCopyInto(out *KwokConfigurationOptions)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ManageNodeRules != nil {
+		in, out := &in.ManageNodeRules, &out.ManageNodeRules
+		*out = make([]ManageNodeRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.EnableCNI != nil {
 		in, out := &in.EnableCNI, &out.EnableCNI
 		*out = new(bool)
@@ -200,6 +277,46 @@ func (in *KwokConfigurationOptions) DeepCopyInto(out *KwokConfigurationOptions)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EnableStageForRefs != nil {
+		in, out := &in.EnableStageForRefs, &out.EnableStageForRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableStageNextAnnotations != nil {
+		in, out := &in.EnableStageNextAnnotations, &out.EnableStageNextAnnotations
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableNodePressure != nil {
+		in, out := &in.EnableNodePressure, &out.EnableNodePressure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeLeaseOnly != nil {
+		in, out := &in.NodeLeaseOnly, &out.NodeLeaseOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableNodeVolumes != nil {
+		in, out := &in.EnableNodeVolumes, &out.EnableNodeVolumes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodAdmissionResources != nil {
+		in, out := &in.PodAdmissionResources, &out.PodAdmissionResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableNodeLifecycleEvents != nil {
+		in, out := &in.EnableNodeLifecycleEvents, &out.EnableNodeLifecycleEvents
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImageSimulation != nil {
+		in, out := &in.ImageSimulation, &out.ImageSimulation
+		*out = new(ImageSimulation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -278,6 +395,11 @@ func (in *KwokctlConfigurationOptions) DeepCopyInto(out *KwokctlConfigurationOpt
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Offline != nil {
+		in, out := &in.Offline, &out.Offline
+		*out = new(bool)
+		**out = **in
+	}
 	if in.DisableKubeScheduler != nil {
 		in, out := &in.DisableKubeScheduler, &out.DisableKubeScheduler
 		*out = new(bool)
@@ -288,6 +410,26 @@ func (in *KwokctlConfigurationOptions) DeepCopyInto(out *KwokctlConfigurationOpt
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EnableMetricsServer != nil {
+		in, out := &in.EnableMetricsServer, &out.EnableMetricsServer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableCustomMetrics != nil {
+		in, out := &in.EnableCustomMetrics, &out.EnableCustomMetrics
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableGrafana != nil {
+		in, out := &in.EnableGrafana, &out.EnableGrafana
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BinaryVerifications != nil {
+		in, out := &in.BinaryVerifications, &out.BinaryVerifications
+		*out = make([]BinaryVerification, len(*in))
+		copy(*out, *in)
+	}
 	if in.KubeAuthorization != nil {
 		in, out := &in.KubeAuthorization, &out.KubeAuthorization
 		*out = new(bool)
@@ -298,6 +440,11 @@ func (in *KwokctlConfigurationOptions) DeepCopyInto(out *KwokctlConfigurationOpt
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EtcdServers != nil {
+		in, out := &in.EtcdServers, &out.EtcdServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.KubeApiserverCertSANs != nil {
 		in, out := &in.KubeApiserverCertSANs, &out.KubeApiserverCertSANs
 		*out = make([]string, len(*in))
@@ -308,6 +455,16 @@ func (in *KwokctlConfigurationOptions) DeepCopyInto(out *KwokctlConfigurationOpt
 		*out = new(bool)
 		**out = **in
 	}
+	if in.KubeconfigContext != nil {
+		in, out := &in.KubeconfigContext, &out.KubeconfigContext
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableIPv6 != nil {
+		in, out := &in.EnableIPv6, &out.EnableIPv6
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -368,6 +525,27 @@ func (in *KwokctlResource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageNodeRule) DeepCopyInto(out *ManageNodeRule) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageNodeRule.
+func (in *ManageNodeRule) DeepCopy() *ManageNodeRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageNodeRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Port) DeepCopyInto(out *Port) {
 	*out = *in
@@ -384,6 +562,44 @@ func (in *Port) DeepCopy() *Port {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Probe) DeepCopyInto(out *Probe) {
+	*out = *in
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(HTTPGetAction)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
+func (in *Probe) DeepCopy() *Probe {
+	if in == nil {
+		return nil
+	}
+	out := new(Probe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimulatedImage) DeepCopyInto(out *SimulatedImage) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimulatedImage.
+func (in *SimulatedImage) DeepCopy() *SimulatedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(SimulatedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Volume) DeepCopyInto(out *Volume) {
 	*out = *in
@@ -64,9 +64,59 @@ func SetObjectDefaults_KwokConfiguration(in *KwokConfiguration) {
 	if in.Options.NodePlayStageParallelism == 0 {
 		in.Options.NodePlayStageParallelism = 4
 	}
+	if in.Options.CustomPlayStageParallelism == 0 {
+		in.Options.CustomPlayStageParallelism = 4
+	}
+	if in.Options.StageMaxChainDepth == 0 {
+		in.Options.StageMaxChainDepth = 16
+	}
+	if in.Options.TimeScale == 0 {
+		in.Options.TimeScale = 1
+	}
+	if in.Options.EnableStageNextAnnotations == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableStageNextAnnotations = &ptrVar1
+	}
+	if in.Options.EnableNodePressure == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableNodePressure = &ptrVar1
+	}
+	if in.Options.NodePressureThreshold == 0 {
+		in.Options.NodePressureThreshold = 0.9
+	}
 	if in.Options.NodeLeaseParallelism == 0 {
 		in.Options.NodeLeaseParallelism = 4
 	}
+	if in.Options.NodeLeaseOnly == nil {
+		var ptrVar1 bool = false
+		in.Options.NodeLeaseOnly = &ptrVar1
+	}
+	if in.Options.NodeFlappingUpSeconds == 0 {
+		in.Options.NodeFlappingUpSeconds = 300
+	}
+	if in.Options.NodeFlappingDownSeconds == 0 {
+		in.Options.NodeFlappingDownSeconds = 60
+	}
+	if in.Options.EnableNodeVolumes == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableNodeVolumes = &ptrVar1
+	}
+	if in.Options.EnableNodeLifecycleEvents == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableNodeLifecycleEvents = &ptrVar1
+	}
+	if in.Options.MetricsMaxSeries == 0 {
+		in.Options.MetricsMaxSeries = 100000
+	}
+	if in.Options.MetricsMaxLabelValues == 0 {
+		in.Options.MetricsMaxLabelValues = 10000
+	}
+	if in.Options.MetricsScrapeTimeoutSeconds == 0 {
+		in.Options.MetricsScrapeTimeoutSeconds = 10
+	}
+	if in.Options.StatsCacheTTLSeconds == 0 {
+		in.Options.StatsCacheTTLSeconds = 5
+	}
 }
 
 func SetObjectDefaults_KwokctlConfiguration(in *KwokctlConfiguration) {
@@ -74,6 +124,10 @@ func SetObjectDefaults_KwokctlConfiguration(in *KwokctlConfiguration) {
 		var ptrVar1 bool = false
 		in.Options.QuietPull = &ptrVar1
 	}
+	if in.Options.Offline == nil {
+		var ptrVar1 bool = false
+		in.Options.Offline = &ptrVar1
+	}
 	if in.Options.DisableKubeScheduler == nil {
 		var ptrVar1 bool = false
 		in.Options.DisableKubeScheduler = &ptrVar1
@@ -82,6 +136,36 @@ func SetObjectDefaults_KwokctlConfiguration(in *KwokctlConfiguration) {
 		var ptrVar1 bool = false
 		in.Options.DisableKubeControllerManager = &ptrVar1
 	}
+	if in.Options.EnableMetricsServer == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableMetricsServer = &ptrVar1
+	}
+	if in.Options.EnableCustomMetrics == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableCustomMetrics = &ptrVar1
+	}
+	if in.Options.EnableGrafana == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableGrafana = &ptrVar1
+	}
+	if in.Options.KubeApiserverReplicas == 0 {
+		in.Options.KubeApiserverReplicas = 1
+	}
+	if in.Options.KubeControllerManagerReplicas == 0 {
+		in.Options.KubeControllerManagerReplicas = 1
+	}
+	if in.Options.KubeSchedulerReplicas == 0 {
+		in.Options.KubeSchedulerReplicas = 1
+	}
+	if in.Options.EtcdPrefix == "" {
+		in.Options.EtcdPrefix = "/registry"
+	}
+	if in.Options.EtcdAutoCompactionMode == "" {
+		in.Options.EtcdAutoCompactionMode = "periodic"
+	}
+	if in.Options.EtcdAutoCompactionRetention == "" {
+		in.Options.EtcdAutoCompactionRetention = "1"
+	}
 	if in.Options.KubeControllerManagerNodeMonitorPeriodMilliseconds == 0 {
 		in.Options.KubeControllerManagerNodeMonitorPeriodMilliseconds = 600000
 	}
@@ -101,6 +185,17 @@ func SetObjectDefaults_KwokctlConfiguration(in *KwokctlConfiguration) {
 		var ptrVar1 bool = false
 		in.Options.DisableQPSLimits = &ptrVar1
 	}
+	if in.Options.ComponentsRestartBackoff == "" {
+		in.Options.ComponentsRestartBackoff = "5m"
+	}
+	if in.Options.KubeconfigContext == nil {
+		var ptrVar1 bool = true
+		in.Options.KubeconfigContext = &ptrVar1
+	}
+	if in.Options.EnableIPv6 == nil {
+		var ptrVar1 bool = false
+		in.Options.EnableIPv6 = &ptrVar1
+	}
 	for i := range in.Components {
 		a := &in.Components[i]
 		for j := range a.Ports {
@@ -115,6 +210,38 @@ func SetObjectDefaults_KwokctlConfiguration(in *KwokctlConfiguration) {
 				b.Value = ""
 			}
 		}
+		if a.ReadinessProbe != nil {
+			if a.ReadinessProbe.HTTPGet != nil {
+				if a.ReadinessProbe.HTTPGet.Scheme == "" {
+					a.ReadinessProbe.HTTPGet.Scheme = "HTTP"
+				}
+			}
+			if a.ReadinessProbe.PeriodSeconds == 0 {
+				a.ReadinessProbe.PeriodSeconds = 10
+			}
+			if a.ReadinessProbe.TimeoutSeconds == 0 {
+				a.ReadinessProbe.TimeoutSeconds = 1
+			}
+			if a.ReadinessProbe.FailureThreshold == 0 {
+				a.ReadinessProbe.FailureThreshold = 3
+			}
+		}
+		if a.LivenessProbe != nil {
+			if a.LivenessProbe.HTTPGet != nil {
+				if a.LivenessProbe.HTTPGet.Scheme == "" {
+					a.LivenessProbe.HTTPGet.Scheme = "HTTP"
+				}
+			}
+			if a.LivenessProbe.PeriodSeconds == 0 {
+				a.LivenessProbe.PeriodSeconds = 10
+			}
+			if a.LivenessProbe.TimeoutSeconds == 0 {
+				a.LivenessProbe.TimeoutSeconds = 1
+			}
+			if a.LivenessProbe.FailureThreshold == 0 {
+				a.LivenessProbe.FailureThreshold = 3
+			}
+		}
 	}
 	for i := range in.ComponentsPatches {
 		a := &in.ComponentsPatches[i]
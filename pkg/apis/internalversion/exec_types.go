@@ -42,6 +42,45 @@ type ExecTarget struct {
 	Containers []string
 	// Local holds information how to exec to a local target.
 	Local *ExecTargetLocal
+	// Scripts is a list of the scripted sessions played instead of executing the commands,
+	// the first one matching the command takes precedence over the Local.
+	Scripts []ExecScript
+}
+
+// ExecScript is a scripted session played instead of executing a command.
+type ExecScript struct {
+	// Command is the beginning of the commands the script is played for, e.g. ["sh"] matches "sh -c ls",
+	// if not set, the script is played for all the commands.
+	Command []string
+	// Steps is a list of the outputs played once the session starts.
+	Steps []ExecScriptStep
+	// Rules is a list of the rules reacting to the lines of the stdin after the steps,
+	// the first one matching a line is played.
+	Rules []ExecScriptRule
+	// ExitCode is the exit code of the session once the stdin is closed, or after the steps if there is no stdin.
+	ExitCode int32
+}
+
+// ExecScriptStep is an output of a scripted session.
+type ExecScriptStep struct {
+	// DelayMilliseconds is the milliseconds to wait before the output.
+	DelayMilliseconds int64
+	// Stdout is written to the stdout.
+	Stdout string
+	// Stderr is written to the stderr, or the stdout with a tty.
+	Stderr string
+}
+
+// ExecScriptRule is a rule reacting to a line of the stdin of a scripted session.
+type ExecScriptRule struct {
+	// Match is the regular expression matching the line of the stdin, without the line ending.
+	Match string
+	// Steps is a list of the outputs played once a line matches.
+	Steps []ExecScriptStep
+	// Exit ends the session after the steps with the ExitCode.
+	Exit bool
+	// ExitCode is the exit code of the session if it exits.
+	ExitCode int32
 }
 
 // ExecTargetLocal holds information how to exec to a local target.
@@ -52,6 +91,29 @@ type ExecTargetLocal struct {
 	Envs []EnvVar
 	// SecurityContext is the user context to exec.
 	SecurityContext *SecurityContext
+	// Sandbox restricts the commands to exec, so that the exec is safe to enable on a shared kwok.
+	Sandbox *ExecTargetLocalSandbox
+}
+
+// ExecTargetLocalSandbox restricts the commands to exec locally,
+// the commands are executed without a shell, in a working directory and with the environment variables of their own.
+type ExecTargetLocalSandbox struct {
+	// AllowedCommands is a list of the commands allowed to exec, the others are rejected,
+	// and the shells are rejected even if they are allowed.
+	AllowedCommands []ExecAllowedCommand
+	// TimeoutSeconds is the seconds a command is allowed to run before it is killed,
+	// if not set, the command is not limited.
+	TimeoutSeconds int64
+	// CPULimitSeconds is the seconds of the CPU time a command is allowed to use before it is killed,
+	// if not set, the command is not limited. It is only supported on Linux.
+	CPULimitSeconds int64
+}
+
+// ExecAllowedCommand is a command allowed to exec.
+type ExecAllowedCommand struct {
+	// Command is the allowed command with all of its arguments, the command must match it exactly,
+	// e.g. ["ls", "-l"] allows "ls -l" but neither "ls" nor "ls -l /".
+	Command []string
 }
 
 // EnvVar represents an environment variable present in a Container.
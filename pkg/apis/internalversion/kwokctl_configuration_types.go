@@ -49,6 +49,24 @@ type ExtraArgs struct {
 	Value string
 }
 
+// BinaryVerification holds information about the verification of a downloaded binary.
+type BinaryVerification struct {
+	// URL is the url of the binary, or of the archive the binary is extracted from.
+	URL string
+	// Sha256 is the sha256 checksum of the file.
+	Sha256 string
+	// Signature is the url or path of the cosign signature of the file.
+	Signature string
+	// Key is the path of the public key the file is signed with.
+	Key string
+	// Certificate is the url or path of the certificate of the keyless signing.
+	Certificate string
+	// CertificateIdentity is the identity expected in the certificate of the keyless signing.
+	CertificateIdentity string
+	// CertificateOIDCIssuer is the OIDC issuer expected in the certificate of the keyless signing.
+	CertificateOIDCIssuer string
+}
+
 // ComponentPatches holds information about the component patches.
 type ComponentPatches struct {
 	// Name is the name of the component.
@@ -83,6 +101,9 @@ type KwokctlConfigurationOptions struct {
 	// JaegerPort is the port to expose Jaeger UI.
 	JaegerPort uint32
 
+	// GrafanaPort is the port to expose Grafana UI.
+	GrafanaPort uint32
+
 	// JaegerOtlpGrpcPort is the port to expose OTLP GRPC collector.
 	JaegerOtlpGrpcPort uint32
 
@@ -104,6 +125,9 @@ type KwokctlConfigurationOptions struct {
 	// JaegerVersion is the version of Jaeger to use.
 	JaegerVersion string
 
+	// GrafanaVersion is the version of Grafana to use.
+	GrafanaVersion string
+
 	// DockerComposeVersion is the version of docker-compose to use.
 	DockerComposeVersion string
 
@@ -117,6 +141,10 @@ type KwokctlConfigurationOptions struct {
 	// QuietPull is the flag to quiet the pull.
 	QuietPull bool
 
+	// Offline is the flag to create the cluster without the network,
+	// the images and binaries are only got from the local or the cache.
+	Offline bool
+
 	// KubeSchedulerConfig is the configuration path for kube-scheduler.
 	KubeSchedulerConfig string
 
@@ -126,6 +154,25 @@ type KwokctlConfigurationOptions struct {
 	// DisableKubeControllerManager is the flag to disable kube-controller-manager.
 	DisableKubeControllerManager bool
 
+	// EnableMetricsServer is the flag to serve the metrics API (metrics.k8s.io) from the usages simulated by the kwok-controller.
+	EnableMetricsServer bool
+
+	// EnableCustomMetrics is the flag to serve the custom metrics API (custom.metrics.k8s.io)
+	// and the external metrics API (external.metrics.k8s.io) from the Metrics by the kwok-controller.
+	EnableCustomMetrics bool
+
+	// EnableGrafana is the flag to deploy Grafana with the dashboards of the cluster.
+	EnableGrafana bool
+
+	// KubeApiserverReplicas is the number of kube-apiserver instances, the replicas share the etcd.
+	KubeApiserverReplicas uint32
+
+	// KubeControllerManagerReplicas is the number of kube-controller-manager instances, the replicas elect a leader.
+	KubeControllerManagerReplicas uint32
+
+	// KubeSchedulerReplicas is the number of kube-scheduler instances, the replicas elect a leader.
+	KubeSchedulerReplicas uint32
+
 	// EtcdImage is the image of etcd.
 	EtcdImage string
 
@@ -150,9 +197,21 @@ type KwokctlConfigurationOptions struct {
 	// JaegerImage is the image of Jaeger
 	JaegerImage string
 
+	// GrafanaImage is the image of Grafana
+	GrafanaImage string
+
 	// KindNodeImage is the image of kind node.
 	KindNodeImage string
 
+	// KindClusterName is the name of an existing kind cluster adopted by kwokctl.
+	KindClusterName string
+
+	// Arch is the architecture of the binaries and images of the components.
+	Arch string
+
+	// BinaryVerifications is the list of the verifications of the downloaded binaries.
+	BinaryVerifications []BinaryVerification
+
 	// BinSuffix is the suffix of the all binary.
 	// On Windows is .exe
 	BinSuffix string
@@ -212,6 +271,10 @@ type KwokctlConfigurationOptions struct {
 	// KubeAuditPolicy is path to the file that defines the audit policy configuration
 	KubeAuditPolicy string
 
+	// KubeAuditWebhook is path to the kubeconfig formatted file that defines the audit webhook configuration,
+	// it only takes effect with the audit policy.
+	KubeAuditWebhook string
+
 	// KubeAuthorization is the flag to enable authorization on secure port.
 	KubeAuthorization bool
 
@@ -224,6 +287,30 @@ type KwokctlConfigurationOptions struct {
 	// EtcdPort is etcd port in the binary runtime
 	EtcdPort uint32
 
+	// EtcdServers is the list of the servers of the external etcd, the etcd is not created if it is set.
+	EtcdServers []string
+
+	// EtcdCaFile is the CA file to secure the communication with the external etcd.
+	EtcdCaFile string
+
+	// EtcdCertFile is the client certificate file of the external etcd.
+	EtcdCertFile string
+
+	// EtcdKeyFile is the client key file of the external etcd.
+	EtcdKeyFile string
+
+	// EtcdPrefix is the prefix of the keys of the cluster in etcd,
+	// the clusters sharing an external etcd have their own prefixes.
+	EtcdPrefix string
+
+	// EtcdAutoCompactionMode is the mode of the auto compaction of etcd, periodic or revision.
+	EtcdAutoCompactionMode string
+
+	// EtcdAutoCompactionRetention is the retention of the auto compaction of etcd,
+	// a duration, e.g. 1h or 1 for hours, in the periodic mode, and a number of revisions in the revision mode,
+	// 0 disables the auto compaction.
+	EtcdAutoCompactionRetention string
+
 	// KubeControllerManagerPort is kube-controller-manager port in the binary runtime
 	KubeControllerManagerPort uint32
 
@@ -239,6 +326,9 @@ type KwokctlConfigurationOptions struct {
 	// CacheDir is the directory of the cache.
 	CacheDir string
 
+	// CacheMaxSize is the maximum size of the cache in the quantity of bytes.
+	CacheMaxSize string
+
 	// KubeControllerManagerNodeMonitorPeriodMilliseconds is --node-monitor-period for kube-controller-manager.
 	KubeControllerManagerNodeMonitorPeriodMilliseconds int64
 
@@ -259,6 +349,27 @@ type KwokctlConfigurationOptions struct {
 
 	// DisableQPSLimits specifies whether to disable QPS limits for components.
 	DisableQPSLimits bool
+
+	// Nodes is the number of the nodes to create after the cluster is started.
+	Nodes uint32
+
+	// PortRange is the range of the ports picked at random, in the form of <min>-<max>.
+	PortRange string
+
+	// ComponentsCPULimit is the CPU limit of each of the components in the quantity of cores.
+	ComponentsCPULimit string
+
+	// ComponentsMemoryLimit is the memory limit of each of the components in the quantity of bytes.
+	ComponentsMemoryLimit string
+
+	// ComponentsRestartBackoff is the maximum backoff of the restarts of the crashed components in the binary runtime.
+	ComponentsRestartBackoff string
+
+	// KubeconfigContext is the flag to add the context of the cluster to the kubeconfig when the cluster is created.
+	KubeconfigContext bool
+
+	// EnableIPv6 is the flag to enable IPv6 in the network of the components.
+	EnableIPv6 bool
 }
 
 // Component is a component of the cluster.
@@ -300,8 +411,48 @@ type Component struct {
 
 	// Version is the version of the component.
 	Version string
+
+	// ReadinessProbe is the probe of whether the component is ready.
+	ReadinessProbe *Probe
+
+	// LivenessProbe is the probe of whether the component is alive.
+	LivenessProbe *Probe
+}
+
+// Probe describes a health check to be performed against a component.
+type Probe struct {
+	// HTTPGet specifies the http request to perform.
+	HTTPGet *HTTPGetAction
+	// InitialDelaySeconds is the number of seconds after the component has started before the probe is initiated.
+	InitialDelaySeconds int32
+	// PeriodSeconds is how often to perform the probe.
+	PeriodSeconds int32
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	TimeoutSeconds int32
+	// FailureThreshold is the minimum consecutive failures for the probe to be considered failed.
+	FailureThreshold int32
+}
+
+// HTTPGetAction describes an action based on HTTP Get requests.
+type HTTPGetAction struct {
+	// Path to access on the HTTP server.
+	Path string
+	// Port is the port the component listens on, which is the port in the container if the component runs in a container.
+	Port uint32
+	// Scheme to use for connecting to the component.
+	Scheme URIScheme
 }
 
+// URIScheme identifies the scheme used for connection to a component for Get actions
+type URIScheme string
+
+const (
+	// URISchemeHTTP means that the scheme used will be http://
+	URISchemeHTTP URIScheme = "HTTP"
+	// URISchemeHTTPS means that the scheme used will be https://
+	URISchemeHTTPS URIScheme = "HTTPS"
+)
+
 // Env represents an environment variable present in a Container.
 type Env struct {
 	// Name of the environment variable.
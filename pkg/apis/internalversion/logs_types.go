@@ -41,6 +41,57 @@ type Log struct {
 	Containers []string
 	// LogsFile is the file from which the log forward starts
 	LogsFile string
+	// PreviousLogsFile is the file of the logs of the previous terminated container, which is read by the previous logs,
+	// if not set, the previous logs are not found.
+	PreviousLogsFile string
 	// Follow up if true
 	Follow bool
+	// Generator generates the logs instead of forwarding the LogsFile.
+	Generator *LogGenerator
 }
+
+// LogGenerator holds information how to generate logs.
+type LogGenerator struct {
+	// Template is the Go template of the message of a log line,
+	// which is given the podName, podNamespace, containerName, sequence and level of the log line,
+	// and the functions of sprig, e.g. randInt and randAlphaNum, plus randChoice returning one of its arguments.
+	Template string
+	// Fields is a list of the fields appended to the log lines.
+	Fields []LogGeneratorField
+	// Levels is the mix of the levels of the log lines,
+	// if not set, all the log lines are of the info level.
+	Levels []LogGeneratorLevel
+	// Format is the format of the log lines, if not set, the log lines are in the text format.
+	Format LogGeneratorFormat
+	// IntervalMilliseconds is the milliseconds between the log lines, if not set, a log line is generated every second.
+	IntervalMilliseconds int64
+	// HistoryLines is the number of the log lines already generated when the logs are requested.
+	HistoryLines int64
+}
+
+// LogGeneratorField is a field of the generated log lines.
+type LogGeneratorField struct {
+	// Name is the name of the field.
+	Name string
+	// Template is the Go template of the value of the field, which is given the same as the template of the message.
+	Template string
+}
+
+// LogGeneratorLevel is a level of the generated log lines.
+type LogGeneratorLevel struct {
+	// Level is the name of the level, e.g. info.
+	Level string
+	// Weight is the relative weight of the level in the mix of the levels.
+	Weight int64
+}
+
+// LogGeneratorFormat is the format of the generated log lines.
+type LogGeneratorFormat string
+
+// The following are valid log generator formats.
+const (
+	// LogGeneratorFormatText writes the log lines as the time, the level, the message and the fields as key=value.
+	LogGeneratorFormatText LogGeneratorFormat = "text"
+	// LogGeneratorFormatJSON writes the log lines as the JSON objects of the time, the level, the msg and the fields.
+	LogGeneratorFormatJSON LogGeneratorFormat = "json"
+)
@@ -51,8 +51,19 @@ type MetricConfig struct {
 	Value string
 	// Buckets is a list of buckets for a histogram metric.
 	Buckets []MetricBucket
+	// BucketsFrom generates the buckets for a histogram metric in addition to the Buckets.
+	BucketsFrom *MetricBucketsFrom
+	// Quantiles is a list of quantiles for a summary metric.
+	Quantiles []MetricQuantile
+	// SampleCount is a CEL expression of the count of the observations for a summary metric.
+	SampleCount string
+	// SampleSum is a CEL expression of the sum of the observations for a summary metric.
+	SampleSum string
 	// Dimension is a dimension of the metric.
 	Dimension Dimension
+	// Device is the extended resource of the devices of the device dimension, e.g. nvidia.com/gpu,
+	// the metric is evaluated for each of the devices of the resource in the allocatable of the node.
+	Device string
 }
 
 // Kind is kind of metric configuration.
@@ -65,6 +76,8 @@ const (
 	KindGauge Kind = "gauge"
 	// KindHistogram is a histogram metric.
 	KindHistogram Kind = "histogram"
+	// KindSummary is a summary metric.
+	KindSummary Kind = "summary"
 )
 
 // Dimension is a dimension of the metric.
@@ -77,6 +90,8 @@ const (
 	DimensionPod Dimension = "pod"
 	// DimensionContainer is a container dimension.
 	DimensionContainer Dimension = "container"
+	// DimensionDevice is a device dimension, e.g. a GPU.
+	DimensionDevice Dimension = "device"
 )
 
 // MetricLabel holds label name and the value of the label.
@@ -97,3 +112,36 @@ type MetricBucket struct {
 	// but value will be calculated and cumulative into the next bucket.
 	Hidden bool
 }
+
+// MetricBucketsFrom generates the buckets for a histogram metric.
+type MetricBucketsFrom struct {
+	// Type is the type of the generated buckets.
+	Type MetricBucketsType
+	// Start is the upper bound of the first bucket.
+	Start float64
+	// Factor is the width of the linear buckets, or the factor between the upper bounds of the exponential buckets.
+	Factor float64
+	// Count is the number of the buckets.
+	Count int32
+	// Value is a CEL expression evaluated for each bucket,
+	// with the variable le of the upper bound of the bucket.
+	Value string
+}
+
+// MetricBucketsType is the type of the generated buckets.
+type MetricBucketsType string
+
+const (
+	// MetricBucketsTypeLinear is the buckets with the upper bounds of Start + i * Factor.
+	MetricBucketsTypeLinear MetricBucketsType = "linear"
+	// MetricBucketsTypeExponential is the buckets with the upper bounds of Start * Factor ^ i.
+	MetricBucketsTypeExponential MetricBucketsType = "exponential"
+)
+
+// MetricQuantile is a single quantile for a summary metric.
+type MetricQuantile struct {
+	// Quantile is the quantile, between 0 and 1.
+	Quantile float64
+	// Value is a CEL expression.
+	Value string
+}
@@ -20,7 +20,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
 
-// ObjectSelector holds information how to match based on namespace and name.
+// ObjectSelector holds information how to match based on namespace, name and labels.
 type ObjectSelector struct {
 	// MatchNamespaces is a list of namespaces to match.
 	// if not set, all namespaces will be matched.
@@ -28,6 +28,12 @@ type ObjectSelector struct {
 	// MatchNames is a list of names to match.
 	// if not set, all names will be matched.
 	MatchNames []string
+	// MatchLabels is a map of {key,value} pairs of the labels to match.
+	// if not set, all labels will be matched.
+	MatchLabels map[string]string
+	// MatchNamespaceLabels is a map of {key,value} pairs of the labels of the namespaces to match.
+	// if not set, all namespaces will be matched.
+	MatchNamespaceLabels map[string]string
 }
 
 // Match returns true if name and namespace is specified within the selector
@@ -44,3 +50,49 @@ func (s *ObjectSelector) Match(name, namespace string) bool {
 	}
 	return true
 }
+
+// MatchObject returns true if name, namespace, labels and labels of the namespace is specified within the selector
+// If the match field is empty, the match on that field is considered to be true.
+func (s *ObjectSelector) MatchObject(name, namespace string, labels, namespaceLabels map[string]string) bool {
+	if s == nil {
+		return true
+	}
+	if !s.Match(name, namespace) {
+		return false
+	}
+	if !matchLabels(s.MatchLabels, labels) {
+		return false
+	}
+	if !matchLabels(s.MatchNamespaceLabels, namespaceLabels) {
+		return false
+	}
+	return true
+}
+
+// Specificity returns how specific the selector is,
+// the more specific selector takes precedence when several selectors match the same object.
+// Names are more specific than labels, labels are more specific than namespaces,
+// and namespaces are more specific than the empty selector.
+func (s *ObjectSelector) Specificity() int {
+	if s == nil {
+		return 0
+	}
+	switch {
+	case len(s.MatchNames) > 0:
+		return 3
+	case len(s.MatchLabels) > 0:
+		return 2
+	case len(s.MatchNamespaces) > 0 || len(s.MatchNamespaceLabels) > 0:
+		return 1
+	}
+	return 0
+}
+
+func matchLabels(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
@@ -104,3 +104,61 @@ func TestObjectSelector_Match(t *testing.T) {
 		}
 	})
 }
+
+func TestObjectSelector_MatchObject(t *testing.T) {
+	selector := ObjectSelector{
+		MatchLabels:          map[string]string{"app": "web"},
+		MatchNamespaceLabels: map[string]string{"env": "prod"},
+	}
+	tt := []struct {
+		labels          map[string]string
+		namespaceLabels map[string]string
+		expect          bool
+	}{
+		{
+			labels:          nil,
+			namespaceLabels: nil,
+			expect:          false,
+		},
+		{
+			labels:          map[string]string{"app": "web", "tier": "frontend"},
+			namespaceLabels: nil,
+			expect:          false,
+		},
+		{
+			labels:          map[string]string{"app": "db"},
+			namespaceLabels: map[string]string{"env": "prod"},
+			expect:          false,
+		},
+		{
+			labels:          map[string]string{"app": "web", "tier": "frontend"},
+			namespaceLabels: map[string]string{"env": "prod"},
+			expect:          true,
+		},
+	}
+	for _, tc := range tt {
+		got := selector.MatchObject("podName", "podNamespace", tc.labels, tc.namespaceLabels)
+		if got != tc.expect {
+			t.Errorf("MatchObject(%v, %v)=%v, expect=%v", tc.labels, tc.namespaceLabels, got, tc.expect)
+		}
+	}
+
+	var empty *ObjectSelector
+	if !empty.MatchObject("podName", "podNamespace", nil, nil) {
+		t.Errorf("expected nil selector to match everything")
+	}
+}
+
+func TestObjectSelector_Specificity(t *testing.T) {
+	selectors := []*ObjectSelector{
+		nil,
+		{MatchNamespaceLabels: map[string]string{"env": "prod"}},
+		{MatchNamespaces: []string{"podNamespace"}, MatchLabels: map[string]string{"app": "web"}},
+		{MatchNames: []string{"podName"}},
+	}
+	for i := 1; i < len(selectors); i++ {
+		if selectors[i].Specificity() <= selectors[i-1].Specificity() {
+			t.Errorf("expected %+v to be more specific than %+v", selectors[i], selectors[i-1])
+		}
+	}
+}
@@ -45,6 +45,9 @@ type Forward struct {
 	// Command is the command to run to forward with stdin/stdout.
 	// if set, Target will be ignored.
 	Command []string
+	// HTTP is the HTTP server served by kwok itself to forward to.
+	// if set, Target and Command will be ignored.
+	HTTP *ForwardHTTP
 }
 
 // ForwardTarget holds information how to forward to a target.
@@ -54,3 +57,40 @@ type ForwardTarget struct {
 	// Address is the address to forward to.
 	Address string
 }
+
+// ForwardHTTP holds information how to serve the HTTP requests forwarded.
+type ForwardHTTP struct {
+	// Routes is a list of routes to respond to the requests.
+	// the first route matching the request is used,
+	// if none matches, the request is echoed back as JSON.
+	Routes []ForwardHTTPRoute
+}
+
+// ForwardHTTPRoute holds information how to respond to the matched requests.
+type ForwardHTTPRoute struct {
+	// Method is the method of the requests to match.
+	// if not set, all methods will be matched.
+	Method string
+	// Path is the path of the requests to match.
+	// a path ending with a slash matches all paths under it,
+	// if not set, all paths will be matched.
+	Path string
+	// StatusCode is the status code of the response.
+	// if not set, 200 will be used.
+	StatusCode int32
+	// Headers is a list of headers of the response.
+	Headers []ForwardHTTPHeader
+	// Body is the template of the body of the response.
+	// the request is given as method, path, query, headers, body, podName, podNamespace and port.
+	Body string
+	// DelayMilliseconds is the delay before the response is written.
+	DelayMilliseconds int64
+}
+
+// ForwardHTTPHeader holds information of a header of the response.
+type ForwardHTTPHeader struct {
+	// Name is the name of the header.
+	Name string
+	// Value is the value of the header.
+	Value string
+}
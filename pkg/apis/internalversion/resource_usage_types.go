@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalversion
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceUsage provides resource usage for a single pod.
+type ResourceUsage struct {
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ObjectMeta
+	// Spec holds spec for resource usage.
+	Spec ResourceUsageSpec
+}
+
+// ResourceUsageSpec holds spec for resource usage.
+type ResourceUsageSpec struct {
+	// Usages is a list of resource usage for the pod.
+	Usages []ResourceUsageContainer
+}
+
+// ResourceUsageContainer holds spec for resource usage container.
+type ResourceUsageContainer struct {
+	// Containers is list of container names.
+	Containers []string
+	// Usage is a list of resource usage for the container.
+	Usage map[string]ResourceUsageValue
+}
+
+// ResourceUsageValue holds value for resource usage.
+type ResourceUsageValue struct {
+	// Value is the value for resource usage.
+	Value *resource.Quantity
+	// Expression is the expression for resource usage.
+	Expression string
+	// Sinusoidal is the sine wave over time for resource usage.
+	Sinusoidal *ResourceUsageSinusoidal
+	// Ramp is the linear ramp over time for resource usage.
+	Ramp *ResourceUsageRamp
+	// Spike is the scheduled spikes over time for resource usage.
+	Spike *ResourceUsageSpike
+}
+
+// ResourceUsageSinusoidal holds a sine wave for resource usage.
+type ResourceUsageSinusoidal struct {
+	// Base is the value the wave oscillates around.
+	Base resource.Quantity
+	// Amplitude is the peak deviation of the wave from the base.
+	Amplitude resource.Quantity
+	// PeriodMilliseconds is the duration of a full cycle of the wave.
+	PeriodMilliseconds int64
+	// PhaseMilliseconds shifts the wave forward in time.
+	PhaseMilliseconds int64
+}
+
+// ResourceUsageRamp holds a linear ramp for resource usage.
+type ResourceUsageRamp struct {
+	// From is the value at the start of the ramp.
+	From resource.Quantity
+	// To is the value at the end of the ramp.
+	To resource.Quantity
+	// DurationMilliseconds is the duration of the ramp.
+	DurationMilliseconds int64
+	// Repeat restarts the ramp from From once it reaches To.
+	Repeat bool
+}
+
+// ResourceUsageSpike holds scheduled spikes for resource usage.
+type ResourceUsageSpike struct {
+	// Base is the value outside the spikes.
+	Base resource.Quantity
+	// Peak is the value during the spikes.
+	Peak resource.Quantity
+	// AfterMilliseconds is the time since the pod started that the first spike begins at.
+	AfterMilliseconds int64
+	// DurationMilliseconds is the duration of each spike.
+	DurationMilliseconds int64
+	// IntervalMilliseconds is the time between the beginnings of the spikes.
+	IntervalMilliseconds int64
+}
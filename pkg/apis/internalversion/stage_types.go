@@ -33,11 +33,19 @@ type Stage struct {
 type StageSpec struct {
 	// ResourceRef specifies the Kind and version of the resource.
 	ResourceRef StageResourceRef
+	// BasedOn is the name of another stage that the fields not set in this stage are inherited from,
+	// except the ResourceRef, and the Selector is merged with the one of the base stage.
+	BasedOn string
 	// Selector specifies the stags will be applied to the selected resource.
 	Selector *StageSelector
 	// Weight means the current stage, in case of multiple stages,
 	// a random stage will be matched as the next stage based on the weight.
 	Weight int
+	// WeightFrom is the expression used to get the weight of each object.
+	// If it is a number type, convert to int.
+	// If it is a string type, the value get will be parsed by strconv.ParseInt.
+	// If the value cannot be got, Weight is used.
+	WeightFrom *ExpressionFromSource
 	// Delay means there is a delay in this stage.
 	Delay *StageDelay
 	// Next indicates that this stage will be moved to.
@@ -71,18 +79,110 @@ type StageDelay struct {
 	// If it is a time.Time type, getting the value will be minus time.Now() to get JitterDurationMilliseconds
 	// If it is a string type, the value get will be parsed by time.ParseDuration.
 	JitterDurationFrom *ExpressionFromSource
+
+	// Distribution means the delay is drawn from a random distribution for each object,
+	// and the sampled value is added to DurationMilliseconds or DurationFrom.
+	// If it is set, JitterDurationMilliseconds and JitterDurationFrom are ignored.
+	Distribution *StageDelayDistribution
+
+	// Schedule means the stage is applied at the wall-clock times of the schedule instead of after the duration,
+	// e.g. to make 500 nodes NotReady 10 minutes after the start of kwok.
+	// If it is set, the other fields of the delay are ignored.
+	Schedule *StageSchedule
 }
 
+// StageSchedule describes the wall-clock times that the stage is applied at.
+// If more than one of the fields are set, the earliest of them is used,
+// and the stage is applied without delay once the time has passed.
+type StageSchedule struct {
+	// At is the time that the stage is applied at.
+	At *metav1.Time
+	// AfterStartMilliseconds is the time since the start of kwok that the stage is applied at,
+	// e.g. 600000 for T+10m.
+	AfterStartMilliseconds *int64
+	// Cron is a cron expression with the five fields of minute, hour, day of month, month and day of week in UTC,
+	// e.g. "*/10 * * * *", the stage is applied at the next time of it.
+	Cron string
+}
+
+// StageDelayDistribution describes a random distribution of the delay time.
+type StageDelayDistribution struct {
+	// Type is the type of the distribution.
+	Type StageDelayDistributionType
+	// MinMilliseconds is the lower bound of the sampled value.
+	// It is required for the Uniform distribution and optional for others.
+	MinMilliseconds *int64
+	// MaxMilliseconds is the upper bound of the sampled value.
+	// It is required for the Uniform distribution and optional for others.
+	MaxMilliseconds *int64
+	// MeanMilliseconds is the mean of the Normal and Exponential distributions.
+	MeanMilliseconds *int64
+	// StdDevMilliseconds is the standard deviation of the Normal distribution.
+	StdDevMilliseconds *int64
+}
+
+// StageDelayDistributionType is the type of the delay distribution.
+type StageDelayDistributionType string
+
+// The following are valid delay distribution types.
+const (
+	// StageDelayDistributionUniform draws the value uniformly between min and max.
+	StageDelayDistributionUniform StageDelayDistributionType = "Uniform"
+	// StageDelayDistributionNormal draws the value from a normal distribution with mean and stdDev.
+	StageDelayDistributionNormal StageDelayDistributionType = "Normal"
+	// StageDelayDistributionExponential draws the value from an exponential distribution with mean.
+	StageDelayDistributionExponential StageDelayDistributionType = "Exponential"
+)
+
 // StageNext describes a stage will be moved to.
 type StageNext struct {
 	// Event means that an event will be sent.
 	Event *StageEvent
+	// Events means that the events will be sent in order, after the Event.
+	Events []StageEvent
 	// Finalizers means that finalizers will be modified.
 	Finalizers *StageFinalizers
 	// Delete means that the resource will be deleted if true.
 	Delete bool
 	// StatusTemplate indicates the template for modifying the status of the resource in the next.
 	StatusTemplate string
+	// Webhook means that the resource will be posted to an external webhook.
+	Webhook *StageWebhook
+	// Patches is the ordered list of patches of the status applied after the next is applied,
+	// each of them is applied after its own delay from the previous one,
+	// e.g. to start the containers of a pod one by one in a single stage.
+	Patches []StagePatch
+	// Subresources indicates the templates for modifying the subresources of the resource other than the status,
+	// e.g. to update the scale of a workload or attach an ephemeral container to a pod.
+	Subresources []StageSubresource
+}
+
+// StageSubresource describes the modification of a subresource.
+type StageSubresource struct {
+	// Name is the name of the subresource, e.g. scale or ephemeralcontainers.
+	Name string
+	// Template indicates the template for the patch of the subresource.
+	Template string
+}
+
+// StagePatch describes one of the ordered patches of the status.
+type StagePatch struct {
+	// DelayMilliseconds is the delay after the previous patch, or the next of the stage, is applied.
+	DelayMilliseconds *int64
+	// StatusTemplate indicates the template for modifying the status of the resource.
+	StatusTemplate string
+}
+
+// StageWebhook describes the webhook called when the stage is played.
+type StageWebhook struct {
+	// URL is the URL of the webhook.
+	// The stage name and the resource are posted to it in JSON, as {"stage": <name>, "object": <resource>}.
+	URL string
+	// TimeoutMilliseconds is the timeout of calling the webhook, defaults to 10 seconds.
+	TimeoutMilliseconds *int64
+	// UseResponse means that the response of the webhook is used as the patch of the status of the resource,
+	// instead of the StatusTemplate. An empty response means no change.
+	UseResponse bool
 }
 
 // StageFinalizers describes the modifications in the finalizers of a resource.
@@ -93,6 +193,14 @@ type StageFinalizers struct {
 	Remove []FinalizerItem
 	// Empty means that the Finalizers for that resource will be emptied.
 	Empty bool
+	// HoldDurationMilliseconds is the duration that the finalizers are held before they are modified,
+	// counting from the deletionTimestamp if the resource is being deleted, otherwise from when the stage is matched.
+	// It simulates the resources that are slow to terminate, e.g. a volume that takes time to detach.
+	HoldDurationMilliseconds *int64
+	// HoldDurationFrom is the expression used to get the value.
+	// If it is a time.Time type, getting the value will be minus time.Now() to get HoldDurationMilliseconds
+	// If it is a string type, the value get will be parsed by time.ParseDuration.
+	HoldDurationFrom *ExpressionFromSource
 }
 
 // FinalizerItem  describes the one of the finalizers.
@@ -108,6 +216,7 @@ type StageEvent struct {
 	// Reason is why the action was taken. It is human-readable.
 	Reason string
 	// Message is a human-readable description of the status of this operation.
+	// It is rendered as a template with the resource, e.g. "Pulling image {{ (index .spec.containers 0).image }}".
 	Message string
 }
 
@@ -25,6 +25,7 @@ import (
 	json "encoding/json"
 	unsafe "unsafe"
 
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -69,6 +70,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*BinaryVerification)(nil), (*configv1alpha1.BinaryVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_BinaryVerification_To_v1alpha1_BinaryVerification(a.(*BinaryVerification), b.(*configv1alpha1.BinaryVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.BinaryVerification)(nil), (*BinaryVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_BinaryVerification_To_internalversion_BinaryVerification(a.(*configv1alpha1.BinaryVerification), b.(*BinaryVerification), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterAttach)(nil), (*v1alpha1.ClusterAttach)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_ClusterAttach_To_v1alpha1_ClusterAttach(a.(*ClusterAttach), b.(*v1alpha1.ClusterAttach), scope)
 	}); err != nil {
@@ -149,6 +160,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterResourceUsage)(nil), (*v1alpha1.ClusterResourceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ClusterResourceUsage_To_v1alpha1_ClusterResourceUsage(a.(*ClusterResourceUsage), b.(*v1alpha1.ClusterResourceUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ClusterResourceUsage)(nil), (*ClusterResourceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterResourceUsage_To_internalversion_ClusterResourceUsage(a.(*v1alpha1.ClusterResourceUsage), b.(*ClusterResourceUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterResourceUsageSpec)(nil), (*v1alpha1.ClusterResourceUsageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ClusterResourceUsageSpec_To_v1alpha1_ClusterResourceUsageSpec(a.(*ClusterResourceUsageSpec), b.(*v1alpha1.ClusterResourceUsageSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ClusterResourceUsageSpec)(nil), (*ClusterResourceUsageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterResourceUsageSpec_To_internalversion_ClusterResourceUsageSpec(a.(*v1alpha1.ClusterResourceUsageSpec), b.(*ClusterResourceUsageSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Component)(nil), (*configv1alpha1.Component)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_Component_To_v1alpha1_Component(a.(*Component), b.(*configv1alpha1.Component), scope)
 	}); err != nil {
@@ -199,6 +230,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ExecAllowedCommand)(nil), (*v1alpha1.ExecAllowedCommand)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ExecAllowedCommand_To_v1alpha1_ExecAllowedCommand(a.(*ExecAllowedCommand), b.(*v1alpha1.ExecAllowedCommand), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ExecAllowedCommand)(nil), (*ExecAllowedCommand)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExecAllowedCommand_To_internalversion_ExecAllowedCommand(a.(*v1alpha1.ExecAllowedCommand), b.(*ExecAllowedCommand), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExecScript)(nil), (*v1alpha1.ExecScript)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ExecScript_To_v1alpha1_ExecScript(a.(*ExecScript), b.(*v1alpha1.ExecScript), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ExecScript)(nil), (*ExecScript)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExecScript_To_internalversion_ExecScript(a.(*v1alpha1.ExecScript), b.(*ExecScript), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExecScriptRule)(nil), (*v1alpha1.ExecScriptRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule(a.(*ExecScriptRule), b.(*v1alpha1.ExecScriptRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ExecScriptRule)(nil), (*ExecScriptRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule(a.(*v1alpha1.ExecScriptRule), b.(*ExecScriptRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExecScriptStep)(nil), (*v1alpha1.ExecScriptStep)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ExecScriptStep_To_v1alpha1_ExecScriptStep(a.(*ExecScriptStep), b.(*v1alpha1.ExecScriptStep), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ExecScriptStep)(nil), (*ExecScriptStep)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExecScriptStep_To_internalversion_ExecScriptStep(a.(*v1alpha1.ExecScriptStep), b.(*ExecScriptStep), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ExecSpec)(nil), (*v1alpha1.ExecSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_ExecSpec_To_v1alpha1_ExecSpec(a.(*ExecSpec), b.(*v1alpha1.ExecSpec), scope)
 	}); err != nil {
@@ -229,6 +300,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ExecTargetLocalSandbox)(nil), (*v1alpha1.ExecTargetLocalSandbox)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ExecTargetLocalSandbox_To_v1alpha1_ExecTargetLocalSandbox(a.(*ExecTargetLocalSandbox), b.(*v1alpha1.ExecTargetLocalSandbox), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ExecTargetLocalSandbox)(nil), (*ExecTargetLocalSandbox)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExecTargetLocalSandbox_To_internalversion_ExecTargetLocalSandbox(a.(*v1alpha1.ExecTargetLocalSandbox), b.(*ExecTargetLocalSandbox), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ExpressionFromSource)(nil), (*v1alpha1.ExpressionFromSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_ExpressionFromSource_To_v1alpha1_ExpressionFromSource(a.(*ExpressionFromSource), b.(*v1alpha1.ExpressionFromSource), scope)
 	}); err != nil {
@@ -269,6 +350,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ForwardHTTP)(nil), (*v1alpha1.ForwardHTTP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ForwardHTTP_To_v1alpha1_ForwardHTTP(a.(*ForwardHTTP), b.(*v1alpha1.ForwardHTTP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ForwardHTTP)(nil), (*ForwardHTTP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ForwardHTTP_To_internalversion_ForwardHTTP(a.(*v1alpha1.ForwardHTTP), b.(*ForwardHTTP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ForwardHTTPHeader)(nil), (*v1alpha1.ForwardHTTPHeader)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader(a.(*ForwardHTTPHeader), b.(*v1alpha1.ForwardHTTPHeader), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ForwardHTTPHeader)(nil), (*ForwardHTTPHeader)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader(a.(*v1alpha1.ForwardHTTPHeader), b.(*ForwardHTTPHeader), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ForwardHTTPRoute)(nil), (*v1alpha1.ForwardHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute(a.(*ForwardHTTPRoute), b.(*v1alpha1.ForwardHTTPRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ForwardHTTPRoute)(nil), (*ForwardHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute(a.(*v1alpha1.ForwardHTTPRoute), b.(*ForwardHTTPRoute), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ForwardTarget)(nil), (*v1alpha1.ForwardTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_ForwardTarget_To_v1alpha1_ForwardTarget(a.(*ForwardTarget), b.(*v1alpha1.ForwardTarget), scope)
 	}); err != nil {
@@ -279,6 +390,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HTTPGetAction)(nil), (*configv1alpha1.HTTPGetAction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_HTTPGetAction_To_v1alpha1_HTTPGetAction(a.(*HTTPGetAction), b.(*configv1alpha1.HTTPGetAction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.HTTPGetAction)(nil), (*HTTPGetAction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HTTPGetAction_To_internalversion_HTTPGetAction(a.(*configv1alpha1.HTTPGetAction), b.(*HTTPGetAction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ImageSimulation)(nil), (*configv1alpha1.ImageSimulation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ImageSimulation_To_v1alpha1_ImageSimulation(a.(*ImageSimulation), b.(*configv1alpha1.ImageSimulation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.ImageSimulation)(nil), (*ImageSimulation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ImageSimulation_To_internalversion_ImageSimulation(a.(*configv1alpha1.ImageSimulation), b.(*ImageSimulation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KwokConfiguration)(nil), (*configv1alpha1.KwokConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_KwokConfiguration_To_v1alpha1_KwokConfiguration(a.(*KwokConfiguration), b.(*configv1alpha1.KwokConfiguration), scope)
 	}); err != nil {
@@ -349,6 +480,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LogGenerator)(nil), (*v1alpha1.LogGenerator)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_LogGenerator_To_v1alpha1_LogGenerator(a.(*LogGenerator), b.(*v1alpha1.LogGenerator), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.LogGenerator)(nil), (*LogGenerator)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LogGenerator_To_internalversion_LogGenerator(a.(*v1alpha1.LogGenerator), b.(*LogGenerator), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LogGeneratorField)(nil), (*v1alpha1.LogGeneratorField)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_LogGeneratorField_To_v1alpha1_LogGeneratorField(a.(*LogGeneratorField), b.(*v1alpha1.LogGeneratorField), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.LogGeneratorField)(nil), (*LogGeneratorField)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LogGeneratorField_To_internalversion_LogGeneratorField(a.(*v1alpha1.LogGeneratorField), b.(*LogGeneratorField), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LogGeneratorLevel)(nil), (*v1alpha1.LogGeneratorLevel)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_LogGeneratorLevel_To_v1alpha1_LogGeneratorLevel(a.(*LogGeneratorLevel), b.(*v1alpha1.LogGeneratorLevel), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.LogGeneratorLevel)(nil), (*LogGeneratorLevel)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LogGeneratorLevel_To_internalversion_LogGeneratorLevel(a.(*v1alpha1.LogGeneratorLevel), b.(*LogGeneratorLevel), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Logs)(nil), (*v1alpha1.Logs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_Logs_To_v1alpha1_Logs(a.(*Logs), b.(*v1alpha1.Logs), scope)
 	}); err != nil {
@@ -369,6 +530,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ManageNodeRule)(nil), (*configv1alpha1.ManageNodeRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ManageNodeRule_To_v1alpha1_ManageNodeRule(a.(*ManageNodeRule), b.(*configv1alpha1.ManageNodeRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.ManageNodeRule)(nil), (*ManageNodeRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ManageNodeRule_To_internalversion_ManageNodeRule(a.(*configv1alpha1.ManageNodeRule), b.(*ManageNodeRule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Metric)(nil), (*v1alpha1.Metric)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_Metric_To_v1alpha1_Metric(a.(*Metric), b.(*v1alpha1.Metric), scope)
 	}); err != nil {
@@ -389,6 +560,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MetricBucketsFrom)(nil), (*v1alpha1.MetricBucketsFrom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_MetricBucketsFrom_To_v1alpha1_MetricBucketsFrom(a.(*MetricBucketsFrom), b.(*v1alpha1.MetricBucketsFrom), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.MetricBucketsFrom)(nil), (*MetricBucketsFrom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MetricBucketsFrom_To_internalversion_MetricBucketsFrom(a.(*v1alpha1.MetricBucketsFrom), b.(*MetricBucketsFrom), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MetricConfig)(nil), (*v1alpha1.MetricConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_MetricConfig_To_v1alpha1_MetricConfig(a.(*MetricConfig), b.(*v1alpha1.MetricConfig), scope)
 	}); err != nil {
@@ -409,6 +590,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MetricQuantile)(nil), (*v1alpha1.MetricQuantile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_MetricQuantile_To_v1alpha1_MetricQuantile(a.(*MetricQuantile), b.(*v1alpha1.MetricQuantile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.MetricQuantile)(nil), (*MetricQuantile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MetricQuantile_To_internalversion_MetricQuantile(a.(*v1alpha1.MetricQuantile), b.(*MetricQuantile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MetricSpec)(nil), (*v1alpha1.MetricSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_MetricSpec_To_v1alpha1_MetricSpec(a.(*MetricSpec), b.(*v1alpha1.MetricSpec), scope)
 	}); err != nil {
@@ -459,6 +650,86 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Probe)(nil), (*configv1alpha1.Probe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_Probe_To_v1alpha1_Probe(a.(*Probe), b.(*configv1alpha1.Probe), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.Probe)(nil), (*Probe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Probe_To_internalversion_Probe(a.(*configv1alpha1.Probe), b.(*Probe), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceUsage)(nil), (*v1alpha1.ResourceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ResourceUsage_To_v1alpha1_ResourceUsage(a.(*ResourceUsage), b.(*v1alpha1.ResourceUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ResourceUsage)(nil), (*ResourceUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceUsage_To_internalversion_ResourceUsage(a.(*v1alpha1.ResourceUsage), b.(*ResourceUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceUsageContainer)(nil), (*v1alpha1.ResourceUsageContainer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ResourceUsageContainer_To_v1alpha1_ResourceUsageContainer(a.(*ResourceUsageContainer), b.(*v1alpha1.ResourceUsageContainer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ResourceUsageContainer)(nil), (*ResourceUsageContainer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceUsageContainer_To_internalversion_ResourceUsageContainer(a.(*v1alpha1.ResourceUsageContainer), b.(*ResourceUsageContainer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceUsageRamp)(nil), (*v1alpha1.ResourceUsageRamp)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ResourceUsageRamp_To_v1alpha1_ResourceUsageRamp(a.(*ResourceUsageRamp), b.(*v1alpha1.ResourceUsageRamp), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ResourceUsageRamp)(nil), (*ResourceUsageRamp)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceUsageRamp_To_internalversion_ResourceUsageRamp(a.(*v1alpha1.ResourceUsageRamp), b.(*ResourceUsageRamp), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceUsageSinusoidal)(nil), (*v1alpha1.ResourceUsageSinusoidal)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ResourceUsageSinusoidal_To_v1alpha1_ResourceUsageSinusoidal(a.(*ResourceUsageSinusoidal), b.(*v1alpha1.ResourceUsageSinusoidal), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ResourceUsageSinusoidal)(nil), (*ResourceUsageSinusoidal)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceUsageSinusoidal_To_internalversion_ResourceUsageSinusoidal(a.(*v1alpha1.ResourceUsageSinusoidal), b.(*ResourceUsageSinusoidal), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceUsageSpec)(nil), (*v1alpha1.ResourceUsageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ResourceUsageSpec_To_v1alpha1_ResourceUsageSpec(a.(*ResourceUsageSpec), b.(*v1alpha1.ResourceUsageSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ResourceUsageSpec)(nil), (*ResourceUsageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceUsageSpec_To_internalversion_ResourceUsageSpec(a.(*v1alpha1.ResourceUsageSpec), b.(*ResourceUsageSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceUsageSpike)(nil), (*v1alpha1.ResourceUsageSpike)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ResourceUsageSpike_To_v1alpha1_ResourceUsageSpike(a.(*ResourceUsageSpike), b.(*v1alpha1.ResourceUsageSpike), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ResourceUsageSpike)(nil), (*ResourceUsageSpike)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceUsageSpike_To_internalversion_ResourceUsageSpike(a.(*v1alpha1.ResourceUsageSpike), b.(*ResourceUsageSpike), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceUsageValue)(nil), (*v1alpha1.ResourceUsageValue)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ResourceUsageValue_To_v1alpha1_ResourceUsageValue(a.(*ResourceUsageValue), b.(*v1alpha1.ResourceUsageValue), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ResourceUsageValue)(nil), (*ResourceUsageValue)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue(a.(*v1alpha1.ResourceUsageValue), b.(*ResourceUsageValue), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SecurityContext)(nil), (*v1alpha1.SecurityContext)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_SecurityContext_To_v1alpha1_SecurityContext(a.(*SecurityContext), b.(*v1alpha1.SecurityContext), scope)
 	}); err != nil {
@@ -479,6 +750,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SimulatedImage)(nil), (*configv1alpha1.SimulatedImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_SimulatedImage_To_v1alpha1_SimulatedImage(a.(*SimulatedImage), b.(*configv1alpha1.SimulatedImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.SimulatedImage)(nil), (*SimulatedImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SimulatedImage_To_internalversion_SimulatedImage(a.(*configv1alpha1.SimulatedImage), b.(*SimulatedImage), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Stage)(nil), (*v1alpha1.Stage)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_Stage_To_v1alpha1_Stage(a.(*Stage), b.(*v1alpha1.Stage), scope)
 	}); err != nil {
@@ -499,6 +780,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StageDelayDistribution)(nil), (*v1alpha1.StageDelayDistribution)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StageDelayDistribution_To_v1alpha1_StageDelayDistribution(a.(*StageDelayDistribution), b.(*v1alpha1.StageDelayDistribution), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StageDelayDistribution)(nil), (*StageDelayDistribution)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StageDelayDistribution_To_internalversion_StageDelayDistribution(a.(*v1alpha1.StageDelayDistribution), b.(*StageDelayDistribution), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StageEvent)(nil), (*v1alpha1.StageEvent)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_StageEvent_To_v1alpha1_StageEvent(a.(*StageEvent), b.(*v1alpha1.StageEvent), scope)
 	}); err != nil {
@@ -529,6 +820,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StagePatch)(nil), (*v1alpha1.StagePatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StagePatch_To_v1alpha1_StagePatch(a.(*StagePatch), b.(*v1alpha1.StagePatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StagePatch)(nil), (*StagePatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StagePatch_To_internalversion_StagePatch(a.(*v1alpha1.StagePatch), b.(*StagePatch), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StageResourceRef)(nil), (*v1alpha1.StageResourceRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_StageResourceRef_To_v1alpha1_StageResourceRef(a.(*StageResourceRef), b.(*v1alpha1.StageResourceRef), scope)
 	}); err != nil {
@@ -539,6 +840,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StageSchedule)(nil), (*v1alpha1.StageSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StageSchedule_To_v1alpha1_StageSchedule(a.(*StageSchedule), b.(*v1alpha1.StageSchedule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StageSchedule)(nil), (*StageSchedule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StageSchedule_To_internalversion_StageSchedule(a.(*v1alpha1.StageSchedule), b.(*StageSchedule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StageSelector)(nil), (*v1alpha1.StageSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_StageSelector_To_v1alpha1_StageSelector(a.(*StageSelector), b.(*v1alpha1.StageSelector), scope)
 	}); err != nil {
@@ -559,6 +870,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StageSubresource)(nil), (*v1alpha1.StageSubresource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StageSubresource_To_v1alpha1_StageSubresource(a.(*StageSubresource), b.(*v1alpha1.StageSubresource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StageSubresource)(nil), (*StageSubresource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StageSubresource_To_internalversion_StageSubresource(a.(*v1alpha1.StageSubresource), b.(*StageSubresource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StageWebhook)(nil), (*v1alpha1.StageWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StageWebhook_To_v1alpha1_StageWebhook(a.(*StageWebhook), b.(*v1alpha1.StageWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StageWebhook)(nil), (*StageWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StageWebhook_To_internalversion_StageWebhook(a.(*v1alpha1.StageWebhook), b.(*StageWebhook), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Volume)(nil), (*configv1alpha1.Volume)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_Volume_To_v1alpha1_Volume(a.(*Volume), b.(*configv1alpha1.Volume), scope)
 	}); err != nil {
@@ -666,6 +997,38 @@ func Convert_v1alpha1_AttachSpec_To_internalversion_AttachSpec(in *v1alpha1.Atta
 	return autoConvert_v1alpha1_AttachSpec_To_internalversion_AttachSpec(in, out, s)
 }
 
+func autoConvert_internalversion_BinaryVerification_To_v1alpha1_BinaryVerification(in *BinaryVerification, out *configv1alpha1.BinaryVerification, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Sha256 = in.Sha256
+	out.Signature = in.Signature
+	out.Key = in.Key
+	out.Certificate = in.Certificate
+	out.CertificateIdentity = in.CertificateIdentity
+	out.CertificateOIDCIssuer = in.CertificateOIDCIssuer
+	return nil
+}
+
+// Convert_internalversion_BinaryVerification_To_v1alpha1_BinaryVerification is an autogenerated conversion function.
+func Convert_internalversion_BinaryVerification_To_v1alpha1_BinaryVerification(in *BinaryVerification, out *configv1alpha1.BinaryVerification, s conversion.Scope) error {
+	return autoConvert_internalversion_BinaryVerification_To_v1alpha1_BinaryVerification(in, out, s)
+}
+
+func autoConvert_v1alpha1_BinaryVerification_To_internalversion_BinaryVerification(in *configv1alpha1.BinaryVerification, out *BinaryVerification, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Sha256 = in.Sha256
+	out.Signature = in.Signature
+	out.Key = in.Key
+	out.Certificate = in.Certificate
+	out.CertificateIdentity = in.CertificateIdentity
+	out.CertificateOIDCIssuer = in.CertificateOIDCIssuer
+	return nil
+}
+
+// Convert_v1alpha1_BinaryVerification_To_internalversion_BinaryVerification is an autogenerated conversion function.
+func Convert_v1alpha1_BinaryVerification_To_internalversion_BinaryVerification(in *configv1alpha1.BinaryVerification, out *BinaryVerification, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BinaryVerification_To_internalversion_BinaryVerification(in, out, s)
+}
+
 func autoConvert_internalversion_ClusterAttach_To_v1alpha1_ClusterAttach(in *ClusterAttach, out *v1alpha1.ClusterAttach, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_internalversion_ClusterAttachSpec_To_v1alpha1_ClusterAttachSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -906,6 +1269,76 @@ func Convert_v1alpha1_ClusterPortForwardSpec_To_internalversion_ClusterPortForwa
 	return autoConvert_v1alpha1_ClusterPortForwardSpec_To_internalversion_ClusterPortForwardSpec(in, out, s)
 }
 
+func autoConvert_internalversion_ClusterResourceUsage_To_v1alpha1_ClusterResourceUsage(in *ClusterResourceUsage, out *v1alpha1.ClusterResourceUsage, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_internalversion_ClusterResourceUsageSpec_To_v1alpha1_ClusterResourceUsageSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_internalversion_ClusterResourceUsage_To_v1alpha1_ClusterResourceUsage is an autogenerated conversion function.
+func Convert_internalversion_ClusterResourceUsage_To_v1alpha1_ClusterResourceUsage(in *ClusterResourceUsage, out *v1alpha1.ClusterResourceUsage, s conversion.Scope) error {
+	return autoConvert_internalversion_ClusterResourceUsage_To_v1alpha1_ClusterResourceUsage(in, out, s)
+}
+
+func autoConvert_v1alpha1_ClusterResourceUsage_To_internalversion_ClusterResourceUsage(in *v1alpha1.ClusterResourceUsage, out *ClusterResourceUsage, s conversion.Scope) error {
+	// INFO: in.TypeMeta opted out of conversion generation
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_ClusterResourceUsageSpec_To_internalversion_ClusterResourceUsageSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	// INFO: in.Status opted out of conversion generation
+	return nil
+}
+
+// Convert_v1alpha1_ClusterResourceUsage_To_internalversion_ClusterResourceUsage is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterResourceUsage_To_internalversion_ClusterResourceUsage(in *v1alpha1.ClusterResourceUsage, out *ClusterResourceUsage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterResourceUsage_To_internalversion_ClusterResourceUsage(in, out, s)
+}
+
+func autoConvert_internalversion_ClusterResourceUsageSpec_To_v1alpha1_ClusterResourceUsageSpec(in *ClusterResourceUsageSpec, out *v1alpha1.ClusterResourceUsageSpec, s conversion.Scope) error {
+	out.Selector = (*v1alpha1.ObjectSelector)(unsafe.Pointer(in.Selector))
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]v1alpha1.ResourceUsageContainer, len(*in))
+		for i := range *in {
+			if err := Convert_internalversion_ResourceUsageContainer_To_v1alpha1_ResourceUsageContainer(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Usages = nil
+	}
+	return nil
+}
+
+// Convert_internalversion_ClusterResourceUsageSpec_To_v1alpha1_ClusterResourceUsageSpec is an autogenerated conversion function.
+func Convert_internalversion_ClusterResourceUsageSpec_To_v1alpha1_ClusterResourceUsageSpec(in *ClusterResourceUsageSpec, out *v1alpha1.ClusterResourceUsageSpec, s conversion.Scope) error {
+	return autoConvert_internalversion_ClusterResourceUsageSpec_To_v1alpha1_ClusterResourceUsageSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_ClusterResourceUsageSpec_To_internalversion_ClusterResourceUsageSpec(in *v1alpha1.ClusterResourceUsageSpec, out *ClusterResourceUsageSpec, s conversion.Scope) error {
+	out.Selector = (*ObjectSelector)(unsafe.Pointer(in.Selector))
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]ResourceUsageContainer, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_ResourceUsageContainer_To_internalversion_ResourceUsageContainer(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Usages = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ClusterResourceUsageSpec_To_internalversion_ClusterResourceUsageSpec is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterResourceUsageSpec_To_internalversion_ClusterResourceUsageSpec(in *v1alpha1.ClusterResourceUsageSpec, out *ClusterResourceUsageSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterResourceUsageSpec_To_internalversion_ClusterResourceUsageSpec(in, out, s)
+}
+
 func autoConvert_internalversion_Component_To_v1alpha1_Component(in *Component, out *configv1alpha1.Component, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Links = *(*[]string)(unsafe.Pointer(&in.Links))
@@ -929,6 +1362,8 @@ func autoConvert_internalversion_Component_To_v1alpha1_Component(in *Component,
 		out.Volumes = nil
 	}
 	out.Version = in.Version
+	out.ReadinessProbe = (*configv1alpha1.Probe)(unsafe.Pointer(in.ReadinessProbe))
+	out.LivenessProbe = (*configv1alpha1.Probe)(unsafe.Pointer(in.LivenessProbe))
 	return nil
 }
 
@@ -960,6 +1395,8 @@ func autoConvert_v1alpha1_Component_To_internalversion_Component(in *configv1alp
 		out.Volumes = nil
 	}
 	out.Version = in.Version
+	out.ReadinessProbe = (*Probe)(unsafe.Pointer(in.ReadinessProbe))
+	out.LivenessProbe = (*Probe)(unsafe.Pointer(in.LivenessProbe))
 	return nil
 }
 
@@ -1086,12 +1523,108 @@ func Convert_v1alpha1_Exec_To_internalversion_Exec(in *v1alpha1.Exec, out *Exec,
 	return autoConvert_v1alpha1_Exec_To_internalversion_Exec(in, out, s)
 }
 
-func autoConvert_internalversion_ExecSpec_To_v1alpha1_ExecSpec(in *ExecSpec, out *v1alpha1.ExecSpec, s conversion.Scope) error {
-	out.Execs = *(*[]v1alpha1.ExecTarget)(unsafe.Pointer(&in.Execs))
+func autoConvert_internalversion_ExecAllowedCommand_To_v1alpha1_ExecAllowedCommand(in *ExecAllowedCommand, out *v1alpha1.ExecAllowedCommand, s conversion.Scope) error {
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
 	return nil
 }
 
-// Convert_internalversion_ExecSpec_To_v1alpha1_ExecSpec is an autogenerated conversion function.
+// Convert_internalversion_ExecAllowedCommand_To_v1alpha1_ExecAllowedCommand is an autogenerated conversion function.
+func Convert_internalversion_ExecAllowedCommand_To_v1alpha1_ExecAllowedCommand(in *ExecAllowedCommand, out *v1alpha1.ExecAllowedCommand, s conversion.Scope) error {
+	return autoConvert_internalversion_ExecAllowedCommand_To_v1alpha1_ExecAllowedCommand(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExecAllowedCommand_To_internalversion_ExecAllowedCommand(in *v1alpha1.ExecAllowedCommand, out *ExecAllowedCommand, s conversion.Scope) error {
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	return nil
+}
+
+// Convert_v1alpha1_ExecAllowedCommand_To_internalversion_ExecAllowedCommand is an autogenerated conversion function.
+func Convert_v1alpha1_ExecAllowedCommand_To_internalversion_ExecAllowedCommand(in *v1alpha1.ExecAllowedCommand, out *ExecAllowedCommand, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExecAllowedCommand_To_internalversion_ExecAllowedCommand(in, out, s)
+}
+
+func autoConvert_internalversion_ExecScript_To_v1alpha1_ExecScript(in *ExecScript, out *v1alpha1.ExecScript, s conversion.Scope) error {
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Steps = *(*[]v1alpha1.ExecScriptStep)(unsafe.Pointer(&in.Steps))
+	out.Rules = *(*[]v1alpha1.ExecScriptRule)(unsafe.Pointer(&in.Rules))
+	out.ExitCode = in.ExitCode
+	return nil
+}
+
+// Convert_internalversion_ExecScript_To_v1alpha1_ExecScript is an autogenerated conversion function.
+func Convert_internalversion_ExecScript_To_v1alpha1_ExecScript(in *ExecScript, out *v1alpha1.ExecScript, s conversion.Scope) error {
+	return autoConvert_internalversion_ExecScript_To_v1alpha1_ExecScript(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExecScript_To_internalversion_ExecScript(in *v1alpha1.ExecScript, out *ExecScript, s conversion.Scope) error {
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Steps = *(*[]ExecScriptStep)(unsafe.Pointer(&in.Steps))
+	out.Rules = *(*[]ExecScriptRule)(unsafe.Pointer(&in.Rules))
+	out.ExitCode = in.ExitCode
+	return nil
+}
+
+// Convert_v1alpha1_ExecScript_To_internalversion_ExecScript is an autogenerated conversion function.
+func Convert_v1alpha1_ExecScript_To_internalversion_ExecScript(in *v1alpha1.ExecScript, out *ExecScript, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExecScript_To_internalversion_ExecScript(in, out, s)
+}
+
+func autoConvert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule(in *ExecScriptRule, out *v1alpha1.ExecScriptRule, s conversion.Scope) error {
+	out.Match = in.Match
+	out.Steps = *(*[]v1alpha1.ExecScriptStep)(unsafe.Pointer(&in.Steps))
+	out.Exit = in.Exit
+	out.ExitCode = in.ExitCode
+	return nil
+}
+
+// Convert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule is an autogenerated conversion function.
+func Convert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule(in *ExecScriptRule, out *v1alpha1.ExecScriptRule, s conversion.Scope) error {
+	return autoConvert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule(in *v1alpha1.ExecScriptRule, out *ExecScriptRule, s conversion.Scope) error {
+	out.Match = in.Match
+	out.Steps = *(*[]ExecScriptStep)(unsafe.Pointer(&in.Steps))
+	out.Exit = in.Exit
+	out.ExitCode = in.ExitCode
+	return nil
+}
+
+// Convert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule is an autogenerated conversion function.
+func Convert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule(in *v1alpha1.ExecScriptRule, out *ExecScriptRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule(in, out, s)
+}
+
+func autoConvert_internalversion_ExecScriptStep_To_v1alpha1_ExecScriptStep(in *ExecScriptStep, out *v1alpha1.ExecScriptStep, s conversion.Scope) error {
+	out.DelayMilliseconds = in.DelayMilliseconds
+	out.Stdout = in.Stdout
+	out.Stderr = in.Stderr
+	return nil
+}
+
+// Convert_internalversion_ExecScriptStep_To_v1alpha1_ExecScriptStep is an autogenerated conversion function.
+func Convert_internalversion_ExecScriptStep_To_v1alpha1_ExecScriptStep(in *ExecScriptStep, out *v1alpha1.ExecScriptStep, s conversion.Scope) error {
+	return autoConvert_internalversion_ExecScriptStep_To_v1alpha1_ExecScriptStep(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExecScriptStep_To_internalversion_ExecScriptStep(in *v1alpha1.ExecScriptStep, out *ExecScriptStep, s conversion.Scope) error {
+	out.DelayMilliseconds = in.DelayMilliseconds
+	out.Stdout = in.Stdout
+	out.Stderr = in.Stderr
+	return nil
+}
+
+// Convert_v1alpha1_ExecScriptStep_To_internalversion_ExecScriptStep is an autogenerated conversion function.
+func Convert_v1alpha1_ExecScriptStep_To_internalversion_ExecScriptStep(in *v1alpha1.ExecScriptStep, out *ExecScriptStep, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExecScriptStep_To_internalversion_ExecScriptStep(in, out, s)
+}
+
+func autoConvert_internalversion_ExecSpec_To_v1alpha1_ExecSpec(in *ExecSpec, out *v1alpha1.ExecSpec, s conversion.Scope) error {
+	out.Execs = *(*[]v1alpha1.ExecTarget)(unsafe.Pointer(&in.Execs))
+	return nil
+}
+
+// Convert_internalversion_ExecSpec_To_v1alpha1_ExecSpec is an autogenerated conversion function.
 func Convert_internalversion_ExecSpec_To_v1alpha1_ExecSpec(in *ExecSpec, out *v1alpha1.ExecSpec, s conversion.Scope) error {
 	return autoConvert_internalversion_ExecSpec_To_v1alpha1_ExecSpec(in, out, s)
 }
@@ -1109,6 +1642,7 @@ func Convert_v1alpha1_ExecSpec_To_internalversion_ExecSpec(in *v1alpha1.ExecSpec
 func autoConvert_internalversion_ExecTarget_To_v1alpha1_ExecTarget(in *ExecTarget, out *v1alpha1.ExecTarget, s conversion.Scope) error {
 	out.Containers = *(*[]string)(unsafe.Pointer(&in.Containers))
 	out.Local = (*v1alpha1.ExecTargetLocal)(unsafe.Pointer(in.Local))
+	out.Scripts = *(*[]v1alpha1.ExecScript)(unsafe.Pointer(&in.Scripts))
 	return nil
 }
 
@@ -1120,6 +1654,7 @@ func Convert_internalversion_ExecTarget_To_v1alpha1_ExecTarget(in *ExecTarget, o
 func autoConvert_v1alpha1_ExecTarget_To_internalversion_ExecTarget(in *v1alpha1.ExecTarget, out *ExecTarget, s conversion.Scope) error {
 	out.Containers = *(*[]string)(unsafe.Pointer(&in.Containers))
 	out.Local = (*ExecTargetLocal)(unsafe.Pointer(in.Local))
+	out.Scripts = *(*[]ExecScript)(unsafe.Pointer(&in.Scripts))
 	return nil
 }
 
@@ -1132,6 +1667,7 @@ func autoConvert_internalversion_ExecTargetLocal_To_v1alpha1_ExecTargetLocal(in
 	out.WorkDir = in.WorkDir
 	out.Envs = *(*[]v1alpha1.EnvVar)(unsafe.Pointer(&in.Envs))
 	out.SecurityContext = (*v1alpha1.SecurityContext)(unsafe.Pointer(in.SecurityContext))
+	out.Sandbox = (*v1alpha1.ExecTargetLocalSandbox)(unsafe.Pointer(in.Sandbox))
 	return nil
 }
 
@@ -1144,6 +1680,7 @@ func autoConvert_v1alpha1_ExecTargetLocal_To_internalversion_ExecTargetLocal(in
 	out.WorkDir = in.WorkDir
 	out.Envs = *(*[]EnvVar)(unsafe.Pointer(&in.Envs))
 	out.SecurityContext = (*SecurityContext)(unsafe.Pointer(in.SecurityContext))
+	out.Sandbox = (*ExecTargetLocalSandbox)(unsafe.Pointer(in.Sandbox))
 	return nil
 }
 
@@ -1152,6 +1689,30 @@ func Convert_v1alpha1_ExecTargetLocal_To_internalversion_ExecTargetLocal(in *v1a
 	return autoConvert_v1alpha1_ExecTargetLocal_To_internalversion_ExecTargetLocal(in, out, s)
 }
 
+func autoConvert_internalversion_ExecTargetLocalSandbox_To_v1alpha1_ExecTargetLocalSandbox(in *ExecTargetLocalSandbox, out *v1alpha1.ExecTargetLocalSandbox, s conversion.Scope) error {
+	out.AllowedCommands = *(*[]v1alpha1.ExecAllowedCommand)(unsafe.Pointer(&in.AllowedCommands))
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.CPULimitSeconds = in.CPULimitSeconds
+	return nil
+}
+
+// Convert_internalversion_ExecTargetLocalSandbox_To_v1alpha1_ExecTargetLocalSandbox is an autogenerated conversion function.
+func Convert_internalversion_ExecTargetLocalSandbox_To_v1alpha1_ExecTargetLocalSandbox(in *ExecTargetLocalSandbox, out *v1alpha1.ExecTargetLocalSandbox, s conversion.Scope) error {
+	return autoConvert_internalversion_ExecTargetLocalSandbox_To_v1alpha1_ExecTargetLocalSandbox(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExecTargetLocalSandbox_To_internalversion_ExecTargetLocalSandbox(in *v1alpha1.ExecTargetLocalSandbox, out *ExecTargetLocalSandbox, s conversion.Scope) error {
+	out.AllowedCommands = *(*[]ExecAllowedCommand)(unsafe.Pointer(&in.AllowedCommands))
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.CPULimitSeconds = in.CPULimitSeconds
+	return nil
+}
+
+// Convert_v1alpha1_ExecTargetLocalSandbox_To_internalversion_ExecTargetLocalSandbox is an autogenerated conversion function.
+func Convert_v1alpha1_ExecTargetLocalSandbox_To_internalversion_ExecTargetLocalSandbox(in *v1alpha1.ExecTargetLocalSandbox, out *ExecTargetLocalSandbox, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExecTargetLocalSandbox_To_internalversion_ExecTargetLocalSandbox(in, out, s)
+}
+
 func autoConvert_internalversion_ExpressionFromSource_To_v1alpha1_ExpressionFromSource(in *ExpressionFromSource, out *v1alpha1.ExpressionFromSource, s conversion.Scope) error {
 	out.ExpressionFrom = in.ExpressionFrom
 	return nil
@@ -1218,6 +1779,7 @@ func autoConvert_internalversion_Forward_To_v1alpha1_Forward(in *Forward, out *v
 	out.Ports = *(*[]int32)(unsafe.Pointer(&in.Ports))
 	out.Target = (*v1alpha1.ForwardTarget)(unsafe.Pointer(in.Target))
 	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.HTTP = (*v1alpha1.ForwardHTTP)(unsafe.Pointer(in.HTTP))
 	return nil
 }
 
@@ -1230,6 +1792,7 @@ func autoConvert_v1alpha1_Forward_To_internalversion_Forward(in *v1alpha1.Forwar
 	out.Ports = *(*[]int32)(unsafe.Pointer(&in.Ports))
 	out.Target = (*ForwardTarget)(unsafe.Pointer(in.Target))
 	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.HTTP = (*ForwardHTTP)(unsafe.Pointer(in.HTTP))
 	return nil
 }
 
@@ -1238,6 +1801,78 @@ func Convert_v1alpha1_Forward_To_internalversion_Forward(in *v1alpha1.Forward, o
 	return autoConvert_v1alpha1_Forward_To_internalversion_Forward(in, out, s)
 }
 
+func autoConvert_internalversion_ForwardHTTP_To_v1alpha1_ForwardHTTP(in *ForwardHTTP, out *v1alpha1.ForwardHTTP, s conversion.Scope) error {
+	out.Routes = *(*[]v1alpha1.ForwardHTTPRoute)(unsafe.Pointer(&in.Routes))
+	return nil
+}
+
+// Convert_internalversion_ForwardHTTP_To_v1alpha1_ForwardHTTP is an autogenerated conversion function.
+func Convert_internalversion_ForwardHTTP_To_v1alpha1_ForwardHTTP(in *ForwardHTTP, out *v1alpha1.ForwardHTTP, s conversion.Scope) error {
+	return autoConvert_internalversion_ForwardHTTP_To_v1alpha1_ForwardHTTP(in, out, s)
+}
+
+func autoConvert_v1alpha1_ForwardHTTP_To_internalversion_ForwardHTTP(in *v1alpha1.ForwardHTTP, out *ForwardHTTP, s conversion.Scope) error {
+	out.Routes = *(*[]ForwardHTTPRoute)(unsafe.Pointer(&in.Routes))
+	return nil
+}
+
+// Convert_v1alpha1_ForwardHTTP_To_internalversion_ForwardHTTP is an autogenerated conversion function.
+func Convert_v1alpha1_ForwardHTTP_To_internalversion_ForwardHTTP(in *v1alpha1.ForwardHTTP, out *ForwardHTTP, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ForwardHTTP_To_internalversion_ForwardHTTP(in, out, s)
+}
+
+func autoConvert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader(in *ForwardHTTPHeader, out *v1alpha1.ForwardHTTPHeader, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader is an autogenerated conversion function.
+func Convert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader(in *ForwardHTTPHeader, out *v1alpha1.ForwardHTTPHeader, s conversion.Scope) error {
+	return autoConvert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader(in, out, s)
+}
+
+func autoConvert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader(in *v1alpha1.ForwardHTTPHeader, out *ForwardHTTPHeader, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader is an autogenerated conversion function.
+func Convert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader(in *v1alpha1.ForwardHTTPHeader, out *ForwardHTTPHeader, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader(in, out, s)
+}
+
+func autoConvert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute(in *ForwardHTTPRoute, out *v1alpha1.ForwardHTTPRoute, s conversion.Scope) error {
+	out.Method = in.Method
+	out.Path = in.Path
+	out.StatusCode = in.StatusCode
+	out.Headers = *(*[]v1alpha1.ForwardHTTPHeader)(unsafe.Pointer(&in.Headers))
+	out.Body = in.Body
+	out.DelayMilliseconds = in.DelayMilliseconds
+	return nil
+}
+
+// Convert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute is an autogenerated conversion function.
+func Convert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute(in *ForwardHTTPRoute, out *v1alpha1.ForwardHTTPRoute, s conversion.Scope) error {
+	return autoConvert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute(in, out, s)
+}
+
+func autoConvert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute(in *v1alpha1.ForwardHTTPRoute, out *ForwardHTTPRoute, s conversion.Scope) error {
+	out.Method = in.Method
+	out.Path = in.Path
+	out.StatusCode = in.StatusCode
+	out.Headers = *(*[]ForwardHTTPHeader)(unsafe.Pointer(&in.Headers))
+	out.Body = in.Body
+	out.DelayMilliseconds = in.DelayMilliseconds
+	return nil
+}
+
+// Convert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute is an autogenerated conversion function.
+func Convert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute(in *v1alpha1.ForwardHTTPRoute, out *ForwardHTTPRoute, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute(in, out, s)
+}
+
 func autoConvert_internalversion_ForwardTarget_To_v1alpha1_ForwardTarget(in *ForwardTarget, out *v1alpha1.ForwardTarget, s conversion.Scope) error {
 	out.Port = in.Port
 	out.Address = in.Address
@@ -1260,6 +1895,52 @@ func Convert_v1alpha1_ForwardTarget_To_internalversion_ForwardTarget(in *v1alpha
 	return autoConvert_v1alpha1_ForwardTarget_To_internalversion_ForwardTarget(in, out, s)
 }
 
+func autoConvert_internalversion_HTTPGetAction_To_v1alpha1_HTTPGetAction(in *HTTPGetAction, out *configv1alpha1.HTTPGetAction, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Port = in.Port
+	out.Scheme = configv1alpha1.URIScheme(in.Scheme)
+	return nil
+}
+
+// Convert_internalversion_HTTPGetAction_To_v1alpha1_HTTPGetAction is an autogenerated conversion function.
+func Convert_internalversion_HTTPGetAction_To_v1alpha1_HTTPGetAction(in *HTTPGetAction, out *configv1alpha1.HTTPGetAction, s conversion.Scope) error {
+	return autoConvert_internalversion_HTTPGetAction_To_v1alpha1_HTTPGetAction(in, out, s)
+}
+
+func autoConvert_v1alpha1_HTTPGetAction_To_internalversion_HTTPGetAction(in *configv1alpha1.HTTPGetAction, out *HTTPGetAction, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Port = in.Port
+	out.Scheme = URIScheme(in.Scheme)
+	return nil
+}
+
+// Convert_v1alpha1_HTTPGetAction_To_internalversion_HTTPGetAction is an autogenerated conversion function.
+func Convert_v1alpha1_HTTPGetAction_To_internalversion_HTTPGetAction(in *configv1alpha1.HTTPGetAction, out *HTTPGetAction, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HTTPGetAction_To_internalversion_HTTPGetAction(in, out, s)
+}
+
+func autoConvert_internalversion_ImageSimulation_To_v1alpha1_ImageSimulation(in *ImageSimulation, out *configv1alpha1.ImageSimulation, s conversion.Scope) error {
+	out.Images = *(*[]configv1alpha1.SimulatedImage)(unsafe.Pointer(&in.Images))
+	out.Bandwidth = (*resource.Quantity)(unsafe.Pointer(in.Bandwidth))
+	return nil
+}
+
+// Convert_internalversion_ImageSimulation_To_v1alpha1_ImageSimulation is an autogenerated conversion function.
+func Convert_internalversion_ImageSimulation_To_v1alpha1_ImageSimulation(in *ImageSimulation, out *configv1alpha1.ImageSimulation, s conversion.Scope) error {
+	return autoConvert_internalversion_ImageSimulation_To_v1alpha1_ImageSimulation(in, out, s)
+}
+
+func autoConvert_v1alpha1_ImageSimulation_To_internalversion_ImageSimulation(in *configv1alpha1.ImageSimulation, out *ImageSimulation, s conversion.Scope) error {
+	out.Images = *(*[]SimulatedImage)(unsafe.Pointer(&in.Images))
+	out.Bandwidth = (*resource.Quantity)(unsafe.Pointer(in.Bandwidth))
+	return nil
+}
+
+// Convert_v1alpha1_ImageSimulation_To_internalversion_ImageSimulation is an autogenerated conversion function.
+func Convert_v1alpha1_ImageSimulation_To_internalversion_ImageSimulation(in *configv1alpha1.ImageSimulation, out *ImageSimulation, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ImageSimulation_To_internalversion_ImageSimulation(in, out, s)
+}
+
 func autoConvert_internalversion_KwokConfiguration_To_v1alpha1_KwokConfiguration(in *KwokConfiguration, out *configv1alpha1.KwokConfiguration, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_internalversion_KwokConfigurationOptions_To_v1alpha1_KwokConfigurationOptions(&in.Options, &out.Options, s); err != nil {
@@ -1301,6 +1982,7 @@ func autoConvert_internalversion_KwokConfigurationOptions_To_v1alpha1_KwokConfig
 	}
 	out.ManageNodesWithAnnotationSelector = in.ManageNodesWithAnnotationSelector
 	out.ManageNodesWithLabelSelector = in.ManageNodesWithLabelSelector
+	out.ManageNodeRules = *(*[]configv1alpha1.ManageNodeRule)(unsafe.Pointer(&in.ManageNodeRules))
 	out.DisregardStatusWithAnnotationSelector = in.DisregardStatusWithAnnotationSelector
 	out.DisregardStatusWithLabelSelector = in.DisregardStatusWithLabelSelector
 	out.ServerAddress = in.ServerAddress
@@ -1318,8 +2000,42 @@ func autoConvert_internalversion_KwokConfigurationOptions_To_v1alpha1_KwokConfig
 	}
 	out.PodPlayStageParallelism = in.PodPlayStageParallelism
 	out.NodePlayStageParallelism = in.NodePlayStageParallelism
+	out.EnableStageForRefs = *(*[]string)(unsafe.Pointer(&in.EnableStageForRefs))
+	out.CustomPlayStageParallelism = in.CustomPlayStageParallelism
+	out.StageMaxChainDepth = in.StageMaxChainDepth
+	out.TimeScale = in.TimeScale
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableStageNextAnnotations, &out.EnableStageNextAnnotations, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableNodePressure, &out.EnableNodePressure, s); err != nil {
+		return err
+	}
+	out.NodePressureThreshold = in.NodePressureThreshold
 	out.NodeLeaseDurationSeconds = in.NodeLeaseDurationSeconds
 	out.NodeLeaseParallelism = in.NodeLeaseParallelism
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeLeaseOnly, &out.NodeLeaseOnly, s); err != nil {
+		return err
+	}
+	out.StaticPodPath = in.StaticPodPath
+	out.FlapNodesWithLabelSelector = in.FlapNodesWithLabelSelector
+	out.NodeFlappingUpSeconds = in.NodeFlappingUpSeconds
+	out.NodeFlappingDownSeconds = in.NodeFlappingDownSeconds
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableNodeVolumes, &out.EnableNodeVolumes, s); err != nil {
+		return err
+	}
+	out.PodAdmissionResources = *(*[]string)(unsafe.Pointer(&in.PodAdmissionResources))
+	out.Shards = in.Shards
+	out.MaxShardsPerReplica = in.MaxShardsPerReplica
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableNodeLifecycleEvents, &out.EnableNodeLifecycleEvents, s); err != nil {
+		return err
+	}
+	out.ImageSimulation = (*configv1alpha1.ImageSimulation)(unsafe.Pointer(in.ImageSimulation))
+	out.CRISocketDir = in.CRISocketDir
+	out.MetricsMaxSeries = in.MetricsMaxSeries
+	out.MetricsMaxLabelValues = in.MetricsMaxLabelValues
+	out.MetricsScrapeTimeoutSeconds = in.MetricsScrapeTimeoutSeconds
+	out.RandSeed = in.RandSeed
+	out.StatsCacheTTLSeconds = in.StatsCacheTTLSeconds
 	return nil
 }
 
@@ -1342,6 +2058,7 @@ func autoConvert_v1alpha1_KwokConfigurationOptions_To_internalversion_KwokConfig
 	}
 	out.ManageNodesWithAnnotationSelector = in.ManageNodesWithAnnotationSelector
 	out.ManageNodesWithLabelSelector = in.ManageNodesWithLabelSelector
+	out.ManageNodeRules = *(*[]ManageNodeRule)(unsafe.Pointer(&in.ManageNodeRules))
 	out.DisregardStatusWithAnnotationSelector = in.DisregardStatusWithAnnotationSelector
 	out.DisregardStatusWithLabelSelector = in.DisregardStatusWithLabelSelector
 	out.ServerAddress = in.ServerAddress
@@ -1359,8 +2076,42 @@ func autoConvert_v1alpha1_KwokConfigurationOptions_To_internalversion_KwokConfig
 	}
 	out.PodPlayStageParallelism = in.PodPlayStageParallelism
 	out.NodePlayStageParallelism = in.NodePlayStageParallelism
+	out.EnableStageForRefs = *(*[]string)(unsafe.Pointer(&in.EnableStageForRefs))
+	out.CustomPlayStageParallelism = in.CustomPlayStageParallelism
+	out.StageMaxChainDepth = in.StageMaxChainDepth
+	out.TimeScale = in.TimeScale
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableStageNextAnnotations, &out.EnableStageNextAnnotations, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableNodePressure, &out.EnableNodePressure, s); err != nil {
+		return err
+	}
+	out.NodePressureThreshold = in.NodePressureThreshold
 	out.NodeLeaseDurationSeconds = in.NodeLeaseDurationSeconds
 	out.NodeLeaseParallelism = in.NodeLeaseParallelism
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeLeaseOnly, &out.NodeLeaseOnly, s); err != nil {
+		return err
+	}
+	out.StaticPodPath = in.StaticPodPath
+	out.FlapNodesWithLabelSelector = in.FlapNodesWithLabelSelector
+	out.NodeFlappingUpSeconds = in.NodeFlappingUpSeconds
+	out.NodeFlappingDownSeconds = in.NodeFlappingDownSeconds
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableNodeVolumes, &out.EnableNodeVolumes, s); err != nil {
+		return err
+	}
+	out.PodAdmissionResources = *(*[]string)(unsafe.Pointer(&in.PodAdmissionResources))
+	out.Shards = in.Shards
+	out.MaxShardsPerReplica = in.MaxShardsPerReplica
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableNodeLifecycleEvents, &out.EnableNodeLifecycleEvents, s); err != nil {
+		return err
+	}
+	out.ImageSimulation = (*ImageSimulation)(unsafe.Pointer(in.ImageSimulation))
+	out.CRISocketDir = in.CRISocketDir
+	out.MetricsMaxSeries = in.MetricsMaxSeries
+	out.MetricsMaxLabelValues = in.MetricsMaxLabelValues
+	out.MetricsScrapeTimeoutSeconds = in.MetricsScrapeTimeoutSeconds
+	out.RandSeed = in.RandSeed
+	out.StatsCacheTTLSeconds = in.StatsCacheTTLSeconds
 	return nil
 }
 
@@ -1453,6 +2204,7 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	out.Runtimes = *(*[]string)(unsafe.Pointer(&in.Runtimes))
 	out.PrometheusPort = in.PrometheusPort
 	out.JaegerPort = in.JaegerPort
+	out.GrafanaPort = in.GrafanaPort
 	out.JaegerOtlpGrpcPort = in.JaegerOtlpGrpcPort
 	out.KwokVersion = in.KwokVersion
 	out.KubeVersion = in.KubeVersion
@@ -1460,6 +2212,7 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	out.DashboardVersion = in.DashboardVersion
 	out.PrometheusVersion = in.PrometheusVersion
 	out.JaegerVersion = in.JaegerVersion
+	out.GrafanaVersion = in.GrafanaVersion
 	out.DockerComposeVersion = in.DockerComposeVersion
 	out.KindVersion = in.KindVersion
 	if err := v1.Convert_bool_To_Pointer_bool(&in.SecurePort, &out.SecurePort, s); err != nil {
@@ -1468,6 +2221,9 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	if err := v1.Convert_bool_To_Pointer_bool(&in.QuietPull, &out.QuietPull, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Offline, &out.Offline, s); err != nil {
+		return err
+	}
 	out.KubeSchedulerConfig = in.KubeSchedulerConfig
 	if err := v1.Convert_bool_To_Pointer_bool(&in.DisableKubeScheduler, &out.DisableKubeScheduler, s); err != nil {
 		return err
@@ -1475,6 +2231,18 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	if err := v1.Convert_bool_To_Pointer_bool(&in.DisableKubeControllerManager, &out.DisableKubeControllerManager, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableMetricsServer, &out.EnableMetricsServer, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableCustomMetrics, &out.EnableCustomMetrics, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableGrafana, &out.EnableGrafana, s); err != nil {
+		return err
+	}
+	out.KubeApiserverReplicas = in.KubeApiserverReplicas
+	out.KubeControllerManagerReplicas = in.KubeControllerManagerReplicas
+	out.KubeSchedulerReplicas = in.KubeSchedulerReplicas
 	out.EtcdImage = in.EtcdImage
 	out.KubeApiserverImage = in.KubeApiserverImage
 	out.KubeControllerManagerImage = in.KubeControllerManagerImage
@@ -1483,7 +2251,11 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	out.DashboardImage = in.DashboardImage
 	out.PrometheusImage = in.PrometheusImage
 	out.JaegerImage = in.JaegerImage
+	out.GrafanaImage = in.GrafanaImage
 	out.KindNodeImage = in.KindNodeImage
+	out.KindClusterName = in.KindClusterName
+	out.Arch = in.Arch
+	out.BinaryVerifications = *(*[]configv1alpha1.BinaryVerification)(unsafe.Pointer(&in.BinaryVerifications))
 	out.BinSuffix = in.BinSuffix
 	out.KubeApiserverBinary = in.KubeApiserverBinary
 	out.KubeControllerManagerBinary = in.KubeControllerManagerBinary
@@ -1502,6 +2274,7 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	out.KubeFeatureGates = in.KubeFeatureGates
 	out.KubeRuntimeConfig = in.KubeRuntimeConfig
 	out.KubeAuditPolicy = in.KubeAuditPolicy
+	out.KubeAuditWebhook = in.KubeAuditWebhook
 	if err := v1.Convert_bool_To_Pointer_bool(&in.KubeAuthorization, &out.KubeAuthorization, s); err != nil {
 		return err
 	}
@@ -1510,11 +2283,19 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	}
 	out.EtcdPeerPort = in.EtcdPeerPort
 	out.EtcdPort = in.EtcdPort
+	out.EtcdServers = *(*[]string)(unsafe.Pointer(&in.EtcdServers))
+	out.EtcdCaFile = in.EtcdCaFile
+	out.EtcdCertFile = in.EtcdCertFile
+	out.EtcdKeyFile = in.EtcdKeyFile
+	out.EtcdPrefix = in.EtcdPrefix
+	out.EtcdAutoCompactionMode = in.EtcdAutoCompactionMode
+	out.EtcdAutoCompactionRetention = in.EtcdAutoCompactionRetention
 	out.KubeControllerManagerPort = in.KubeControllerManagerPort
 	out.KubeSchedulerPort = in.KubeSchedulerPort
 	out.DashboardPort = in.DashboardPort
 	out.KwokControllerPort = in.KwokControllerPort
 	out.CacheDir = in.CacheDir
+	out.CacheMaxSize = in.CacheMaxSize
 	out.KubeControllerManagerNodeMonitorPeriodMilliseconds = in.KubeControllerManagerNodeMonitorPeriodMilliseconds
 	out.KubeControllerManagerNodeMonitorGracePeriodMilliseconds = in.KubeControllerManagerNodeMonitorGracePeriodMilliseconds
 	out.NodeStatusUpdateFrequencyMilliseconds = in.NodeStatusUpdateFrequencyMilliseconds
@@ -1524,6 +2305,17 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	if err := v1.Convert_bool_To_Pointer_bool(&in.DisableQPSLimits, &out.DisableQPSLimits, s); err != nil {
 		return err
 	}
+	out.Nodes = in.Nodes
+	out.PortRange = in.PortRange
+	out.ComponentsCPULimit = in.ComponentsCPULimit
+	out.ComponentsMemoryLimit = in.ComponentsMemoryLimit
+	out.ComponentsRestartBackoff = in.ComponentsRestartBackoff
+	if err := v1.Convert_bool_To_Pointer_bool(&in.KubeconfigContext, &out.KubeconfigContext, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.EnableIPv6, &out.EnableIPv6, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1539,6 +2331,7 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	out.Runtimes = *(*[]string)(unsafe.Pointer(&in.Runtimes))
 	out.PrometheusPort = in.PrometheusPort
 	out.JaegerPort = in.JaegerPort
+	out.GrafanaPort = in.GrafanaPort
 	out.JaegerOtlpGrpcPort = in.JaegerOtlpGrpcPort
 	out.KwokVersion = in.KwokVersion
 	out.KubeVersion = in.KubeVersion
@@ -1546,6 +2339,7 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	out.DashboardVersion = in.DashboardVersion
 	out.PrometheusVersion = in.PrometheusVersion
 	out.JaegerVersion = in.JaegerVersion
+	out.GrafanaVersion = in.GrafanaVersion
 	out.DockerComposeVersion = in.DockerComposeVersion
 	out.KindVersion = in.KindVersion
 	if err := v1.Convert_Pointer_bool_To_bool(&in.SecurePort, &out.SecurePort, s); err != nil {
@@ -1554,6 +2348,9 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	if err := v1.Convert_Pointer_bool_To_bool(&in.QuietPull, &out.QuietPull, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Offline, &out.Offline, s); err != nil {
+		return err
+	}
 	out.KubeSchedulerConfig = in.KubeSchedulerConfig
 	if err := v1.Convert_Pointer_bool_To_bool(&in.DisableKubeScheduler, &out.DisableKubeScheduler, s); err != nil {
 		return err
@@ -1561,12 +2358,25 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	if err := v1.Convert_Pointer_bool_To_bool(&in.DisableKubeControllerManager, &out.DisableKubeControllerManager, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableMetricsServer, &out.EnableMetricsServer, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableCustomMetrics, &out.EnableCustomMetrics, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableGrafana, &out.EnableGrafana, s); err != nil {
+		return err
+	}
+	out.KubeApiserverReplicas = in.KubeApiserverReplicas
+	out.KubeControllerManagerReplicas = in.KubeControllerManagerReplicas
+	out.KubeSchedulerReplicas = in.KubeSchedulerReplicas
 	// INFO: in.KubeImagePrefix opted out of conversion generation
 	// INFO: in.EtcdImagePrefix opted out of conversion generation
 	// INFO: in.KwokImagePrefix opted out of conversion generation
 	// INFO: in.DashboardImagePrefix opted out of conversion generation
 	// INFO: in.PrometheusImagePrefix opted out of conversion generation
 	// INFO: in.JaegerImagePrefix opted out of conversion generation
+	// INFO: in.GrafanaImagePrefix opted out of conversion generation
 	out.EtcdImage = in.EtcdImage
 	out.KubeApiserverImage = in.KubeApiserverImage
 	out.KubeControllerManagerImage = in.KubeControllerManagerImage
@@ -1575,8 +2385,12 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	out.DashboardImage = in.DashboardImage
 	out.PrometheusImage = in.PrometheusImage
 	out.JaegerImage = in.JaegerImage
+	out.GrafanaImage = in.GrafanaImage
 	// INFO: in.KindNodeImagePrefix opted out of conversion generation
 	out.KindNodeImage = in.KindNodeImage
+	out.KindClusterName = in.KindClusterName
+	out.Arch = in.Arch
+	out.BinaryVerifications = *(*[]BinaryVerification)(unsafe.Pointer(&in.BinaryVerifications))
 	out.BinSuffix = in.BinSuffix
 	// INFO: in.KubeBinaryPrefix opted out of conversion generation
 	out.KubeApiserverBinary = in.KubeApiserverBinary
@@ -1602,6 +2416,7 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	out.KubeFeatureGates = in.KubeFeatureGates
 	out.KubeRuntimeConfig = in.KubeRuntimeConfig
 	out.KubeAuditPolicy = in.KubeAuditPolicy
+	out.KubeAuditWebhook = in.KubeAuditWebhook
 	if err := v1.Convert_Pointer_bool_To_bool(&in.KubeAuthorization, &out.KubeAuthorization, s); err != nil {
 		return err
 	}
@@ -1610,11 +2425,19 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	}
 	out.EtcdPeerPort = in.EtcdPeerPort
 	out.EtcdPort = in.EtcdPort
+	out.EtcdServers = *(*[]string)(unsafe.Pointer(&in.EtcdServers))
+	out.EtcdCaFile = in.EtcdCaFile
+	out.EtcdCertFile = in.EtcdCertFile
+	out.EtcdKeyFile = in.EtcdKeyFile
+	out.EtcdPrefix = in.EtcdPrefix
+	out.EtcdAutoCompactionMode = in.EtcdAutoCompactionMode
+	out.EtcdAutoCompactionRetention = in.EtcdAutoCompactionRetention
 	out.KubeControllerManagerPort = in.KubeControllerManagerPort
 	out.KubeSchedulerPort = in.KubeSchedulerPort
 	out.DashboardPort = in.DashboardPort
 	out.KwokControllerPort = in.KwokControllerPort
 	out.CacheDir = in.CacheDir
+	out.CacheMaxSize = in.CacheMaxSize
 	out.KubeControllerManagerNodeMonitorPeriodMilliseconds = in.KubeControllerManagerNodeMonitorPeriodMilliseconds
 	out.KubeControllerManagerNodeMonitorGracePeriodMilliseconds = in.KubeControllerManagerNodeMonitorGracePeriodMilliseconds
 	out.NodeStatusUpdateFrequencyMilliseconds = in.NodeStatusUpdateFrequencyMilliseconds
@@ -1624,6 +2447,17 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	if err := v1.Convert_Pointer_bool_To_bool(&in.DisableQPSLimits, &out.DisableQPSLimits, s); err != nil {
 		return err
 	}
+	out.Nodes = in.Nodes
+	out.PortRange = in.PortRange
+	out.ComponentsCPULimit = in.ComponentsCPULimit
+	out.ComponentsMemoryLimit = in.ComponentsMemoryLimit
+	out.ComponentsRestartBackoff = in.ComponentsRestartBackoff
+	if err := v1.Convert_Pointer_bool_To_bool(&in.KubeconfigContext, &out.KubeconfigContext, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.EnableIPv6, &out.EnableIPv6, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1682,9 +2516,13 @@ func autoConvert_internalversion_Log_To_v1alpha1_Log(in *Log, out *v1alpha1.Log,
 	if err := v1.Convert_string_To_Pointer_string(&in.LogsFile, &out.LogsFile, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_string_To_Pointer_string(&in.PreviousLogsFile, &out.PreviousLogsFile, s); err != nil {
+		return err
+	}
 	if err := v1.Convert_bool_To_Pointer_bool(&in.Follow, &out.Follow, s); err != nil {
 		return err
 	}
+	out.Generator = (*v1alpha1.LogGenerator)(unsafe.Pointer(in.Generator))
 	return nil
 }
 
@@ -1698,9 +2536,13 @@ func autoConvert_v1alpha1_Log_To_internalversion_Log(in *v1alpha1.Log, out *Log,
 	if err := v1.Convert_Pointer_string_To_string(&in.LogsFile, &out.LogsFile, s); err != nil {
 		return err
 	}
+	if err := v1.Convert_Pointer_string_To_string(&in.PreviousLogsFile, &out.PreviousLogsFile, s); err != nil {
+		return err
+	}
 	if err := v1.Convert_Pointer_bool_To_bool(&in.Follow, &out.Follow, s); err != nil {
 		return err
 	}
+	out.Generator = (*LogGenerator)(unsafe.Pointer(in.Generator))
 	return nil
 }
 
@@ -1709,6 +2551,80 @@ func Convert_v1alpha1_Log_To_internalversion_Log(in *v1alpha1.Log, out *Log, s c
 	return autoConvert_v1alpha1_Log_To_internalversion_Log(in, out, s)
 }
 
+func autoConvert_internalversion_LogGenerator_To_v1alpha1_LogGenerator(in *LogGenerator, out *v1alpha1.LogGenerator, s conversion.Scope) error {
+	out.Template = in.Template
+	out.Fields = *(*[]v1alpha1.LogGeneratorField)(unsafe.Pointer(&in.Fields))
+	out.Levels = *(*[]v1alpha1.LogGeneratorLevel)(unsafe.Pointer(&in.Levels))
+	out.Format = v1alpha1.LogGeneratorFormat(in.Format)
+	out.IntervalMilliseconds = in.IntervalMilliseconds
+	out.HistoryLines = in.HistoryLines
+	return nil
+}
+
+// Convert_internalversion_LogGenerator_To_v1alpha1_LogGenerator is an autogenerated conversion function.
+func Convert_internalversion_LogGenerator_To_v1alpha1_LogGenerator(in *LogGenerator, out *v1alpha1.LogGenerator, s conversion.Scope) error {
+	return autoConvert_internalversion_LogGenerator_To_v1alpha1_LogGenerator(in, out, s)
+}
+
+func autoConvert_v1alpha1_LogGenerator_To_internalversion_LogGenerator(in *v1alpha1.LogGenerator, out *LogGenerator, s conversion.Scope) error {
+	out.Template = in.Template
+	out.Fields = *(*[]LogGeneratorField)(unsafe.Pointer(&in.Fields))
+	out.Levels = *(*[]LogGeneratorLevel)(unsafe.Pointer(&in.Levels))
+	out.Format = LogGeneratorFormat(in.Format)
+	out.IntervalMilliseconds = in.IntervalMilliseconds
+	out.HistoryLines = in.HistoryLines
+	return nil
+}
+
+// Convert_v1alpha1_LogGenerator_To_internalversion_LogGenerator is an autogenerated conversion function.
+func Convert_v1alpha1_LogGenerator_To_internalversion_LogGenerator(in *v1alpha1.LogGenerator, out *LogGenerator, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LogGenerator_To_internalversion_LogGenerator(in, out, s)
+}
+
+func autoConvert_internalversion_LogGeneratorField_To_v1alpha1_LogGeneratorField(in *LogGeneratorField, out *v1alpha1.LogGeneratorField, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_internalversion_LogGeneratorField_To_v1alpha1_LogGeneratorField is an autogenerated conversion function.
+func Convert_internalversion_LogGeneratorField_To_v1alpha1_LogGeneratorField(in *LogGeneratorField, out *v1alpha1.LogGeneratorField, s conversion.Scope) error {
+	return autoConvert_internalversion_LogGeneratorField_To_v1alpha1_LogGeneratorField(in, out, s)
+}
+
+func autoConvert_v1alpha1_LogGeneratorField_To_internalversion_LogGeneratorField(in *v1alpha1.LogGeneratorField, out *LogGeneratorField, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_v1alpha1_LogGeneratorField_To_internalversion_LogGeneratorField is an autogenerated conversion function.
+func Convert_v1alpha1_LogGeneratorField_To_internalversion_LogGeneratorField(in *v1alpha1.LogGeneratorField, out *LogGeneratorField, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LogGeneratorField_To_internalversion_LogGeneratorField(in, out, s)
+}
+
+func autoConvert_internalversion_LogGeneratorLevel_To_v1alpha1_LogGeneratorLevel(in *LogGeneratorLevel, out *v1alpha1.LogGeneratorLevel, s conversion.Scope) error {
+	out.Level = in.Level
+	out.Weight = in.Weight
+	return nil
+}
+
+// Convert_internalversion_LogGeneratorLevel_To_v1alpha1_LogGeneratorLevel is an autogenerated conversion function.
+func Convert_internalversion_LogGeneratorLevel_To_v1alpha1_LogGeneratorLevel(in *LogGeneratorLevel, out *v1alpha1.LogGeneratorLevel, s conversion.Scope) error {
+	return autoConvert_internalversion_LogGeneratorLevel_To_v1alpha1_LogGeneratorLevel(in, out, s)
+}
+
+func autoConvert_v1alpha1_LogGeneratorLevel_To_internalversion_LogGeneratorLevel(in *v1alpha1.LogGeneratorLevel, out *LogGeneratorLevel, s conversion.Scope) error {
+	out.Level = in.Level
+	out.Weight = in.Weight
+	return nil
+}
+
+// Convert_v1alpha1_LogGeneratorLevel_To_internalversion_LogGeneratorLevel is an autogenerated conversion function.
+func Convert_v1alpha1_LogGeneratorLevel_To_internalversion_LogGeneratorLevel(in *v1alpha1.LogGeneratorLevel, out *LogGeneratorLevel, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LogGeneratorLevel_To_internalversion_LogGeneratorLevel(in, out, s)
+}
+
 func autoConvert_internalversion_Logs_To_v1alpha1_Logs(in *Logs, out *v1alpha1.Logs, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_internalversion_LogsSpec_To_v1alpha1_LogsSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1777,6 +2693,32 @@ func Convert_v1alpha1_LogsSpec_To_internalversion_LogsSpec(in *v1alpha1.LogsSpec
 	return autoConvert_v1alpha1_LogsSpec_To_internalversion_LogsSpec(in, out, s)
 }
 
+func autoConvert_internalversion_ManageNodeRule_To_v1alpha1_ManageNodeRule(in *ManageNodeRule, out *configv1alpha1.ManageNodeRule, s conversion.Scope) error {
+	out.Names = *(*[]string)(unsafe.Pointer(&in.Names))
+	out.LabelSelector = in.LabelSelector
+	out.AnnotationSelector = in.AnnotationSelector
+	out.Exclude = in.Exclude
+	return nil
+}
+
+// Convert_internalversion_ManageNodeRule_To_v1alpha1_ManageNodeRule is an autogenerated conversion function.
+func Convert_internalversion_ManageNodeRule_To_v1alpha1_ManageNodeRule(in *ManageNodeRule, out *configv1alpha1.ManageNodeRule, s conversion.Scope) error {
+	return autoConvert_internalversion_ManageNodeRule_To_v1alpha1_ManageNodeRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_ManageNodeRule_To_internalversion_ManageNodeRule(in *configv1alpha1.ManageNodeRule, out *ManageNodeRule, s conversion.Scope) error {
+	out.Names = *(*[]string)(unsafe.Pointer(&in.Names))
+	out.LabelSelector = in.LabelSelector
+	out.AnnotationSelector = in.AnnotationSelector
+	out.Exclude = in.Exclude
+	return nil
+}
+
+// Convert_v1alpha1_ManageNodeRule_To_internalversion_ManageNodeRule is an autogenerated conversion function.
+func Convert_v1alpha1_ManageNodeRule_To_internalversion_ManageNodeRule(in *configv1alpha1.ManageNodeRule, out *ManageNodeRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ManageNodeRule_To_internalversion_ManageNodeRule(in, out, s)
+}
+
 func autoConvert_internalversion_Metric_To_v1alpha1_Metric(in *Metric, out *v1alpha1.Metric, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_internalversion_MetricSpec_To_v1alpha1_MetricSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1829,6 +2771,34 @@ func Convert_v1alpha1_MetricBucket_To_internalversion_MetricBucket(in *v1alpha1.
 	return autoConvert_v1alpha1_MetricBucket_To_internalversion_MetricBucket(in, out, s)
 }
 
+func autoConvert_internalversion_MetricBucketsFrom_To_v1alpha1_MetricBucketsFrom(in *MetricBucketsFrom, out *v1alpha1.MetricBucketsFrom, s conversion.Scope) error {
+	out.Type = v1alpha1.MetricBucketsType(in.Type)
+	out.Start = in.Start
+	out.Factor = in.Factor
+	out.Count = in.Count
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_internalversion_MetricBucketsFrom_To_v1alpha1_MetricBucketsFrom is an autogenerated conversion function.
+func Convert_internalversion_MetricBucketsFrom_To_v1alpha1_MetricBucketsFrom(in *MetricBucketsFrom, out *v1alpha1.MetricBucketsFrom, s conversion.Scope) error {
+	return autoConvert_internalversion_MetricBucketsFrom_To_v1alpha1_MetricBucketsFrom(in, out, s)
+}
+
+func autoConvert_v1alpha1_MetricBucketsFrom_To_internalversion_MetricBucketsFrom(in *v1alpha1.MetricBucketsFrom, out *MetricBucketsFrom, s conversion.Scope) error {
+	out.Type = MetricBucketsType(in.Type)
+	out.Start = in.Start
+	out.Factor = in.Factor
+	out.Count = in.Count
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1alpha1_MetricBucketsFrom_To_internalversion_MetricBucketsFrom is an autogenerated conversion function.
+func Convert_v1alpha1_MetricBucketsFrom_To_internalversion_MetricBucketsFrom(in *v1alpha1.MetricBucketsFrom, out *MetricBucketsFrom, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MetricBucketsFrom_To_internalversion_MetricBucketsFrom(in, out, s)
+}
+
 func autoConvert_internalversion_MetricConfig_To_v1alpha1_MetricConfig(in *MetricConfig, out *v1alpha1.MetricConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Help = in.Help
@@ -1836,7 +2806,12 @@ func autoConvert_internalversion_MetricConfig_To_v1alpha1_MetricConfig(in *Metri
 	out.Labels = *(*[]v1alpha1.MetricLabel)(unsafe.Pointer(&in.Labels))
 	out.Value = in.Value
 	out.Buckets = *(*[]v1alpha1.MetricBucket)(unsafe.Pointer(&in.Buckets))
+	out.BucketsFrom = (*v1alpha1.MetricBucketsFrom)(unsafe.Pointer(in.BucketsFrom))
+	out.Quantiles = *(*[]v1alpha1.MetricQuantile)(unsafe.Pointer(&in.Quantiles))
+	out.SampleCount = in.SampleCount
+	out.SampleSum = in.SampleSum
 	out.Dimension = v1alpha1.Dimension(in.Dimension)
+	out.Device = in.Device
 	return nil
 }
 
@@ -1852,7 +2827,12 @@ func autoConvert_v1alpha1_MetricConfig_To_internalversion_MetricConfig(in *v1alp
 	out.Labels = *(*[]MetricLabel)(unsafe.Pointer(&in.Labels))
 	out.Value = in.Value
 	out.Buckets = *(*[]MetricBucket)(unsafe.Pointer(&in.Buckets))
+	out.BucketsFrom = (*MetricBucketsFrom)(unsafe.Pointer(in.BucketsFrom))
+	out.Quantiles = *(*[]MetricQuantile)(unsafe.Pointer(&in.Quantiles))
+	out.SampleCount = in.SampleCount
+	out.SampleSum = in.SampleSum
 	out.Dimension = Dimension(in.Dimension)
+	out.Device = in.Device
 	return nil
 }
 
@@ -1883,6 +2863,28 @@ func Convert_v1alpha1_MetricLabel_To_internalversion_MetricLabel(in *v1alpha1.Me
 	return autoConvert_v1alpha1_MetricLabel_To_internalversion_MetricLabel(in, out, s)
 }
 
+func autoConvert_internalversion_MetricQuantile_To_v1alpha1_MetricQuantile(in *MetricQuantile, out *v1alpha1.MetricQuantile, s conversion.Scope) error {
+	out.Quantile = in.Quantile
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_internalversion_MetricQuantile_To_v1alpha1_MetricQuantile is an autogenerated conversion function.
+func Convert_internalversion_MetricQuantile_To_v1alpha1_MetricQuantile(in *MetricQuantile, out *v1alpha1.MetricQuantile, s conversion.Scope) error {
+	return autoConvert_internalversion_MetricQuantile_To_v1alpha1_MetricQuantile(in, out, s)
+}
+
+func autoConvert_v1alpha1_MetricQuantile_To_internalversion_MetricQuantile(in *v1alpha1.MetricQuantile, out *MetricQuantile, s conversion.Scope) error {
+	out.Quantile = in.Quantile
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1alpha1_MetricQuantile_To_internalversion_MetricQuantile is an autogenerated conversion function.
+func Convert_v1alpha1_MetricQuantile_To_internalversion_MetricQuantile(in *v1alpha1.MetricQuantile, out *MetricQuantile, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MetricQuantile_To_internalversion_MetricQuantile(in, out, s)
+}
+
 func autoConvert_internalversion_MetricSpec_To_v1alpha1_MetricSpec(in *MetricSpec, out *v1alpha1.MetricSpec, s conversion.Scope) error {
 	out.Path = in.Path
 	out.Metrics = *(*[]v1alpha1.MetricConfig)(unsafe.Pointer(&in.Metrics))
@@ -1908,6 +2910,8 @@ func Convert_v1alpha1_MetricSpec_To_internalversion_MetricSpec(in *v1alpha1.Metr
 func autoConvert_internalversion_ObjectSelector_To_v1alpha1_ObjectSelector(in *ObjectSelector, out *v1alpha1.ObjectSelector, s conversion.Scope) error {
 	out.MatchNamespaces = *(*[]string)(unsafe.Pointer(&in.MatchNamespaces))
 	out.MatchNames = *(*[]string)(unsafe.Pointer(&in.MatchNames))
+	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
+	out.MatchNamespaceLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchNamespaceLabels))
 	return nil
 }
 
@@ -1919,6 +2923,8 @@ func Convert_internalversion_ObjectSelector_To_v1alpha1_ObjectSelector(in *Objec
 func autoConvert_v1alpha1_ObjectSelector_To_internalversion_ObjectSelector(in *v1alpha1.ObjectSelector, out *ObjectSelector, s conversion.Scope) error {
 	out.MatchNamespaces = *(*[]string)(unsafe.Pointer(&in.MatchNamespaces))
 	out.MatchNames = *(*[]string)(unsafe.Pointer(&in.MatchNames))
+	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
+	out.MatchNamespaceLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchNamespaceLabels))
 	return nil
 }
 
@@ -2001,6 +3007,324 @@ func Convert_v1alpha1_PortForwardSpec_To_internalversion_PortForwardSpec(in *v1a
 	return autoConvert_v1alpha1_PortForwardSpec_To_internalversion_PortForwardSpec(in, out, s)
 }
 
+func autoConvert_internalversion_Probe_To_v1alpha1_Probe(in *Probe, out *configv1alpha1.Probe, s conversion.Scope) error {
+	out.HTTPGet = (*configv1alpha1.HTTPGetAction)(unsafe.Pointer(in.HTTPGet))
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.PeriodSeconds = in.PeriodSeconds
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_internalversion_Probe_To_v1alpha1_Probe is an autogenerated conversion function.
+func Convert_internalversion_Probe_To_v1alpha1_Probe(in *Probe, out *configv1alpha1.Probe, s conversion.Scope) error {
+	return autoConvert_internalversion_Probe_To_v1alpha1_Probe(in, out, s)
+}
+
+func autoConvert_v1alpha1_Probe_To_internalversion_Probe(in *configv1alpha1.Probe, out *Probe, s conversion.Scope) error {
+	out.HTTPGet = (*HTTPGetAction)(unsafe.Pointer(in.HTTPGet))
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.PeriodSeconds = in.PeriodSeconds
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_v1alpha1_Probe_To_internalversion_Probe is an autogenerated conversion function.
+func Convert_v1alpha1_Probe_To_internalversion_Probe(in *configv1alpha1.Probe, out *Probe, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Probe_To_internalversion_Probe(in, out, s)
+}
+
+func autoConvert_internalversion_ResourceUsage_To_v1alpha1_ResourceUsage(in *ResourceUsage, out *v1alpha1.ResourceUsage, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_internalversion_ResourceUsageSpec_To_v1alpha1_ResourceUsageSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_internalversion_ResourceUsage_To_v1alpha1_ResourceUsage is an autogenerated conversion function.
+func Convert_internalversion_ResourceUsage_To_v1alpha1_ResourceUsage(in *ResourceUsage, out *v1alpha1.ResourceUsage, s conversion.Scope) error {
+	return autoConvert_internalversion_ResourceUsage_To_v1alpha1_ResourceUsage(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceUsage_To_internalversion_ResourceUsage(in *v1alpha1.ResourceUsage, out *ResourceUsage, s conversion.Scope) error {
+	// INFO: in.TypeMeta opted out of conversion generation
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_ResourceUsageSpec_To_internalversion_ResourceUsageSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	// INFO: in.Status opted out of conversion generation
+	return nil
+}
+
+// Convert_v1alpha1_ResourceUsage_To_internalversion_ResourceUsage is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceUsage_To_internalversion_ResourceUsage(in *v1alpha1.ResourceUsage, out *ResourceUsage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceUsage_To_internalversion_ResourceUsage(in, out, s)
+}
+
+func autoConvert_internalversion_ResourceUsageContainer_To_v1alpha1_ResourceUsageContainer(in *ResourceUsageContainer, out *v1alpha1.ResourceUsageContainer, s conversion.Scope) error {
+	out.Containers = *(*[]string)(unsafe.Pointer(&in.Containers))
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = make(map[string]v1alpha1.ResourceUsageValue, len(*in))
+		for key, val := range *in {
+			newVal := new(v1alpha1.ResourceUsageValue)
+			if err := Convert_internalversion_ResourceUsageValue_To_v1alpha1_ResourceUsageValue(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Usage = nil
+	}
+	return nil
+}
+
+// Convert_internalversion_ResourceUsageContainer_To_v1alpha1_ResourceUsageContainer is an autogenerated conversion function.
+func Convert_internalversion_ResourceUsageContainer_To_v1alpha1_ResourceUsageContainer(in *ResourceUsageContainer, out *v1alpha1.ResourceUsageContainer, s conversion.Scope) error {
+	return autoConvert_internalversion_ResourceUsageContainer_To_v1alpha1_ResourceUsageContainer(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceUsageContainer_To_internalversion_ResourceUsageContainer(in *v1alpha1.ResourceUsageContainer, out *ResourceUsageContainer, s conversion.Scope) error {
+	out.Containers = *(*[]string)(unsafe.Pointer(&in.Containers))
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = make(map[string]ResourceUsageValue, len(*in))
+		for key, val := range *in {
+			newVal := new(ResourceUsageValue)
+			if err := Convert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Usage = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ResourceUsageContainer_To_internalversion_ResourceUsageContainer is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceUsageContainer_To_internalversion_ResourceUsageContainer(in *v1alpha1.ResourceUsageContainer, out *ResourceUsageContainer, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceUsageContainer_To_internalversion_ResourceUsageContainer(in, out, s)
+}
+
+func autoConvert_internalversion_ResourceUsageRamp_To_v1alpha1_ResourceUsageRamp(in *ResourceUsageRamp, out *v1alpha1.ResourceUsageRamp, s conversion.Scope) error {
+	out.From = in.From
+	out.To = in.To
+	out.DurationMilliseconds = in.DurationMilliseconds
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Repeat, &out.Repeat, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_internalversion_ResourceUsageRamp_To_v1alpha1_ResourceUsageRamp is an autogenerated conversion function.
+func Convert_internalversion_ResourceUsageRamp_To_v1alpha1_ResourceUsageRamp(in *ResourceUsageRamp, out *v1alpha1.ResourceUsageRamp, s conversion.Scope) error {
+	return autoConvert_internalversion_ResourceUsageRamp_To_v1alpha1_ResourceUsageRamp(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceUsageRamp_To_internalversion_ResourceUsageRamp(in *v1alpha1.ResourceUsageRamp, out *ResourceUsageRamp, s conversion.Scope) error {
+	out.From = in.From
+	out.To = in.To
+	out.DurationMilliseconds = in.DurationMilliseconds
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Repeat, &out.Repeat, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ResourceUsageRamp_To_internalversion_ResourceUsageRamp is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceUsageRamp_To_internalversion_ResourceUsageRamp(in *v1alpha1.ResourceUsageRamp, out *ResourceUsageRamp, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceUsageRamp_To_internalversion_ResourceUsageRamp(in, out, s)
+}
+
+func autoConvert_internalversion_ResourceUsageSinusoidal_To_v1alpha1_ResourceUsageSinusoidal(in *ResourceUsageSinusoidal, out *v1alpha1.ResourceUsageSinusoidal, s conversion.Scope) error {
+	out.Base = in.Base
+	out.Amplitude = in.Amplitude
+	out.PeriodMilliseconds = in.PeriodMilliseconds
+	if err := v1.Convert_int64_To_Pointer_int64(&in.PhaseMilliseconds, &out.PhaseMilliseconds, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_internalversion_ResourceUsageSinusoidal_To_v1alpha1_ResourceUsageSinusoidal is an autogenerated conversion function.
+func Convert_internalversion_ResourceUsageSinusoidal_To_v1alpha1_ResourceUsageSinusoidal(in *ResourceUsageSinusoidal, out *v1alpha1.ResourceUsageSinusoidal, s conversion.Scope) error {
+	return autoConvert_internalversion_ResourceUsageSinusoidal_To_v1alpha1_ResourceUsageSinusoidal(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceUsageSinusoidal_To_internalversion_ResourceUsageSinusoidal(in *v1alpha1.ResourceUsageSinusoidal, out *ResourceUsageSinusoidal, s conversion.Scope) error {
+	out.Base = in.Base
+	out.Amplitude = in.Amplitude
+	out.PeriodMilliseconds = in.PeriodMilliseconds
+	if err := v1.Convert_Pointer_int64_To_int64(&in.PhaseMilliseconds, &out.PhaseMilliseconds, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ResourceUsageSinusoidal_To_internalversion_ResourceUsageSinusoidal is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceUsageSinusoidal_To_internalversion_ResourceUsageSinusoidal(in *v1alpha1.ResourceUsageSinusoidal, out *ResourceUsageSinusoidal, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceUsageSinusoidal_To_internalversion_ResourceUsageSinusoidal(in, out, s)
+}
+
+func autoConvert_internalversion_ResourceUsageSpec_To_v1alpha1_ResourceUsageSpec(in *ResourceUsageSpec, out *v1alpha1.ResourceUsageSpec, s conversion.Scope) error {
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]v1alpha1.ResourceUsageContainer, len(*in))
+		for i := range *in {
+			if err := Convert_internalversion_ResourceUsageContainer_To_v1alpha1_ResourceUsageContainer(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Usages = nil
+	}
+	return nil
+}
+
+// Convert_internalversion_ResourceUsageSpec_To_v1alpha1_ResourceUsageSpec is an autogenerated conversion function.
+func Convert_internalversion_ResourceUsageSpec_To_v1alpha1_ResourceUsageSpec(in *ResourceUsageSpec, out *v1alpha1.ResourceUsageSpec, s conversion.Scope) error {
+	return autoConvert_internalversion_ResourceUsageSpec_To_v1alpha1_ResourceUsageSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceUsageSpec_To_internalversion_ResourceUsageSpec(in *v1alpha1.ResourceUsageSpec, out *ResourceUsageSpec, s conversion.Scope) error {
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]ResourceUsageContainer, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_ResourceUsageContainer_To_internalversion_ResourceUsageContainer(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Usages = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ResourceUsageSpec_To_internalversion_ResourceUsageSpec is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceUsageSpec_To_internalversion_ResourceUsageSpec(in *v1alpha1.ResourceUsageSpec, out *ResourceUsageSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceUsageSpec_To_internalversion_ResourceUsageSpec(in, out, s)
+}
+
+func autoConvert_internalversion_ResourceUsageSpike_To_v1alpha1_ResourceUsageSpike(in *ResourceUsageSpike, out *v1alpha1.ResourceUsageSpike, s conversion.Scope) error {
+	out.Base = in.Base
+	out.Peak = in.Peak
+	if err := v1.Convert_int64_To_Pointer_int64(&in.AfterMilliseconds, &out.AfterMilliseconds, s); err != nil {
+		return err
+	}
+	out.DurationMilliseconds = in.DurationMilliseconds
+	if err := v1.Convert_int64_To_Pointer_int64(&in.IntervalMilliseconds, &out.IntervalMilliseconds, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_internalversion_ResourceUsageSpike_To_v1alpha1_ResourceUsageSpike is an autogenerated conversion function.
+func Convert_internalversion_ResourceUsageSpike_To_v1alpha1_ResourceUsageSpike(in *ResourceUsageSpike, out *v1alpha1.ResourceUsageSpike, s conversion.Scope) error {
+	return autoConvert_internalversion_ResourceUsageSpike_To_v1alpha1_ResourceUsageSpike(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceUsageSpike_To_internalversion_ResourceUsageSpike(in *v1alpha1.ResourceUsageSpike, out *ResourceUsageSpike, s conversion.Scope) error {
+	out.Base = in.Base
+	out.Peak = in.Peak
+	if err := v1.Convert_Pointer_int64_To_int64(&in.AfterMilliseconds, &out.AfterMilliseconds, s); err != nil {
+		return err
+	}
+	out.DurationMilliseconds = in.DurationMilliseconds
+	if err := v1.Convert_Pointer_int64_To_int64(&in.IntervalMilliseconds, &out.IntervalMilliseconds, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ResourceUsageSpike_To_internalversion_ResourceUsageSpike is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceUsageSpike_To_internalversion_ResourceUsageSpike(in *v1alpha1.ResourceUsageSpike, out *ResourceUsageSpike, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceUsageSpike_To_internalversion_ResourceUsageSpike(in, out, s)
+}
+
+func autoConvert_internalversion_ResourceUsageValue_To_v1alpha1_ResourceUsageValue(in *ResourceUsageValue, out *v1alpha1.ResourceUsageValue, s conversion.Scope) error {
+	out.Value = (*resource.Quantity)(unsafe.Pointer(in.Value))
+	if err := v1.Convert_string_To_Pointer_string(&in.Expression, &out.Expression, s); err != nil {
+		return err
+	}
+	if in.Sinusoidal != nil {
+		in, out := &in.Sinusoidal, &out.Sinusoidal
+		*out = new(v1alpha1.ResourceUsageSinusoidal)
+		if err := Convert_internalversion_ResourceUsageSinusoidal_To_v1alpha1_ResourceUsageSinusoidal(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Sinusoidal = nil
+	}
+	if in.Ramp != nil {
+		in, out := &in.Ramp, &out.Ramp
+		*out = new(v1alpha1.ResourceUsageRamp)
+		if err := Convert_internalversion_ResourceUsageRamp_To_v1alpha1_ResourceUsageRamp(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Ramp = nil
+	}
+	if in.Spike != nil {
+		in, out := &in.Spike, &out.Spike
+		*out = new(v1alpha1.ResourceUsageSpike)
+		if err := Convert_internalversion_ResourceUsageSpike_To_v1alpha1_ResourceUsageSpike(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Spike = nil
+	}
+	return nil
+}
+
+// Convert_internalversion_ResourceUsageValue_To_v1alpha1_ResourceUsageValue is an autogenerated conversion function.
+func Convert_internalversion_ResourceUsageValue_To_v1alpha1_ResourceUsageValue(in *ResourceUsageValue, out *v1alpha1.ResourceUsageValue, s conversion.Scope) error {
+	return autoConvert_internalversion_ResourceUsageValue_To_v1alpha1_ResourceUsageValue(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue(in *v1alpha1.ResourceUsageValue, out *ResourceUsageValue, s conversion.Scope) error {
+	out.Value = (*resource.Quantity)(unsafe.Pointer(in.Value))
+	if err := v1.Convert_Pointer_string_To_string(&in.Expression, &out.Expression, s); err != nil {
+		return err
+	}
+	if in.Sinusoidal != nil {
+		in, out := &in.Sinusoidal, &out.Sinusoidal
+		*out = new(ResourceUsageSinusoidal)
+		if err := Convert_v1alpha1_ResourceUsageSinusoidal_To_internalversion_ResourceUsageSinusoidal(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Sinusoidal = nil
+	}
+	if in.Ramp != nil {
+		in, out := &in.Ramp, &out.Ramp
+		*out = new(ResourceUsageRamp)
+		if err := Convert_v1alpha1_ResourceUsageRamp_To_internalversion_ResourceUsageRamp(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Ramp = nil
+	}
+	if in.Spike != nil {
+		in, out := &in.Spike, &out.Spike
+		*out = new(ResourceUsageSpike)
+		if err := Convert_v1alpha1_ResourceUsageSpike_To_internalversion_ResourceUsageSpike(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Spike = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue(in *v1alpha1.ResourceUsageValue, out *ResourceUsageValue, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue(in, out, s)
+}
+
 func autoConvert_internalversion_SecurityContext_To_v1alpha1_SecurityContext(in *SecurityContext, out *v1alpha1.SecurityContext, s conversion.Scope) error {
 	out.RunAsUser = (*int64)(unsafe.Pointer(in.RunAsUser))
 	out.RunAsGroup = (*int64)(unsafe.Pointer(in.RunAsGroup))
@@ -2047,6 +3371,28 @@ func Convert_v1alpha1_SelectorRequirement_To_internalversion_SelectorRequirement
 	return autoConvert_v1alpha1_SelectorRequirement_To_internalversion_SelectorRequirement(in, out, s)
 }
 
+func autoConvert_internalversion_SimulatedImage_To_v1alpha1_SimulatedImage(in *SimulatedImage, out *configv1alpha1.SimulatedImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_internalversion_SimulatedImage_To_v1alpha1_SimulatedImage is an autogenerated conversion function.
+func Convert_internalversion_SimulatedImage_To_v1alpha1_SimulatedImage(in *SimulatedImage, out *configv1alpha1.SimulatedImage, s conversion.Scope) error {
+	return autoConvert_internalversion_SimulatedImage_To_v1alpha1_SimulatedImage(in, out, s)
+}
+
+func autoConvert_v1alpha1_SimulatedImage_To_internalversion_SimulatedImage(in *configv1alpha1.SimulatedImage, out *SimulatedImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_v1alpha1_SimulatedImage_To_internalversion_SimulatedImage is an autogenerated conversion function.
+func Convert_v1alpha1_SimulatedImage_To_internalversion_SimulatedImage(in *configv1alpha1.SimulatedImage, out *SimulatedImage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SimulatedImage_To_internalversion_SimulatedImage(in, out, s)
+}
+
 func autoConvert_internalversion_Stage_To_v1alpha1_Stage(in *Stage, out *v1alpha1.Stage, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_internalversion_StageSpec_To_v1alpha1_StageSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -2080,6 +3426,8 @@ func autoConvert_internalversion_StageDelay_To_v1alpha1_StageDelay(in *StageDela
 	out.DurationFrom = (*v1alpha1.ExpressionFromSource)(unsafe.Pointer(in.DurationFrom))
 	out.JitterDurationMilliseconds = (*int64)(unsafe.Pointer(in.JitterDurationMilliseconds))
 	out.JitterDurationFrom = (*v1alpha1.ExpressionFromSource)(unsafe.Pointer(in.JitterDurationFrom))
+	out.Distribution = (*v1alpha1.StageDelayDistribution)(unsafe.Pointer(in.Distribution))
+	out.Schedule = (*v1alpha1.StageSchedule)(unsafe.Pointer(in.Schedule))
 	return nil
 }
 
@@ -2093,6 +3441,8 @@ func autoConvert_v1alpha1_StageDelay_To_internalversion_StageDelay(in *v1alpha1.
 	out.DurationFrom = (*ExpressionFromSource)(unsafe.Pointer(in.DurationFrom))
 	out.JitterDurationMilliseconds = (*int64)(unsafe.Pointer(in.JitterDurationMilliseconds))
 	out.JitterDurationFrom = (*ExpressionFromSource)(unsafe.Pointer(in.JitterDurationFrom))
+	out.Distribution = (*StageDelayDistribution)(unsafe.Pointer(in.Distribution))
+	out.Schedule = (*StageSchedule)(unsafe.Pointer(in.Schedule))
 	return nil
 }
 
@@ -2101,6 +3451,34 @@ func Convert_v1alpha1_StageDelay_To_internalversion_StageDelay(in *v1alpha1.Stag
 	return autoConvert_v1alpha1_StageDelay_To_internalversion_StageDelay(in, out, s)
 }
 
+func autoConvert_internalversion_StageDelayDistribution_To_v1alpha1_StageDelayDistribution(in *StageDelayDistribution, out *v1alpha1.StageDelayDistribution, s conversion.Scope) error {
+	out.Type = v1alpha1.StageDelayDistributionType(in.Type)
+	out.MinMilliseconds = (*int64)(unsafe.Pointer(in.MinMilliseconds))
+	out.MaxMilliseconds = (*int64)(unsafe.Pointer(in.MaxMilliseconds))
+	out.MeanMilliseconds = (*int64)(unsafe.Pointer(in.MeanMilliseconds))
+	out.StdDevMilliseconds = (*int64)(unsafe.Pointer(in.StdDevMilliseconds))
+	return nil
+}
+
+// Convert_internalversion_StageDelayDistribution_To_v1alpha1_StageDelayDistribution is an autogenerated conversion function.
+func Convert_internalversion_StageDelayDistribution_To_v1alpha1_StageDelayDistribution(in *StageDelayDistribution, out *v1alpha1.StageDelayDistribution, s conversion.Scope) error {
+	return autoConvert_internalversion_StageDelayDistribution_To_v1alpha1_StageDelayDistribution(in, out, s)
+}
+
+func autoConvert_v1alpha1_StageDelayDistribution_To_internalversion_StageDelayDistribution(in *v1alpha1.StageDelayDistribution, out *StageDelayDistribution, s conversion.Scope) error {
+	out.Type = StageDelayDistributionType(in.Type)
+	out.MinMilliseconds = (*int64)(unsafe.Pointer(in.MinMilliseconds))
+	out.MaxMilliseconds = (*int64)(unsafe.Pointer(in.MaxMilliseconds))
+	out.MeanMilliseconds = (*int64)(unsafe.Pointer(in.MeanMilliseconds))
+	out.StdDevMilliseconds = (*int64)(unsafe.Pointer(in.StdDevMilliseconds))
+	return nil
+}
+
+// Convert_v1alpha1_StageDelayDistribution_To_internalversion_StageDelayDistribution is an autogenerated conversion function.
+func Convert_v1alpha1_StageDelayDistribution_To_internalversion_StageDelayDistribution(in *v1alpha1.StageDelayDistribution, out *StageDelayDistribution, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StageDelayDistribution_To_internalversion_StageDelayDistribution(in, out, s)
+}
+
 func autoConvert_internalversion_StageEvent_To_v1alpha1_StageEvent(in *StageEvent, out *v1alpha1.StageEvent, s conversion.Scope) error {
 	out.Type = in.Type
 	out.Reason = in.Reason
@@ -2129,6 +3507,8 @@ func autoConvert_internalversion_StageFinalizers_To_v1alpha1_StageFinalizers(in
 	out.Add = *(*[]v1alpha1.FinalizerItem)(unsafe.Pointer(&in.Add))
 	out.Remove = *(*[]v1alpha1.FinalizerItem)(unsafe.Pointer(&in.Remove))
 	out.Empty = in.Empty
+	out.HoldDurationMilliseconds = (*int64)(unsafe.Pointer(in.HoldDurationMilliseconds))
+	out.HoldDurationFrom = (*v1alpha1.ExpressionFromSource)(unsafe.Pointer(in.HoldDurationFrom))
 	return nil
 }
 
@@ -2141,6 +3521,8 @@ func autoConvert_v1alpha1_StageFinalizers_To_internalversion_StageFinalizers(in
 	out.Add = *(*[]FinalizerItem)(unsafe.Pointer(&in.Add))
 	out.Remove = *(*[]FinalizerItem)(unsafe.Pointer(&in.Remove))
 	out.Empty = in.Empty
+	out.HoldDurationMilliseconds = (*int64)(unsafe.Pointer(in.HoldDurationMilliseconds))
+	out.HoldDurationFrom = (*ExpressionFromSource)(unsafe.Pointer(in.HoldDurationFrom))
 	return nil
 }
 
@@ -2151,9 +3533,13 @@ func Convert_v1alpha1_StageFinalizers_To_internalversion_StageFinalizers(in *v1a
 
 func autoConvert_internalversion_StageNext_To_v1alpha1_StageNext(in *StageNext, out *v1alpha1.StageNext, s conversion.Scope) error {
 	out.Event = (*v1alpha1.StageEvent)(unsafe.Pointer(in.Event))
+	out.Events = *(*[]v1alpha1.StageEvent)(unsafe.Pointer(&in.Events))
 	out.Finalizers = (*v1alpha1.StageFinalizers)(unsafe.Pointer(in.Finalizers))
 	out.Delete = in.Delete
 	out.StatusTemplate = in.StatusTemplate
+	out.Webhook = (*v1alpha1.StageWebhook)(unsafe.Pointer(in.Webhook))
+	out.Patches = *(*[]v1alpha1.StagePatch)(unsafe.Pointer(&in.Patches))
+	out.Subresources = *(*[]v1alpha1.StageSubresource)(unsafe.Pointer(&in.Subresources))
 	return nil
 }
 
@@ -2164,9 +3550,13 @@ func Convert_internalversion_StageNext_To_v1alpha1_StageNext(in *StageNext, out
 
 func autoConvert_v1alpha1_StageNext_To_internalversion_StageNext(in *v1alpha1.StageNext, out *StageNext, s conversion.Scope) error {
 	out.Event = (*StageEvent)(unsafe.Pointer(in.Event))
+	out.Events = *(*[]StageEvent)(unsafe.Pointer(&in.Events))
 	out.Finalizers = (*StageFinalizers)(unsafe.Pointer(in.Finalizers))
 	out.Delete = in.Delete
 	out.StatusTemplate = in.StatusTemplate
+	out.Webhook = (*StageWebhook)(unsafe.Pointer(in.Webhook))
+	out.Patches = *(*[]StagePatch)(unsafe.Pointer(&in.Patches))
+	out.Subresources = *(*[]StageSubresource)(unsafe.Pointer(&in.Subresources))
 	return nil
 }
 
@@ -2175,6 +3565,28 @@ func Convert_v1alpha1_StageNext_To_internalversion_StageNext(in *v1alpha1.StageN
 	return autoConvert_v1alpha1_StageNext_To_internalversion_StageNext(in, out, s)
 }
 
+func autoConvert_internalversion_StagePatch_To_v1alpha1_StagePatch(in *StagePatch, out *v1alpha1.StagePatch, s conversion.Scope) error {
+	out.DelayMilliseconds = (*int64)(unsafe.Pointer(in.DelayMilliseconds))
+	out.StatusTemplate = in.StatusTemplate
+	return nil
+}
+
+// Convert_internalversion_StagePatch_To_v1alpha1_StagePatch is an autogenerated conversion function.
+func Convert_internalversion_StagePatch_To_v1alpha1_StagePatch(in *StagePatch, out *v1alpha1.StagePatch, s conversion.Scope) error {
+	return autoConvert_internalversion_StagePatch_To_v1alpha1_StagePatch(in, out, s)
+}
+
+func autoConvert_v1alpha1_StagePatch_To_internalversion_StagePatch(in *v1alpha1.StagePatch, out *StagePatch, s conversion.Scope) error {
+	out.DelayMilliseconds = (*int64)(unsafe.Pointer(in.DelayMilliseconds))
+	out.StatusTemplate = in.StatusTemplate
+	return nil
+}
+
+// Convert_v1alpha1_StagePatch_To_internalversion_StagePatch is an autogenerated conversion function.
+func Convert_v1alpha1_StagePatch_To_internalversion_StagePatch(in *v1alpha1.StagePatch, out *StagePatch, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StagePatch_To_internalversion_StagePatch(in, out, s)
+}
+
 func autoConvert_internalversion_StageResourceRef_To_v1alpha1_StageResourceRef(in *StageResourceRef, out *v1alpha1.StageResourceRef, s conversion.Scope) error {
 	out.APIGroup = in.APIGroup
 	out.Kind = in.Kind
@@ -2197,6 +3609,30 @@ func Convert_v1alpha1_StageResourceRef_To_internalversion_StageResourceRef(in *v
 	return autoConvert_v1alpha1_StageResourceRef_To_internalversion_StageResourceRef(in, out, s)
 }
 
+func autoConvert_internalversion_StageSchedule_To_v1alpha1_StageSchedule(in *StageSchedule, out *v1alpha1.StageSchedule, s conversion.Scope) error {
+	out.At = (*v1.Time)(unsafe.Pointer(in.At))
+	out.AfterStartMilliseconds = (*int64)(unsafe.Pointer(in.AfterStartMilliseconds))
+	out.Cron = in.Cron
+	return nil
+}
+
+// Convert_internalversion_StageSchedule_To_v1alpha1_StageSchedule is an autogenerated conversion function.
+func Convert_internalversion_StageSchedule_To_v1alpha1_StageSchedule(in *StageSchedule, out *v1alpha1.StageSchedule, s conversion.Scope) error {
+	return autoConvert_internalversion_StageSchedule_To_v1alpha1_StageSchedule(in, out, s)
+}
+
+func autoConvert_v1alpha1_StageSchedule_To_internalversion_StageSchedule(in *v1alpha1.StageSchedule, out *StageSchedule, s conversion.Scope) error {
+	out.At = (*v1.Time)(unsafe.Pointer(in.At))
+	out.AfterStartMilliseconds = (*int64)(unsafe.Pointer(in.AfterStartMilliseconds))
+	out.Cron = in.Cron
+	return nil
+}
+
+// Convert_v1alpha1_StageSchedule_To_internalversion_StageSchedule is an autogenerated conversion function.
+func Convert_v1alpha1_StageSchedule_To_internalversion_StageSchedule(in *v1alpha1.StageSchedule, out *StageSchedule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StageSchedule_To_internalversion_StageSchedule(in, out, s)
+}
+
 func autoConvert_internalversion_StageSelector_To_v1alpha1_StageSelector(in *StageSelector, out *v1alpha1.StageSelector, s conversion.Scope) error {
 	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
 	out.MatchAnnotations = *(*map[string]string)(unsafe.Pointer(&in.MatchAnnotations))
@@ -2225,8 +3661,10 @@ func autoConvert_internalversion_StageSpec_To_v1alpha1_StageSpec(in *StageSpec,
 	if err := Convert_internalversion_StageResourceRef_To_v1alpha1_StageResourceRef(&in.ResourceRef, &out.ResourceRef, s); err != nil {
 		return err
 	}
+	out.BasedOn = in.BasedOn
 	out.Selector = (*v1alpha1.StageSelector)(unsafe.Pointer(in.Selector))
 	out.Weight = in.Weight
+	out.WeightFrom = (*v1alpha1.ExpressionFromSource)(unsafe.Pointer(in.WeightFrom))
 	out.Delay = (*v1alpha1.StageDelay)(unsafe.Pointer(in.Delay))
 	if err := Convert_internalversion_StageNext_To_v1alpha1_StageNext(&in.Next, &out.Next, s); err != nil {
 		return err
@@ -2246,8 +3684,10 @@ func autoConvert_v1alpha1_StageSpec_To_internalversion_StageSpec(in *v1alpha1.St
 	if err := Convert_v1alpha1_StageResourceRef_To_internalversion_StageResourceRef(&in.ResourceRef, &out.ResourceRef, s); err != nil {
 		return err
 	}
+	out.BasedOn = in.BasedOn
 	out.Selector = (*StageSelector)(unsafe.Pointer(in.Selector))
 	out.Weight = in.Weight
+	out.WeightFrom = (*ExpressionFromSource)(unsafe.Pointer(in.WeightFrom))
 	out.Delay = (*StageDelay)(unsafe.Pointer(in.Delay))
 	if err := Convert_v1alpha1_StageNext_To_internalversion_StageNext(&in.Next, &out.Next, s); err != nil {
 		return err
@@ -2263,6 +3703,52 @@ func Convert_v1alpha1_StageSpec_To_internalversion_StageSpec(in *v1alpha1.StageS
 	return autoConvert_v1alpha1_StageSpec_To_internalversion_StageSpec(in, out, s)
 }
 
+func autoConvert_internalversion_StageSubresource_To_v1alpha1_StageSubresource(in *StageSubresource, out *v1alpha1.StageSubresource, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_internalversion_StageSubresource_To_v1alpha1_StageSubresource is an autogenerated conversion function.
+func Convert_internalversion_StageSubresource_To_v1alpha1_StageSubresource(in *StageSubresource, out *v1alpha1.StageSubresource, s conversion.Scope) error {
+	return autoConvert_internalversion_StageSubresource_To_v1alpha1_StageSubresource(in, out, s)
+}
+
+func autoConvert_v1alpha1_StageSubresource_To_internalversion_StageSubresource(in *v1alpha1.StageSubresource, out *StageSubresource, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_v1alpha1_StageSubresource_To_internalversion_StageSubresource is an autogenerated conversion function.
+func Convert_v1alpha1_StageSubresource_To_internalversion_StageSubresource(in *v1alpha1.StageSubresource, out *StageSubresource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StageSubresource_To_internalversion_StageSubresource(in, out, s)
+}
+
+func autoConvert_internalversion_StageWebhook_To_v1alpha1_StageWebhook(in *StageWebhook, out *v1alpha1.StageWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.TimeoutMilliseconds = (*int64)(unsafe.Pointer(in.TimeoutMilliseconds))
+	out.UseResponse = in.UseResponse
+	return nil
+}
+
+// Convert_internalversion_StageWebhook_To_v1alpha1_StageWebhook is an autogenerated conversion function.
+func Convert_internalversion_StageWebhook_To_v1alpha1_StageWebhook(in *StageWebhook, out *v1alpha1.StageWebhook, s conversion.Scope) error {
+	return autoConvert_internalversion_StageWebhook_To_v1alpha1_StageWebhook(in, out, s)
+}
+
+func autoConvert_v1alpha1_StageWebhook_To_internalversion_StageWebhook(in *v1alpha1.StageWebhook, out *StageWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.TimeoutMilliseconds = (*int64)(unsafe.Pointer(in.TimeoutMilliseconds))
+	out.UseResponse = in.UseResponse
+	return nil
+}
+
+// Convert_v1alpha1_StageWebhook_To_internalversion_StageWebhook is an autogenerated conversion function.
+func Convert_v1alpha1_StageWebhook_To_internalversion_StageWebhook(in *v1alpha1.StageWebhook, out *StageWebhook, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StageWebhook_To_internalversion_StageWebhook(in, out, s)
+}
+
 func autoConvert_internalversion_Volume_To_v1alpha1_Volume(in *Volume, out *configv1alpha1.Volume, s conversion.Scope) error {
 	out.Name = in.Name
 	if err := v1.Convert_bool_To_Pointer_bool(&in.ReadOnly, &out.ReadOnly, s); err != nil {
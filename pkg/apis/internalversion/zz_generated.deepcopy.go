@@ -87,6 +87,22 @@ func (in *AttachSpec) DeepCopy() *AttachSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BinaryVerification) DeepCopyInto(out *BinaryVerification) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinaryVerification.
+func (in *BinaryVerification) DeepCopy() *BinaryVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(BinaryVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterAttach) DeepCopyInto(out *ClusterAttach) {
 	*out = *in
@@ -271,6 +287,52 @@ func (in *ClusterPortForwardSpec) DeepCopy() *ClusterPortForwardSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceUsage) DeepCopyInto(out *ClusterResourceUsage) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceUsage.
+func (in *ClusterResourceUsage) DeepCopy() *ClusterResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceUsageSpec) DeepCopyInto(out *ClusterResourceUsageSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(ObjectSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]ResourceUsageContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceUsageSpec.
+func (in *ClusterResourceUsageSpec) DeepCopy() *ClusterResourceUsageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceUsageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Component) DeepCopyInto(out *Component) {
 	*out = *in
@@ -304,6 +366,16 @@ func (in *Component) DeepCopyInto(out *Component) {
 		*out = make([]Volume, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(Probe)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -398,6 +470,97 @@ func (in *Exec) DeepCopy() *Exec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecAllowedCommand) DeepCopyInto(out *ExecAllowedCommand) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecAllowedCommand.
+func (in *ExecAllowedCommand) DeepCopy() *ExecAllowedCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecAllowedCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScript) DeepCopyInto(out *ExecScript) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ExecScriptStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ExecScriptRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScript.
+func (in *ExecScript) DeepCopy() *ExecScript {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScriptRule) DeepCopyInto(out *ExecScriptRule) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ExecScriptStep, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScriptRule.
+func (in *ExecScriptRule) DeepCopy() *ExecScriptRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScriptRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScriptStep) DeepCopyInto(out *ExecScriptStep) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScriptStep.
+func (in *ExecScriptStep) DeepCopy() *ExecScriptStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScriptStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecSpec) DeepCopyInto(out *ExecSpec) {
 	*out = *in
@@ -434,6 +597,13 @@ func (in *ExecTarget) DeepCopyInto(out *ExecTarget) {
 		*out = new(ExecTargetLocal)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scripts != nil {
+		in, out := &in.Scripts, &out.Scripts
+		*out = make([]ExecScript, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -460,6 +630,11 @@ func (in *ExecTargetLocal) DeepCopyInto(out *ExecTargetLocal) {
 		*out = new(SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sandbox != nil {
+		in, out := &in.Sandbox, &out.Sandbox
+		*out = new(ExecTargetLocalSandbox)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -473,6 +648,29 @@ func (in *ExecTargetLocal) DeepCopy() *ExecTargetLocal {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecTargetLocalSandbox) DeepCopyInto(out *ExecTargetLocalSandbox) {
+	*out = *in
+	if in.AllowedCommands != nil {
+		in, out := &in.AllowedCommands, &out.AllowedCommands
+		*out = make([]ExecAllowedCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecTargetLocalSandbox.
+func (in *ExecTargetLocalSandbox) DeepCopy() *ExecTargetLocalSandbox {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecTargetLocalSandbox)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExpressionFromSource) DeepCopyInto(out *ExpressionFromSource) {
 	*out = *in
@@ -539,6 +737,11 @@ func (in *Forward) DeepCopyInto(out *Forward) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(ForwardHTTP)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -552,6 +755,66 @@ func (in *Forward) DeepCopy() *Forward {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTP) DeepCopyInto(out *ForwardHTTP) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]ForwardHTTPRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTP.
+func (in *ForwardHTTP) DeepCopy() *ForwardHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPHeader) DeepCopyInto(out *ForwardHTTPHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPHeader.
+func (in *ForwardHTTPHeader) DeepCopy() *ForwardHTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPRoute) DeepCopyInto(out *ForwardHTTPRoute) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]ForwardHTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPRoute.
+func (in *ForwardHTTPRoute) DeepCopy() *ForwardHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardTarget) DeepCopyInto(out *ForwardTarget) {
 	*out = *in
@@ -568,6 +831,50 @@ func (in *ForwardTarget) DeepCopy() *ForwardTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPGetAction) DeepCopyInto(out *HTTPGetAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPGetAction.
+func (in *HTTPGetAction) DeepCopy() *HTTPGetAction {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPGetAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSimulation) DeepCopyInto(out *ImageSimulation) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]SimulatedImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSimulation.
+func (in *ImageSimulation) DeepCopy() *ImageSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KwokConfiguration) DeepCopyInto(out *KwokConfiguration) {
 	*out = *in
@@ -594,6 +901,28 @@ func (in *KwokConfigurationOptions) DeepCopyInto(out *KwokConfigurationOptions)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ManageNodeRules != nil {
+		in, out := &in.ManageNodeRules, &out.ManageNodeRules
+		*out = make([]ManageNodeRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnableStageForRefs != nil {
+		in, out := &in.EnableStageForRefs, &out.EnableStageForRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodAdmissionResources != nil {
+		in, out := &in.PodAdmissionResources, &out.PodAdmissionResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageSimulation != nil {
+		in, out := &in.ImageSimulation, &out.ImageSimulation
+		*out = new(ImageSimulation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -653,6 +982,16 @@ func (in *KwokctlConfigurationOptions) DeepCopyInto(out *KwokctlConfigurationOpt
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BinaryVerifications != nil {
+		in, out := &in.BinaryVerifications, &out.BinaryVerifications
+		*out = make([]BinaryVerification, len(*in))
+		copy(*out, *in)
+	}
+	if in.EtcdServers != nil {
+		in, out := &in.EtcdServers, &out.EtcdServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.KubeApiserverCertSANs != nil {
 		in, out := &in.KubeApiserverCertSANs, &out.KubeApiserverCertSANs
 		*out = make([]string, len(*in))
@@ -717,6 +1056,11 @@ func (in *Log) DeepCopyInto(out *Log) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Generator != nil {
+		in, out := &in.Generator, &out.Generator
+		*out = new(LogGenerator)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -731,55 +1075,134 @@ func (in *Log) DeepCopy() *Log {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Logs) DeepCopyInto(out *Logs) {
+func (in *LogGenerator) DeepCopyInto(out *LogGenerator) {
 	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]LogGeneratorField, len(*in))
+		copy(*out, *in)
+	}
+	if in.Levels != nil {
+		in, out := &in.Levels, &out.Levels
+		*out = make([]LogGeneratorLevel, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logs.
-func (in *Logs) DeepCopy() *Logs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogGenerator.
+func (in *LogGenerator) DeepCopy() *LogGenerator {
 	if in == nil {
 		return nil
 	}
-	out := new(Logs)
+	out := new(LogGenerator)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogsSpec) DeepCopyInto(out *LogsSpec) {
+func (in *LogGeneratorField) DeepCopyInto(out *LogGeneratorField) {
 	*out = *in
-	if in.Logs != nil {
-		in, out := &in.Logs, &out.Logs
-		*out = make([]Log, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsSpec.
-func (in *LogsSpec) DeepCopy() *LogsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogGeneratorField.
+func (in *LogGeneratorField) DeepCopy() *LogGeneratorField {
 	if in == nil {
 		return nil
 	}
-	out := new(LogsSpec)
+	out := new(LogGeneratorField)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Metric) DeepCopyInto(out *Metric) {
+func (in *LogGeneratorLevel) DeepCopyInto(out *LogGeneratorLevel) {
 	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metric.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogGeneratorLevel.
+func (in *LogGeneratorLevel) DeepCopy() *LogGeneratorLevel {
+	if in == nil {
+		return nil
+	}
+	out := new(LogGeneratorLevel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Logs) DeepCopyInto(out *Logs) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logs.
+func (in *Logs) DeepCopy() *Logs {
+	if in == nil {
+		return nil
+	}
+	out := new(Logs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsSpec) DeepCopyInto(out *LogsSpec) {
+	*out = *in
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = make([]Log, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsSpec.
+func (in *LogsSpec) DeepCopy() *LogsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageNodeRule) DeepCopyInto(out *ManageNodeRule) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageNodeRule.
+func (in *ManageNodeRule) DeepCopy() *ManageNodeRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageNodeRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Metric) DeepCopyInto(out *Metric) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metric.
 func (in *Metric) DeepCopy() *Metric {
 	if in == nil {
 		return nil
@@ -805,6 +1228,22 @@ func (in *MetricBucket) DeepCopy() *MetricBucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricBucketsFrom) DeepCopyInto(out *MetricBucketsFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricBucketsFrom.
+func (in *MetricBucketsFrom) DeepCopy() *MetricBucketsFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricBucketsFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricConfig) DeepCopyInto(out *MetricConfig) {
 	*out = *in
@@ -818,6 +1257,16 @@ func (in *MetricConfig) DeepCopyInto(out *MetricConfig) {
 		*out = make([]MetricBucket, len(*in))
 		copy(*out, *in)
 	}
+	if in.BucketsFrom != nil {
+		in, out := &in.BucketsFrom, &out.BucketsFrom
+		*out = new(MetricBucketsFrom)
+		**out = **in
+	}
+	if in.Quantiles != nil {
+		in, out := &in.Quantiles, &out.Quantiles
+		*out = make([]MetricQuantile, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -847,6 +1296,22 @@ func (in *MetricLabel) DeepCopy() *MetricLabel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricQuantile) DeepCopyInto(out *MetricQuantile) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricQuantile.
+func (in *MetricQuantile) DeepCopy() *MetricQuantile {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricQuantile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
 	*out = *in
@@ -883,6 +1348,20 @@ func (in *ObjectSelector) DeepCopyInto(out *ObjectSelector) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchNamespaceLabels != nil {
+		in, out := &in.MatchNamespaceLabels, &out.MatchNamespaceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -953,6 +1432,186 @@ func (in *PortForwardSpec) DeepCopy() *PortForwardSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Probe) DeepCopyInto(out *Probe) {
+	*out = *in
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(HTTPGetAction)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
+func (in *Probe) DeepCopy() *Probe {
+	if in == nil {
+		return nil
+	}
+	out := new(Probe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsage) DeepCopyInto(out *ResourceUsage) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsage.
+func (in *ResourceUsage) DeepCopy() *ResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageContainer) DeepCopyInto(out *ResourceUsageContainer) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = make(map[string]ResourceUsageValue, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageContainer.
+func (in *ResourceUsageContainer) DeepCopy() *ResourceUsageContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageRamp) DeepCopyInto(out *ResourceUsageRamp) {
+	*out = *in
+	out.From = in.From.DeepCopy()
+	out.To = in.To.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageRamp.
+func (in *ResourceUsageRamp) DeepCopy() *ResourceUsageRamp {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageRamp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageSinusoidal) DeepCopyInto(out *ResourceUsageSinusoidal) {
+	*out = *in
+	out.Base = in.Base.DeepCopy()
+	out.Amplitude = in.Amplitude.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageSinusoidal.
+func (in *ResourceUsageSinusoidal) DeepCopy() *ResourceUsageSinusoidal {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageSinusoidal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageSpec) DeepCopyInto(out *ResourceUsageSpec) {
+	*out = *in
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]ResourceUsageContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageSpec.
+func (in *ResourceUsageSpec) DeepCopy() *ResourceUsageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageSpike) DeepCopyInto(out *ResourceUsageSpike) {
+	*out = *in
+	out.Base = in.Base.DeepCopy()
+	out.Peak = in.Peak.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageSpike.
+func (in *ResourceUsageSpike) DeepCopy() *ResourceUsageSpike {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageSpike)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageValue) DeepCopyInto(out *ResourceUsageValue) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Sinusoidal != nil {
+		in, out := &in.Sinusoidal, &out.Sinusoidal
+		*out = new(ResourceUsageSinusoidal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ramp != nil {
+		in, out := &in.Ramp, &out.Ramp
+		*out = new(ResourceUsageRamp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Spike != nil {
+		in, out := &in.Spike, &out.Spike
+		*out = new(ResourceUsageSpike)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageValue.
+func (in *ResourceUsageValue) DeepCopy() *ResourceUsageValue {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityContext) DeepCopyInto(out *SecurityContext) {
 	*out = *in
@@ -1000,6 +1659,23 @@ func (in *SelectorRequirement) DeepCopy() *SelectorRequirement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SimulatedImage) DeepCopyInto(out *SimulatedImage) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SimulatedImage.
+func (in *SimulatedImage) DeepCopy() *SimulatedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(SimulatedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Stage) DeepCopyInto(out *Stage) {
 	*out = *in
@@ -1041,6 +1717,16 @@ func (in *StageDelay) DeepCopyInto(out *StageDelay) {
 		*out = new(ExpressionFromSource)
 		**out = **in
 	}
+	if in.Distribution != nil {
+		in, out := &in.Distribution, &out.Distribution
+		*out = new(StageDelayDistribution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(StageSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1054,6 +1740,42 @@ func (in *StageDelay) DeepCopy() *StageDelay {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageDelayDistribution) DeepCopyInto(out *StageDelayDistribution) {
+	*out = *in
+	if in.MinMilliseconds != nil {
+		in, out := &in.MinMilliseconds, &out.MinMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxMilliseconds != nil {
+		in, out := &in.MaxMilliseconds, &out.MaxMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MeanMilliseconds != nil {
+		in, out := &in.MeanMilliseconds, &out.MeanMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StdDevMilliseconds != nil {
+		in, out := &in.StdDevMilliseconds, &out.StdDevMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageDelayDistribution.
+func (in *StageDelayDistribution) DeepCopy() *StageDelayDistribution {
+	if in == nil {
+		return nil
+	}
+	out := new(StageDelayDistribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageEvent) DeepCopyInto(out *StageEvent) {
 	*out = *in
@@ -1083,6 +1805,16 @@ func (in *StageFinalizers) DeepCopyInto(out *StageFinalizers) {
 		*out = make([]FinalizerItem, len(*in))
 		copy(*out, *in)
 	}
+	if in.HoldDurationMilliseconds != nil {
+		in, out := &in.HoldDurationMilliseconds, &out.HoldDurationMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HoldDurationFrom != nil {
+		in, out := &in.HoldDurationFrom, &out.HoldDurationFrom
+		*out = new(ExpressionFromSource)
+		**out = **in
+	}
 	return
 }
 
@@ -1104,11 +1836,33 @@ func (in *StageNext) DeepCopyInto(out *StageNext) {
 		*out = new(StageEvent)
 		**out = **in
 	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]StageEvent, len(*in))
+		copy(*out, *in)
+	}
 	if in.Finalizers != nil {
 		in, out := &in.Finalizers, &out.Finalizers
 		*out = new(StageFinalizers)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(StageWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]StagePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		*out = make([]StageSubresource, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1122,6 +1876,27 @@ func (in *StageNext) DeepCopy() *StageNext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StagePatch) DeepCopyInto(out *StagePatch) {
+	*out = *in
+	if in.DelayMilliseconds != nil {
+		in, out := &in.DelayMilliseconds, &out.DelayMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StagePatch.
+func (in *StagePatch) DeepCopy() *StagePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(StagePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageResourceRef) DeepCopyInto(out *StageResourceRef) {
 	*out = *in
@@ -1138,6 +1913,31 @@ func (in *StageResourceRef) DeepCopy() *StageResourceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageSchedule) DeepCopyInto(out *StageSchedule) {
+	*out = *in
+	if in.At != nil {
+		in, out := &in.At, &out.At
+		*out = (*in).DeepCopy()
+	}
+	if in.AfterStartMilliseconds != nil {
+		in, out := &in.AfterStartMilliseconds, &out.AfterStartMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageSchedule.
+func (in *StageSchedule) DeepCopy() *StageSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(StageSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageSelector) DeepCopyInto(out *StageSelector) {
 	*out = *in
@@ -1184,6 +1984,11 @@ func (in *StageSpec) DeepCopyInto(out *StageSpec) {
 		*out = new(StageSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WeightFrom != nil {
+		in, out := &in.WeightFrom, &out.WeightFrom
+		*out = new(ExpressionFromSource)
+		**out = **in
+	}
 	if in.Delay != nil {
 		in, out := &in.Delay, &out.Delay
 		*out = new(StageDelay)
@@ -1203,6 +2008,43 @@ func (in *StageSpec) DeepCopy() *StageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageSubresource) DeepCopyInto(out *StageSubresource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageSubresource.
+func (in *StageSubresource) DeepCopy() *StageSubresource {
+	if in == nil {
+		return nil
+	}
+	out := new(StageSubresource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageWebhook) DeepCopyInto(out *StageWebhook) {
+	*out = *in
+	if in.TimeoutMilliseconds != nil {
+		in, out := &in.TimeoutMilliseconds, &out.TimeoutMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageWebhook.
+func (in *StageWebhook) DeepCopy() *StageWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(StageWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Volume) DeepCopyInto(out *Volume) {
 	*out = *in
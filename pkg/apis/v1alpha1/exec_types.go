@@ -67,6 +67,46 @@ type ExecTarget struct {
 	Containers []string `json:"containers,omitempty"`
 	// Local holds information how to exec to a local target.
 	Local *ExecTargetLocal `json:"local,omitempty"`
+	// Scripts is a list of the scripted sessions played instead of executing the commands,
+	// the first one matching the command takes precedence over the Local.
+	Scripts []ExecScript `json:"scripts,omitempty"`
+}
+
+// ExecScript is a scripted session played instead of executing a command.
+type ExecScript struct {
+	// Command is the beginning of the commands the script is played for, e.g. ["sh"] matches "sh -c ls",
+	// if not set, the script is played for all the commands.
+	Command []string `json:"command,omitempty"`
+	// Steps is a list of the outputs played once the session starts.
+	Steps []ExecScriptStep `json:"steps,omitempty"`
+	// Rules is a list of the rules reacting to the lines of the stdin after the steps,
+	// the first one matching a line is played.
+	Rules []ExecScriptRule `json:"rules,omitempty"`
+	// ExitCode is the exit code of the session once the stdin is closed, or after the steps if there is no stdin.
+	ExitCode int32 `json:"exitCode,omitempty"`
+}
+
+// ExecScriptStep is an output of a scripted session.
+type ExecScriptStep struct {
+	// DelayMilliseconds is the milliseconds to wait before the output.
+	DelayMilliseconds int64 `json:"delayMilliseconds,omitempty"`
+	// Stdout is written to the stdout.
+	Stdout string `json:"stdout,omitempty"`
+	// Stderr is written to the stderr, or the stdout with a tty.
+	Stderr string `json:"stderr,omitempty"`
+}
+
+// ExecScriptRule is a rule reacting to a line of the stdin of a scripted session.
+type ExecScriptRule struct {
+	// Match is the regular expression matching the line of the stdin, without the line ending.
+	// +kubebuilder:validation:Required
+	Match string `json:"match"`
+	// Steps is a list of the outputs played once a line matches.
+	Steps []ExecScriptStep `json:"steps,omitempty"`
+	// Exit ends the session after the steps with the ExitCode.
+	Exit bool `json:"exit,omitempty"`
+	// ExitCode is the exit code of the session if it exits.
+	ExitCode int32 `json:"exitCode,omitempty"`
 }
 
 // ExecTargetLocal holds information how to exec to a local target.
@@ -77,6 +117,31 @@ type ExecTargetLocal struct {
 	Envs []EnvVar `json:"envs,omitempty"`
 	// SecurityContext is the user context to exec.
 	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
+	// Sandbox restricts the commands to exec, so that the exec is safe to enable on a shared kwok.
+	Sandbox *ExecTargetLocalSandbox `json:"sandbox,omitempty"`
+}
+
+// ExecTargetLocalSandbox restricts the commands to exec locally,
+// the commands are executed without a shell, in a working directory and with the environment variables of their own.
+type ExecTargetLocalSandbox struct {
+	// AllowedCommands is a list of the commands allowed to exec, the others are rejected,
+	// and the shells are rejected even if they are allowed.
+	AllowedCommands []ExecAllowedCommand `json:"allowedCommands,omitempty"`
+	// TimeoutSeconds is the seconds a command is allowed to run before it is killed,
+	// if not set, the command is not limited.
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+	// CPULimitSeconds is the seconds of the CPU time a command is allowed to use before it is killed,
+	// if not set, the command is not limited. It is only supported on Linux.
+	CPULimitSeconds int64 `json:"cpuLimitSeconds,omitempty"`
+}
+
+// ExecAllowedCommand is a command allowed to exec.
+type ExecAllowedCommand struct {
+	// Command is the allowed command with all of its arguments, the command must match it exactly,
+	// e.g. ["ls", "-l"] allows "ls -l" but neither "ls" nor "ls -l /".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
 }
 
 // EnvVar represents an environment variable present in a Container.
@@ -66,10 +66,69 @@ type Log struct {
 	Containers []string `json:"containers,omitempty"`
 	// LogsFile is the file from which the log forward starts
 	LogsFile *string `json:"logsFile,omitempty"`
+	// PreviousLogsFile is the file of the logs of the previous terminated container, which is read by the previous logs,
+	// if not set, the previous logs are not found.
+	PreviousLogsFile *string `json:"previousLogsFile,omitempty"`
 	// Follow up if true
 	Follow *bool `json:"follow,omitempty"`
+	// Generator generates the logs instead of forwarding the LogsFile.
+	Generator *LogGenerator `json:"generator,omitempty"`
 }
 
+// LogGenerator holds information how to generate logs.
+type LogGenerator struct {
+	// Template is the Go template of the message of a log line,
+	// which is given the podName, podNamespace, containerName, sequence and level of the log line,
+	// and the functions of sprig, e.g. randInt and randAlphaNum, plus randChoice returning one of its arguments.
+	// +kubebuilder:validation:Required
+	Template string `json:"template"`
+	// Fields is a list of the fields appended to the log lines.
+	Fields []LogGeneratorField `json:"fields,omitempty"`
+	// Levels is the mix of the levels of the log lines,
+	// if not set, all the log lines are of the info level.
+	Levels []LogGeneratorLevel `json:"levels,omitempty"`
+	// Format is the format of the log lines, if not set, the log lines are in the text format.
+	Format LogGeneratorFormat `json:"format,omitempty"`
+	// IntervalMilliseconds is the milliseconds between the log lines, if not set, a log line is generated every second.
+	// +kubebuilder:validation:Minimum=0
+	IntervalMilliseconds int64 `json:"intervalMilliseconds,omitempty"`
+	// HistoryLines is the number of the log lines already generated when the logs are requested.
+	// +kubebuilder:validation:Minimum=0
+	HistoryLines int64 `json:"historyLines,omitempty"`
+}
+
+// LogGeneratorField is a field of the generated log lines.
+type LogGeneratorField struct {
+	// Name is the name of the field.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Template is the Go template of the value of the field, which is given the same as the template of the message.
+	Template string `json:"template,omitempty"`
+}
+
+// LogGeneratorLevel is a level of the generated log lines.
+type LogGeneratorLevel struct {
+	// Level is the name of the level, e.g. info.
+	// +kubebuilder:validation:Required
+	Level string `json:"level"`
+	// Weight is the relative weight of the level in the mix of the levels.
+	// +kubebuilder:validation:Minimum=0
+	Weight int64 `json:"weight,omitempty"`
+}
+
+// LogGeneratorFormat is the format of the generated log lines.
+// +enum
+// +kubebuilder:validation:Enum=text;json
+type LogGeneratorFormat string
+
+// The following are valid log generator formats.
+const (
+	// LogGeneratorFormatText writes the log lines as the time, the level, the message and the fields as key=value.
+	LogGeneratorFormatText LogGeneratorFormat = "text"
+	// LogGeneratorFormatJSON writes the log lines as the JSON objects of the time, the level, the msg and the fields.
+	LogGeneratorFormatJSON LogGeneratorFormat = "json"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 
@@ -76,7 +76,7 @@ type MetricConfig struct {
 	Help string `json:"help,omitempty"`
 	// Kind is kind of metric
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=counter;gauge;histogram
+	// +kubebuilder:validation:Enum=counter;gauge;histogram;summary
 	Kind Kind `json:"kind"`
 	// Labels are metric labels.
 	// +patchMergeKey=name
@@ -92,9 +92,24 @@ type MetricConfig struct {
 	// +listType=map
 	// +listMapKey=le
 	Buckets []MetricBucket `json:"buckets,omitempty"`
+	// BucketsFrom generates the buckets for a histogram metric in addition to the Buckets.
+	BucketsFrom *MetricBucketsFrom `json:"bucketsFrom,omitempty"`
+	// Quantiles is a list of quantiles for a summary metric.
+	// +patchMergeKey=quantile
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=quantile
+	Quantiles []MetricQuantile `json:"quantiles,omitempty"`
+	// SampleCount is a CEL expression of the count of the observations for a summary metric.
+	SampleCount string `json:"sampleCount,omitempty"`
+	// SampleSum is a CEL expression of the sum of the observations for a summary metric.
+	SampleSum string `json:"sampleSum,omitempty"`
 	// Dimension is a dimension of the metric.
 	// +default="node"
 	Dimension Dimension `json:"dimension,omitempty"`
+	// Device is the extended resource of the devices of the device dimension, e.g. nvidia.com/gpu,
+	// the metric is evaluated for each of the devices of the resource in the allocatable of the node.
+	Device string `json:"device,omitempty"`
 }
 
 // Kind is kind of metric configuration.
@@ -108,6 +123,8 @@ const (
 	KindGauge Kind = "gauge"
 	// KindHistogram is a histogram metric.
 	KindHistogram Kind = "histogram"
+	// KindSummary is a summary metric.
+	KindSummary Kind = "summary"
 )
 
 // Dimension is a dimension of the metric.
@@ -121,6 +138,8 @@ const (
 	DimensionPod Dimension = "pod"
 	// DimensionContainer is a container dimension.
 	DimensionContainer Dimension = "container"
+	// DimensionDevice is a device dimension, e.g. a GPU.
+	DimensionDevice Dimension = "device"
 )
 
 // MetricLabel holds label name and the value of the label.
@@ -149,6 +168,51 @@ type MetricBucket struct {
 	Hidden bool `json:"hidden,omitempty"`
 }
 
+// MetricBucketsFrom generates the buckets for a histogram metric.
+type MetricBucketsFrom struct {
+	// Type is the type of the generated buckets.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=linear;exponential
+	Type MetricBucketsType `json:"type"`
+	// Start is the upper bound of the first bucket.
+	// +kubebuilder:validation:Required
+	Start float64 `json:"start"`
+	// Factor is the width of the linear buckets, or the factor between the upper bounds of the exponential buckets.
+	// +kubebuilder:validation:Required
+	Factor float64 `json:"factor"`
+	// Count is the number of the buckets.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count"`
+	// Value is a CEL expression evaluated for each bucket,
+	// with the variable le of the upper bound of the bucket.
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
+}
+
+// MetricBucketsType is the type of the generated buckets.
+// +enum
+type MetricBucketsType string
+
+const (
+	// MetricBucketsTypeLinear is the buckets with the upper bounds of Start + i * Factor.
+	MetricBucketsTypeLinear MetricBucketsType = "linear"
+	// MetricBucketsTypeExponential is the buckets with the upper bounds of Start * Factor ^ i.
+	MetricBucketsTypeExponential MetricBucketsType = "exponential"
+)
+
+// MetricQuantile is a single quantile for a summary metric.
+type MetricQuantile struct {
+	// Quantile is the quantile, between 0 and 1.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	Quantile float64 `json:"quantile"`
+	// Value is a CEL expression.
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 
@@ -16,7 +16,7 @@ limitations under the License.
 
 package v1alpha1
 
-// ObjectSelector holds information how to match based on namespace and name.
+// ObjectSelector holds information how to match based on namespace, name and labels.
 type ObjectSelector struct {
 	// MatchNamespaces is a list of namespaces to match.
 	// if not set, all namespaces will be matched.
@@ -24,4 +24,10 @@ type ObjectSelector struct {
 	// MatchNames is a list of names to match.
 	// if not set, all names will be matched.
 	MatchNames []string `json:"matchNames,omitempty"`
+	// MatchLabels is a map of {key,value} pairs of the labels to match.
+	// if not set, all labels will be matched.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// MatchNamespaceLabels is a map of {key,value} pairs of the labels of the namespaces to match.
+	// if not set, all namespaces will be matched.
+	MatchNamespaceLabels map[string]string `json:"matchNamespaceLabels,omitempty"`
 }
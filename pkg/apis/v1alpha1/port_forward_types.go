@@ -70,6 +70,9 @@ type Forward struct {
 	// Command is the command to run to forward with stdin/stdout.
 	// if set, Target will be ignored.
 	Command []string `json:"command,omitempty"`
+	// HTTP is the HTTP server served by kwok itself to forward to.
+	// if set, Target and Command will be ignored.
+	HTTP *ForwardHTTP `json:"http,omitempty"`
 }
 
 // ForwardTarget holds information how to forward to a target.
@@ -85,6 +88,48 @@ type ForwardTarget struct {
 	Address string `json:"address"`
 }
 
+// ForwardHTTP holds information how to serve the HTTP requests forwarded.
+type ForwardHTTP struct {
+	// Routes is a list of routes to respond to the requests.
+	// the first route matching the request is used,
+	// if none matches, the request is echoed back as JSON.
+	Routes []ForwardHTTPRoute `json:"routes,omitempty"`
+}
+
+// ForwardHTTPRoute holds information how to respond to the matched requests.
+type ForwardHTTPRoute struct {
+	// Method is the method of the requests to match.
+	// if not set, all methods will be matched.
+	Method string `json:"method,omitempty"`
+	// Path is the path of the requests to match.
+	// a path ending with a slash matches all paths under it,
+	// if not set, all paths will be matched.
+	Path string `json:"path,omitempty"`
+	// StatusCode is the status code of the response.
+	// if not set, 200 will be used.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=999
+	StatusCode int32 `json:"statusCode,omitempty"`
+	// Headers is a list of headers of the response.
+	Headers []ForwardHTTPHeader `json:"headers,omitempty"`
+	// Body is the template of the body of the response.
+	// the request is given as method, path, query, headers, body, podName, podNamespace and port.
+	Body string `json:"body,omitempty"`
+	// DelayMilliseconds is the delay before the response is written.
+	// +kubebuilder:validation:Minimum=0
+	DelayMilliseconds int64 `json:"delayMilliseconds,omitempty"`
+}
+
+// ForwardHTTPHeader holds information of a header of the response.
+type ForwardHTTPHeader struct {
+	// Name is the name of the header.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Value is the value of the header.
+	Value string `json:"value,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ResourceUsageKind is the kind of the ResourceUsage.
+	ResourceUsageKind = "ResourceUsage"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient
+// +kubebuilder:subresource:status
+// +kubebuilder:rbac:groups=kwok.x-k8s.io,resources=resourceusages,verbs=create;delete;get;list;patch;update;watch
+
+// ResourceUsage provides resource usage for a single pod.
+type ResourceUsage struct {
+	//+k8s:conversion-gen=false
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata"`
+	// Spec holds spec for resource usage.
+	Spec ResourceUsageSpec `json:"spec"`
+	// Status holds status for resource usage
+	//+k8s:conversion-gen=false
+	Status ResourceUsageStatus `json:"status,omitempty"`
+}
+
+// ResourceUsageStatus holds status for resource usage
+type ResourceUsageStatus struct {
+	// Conditions holds conditions for resource usage
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// ResourceUsageSpec holds spec for resource usage.
+type ResourceUsageSpec struct {
+	// Usages is a list of resource usage for the pod.
+	Usages []ResourceUsageContainer `json:"usages,omitempty"`
+}
+
+// ResourceUsageContainer holds spec for resource usage container.
+type ResourceUsageContainer struct {
+	// Containers is list of container names.
+	// if not set, all containers will be matched.
+	Containers []string `json:"containers,omitempty"`
+	// Usage is a list of resource usage for the container.
+	Usage map[string]ResourceUsageValue `json:"usage,omitempty"`
+}
+
+// ResourceUsageValue holds value for resource usage.
+type ResourceUsageValue struct {
+	// Value is the value for resource usage.
+	Value *resource.Quantity `json:"value,omitempty"`
+	// Expression is the expression for resource usage.
+	Expression *string `json:"expression,omitempty"`
+	// Sinusoidal is the sine wave over time for resource usage.
+	Sinusoidal *ResourceUsageSinusoidal `json:"sinusoidal,omitempty"`
+	// Ramp is the linear ramp over time for resource usage.
+	Ramp *ResourceUsageRamp `json:"ramp,omitempty"`
+	// Spike is the scheduled spikes over time for resource usage.
+	Spike *ResourceUsageSpike `json:"spike,omitempty"`
+}
+
+// ResourceUsageSinusoidal holds a sine wave for resource usage,
+// the usage is Base + Amplitude * sin(2 * pi * (t + PhaseMilliseconds) / PeriodMilliseconds),
+// where t is the time since the pod started, and it never goes below zero.
+type ResourceUsageSinusoidal struct {
+	// Base is the value the wave oscillates around.
+	Base resource.Quantity `json:"base"`
+	// Amplitude is the peak deviation of the wave from the base.
+	Amplitude resource.Quantity `json:"amplitude"`
+	// PeriodMilliseconds is the duration of a full cycle of the wave.
+	// +kubebuilder:validation:Minimum=1
+	PeriodMilliseconds int64 `json:"periodMilliseconds"`
+	// PhaseMilliseconds shifts the wave forward in time.
+	PhaseMilliseconds *int64 `json:"phaseMilliseconds,omitempty"`
+}
+
+// ResourceUsageRamp holds a linear ramp for resource usage,
+// the usage goes from From to To in DurationMilliseconds since the pod started.
+type ResourceUsageRamp struct {
+	// From is the value at the start of the ramp.
+	From resource.Quantity `json:"from"`
+	// To is the value at the end of the ramp.
+	To resource.Quantity `json:"to"`
+	// DurationMilliseconds is the duration of the ramp.
+	// +kubebuilder:validation:Minimum=1
+	DurationMilliseconds int64 `json:"durationMilliseconds"`
+	// Repeat restarts the ramp from From once it reaches To,
+	// otherwise the usage stays at To.
+	Repeat *bool `json:"repeat,omitempty"`
+}
+
+// ResourceUsageSpike holds scheduled spikes for resource usage,
+// the usage is Peak during the spikes and Base otherwise.
+type ResourceUsageSpike struct {
+	// Base is the value outside the spikes.
+	Base resource.Quantity `json:"base"`
+	// Peak is the value during the spikes.
+	Peak resource.Quantity `json:"peak"`
+	// AfterMilliseconds is the time since the pod started that the first spike begins at.
+	AfterMilliseconds *int64 `json:"afterMilliseconds,omitempty"`
+	// DurationMilliseconds is the duration of each spike.
+	// +kubebuilder:validation:Minimum=1
+	DurationMilliseconds int64 `json:"durationMilliseconds"`
+	// IntervalMilliseconds is the time between the beginnings of the spikes,
+	// if not set, there is only one spike.
+	IntervalMilliseconds *int64 `json:"intervalMilliseconds,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// ResourceUsageList is a list of ResourceUsage.
+type ResourceUsageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceUsage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourceUsage{}, &ResourceUsageList{})
+}
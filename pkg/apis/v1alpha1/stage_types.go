@@ -23,6 +23,23 @@ import (
 const (
 	// StageKind is the kind of the Stage resource.
 	StageKind = "Stage"
+
+	// StagePausedAnnotation is the annotation to pause the stages.
+	// No stage is played on the resource with the annotation set to "true",
+	// and the Stage with the annotation set to "true" is not matched by any resource.
+	StagePausedAnnotation = "stage.kwok.x-k8s.io/paused"
+
+	// StageSetAnnotation is the annotation to group the stages into sets.
+	// The Stage with the annotation belongs to the set, and the resource with the annotation
+	// only follows the stages in the comma separated sets, e.g. "slow,flaky",
+	// while the resource without the annotation only follows the stages not in any set.
+	StageSetAnnotation = "stage.kwok.x-k8s.io/set"
+
+	// StageNextAnnotation is the annotation of the next stage planned for the resource,
+	// it is set with --enable-stage-next-annotations to help to debug the stages with long delays.
+	StageNextAnnotation = "stage.kwok.x-k8s.io/next"
+	// StageNextTimeAnnotation is the annotation of the time that the next stage is planned at, in RFC3339.
+	StageNextTimeAnnotation = "stage.kwok.x-k8s.io/next-time"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -60,6 +77,9 @@ type StageStatus struct {
 type StageSpec struct {
 	// ResourceRef specifies the Kind and version of the resource.
 	ResourceRef StageResourceRef `json:"resourceRef"`
+	// BasedOn is the name of another stage that the fields not set in this stage are inherited from,
+	// except the ResourceRef, and the Selector is merged with the one of the base stage.
+	BasedOn string `json:"basedOn,omitempty"`
 	// Selector specifies the stags will be applied to the selected resource.
 	Selector *StageSelector `json:"selector,omitempty"`
 	// Weight means the current stage, in case of multiple stages,
@@ -68,6 +88,11 @@ type StageSpec struct {
 	// +kubebuilder:default=0
 	// +kubebuilder:validation:Minimum=0
 	Weight int `json:"weight,omitempty"`
+	// WeightFrom is the expression used to get the weight of each object.
+	// If it is a number type, convert to int.
+	// If it is a string type, the value get will be parsed by strconv.ParseInt.
+	// If the value cannot be got, Weight is used.
+	WeightFrom *ExpressionFromSource `json:"weightFrom,omitempty"`
 	// Delay means there is a delay in this stage.
 	Delay *StageDelay `json:"delay,omitempty"`
 	// Next indicates that this stage will be moved to.
@@ -83,7 +108,6 @@ type StageResourceRef struct {
 	// +kubebuilder:default="v1"
 	APIGroup string `json:"apiGroup,omitempty"`
 	// Kind of the referent.
-	// +kubebuilder:validation:Enum=Pod;Node
 	Kind string `json:"kind"`
 }
 
@@ -106,18 +130,117 @@ type StageDelay struct {
 	// If it is a time.Time type, getting the value will be minus time.Now() to get JitterDurationMilliseconds
 	// If it is a string type, the value get will be parsed by time.ParseDuration.
 	JitterDurationFrom *ExpressionFromSource `json:"jitterDurationFrom,omitempty"`
+
+	// Distribution means the delay is drawn from a random distribution for each object,
+	// and the sampled value is added to DurationMilliseconds or DurationFrom.
+	// If it is set, JitterDurationMilliseconds and JitterDurationFrom are ignored.
+	Distribution *StageDelayDistribution `json:"distribution,omitempty"`
+
+	// Schedule means the stage is applied at the wall-clock times of the schedule instead of after the duration,
+	// e.g. to make 500 nodes NotReady 10 minutes after the start of kwok.
+	// If it is set, the other fields of the delay are ignored.
+	Schedule *StageSchedule `json:"schedule,omitempty"`
+}
+
+// StageSchedule describes the wall-clock times that the stage is applied at.
+// If more than one of the fields are set, the earliest of them is used,
+// and the stage is applied without delay once the time has passed.
+type StageSchedule struct {
+	// At is the time that the stage is applied at.
+	At *metav1.Time `json:"at,omitempty"`
+	// AfterStartMilliseconds is the time since the start of kwok that the stage is applied at,
+	// e.g. 600000 for T+10m.
+	// +kubebuilder:validation:Minimum=0
+	AfterStartMilliseconds *int64 `json:"afterStartMilliseconds,omitempty"`
+	// Cron is a cron expression with the five fields of minute, hour, day of month, month and day of week in UTC,
+	// e.g. "*/10 * * * *", the stage is applied at the next time of it.
+	Cron string `json:"cron,omitempty"`
+}
+
+// StageDelayDistribution describes a random distribution of the delay time.
+type StageDelayDistribution struct {
+	// Type is the type of the distribution.
+	// +kubebuilder:validation:Enum=Uniform;Normal;Exponential
+	Type StageDelayDistributionType `json:"type"`
+	// MinMilliseconds is the lower bound of the sampled value.
+	// It is required for the Uniform distribution and optional for others.
+	// +kubebuilder:validation:Minimum=0
+	MinMilliseconds *int64 `json:"minMilliseconds,omitempty"`
+	// MaxMilliseconds is the upper bound of the sampled value.
+	// It is required for the Uniform distribution and optional for others.
+	// +kubebuilder:validation:Minimum=0
+	MaxMilliseconds *int64 `json:"maxMilliseconds,omitempty"`
+	// MeanMilliseconds is the mean of the Normal and Exponential distributions.
+	// +kubebuilder:validation:Minimum=0
+	MeanMilliseconds *int64 `json:"meanMilliseconds,omitempty"`
+	// StdDevMilliseconds is the standard deviation of the Normal distribution.
+	// +kubebuilder:validation:Minimum=0
+	StdDevMilliseconds *int64 `json:"stdDevMilliseconds,omitempty"`
 }
 
+// StageDelayDistributionType is the type of the delay distribution.
+// +enum
+type StageDelayDistributionType string
+
+// The following are valid delay distribution types.
+const (
+	// StageDelayDistributionUniform draws the value uniformly between min and max.
+	StageDelayDistributionUniform StageDelayDistributionType = "Uniform"
+	// StageDelayDistributionNormal draws the value from a normal distribution with mean and stdDev.
+	StageDelayDistributionNormal StageDelayDistributionType = "Normal"
+	// StageDelayDistributionExponential draws the value from an exponential distribution with mean.
+	StageDelayDistributionExponential StageDelayDistributionType = "Exponential"
+)
+
 // StageNext describes a stage will be moved to.
 type StageNext struct {
 	// Event means that an event will be sent.
 	Event *StageEvent `json:"event,omitempty"`
+	// Events means that the events will be sent in order, after the Event.
+	Events []StageEvent `json:"events,omitempty"`
 	// Finalizers means that finalizers will be modified.
 	Finalizers *StageFinalizers `json:"finalizers,omitempty"`
 	// Delete means that the resource will be deleted if true.
 	Delete bool `json:"delete,omitempty"`
 	// StatusTemplate indicates the template for modifying the status of the resource in the next.
 	StatusTemplate string `json:"statusTemplate,omitempty"`
+	// Webhook means that the resource will be posted to an external webhook.
+	Webhook *StageWebhook `json:"webhook,omitempty"`
+	// Patches is the ordered list of patches of the status applied after the next is applied,
+	// each of them is applied after its own delay from the previous one,
+	// e.g. to start the containers of a pod one by one in a single stage.
+	Patches []StagePatch `json:"patches,omitempty"`
+	// Subresources indicates the templates for modifying the subresources of the resource other than the status,
+	// e.g. to update the scale of a workload or attach an ephemeral container to a pod.
+	Subresources []StageSubresource `json:"subresources,omitempty"`
+}
+
+// StageSubresource describes the modification of a subresource.
+type StageSubresource struct {
+	// Name is the name of the subresource, e.g. scale or ephemeralcontainers.
+	Name string `json:"name"`
+	// Template indicates the template for the patch of the subresource.
+	Template string `json:"template"`
+}
+
+// StagePatch describes one of the ordered patches of the status.
+type StagePatch struct {
+	// DelayMilliseconds is the delay after the previous patch, or the next of the stage, is applied.
+	DelayMilliseconds *int64 `json:"delayMilliseconds,omitempty"`
+	// StatusTemplate indicates the template for modifying the status of the resource.
+	StatusTemplate string `json:"statusTemplate"`
+}
+
+// StageWebhook describes the webhook called when the stage is played.
+type StageWebhook struct {
+	// URL is the URL of the webhook.
+	// The stage name and the resource are posted to it in JSON, as {"stage": <name>, "object": <resource>}.
+	URL string `json:"url"`
+	// TimeoutMilliseconds is the timeout of calling the webhook, defaults to 10 seconds.
+	TimeoutMilliseconds *int64 `json:"timeoutMilliseconds,omitempty"`
+	// UseResponse means that the response of the webhook is used as the patch of the status of the resource,
+	// instead of the StatusTemplate. An empty response means no change.
+	UseResponse bool `json:"useResponse,omitempty"`
 }
 
 // StageFinalizers describes the modifications in the finalizers of a resource.
@@ -128,6 +251,14 @@ type StageFinalizers struct {
 	Remove []FinalizerItem `json:"remove,omitempty"`
 	// Empty means that the Finalizers for that resource will be emptied.
 	Empty bool `json:"empty,omitempty"`
+	// HoldDurationMilliseconds is the duration that the finalizers are held before they are modified,
+	// counting from the deletionTimestamp if the resource is being deleted, otherwise from when the stage is matched.
+	// It simulates the resources that are slow to terminate, e.g. a volume that takes time to detach.
+	HoldDurationMilliseconds *int64 `json:"holdDurationMilliseconds,omitempty"`
+	// HoldDurationFrom is the expression used to get the value.
+	// If it is a time.Time type, getting the value will be minus time.Now() to get HoldDurationMilliseconds
+	// If it is a string type, the value get will be parsed by time.ParseDuration.
+	HoldDurationFrom *ExpressionFromSource `json:"holdDurationFrom,omitempty"`
 }
 
 // FinalizerItem  describes the one of the finalizers.
@@ -143,6 +274,7 @@ type StageEvent struct {
 	// Reason is why the action was taken. It is human-readable.
 	Reason string `json:"reason,omitempty"`
 	// Message is a human-readable description of the status of this operation.
+	// It is rendered as a template with the resource, e.g. "Pulling image {{ (index .spec.containers 0).image }}".
 	Message string `json:"message,omitempty"`
 }
 
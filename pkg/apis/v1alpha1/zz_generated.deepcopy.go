@@ -606,6 +606,118 @@ func (in *ClusterPortForwardStatus) DeepCopy() *ClusterPortForwardStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceUsage) DeepCopyInto(out *ClusterResourceUsage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceUsage.
+func (in *ClusterResourceUsage) DeepCopy() *ClusterResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterResourceUsage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceUsageList) DeepCopyInto(out *ClusterResourceUsageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterResourceUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceUsageList.
+func (in *ClusterResourceUsageList) DeepCopy() *ClusterResourceUsageList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceUsageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterResourceUsageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceUsageSpec) DeepCopyInto(out *ClusterResourceUsageSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(ObjectSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]ResourceUsageContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceUsageSpec.
+func (in *ClusterResourceUsageSpec) DeepCopy() *ClusterResourceUsageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceUsageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceUsageStatus) DeepCopyInto(out *ClusterResourceUsageStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceUsageStatus.
+func (in *ClusterResourceUsageStatus) DeepCopy() *ClusterResourceUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -667,6 +779,27 @@ func (in *Exec) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecAllowedCommand) DeepCopyInto(out *ExecAllowedCommand) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecAllowedCommand.
+func (in *ExecAllowedCommand) DeepCopy() *ExecAllowedCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecAllowedCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecList) DeepCopyInto(out *ExecList) {
 	*out = *in
@@ -700,6 +833,76 @@ func (in *ExecList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScript) DeepCopyInto(out *ExecScript) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ExecScriptStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ExecScriptRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScript.
+func (in *ExecScript) DeepCopy() *ExecScript {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScriptRule) DeepCopyInto(out *ExecScriptRule) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ExecScriptStep, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScriptRule.
+func (in *ExecScriptRule) DeepCopy() *ExecScriptRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScriptRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScriptStep) DeepCopyInto(out *ExecScriptStep) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScriptStep.
+func (in *ExecScriptStep) DeepCopy() *ExecScriptStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScriptStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecSpec) DeepCopyInto(out *ExecSpec) {
 	*out = *in
@@ -759,6 +962,13 @@ func (in *ExecTarget) DeepCopyInto(out *ExecTarget) {
 		*out = new(ExecTargetLocal)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scripts != nil {
+		in, out := &in.Scripts, &out.Scripts
+		*out = make([]ExecScript, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -785,6 +995,11 @@ func (in *ExecTargetLocal) DeepCopyInto(out *ExecTargetLocal) {
 		*out = new(SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sandbox != nil {
+		in, out := &in.Sandbox, &out.Sandbox
+		*out = new(ExecTargetLocalSandbox)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -798,6 +1013,29 @@ func (in *ExecTargetLocal) DeepCopy() *ExecTargetLocal {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecTargetLocalSandbox) DeepCopyInto(out *ExecTargetLocalSandbox) {
+	*out = *in
+	if in.AllowedCommands != nil {
+		in, out := &in.AllowedCommands, &out.AllowedCommands
+		*out = make([]ExecAllowedCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecTargetLocalSandbox.
+func (in *ExecTargetLocalSandbox) DeepCopy() *ExecTargetLocalSandbox {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecTargetLocalSandbox)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExpressionFromSource) DeepCopyInto(out *ExpressionFromSource) {
 	*out = *in
@@ -848,6 +1086,11 @@ func (in *Forward) DeepCopyInto(out *Forward) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(ForwardHTTP)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -861,6 +1104,66 @@ func (in *Forward) DeepCopy() *Forward {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTP) DeepCopyInto(out *ForwardHTTP) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]ForwardHTTPRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTP.
+func (in *ForwardHTTP) DeepCopy() *ForwardHTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPHeader) DeepCopyInto(out *ForwardHTTPHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPHeader.
+func (in *ForwardHTTPHeader) DeepCopy() *ForwardHTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPRoute) DeepCopyInto(out *ForwardHTTPRoute) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]ForwardHTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPRoute.
+func (in *ForwardHTTPRoute) DeepCopy() *ForwardHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardTarget) DeepCopyInto(out *ForwardTarget) {
 	*out = *in
@@ -890,11 +1193,21 @@ func (in *Log) DeepCopyInto(out *Log) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PreviousLogsFile != nil {
+		in, out := &in.PreviousLogsFile, &out.PreviousLogsFile
+		*out = new(string)
+		**out = **in
+	}
 	if in.Follow != nil {
 		in, out := &in.Follow, &out.Follow
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Generator != nil {
+		in, out := &in.Generator, &out.Generator
+		*out = new(LogGenerator)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -909,38 +1222,96 @@ func (in *Log) DeepCopy() *Log {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Logs) DeepCopyInto(out *Logs) {
+func (in *LogGenerator) DeepCopyInto(out *LogGenerator) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]LogGeneratorField, len(*in))
+		copy(*out, *in)
+	}
+	if in.Levels != nil {
+		in, out := &in.Levels, &out.Levels
+		*out = make([]LogGeneratorLevel, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logs.
-func (in *Logs) DeepCopy() *Logs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogGenerator.
+func (in *LogGenerator) DeepCopy() *LogGenerator {
 	if in == nil {
 		return nil
 	}
-	out := new(Logs)
+	out := new(LogGenerator)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Logs) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogGeneratorField) DeepCopyInto(out *LogGeneratorField) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogGeneratorField.
+func (in *LogGeneratorField) DeepCopy() *LogGeneratorField {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(LogGeneratorField)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogsList) DeepCopyInto(out *LogsList) {
+func (in *LogGeneratorLevel) DeepCopyInto(out *LogGeneratorLevel) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogGeneratorLevel.
+func (in *LogGeneratorLevel) DeepCopy() *LogGeneratorLevel {
+	if in == nil {
+		return nil
+	}
+	out := new(LogGeneratorLevel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Logs) DeepCopyInto(out *Logs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Logs.
+func (in *Logs) DeepCopy() *Logs {
+	if in == nil {
+		return nil
+	}
+	out := new(Logs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Logs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsList) DeepCopyInto(out *LogsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]Logs, len(*in))
@@ -1059,6 +1430,22 @@ func (in *MetricBucket) DeepCopy() *MetricBucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricBucketsFrom) DeepCopyInto(out *MetricBucketsFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricBucketsFrom.
+func (in *MetricBucketsFrom) DeepCopy() *MetricBucketsFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricBucketsFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricConfig) DeepCopyInto(out *MetricConfig) {
 	*out = *in
@@ -1072,6 +1459,16 @@ func (in *MetricConfig) DeepCopyInto(out *MetricConfig) {
 		*out = make([]MetricBucket, len(*in))
 		copy(*out, *in)
 	}
+	if in.BucketsFrom != nil {
+		in, out := &in.BucketsFrom, &out.BucketsFrom
+		*out = new(MetricBucketsFrom)
+		**out = **in
+	}
+	if in.Quantiles != nil {
+		in, out := &in.Quantiles, &out.Quantiles
+		*out = make([]MetricQuantile, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1091,43 +1488,280 @@ func (in *MetricLabel) DeepCopyInto(out *MetricLabel) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricLabel.
-func (in *MetricLabel) DeepCopy() *MetricLabel {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricLabel.
+func (in *MetricLabel) DeepCopy() *MetricLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricList) DeepCopyInto(out *MetricList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Metric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricList.
+func (in *MetricList) DeepCopy() *MetricList {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MetricList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricQuantile) DeepCopyInto(out *MetricQuantile) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricQuantile.
+func (in *MetricQuantile) DeepCopy() *MetricQuantile {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricQuantile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]MetricConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSpec.
+func (in *MetricSpec) DeepCopy() *MetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricStatus) DeepCopyInto(out *MetricStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricStatus.
+func (in *MetricStatus) DeepCopy() *MetricStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSelector) DeepCopyInto(out *ObjectSelector) {
+	*out = *in
+	if in.MatchNamespaces != nil {
+		in, out := &in.MatchNamespaces, &out.MatchNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchNames != nil {
+		in, out := &in.MatchNames, &out.MatchNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchNamespaceLabels != nil {
+		in, out := &in.MatchNamespaceLabels, &out.MatchNamespaceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSelector.
+func (in *ObjectSelector) DeepCopy() *ObjectSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortForward) DeepCopyInto(out *PortForward) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortForward.
+func (in *PortForward) DeepCopy() *PortForward {
+	if in == nil {
+		return nil
+	}
+	out := new(PortForward)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PortForward) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortForwardList) DeepCopyInto(out *PortForwardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PortForward, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortForwardList.
+func (in *PortForwardList) DeepCopy() *PortForwardList {
+	if in == nil {
+		return nil
+	}
+	out := new(PortForwardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PortForwardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortForwardSpec) DeepCopyInto(out *PortForwardSpec) {
+	*out = *in
+	if in.Forwards != nil {
+		in, out := &in.Forwards, &out.Forwards
+		*out = make([]Forward, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortForwardSpec.
+func (in *PortForwardSpec) DeepCopy() *PortForwardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PortForwardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortForwardStatus) DeepCopyInto(out *PortForwardStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortForwardStatus.
+func (in *PortForwardStatus) DeepCopy() *PortForwardStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricLabel)
+	out := new(PortForwardStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricList) DeepCopyInto(out *MetricList) {
+func (in *ResourceUsage) DeepCopyInto(out *ResourceUsage) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Metric, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricList.
-func (in *MetricList) DeepCopy() *MetricList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsage.
+func (in *ResourceUsage) DeepCopy() *ResourceUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricList)
+	out := new(ResourceUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MetricList) DeepCopyObject() runtime.Object {
+func (in *ResourceUsage) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1135,34 +1769,41 @@ func (in *MetricList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
+func (in *ResourceUsageContainer) DeepCopyInto(out *ResourceUsageContainer) {
 	*out = *in
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = make([]MetricConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = make(map[string]ResourceUsageValue, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSpec.
-func (in *MetricSpec) DeepCopy() *MetricSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageContainer.
+func (in *ResourceUsageContainer) DeepCopy() *ResourceUsageContainer {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricSpec)
+	out := new(ResourceUsageContainer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricStatus) DeepCopyInto(out *MetricStatus) {
+func (in *ResourceUsageList) DeepCopyInto(out *ResourceUsageList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceUsage, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1170,78 +1811,76 @@ func (in *MetricStatus) DeepCopyInto(out *MetricStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricStatus.
-func (in *MetricStatus) DeepCopy() *MetricStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageList.
+func (in *ResourceUsageList) DeepCopy() *ResourceUsageList {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricStatus)
+	out := new(ResourceUsageList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceUsageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectSelector) DeepCopyInto(out *ObjectSelector) {
+func (in *ResourceUsageRamp) DeepCopyInto(out *ResourceUsageRamp) {
 	*out = *in
-	if in.MatchNamespaces != nil {
-		in, out := &in.MatchNamespaces, &out.MatchNamespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.MatchNames != nil {
-		in, out := &in.MatchNames, &out.MatchNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.From = in.From.DeepCopy()
+	out.To = in.To.DeepCopy()
+	if in.Repeat != nil {
+		in, out := &in.Repeat, &out.Repeat
+		*out = new(bool)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSelector.
-func (in *ObjectSelector) DeepCopy() *ObjectSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageRamp.
+func (in *ResourceUsageRamp) DeepCopy() *ResourceUsageRamp {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectSelector)
+	out := new(ResourceUsageRamp)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortForward) DeepCopyInto(out *PortForward) {
+func (in *ResourceUsageSinusoidal) DeepCopyInto(out *ResourceUsageSinusoidal) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Base = in.Base.DeepCopy()
+	out.Amplitude = in.Amplitude.DeepCopy()
+	if in.PhaseMilliseconds != nil {
+		in, out := &in.PhaseMilliseconds, &out.PhaseMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortForward.
-func (in *PortForward) DeepCopy() *PortForward {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageSinusoidal.
+func (in *ResourceUsageSinusoidal) DeepCopy() *ResourceUsageSinusoidal {
 	if in == nil {
 		return nil
 	}
-	out := new(PortForward)
+	out := new(ResourceUsageSinusoidal)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PortForward) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortForwardList) DeepCopyInto(out *PortForwardList) {
+func (in *ResourceUsageSpec) DeepCopyInto(out *ResourceUsageSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PortForward, len(*in))
+	if in.Usages != nil {
+		in, out := &in.Usages, &out.Usages
+		*out = make([]ResourceUsageContainer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1249,49 +1888,46 @@ func (in *PortForwardList) DeepCopyInto(out *PortForwardList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortForwardList.
-func (in *PortForwardList) DeepCopy() *PortForwardList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageSpec.
+func (in *ResourceUsageSpec) DeepCopy() *ResourceUsageSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PortForwardList)
+	out := new(ResourceUsageSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PortForwardList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortForwardSpec) DeepCopyInto(out *PortForwardSpec) {
+func (in *ResourceUsageSpike) DeepCopyInto(out *ResourceUsageSpike) {
 	*out = *in
-	if in.Forwards != nil {
-		in, out := &in.Forwards, &out.Forwards
-		*out = make([]Forward, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.Base = in.Base.DeepCopy()
+	out.Peak = in.Peak.DeepCopy()
+	if in.AfterMilliseconds != nil {
+		in, out := &in.AfterMilliseconds, &out.AfterMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IntervalMilliseconds != nil {
+		in, out := &in.IntervalMilliseconds, &out.IntervalMilliseconds
+		*out = new(int64)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortForwardSpec.
-func (in *PortForwardSpec) DeepCopy() *PortForwardSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageSpike.
+func (in *ResourceUsageSpike) DeepCopy() *ResourceUsageSpike {
 	if in == nil {
 		return nil
 	}
-	out := new(PortForwardSpec)
+	out := new(ResourceUsageSpike)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PortForwardStatus) DeepCopyInto(out *PortForwardStatus) {
+func (in *ResourceUsageStatus) DeepCopyInto(out *ResourceUsageStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1303,12 +1939,53 @@ func (in *PortForwardStatus) DeepCopyInto(out *PortForwardStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortForwardStatus.
-func (in *PortForwardStatus) DeepCopy() *PortForwardStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageStatus.
+func (in *ResourceUsageStatus) DeepCopy() *ResourceUsageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PortForwardStatus)
+	out := new(ResourceUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageValue) DeepCopyInto(out *ResourceUsageValue) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(string)
+		**out = **in
+	}
+	if in.Sinusoidal != nil {
+		in, out := &in.Sinusoidal, &out.Sinusoidal
+		*out = new(ResourceUsageSinusoidal)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ramp != nil {
+		in, out := &in.Ramp, &out.Ramp
+		*out = new(ResourceUsageRamp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Spike != nil {
+		in, out := &in.Spike, &out.Spike
+		*out = new(ResourceUsageSpike)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageValue.
+func (in *ResourceUsageValue) DeepCopy() *ResourceUsageValue {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageValue)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1411,6 +2088,16 @@ func (in *StageDelay) DeepCopyInto(out *StageDelay) {
 		*out = new(ExpressionFromSource)
 		**out = **in
 	}
+	if in.Distribution != nil {
+		in, out := &in.Distribution, &out.Distribution
+		*out = new(StageDelayDistribution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(StageSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1424,6 +2111,42 @@ func (in *StageDelay) DeepCopy() *StageDelay {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageDelayDistribution) DeepCopyInto(out *StageDelayDistribution) {
+	*out = *in
+	if in.MinMilliseconds != nil {
+		in, out := &in.MinMilliseconds, &out.MinMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxMilliseconds != nil {
+		in, out := &in.MaxMilliseconds, &out.MaxMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MeanMilliseconds != nil {
+		in, out := &in.MeanMilliseconds, &out.MeanMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StdDevMilliseconds != nil {
+		in, out := &in.StdDevMilliseconds, &out.StdDevMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageDelayDistribution.
+func (in *StageDelayDistribution) DeepCopy() *StageDelayDistribution {
+	if in == nil {
+		return nil
+	}
+	out := new(StageDelayDistribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageEvent) DeepCopyInto(out *StageEvent) {
 	*out = *in
@@ -1453,6 +2176,16 @@ func (in *StageFinalizers) DeepCopyInto(out *StageFinalizers) {
 		*out = make([]FinalizerItem, len(*in))
 		copy(*out, *in)
 	}
+	if in.HoldDurationMilliseconds != nil {
+		in, out := &in.HoldDurationMilliseconds, &out.HoldDurationMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HoldDurationFrom != nil {
+		in, out := &in.HoldDurationFrom, &out.HoldDurationFrom
+		*out = new(ExpressionFromSource)
+		**out = **in
+	}
 	return
 }
 
@@ -1507,11 +2240,33 @@ func (in *StageNext) DeepCopyInto(out *StageNext) {
 		*out = new(StageEvent)
 		**out = **in
 	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]StageEvent, len(*in))
+		copy(*out, *in)
+	}
 	if in.Finalizers != nil {
 		in, out := &in.Finalizers, &out.Finalizers
 		*out = new(StageFinalizers)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(StageWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]StagePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subresources != nil {
+		in, out := &in.Subresources, &out.Subresources
+		*out = make([]StageSubresource, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1525,6 +2280,27 @@ func (in *StageNext) DeepCopy() *StageNext {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StagePatch) DeepCopyInto(out *StagePatch) {
+	*out = *in
+	if in.DelayMilliseconds != nil {
+		in, out := &in.DelayMilliseconds, &out.DelayMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StagePatch.
+func (in *StagePatch) DeepCopy() *StagePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(StagePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageResourceRef) DeepCopyInto(out *StageResourceRef) {
 	*out = *in
@@ -1541,6 +2317,31 @@ func (in *StageResourceRef) DeepCopy() *StageResourceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageSchedule) DeepCopyInto(out *StageSchedule) {
+	*out = *in
+	if in.At != nil {
+		in, out := &in.At, &out.At
+		*out = (*in).DeepCopy()
+	}
+	if in.AfterStartMilliseconds != nil {
+		in, out := &in.AfterStartMilliseconds, &out.AfterStartMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageSchedule.
+func (in *StageSchedule) DeepCopy() *StageSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(StageSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageSelector) DeepCopyInto(out *StageSelector) {
 	*out = *in
@@ -1587,6 +2388,11 @@ func (in *StageSpec) DeepCopyInto(out *StageSpec) {
 		*out = new(StageSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WeightFrom != nil {
+		in, out := &in.WeightFrom, &out.WeightFrom
+		*out = new(ExpressionFromSource)
+		**out = **in
+	}
 	if in.Delay != nil {
 		in, out := &in.Delay, &out.Delay
 		*out = new(StageDelay)
@@ -1633,3 +2439,40 @@ func (in *StageStatus) DeepCopy() *StageStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageSubresource) DeepCopyInto(out *StageSubresource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageSubresource.
+func (in *StageSubresource) DeepCopy() *StageSubresource {
+	if in == nil {
+		return nil
+	}
+	out := new(StageSubresource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageWebhook) DeepCopyInto(out *StageWebhook) {
+	*out = *in
+	if in.TimeoutMilliseconds != nil {
+		in, out := &in.TimeoutMilliseconds, &out.TimeoutMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageWebhook.
+func (in *StageWebhook) DeepCopy() *StageWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(StageWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
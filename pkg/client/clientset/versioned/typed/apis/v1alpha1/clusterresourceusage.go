@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+	v1alpha1 "sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+	scheme "sigs.k8s.io/kwok/pkg/client/clientset/versioned/scheme"
+)
+
+// ClusterResourceUsagesGetter has a method to return a ClusterResourceUsageInterface.
+// A group's client should implement this interface.
+type ClusterResourceUsagesGetter interface {
+	ClusterResourceUsages() ClusterResourceUsageInterface
+}
+
+// ClusterResourceUsageInterface has methods to work with ClusterResourceUsage resources.
+type ClusterResourceUsageInterface interface {
+	Create(ctx context.Context, clusterResourceUsage *v1alpha1.ClusterResourceUsage, opts v1.CreateOptions) (*v1alpha1.ClusterResourceUsage, error)
+	Update(ctx context.Context, clusterResourceUsage *v1alpha1.ClusterResourceUsage, opts v1.UpdateOptions) (*v1alpha1.ClusterResourceUsage, error)
+	UpdateStatus(ctx context.Context, clusterResourceUsage *v1alpha1.ClusterResourceUsage, opts v1.UpdateOptions) (*v1alpha1.ClusterResourceUsage, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ClusterResourceUsage, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ClusterResourceUsageList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterResourceUsage, err error)
+	ClusterResourceUsageExpansion
+}
+
+// clusterResourceUsages implements ClusterResourceUsageInterface
+type clusterResourceUsages struct {
+	client rest.Interface
+}
+
+// newClusterResourceUsages returns a ClusterResourceUsages
+func newClusterResourceUsages(c *KwokV1alpha1Client) *clusterResourceUsages {
+	return &clusterResourceUsages{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterResourceUsage, and returns the corresponding clusterResourceUsage object, and an error if there is any.
+func (c *clusterResourceUsages) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterResourceUsage, err error) {
+	result = &v1alpha1.ClusterResourceUsage{}
+	err = c.client.Get().
+		Resource("clusterresourceusages").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterResourceUsages that match those selectors.
+func (c *clusterResourceUsages) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterResourceUsageList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ClusterResourceUsageList{}
+	err = c.client.Get().
+		Resource("clusterresourceusages").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterResourceUsages.
+func (c *clusterResourceUsages) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterresourceusages").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterResourceUsage and creates it.  Returns the server's representation of the clusterResourceUsage, and an error, if there is any.
+func (c *clusterResourceUsages) Create(ctx context.Context, clusterResourceUsage *v1alpha1.ClusterResourceUsage, opts v1.CreateOptions) (result *v1alpha1.ClusterResourceUsage, err error) {
+	result = &v1alpha1.ClusterResourceUsage{}
+	err = c.client.Post().
+		Resource("clusterresourceusages").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterResourceUsage).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterResourceUsage and updates it. Returns the server's representation of the clusterResourceUsage, and an error, if there is any.
+func (c *clusterResourceUsages) Update(ctx context.Context, clusterResourceUsage *v1alpha1.ClusterResourceUsage, opts v1.UpdateOptions) (result *v1alpha1.ClusterResourceUsage, err error) {
+	result = &v1alpha1.ClusterResourceUsage{}
+	err = c.client.Put().
+		Resource("clusterresourceusages").
+		Name(clusterResourceUsage.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterResourceUsage).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *clusterResourceUsages) UpdateStatus(ctx context.Context, clusterResourceUsage *v1alpha1.ClusterResourceUsage, opts v1.UpdateOptions) (result *v1alpha1.ClusterResourceUsage, err error) {
+	result = &v1alpha1.ClusterResourceUsage{}
+	err = c.client.Put().
+		Resource("clusterresourceusages").
+		Name(clusterResourceUsage.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterResourceUsage).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterResourceUsage and deletes it. Returns an error if one occurs.
+func (c *clusterResourceUsages) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterresourceusages").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterResourceUsages) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterresourceusages").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterResourceUsage.
+func (c *clusterResourceUsages) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterResourceUsage, err error) {
+	result = &v1alpha1.ClusterResourceUsage{}
+	err = c.client.Patch(pt).
+		Resource("clusterresourceusages").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+)
+
+// FakeResourceUsages implements ResourceUsageInterface
+type FakeResourceUsages struct {
+	Fake *FakeKwokV1alpha1
+	ns   string
+}
+
+var resourceusagesResource = v1alpha1.SchemeGroupVersion.WithResource("resourceusages")
+
+var resourceusagesKind = v1alpha1.SchemeGroupVersion.WithKind("ResourceUsage")
+
+// Get takes name of the resourceUsage, and returns the corresponding resourceUsage object, and an error if there is any.
+func (c *FakeResourceUsages) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ResourceUsage, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(resourceusagesResource, c.ns, name), &v1alpha1.ResourceUsage{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ResourceUsage), err
+}
+
+// List takes label and field selectors, and returns the list of ResourceUsages that match those selectors.
+func (c *FakeResourceUsages) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ResourceUsageList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(resourceusagesResource, resourceusagesKind, c.ns, opts), &v1alpha1.ResourceUsageList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ResourceUsageList{ListMeta: obj.(*v1alpha1.ResourceUsageList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ResourceUsageList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested resourceUsages.
+func (c *FakeResourceUsages) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(resourceusagesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a resourceUsage and creates it.  Returns the server's representation of the resourceUsage, and an error, if there is any.
+func (c *FakeResourceUsages) Create(ctx context.Context, resourceUsage *v1alpha1.ResourceUsage, opts v1.CreateOptions) (result *v1alpha1.ResourceUsage, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(resourceusagesResource, c.ns, resourceUsage), &v1alpha1.ResourceUsage{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ResourceUsage), err
+}
+
+// Update takes the representation of a resourceUsage and updates it. Returns the server's representation of the resourceUsage, and an error, if there is any.
+func (c *FakeResourceUsages) Update(ctx context.Context, resourceUsage *v1alpha1.ResourceUsage, opts v1.UpdateOptions) (result *v1alpha1.ResourceUsage, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(resourceusagesResource, c.ns, resourceUsage), &v1alpha1.ResourceUsage{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ResourceUsage), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeResourceUsages) UpdateStatus(ctx context.Context, resourceUsage *v1alpha1.ResourceUsage, opts v1.UpdateOptions) (*v1alpha1.ResourceUsage, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(resourceusagesResource, "status", c.ns, resourceUsage), &v1alpha1.ResourceUsage{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ResourceUsage), err
+}
+
+// Delete takes name of the resourceUsage and deletes it. Returns an error if one occurs.
+func (c *FakeResourceUsages) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(resourceusagesResource, c.ns, name, opts), &v1alpha1.ResourceUsage{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeResourceUsages) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(resourceusagesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ResourceUsageList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched resourceUsage.
+func (c *FakeResourceUsages) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ResourceUsage, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(resourceusagesResource, c.ns, name, pt, data, subresources...), &v1alpha1.ResourceUsage{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ResourceUsage), err
+}
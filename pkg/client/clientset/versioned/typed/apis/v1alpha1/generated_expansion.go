@@ -28,6 +28,8 @@ type ClusterLogsExpansion interface{}
 
 type ClusterPortForwardExpansion interface{}
 
+type ClusterResourceUsageExpansion interface{}
+
 type ExecExpansion interface{}
 
 type LogsExpansion interface{}
@@ -36,4 +38,6 @@ type MetricExpansion interface{}
 
 type PortForwardExpansion interface{}
 
+type ResourceUsageExpansion interface{}
+
 type StageExpansion interface{}
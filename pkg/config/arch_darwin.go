@@ -0,0 +1,33 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// hostArch returns the architecture of the host,
+// which is arm64 if kwokctl built for amd64 is translated by Rosetta on Apple silicon.
+func hostArch() string {
+	if GOARCH == "amd64" {
+		translated, err := unix.SysctlUint32("sysctl.proc_translated")
+		if err == nil && translated == 1 {
+			return "arm64"
+		}
+	}
+	return GOARCH
+}
@@ -167,6 +167,18 @@ var configHandlers = map[string]configHandler{
 		MutateToInternal: mutateToInternalConfig(internalversion.ConvertToInternalMetric),
 		MutateToVersiond: mutateToVersiondConfig(internalversion.ConvertToV1Alpha1Metric),
 	},
+	v1alpha1.ResourceUsageKind: {
+		Unmarshal:        unmarshalConfig[*v1alpha1.ResourceUsage],
+		Marshal:          marshalConfig,
+		MutateToInternal: mutateToInternalConfig(internalversion.ConvertToInternalResourceUsage),
+		MutateToVersiond: mutateToVersiondConfig(internalversion.ConvertToV1Alpha1ResourceUsage),
+	},
+	v1alpha1.ClusterResourceUsageKind: {
+		Unmarshal:        unmarshalConfig[*v1alpha1.ClusterResourceUsage],
+		Marshal:          marshalConfig,
+		MutateToInternal: mutateToInternalConfig(internalversion.ConvertToInternalClusterResourceUsage),
+		MutateToVersiond: mutateToVersiondConfig(internalversion.ConvertToV1Alpha1ClusterResourceUsage),
+	},
 }
 
 func unmarshalConfig[T versiondObject](raw []byte) (versiondObject, error) {
@@ -261,7 +273,12 @@ func Load(ctx context.Context, src ...string) ([]InternalObject, error) {
 	if err != nil {
 		return nil, err
 	}
+	return load(ctx, raws, src)
+}
 
+// load converts the raw configurations loaded from the src to the internal objects,
+// the configurations of the same kind which are merged are overridden by the latter ones.
+func load(ctx context.Context, raws []json.RawMessage, src []string) ([]InternalObject, error) {
 	result := map[string][]versiondObject{}
 
 	logger := log.FromContext(ctx)
@@ -36,6 +36,11 @@ func setupContext(ctx context.Context, objs []InternalObject) context.Context {
 	return context.WithValue(ctx, configCtx(0), val)
 }
 
+// NewContext returns a new context with the given objects, which replace the ones loaded from the config files.
+func NewContext(ctx context.Context, objs []InternalObject) context.Context {
+	return setupContext(ctx, objs)
+}
+
 // addToContext adds the given objects to the context.
 func addToContext(ctx context.Context, objs ...InternalObject) {
 	v := ctx.Value(configCtx(0))
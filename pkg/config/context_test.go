@@ -54,3 +54,27 @@ func TestContext(t *testing.T) {
 		t.Errorf("unexpected objects (-want +got):\n%s", diff)
 	}
 }
+
+func TestNewContext(t *testing.T) {
+	ctx := context.Background()
+	ctx = setupContext(ctx, []InternalObject{
+		&metav1.ObjectMeta{
+			Name: "loaded",
+		},
+	})
+
+	ctx = NewContext(ctx, []InternalObject{
+		&metav1.ObjectMeta{
+			Name: "spec",
+		},
+	})
+
+	want := []InternalObject{
+		&metav1.ObjectMeta{
+			Name: "spec",
+		},
+	}
+	if diff := cmp.Diff(want, GetFromContext(ctx)); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+}
@@ -19,6 +19,7 @@ package config
 import (
 	"context"
 	"os"
+	"strings"
 
 	"github.com/spf13/pflag"
 
@@ -29,10 +30,20 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
 
+// archFlag is the architecture given by the flag --arch, which overrides the one in the configurations.
+var archFlag string
+
+// InitArchFlag adds the flag --arch to override the architecture of the binaries and images of the components,
+// it has to be added before InitFlags parses the flags.
+func InitArchFlag(flags *pflag.FlagSet) {
+	flags.StringVar(&archFlag, "arch", archFlag, "Architecture of the binaries and images of the components, e.g. amd64 or arm64, the architecture of the host by default")
+}
+
 // InitFlags initializes the flags for the configuration.
 func InitFlags(ctx context.Context, flags *pflag.FlagSet) (context.Context, error) {
 	defaultConfigPath := path.RelFromHome(path.Join(WorkDir, consts.ConfigName))
 	config := flags.StringSliceP("config", "c", []string{defaultConfigPath}, "config path")
+	profiles := flags.StringSlice("profile", nil, "Profile of the configurations loaded before the config path, one of "+strings.Join(BuiltinProfiles(), ", ")+" or a custom one in "+path.RelFromHome(path.Join(ProfilesDir, "<profile>.yaml")))
 	_ = flags.Parse(os.Args[1:])
 
 	// Expand the all config paths.
@@ -56,7 +67,15 @@ func InitFlags(ctx context.Context, flags *pflag.FlagSet) (context.Context, erro
 	configPaths = loadConfig(configPaths, defaultConfigPath, file.Exists(defaultConfigPath))
 
 	logger := log.FromContext(ctx)
-	objs, err := Load(ctx, configPaths...)
+	raws, err := loadProfiles(*profiles)
+	if err != nil {
+		return nil, err
+	}
+	configRaws, err := loadRawMessages(configPaths)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := load(ctx, append(raws, configRaws...), configPaths)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +87,7 @@ func InitFlags(ctx context.Context, flags *pflag.FlagSet) (context.Context, erro
 		)
 	} else {
 		logger.Debug("Load config",
+			"profile", *profiles,
 			"path", configPaths,
 			"count", len(objs),
 			"content", objs,
@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kwok/kustomize/metrics/cadvisor"
+	metricsdcgm "sigs.k8s.io/kwok/kustomize/metrics/dcgm"
+	metricsenergy "sigs.k8s.io/kwok/kustomize/metrics/energy"
+	metricspatterns "sigs.k8s.io/kwok/kustomize/metrics/patterns"
+	metricsresource "sigs.k8s.io/kwok/kustomize/metrics/resource"
+	podfast "sigs.k8s.io/kwok/kustomize/stage/pod/fast"
+	podgeneral "sigs.k8s.io/kwok/kustomize/stage/pod/general"
+	podjob "sigs.k8s.io/kwok/kustomize/stage/pod/job"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+var (
+	//go:embed profiles/scheduler-benchmark.yaml
+	schedulerBenchmarkProfile string
+
+	//go:embed profiles/autoscaler-dev.yaml
+	autoscalerDevProfile string
+)
+
+// builtinProfiles is the profiles provided by kwok, each of which bundles the configurations for a common setup
+var builtinProfiles = map[string][]string{
+	"scheduler-benchmark": {
+		schedulerBenchmarkProfile,
+	},
+	"autoscaler-dev": {
+		autoscalerDevProfile,
+		podgeneral.DefaultPodCreate,
+		podgeneral.DefaultPodImagePullFailed,
+		podgeneral.DefaultPodInitContainerRunning,
+		podgeneral.DefaultPodInitContainerCompleted,
+		podgeneral.DefaultPodReady,
+		podgeneral.DefaultPodComplete,
+		podgeneral.DefaultPodRemoveFinalizer,
+		podgeneral.DefaultPodDelete,
+	},
+	"pod-fast-job": {
+		podfast.DefaultPodReady,
+		podjob.DefaultPodJobComplete,
+		podjob.DefaultPodJobFail,
+		podfast.DefaultPodDelete,
+	},
+	"pod-general-job": {
+		podgeneral.DefaultPodCreate,
+		podgeneral.DefaultPodImagePullFailed,
+		podgeneral.DefaultPodInitContainerRunning,
+		podgeneral.DefaultPodInitContainerCompleted,
+		podgeneral.DefaultPodReady,
+		podjob.DefaultPodJobComplete,
+		podjob.DefaultPodJobFail,
+		podgeneral.DefaultPodRemoveFinalizer,
+		podgeneral.DefaultPodDelete,
+	},
+	"kubelet-metrics": {
+		cadvisor.DefaultMetricsCadvisor,
+		metricsresource.DefaultMetricsResource,
+	},
+	"gpu-metrics": {
+		metricsdcgm.DefaultMetricsDCGM,
+	},
+	"energy-metrics": {
+		metricsenergy.DefaultMetricsEnergy,
+	},
+	"web-service": {
+		metricspatterns.WebService,
+	},
+	"batch": {
+		metricspatterns.Batch,
+	},
+	"ml-training": {
+		metricspatterns.MLTraining,
+	},
+}
+
+// BuiltinProfiles returns the names of the profiles provided by kwok
+func BuiltinProfiles() []string {
+	names := maps.Keys(builtinProfiles)
+	sort.Strings(names)
+	return names
+}
+
+// loadProfiles loads the configurations of the profiles,
+// a custom profile in the profiles directory takes precedence over the built-in one with the same name.
+func loadProfiles(names []string) ([]json.RawMessage, error) {
+	var raws []json.RawMessage
+	for _, name := range names {
+		p := path.Join(ProfilesDir, name+".yaml")
+		if file.Exists(p) {
+			r, err := loadRawFromFile(p)
+			if err != nil {
+				return nil, err
+			}
+			raws = append(raws, r...)
+			continue
+		}
+
+		profile, ok := builtinProfiles[name]
+		if !ok {
+			return nil, fmt.Errorf("profile %q is neither in %s nor one of the built-in profiles %v", name, ProfilesDir, BuiltinProfiles())
+		}
+		for _, data := range profile {
+			r, err := loadRaw(strings.NewReader(data))
+			if err != nil {
+				return nil, err
+			}
+			raws = append(raws, r...)
+		}
+	}
+	return raws, nil
+}
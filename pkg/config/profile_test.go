@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func Test_loadProfiles(t *testing.T) {
+	profilesDir := ProfilesDir
+	ProfilesDir = t.TempDir()
+	defer func() {
+		ProfilesDir = profilesDir
+	}()
+
+	err := os.WriteFile(filepath.Join(ProfilesDir, "custom.yaml"), []byte(`
+kind: KwokctlConfiguration
+apiVersion: config.kwok.x-k8s.io/v1alpha1
+options:
+  nodes: 10
+`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		profiles   []string
+		wantNodes  uint32
+		wantStage  int
+		wantMetric int
+		wantUsage  int
+		wantNames  []string
+		wantErr    bool
+	}{
+		{
+			name:      "built-in profile",
+			profiles:  []string{"scheduler-benchmark"},
+			wantNodes: 1000,
+		},
+		{
+			name:      "built-in profile with stages",
+			profiles:  []string{"autoscaler-dev"},
+			wantNodes: 3,
+			wantStage: 8,
+		},
+		{
+			name:      "built-in profile of the pod fast stages with jobs",
+			profiles:  []string{"pod-fast-job"},
+			wantStage: 4,
+			wantNames: []string{"pod-ready", "pod-job-complete", "pod-job-fail", "pod-delete"},
+		},
+		{
+			name:      "built-in profile of the pod general stages with jobs",
+			profiles:  []string{"pod-general-job"},
+			wantStage: 9,
+			wantNames: []string{"pod-create", "pod-job-complete", "pod-job-fail", "pod-delete"},
+		},
+		{
+			name:       "built-in profile with metrics",
+			profiles:   []string{"kubelet-metrics"},
+			wantMetric: 2,
+		},
+		{
+			name:       "built-in profile of gpu metrics",
+			profiles:   []string{"gpu-metrics"},
+			wantMetric: 1,
+		},
+		{
+			name:       "built-in profile of energy metrics",
+			profiles:   []string{"energy-metrics"},
+			wantMetric: 1,
+		},
+		{
+			name:       "built-in profile of workload pattern",
+			profiles:   []string{"web-service"},
+			wantMetric: 1,
+			wantUsage:  1,
+		},
+		{
+			name:       "built-in profiles of workload patterns",
+			profiles:   []string{"kubelet-metrics", "batch", "ml-training"},
+			wantMetric: 4,
+			wantUsage:  2,
+		},
+		{
+			name:      "custom profile",
+			profiles:  []string{"custom"},
+			wantNodes: 10,
+		},
+		{
+			name:      "latter profile overrides",
+			profiles:  []string{"autoscaler-dev", "custom"},
+			wantNodes: 10,
+			wantStage: 8,
+		},
+		{
+			name:     "unknown profile",
+			profiles: []string{"unknown"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raws, err := loadProfiles(tt.profiles)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadProfiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			objs, err := load(context.Background(), raws, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := setupContext(context.Background(), objs)
+
+			conf := GetKwokctlConfiguration(ctx)
+			if conf.Options.Nodes != tt.wantNodes {
+				t.Errorf("want nodes %d, got %d", tt.wantNodes, conf.Options.Nodes)
+			}
+			stages := FilterWithTypeFromContext[*internalversion.Stage](ctx)
+			if len(stages) != tt.wantStage {
+				t.Errorf("want %d stages, got %d", tt.wantStage, len(stages))
+			}
+			for _, name := range tt.wantNames {
+				_, ok := slices.Find(stages, func(stage *internalversion.Stage) bool {
+					return stage.Name == name
+				})
+				if !ok {
+					t.Errorf("want stage %s", name)
+				}
+			}
+			metrics := FilterWithTypeFromContext[*internalversion.Metric](ctx)
+			if len(metrics) != tt.wantMetric {
+				t.Errorf("want %d metrics, got %d", tt.wantMetric, len(metrics))
+			}
+			usages := FilterWithTypeFromContext[*internalversion.ClusterResourceUsage](ctx)
+			if len(usages) != tt.wantUsage {
+				t.Errorf("want %d cluster resource usages, got %d", tt.wantUsage, len(usages))
+			}
+		})
+	}
+}
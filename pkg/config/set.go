@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "sigs.k8s.io/kwok/pkg/apis/config/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// SetKwokctlOption sets the option of the KwokctlConfiguration in the config file p to the value,
+// which is parsed as YAML, or as a string if it does not fit the option.
+// Only the given option is written, so the options not set are still defaulted when the file is loaded,
+// and the other contents of the file are kept.
+func SetKwokctlOption(ctx context.Context, p string, key string, value string) error {
+	var raws []json.RawMessage
+	if file.Exists(p) {
+		r, err := loadRawFromFile(p)
+		if err != nil {
+			return err
+		}
+		raws = r
+	}
+
+	index := -1
+	obj := map[string]interface{}{}
+	meta := metav1.TypeMeta{}
+	for i, raw := range raws {
+		err := json.Unmarshal(raw, &meta)
+		if err != nil {
+			continue
+		}
+		if meta.Kind == configv1alpha1.KwokctlConfigurationKind {
+			err = json.Unmarshal(raw, &obj)
+			if err != nil {
+				return err
+			}
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		obj["apiVersion"] = configv1alpha1.GroupVersion.String()
+		obj["kind"] = configv1alpha1.KwokctlConfigurationKind
+	}
+
+	options, _ := obj["options"].(map[string]interface{})
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+
+	var val interface{}
+	err := yaml.Unmarshal([]byte(value), &val)
+	if err != nil {
+		val = value
+	}
+	options[key] = val
+	err = checkKwokctlOptions(options)
+	if err != nil {
+		// The value like 1.28 is parsed as a number, retry it as a string.
+		options[key] = value
+		if checkKwokctlOptions(options) != nil {
+			return fmt.Errorf("invalid option %q: %w", key, err)
+		}
+	}
+	obj["options"] = options
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	if index == -1 {
+		raws = append(raws, raw)
+	} else {
+		raws[index] = raw
+	}
+
+	buf := bytes.NewBuffer(nil)
+	for i, raw := range raws {
+		if i != 0 {
+			_, _ = buf.WriteString("---\n")
+		}
+		data, err := yaml.JSONToYAML(raw)
+		if err != nil {
+			return err
+		}
+		_, _ = buf.Write(data)
+	}
+
+	err = file.MkdirAll(path.Dir(p))
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Debug("Set option",
+		"path", p,
+		"key", key,
+		"value", options[key],
+	)
+	return file.Write(p, buf.Bytes())
+}
+
+// checkKwokctlOptions checks the options are all known and of the right types.
+func checkKwokctlOptions(options map[string]interface{}) error {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(&configv1alpha1.KwokctlConfigurationOptions{})
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestSetKwokctlOption(t *testing.T) {
+	ctx := context.Background()
+	p := filepath.Join(t.TempDir(), "kwok.yaml")
+	err := os.WriteFile(p, []byte(`kind: Stage
+apiVersion: kwok.x-k8s.io/v1alpha1
+metadata:
+  name: test
+`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, kv := range [][2]string{
+		{"kubeVersion", "v1.27.0"},
+		{"kubeApiserverPort", "6443"},
+		{"disableQPSLimits", "true"},
+		{"kubeVersion", "1.26"},
+	} {
+		err = SetKwokctlOption(ctx, p, kv[0], kv[1])
+		if err != nil {
+			t.Fatalf("set %s=%s: %v", kv[0], kv[1], err)
+		}
+	}
+
+	err = SetKwokctlOption(ctx, p, "unknownOption", "1")
+	if err == nil {
+		t.Fatal("want error for the unknown option")
+	}
+	err = SetKwokctlOption(ctx, p, "kubeApiserverPort", "abc")
+	if err == nil {
+		t.Fatal("want error for the invalid value")
+	}
+
+	objs, err := Load(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(FilterWithType[*internalversion.Stage](objs)) != 1 {
+		t.Fatalf("want the stage kept, got %v", objs)
+	}
+	confs := FilterWithType[*internalversion.KwokctlConfiguration](objs)
+	if len(confs) != 1 {
+		t.Fatalf("want one KwokctlConfiguration, got %v", objs)
+	}
+	conf := confs[0].Options
+	if conf.KubeVersion != "v1.26" {
+		t.Errorf("want kubeVersion v1.26, got %q", conf.KubeVersion)
+	}
+	if conf.KubeApiserverPort != 6443 {
+		t.Errorf("want kubeApiserverPort 6443, got %d", conf.KubeApiserverPort)
+	}
+	if !conf.DisableQPSLimits {
+		t.Errorf("want disableQPSLimits true")
+	}
+}
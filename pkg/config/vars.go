@@ -44,6 +44,15 @@ var (
 	// ClustersDir is the directory of the clusters.
 	ClustersDir = path.Join(WorkDir, "clusters")
 
+	// ProfilesDir is the directory of the custom profiles.
+	ProfilesDir = path.Join(WorkDir, "profiles")
+
+	// PortsDir is the directory of the files reserving the ports for the clusters.
+	PortsDir = path.Join(WorkDir, "ports")
+
+	// PluginsDir is the directory of the plugins of the runtimes out of the tree.
+	PluginsDir = path.Join(WorkDir, "plugins")
+
 	// GOOS is the operating system target for which the code is compiled.
 	GOOS = runtime.GOOS
 
@@ -192,6 +201,22 @@ func setKwokctlConfigurationDefaults(config *configv1alpha1.KwokctlConfiguration
 
 	conf.QuietPull = format.Ptr(envs.GetEnvWithPrefix("QUIET_PULL", *conf.QuietPull))
 
+	conf.Offline = format.Ptr(envs.GetEnvWithPrefix("OFFLINE", *conf.Offline))
+
+	conf.Nodes = envs.GetEnvWithPrefix("NODES", conf.Nodes)
+
+	conf.PortRange = envs.GetEnvWithPrefix("PORT_RANGE", conf.PortRange)
+
+	conf.ComponentsCPULimit = envs.GetEnvWithPrefix("COMPONENTS_CPU_LIMIT", conf.ComponentsCPULimit)
+
+	conf.ComponentsMemoryLimit = envs.GetEnvWithPrefix("COMPONENTS_MEMORY_LIMIT", conf.ComponentsMemoryLimit)
+
+	conf.ComponentsRestartBackoff = envs.GetEnvWithPrefix("COMPONENTS_RESTART_BACKOFF", conf.ComponentsRestartBackoff)
+
+	conf.KubeconfigContext = format.Ptr(envs.GetEnvWithPrefix("KUBECONFIG_CONTEXT", *conf.KubeconfigContext))
+
+	conf.EnableIPv6 = format.Ptr(envs.GetEnvWithPrefix("ENABLE_IPV6", *conf.EnableIPv6))
+
 	conf.Runtime = envs.GetEnvWithPrefix("RUNTIME", conf.Runtime)
 	if conf.Runtime == "" && len(conf.Runtimes) == 0 {
 		conf.Runtimes = []string{
@@ -204,6 +229,11 @@ func setKwokctlConfigurationDefaults(config *configv1alpha1.KwokctlConfiguration
 				consts.RuntimeTypeNerdctl,
 				consts.RuntimeTypeBinary,
 			)
+		} else if GOOS == windows {
+			// The binary runtime is the fallback of the hosts without docker
+			conf.Runtimes = append(conf.Runtimes,
+				consts.RuntimeTypeBinary,
+			)
 		}
 	}
 	if conf.Runtime == "" && len(conf.Runtimes) == 1 {
@@ -216,12 +246,22 @@ func setKwokctlConfigurationDefaults(config *configv1alpha1.KwokctlConfiguration
 		conf.CacheDir = path.Join(WorkDir, "cache")
 	}
 
+	conf.CacheMaxSize = envs.GetEnvWithPrefix("CACHE_MAX_SIZE", conf.CacheMaxSize)
+
 	if conf.BinSuffix == "" {
 		if GOOS == windows {
 			conf.BinSuffix = ".exe"
 		}
 	}
 
+	if conf.Arch == "" {
+		conf.Arch = hostArch()
+	}
+	conf.Arch = envs.GetEnvWithPrefix("ARCH", conf.Arch)
+	if archFlag != "" {
+		conf.Arch = archFlag
+	}
+
 	// Disable node lease duration seconds for kubernetes < 1.14
 	if conf.NodeLeaseDurationSeconds != 0 {
 		minor := parseRelease(conf.KubeVersion)
@@ -246,12 +286,21 @@ func setKwokctlConfigurationDefaults(config *configv1alpha1.KwokctlConfiguration
 
 	setKwokctlJaegerConfig(conf)
 
+	setKwokctlGrafanaConfig(conf)
+
 	return config
 }
 
 func setKwokctlKubernetesConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	conf.DisableKubeScheduler = format.Ptr(envs.GetEnvWithPrefix("DISABLE_KUBE_SCHEDULER", *conf.DisableKubeScheduler))
 	conf.DisableKubeControllerManager = format.Ptr(envs.GetEnvWithPrefix("DISABLE_KUBE_CONTROLLER_MANAGER", *conf.DisableKubeControllerManager))
+	conf.EnableMetricsServer = format.Ptr(envs.GetEnvWithPrefix("ENABLE_METRICS_SERVER", *conf.EnableMetricsServer))
+	conf.EnableCustomMetrics = format.Ptr(envs.GetEnvWithPrefix("ENABLE_CUSTOM_METRICS", *conf.EnableCustomMetrics))
+	conf.EnableGrafana = format.Ptr(envs.GetEnvWithPrefix("ENABLE_GRAFANA", *conf.EnableGrafana))
+
+	conf.KubeApiserverReplicas = envs.GetEnvWithPrefix("KUBE_APISERVER_REPLICAS", conf.KubeApiserverReplicas)
+	conf.KubeControllerManagerReplicas = envs.GetEnvWithPrefix("KUBE_CONTROLLER_MANAGER_REPLICAS", conf.KubeControllerManagerReplicas)
+	conf.KubeSchedulerReplicas = envs.GetEnvWithPrefix("KUBE_SCHEDULER_REPLICAS", conf.KubeSchedulerReplicas)
 
 	conf.KubeAuthorization = format.Ptr(envs.GetEnvWithPrefix("KUBE_AUTHORIZATION", *conf.KubeAuthorization))
 	conf.KubeAdmission = envs.GetEnvWithPrefix("KUBE_ADMISSION", conf.KubeAdmission)
@@ -274,8 +323,10 @@ func setKwokctlKubernetesConfig(conf *configv1alpha1.KwokctlConfigurationOptions
 
 	conf.KubeAuditPolicy = envs.GetEnvWithPrefix("KUBE_AUDIT_POLICY", conf.KubeAuditPolicy)
 
+	conf.KubeAuditWebhook = envs.GetEnvWithPrefix("KUBE_AUDIT_WEBHOOK", conf.KubeAuditWebhook)
+
 	if conf.KubeBinaryPrefix == "" {
-		conf.KubeBinaryPrefix = consts.KubeBinaryPrefix + "/" + conf.KubeVersion + "/bin/" + GOOS + "/" + GOARCH
+		conf.KubeBinaryPrefix = consts.KubeBinaryPrefix + "/" + conf.KubeVersion + "/bin/" + GOOS + "/" + conf.Arch
 	}
 	conf.KubeBinaryPrefix = envs.GetEnvWithPrefix("KUBE_BINARY_PREFIX", conf.KubeBinaryPrefix)
 
@@ -331,7 +382,7 @@ func setKwokctlKwokConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	conf.KwokBinaryPrefix = envs.GetEnvWithPrefix("BINARY_PREFIX", conf.KwokBinaryPrefix)
 
 	if conf.KwokControllerBinary == "" {
-		conf.KwokControllerBinary = conf.KwokBinaryPrefix + "/kwok-" + GOOS + "-" + GOARCH + conf.BinSuffix
+		conf.KwokControllerBinary = conf.KwokBinaryPrefix + "/kwok-" + GOOS + "-" + conf.Arch + conf.BinSuffix
 	}
 	conf.KwokControllerBinary = envs.GetEnvWithPrefix("CONTROLLER_BINARY", conf.KwokControllerBinary)
 
@@ -361,7 +412,7 @@ func setKwokctlEtcdConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	conf.EtcdBinary = envs.GetEnvWithPrefix("ETCD_BINARY", conf.EtcdBinary)
 
 	if conf.EtcdBinaryTar == "" {
-		conf.EtcdBinaryTar = conf.EtcdBinaryPrefix + "/etcd-v" + strings.TrimSuffix(conf.EtcdVersion, "-0") + "-" + GOOS + "-" + GOARCH + "." + func() string {
+		conf.EtcdBinaryTar = conf.EtcdBinaryPrefix + "/etcd-v" + strings.TrimSuffix(conf.EtcdVersion, "-0") + "-" + GOOS + "-" + conf.Arch + "." + func() string {
 			if GOOS == linux {
 				return binarySuffixTar
 			}
@@ -370,6 +421,13 @@ func setKwokctlEtcdConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	}
 	conf.EtcdBinaryTar = envs.GetEnvWithPrefix("ETCD_BINARY_TAR", conf.EtcdBinaryTar)
 
+	conf.EtcdCaFile = envs.GetEnvWithPrefix("ETCD_CAFILE", conf.EtcdCaFile)
+	conf.EtcdCertFile = envs.GetEnvWithPrefix("ETCD_CERTFILE", conf.EtcdCertFile)
+	conf.EtcdKeyFile = envs.GetEnvWithPrefix("ETCD_KEYFILE", conf.EtcdKeyFile)
+	conf.EtcdPrefix = envs.GetEnvWithPrefix("ETCD_PREFIX", conf.EtcdPrefix)
+	conf.EtcdAutoCompactionMode = envs.GetEnvWithPrefix("ETCD_AUTO_COMPACTION_MODE", conf.EtcdAutoCompactionMode)
+	conf.EtcdAutoCompactionRetention = envs.GetEnvWithPrefix("ETCD_AUTO_COMPACTION_RETENTION", conf.EtcdAutoCompactionRetention)
+
 	if conf.EtcdImagePrefix == "" {
 		conf.EtcdImagePrefix = conf.KubeImagePrefix
 	}
@@ -405,7 +463,7 @@ func setKwokctlKindConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	conf.KindBinaryPrefix = envs.GetEnvWithPrefix("KIND_BINARY_PREFIX", conf.KindBinaryPrefix)
 
 	if conf.KindBinary == "" {
-		conf.KindBinary = conf.KindBinaryPrefix + "/kind-" + GOOS + "-" + GOARCH + conf.BinSuffix
+		conf.KindBinary = conf.KindBinaryPrefix + "/kind-" + GOOS + "-" + conf.Arch + conf.BinSuffix
 	}
 	conf.KindBinary = envs.GetEnvWithPrefix("KIND_BINARY", conf.KindBinary)
 }
@@ -422,7 +480,7 @@ func setKwokctlDockerConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	conf.DockerComposeBinaryPrefix = envs.GetEnvWithPrefix("DOCKER_COMPOSE_BINARY_PREFIX", conf.DockerComposeBinaryPrefix)
 
 	if conf.DockerComposeBinary == "" {
-		conf.DockerComposeBinary = conf.DockerComposeBinaryPrefix + "/docker-compose-" + GOOS + "-" + archAlias(GOARCH) + conf.BinSuffix
+		conf.DockerComposeBinary = conf.DockerComposeBinaryPrefix + "/docker-compose-" + GOOS + "-" + archAlias(conf.Arch) + conf.BinSuffix
 	}
 	conf.DockerComposeBinary = envs.GetEnvWithPrefix("DOCKER_COMPOSE_BINARY", conf.DockerComposeBinary)
 }
@@ -450,7 +508,7 @@ func setKwokctlDashboardConfig(conf *configv1alpha1.KwokctlConfigurationOptions)
 	// conf.DashboardBinaryPrefix = envs.GetEnvWithPrefix("DASHBOARD_BINARY_PREFIX", conf.DashboardBinaryPrefix)\
 	//
 	// if conf.DashboardBinary == "" {
-	// 	conf.DashboardBinary = conf.DashboardBinaryPrefix + "/dashboard-" + GOOS + "-" + GOARCH + conf.BinSuffix
+	// 	conf.DashboardBinary = conf.DashboardBinaryPrefix + "/dashboard-" + GOOS + "-" + conf.Arch + conf.BinSuffix
 	// }
 	// conf.DashboardBinary = envs.GetEnvWithPrefix("DASHBOARD_BINARY", conf.DashboardBinary)
 }
@@ -481,7 +539,7 @@ func setKwokctlPrometheusConfig(conf *configv1alpha1.KwokctlConfigurationOptions
 	conf.PrometheusBinary = envs.GetEnvWithPrefix("PROMETHEUS_BINARY", conf.PrometheusBinary)
 
 	if conf.PrometheusBinaryTar == "" {
-		conf.PrometheusBinaryTar = conf.PrometheusBinaryPrefix + "/prometheus-" + strings.TrimPrefix(conf.PrometheusVersion, "v") + "." + GOOS + "-" + GOARCH + "." + func() string {
+		conf.PrometheusBinaryTar = conf.PrometheusBinaryPrefix + "/prometheus-" + strings.TrimPrefix(conf.PrometheusVersion, "v") + "." + GOOS + "-" + conf.Arch + "." + func() string {
 			if GOOS == windows {
 				return binarySuffixZip
 			}
@@ -517,7 +575,7 @@ func setKwokctlJaegerConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	conf.JaegerBinary = envs.GetEnvWithPrefix("JAEGER_BINARY", conf.JaegerBinary)
 
 	if conf.JaegerBinaryTar == "" {
-		conf.JaegerBinaryTar = conf.JaegerBinaryPrefix + "/jaeger-" + strings.TrimPrefix(conf.JaegerVersion, "v") + "-" + GOOS + "-" + GOARCH + "." + func() string {
+		conf.JaegerBinaryTar = conf.JaegerBinaryPrefix + "/jaeger-" + strings.TrimPrefix(conf.JaegerVersion, "v") + "-" + GOOS + "-" + conf.Arch + "." + func() string {
 			if GOOS == windows {
 				return binarySuffixZip
 			}
@@ -527,6 +585,25 @@ func setKwokctlJaegerConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	conf.JaegerBinaryTar = envs.GetEnvWithPrefix("JAEGER_BINARY_TAR", conf.JaegerBinaryTar)
 }
 
+func setKwokctlGrafanaConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
+	conf.GrafanaPort = envs.GetEnvWithPrefix("GRAFANA_PORT", conf.GrafanaPort)
+
+	if conf.GrafanaVersion == "" {
+		conf.GrafanaVersion = consts.GrafanaVersion
+	}
+	conf.GrafanaVersion = version.AddPrefixV(envs.GetEnvWithPrefix("GRAFANA_VERSION", conf.GrafanaVersion))
+
+	if conf.GrafanaImagePrefix == "" {
+		conf.GrafanaImagePrefix = consts.GrafanaImagePrefix
+	}
+	conf.GrafanaImagePrefix = envs.GetEnvWithPrefix("GRAFANA_IMAGE_PREFIX", conf.GrafanaImagePrefix)
+
+	if conf.GrafanaImage == "" {
+		conf.GrafanaImage = joinImageURI(conf.GrafanaImagePrefix, "grafana", strings.TrimPrefix(conf.GrafanaVersion, "v"))
+	}
+	conf.GrafanaImage = envs.GetEnvWithPrefix("GRAFANA_IMAGE", conf.GrafanaImage)
+}
+
 // joinImageURI joins the image URI.
 func joinImageURI(prefix, name, version string) string {
 	return prefix + "/" + name + ":" + version
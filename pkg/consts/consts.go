@@ -61,6 +61,9 @@ var (
 	JaegerBinaryPrefix = "https://github.com/jaegertracing/jaeger/releases/download"
 	JaegerImagePrefix  = "docker.io/jaegertracing"
 
+	GrafanaVersion     = "10.0.0"
+	GrafanaImagePrefix = "docker.io/grafana"
+
 	DefaultUnlimitedQPS   = 5000.0
 	DefaultUnlimitedBurst = 10000
 )
@@ -72,6 +75,8 @@ const (
 	RuntimeTypeDocker     = "docker"
 	RuntimeTypeNerdctl    = "nerdctl"
 	RuntimeTypePodman     = "podman"
+	RuntimeTypeContainerd = "containerd"
+	RuntimeTypeKubernetes = "kubernetes"
 	RuntimeTypeBinary     = "binary"
 )
 
@@ -85,4 +90,5 @@ const (
 	ComponentDashboard             = "dashboard"
 	ComponentPrometheus            = "prometheus"
 	ComponentJaeger                = "jaeger"
+	ComponentGrafana               = "grafana"
 )
@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubectlkwok contains the shared parts of the kubectl-kwok plugin.
+package kubectlkwok
+
+import (
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// Kubeconfig is the path to the kubeconfig of the cluster, the in-cluster config is used if it is empty.
+var Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
+
+// NewClientset returns the clientset of the cluster,
+// the in-cluster config is used if the kubeconfig does not exist, e.g. in a pod.
+func NewClientset() (client.Clientset, error) {
+	kubeconfigPath := Kubeconfig
+	if kubeconfigPath != "" {
+		var err error
+		kubeconfigPath, err = path.Expand(kubeconfigPath)
+		if err != nil {
+			return nil, err
+		}
+		if !file.Exists(kubeconfigPath) {
+			kubeconfigPath = ""
+		}
+	}
+	return client.NewClientset("", kubeconfigPath)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectlkwok
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewClientset(t *testing.T) {
+	defer func(old string) { Kubeconfig = old }(Kubeconfig)
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	err := os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: kwok
+  cluster:
+    server: https://127.0.0.1:32766
+contexts:
+- name: kwok
+  context:
+    cluster: kwok
+current-context: kwok
+`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Kubeconfig = kubeconfig
+	clientset, err := NewClientset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restConfig.Host != "https://127.0.0.1:32766" {
+		t.Errorf("want the server of the kubeconfig, got %q", restConfig.Host)
+	}
+
+	// Without the kubeconfig, the in-cluster config is used, which is not available in the test.
+	Kubeconfig = filepath.Join(t.TempDir(), "missing")
+	clientset, err = NewClientset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = clientset.ToRESTConfig()
+	if err == nil {
+		t.Errorf("want the in-cluster config used without the kubeconfig")
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stages contains a command to list the stages of a cluster.
+package stages
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/kubectlkwok"
+)
+
+// NewCommand returns a new cobra.Command for getting the stages
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "stages",
+		Short: "Lists the stages of the cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context, out io.Writer) error {
+	clientset, err := kubectlkwok.NewClientset()
+	if err != nil {
+		return err
+	}
+	typedKwokClient, err := clientset.ToTypedKwokClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := typedKwokClient.KwokV1alpha1().Stages().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	return printStages(out, list.Items, time.Now())
+}
+
+func printStages(out io.Writer, stages []v1alpha1.Stage, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tAPIGROUP\tKIND\tWEIGHT\tAGE")
+	for _, stage := range stages {
+		ref := stage.Spec.ResourceRef
+		age := duration.HumanDuration(now.Sub(stage.CreationTimestamp.Time))
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", stage.Name, ref.APIGroup, ref.Kind, stage.Spec.Weight, age)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stages
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+)
+
+func Test_printStages(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	newStage := func(name, apiGroup, kind string, weight int, age time.Duration) v1alpha1.Stage {
+		return v1alpha1.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec: v1alpha1.StageSpec{
+				ResourceRef: v1alpha1.StageResourceRef{APIGroup: apiGroup, Kind: kind},
+				Weight:      weight,
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		stages []v1alpha1.Stage
+		want   string
+	}{
+		{
+			name: "no stages",
+			want: "NAME   APIGROUP   KIND   WEIGHT   AGE\n",
+		},
+		{
+			name: "stages",
+			stages: []v1alpha1.Stage{
+				newStage("node-initialize", "v1", "Node", 0, 90*time.Second),
+				newStage("pod-ready", "v1", "Pod", 10, 2*time.Hour),
+			},
+			want: "NAME              APIGROUP   KIND   WEIGHT   AGE\n" +
+				"node-initialize   v1         Node   0        90s\n" +
+				"pod-ready         v1         Pod    10       120m\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			err := printStages(buf, tt.stages, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
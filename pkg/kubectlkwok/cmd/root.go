@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cmd defines a root command for the kubectl-kwok plugin.
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kubectlkwok"
+	"sigs.k8s.io/kwok/pkg/kubectlkwok/cmd/get"
+	"sigs.k8s.io/kwok/pkg/kubectlkwok/cmd/scale"
+	"sigs.k8s.io/kwok/pkg/kubectlkwok/cmd/snapshot"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+// NewCommand returns a new cobra.Command for root
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:          cobra.NoArgs,
+		Use:           "kubectl-kwok [command]",
+		Short:         "kubectl-kwok is a kubectl plugin to drive the simulations of any cluster running the kwok-controller",
+		Version:       version.DisplayVersion(),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&kubectlkwok.Kubeconfig, "kubeconfig", kubectlkwok.Kubeconfig, "Path to the kubeconfig file, the in-cluster config is used if it does not exist")
+	cmd.PersistentFlags().BoolVar(&dryrun.DryRun, "dry-run", dryrun.DryRun, "Print the command that would be executed, but do not execute it")
+	cmd.TraverseChildren = true
+
+	cmd.AddCommand(
+		get.NewCommand(ctx),
+		scale.NewCommand(ctx),
+		snapshot.NewCommand(ctx),
+	)
+	return cmd
+}
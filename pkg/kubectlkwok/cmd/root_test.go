@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/kubectlkwok"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+)
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand(context.Background())
+
+	for _, args := range [][]string{
+		{"get", "stages"},
+		{"scale"},
+		{"snapshot", "save"},
+		{"snapshot", "restore"},
+	} {
+		sub, _, err := cmd.Find(args)
+		if err != nil {
+			t.Errorf("want the command %v, got %v", args, err)
+			continue
+		}
+		if sub.Name() != args[len(args)-1] {
+			t.Errorf("want the command %v, got %s", args, sub.CommandPath())
+		}
+	}
+}
+
+func TestNewCommandFlags(t *testing.T) {
+	defer func(kubeconfig string, dryRun bool) {
+		kubectlkwok.Kubeconfig = kubeconfig
+		dryrun.DryRun = dryRun
+	}(kubectlkwok.Kubeconfig, dryrun.DryRun)
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	snapshot := filepath.Join(t.TempDir(), "snapshot.yaml")
+
+	cmd := NewCommand(context.Background())
+	cmd.SetArgs([]string{"--kubeconfig", kubeconfig, "--dry-run", "snapshot", "save", "--path", snapshot})
+	err := cmd.ExecuteContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kubectlkwok.Kubeconfig != kubeconfig {
+		t.Errorf("want the kubeconfig %q, got %q", kubeconfig, kubectlkwok.Kubeconfig)
+	}
+	if !dryrun.DryRun {
+		t.Errorf("want the dry run enabled")
+	}
+}
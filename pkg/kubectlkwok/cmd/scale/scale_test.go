@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		want      flagpole
+		wantArgs  []string
+		wantError bool
+	}{
+		{
+			name:     "defaults",
+			args:     []string{"node"},
+			wantArgs: []string{"node"},
+			want: flagpole{
+				Replicas:     1,
+				SerialLength: 6,
+			},
+		},
+		{
+			name: "flags",
+			args: []string{
+				"pod", "fake-pod",
+				"--replicas", "10",
+				"--serial-length", "3",
+				"-n", "default",
+				"--param", ".nodeName=\"fake-node\"",
+				"--param", ".image=\"busybox\"",
+			},
+			wantArgs: []string{"pod", "fake-pod"},
+			want: flagpole{
+				Replicas:     10,
+				SerialLength: 3,
+				Namespace:    "default",
+				Params:       []string{".nodeName=\"fake-node\"", ".image=\"busybox\""},
+			},
+		},
+		{
+			name:      "without the kind",
+			args:      []string{},
+			wantError: true,
+		},
+		{
+			name:      "too many args",
+			args:      []string{"pod", "a", "b"},
+			wantError: true,
+		},
+		{
+			name:      "negative replicas",
+			args:      []string{"node", "--replicas", "-1"},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewCommand(context.Background())
+			err := cmd.ParseFlags(tt.args)
+			if err == nil {
+				err = cmd.ValidateArgs(cmd.Flags().Args())
+			}
+			if (err != nil) != tt.wantError {
+				t.Fatalf("want error %v, got %v", tt.wantError, err)
+			}
+			if tt.wantError {
+				return
+			}
+			if diff := cmp.Diff(tt.wantArgs, cmd.Flags().Args()); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+
+			got := flagpole{}
+			got.Replicas, _ = cmd.Flags().GetUint64("replicas")
+			got.SerialLength, _ = cmd.Flags().GetInt("serial-length")
+			got.Namespace, _ = cmd.Flags().GetString("namespace")
+			got.Params, _ = cmd.Flags().GetStringArray("param")
+			if len(got.Params) == 0 {
+				got.Params = nil
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected flags (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package restore provides a command to restore the snapshot of a cluster.
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kubectlkwok"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+)
+
+type flagpole struct {
+	Path    string
+	Filters []string
+}
+
+// NewCommand returns a new cobra.Command to restore the snapshot to the cluster.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "restore",
+		Short: "Restore the resources of the snapshot in the k8s format to the cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the snapshot")
+	cmd.Flags().StringSliceVar(&flags.Filters, "filter", snapshot.Resources, "Filter the resources to restore")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+
+	if dryrun.DryRun {
+		dryrun.PrintMessage("kubectl create -f %s", flags.Path)
+		return nil
+	}
+
+	clientset, err := kubectlkwok.NewClientset()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(flags.Path, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return snapshot.Load(ctx, clientset, f, flags.Filters)
+}
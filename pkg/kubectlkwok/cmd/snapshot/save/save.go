@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package save provides a command to save the snapshot of a cluster.
+package save
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kubectlkwok"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+)
+
+type flagpole struct {
+	Path    string
+	Filters []string
+}
+
+// NewCommand returns a new cobra.Command for cluster snapshotting.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "save",
+		Short: "Save the resources of the cluster as a snapshot in the k8s format",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the snapshot")
+	cmd.Flags().StringSliceVar(&flags.Filters, "filter", snapshot.Resources, "Filter the resources to save")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	if file.Exists(flags.Path) {
+		return fmt.Errorf("file %q already exists", flags.Path)
+	}
+
+	if dryrun.DryRun {
+		dryrun.PrintMessage("kubectl get %s -o yaml >%s", strings.Join(flags.Filters, ","), flags.Path)
+		return nil
+	}
+
+	clientset, err := kubectlkwok.NewClientset()
+	if err != nil {
+		return err
+	}
+
+	f, err := file.Open(flags.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+		if err != nil {
+			_ = os.Remove(flags.Path)
+		}
+	}()
+
+	err = snapshot.Save(ctx, clientset, f, flags.Filters, snapshot.SaveConfig{})
+	return err
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package save
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/kubectlkwok"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+)
+
+func Test_runE(t *testing.T) {
+	defer func(old string) { kubectlkwok.Kubeconfig = old }(kubectlkwok.Kubeconfig)
+	defer func(old bool) { dryrun.DryRun = old }(dryrun.DryRun)
+
+	dir := t.TempDir()
+	kubectlkwok.Kubeconfig = filepath.Join(dir, "kubeconfig")
+	dryrun.DryRun = true
+
+	existing := filepath.Join(dir, "existing.yaml")
+	err := os.WriteFile(existing, nil, 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		flags     flagpole
+		wantError bool
+	}{
+		{
+			name:      "without the path",
+			wantError: true,
+		},
+		{
+			name:      "the file already exists",
+			flags:     flagpole{Path: existing},
+			wantError: true,
+		},
+		{
+			name:  "dry run",
+			flags: flagpole{Path: filepath.Join(dir, "snapshot.yaml")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runE(context.Background(), &tt.flags)
+			if (err != nil) != tt.wantError {
+				t.Errorf("want error %v, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
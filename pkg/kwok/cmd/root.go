@@ -25,6 +25,7 @@ import (
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/utils/clock"
 
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/kwok/controllers"
+	"sigs.k8s.io/kwok/pkg/kwok/cri"
 	"sigs.k8s.io/kwok/pkg/kwok/server"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
@@ -74,8 +76,8 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	flags.Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
 
-	cmd.Flags().StringVar(&flags.Options.CIDR, "cidr", flags.Options.CIDR, "CIDR of the pod ip")
-	cmd.Flags().StringVar(&flags.Options.NodeIP, "node-ip", flags.Options.NodeIP, "IP of the node")
+	cmd.Flags().StringVar(&flags.Options.CIDR, "cidr", flags.Options.CIDR, "CIDR of the pod ip, comma-separated CIDRs of the IPv4 and IPv6 families for dual-stack")
+	cmd.Flags().StringVar(&flags.Options.NodeIP, "node-ip", flags.Options.NodeIP, "IP of the node, comma-separated IPs of the IPv4 and IPv6 families for dual-stack")
 	cmd.Flags().StringVar(&flags.Options.NodeName, "node-name", flags.Options.NodeName, "Name of the node")
 	cmd.Flags().IntVar(&flags.Options.NodePort, "node-port", flags.Options.NodePort, "Port of the node")
 	cmd.Flags().StringVar(&flags.Options.TLSCertFile, "tls-cert-file", flags.Options.TLSCertFile, "File containing the default x509 Certificate for HTTPS")
@@ -90,7 +92,29 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVar(&flags.Master, "master", flags.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig).")
 	cmd.Flags().StringVar(&flags.Options.ServerAddress, "server-address", flags.Options.ServerAddress, "Address to expose the server on")
 	cmd.Flags().UintVar(&flags.Options.NodeLeaseDurationSeconds, "node-lease-duration-seconds", flags.Options.NodeLeaseDurationSeconds, "Duration of node lease seconds")
+	cmd.Flags().BoolVar(&flags.Options.NodeLeaseOnly, "node-lease-only", flags.Options.NodeLeaseOnly, "Keep the nodes alive by their leases only, without updating the status of the nodes if only the heartbeat times would change")
+	cmd.Flags().StringVar(&flags.Options.StaticPodPath, "static-pod-path", flags.Options.StaticPodPath, "Directory of the manifests of the static pods whose mirror pods are created on the managed nodes")
+	cmd.Flags().StringVar(&flags.Options.FlapNodesWithLabelSelector, "flap-nodes-with-label-selector", flags.Options.FlapNodesWithLabelSelector, "Flip the Ready condition of the nodes that match the label selector between True and False periodically")
+	cmd.Flags().UintVar(&flags.Options.NodeFlappingUpSeconds, "node-flapping-up-seconds", flags.Options.NodeFlappingUpSeconds, "Seconds the flapping nodes are Ready for in each period")
+	cmd.Flags().UintVar(&flags.Options.NodeFlappingDownSeconds, "node-flapping-down-seconds", flags.Options.NodeFlappingDownSeconds, "Seconds the flapping nodes are NotReady for in each period")
+	cmd.Flags().BoolVar(&flags.Options.EnableNodeVolumes, "enable-node-volumes", flags.Options.EnableNodeVolumes, "Report the volumes of the PersistentVolumeClaims of the pods on the nodes in the volumesInUse of the nodes")
+	cmd.Flags().StringSliceVar(&flags.Options.PodAdmissionResources, "pod-admission-resources", flags.Options.PodAdmissionResources, "Resources of the allocatable of the nodes that the pods are admitted against as the kubelet does, e.g. pods,cpu,memory, the pods not fitting are failed with the reason like OutOfpods")
+	cmd.Flags().UintVar(&flags.Options.Shards, "shards", flags.Options.Shards, "Number of shards that the nodes are split into by the hash of their names, each shard is managed by the replica holding its lease, 0 means no sharding")
+	cmd.Flags().UintVar(&flags.Options.MaxShardsPerReplica, "max-shards-per-replica", flags.Options.MaxShardsPerReplica, "Max number of shards held by a replica, 0 means no limit, leave room to take over the shards of the replicas gone")
+	cmd.Flags().BoolVar(&flags.Options.EnableNodeLifecycleEvents, "enable-node-lifecycle-events", flags.Options.EnableNodeLifecycleEvents, "Record the Events of the lifecycle of the nodes, e.g. Starting, NodeReady and NodeNotReady from the kubelet, and RegisteredNode from the node-controller")
 	cmd.Flags().StringSliceVar(&flags.Options.EnableCRDs, "enable-crds", flags.Options.EnableCRDs, "List of CRDs to enable")
+	cmd.Flags().UintVar(&flags.Options.StageMaxChainDepth, "stage-max-chain-depth", flags.Options.StageMaxChainDepth, "Max number of stages played one after another by immediateNextStage, 0 means no limit")
+	cmd.Flags().Float64Var(&flags.Options.TimeScale, "time-scale", flags.Options.TimeScale, "Factor that all delays of the stages are divided by, e.g. 60 plays a scenario of 1 hour in 1 minute, and 0.5 plays it at half speed")
+	cmd.Flags().BoolVar(&flags.Options.EnableStageNextAnnotations, "enable-stage-next-annotations", flags.Options.EnableStageNextAnnotations, "Annotate the resources with the next stage planned for them and the time of it")
+	cmd.Flags().BoolVar(&flags.Options.EnableNodePressure, "enable-node-pressure", flags.Options.EnableNodePressure, "Flip the pressure conditions of the nodes by the requests of the pods on them")
+	cmd.Flags().Float64Var(&flags.Options.NodePressureThreshold, "node-pressure-threshold", flags.Options.NodePressureThreshold, "Ratio of the requests of the pods to the allocatable of the node at or above which the node is under pressure")
+	cmd.Flags().StringSliceVar(&flags.Options.EnableStageForRefs, "enable-stage-for-refs", flags.Options.EnableStageForRefs, "List of resources other than Pod and Node whose lifecycle is driven by the stages, in the format of <kind>.<version>.<group>")
+	cmd.Flags().StringVar(&flags.Options.CRISocketDir, "cri-socket-dir", flags.Options.CRISocketDir, "Directory of the fake CRI endpoints of the managed nodes, the endpoint of a node is served on <dir>/<node>.sock, disabled if empty")
+	cmd.Flags().UintVar(&flags.Options.MetricsMaxSeries, "metrics-max-series", flags.Options.MetricsMaxSeries, "Max number of the series of a Metric on a node updated in a scrape, the metrics exceeding it are dropped, 0 means no limit")
+	cmd.Flags().UintVar(&flags.Options.MetricsMaxLabelValues, "metrics-max-label-values", flags.Options.MetricsMaxLabelValues, "Max number of the values of a label of a metric of a Metric on a node in a scrape, the metrics exceeding it are dropped, 0 means no limit")
+	cmd.Flags().UintVar(&flags.Options.MetricsScrapeTimeoutSeconds, "metrics-scrape-timeout-seconds", flags.Options.MetricsScrapeTimeoutSeconds, "Seconds a scrape of a Metric is allowed to evaluate the series for, the series not evaluated in time keep their last values, 0 means no timeout")
+	cmd.Flags().Int64Var(&flags.Options.RandSeed, "rand-seed", flags.Options.RandSeed, "Seed of the SeededRand of the CEL expressions of the ResourceUsages and the Metrics, combined with the UID of the object")
+	cmd.Flags().UintVar(&flags.Options.StatsCacheTTLSeconds, "stats-cache-ttl-seconds", flags.Options.StatsCacheTTLSeconds, "Seconds the usages of the containers and the stats of the pods are cached for, a pod is recomputed before it once it changes, 0 means no cache")
 
 	cmd.Flags().BoolVar(&flags.Options.EnableCNI, "experimental-enable-cni", flags.Options.EnableCNI, "Experimental support for getting pod ip from CNI, for CNI-related components, Only works with Linux")
 	if config.GOOS != "linux" {
@@ -100,16 +124,18 @@ func NewCommand(ctx context.Context) *cobra.Command {
 }
 
 var crdDefines = map[string]struct{}{
-	v1alpha1.StageKind:              {},
-	v1alpha1.AttachKind:             {},
-	v1alpha1.ClusterAttachKind:      {},
-	v1alpha1.ExecKind:               {},
-	v1alpha1.ClusterExecKind:        {},
-	v1alpha1.PortForwardKind:        {},
-	v1alpha1.ClusterPortForwardKind: {},
-	v1alpha1.LogsKind:               {},
-	v1alpha1.ClusterLogsKind:        {},
-	v1alpha1.MetricKind:             {},
+	v1alpha1.StageKind:                {},
+	v1alpha1.AttachKind:               {},
+	v1alpha1.ClusterAttachKind:        {},
+	v1alpha1.ExecKind:                 {},
+	v1alpha1.ClusterExecKind:          {},
+	v1alpha1.PortForwardKind:          {},
+	v1alpha1.ClusterPortForwardKind:   {},
+	v1alpha1.LogsKind:                 {},
+	v1alpha1.ClusterLogsKind:          {},
+	v1alpha1.MetricKind:               {},
+	v1alpha1.ResourceUsageKind:        {},
+	v1alpha1.ClusterResourceUsageKind: {},
 }
 
 func runE(ctx context.Context, flags *flagpole) error {
@@ -139,9 +165,20 @@ func runE(ctx context.Context, flags *flagpole) error {
 	if err != nil {
 		return err
 	}
+	stagesData, err = controllers.ResolveStages(stagesData)
+	if err != nil {
+		return err
+	}
 
 	nodeStages := filterStages(stagesData, "v1", "Node")
 	podStages := filterStages(stagesData, "v1", "Pod")
+	customStages := slices.Filter(stagesData, func(stage *internalversion.Stage) bool {
+		return !slices.Contains(nodeStages, stage) && !slices.Contains(podStages, stage)
+	})
+	enableStageForRefs, err := parseStageResourceRefs(flags.Options.EnableStageForRefs)
+	if err != nil {
+		return err
+	}
 	if !slices.Contains(flags.Options.EnableCRDs, v1alpha1.StageKind) {
 		if len(nodeStages) == 0 {
 			logger.Warn("No node stages found, using default node stages")
@@ -213,6 +250,18 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
+	resourceUsages := config.FilterWithTypeFromContext[*internalversion.ResourceUsage](ctx)
+	err = checkConfigOrCRD(flags.Options.EnableCRDs, v1alpha1.ResourceUsageKind, resourceUsages)
+	if err != nil {
+		return err
+	}
+
+	clusterResourceUsages := config.FilterWithTypeFromContext[*internalversion.ClusterResourceUsage](ctx)
+	err = checkConfigOrCRD(flags.Options.EnableCRDs, v1alpha1.ClusterResourceUsageKind, clusterResourceUsages)
+	if err != nil {
+		return err
+	}
+
 	if flags.Kubeconfig == "" && flags.Master == "" {
 		logger.Warn("Neither --kubeconfig nor --master was specified")
 		logger.Info("Using the inClusterConfig")
@@ -230,8 +279,20 @@ func runE(ctx context.Context, flags *flagpole) error {
 	if err != nil {
 		return err
 	}
+	dynamicClient, err := clientset.ToDynamicClient()
+	if err != nil {
+		return err
+	}
+	restMapper, err := clientset.ToRESTMapper()
+	if err != nil {
+		return err
+	}
 
 	switch {
+	case len(flags.Options.ManageNodeRules) != 0:
+		logger.Info("Watch nodes",
+			"rules", len(flags.Options.ManageNodeRules),
+		)
 	case flags.Options.ManageSingleNode != "":
 		logger.Info("Watch single node",
 			"node", flags.Options.ManageSingleNode,
@@ -252,17 +313,30 @@ func runE(ctx context.Context, flags *flagpole) error {
 	ctx = log.NewContext(ctx, logger.With("id", id))
 
 	enableMetrics := len(metrics) != 0 || slices.Contains(flags.Options.EnableCRDs, v1alpha1.MetricKind)
+	enableResourceUsage := len(resourceUsages) != 0 || slices.Contains(flags.Options.EnableCRDs, v1alpha1.ResourceUsageKind) ||
+		len(clusterResourceUsages) != 0 || slices.Contains(flags.Options.EnableCRDs, v1alpha1.ClusterResourceUsageKind)
+	enableLabelSelectors := needLabelSelectors(flags.Options.EnableCRDs,
+		slices.Map(clusterPortForwards, func(c *internalversion.ClusterPortForward) *internalversion.ObjectSelector { return c.Spec.Selector }),
+		slices.Map(clusterExecs, func(c *internalversion.ClusterExec) *internalversion.ObjectSelector { return c.Spec.Selector }),
+		slices.Map(clusterLogs, func(c *internalversion.ClusterLogs) *internalversion.ObjectSelector { return c.Spec.Selector }),
+		slices.Map(clusterAttaches, func(c *internalversion.ClusterAttach) *internalversion.ObjectSelector { return c.Spec.Selector }),
+		slices.Map(clusterResourceUsages, func(c *internalversion.ClusterResourceUsage) *internalversion.ObjectSelector { return c.Spec.Selector }),
+	)
 	ctr, err := controllers.NewController(controllers.Config{
 		Clock:                                 clock.RealClock{},
 		TypedClient:                           typedClient,
 		TypedKwokClient:                       typedKwokClient,
+		DynamicClient:                         dynamicClient,
+		RESTMapper:                            restMapper,
 		EnableCNI:                             flags.Options.EnableCNI,
 		EnableMetrics:                         enableMetrics,
-		EnablePodCache:                        enableMetrics,
+		EnablePodCache:                        enableMetrics || enableResourceUsage || enableLabelSelectors,
+		EnableNamespaceCache:                  enableLabelSelectors,
 		ManageSingleNode:                      flags.Options.ManageSingleNode,
 		ManageAllNodes:                        flags.Options.ManageAllNodes,
 		ManageNodesWithAnnotationSelector:     flags.Options.ManageNodesWithAnnotationSelector,
 		ManageNodesWithLabelSelector:          flags.Options.ManageNodesWithLabelSelector,
+		ManageNodeRules:                       flags.Options.ManageNodeRules,
 		DisregardStatusWithAnnotationSelector: flags.Options.DisregardStatusWithAnnotationSelector,
 		DisregardStatusWithLabelSelector:      flags.Options.DisregardStatusWithLabelSelector,
 		CIDR:                                  flags.Options.CIDR,
@@ -273,8 +347,27 @@ func runE(ctx context.Context, flags *flagpole) error {
 		NodePlayStageParallelism:              flags.Options.NodePlayStageParallelism,
 		NodeStages:                            nodeStages,
 		PodStages:                             podStages,
+		CustomStages:                          customStages,
+		EnableStageForRefs:                    enableStageForRefs,
+		CustomPlayStageParallelism:            flags.Options.CustomPlayStageParallelism,
+		StageMaxChainDepth:                    flags.Options.StageMaxChainDepth,
+		TimeScale:                             flags.Options.TimeScale,
+		EnableStageNextAnnotations:            flags.Options.EnableStageNextAnnotations,
+		EnableNodePressure:                    flags.Options.EnableNodePressure,
+		NodePressureThreshold:                 flags.Options.NodePressureThreshold,
 		NodeLeaseParallelism:                  flags.Options.NodeLeaseParallelism,
 		NodeLeaseDurationSeconds:              flags.Options.NodeLeaseDurationSeconds,
+		NodeLeaseOnly:                         flags.Options.NodeLeaseOnly,
+		StaticPodPath:                         flags.Options.StaticPodPath,
+		FlapNodesWithLabelSelector:            flags.Options.FlapNodesWithLabelSelector,
+		NodeFlappingUpSeconds:                 flags.Options.NodeFlappingUpSeconds,
+		NodeFlappingDownSeconds:               flags.Options.NodeFlappingDownSeconds,
+		EnableNodeVolumes:                     flags.Options.EnableNodeVolumes,
+		PodAdmissionResources:                 flags.Options.PodAdmissionResources,
+		Shards:                                flags.Options.Shards,
+		MaxShardsPerReplica:                   flags.Options.MaxShardsPerReplica,
+		EnableNodeLifecycleEvents:             flags.Options.EnableNodeLifecycleEvents,
+		ImageSimulation:                       flags.Options.ImageSimulation,
 		ID:                                    id,
 	})
 	if err != nil {
@@ -291,6 +384,18 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
+	if flags.Options.CRISocketDir != "" {
+		criServer, err := cri.NewServer(cri.Config{
+			SocketDir:      flags.Options.CRISocketDir,
+			DataSource:     ctr,
+			PodCacheGetter: ctr.GetPodCache(),
+		})
+		if err != nil {
+			return err
+		}
+		go criServer.Run(ctx)
+	}
+
 	serverAddress := flags.Options.ServerAddress
 	if serverAddress == "" && flags.Options.NodePort != 0 {
 		serverAddress = "0.0.0.0:" + format.String(flags.Options.NodePort)
@@ -298,20 +403,29 @@ func runE(ctx context.Context, flags *flagpole) error {
 
 	if serverAddress != "" {
 		conf := server.Config{
-			TypedKwokClient:     typedKwokClient,
-			EnableCRDs:          flags.Options.EnableCRDs,
-			ClusterPortForwards: clusterPortForwards,
-			PortForwards:        portForwards,
-			ClusterExecs:        clusterExecs,
-			Execs:               execs,
-			ClusterLogs:         clusterLogs,
-			Logs:                logs,
-			ClusterAttaches:     clusterAttaches,
-			Attaches:            attaches,
-			Metrics:             metrics,
-			DataSource:          ctr,
-			NodeCacheGetter:     ctr.GetNodeCache(),
-			PodCacheGetter:      ctr.GetPodCache(),
+			TypedKwokClient:       typedKwokClient,
+			EnableCRDs:            flags.Options.EnableCRDs,
+			ClusterPortForwards:   clusterPortForwards,
+			PortForwards:          portForwards,
+			ClusterExecs:          clusterExecs,
+			Execs:                 execs,
+			ClusterLogs:           clusterLogs,
+			Logs:                  logs,
+			ClusterAttaches:       clusterAttaches,
+			Attaches:              attaches,
+			Metrics:               metrics,
+			ResourceUsages:        resourceUsages,
+			ClusterResourceUsages: clusterResourceUsages,
+			DataSource:            ctr,
+			NodeCacheGetter:       ctr.GetNodeCache(),
+			PodCacheGetter:        ctr.GetPodCache(),
+			NamespaceCacheGetter:  ctr.GetNamespaceCache(),
+
+			MetricsMaxSeries:      int(flags.Options.MetricsMaxSeries),
+			MetricsMaxLabelValues: int(flags.Options.MetricsMaxLabelValues),
+			MetricsScrapeTimeout:  time.Duration(flags.Options.MetricsScrapeTimeoutSeconds) * time.Second,
+			RandSeed:              flags.Options.RandSeed,
+			StatsCacheTTL:         time.Duration(flags.Options.StatsCacheTTLSeconds) * time.Second,
 		}
 		svc, err := server.NewServer(conf)
 		if err != nil {
@@ -321,6 +435,10 @@ func runE(ctx context.Context, flags *flagpole) error {
 
 		svc.InstallServiceDiscovery()
 
+		svc.InstallStageStatistics()
+
+		svc.InstallClusterStatistics()
+
 		if flags.Options.EnableDebuggingHandlers {
 			svc.InstallDebuggingHandlers()
 			svc.InstallProfilingHandler(flags.Options.EnableProfilingHandler, flags.Options.EnableContentionProfiling)
@@ -338,6 +456,18 @@ func runE(ctx context.Context, flags *flagpole) error {
 			return fmt.Errorf("failed to install metrics: %w", err)
 		}
 
+		if enableMetrics {
+			err = svc.InstallCustomMetricsAPI()
+			if err != nil {
+				return fmt.Errorf("failed to install custom metrics api: %w", err)
+			}
+		}
+
+		if enableResourceUsage {
+			svc.InstallMetricsAPI()
+			svc.InstallStatsSummary()
+		}
+
 		go func() {
 			err := svc.Run(ctx, serverAddress, flags.Options.TLSCertFile, flags.Options.TLSPrivateKeyFile)
 			if err != nil {
@@ -366,12 +496,52 @@ func checkConfigOrCRD[T metav1.Object](crds []string, kind string, crs []T) erro
 	return nil
 }
 
+// needLabelSelectors returns true if the labels of the pods and the namespaces are needed to match the selectors of the cluster resources,
+// the selectors of the cluster resources enabled as CRDs are not known until they are watched, so they are assumed to need the labels.
+func needLabelSelectors(crds []string, selectors ...[]*internalversion.ObjectSelector) bool {
+	for _, kind := range []string{
+		v1alpha1.ClusterPortForwardKind,
+		v1alpha1.ClusterExecKind,
+		v1alpha1.ClusterLogsKind,
+		v1alpha1.ClusterAttachKind,
+		v1alpha1.ClusterResourceUsageKind,
+	} {
+		if slices.Contains(crds, kind) {
+			return true
+		}
+	}
+	for _, list := range selectors {
+		for _, selector := range list {
+			if selector != nil && (len(selector.MatchLabels) != 0 || len(selector.MatchNamespaceLabels) != 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func filterStages(stages []*internalversion.Stage, apiGroup, kind string) []*internalversion.Stage {
 	return slices.Filter(stages, func(stage *internalversion.Stage) bool {
 		return stage.Spec.ResourceRef.APIGroup == apiGroup && stage.Spec.ResourceRef.Kind == kind
 	})
 }
 
+// parseStageResourceRefs parses the refs in the format of <kind>.<version>.<group>
+func parseStageResourceRefs(refs []string) ([]internalversion.StageResourceRef, error) {
+	out := make([]internalversion.StageResourceRef, 0, len(refs))
+	for _, ref := range refs {
+		gvk, _ := schema.ParseKindArg(ref)
+		if gvk == nil {
+			return nil, fmt.Errorf("invalid stage ref %q, must be in the format of <kind>.<version>.<group>", ref)
+		}
+		out = append(out, internalversion.StageResourceRef{
+			APIGroup: gvk.GroupVersion().String(),
+			Kind:     gvk.Kind,
+		})
+	}
+	return out, nil
+}
+
 func waitForReady(ctx context.Context, clientset kubernetes.Interface) error {
 	logger := log.FromContext(ctx)
 	backoff := wait.Backoff{
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// NodeStatusReady is the status of the nodes with the Ready condition of True.
+	NodeStatusReady = "Ready"
+	// NodeStatusNotReady is the status of the nodes with the Ready condition of False.
+	NodeStatusNotReady = "NotReady"
+	// NodeStatusUnknown is the status of the nodes with the Ready condition of Unknown or without it.
+	NodeStatusUnknown = "Unknown"
+)
+
+// ClusterStatistics is the statistics of the whole fleet of the nodes and the pods managed by the controllers
+type ClusterStatistics struct {
+	// Time is the time the statistics are taken.
+	Time time.Time `json:"time"`
+	// Nodes is the number of the nodes of each status, Ready, NotReady or Unknown.
+	Nodes map[string]int64 `json:"nodes"`
+	// Pods is the number of the pods on the nodes of each phase.
+	Pods map[corev1.PodPhase]int64 `json:"pods"`
+	// SchedulingLatency is the distribution of the time from the creation of the pods to them being scheduled,
+	// of the pods seen since the controllers started.
+	SchedulingLatency LatencyDistribution `json:"schedulingLatency"`
+	// Stages is the statistics of the stages, with the rates of them played.
+	Stages []StageStatistic `json:"stages"`
+}
+
+// ClusterStatistics returns the statistics of the whole fleet of the nodes and the pods managed by the controllers
+func (c *Controller) ClusterStatistics() ClusterStatistics {
+	now := c.now()
+	nodes := map[string]int64{}
+	for _, nodeName := range c.ListNodes() {
+		status := NodeStatusUnknown
+		if c.nodeCacheGetter != nil {
+			if node, ok := c.nodeCacheGetter.Get(nodeName); ok {
+				status = nodeStatus(node)
+			}
+		}
+		nodes[status]++
+	}
+	return ClusterStatistics{
+		Time:              now,
+		Nodes:             nodes,
+		Pods:              podStats.Phases(),
+		SchedulingLatency: podStats.SchedulingLatency(),
+		Stages:            stageStats.List(now),
+	}
+}
+
+// nodeStatus returns the status of the node by the Ready condition of it
+func nodeStatus(node *corev1.Node) string {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type != corev1.NodeReady {
+			continue
+		}
+		switch cond.Status {
+		case corev1.ConditionTrue:
+			return NodeStatusReady
+		case corev1.ConditionFalse:
+			return NodeStatusNotReady
+		}
+		return NodeStatusUnknown
+	}
+	return NodeStatusUnknown
+}
+
+func (c *Controller) now() time.Time {
+	if c.conf.Clock == nil {
+		return time.Now()
+	}
+	return c.conf.Clock.Now()
+}
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -27,9 +28,13 @@ import (
 
 	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -44,6 +49,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/gotpl"
 	"sigs.k8s.io/kwok/pkg/utils/informer"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/queue"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 	"sigs.k8s.io/kwok/pkg/utils/yaml"
@@ -52,7 +58,7 @@ import (
 var (
 	startTime = time.Now().Format(time.RFC3339Nano)
 
-	defaultFuncMap = gotpl.FuncMap{
+	defaultFuncMap = maps.Merge(gotpl.GenericFuncMap(), gotpl.FuncMap{
 		"Quote": func(s any) string {
 			data, err := json.Marshal(s)
 			if err != nil {
@@ -88,7 +94,9 @@ var (
 		"Version": func() string {
 			return consts.Version
 		},
-	}
+		"ContainerID": containerID,
+		"ImageID":     imageID,
+	})
 
 	nodeKind = corev1.SchemeGroupVersion.WithKind("Node")
 )
@@ -99,11 +107,13 @@ type Controller struct {
 	nodes       *NodeController
 	pods        *PodController
 	nodeLeases  *NodeLeaseController
+	stages      []*StageController
 	broadcaster record.EventBroadcaster
 	typedClient kubernetes.Interface
 
-	nodeCacheGetter informer.Getter[*corev1.Node]
-	podCacheGetter  informer.Getter[*corev1.Pod]
+	nodeCacheGetter      informer.Getter[*corev1.Node]
+	podCacheGetter       informer.Getter[*corev1.Pod]
+	namespaceCacheGetter informer.Getter[*corev1.Namespace]
 }
 
 // Config is the configuration for the controller
@@ -112,10 +122,13 @@ type Config struct {
 	EnableCNI                             bool
 	TypedClient                           kubernetes.Interface
 	TypedKwokClient                       versioned.Interface
+	DynamicClient                         dynamic.Interface
+	RESTMapper                            meta.RESTMapper
 	ManageSingleNode                      string
 	ManageAllNodes                        bool
 	ManageNodesWithAnnotationSelector     string
 	ManageNodesWithLabelSelector          string
+	ManageNodeRules                       []internalversion.ManageNodeRule
 	DisregardStatusWithAnnotationSelector string
 	DisregardStatusWithLabelSelector      string
 	CIDR                                  string
@@ -124,17 +137,45 @@ type Config struct {
 	NodePort                              int
 	PodStages                             []*internalversion.Stage
 	NodeStages                            []*internalversion.Stage
+	CustomStages                          []*internalversion.Stage
+	EnableStageForRefs                    []internalversion.StageResourceRef
+	CustomPlayStageParallelism            uint
+	StageMaxChainDepth                    uint
+	TimeScale                             float64
+	EnableStageNextAnnotations            bool
+	EnableNodePressure                    bool
+	NodePressureThreshold                 float64
 	PodPlayStageParallelism               uint
 	NodePlayStageParallelism              uint
 	NodeLeaseDurationSeconds              uint
 	NodeLeaseParallelism                  uint
+	NodeLeaseOnly                         bool
+	StaticPodPath                         string
+	FlapNodesWithLabelSelector            string
+	NodeFlappingUpSeconds                 uint
+	NodeFlappingDownSeconds               uint
+	EnableNodeVolumes                     bool
+	Shards                                uint
+	MaxShardsPerReplica                   uint
+	EnableNodeLifecycleEvents             bool
+	PodAdmissionResources                 []string
+	ImageSimulation                       *internalversion.ImageSimulation
 	ID                                    string
 	EnableMetrics                         bool
 	EnablePodCache                        bool
+	EnableNamespaceCache                  bool
 }
 
 func (c Config) validate() error {
 	switch {
+	case len(c.ManageNodeRules) != 0:
+		if c.ManageSingleNode != "" || c.ManageAllNodes || c.ManageNodesWithAnnotationSelector != "" || c.ManageNodesWithLabelSelector != "" {
+			return fmt.Errorf("manage-node-rules is conflicted with manage-single-node, manage-all-nodes, manage-nodes-with-annotation-selector or manage-nodes-with-label-selector")
+		}
+		_, err := newNodeManageRules(c.ManageNodeRules)
+		if err != nil {
+			return err
+		}
 	case c.ManageSingleNode != "":
 		if c.ManageAllNodes {
 			return fmt.Errorf("manage-single-node is conflicted with manage-all-nodes")
@@ -190,11 +231,19 @@ func (c *Controller) Start(ctx context.Context) error {
 		manageNodesWithLabelSelector      string
 		manageNodesWithAnnotationSelector string
 		manageNodesWithFieldSelector      string
+		manageNodesWithFilter             func(obj metav1.Object) bool
 		manageNodeLeasesWithFieldSelector string
 		managePodsWithFieldSelector       string
 	)
 
 	switch {
+	case len(conf.ManageNodeRules) != 0:
+		rules, err := newNodeManageRules(conf.ManageNodeRules)
+		if err != nil {
+			return err
+		}
+		manageNodesWithFilter = rules.Manage
+		managePodsWithFieldSelector = fields.OneTermNotEqualSelector("spec.nodeName", "").String()
 	case conf.ManageSingleNode != "":
 		managePodsWithFieldSelector = fields.OneTermEqualSelector("spec.nodeName", conf.ManageSingleNode).String()
 		manageNodesWithFieldSelector = fields.OneTermEqualSelector("metadata.name", conf.ManageSingleNode).String()
@@ -214,6 +263,7 @@ func (c *Controller) Start(ctx context.Context) error {
 		LabelSelector:      manageNodesWithLabelSelector,
 		AnnotationSelector: manageNodesWithAnnotationSelector,
 		FieldSelector:      manageNodesWithFieldSelector,
+		Filter:             manageNodesWithFilter,
 	}, nodeChan)
 	if err != nil {
 		return fmt.Errorf("failed to watch nodes: %w", err)
@@ -237,6 +287,62 @@ func (c *Controller) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to watch pods: %w", err)
 	}
 
+	var namespacesCache informer.Getter[*corev1.Namespace]
+	if conf.EnableNamespaceCache {
+		namespacesChan := make(chan informer.Event[*corev1.Namespace], 1)
+		namespacesCli := conf.TypedClient.CoreV1().Namespaces()
+		namespacesInformer := informer.NewInformer[*corev1.Namespace, *corev1.NamespaceList](namespacesCli)
+		namespacesCache, err = namespacesInformer.WatchWithCache(ctx, informer.Option{}, namespacesChan)
+		if err != nil {
+			return fmt.Errorf("failed to watch namespaces: %w", err)
+		}
+
+		// Only the cache of the namespaces is used, the events are dropped.
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-namespacesChan:
+				}
+			}
+		}()
+	}
+
+	var shards *ShardController
+	var nodeOwnedFunc func(nodeName string) bool
+	if conf.Shards != 0 {
+		shards, err = NewShardController(ShardControllerConfig{
+			Clock:          conf.Clock,
+			TypedClient:    conf.TypedClient,
+			HolderIdentity: conf.ID,
+			Shards:         conf.Shards,
+			MaxShards:      conf.MaxShardsPerReplica,
+			OnShardAcquiredFunc: func(shard uint32) {
+				// Manage the nodes of the shard taken over
+				go func() {
+					for _, node := range nodesCache.List() {
+						if shardOf(node.Name, uint32(conf.Shards)) == shard {
+							nodeChan <- informer.Event[*corev1.Node]{
+								Type:   informer.Sync,
+								Object: node,
+							}
+						}
+					}
+				}()
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create shards controller: %w", err)
+		}
+		nodeOwnedFunc = shards.Owns
+
+		// Not holding the shard means the node is not managed
+		readOnlyFunc = func(nodeName string) bool {
+			return !shards.Owns(nodeName)
+		}
+	}
+
 	if conf.NodeLeaseDurationSeconds != 0 {
 		nodeLeasesChan = make(chan informer.Event[*coordinationv1.Lease], 1)
 		nodeLeasesCli := conf.TypedClient.CoordinationV1().Leases(corev1.NamespaceNodeLease)
@@ -280,6 +386,7 @@ func (c *Controller) Start(ctx context.Context) error {
 			OnNodeManagedFunc: func(nodeName string) {
 				onLeaseNodeManageFunc(nodeName)
 			},
+			NodeOwnedFunc: nodeOwnedFunc,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create node leases controller: %w", err)
@@ -287,6 +394,9 @@ func (c *Controller) Start(ctx context.Context) error {
 
 		// Not holding the lease means the node is not managed
 		readOnlyFunc = func(nodeName string) bool {
+			if nodeOwnedFunc != nil && !nodeOwnedFunc(nodeName) {
+				return true
+			}
 			return !nodeLeases.Held(nodeName)
 		}
 	}
@@ -295,6 +405,7 @@ func (c *Controller) Start(ctx context.Context) error {
 
 	var nodeLifecycleGetter resources.Getter[Lifecycle]
 	var podLifecycleGetter resources.Getter[Lifecycle]
+	var lifecycleGetterFor func(ref internalversion.StageResourceRef) (resources.Getter[Lifecycle], error)
 
 	if len(conf.PodStages) == 0 && len(conf.NodeStages) == 0 {
 		getter := resources.NewDynamicGetter[
@@ -304,7 +415,7 @@ func (c *Controller) Start(ctx context.Context) error {
 		](
 			conf.TypedKwokClient.KwokV1alpha1().Stages(),
 			func(objs []*v1alpha1.Stage) []*internalversion.Stage {
-				return slices.FilterAndMap(objs, func(obj *v1alpha1.Stage) (*internalversion.Stage, bool) {
+				stages := slices.FilterAndMap(objs, func(obj *v1alpha1.Stage) (*internalversion.Stage, bool) {
 					r, err := internalversion.ConvertToInternalStage(obj)
 					if err != nil {
 						logger.Error("failed to convert to internal stage", err, "obj", obj)
@@ -312,57 +423,103 @@ func (c *Controller) Start(ctx context.Context) error {
 					}
 					return r, true
 				})
+				stages, err := ResolveStages(stages)
+				if err != nil {
+					logger.Error("failed to resolve stages", err)
+				}
+				return stages
 			},
 		)
 
-		nodeLifecycleGetter = resources.NewFilter[Lifecycle, []*internalversion.Stage](getter, func(stages []*internalversion.Stage) Lifecycle {
-			lifecycle := slices.FilterAndMap(stages, func(stage *internalversion.Stage) (*LifecycleStage, bool) {
-				if stage.Spec.ResourceRef.Kind != "Node" {
-					return nil, false
-				}
-
-				lifecycleStage, err := NewLifecycleStage(stage)
-				if err != nil {
-					logger.Error("failed to create node lifecycle stage", err, "stage", stage)
-					return nil, false
-				}
-				return lifecycleStage, true
-			})
-			return lifecycle
-		})
+		lifecycleGetterFor = func(ref internalversion.StageResourceRef) (resources.Getter[Lifecycle], error) {
+			return resources.NewFilter[Lifecycle, []*internalversion.Stage](getter, func(stages []*internalversion.Stage) Lifecycle {
+				lifecycle := slices.FilterAndMap(stages, func(stage *internalversion.Stage) (*LifecycleStage, bool) {
+					if !stageRefMatches(stage.Spec.ResourceRef, ref) {
+						return nil, false
+					}
 
-		podLifecycleGetter = resources.NewFilter[Lifecycle, []*internalversion.Stage](getter, func(stages []*internalversion.Stage) Lifecycle {
-			lifecycle := slices.FilterAndMap(stages, func(stage *internalversion.Stage) (*LifecycleStage, bool) {
-				if stage.Spec.ResourceRef.Kind != "Pod" {
-					return nil, false
-				}
+					// The stages from the CRD are not validated by the apiserver, so skip the invalid ones instead of failing at runtime
+					if errs := LintStage(stage); len(errs) != 0 {
+						logger.Error("invalid stage", errors.Join(errs...), "stage", stage.Name)
+						return nil, false
+					}
 
-				lifecycleStage, err := NewLifecycleStage(stage)
-				if err != nil {
-					logger.Error("failed to create node lifecycle stage", err, "stage", stage)
-					return nil, false
-				}
-				return lifecycleStage, true
-			})
-			return lifecycle
-		})
+					lifecycleStage, err := NewLifecycleStage(stage)
+					if err != nil {
+						logger.Error("failed to create lifecycle stage", err, "stage", stage)
+						return nil, false
+					}
+					return lifecycleStage, true
+				})
+				return lifecycle
+			}), nil
+		}
 
 		err := getter.Start(ctx)
 		if err != nil {
 			return err
 		}
 	} else {
-		lifecycle, err := NewLifecycle(conf.PodStages)
-		if err != nil {
-			return fmt.Errorf("failed to create pod lifecycle: %w", err)
+		lifecycleGetterFor = func(ref internalversion.StageResourceRef) (resources.Getter[Lifecycle], error) {
+			var stages []*internalversion.Stage
+			switch {
+			case stageRefMatches(ref, podRef):
+				stages = conf.PodStages
+			case stageRefMatches(ref, nodeRef):
+				stages = conf.NodeStages
+			default:
+				stages = slices.Filter(conf.CustomStages, func(stage *internalversion.Stage) bool {
+					return stageRefMatches(stage.Spec.ResourceRef, ref)
+				})
+			}
+			for _, stage := range stages {
+				if errs := LintStage(stage); len(errs) != 0 {
+					return nil, fmt.Errorf("invalid stage %q: %w", stage.Name, errors.Join(errs...))
+				}
+				if errs := LintStageWarnings(stage); len(errs) != 0 {
+					logger.Warn("Stage is skipped", "stage", stage.Name, "err", errors.Join(errs...))
+				}
+			}
+			lifecycle, err := NewLifecycle(stages)
+			if err != nil {
+				return nil, err
+			}
+			return resources.NewStaticGetter(lifecycle), nil
 		}
-		podLifecycleGetter = resources.NewStaticGetter(lifecycle)
+	}
 
-		lifecycle, err = NewLifecycle(conf.NodeStages)
-		if err != nil {
-			return fmt.Errorf("failed to create node lifecycle: %w", err)
+	podLifecycleGetter, err = lifecycleGetterFor(podRef)
+	if err != nil {
+		return fmt.Errorf("failed to create pod lifecycle: %w", err)
+	}
+	nodeLifecycleGetter, err = lifecycleGetterFor(nodeRef)
+	if err != nil {
+		return fmt.Errorf("failed to create node lifecycle: %w", err)
+	}
+
+	stages, err := c.startStageControllers(ctx, recorder, lifecycleGetterFor)
+	if err != nil {
+		return err
+	}
+
+	var pods *PodController
+	var podRequestsFunc func(nodeName string) corev1.ResourceList
+	if conf.EnableNodePressure {
+		podRequestsFunc = func(nodeName string) corev1.ResourceList {
+			return pods.Requests(nodeName)
+		}
+	}
+	var podClaimsFunc func(nodeName string) []log.ObjectRef
+	if conf.EnableNodeVolumes {
+		podClaimsFunc = func(nodeName string) []log.ObjectRef {
+			return pods.Claims(nodeName)
+		}
+	}
+	var nodeRecorderFunc func(source corev1.EventSource) record.EventRecorder
+	if conf.EnableNodeLifecycleEvents {
+		nodeRecorderFunc = func(source corev1.EventSource) record.EventRecorder {
+			return c.broadcaster.NewRecorder(scheme.Scheme, source)
 		}
-		nodeLifecycleGetter = resources.NewStaticGetter(lifecycle)
 	}
 
 	nodes, err := NewNodeController(NodeControllerConfig{
@@ -377,18 +534,59 @@ func (c *Controller) Start(ctx context.Context) error {
 		OnNodeManagedFunc: func(nodeName string) {
 			onNodeManagedFunc(nodeName)
 		},
-		Lifecycle:            nodeLifecycleGetter,
-		PlayStageParallelism: conf.NodePlayStageParallelism,
-		FuncMap:              defaultFuncMap,
-		Recorder:             recorder,
-		ReadOnlyFunc:         readOnlyFunc,
-		EnableMetrics:        conf.EnableMetrics,
+		Lifecycle:                  nodeLifecycleGetter,
+		PlayStageParallelism:       conf.NodePlayStageParallelism,
+		StageMaxChainDepth:         conf.StageMaxChainDepth,
+		TimeScale:                  conf.TimeScale,
+		EnableStageNextAnnotations: conf.EnableStageNextAnnotations,
+		FuncMap:                    defaultFuncMap,
+		Recorder:                   recorder,
+		ReadOnlyFunc:               readOnlyFunc,
+		EnableMetrics:              conf.EnableMetrics,
+		PodRequestsFunc:            podRequestsFunc,
+		NodePressureThreshold:      conf.NodePressureThreshold,
+		NodeLeaseOnly:              conf.NodeLeaseOnly && conf.NodeLeaseDurationSeconds != 0,
+		FlapWithLabelSelector:      conf.FlapNodesWithLabelSelector,
+		FlappingUp:                 time.Duration(conf.NodeFlappingUpSeconds) * time.Second,
+		FlappingDown:               time.Duration(conf.NodeFlappingDownSeconds) * time.Second,
+		PodClaimsFunc:              podClaimsFunc,
+		NodeRecorderFunc:           nodeRecorderFunc,
+		OnNodeRebootFunc: func(ctx context.Context, nodeName string, up bool) error {
+			return pods.Reboot(ctx, nodeName, up)
+		},
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create nodes controller: %w", err)
 	}
 
-	pods, err := NewPodController(PodControllerConfig{
+	var staticPods *StaticPodController
+	var onPodDeletedFunc func(pod *corev1.Pod)
+	if conf.StaticPodPath != "" {
+		staticPods, err = NewStaticPodController(StaticPodControllerConfig{
+			TypedClient:     conf.TypedClient,
+			NodeCacheGetter: nodesCache,
+			ManifestPath:    conf.StaticPodPath,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create static pods controller: %w", err)
+		}
+		onPodDeletedFunc = func(pod *corev1.Pod) {
+			// Create the mirror pod again as the kubelet does
+			if isMirrorPod(pod) {
+				staticPods.Sync(pod.Spec.NodeName)
+			}
+		}
+	}
+
+	var onPodsOnNodeChangedFunc func(nodeName string)
+	if conf.EnableNodePressure || conf.EnableNodeVolumes {
+		onPodsOnNodeChangedFunc = func(nodeName string) {
+			nodes.SyncPressure(nodeName)
+			nodes.SyncVolumes(nodeName)
+		}
+	}
+
+	pods, err = NewPodController(PodControllerConfig{
 		Clock:                                 conf.Clock,
 		EnableCNI:                             conf.EnableCNI,
 		TypedClient:                           conf.TypedClient,
@@ -400,10 +598,19 @@ func (c *Controller) Start(ctx context.Context) error {
 		Lifecycle:                             podLifecycleGetter,
 		PlayStageParallelism:                  conf.PodPlayStageParallelism,
 		NodeGetFunc:                           nodes.Get,
+		StageMaxChainDepth:                    conf.StageMaxChainDepth,
+		TimeScale:                             conf.TimeScale,
+		EnableStageNextAnnotations:            conf.EnableStageNextAnnotations,
 		FuncMap:                               defaultFuncMap,
 		Recorder:                              recorder,
 		ReadOnlyFunc:                          readOnlyFunc,
 		EnableMetrics:                         conf.EnableMetrics,
+		OnPodsOnNodeChangedFunc:               onPodsOnNodeChangedFunc,
+		AdmissionResources: slices.Map(conf.PodAdmissionResources, func(name string) corev1.ResourceName {
+			return corev1.ResourceName(name)
+		}),
+		OnPodDeletedFunc: onPodDeletedFunc,
+		ImageSimulation:  conf.ImageSimulation,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create pods controller: %w", err)
@@ -450,6 +657,9 @@ func (c *Controller) Start(ctx context.Context) error {
 	go func() {
 		for {
 			nodeName := podOnNodeManageQueue.GetOrWait()
+			if staticPods != nil {
+				staticPods.Sync(nodeName)
+			}
 			err = podsInformer.Sync(ctx, informer.Option{
 				FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
 			}, podsChan)
@@ -460,12 +670,24 @@ func (c *Controller) Start(ctx context.Context) error {
 	}()
 
 	c.broadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: c.typedClient.CoreV1().Events("")})
+	if shards != nil {
+		err := shards.Start(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start shards controller: %w", err)
+		}
+	}
 	if nodeLeases != nil {
 		err := nodeLeases.Start(ctx, nodeLeasesChan)
 		if err != nil {
 			return fmt.Errorf("failed to start node leases controller: %w", err)
 		}
 	}
+	if staticPods != nil {
+		err := staticPods.Start(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start static pods controller: %w", err)
+		}
+	}
 	err = pods.Start(ctx, podsChan)
 	if err != nil {
 		return fmt.Errorf("failed to start pods controller: %w", err)
@@ -478,11 +700,116 @@ func (c *Controller) Start(ctx context.Context) error {
 	c.pods = pods
 	c.nodes = nodes
 	c.nodeLeases = nodeLeases
+	c.stages = stages
 	c.nodeCacheGetter = nodesCache
 	c.podCacheGetter = podsCache
+	c.namespaceCacheGetter = namespacesCache
 	return nil
 }
 
+var (
+	podRef  = internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"}
+	nodeRef = internalversion.StageResourceRef{APIGroup: "v1", Kind: "Node"}
+)
+
+func stageRefMatches(a, b internalversion.StageResourceRef) bool {
+	if a.APIGroup == "" {
+		a.APIGroup = "v1"
+	}
+	if b.APIGroup == "" {
+		b.APIGroup = "v1"
+	}
+	return a.APIGroup == b.APIGroup && a.Kind == b.Kind
+}
+
+// customStageRefs returns the resources other than pods and nodes that are driven by stages
+func (c *Controller) customStageRefs() []internalversion.StageResourceRef {
+	refs := []internalversion.StageResourceRef{}
+	add := func(ref internalversion.StageResourceRef) {
+		if stageRefMatches(ref, podRef) || stageRefMatches(ref, nodeRef) {
+			return
+		}
+		for _, r := range refs {
+			if stageRefMatches(r, ref) {
+				return
+			}
+		}
+		refs = append(refs, ref)
+	}
+	for _, stage := range c.conf.CustomStages {
+		add(stage.Spec.ResourceRef)
+	}
+	for _, ref := range c.conf.EnableStageForRefs {
+		add(ref)
+	}
+	return refs
+}
+
+// startStageControllers starts the stage controllers for the resources other than pods and nodes
+func (c *Controller) startStageControllers(ctx context.Context, recorder record.EventRecorder, lifecycleGetterFor func(ref internalversion.StageResourceRef) (resources.Getter[Lifecycle], error)) ([]*StageController, error) {
+	refs := c.customStageRefs()
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	if c.conf.DynamicClient == nil || c.conf.RESTMapper == nil {
+		return nil, fmt.Errorf("dynamic client and rest mapper are required for stages of %s", refs[0].Kind)
+	}
+
+	parallelism := c.conf.CustomPlayStageParallelism
+	if parallelism == 0 {
+		parallelism = 1
+	}
+
+	controllers := make([]*StageController, 0, len(refs))
+	for _, ref := range refs {
+		gv, err := schema.ParseGroupVersion(ref.APIGroup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse api group %q: %w", ref.APIGroup, err)
+		}
+		gvk := gv.WithKind(ref.Kind)
+		mapping, err := c.conf.RESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rest mapping of %s: %w", gvk, err)
+		}
+
+		lifecycle, err := lifecycleGetterFor(ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create lifecycle of %s: %w", gvk, err)
+		}
+
+		ctr, err := NewStageController(StageControllerConfig{
+			Clock:                      c.conf.Clock,
+			DynamicClient:              c.conf.DynamicClient,
+			GVR:                        mapping.Resource,
+			GVK:                        gvk,
+			Lifecycle:                  lifecycle,
+			PlayStageParallelism:       parallelism,
+			StageMaxChainDepth:         c.conf.StageMaxChainDepth,
+			TimeScale:                  c.conf.TimeScale,
+			EnableStageNextAnnotations: c.conf.EnableStageNextAnnotations,
+			FuncMap:                    defaultFuncMap,
+			Recorder:                   recorder,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stage controller of %s: %w", gvk, err)
+		}
+
+		events := make(chan informer.Event[*unstructured.Unstructured], 1)
+		resourceInformer := informer.NewInformer[*unstructured.Unstructured, *unstructured.UnstructuredList](c.conf.DynamicClient.Resource(mapping.Resource))
+		err = resourceInformer.Watch(ctx, informer.Option{}, events)
+		if err != nil {
+			return nil, fmt.Errorf("failed to watch %s: %w", mapping.Resource, err)
+		}
+
+		err = ctr.Start(ctx, events)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start stage controller of %s: %w", gvk, err)
+		}
+		controllers = append(controllers, ctr)
+	}
+	return controllers, nil
+}
+
 // ListNodes returns all nodes
 func (c *Controller) ListNodes() []string {
 	return c.nodes.List()
@@ -498,11 +825,21 @@ func (c *Controller) GetPodCache() informer.Getter[*corev1.Pod] {
 	return c.podCacheGetter
 }
 
+// GetNamespaceCache returns the namespace cache
+func (c *Controller) GetNamespaceCache() informer.Getter[*corev1.Namespace] {
+	return c.namespaceCacheGetter
+}
+
 // GetNodeCache returns the node cache
 func (c *Controller) GetNodeCache() informer.Getter[*corev1.Node] {
 	return c.nodeCacheGetter
 }
 
+// StageStatistics returns the statistics of the stages played by the controllers
+func (c *Controller) StageStatistics() []StageStatistic {
+	return stageStats.List(c.now())
+}
+
 // StartedContainersTotal returns the total number of containers started
 func (c *Controller) StartedContainersTotal(nodeName string) int64 {
 	nodeInfo, ok := c.nodes.Get(nodeName)
@@ -512,6 +849,15 @@ func (c *Controller) StartedContainersTotal(nodeName string) int64 {
 	return nodeInfo.StartedContainer.Load()
 }
 
+// AllocatedResource returns the quantity of the resource requested by the pods on the given node
+func (c *Controller) AllocatedResource(nodeName, resourceName string) float64 {
+	quantity, ok := c.pods.Requests(nodeName)[corev1.ResourceName(resourceName)]
+	if !ok {
+		return 0
+	}
+	return quantity.AsApproximateFloat64()
+}
+
 // Identity returns a unique identifier for this controller
 func Identity() (string, error) {
 	hostname, err := os.Hostname()
@@ -145,6 +145,32 @@ func TestController(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "node controller test: manage nodes with rules",
+			conf: Config{
+				TypedClient: fake.NewSimpleClientset(nodes...),
+				ManageNodeRules: []internalversion.ManageNodeRule{
+					{
+						LabelSelector: "manage-by-kwok",
+						Exclude:       true,
+					},
+					{
+						Names: []string{"node-0", "node-2"},
+					},
+				},
+				NodeStages:               nodeStages,
+				PodStages:                podStages,
+				CIDR:                     "10.0.0.1/24",
+				NodePlayStageParallelism: 1,
+				PodPlayStageParallelism:  1,
+			},
+			wantNodePhase: map[string]corev1.NodePhase{
+				"node-0": corev1.NodePending,
+				"node-1": corev1.NodePending,
+				"node-2": corev1.NodeRunning,
+			},
+			wantErr: false,
+		},
 	}
 
 	ctx := context.Background()
@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+)
+
+// imagePullBandwidthAnnotation is the annotation of the node to override the bandwidth to pull the images,
+// in bytes per second, e.g. "10Mi".
+const imagePullBandwidthAnnotation = "kwok.x-k8s.io/image-pull-bandwidth"
+
+// imagePullVariable is the variable of the pulling of the images of the pod, that can be referenced in the stage expressions,
+// e.g. `$imagePull.duration` is the time until the images are pulled,
+// and `$imagePull.unknownImages` is the images not in the registry.
+const imagePullVariable = "imagePull"
+
+// defaultImagePullBandwidth is the bandwidth of the nodes to pull the images if it is not set
+var defaultImagePullBandwidth = resource.MustParse("100Mi")
+
+// imagePuller simulates the nodes pulling the images from a fake registry,
+// the images are pulled one by one on each node, and the pulled images are not pulled again.
+type imagePuller struct {
+	clock     clock.Clock
+	images    map[string]int64
+	bandwidth int64
+	timeScale float64
+	nodes     maps.SyncMap[string, *nodeImages]
+}
+
+// nodeImages is the images pulled or being pulled on the node
+type nodeImages struct {
+	mut sync.Mutex
+	// pulledAt is the time each image is pulled at
+	pulledAt map[string]time.Time
+	// idleAt is the time the node finishes pulling all the images
+	idleAt time.Time
+}
+
+// newImagePuller returns a new imagePuller, or nil if the image simulation is disabled
+func newImagePuller(conf *internalversion.ImageSimulation, clock clock.Clock, timeScale float64) *imagePuller {
+	if conf == nil {
+		return nil
+	}
+	images := make(map[string]int64, len(conf.Images))
+	for _, image := range conf.Images {
+		images[image.Name] = image.Size.Value()
+	}
+	bandwidth := defaultImagePullBandwidth.Value()
+	if conf.Bandwidth != nil && conf.Bandwidth.Value() > 0 {
+		bandwidth = conf.Bandwidth.Value()
+	}
+	if timeScale <= 0 {
+		timeScale = 1
+	}
+	return &imagePuller{
+		clock:     clock,
+		images:    images,
+		bandwidth: bandwidth,
+		timeScale: timeScale,
+	}
+}
+
+// Known returns whether the image is in the registry
+func (p *imagePuller) Known(image string) bool {
+	_, ok := p.images[image]
+	return ok
+}
+
+// nodeBandwidth returns the bandwidth of the node to pull the images
+func (p *imagePuller) nodeBandwidth(node *corev1.Node) int64 {
+	if node == nil {
+		return p.bandwidth
+	}
+	value, ok := node.Annotations[imagePullBandwidthAnnotation]
+	if !ok {
+		return p.bandwidth
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil || q.Value() <= 0 {
+		return p.bandwidth
+	}
+	return q.Value()
+}
+
+// Pull starts pulling the images of the pod on the node which are not pulled yet,
+// and returns the value of the $imagePull variable for the pod.
+// The duration is scaled by the time scale like the delays of the stages.
+func (p *imagePuller) Pull(nodeName string, node *corev1.Node, pod *corev1.Pod) map[string]interface{} {
+	images, _ := p.nodes.LoadOrStore(nodeName, &nodeImages{
+		pulledAt: map[string]time.Time{},
+	})
+	bandwidth := p.nodeBandwidth(node)
+	now := p.clock.Now()
+
+	images.mut.Lock()
+	defer images.mut.Unlock()
+
+	unknownImages := []interface{}{}
+	var doneAt time.Time
+	pull := func(containers []corev1.Container) {
+		for _, container := range containers {
+			size, ok := p.images[container.Image]
+			if !ok {
+				unknownImages = append(unknownImages, container.Image)
+				continue
+			}
+			pulledAt, ok := images.pulledAt[container.Image]
+			if !ok {
+				start := images.idleAt
+				if start.Before(now) {
+					start = now
+				}
+				seconds := float64(size) / float64(bandwidth) / p.timeScale
+				pulledAt = start.Add(time.Duration(seconds * float64(time.Second)))
+				images.pulledAt[container.Image] = pulledAt
+				images.idleAt = pulledAt
+			}
+			if pulledAt.After(doneAt) {
+				doneAt = pulledAt
+			}
+		}
+	}
+	pull(pod.Spec.InitContainers)
+	pull(pod.Spec.Containers)
+
+	var duration time.Duration
+	if doneAt.After(now) {
+		duration = time.Duration(float64(doneAt.Sub(now)) * p.timeScale)
+	}
+	return map[string]interface{}{
+		"duration":      duration.String(),
+		"unknownImages": unknownImages,
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func podWithImages(images ...string) *corev1.Pod {
+	pod := &corev1.Pod{}
+	for _, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Image: image})
+	}
+	return pod
+}
+
+func TestImagePuller(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clocktesting.NewFakeClock(now)
+	puller := newImagePuller(&internalversion.ImageSimulation{
+		Images: []internalversion.SimulatedImage{
+			{Name: "nginx:1.25", Size: resource.MustParse("100Mi")},
+			{Name: "redis:7", Size: resource.MustParse("50Mi")},
+		},
+		Bandwidth: format.Ptr(resource.MustParse("10Mi")),
+	}, clock, 1)
+
+	if !puller.Known("nginx:1.25") || puller.Known("nginx:latest") {
+		t.Errorf("Known() want nginx:1.25 only")
+	}
+
+	got := puller.Pull("node0", nil, podWithImages("nginx:1.25"))
+	want := map[string]interface{}{
+		"duration":      "10s",
+		"unknownImages": []interface{}{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Pull() = %v, want %v", got, want)
+	}
+
+	// The images are pulled one by one, and the pulled ones are not pulled again
+	clock.Step(4 * time.Second)
+	got = puller.Pull("node0", nil, podWithImages("nginx:1.25", "redis:7", "unknown:1"))
+	want = map[string]interface{}{
+		"duration":      "11s",
+		"unknownImages": []interface{}{"unknown:1"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Pull() = %v, want %v", got, want)
+	}
+
+	clock.Step(20 * time.Second)
+	got = puller.Pull("node0", nil, podWithImages("nginx:1.25"))
+	if got["duration"] != "0s" {
+		t.Errorf("Pull() duration = %v, want 0s for the pulled image", got["duration"])
+	}
+
+	// The bandwidth of the node is overridden by the annotation
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				imagePullBandwidthAnnotation: "50Mi",
+			},
+		},
+	}
+	got = puller.Pull("node1", node, podWithImages("nginx:1.25"))
+	if got["duration"] != "2s" {
+		t.Errorf("Pull() duration = %v, want 2s by the bandwidth of the node", got["duration"])
+	}
+}
+
+func TestImagePuller_TimeScale(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clocktesting.NewFakeClock(now)
+	puller := newImagePuller(&internalversion.ImageSimulation{
+		Images: []internalversion.SimulatedImage{
+			{Name: "nginx:1.25", Size: resource.MustParse("100Mi")},
+		},
+		Bandwidth: format.Ptr(resource.MustParse("1Mi")),
+	}, clock, 10)
+
+	puller.Pull("node0", nil, podWithImages("nginx:1.25"))
+	// 100s of the scenario is 10s of the wall clock
+	clock.Step(5 * time.Second)
+	got := puller.Pull("node0", nil, podWithImages("nginx:1.25"))
+	if got["duration"] != "50s" {
+		t.Errorf("Pull() duration = %v, want 50s", got["duration"])
+	}
+}
+
+func TestLifecycleStage_DelayImagePull(t *testing.T) {
+	stage, err := config.UnmarshalWithType[*internalversion.Stage](`
+apiVersion: kwok.x-k8s.io/v1alpha1
+kind: Stage
+metadata:
+  name: pod-ready
+spec:
+  resourceRef:
+    apiGroup: v1
+    kind: Pod
+  selector:
+    matchExpressions:
+    - key: '( $imagePull.unknownImages // [] ) | length == 0'
+      operator: 'In'
+      values:
+      - 'true'
+  delay:
+    durationMilliseconds: 1000
+    durationFrom:
+      expressionFrom: '$imagePull.duration // empty'
+  next:
+    statusTemplate: |
+      phase: Running
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lifecycle, err := NewLifecycle([]*internalversion.Stage{stage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lifecycle.HasVariable(imagePullVariable) {
+		t.Fatalf("HasVariable(%q) = false, want true", imagePullVariable)
+	}
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	pod := map[string]interface{}{}
+	tests := []struct {
+		name      string
+		imagePull interface{}
+		wantMatch bool
+		wantDelay time.Duration
+	}{
+		{
+			name:      "image simulation disabled",
+			wantMatch: true,
+			wantDelay: time.Second,
+		},
+		{
+			name: "pulling",
+			imagePull: map[string]interface{}{
+				"duration":      "30s",
+				"unknownImages": []interface{}{},
+			},
+			wantMatch: true,
+			wantDelay: 30 * time.Second,
+		},
+		{
+			name: "unknown image",
+			imagePull: map[string]interface{}{
+				"duration":      "0s",
+				"unknownImages": []interface{}{"unknown:1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars := map[string]interface{}{}
+			if tt.imagePull != nil {
+				vars[imagePullVariable] = tt.imagePull
+			}
+			ctx, err := expression.WithVariables(context.Background(), vars)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := lifecycle.Match(ctx, nil, nil, pod)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got != nil) != tt.wantMatch {
+				t.Fatalf("Match() = %v, want match %v", got, tt.wantMatch)
+			}
+			if got == nil {
+				return
+			}
+			delay, _ := got.Delay(ctx, pod, now)
+			if delay != tt.wantDelay {
+				t.Errorf("Delay() = %v, want %v", delay, tt.wantDelay)
+			}
+		})
+	}
+}
@@ -19,20 +19,27 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kwok/pkg/utils/expression"
 	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
 
 // NewLifecycle returns a new Lifecycle.
 func NewLifecycle(stages []*internalversion.Stage) (Lifecycle, error) {
 	lcs := Lifecycle{}
 	for _, stage := range stages {
+		if stagePaused(stage.Annotations) {
+			continue
+		}
 		lc, err := NewLifecycleStage(stage)
 		if err != nil {
 			return nil, fmt.Errorf("lifecycle stage: %w", err)
@@ -48,10 +55,10 @@ func NewLifecycle(stages []*internalversion.Stage) (Lifecycle, error) {
 // Lifecycle is a list of lifecycle stage.
 type Lifecycle []*LifecycleStage
 
-func (s Lifecycle) match(label, annotation labels.Set, data interface{}) ([]*LifecycleStage, error) {
+func (s Lifecycle) match(ctx context.Context, label, annotation labels.Set, data interface{}) ([]*LifecycleStage, error) {
 	out := []*LifecycleStage{}
 	for _, stage := range s {
-		ok, err := stage.match(label, annotation, data)
+		ok, err := stage.match(ctx, label, annotation, data)
 		if err != nil {
 			return nil, err
 		}
@@ -62,13 +69,24 @@ func (s Lifecycle) match(label, annotation labels.Set, data interface{}) ([]*Lif
 	return out, nil
 }
 
+// HasVariable returns whether any stage references the variable in its expressions.
+func (s Lifecycle) HasVariable(name string) bool {
+	for _, stage := range s {
+		if slices.Contains(stage.variables, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // Match returns matched stage.
-func (s Lifecycle) Match(label, annotation labels.Set, data interface{}) (*LifecycleStage, error) {
+// The variables in the context can be referenced by the expressions of the stages.
+func (s Lifecycle) Match(ctx context.Context, label, annotation labels.Set, data interface{}) (*LifecycleStage, error) {
 	data, err := expression.ToJSONStandard(data)
 	if err != nil {
 		return nil, err
 	}
-	stages, err := s.match(label, annotation, data)
+	stages, err := s.match(ctx, label, annotation, data)
 	if err != nil {
 		return nil, err
 	}
@@ -78,9 +96,15 @@ func (s Lifecycle) Match(label, annotation labels.Set, data interface{}) (*Lifec
 	if len(stages) == 1 {
 		return stages[0], nil
 	}
-	totalWeights := 0
-	for _, stage := range stages {
-		totalWeights += stage.weight
+	weights := make([]int64, len(stages))
+	totalWeights := int64(0)
+	for i, stage := range stages {
+		weight, _ := stage.Weight(ctx, data)
+		if weight < 0 {
+			weight = 0
+		}
+		weights[i] = weight
+		totalWeights += weight
 	}
 	if totalWeights == 0 {
 		//nolint:gosec
@@ -88,12 +112,12 @@ func (s Lifecycle) Match(label, annotation labels.Set, data interface{}) (*Lifec
 	}
 
 	//nolint:gosec
-	off := rand.Intn(totalWeights)
-	for _, stage := range stages {
-		if stage.weight == 0 {
+	off := rand.Int63n(totalWeights)
+	for i, stage := range stages {
+		if weights[i] == 0 {
 			continue
 		}
-		off -= stage.weight
+		off -= weights[i]
 		if off < 0 {
 			return stage, nil
 		}
@@ -105,6 +129,9 @@ func (s Lifecycle) Match(label, annotation labels.Set, data interface{}) (*Lifec
 func NewLifecycleStage(s *internalversion.Stage) (*LifecycleStage, error) {
 	stage := &LifecycleStage{
 		name: s.Name,
+		set:  s.Annotations[v1alpha1.StageSetAnnotation],
+
+		ageThresholdsComplete: true,
 	}
 	selector := s.Spec.Selector
 	if selector == nil {
@@ -124,6 +151,12 @@ func NewLifecycleStage(s *internalversion.Stage) (*LifecycleStage, error) {
 				return nil, err
 			}
 			stage.matchExpressions = append(stage.matchExpressions, requirement)
+			stage.addVariables(express.Key)
+			thresholds, complete := ageThresholds(express.Key)
+			stage.ageThresholds = append(stage.ageThresholds, thresholds...)
+			if !complete {
+				stage.ageThresholdsComplete = false
+			}
 		}
 	}
 
@@ -132,6 +165,7 @@ func NewLifecycleStage(s *internalversion.Stage) (*LifecycleStage, error) {
 		var durationFrom *string
 		if delay.DurationFrom != nil {
 			durationFrom = &delay.DurationFrom.ExpressionFrom
+			stage.addVariables(*durationFrom)
 		}
 		var delayDuration time.Duration
 		if delay.DurationMilliseconds != nil {
@@ -147,6 +181,7 @@ func NewLifecycleStage(s *internalversion.Stage) (*LifecycleStage, error) {
 			var jitterDurationFrom *string
 			if delay.JitterDurationFrom != nil {
 				jitterDurationFrom = &delay.JitterDurationFrom.ExpressionFrom
+				stage.addVariables(*jitterDurationFrom)
 			}
 			var jitterDuration *time.Duration
 			if delay.JitterDurationMilliseconds != nil {
@@ -158,13 +193,57 @@ func NewLifecycleStage(s *internalversion.Stage) (*LifecycleStage, error) {
 			}
 			stage.jitterDuration = jitterDurationGetter
 		}
+
+		if delay.Distribution != nil {
+			distribution, err := newDelayDistribution(delay.Distribution)
+			if err != nil {
+				return nil, err
+			}
+			stage.distribution = distribution
+		}
+
+		if delay.Schedule != nil {
+			schedule, err := newStageSchedule(delay.Schedule)
+			if err != nil {
+				return nil, err
+			}
+			stage.schedule = schedule
+		}
 	}
 
-	if weight := s.Spec.Weight; weight > 1 {
-		stage.weight = weight
-	} else {
-		stage.weight = 0
+	if finalizers := s.Spec.Next.Finalizers; finalizers != nil &&
+		(finalizers.HoldDurationMilliseconds != nil || finalizers.HoldDurationFrom != nil) {
+		var holdDurationFrom *string
+		if finalizers.HoldDurationFrom != nil {
+			holdDurationFrom = &finalizers.HoldDurationFrom.ExpressionFrom
+			stage.addVariables(*holdDurationFrom)
+		}
+		var holdDuration *time.Duration
+		if finalizers.HoldDurationMilliseconds != nil {
+			holdDuration = format.Ptr(time.Duration(*finalizers.HoldDurationMilliseconds) * time.Millisecond)
+		}
+		holdDurationGetter, err := expression.NewDurationFrom(holdDuration, holdDurationFrom)
+		if err != nil {
+			return nil, err
+		}
+		stage.finalizersHoldDuration = holdDurationGetter
+	}
+
+	// The weight of 1 is the same as no weight, so that the stages of 1 are not weighted.
+	var weight *int64
+	if s.Spec.Weight > 1 {
+		weight = format.Ptr(int64(s.Spec.Weight))
+	}
+	var weightFrom *string
+	if s.Spec.WeightFrom != nil {
+		weightFrom = &s.Spec.WeightFrom.ExpressionFrom
+		stage.addVariables(*weightFrom)
 	}
+	weightGetter, err := expression.NewIntFrom(weight, weightFrom)
+	if err != nil {
+		return nil, err
+	}
+	stage.weight = weightGetter
 
 	stage.immediateNextStage = s.Spec.ImmediateNextStage
 
@@ -174,20 +253,44 @@ func NewLifecycleStage(s *internalversion.Stage) (*LifecycleStage, error) {
 // LifecycleStage is a resource lifecycle stage manager
 type LifecycleStage struct {
 	name             string
+	set              string
 	matchLabels      labels.Selector
 	matchAnnotations labels.Selector
 	matchExpressions []*expression.Requirement
 
-	weight int
+	weight expression.IntGetter
 	next   *internalversion.StageNext
 
 	duration       expression.DurationGetter
 	jitterDuration expression.DurationGetter
+	distribution   *delayDistribution
+	schedule       *stageSchedule
+
+	finalizersHoldDuration expression.DurationGetter
 
 	immediateNextStage bool
+
+	variables []string
+
+	// ageThresholds are the ages compared by the selector, to recheck the resource once they are crossed.
+	ageThresholds []ageThreshold
+	// ageThresholdsComplete is false if the selector compares an age in a way the thresholds can not be found.
+	ageThresholdsComplete bool
 }
 
-func (s *LifecycleStage) match(label, annotation labels.Set, jsonStandard interface{}) (bool, error) {
+func (s *LifecycleStage) addVariables(src string) {
+	for _, v := range expression.Variables(src) {
+		if !slices.Contains(s.variables, v) {
+			s.variables = append(s.variables, v)
+		}
+	}
+}
+
+func (s *LifecycleStage) match(ctx context.Context, label, annotation labels.Set, jsonStandard interface{}) (bool, error) {
+	if !s.inSets(annotation[v1alpha1.StageSetAnnotation]) {
+		return false, nil
+	}
+
 	if s.matchLabels != nil {
 		if !s.matchLabels.Matches(label) {
 			return false, nil
@@ -201,7 +304,7 @@ func (s *LifecycleStage) match(label, annotation labels.Set, jsonStandard interf
 
 	if s.matchExpressions != nil {
 		for _, requirement := range s.matchExpressions {
-			ok, err := requirement.Matches(context.Background(), jsonStandard)
+			ok, err := requirement.Matches(ctx, jsonStandard)
 			if err != nil {
 				return false, err
 			}
@@ -213,14 +316,81 @@ func (s *LifecycleStage) match(label, annotation labels.Set, jsonStandard interf
 	return true, nil
 }
 
+// inSets returns whether the stage is in one of the comma separated sets,
+// the stages not in any set are only matched without sets.
+func (s *LifecycleStage) inSets(sets string) bool {
+	if sets == "" || s.set == "" {
+		return sets == s.set
+	}
+	for _, set := range strings.Split(sets, ",") {
+		if strings.TrimSpace(set) == s.set {
+			return true
+		}
+	}
+	return false
+}
+
+// Weight returns the weight of the stage for the object.
+func (s *LifecycleStage) Weight(ctx context.Context, v interface{}) (int64, bool) {
+	if s.weight == nil {
+		return 0, false
+	}
+	return s.weight.Get(ctx, v)
+}
+
 // Delay returns the delay duration of the stage.
 // It's not a constant value, it can be a random value.
 func (s *LifecycleStage) Delay(ctx context.Context, v interface{}, now time.Time) (time.Duration, bool) {
+	duration, ok := s.delay(ctx, v, now)
+	if s.finalizersHoldDuration == nil {
+		return duration, ok
+	}
+
+	hold, holdOk := s.finalizersHoldDuration.Get(ctx, v, now)
+	if !holdOk {
+		return duration, ok
+	}
+	// The finalizers are held since the deletion of the resource
+	if since, ok := deletionTimestamp.Get(ctx, v, now); ok {
+		hold += since
+	}
+	if !ok || hold > duration {
+		duration = hold
+	}
+	if duration < 0 {
+		duration = 0
+	}
+	return duration, true
+}
+
+// Scheduled returns whether the stage is applied at the wall-clock times of a schedule.
+func (s *LifecycleStage) Scheduled() bool {
+	return s.schedule != nil
+}
+
+// deletionTimestamp gets the duration from now to the deletionTimestamp of the resource, which is negative once deleting.
+var deletionTimestamp, _ = expression.NewDurationFrom(nil, format.Ptr(".metadata.deletionTimestamp"))
+
+func (s *LifecycleStage) delay(ctx context.Context, v interface{}, now time.Time) (time.Duration, bool) {
+	if s.schedule != nil {
+		return s.schedule.Delay(now)
+	}
+
 	if s.duration == nil {
 		return 0, false
 	}
 
 	duration, ok := s.duration.Get(ctx, v, now)
+	if s.distribution != nil {
+		if !ok {
+			duration = 0
+		}
+		duration += s.distribution.Sample()
+		if duration < 0 {
+			duration = 0
+		}
+		return duration, true
+	}
 	if !ok {
 		return 0, false
 	}
@@ -234,7 +404,7 @@ func (s *LifecycleStage) Delay(ctx context.Context, v interface{}, now time.Time
 		return duration, true
 	}
 
-	if jitterDuration < duration {
+	if jitterDuration <= duration {
 		return jitterDuration, true
 	}
 
@@ -242,6 +412,81 @@ func (s *LifecycleStage) Delay(ctx context.Context, v interface{}, now time.Time
 	return duration + time.Duration(rand.Int63n(int64(jitterDuration-duration))), true
 }
 
+// delayDistribution is a random distribution of the delay time.
+type delayDistribution struct {
+	typ    internalversion.StageDelayDistributionType
+	min    *time.Duration
+	max    *time.Duration
+	mean   time.Duration
+	stdDev time.Duration
+}
+
+func newDelayDistribution(d *internalversion.StageDelayDistribution) (*delayDistribution, error) {
+	dist := &delayDistribution{
+		typ: d.Type,
+	}
+	if d.MinMilliseconds != nil {
+		dist.min = format.Ptr(time.Duration(*d.MinMilliseconds) * time.Millisecond)
+	}
+	if d.MaxMilliseconds != nil {
+		dist.max = format.Ptr(time.Duration(*d.MaxMilliseconds) * time.Millisecond)
+	}
+	if d.MeanMilliseconds != nil {
+		dist.mean = time.Duration(*d.MeanMilliseconds) * time.Millisecond
+	}
+	if d.StdDevMilliseconds != nil {
+		dist.stdDev = time.Duration(*d.StdDevMilliseconds) * time.Millisecond
+	}
+	if dist.min != nil && dist.max != nil && *dist.min > *dist.max {
+		return nil, fmt.Errorf("delay distribution: min %s is greater than max %s", *dist.min, *dist.max)
+	}
+
+	switch d.Type {
+	case internalversion.StageDelayDistributionUniform:
+		if dist.min == nil || dist.max == nil {
+			return nil, fmt.Errorf("delay distribution %s: min and max are required", d.Type)
+		}
+	case internalversion.StageDelayDistributionNormal:
+		if d.MeanMilliseconds == nil || d.StdDevMilliseconds == nil {
+			return nil, fmt.Errorf("delay distribution %s: mean and stdDev are required", d.Type)
+		}
+	case internalversion.StageDelayDistributionExponential:
+		if d.MeanMilliseconds == nil {
+			return nil, fmt.Errorf("delay distribution %s: mean is required", d.Type)
+		}
+	default:
+		return nil, fmt.Errorf("delay distribution: unsupported type %q", d.Type)
+	}
+	return dist, nil
+}
+
+// Sample returns a random value drawn from the distribution.
+func (d *delayDistribution) Sample() time.Duration {
+	var v time.Duration
+	switch d.typ {
+	case internalversion.StageDelayDistributionUniform:
+		if *d.max == *d.min {
+			return *d.min
+		}
+		//nolint:gosec
+		return *d.min + time.Duration(rand.Int63n(int64(*d.max-*d.min)))
+	case internalversion.StageDelayDistributionNormal:
+		//nolint:gosec
+		v = d.mean + time.Duration(rand.NormFloat64()*float64(d.stdDev))
+	case internalversion.StageDelayDistributionExponential:
+		//nolint:gosec
+		v = time.Duration(math.Round(rand.ExpFloat64() * float64(d.mean)))
+	}
+
+	if d.min != nil && v < *d.min {
+		v = *d.min
+	}
+	if d.max != nil && v > *d.max {
+		v = *d.max
+	}
+	return v
+}
+
 // Next returns the next of the stage.
 func (s *LifecycleStage) Next() *internalversion.StageNext {
 	return s.next
@@ -0,0 +1,496 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodeheartbeat "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat"
+	poddrain "sigs.k8s.io/kwok/kustomize/stage/pod/drain"
+	podfast "sigs.k8s.io/kwok/kustomize/stage/pod/fast"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func Test_delayDistribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		dist    internalversion.StageDelayDistribution
+		wantErr bool
+		min     time.Duration
+		max     time.Duration
+	}{
+		{
+			name: "uniform",
+			dist: internalversion.StageDelayDistribution{
+				Type:            internalversion.StageDelayDistributionUniform,
+				MinMilliseconds: format.Ptr[int64](1000),
+				MaxMilliseconds: format.Ptr[int64](2000),
+			},
+			min: time.Second,
+			max: 2 * time.Second,
+		},
+		{
+			name: "uniform without max",
+			dist: internalversion.StageDelayDistribution{
+				Type:            internalversion.StageDelayDistributionUniform,
+				MinMilliseconds: format.Ptr[int64](1000),
+			},
+			wantErr: true,
+		},
+		{
+			name: "normal with bounds",
+			dist: internalversion.StageDelayDistribution{
+				Type:               internalversion.StageDelayDistributionNormal,
+				MeanMilliseconds:   format.Ptr[int64](1000),
+				StdDevMilliseconds: format.Ptr[int64](500),
+				MinMilliseconds:    format.Ptr[int64](500),
+				MaxMilliseconds:    format.Ptr[int64](1500),
+			},
+			min: 500 * time.Millisecond,
+			max: 1500 * time.Millisecond,
+		},
+		{
+			name: "exponential with max",
+			dist: internalversion.StageDelayDistribution{
+				Type:             internalversion.StageDelayDistributionExponential,
+				MeanMilliseconds: format.Ptr[int64](1000),
+				MaxMilliseconds:  format.Ptr[int64](3000),
+			},
+			min: 0,
+			max: 3 * time.Second,
+		},
+		{
+			name: "min greater than max",
+			dist: internalversion.StageDelayDistribution{
+				Type:             internalversion.StageDelayDistributionExponential,
+				MeanMilliseconds: format.Ptr[int64](1000),
+				MinMilliseconds:  format.Ptr[int64](2000),
+				MaxMilliseconds:  format.Ptr[int64](1000),
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown type",
+			dist: internalversion.StageDelayDistribution{
+				Type: "Unknown",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := newDelayDistribution(&tt.dist)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newDelayDistribution() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for i := 0; i != 1000; i++ {
+				got := d.Sample()
+				if got < tt.min || got > tt.max {
+					t.Fatalf("Sample() = %v, want between %v and %v", got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestLifecycle_MatchWeight(t *testing.T) {
+	lifecycle, err := NewLifecycle([]*internalversion.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "running"},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+				Weight:   2,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed"},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+				WeightFrom: &internalversion.ExpressionFromSource{
+					ExpressionFrom: `.metadata.annotations["fail-weight"]`,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{
+		{
+			name: "without weight from",
+			want: "running",
+		},
+		{
+			name: "with weight from",
+			annotations: map[string]string{
+				"fail-weight": "1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			got := map[string]int{}
+			for i := 0; i != 1000; i++ {
+				stage, err := lifecycle.Match(context.Background(), pod.Labels, pod.Annotations, pod)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[stage.Name()]++
+			}
+			if tt.want != "" {
+				if got[tt.want] != 1000 {
+					t.Errorf("Match() got = %v, want always %s", got, tt.want)
+				}
+			} else if got["running"] == 0 || got["failed"] == 0 {
+				t.Errorf("Match() got = %v, want both stages", got)
+			}
+		})
+	}
+}
+
+func TestLifecycle_MatchWeightOne(t *testing.T) {
+	lifecycle, err := NewLifecycle([]*internalversion.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "weight-one"},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+				Weight:   1,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-weight"},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod := &corev1.Pod{}
+	for _, stage := range lifecycle {
+		if weight, _ := stage.Weight(context.Background(), pod); weight != 0 {
+			t.Errorf("want stage %s unweighted, got weight %d", stage.Name(), weight)
+		}
+	}
+
+	// The stages are unweighted, so that both of them are matched at random.
+	got := map[string]int{}
+	for i := 0; i != 1000; i++ {
+		stage, err := lifecycle.Match(context.Background(), pod.Labels, pod.Annotations, pod)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[stage.Name()]++
+	}
+	if got["weight-one"] == 0 || got["no-weight"] == 0 {
+		t.Errorf("Match() got = %v, want both stages", got)
+	}
+}
+
+func TestLifecycleStage_DelayFinalizersHold(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 1, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		delay *internalversion.StageDelay
+		obj   map[string]interface{}
+		want  time.Duration
+	}{
+		{
+			name: "not deleting",
+			obj:  map[string]interface{}{"metadata": map[string]interface{}{}},
+			want: 90 * time.Second,
+		},
+		{
+			name: "deleting",
+			obj: map[string]interface{}{"metadata": map[string]interface{}{
+				"deletionTimestamp": "2023-01-01T00:00:00Z",
+			}},
+			want: 30 * time.Second,
+		},
+		{
+			name: "hold expired",
+			obj: map[string]interface{}{"metadata": map[string]interface{}{
+				"deletionTimestamp": "2022-12-31T00:00:00Z",
+			}},
+			want: 0,
+		},
+		{
+			name: "longer delay",
+			delay: &internalversion.StageDelay{
+				DurationMilliseconds: format.Ptr[int64](120000),
+			},
+			obj: map[string]interface{}{"metadata": map[string]interface{}{
+				"deletionTimestamp": "2023-01-01T00:00:00Z",
+			}},
+			want: 120 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage, err := NewLifecycleStage(&internalversion.Stage{
+				Spec: internalversion.StageSpec{
+					Selector: &internalversion.StageSelector{},
+					Delay:    tt.delay,
+					Next: internalversion.StageNext{
+						Finalizers: &internalversion.StageFinalizers{
+							Empty:                    true,
+							HoldDurationMilliseconds: format.Ptr[int64](90000),
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := stage.Delay(context.Background(), tt.obj, now)
+			if !ok {
+				t.Fatal("want delay")
+			}
+			if got != tt.want {
+				t.Errorf("want delay %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewLifecycle_Paused(t *testing.T) {
+	lifecycle, err := NewLifecycle([]*internalversion.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "running"},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "paused",
+				Annotations: map[string]string{
+					"stage.kwok.x-k8s.io/paused": "true",
+				},
+			},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lifecycle) != 1 || lifecycle[0].Name() != "running" {
+		t.Fatalf("want only the running stage, got %d stages", len(lifecycle))
+	}
+}
+
+func TestLifecycle_MatchSets(t *testing.T) {
+	lifecycle, err := NewLifecycle([]*internalversion.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "slow",
+				Annotations: map[string]string{
+					"stage.kwok.x-k8s.io/set": "slow",
+				},
+			},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "flaky",
+				Annotations: map[string]string{
+					"stage.kwok.x-k8s.io/set": "flaky",
+				},
+			},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		sets string
+		want []string
+	}{
+		{
+			name: "no sets",
+			want: []string{"default"},
+		},
+		{
+			name: "one set",
+			sets: "slow",
+			want: []string{"slow"},
+		},
+		{
+			name: "multiple sets",
+			sets: "slow, flaky",
+			want: []string{"slow", "flaky"},
+		},
+		{
+			name: "unknown set",
+			sets: "unknown",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var annotations map[string]string
+			if tt.sets != "" {
+				annotations = map[string]string{"stage.kwok.x-k8s.io/set": tt.sets}
+			}
+			stages, err := lifecycle.match(context.Background(), nil, annotations, map[string]interface{}{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(stages))
+			for _, stage := range stages {
+				got = append(got, stage.Name())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want stages %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLifecycleStage_DelayHeartbeatInterval(t *testing.T) {
+	stage, err := config.UnmarshalWithType[*internalversion.Stage](nodeheartbeat.DefaultNodeHeartbeat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lifecycleStage, err := NewLifecycleStage(stage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	got, ok := lifecycleStage.Delay(context.Background(), map[string]interface{}{
+		"metadata": map[string]interface{}{},
+	}, now)
+	if !ok || got < 20*time.Second || got > 25*time.Second {
+		t.Errorf("want delay between 20s and 25s, got %s", got)
+	}
+
+	got, ok = lifecycleStage.Delay(context.Background(), map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				nodeHeartbeatIntervalAnnotation: "2m",
+			},
+		},
+	}, now)
+	if !ok || got != 2*time.Minute {
+		t.Errorf("want delay 2m, got %s", got)
+	}
+}
+
+func TestLifecycle_MatchDrain(t *testing.T) {
+	stages, err := slices.MapWithError([]string{
+		podfast.DefaultPodDelete,
+		poddrain.DefaultPodDrainDelete,
+	}, config.UnmarshalWithType[*internalversion.Stage, string])
+	if err != nil {
+		t.Fatal(err)
+	}
+	lifecycle, err := NewLifecycle(stages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	pod := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"deletionTimestamp": now.Add(30 * time.Second).Format(time.RFC3339),
+		},
+	}
+	tests := []struct {
+		name      string
+		node      *corev1.Node
+		want      string
+		wantDelay time.Duration
+	}{
+		{
+			name: "schedulable node",
+			node: &corev1.Node{},
+			want: "pod-delete",
+		},
+		{
+			name: "cordoned node",
+			node: &corev1.Node{
+				Spec: corev1.NodeSpec{
+					Unschedulable: true,
+				},
+			},
+			want:      "pod-drain-delete",
+			wantDelay: 30 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := expression.WithVariables(context.Background(), map[string]interface{}{
+				"node": tt.node,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			stage, err := lifecycle.Match(ctx, nil, nil, pod)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stage == nil || stage.Name() != tt.want {
+				t.Fatalf("want stage %s, got %v", tt.want, stage)
+			}
+			delay, _ := stage.Delay(ctx, pod, now)
+			if delay != tt.wantDelay {
+				t.Errorf("want delay %s, got %s", tt.wantDelay, delay)
+			}
+		})
+	}
+}
@@ -23,13 +23,16 @@ import (
 	"fmt"
 	"net"
 	"sync/atomic"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
@@ -45,6 +48,9 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/queue"
 )
 
+// nodeLeaseOnlyAnnotation is the annotation of the node to opt out of the node lease only mode with "false"
+const nodeLeaseOnlyAnnotation = "kwok.x-k8s.io/node-lease-only"
+
 var (
 	// https://kubernetes.io/docs/concepts/architecture/nodes/#condition
 	nodeConditions = []corev1.NodeCondition{
@@ -88,6 +94,7 @@ type NodeController struct {
 	typedClient                           kubernetes.Interface
 	nodeCacheGetter                       informer.Getter[*corev1.Node]
 	nodeIP                                string
+	nodeIPs                               []string
 	nodeName                              string
 	nodePort                              int
 	disregardStatusWithAnnotationSelector labels.Selector
@@ -100,9 +107,24 @@ type NodeController struct {
 	lifecycle                             resources.Getter[Lifecycle]
 	delayQueue                            queue.DelayingQueue[resourceStageJob[*corev1.Node]]
 	delayQueueMapping                     maps.SyncMap[string, resourceStageJob[*corev1.Node]]
+	stageChains                           *stageChains
+	timeScale                             float64
+	enableStageNextAnnotations            bool
 	recorder                              record.EventRecorder
 	readOnlyFunc                          func(nodeName string) bool
 	enableMetrics                         bool
+	podRequestsFunc                       func(nodeName string) corev1.ResourceList
+	nodePressureThreshold                 float64
+	pressureQueue                         queue.Queue[string]
+	nodeLeaseOnly                         bool
+	flapping                              *nodeFlapping
+	flappingQueue                         queue.DelayingQueue[string]
+	podClaimsFunc                         func(nodeName string) []log.ObjectRef
+	volumesQueue                          queue.Queue[string]
+	nodeRecorderFunc                      func(source corev1.EventSource) record.EventRecorder
+	nodesReady                            maps.SyncMap[string, corev1.ConditionStatus]
+	rebootQueue                           queue.DelayingQueue[string]
+	onNodeRebootFunc                      func(ctx context.Context, nodeName string, up bool) error
 }
 
 // NodeControllerConfig is the configuration for the NodeController
@@ -118,10 +140,22 @@ type NodeControllerConfig struct {
 	NodePort                              int
 	Lifecycle                             resources.Getter[Lifecycle]
 	PlayStageParallelism                  uint
+	StageMaxChainDepth                    uint
+	TimeScale                             float64
+	EnableStageNextAnnotations            bool
 	FuncMap                               gotpl.FuncMap
 	Recorder                              record.EventRecorder
 	ReadOnlyFunc                          func(nodeName string) bool
 	EnableMetrics                         bool
+	PodRequestsFunc                       func(nodeName string) corev1.ResourceList
+	NodePressureThreshold                 float64
+	NodeLeaseOnly                         bool
+	FlapWithLabelSelector                 string
+	FlappingUp                            time.Duration
+	FlappingDown                          time.Duration
+	PodClaimsFunc                         func(nodeName string) []log.ObjectRef
+	NodeRecorderFunc                      func(source corev1.EventSource) record.EventRecorder
+	OnNodeRebootFunc                      func(ctx context.Context, nodeName string, up bool) error
 }
 
 // NodeInfo is the collection of necessary node information
@@ -145,10 +179,17 @@ func NewNodeController(conf NodeControllerConfig) (*NodeController, error) {
 		return nil, err
 	}
 
+	flapping, err := newNodeFlapping(conf.FlapWithLabelSelector, conf.FlappingUp, conf.FlappingDown)
+	if err != nil {
+		return nil, err
+	}
+
 	if conf.Clock == nil {
 		conf.Clock = clock.RealClock{}
 	}
 
+	nodeIPs := splitList(conf.NodeIP)
+
 	c := &NodeController{
 		clock:                                 conf.Clock,
 		typedClient:                           conf.TypedClient,
@@ -156,20 +197,36 @@ func NewNodeController(conf NodeControllerConfig) (*NodeController, error) {
 		disregardStatusWithAnnotationSelector: disregardStatusWithAnnotationSelector,
 		disregardStatusWithLabelSelector:      disregardStatusWithLabelSelector,
 		onNodeManagedFunc:                     conf.OnNodeManagedFunc,
-		nodeIP:                                conf.NodeIP,
+		nodeIP:                                firstOf(nodeIPs),
+		nodeIPs:                               nodeIPs,
 		nodeName:                              conf.NodeName,
 		nodePort:                              conf.NodePort,
 		delayQueue:                            queue.NewDelayingQueue[resourceStageJob[*corev1.Node]](conf.Clock),
 		lifecycle:                             conf.Lifecycle,
 		playStageParallelism:                  conf.PlayStageParallelism,
 		preprocessChan:                        make(chan *corev1.Node),
+		stageChains:                           newStageChains(conf.StageMaxChainDepth, conf.Recorder),
+		timeScale:                             conf.TimeScale,
+		enableStageNextAnnotations:            conf.EnableStageNextAnnotations,
 		recorder:                              conf.Recorder,
 		readOnlyFunc:                          conf.ReadOnlyFunc,
 		enableMetrics:                         conf.EnableMetrics,
+		podRequestsFunc:                       conf.PodRequestsFunc,
+		nodePressureThreshold:                 conf.NodePressureThreshold,
+		pressureQueue:                         queue.NewQueue[string](),
+		nodeLeaseOnly:                         conf.NodeLeaseOnly,
+		flapping:                              flapping,
+		flappingQueue:                         queue.NewDelayingQueue[string](conf.Clock),
+		podClaimsFunc:                         conf.PodClaimsFunc,
+		volumesQueue:                          queue.NewQueue[string](),
+		nodeRecorderFunc:                      conf.NodeRecorderFunc,
+		rebootQueue:                           queue.NewDelayingQueue[string](conf.Clock),
+		onNodeRebootFunc:                      conf.OnNodeRebootFunc,
 	}
 
 	funcMap := maps.Merge(gotpl.FuncMap{
 		"NodeIP":   c.funcNodeIP,
+		"NodeIPs":  c.funcNodeIPs,
 		"NodeName": c.funcNodeName,
 		"NodePort": c.funcNodePort,
 		"NodeConditions": func() interface{} {
@@ -183,10 +240,22 @@ func NewNodeController(conf NodeControllerConfig) (*NodeController, error) {
 // Start starts the fake nodes controller
 // if nodeSelectorFunc is not nil, it will use it to determine if the node should be managed
 func (c *NodeController) Start(ctx context.Context, events <-chan informer.Event[*corev1.Node]) error {
+	stageDelayQueueLength.Register("Node", c.delayQueueMapping.Size)
+
 	go c.preprocessWorker(ctx)
 	for i := uint(0); i < c.playStageParallelism; i++ {
 		go c.playStageWorker(ctx)
 	}
+	if c.podRequestsFunc != nil {
+		go c.pressureWorker(ctx)
+	}
+	if c.flapping != nil {
+		go c.flappingWorker(ctx)
+	}
+	if c.podClaimsFunc != nil {
+		go c.volumesWorker(ctx)
+	}
+	go c.rebootWorker(ctx)
 	go c.watchResources(ctx, events)
 	return nil
 }
@@ -228,7 +297,14 @@ loop:
 							"node", node.Name,
 						)
 					} else {
+						c.recordLifecycleEvents(node)
 						c.preprocessChan <- node
+						if c.podRequestsFunc != nil {
+							// The stages may reset the pressure conditions
+							c.SyncPressure(node.Name)
+						}
+						c.syncFlappingLater(node)
+						c.syncRebootLater(node)
 					}
 				}
 
@@ -246,6 +322,14 @@ loop:
 					if ok {
 						c.delayQueue.Cancel(resourceJob)
 					}
+					c.flappingQueue.Cancel(node.Name)
+					c.rebootQueue.Cancel(node.Name)
+					c.nodesReady.Delete(node.Name)
+
+					c.stageChains.Delete(&corev1.ObjectReference{
+						Kind: "Node",
+						Name: node.Name,
+					})
 				}
 			}
 		case <-ctx.Done():
@@ -285,6 +369,48 @@ func (c *NodeController) finalizersModify(ctx context.Context, node *corev1.Node
 	return result, nil
 }
 
+// annotateStageNext annotates the node with the next stage planned for it,
+// the patched node is returned, or the node itself if it is not patched.
+func (c *NodeController) annotateStageNext(ctx context.Context, node *corev1.Node, stage string, at time.Time) *corev1.Node {
+	patch, err := stageNextPatch(node.Annotations, stage, at)
+	if err != nil || patch == nil {
+		return node
+	}
+	result, err := c.typedClient.CoreV1().Nodes().Patch(ctx, node.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Error("Failed to annotate the next stage", err,
+			"node", node.Name,
+		)
+		return node
+	}
+	return result
+}
+
+// advertiseDevices patches the capacity and allocatable of the node with the devices of its annotation
+func (c *NodeController) advertiseDevices(ctx context.Context, node *corev1.Node) (*corev1.Node, error) {
+	patch, err := nodeDevicesPatch(node)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Warn("Skip devices of node",
+			"node", node.Name,
+			"err", err,
+		)
+		return node, nil
+	}
+	if patch == nil {
+		return node, nil
+	}
+	result, err := c.patchResource(ctx, node, patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to advertise devices: %w", err)
+	}
+	if result == nil {
+		return node, nil
+	}
+	return result, nil
+}
+
 // deleteResource deletes a node
 func (c *NodeController) deleteResource(ctx context.Context, node *corev1.Node) error {
 	logger := log.FromContext(ctx)
@@ -307,6 +433,330 @@ func (c *NodeController) deleteResource(ctx context.Context, node *corev1.Node)
 	return nil
 }
 
+// SyncPressure flips the pressure conditions of the node by the requests of the pods on it later
+func (c *NodeController) SyncPressure(nodeName string) {
+	if c.podRequestsFunc == nil {
+		return
+	}
+	c.pressureQueue.Add(nodeName)
+}
+
+// pressureWorker receives the node name from the pressureQueue and syncs its pressure conditions
+func (c *NodeController) pressureWorker(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for ctx.Err() == nil {
+		nodeName := c.pressureQueue.GetOrWait()
+		err := c.syncPressure(ctx, nodeName)
+		if err != nil {
+			logger.Error("Failed to sync node pressure", err,
+				"node", nodeName,
+			)
+		}
+	}
+	logger.Debug("Stop pressure worker")
+}
+
+// syncPressure flips the pressure conditions of the node by the requests of the pods on it
+func (c *NodeController) syncPressure(ctx context.Context, nodeName string) error {
+	if _, ok := c.nodesSets.Load(nodeName); !ok || c.nodeCacheGetter == nil || c.readOnly(nodeName) {
+		return nil
+	}
+	node, ok := c.nodeCacheGetter.Get(nodeName)
+	if !ok {
+		return nil
+	}
+	patch, err := nodePressurePatch(node, c.podRequestsFunc(nodeName), c.nodePressureThreshold, c.clock.Now())
+	if err != nil || patch == nil {
+		return err
+	}
+	_, err = c.patchResource(ctx, node, patch)
+	return err
+}
+
+// SyncVolumes reports the volumes in use by the pods on the node later
+func (c *NodeController) SyncVolumes(nodeName string) {
+	if c.podClaimsFunc == nil {
+		return
+	}
+	c.volumesQueue.Add(nodeName)
+}
+
+// volumesWorker receives the node name from the volumesQueue and syncs its volumes in use
+func (c *NodeController) volumesWorker(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for ctx.Err() == nil {
+		nodeName := c.volumesQueue.GetOrWait()
+		err := c.syncVolumes(ctx, nodeName)
+		if err != nil {
+			logger.Error("Failed to sync node volumes", err,
+				"node", nodeName,
+			)
+		}
+	}
+	logger.Debug("Stop volumes worker")
+}
+
+// syncVolumes reports the volumes of the bound PersistentVolumeClaims of the pods on the node
+// in the volumesInUse of the node, as the kubelet does once it mounts them.
+func (c *NodeController) syncVolumes(ctx context.Context, nodeName string) error {
+	if _, ok := c.nodesSets.Load(nodeName); !ok || c.nodeCacheGetter == nil || c.readOnly(nodeName) {
+		return nil
+	}
+	node, ok := c.nodeCacheGetter.Get(nodeName)
+	if !ok {
+		return nil
+	}
+
+	var inUse []corev1.UniqueVolumeName
+	for _, claim := range c.podClaimsFunc(nodeName) {
+		pvc, err := c.typedClient.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(ctx, claim.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if pvc.Spec.VolumeName == "" {
+			continue
+		}
+		pv, err := c.typedClient.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		name, ok := volumeUniqueName(pv)
+		if !ok {
+			continue
+		}
+		inUse = append(inUse, name)
+	}
+
+	patch, err := nodeVolumesPatch(node, inUse)
+	if err != nil || patch == nil {
+		return err
+	}
+	_, err = c.patchResource(ctx, node, patch)
+	return err
+}
+
+// syncFlappingLater flips the Ready condition of the node now if it is out of date,
+// or at the next flip of it, if the node is flapping.
+func (c *NodeController) syncFlappingLater(node *corev1.Node) {
+	if c.flapping == nil || !c.flapping.match(node) {
+		return
+	}
+	now := c.clock.Now()
+	ready, since, next := c.flapping.phase(node, now)
+	patch, _ := nodeFlappingPatch(node, ready, since, now)
+	if patch != nil {
+		c.flappingQueue.Add(node.Name)
+		return
+	}
+	c.flappingQueue.AddAfter(node.Name, next.Sub(now))
+}
+
+// flappingWorker receives the node name from the flappingQueue and flips its Ready condition
+func (c *NodeController) flappingWorker(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for ctx.Err() == nil {
+		nodeName := c.flappingQueue.GetOrWait()
+		err := c.syncFlapping(ctx, nodeName)
+		if err != nil {
+			logger.Error("Failed to sync node flapping", err,
+				"node", nodeName,
+			)
+		}
+	}
+	logger.Debug("Stop flapping worker")
+}
+
+// syncFlapping flips the Ready condition of the node by the phase of it, and schedules the next flip
+func (c *NodeController) syncFlapping(ctx context.Context, nodeName string) error {
+	if _, ok := c.nodesSets.Load(nodeName); !ok || c.nodeCacheGetter == nil || c.readOnly(nodeName) {
+		return nil
+	}
+	node, ok := c.nodeCacheGetter.Get(nodeName)
+	if !ok || !c.flapping.match(node) {
+		return nil
+	}
+	now := c.clock.Now()
+	ready, since, next := c.flapping.phase(node, now)
+	c.flappingQueue.AddAfter(nodeName, next.Sub(now))
+
+	patch, err := nodeFlappingPatch(node, ready, since, now)
+	if err != nil || patch == nil {
+		return err
+	}
+	_, err = c.patchResource(ctx, node, patch)
+	if err != nil {
+		return err
+	}
+	logger := log.FromContext(ctx)
+	logger.Info("Flip node",
+		"node", nodeName,
+		"ready", ready,
+		"next", next,
+	)
+	return nil
+}
+
+// flappingDown returns whether the node is flapping and down,
+// the stages do not update the status of the node then, as the kubelet of it is down.
+func (c *NodeController) flappingDown(node *corev1.Node) bool {
+	if c.flapping == nil || !c.flapping.match(node) {
+		return false
+	}
+	ready, _, _ := c.flapping.phase(node, c.clock.Now())
+	if ready {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return true
+		}
+	}
+	// The conditions are not initialized by the stages yet
+	return false
+}
+
+// syncRebootLater reboots the node now if it is annotated to reboot,
+// or brings it back once its reboot is done, if it is being rebooted.
+func (c *NodeController) syncRebootLater(node *corev1.Node) {
+	if _, ok := node.Annotations[nodeRebootAnnotation]; ok {
+		c.rebootQueue.Add(node.Name)
+		return
+	}
+	until, ok := nodeRebootingUntil(node)
+	if !ok {
+		return
+	}
+	c.rebootQueue.AddAfter(node.Name, until.Sub(c.clock.Now()))
+}
+
+// rebootWorker receives the node name from the rebootQueue and reboots it
+func (c *NodeController) rebootWorker(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for ctx.Err() == nil {
+		nodeName := c.rebootQueue.GetOrWait()
+		err := c.syncReboot(ctx, nodeName)
+		if err != nil {
+			logger.Error("Failed to sync node reboot", err,
+				"node", nodeName,
+			)
+		}
+	}
+	logger.Debug("Stop reboot worker")
+}
+
+// syncReboot takes the node annotated to reboot down until the duration of the annotation passes,
+// and brings it back with a new boot ID once it passes, the containers of the pods on it are restarted then.
+func (c *NodeController) syncReboot(ctx context.Context, nodeName string) error {
+	if _, ok := c.nodesSets.Load(nodeName); !ok || c.nodeCacheGetter == nil || c.readOnly(nodeName) {
+		return nil
+	}
+	node, ok := c.nodeCacheGetter.Get(nodeName)
+	if !ok {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+	now := c.clock.Now()
+
+	if value, ok := node.Annotations[nodeRebootAnnotation]; ok {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			// Drop the invalid annotation, so it is not retried on every update of the node
+			patch, _ := nodeRebootAnnotationsPatch(time.Time{})
+			_, _ = c.typedClient.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+			return fmt.Errorf("invalid duration %q of annotation %s", value, nodeRebootAnnotation)
+		}
+		until := now.Add(duration)
+		err = c.patchReboot(ctx, node, until, false, now)
+		if err != nil {
+			return err
+		}
+		c.rebootQueue.AddAfter(nodeName, duration)
+		logger.Info("Reboot node",
+			"node", nodeName,
+			"until", until,
+		)
+		return nil
+	}
+
+	until, ok := nodeRebootingUntil(node)
+	if !ok {
+		return nil
+	}
+	if now.Before(until) {
+		c.rebootQueue.AddAfter(nodeName, until.Sub(now))
+		return nil
+	}
+	err := c.patchReboot(ctx, node, time.Time{}, true, now)
+	if err != nil {
+		return err
+	}
+	logger.Info("Node rebooted",
+		"node", nodeName,
+	)
+	return nil
+}
+
+// patchReboot patches the node and the pods on it going down until the time, or coming back
+func (c *NodeController) patchReboot(ctx context.Context, node *corev1.Node, until time.Time, up bool, now time.Time) error {
+	bootID := string(uuid.NewUUID())
+	status, err := nodeRebootPatch(up, bootID, now)
+	if err != nil {
+		return err
+	}
+	annotations, err := nodeRebootAnnotationsPatch(until)
+	if err != nil {
+		return err
+	}
+
+	// The stages stop updating the node once it is annotated, and resume once the annotation is removed
+	if !up {
+		_, err = c.typedClient.CoreV1().Nodes().Patch(ctx, node.Name, types.MergePatchType, annotations, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.patchResource(ctx, node, status)
+	if err != nil {
+		return err
+	}
+	if c.onNodeRebootFunc != nil {
+		err = c.onNodeRebootFunc(ctx, node.Name, up)
+		if err != nil {
+			return err
+		}
+	}
+	if up {
+		_, err = c.typedClient.CoreV1().Nodes().Patch(ctx, node.Name, types.MergePatchType, annotations, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+		if c.nodeRecorderFunc != nil {
+			c.nodeRecorderFunc(corev1.EventSource{
+				Component: kubeletComponent,
+				Host:      node.Name,
+			}).Eventf(&corev1.ObjectReference{
+				Kind: "Node",
+				Name: node.Name,
+				UID:  types.UID(node.Name),
+			}, corev1.EventTypeWarning, "Rebooted", "Node %s has been rebooted, boot id: %s", node.Name, bootID)
+		}
+	}
+	return nil
+}
+
+// rebooting returns whether the node is being rebooted,
+// the stages do not update the status of the node then, as the kubelet of it is down.
+func rebooting(node *corev1.Node) bool {
+	_, ok := node.Annotations[nodeRebootingUntilAnnotation]
+	return ok
+}
+
 // preprocessWorker receives the resource from the preprocessChan and preprocess it
 func (c *NodeController) preprocessWorker(ctx context.Context) {
 	logger := log.FromContext(ctx)
@@ -340,25 +790,77 @@ func (c *NodeController) preprocess(ctx context.Context, node *corev1.Node) erro
 		"node", key,
 	)
 
+	if stagePaused(node.Annotations) {
+		if ok {
+			c.delayQueueMapping.Delete(key)
+			c.delayQueue.Cancel(resourceJob)
+		}
+		logger.Debug("Skip node",
+			"reason", "stages paused",
+		)
+		return nil
+	}
+
+	if ok && resourceJob.Step != 0 {
+		logger.Debug("Skip node",
+			"reason", "patches of the stage are being applied",
+		)
+		return nil
+	}
+
+	node, err := c.advertiseDevices(ctx, node)
+	if err != nil {
+		return err
+	}
+
 	data, err := expression.ToJSONStandard(node)
 	if err != nil {
 		return err
 	}
 
 	lifecycle := c.lifecycle.Get()
-	stage, err := lifecycle.Match(node.Labels, node.Annotations, data)
+	now := c.clock.Now()
+	ctx, err = expression.WithVariables(ctx, ageVariables(lifecycle, data, now, c.timeScale))
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	stage, err := lifecycle.Match(ctx, node.Labels, node.Annotations, data)
+	stageEvaluationDurationSeconds.WithLabelValues("Node").Observe(time.Since(start).Seconds())
 	if err != nil {
 		return fmt.Errorf("stage match: %w", err)
 	}
 	if stage == nil {
+		if c.enableStageNextAnnotations {
+			node = c.annotateStageNext(ctx, node, "", time.Time{})
+		}
+		if wait, ok := nextAgeRecheck(lifecycle, data, now, c.timeScale); ok {
+			// The ages of the resource change without any update, so recheck it once the next threshold is crossed.
+			item := resourceStageJob[*corev1.Node]{
+				Resource: node,
+				Key:      key,
+			}
+			if c.delayQueue.AddAfter(item, wait) {
+				c.delayQueueMapping.Store(key, item)
+			}
+		}
 		logger.Debug("Skip node",
 			"reason", "not match any stages",
 		)
 		return nil
 	}
+	stageMatchTotal.WithLabelValues("Node", stage.Name()).Inc()
 
-	now := c.clock.Now()
-	delay, _ := stage.Delay(ctx, data, now)
+	delay := stageDelay(ctx, stage, data, now, c.timeScale)
+	if c.enableStageNextAnnotations {
+		// The stage played at once is not planned, so the annotations of the previous stage are removed.
+		next, at := "", time.Time{}
+		if delay != 0 {
+			next, at = stage.Name(), now.Add(delay)
+		}
+		node = c.annotateStageNext(ctx, node, next, at)
+	}
+	stageStats.Matched("Node", stage.Name(), delay)
 
 	if delay != 0 {
 		stageName := stage.Name()
@@ -388,46 +890,89 @@ func (c *NodeController) preprocess(ctx context.Context, node *corev1.Node) erro
 func (c *NodeController) playStageWorker(ctx context.Context) {
 	for ctx.Err() == nil {
 		node := c.delayQueue.GetOrWait()
+		if node.Stage == nil {
+			// Recheck the resource against the stages, unless it has been updated since.
+			if job, ok := c.delayQueueMapping.Load(node.Key); ok && job == node {
+				c.delayQueueMapping.Delete(node.Key)
+				c.preprocessChan <- node.Resource
+			}
+			continue
+		}
 		c.delayQueueMapping.Delete(node.Key)
-		c.playStage(ctx, node.Resource, node.Stage)
+		if node.Step != 0 {
+			c.playStagePatch(ctx, node.Resource, node.Stage, node.Step)
+		} else {
+			c.playStage(ctx, node.Resource, node.Stage)
+		}
 	}
 }
 
 // playStage plays the stage
 func (c *NodeController) playStage(ctx context.Context, node *corev1.Node, stage *LifecycleStage) {
 	next := stage.Next()
+	stageStats.Played("Node", stage.Name(), c.clock.Now())
 	logger := log.FromContext(ctx)
 	logger = logger.With(
 		"node", node.Name,
 		"stage", stage.Name(),
 	)
 
-	if next.Event != nil && c.recorder != nil {
-		c.recorder.Event(&corev1.ObjectReference{
-			Kind:      "Node",
-			UID:       node.UID,
-			Name:      node.Name,
-			Namespace: "",
-		}, next.Event.Type, next.Event.Reason, next.Event.Message)
+	ref := &corev1.ObjectReference{
+		Kind:      "Node",
+		UID:       node.UID,
+		Name:      node.Name,
+		Namespace: "",
+	}
+	recordStageEvents(ctx, c.recorder, c.renderer, ref, next, node)
+	var status []byte
+	if next.Webhook != nil {
+		var err error
+		status, err = callStageWebhook(ctx, stage.Name(), next.Webhook, node)
+		if err != nil {
+			logger.Error("Failed to call webhook", err)
+			stagePatchFailedTotal.WithLabelValues("Node", stage.Name()).Inc()
+			return
+		}
 	}
+	// The patches are applied after the next, and the immediate next stage is matched after the last patch.
+	patches := !next.Delete && len(next.Patches) != 0
+	latest := node
 	if next.Finalizers != nil {
 		result, err := c.finalizersModify(ctx, node, next.Finalizers)
 		if err != nil {
 			logger.Error("Failed to finalizers of node", err)
+			stagePatchFailedTotal.WithLabelValues("Node", stage.Name()).Inc()
+		}
+		if result != nil {
+			latest = result
+			if !patches && stage.ImmediateNextStage() &&
+				c.stageChains.Next(ctx, ref, node.ResourceVersion, stage.Name(), result.ResourceVersion) {
+				c.preprocessChan <- result
+			}
 		}
-		if result != nil && stage.ImmediateNextStage() {
-			c.preprocessChan <- result
+	}
+	if !next.Delete {
+		for _, subresource := range next.Subresources {
+			err := c.patchSubresource(ctx, node, subresource)
+			if err != nil {
+				logger.Error("Failed to patch subresource", err,
+					"subresource", subresource.Name,
+				)
+				stagePatchFailedTotal.WithLabelValues("Node", stage.Name()).Inc()
+			}
 		}
 	}
 	if next.Delete {
 		err := c.deleteResource(ctx, node)
 		if err != nil {
 			logger.Error("Failed to delete node", err)
+			stagePatchFailedTotal.WithLabelValues("Node", stage.Name()).Inc()
 		}
-	} else if next.StatusTemplate != "" {
-		patch, err := c.computePatch(node, next.StatusTemplate)
+	} else if next.StatusTemplate != "" || status != nil {
+		patch, err := c.computePatch(node, next.StatusTemplate, status)
 		if err != nil {
 			logger.Error("Failed to configure node", err)
+			stagePatchFailedTotal.WithLabelValues("Node", stage.Name()).Inc()
 			return
 		}
 		if patch == nil {
@@ -438,12 +983,84 @@ func (c *NodeController) playStage(ctx context.Context, node *corev1.Node, stage
 			result, err := c.patchResource(ctx, node, patch)
 			if err != nil {
 				logger.Error("Failed to patch node", err)
+				stagePatchFailedTotal.WithLabelValues("Node", stage.Name()).Inc()
 			}
-			if result != nil && stage.ImmediateNextStage() {
-				c.preprocessChan <- result
+			if result != nil {
+				latest = result
+				if !patches && stage.ImmediateNextStage() &&
+					c.stageChains.Next(ctx, ref, node.ResourceVersion, stage.Name(), result.ResourceVersion) {
+					c.preprocessChan <- result
+				}
 			}
 		}
 	}
+	if patches {
+		c.addStagePatch(latest, stage, 1)
+	}
+}
+
+// playStagePatch applies the patch of the stage, and schedules the next patch
+func (c *NodeController) playStagePatch(ctx context.Context, node *corev1.Node, stage *LifecycleStage, step int) {
+	patches := stage.Next().Patches
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"node", node.Name,
+		"stage", stage.Name(),
+		"step", step,
+	)
+
+	patch, err := c.computePatch(node, patches[step-1].StatusTemplate, nil)
+	if err != nil {
+		logger.Error("Failed to configure node", err)
+		stagePatchFailedTotal.WithLabelValues("Node", stage.Name()).Inc()
+		return
+	}
+	latest := node
+	if patch == nil {
+		logger.Debug("Skip node",
+			"reason", "do not need to modify",
+		)
+	} else {
+		result, err := c.patchResource(ctx, node, patch)
+		if err != nil {
+			logger.Error("Failed to patch node", err)
+			stagePatchFailedTotal.WithLabelValues("Node", stage.Name()).Inc()
+			return
+		}
+		if result == nil {
+			return
+		}
+		latest = result
+	}
+
+	if step < len(patches) {
+		c.addStagePatch(latest, stage, step+1)
+		return
+	}
+
+	ref := &corev1.ObjectReference{
+		Kind:      "Node",
+		UID:       node.UID,
+		Name:      node.Name,
+		Namespace: "",
+	}
+	if latest != node && stage.ImmediateNextStage() &&
+		c.stageChains.Next(ctx, ref, node.ResourceVersion, stage.Name(), latest.ResourceVersion) {
+		c.preprocessChan <- latest
+	}
+}
+
+// addStagePatch schedules the patch of the stage to be applied after its delay
+func (c *NodeController) addStagePatch(node *corev1.Node, stage *LifecycleStage, step int) {
+	item := resourceStageJob[*corev1.Node]{
+		Resource: node,
+		Stage:    stage,
+		Key:      node.Name,
+		Step:     step,
+	}
+	if c.delayQueue.AddAfter(item, scaleDuration(stagePatchDelay(stage, step), c.timeScale)) {
+		c.delayQueueMapping.Store(item.Key, item)
+	}
 }
 
 func (c *NodeController) readOnly(nodeName string) bool {
@@ -474,10 +1091,48 @@ func (c *NodeController) patchResource(ctx context.Context, node *corev1.Node, p
 	return result, nil
 }
 
-func (c *NodeController) computePatch(node *corev1.Node, tpl string) ([]byte, error) {
-	patch, err := c.renderer.ToJSON(tpl, node)
+// patchSubresource patches the subresource of the node
+func (c *NodeController) patchSubresource(ctx context.Context, node *corev1.Node, subresource internalversion.StageSubresource) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"node", node.Name,
+		"subresource", subresource.Name,
+	)
+
+	data, err := c.renderer.ToJSON(subresource.Template, node)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	_, err = c.typedClient.CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{}, subresource.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warn("Patch node subresource",
+				"err", err,
+			)
+			return nil
+		}
+		return err
+	}
+	logger.Info("Patch node subresource")
+	return nil
+}
+
+// computePatch renders the template and returns the patch of the status,
+// the response is used as the patch instead if it is not nil.
+// It returns nil if the node is flapping and down, or being rebooted.
+func (c *NodeController) computePatch(node *corev1.Node, tpl string, response []byte) ([]byte, error) {
+	if c.flappingDown(node) || rebooting(node) {
+		return nil, nil
+	}
+
+	patch := response
+	if patch == nil {
+		var err error
+		patch, err = c.renderer.ToJSON(tpl, node)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	original, err := json.Marshal(node.Status)
@@ -505,11 +1160,39 @@ func (c *NodeController) computePatch(node *corev1.Node, tpl string) ([]byte, er
 		return nil, nil
 	}
 
+	if c.leaseOnly(node) && equalIgnoringHeartbeat(node.Status, nodeStatus) {
+		return nil, nil
+	}
+
 	return json.Marshal(map[string]json.RawMessage{
 		"status": patch,
 	})
 }
 
+// leaseOnly returns whether the node is kept alive by its lease only
+func (c *NodeController) leaseOnly(node *corev1.Node) bool {
+	return c.nodeLeaseOnly && node.Annotations[nodeLeaseOnlyAnnotation] != "false"
+}
+
+// equalIgnoringHeartbeat returns whether the statuses are equal except the heartbeat times of the conditions
+func equalIgnoringHeartbeat(a, b corev1.NodeStatus) bool {
+	a.Conditions = withoutHeartbeat(a.Conditions)
+	b.Conditions = withoutHeartbeat(b.Conditions)
+	return apiequality.Semantic.DeepEqual(a, b)
+}
+
+func withoutHeartbeat(conditions []corev1.NodeCondition) []corev1.NodeCondition {
+	if conditions == nil {
+		return nil
+	}
+	out := make([]corev1.NodeCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		condition.LastHeartbeatTime = metav1.Time{}
+		out = append(out, condition)
+	}
+	return out
+}
+
 // putNodeInfo puts node info
 func (c *NodeController) putNodeInfo(node *corev1.Node) {
 	c.nodesSets.Store(node.Name, &NodeInfo{})
@@ -580,6 +1263,10 @@ func (c *NodeController) funcNodeIP() string {
 	return c.nodeIP
 }
 
+func (c *NodeController) funcNodeIPs() []string {
+	return c.nodeIPs
+}
+
 func (c *NodeController) funcNodeName() string {
 	return c.nodeName
 }
@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
+	nodeheartbeatwithlease "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat-with-lease"
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/config/resources"
@@ -63,6 +65,18 @@ func TestNodeController(t *testing.T) {
 				},
 			},
 		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "windows-node",
+				Annotations: map[string]string{
+					"node": "true",
+				},
+				Labels: map[string]string{
+					"kubernetes.io/os":   "windows",
+					"kubernetes.io/arch": "arm64",
+				},
+			},
+		},
 		&corev1.Node{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "other-node",
@@ -127,6 +141,21 @@ func TestNodeController(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	err = wait.Poll(ctx, func(ctx context.Context) (done bool, err error) {
+		node, err := clientset.CoreV1().Nodes().Get(ctx, "windows-node", metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to get windows-node: %w", err)
+		}
+		nodeInfo := node.Status.NodeInfo
+		if nodeInfo.OperatingSystem != "windows" || nodeInfo.Architecture != "arm64" {
+			return false, fmt.Errorf("windows-node want windows/arm64, got %s/%s", nodeInfo.OperatingSystem, nodeInfo.Architecture)
+		}
+		return true, nil
+	}, wait.WithContinueOnError(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	node1 := node0.DeepCopy()
 	node1.Name = "node1"
 	node1.Status.Allocatable[corev1.ResourceCPU] = resource.MustParse("16")
@@ -165,3 +194,72 @@ func TestNodeController(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestNodeController_computePatchLeaseOnly(t *testing.T) {
+	stage, err := config.UnmarshalWithType[*internalversion.Stage](nodeheartbeatwithlease.DefaultNodeHeartbeatWithLease)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl := stage.Spec.Next.StatusTemplate
+
+	nodes, err := NewNodeController(NodeControllerConfig{
+		NodeIP:               "10.0.0.1",
+		FuncMap:              defaultFuncMap,
+		PlayStageParallelism: 1,
+		NodeLeaseOnly:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "node0",
+			CreationTimestamp: metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+	patch, err := nodes.computePatch(node, tpl, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch == nil {
+		t.Fatal("want patch for the node not initialized")
+	}
+	node.Status = corev1.NodeStatus{}
+	err = applyNodeStatusPatch(node, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range node.Status.Conditions {
+		node.Status.Conditions[i].LastHeartbeatTime = metav1.NewTime(time.Now().Add(-time.Hour))
+	}
+
+	patch, err = nodes.computePatch(node, tpl, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch != nil {
+		t.Errorf("want no patch if only the heartbeat times would change, got %s", patch)
+	}
+
+	node.Annotations = map[string]string{nodeLeaseOnlyAnnotation: "false"}
+	patch, err = nodes.computePatch(node, tpl, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch == nil {
+		t.Error("want patch for the node opted out")
+	}
+}
+
+func applyNodeStatusPatch(node *corev1.Node, patch []byte) error {
+	var status struct {
+		Status corev1.NodeStatus `json:"status"`
+	}
+	err := json.Unmarshal(patch, &status)
+	if err != nil {
+		return err
+	}
+	node.Status = status.Status
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// nodeDevicesAnnotation is the annotation of the node to advertise the devices of the node,
+// which is a comma separated list of the extended resources and their quantities,
+// e.g. "nvidia.com/gpu=8,example.com/fpga=2".
+const nodeDevicesAnnotation = "kwok.x-k8s.io/devices"
+
+// parseNodeDevices parses the value of the devices annotation
+func parseNodeDevices(s string) (corev1.ResourceList, error) {
+	devices := corev1.ResourceList{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid device %q, want <resource>=<quantity>", item)
+		}
+		name = strings.TrimSpace(name)
+		if !strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid device %q, the resource must be an extended resource with a domain", item)
+		}
+		quantity, err := resource.ParseQuantity(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid device %q: %w", item, err)
+		}
+		if quantity.Sign() < 0 {
+			return nil, fmt.Errorf("invalid device %q, the quantity must not be negative", item)
+		}
+		devices[corev1.ResourceName(name)] = quantity
+	}
+	return devices, nil
+}
+
+// nodeDevicesPatch returns the patch of the status of the node to advertise the devices of the annotation
+// in its capacity and allocatable, it returns nil if they are up to date.
+func nodeDevicesPatch(node *corev1.Node) ([]byte, error) {
+	value, ok := node.Annotations[nodeDevicesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	devices, err := parseNodeDevices(value)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := corev1.ResourceList{}
+	allocatable := corev1.ResourceList{}
+	for name, quantity := range devices {
+		if q, ok := node.Status.Capacity[name]; !ok || q.Cmp(quantity) != 0 {
+			capacity[name] = quantity
+		}
+		if q, ok := node.Status.Allocatable[name]; !ok || q.Cmp(quantity) != 0 {
+			allocatable[name] = quantity
+		}
+	}
+	if len(capacity) == 0 && len(allocatable) == 0 {
+		return nil, nil
+	}
+
+	status := map[string]interface{}{}
+	if len(capacity) != 0 {
+		status["capacity"] = capacity
+	}
+	if len(allocatable) != 0 {
+		status["allocatable"] = allocatable
+	}
+	return json.Marshal(map[string]interface{}{
+		"status": status,
+	})
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseNodeDevices(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[corev1.ResourceName]string
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  map[corev1.ResourceName]string{},
+		},
+		{
+			name:  "devices",
+			value: "nvidia.com/gpu=8, example.com/fpga=2",
+			want: map[corev1.ResourceName]string{
+				"nvidia.com/gpu":   "8",
+				"example.com/fpga": "2",
+			},
+		},
+		{
+			name:    "missing quantity",
+			value:   "nvidia.com/gpu",
+			wantErr: true,
+		},
+		{
+			name:    "not extended resource",
+			value:   "gpu=8",
+			wantErr: true,
+		},
+		{
+			name:    "invalid quantity",
+			value:   "nvidia.com/gpu=many",
+			wantErr: true,
+		},
+		{
+			name:    "negative quantity",
+			value:   "nvidia.com/gpu=-1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNodeDevices(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNodeDevices() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseNodeDevices() = %v, want %v", got, tt.want)
+			}
+			for name, value := range tt.want {
+				if q := got[name]; q.Cmp(resource.MustParse(value)) != 0 {
+					t.Errorf("parseNodeDevices()[%s] = %s, want %s", name, q.String(), value)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeDevicesPatch(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				nodeDevicesAnnotation: "nvidia.com/gpu=8",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("4"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("4"),
+			},
+		},
+	}
+
+	patch, err := nodeDevicesPatch(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Status corev1.NodeStatus `json:"status"`
+	}
+	err = json.Unmarshal(patch, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := resource.MustParse("8")
+	if q := got.Status.Capacity["nvidia.com/gpu"]; q.Cmp(want) != 0 || len(got.Status.Capacity) != 1 {
+		t.Errorf("capacity = %v, want nvidia.com/gpu=8 only", got.Status.Capacity)
+	}
+	if q := got.Status.Allocatable["nvidia.com/gpu"]; q.Cmp(want) != 0 || len(got.Status.Allocatable) != 1 {
+		t.Errorf("allocatable = %v, want nvidia.com/gpu=8 only", got.Status.Allocatable)
+	}
+
+	node.Status.Capacity["nvidia.com/gpu"] = want
+	node.Status.Allocatable["nvidia.com/gpu"] = want
+	patch, err = nodeDevicesPatch(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch != nil {
+		t.Errorf("nodeDevicesPatch() = %s, want nil when up to date", patch)
+	}
+
+	delete(node.Annotations, nodeDevicesAnnotation)
+	node.Status.Allocatable["nvidia.com/gpu"] = resource.MustParse("1")
+	patch, err = nodeDevicesPatch(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch != nil {
+		t.Errorf("nodeDevicesPatch() = %s, want nil without annotation", patch)
+	}
+}
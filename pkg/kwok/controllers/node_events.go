@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// kubeletComponent is the component of the Events recorded by the kubelet
+	kubeletComponent = "kubelet"
+	// nodeControllerComponent is the component of the Events recorded by the node lifecycle controller
+	nodeControllerComponent = "node-controller"
+)
+
+// nodeReadyStatus returns the status of the Ready condition of the node, or empty if it has none
+func nodeReadyStatus(node *corev1.Node) corev1.ConditionStatus {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status
+		}
+	}
+	return ""
+}
+
+// recordLifecycleEvents records the Events of the lifecycle of the node in the same way as the kubelet
+// and the node lifecycle controller, Starting and RegisteredNode once the node is managed,
+// and NodeReady or NodeNotReady once the status of its Ready condition changes.
+func (c *NodeController) recordLifecycleEvents(node *corev1.Node) {
+	if c.nodeRecorderFunc == nil {
+		return
+	}
+
+	status := nodeReadyStatus(node)
+	last, loaded := c.nodesReady.Swap(node.Name, status)
+
+	// The kubelet refers to the node by its name as the UID
+	kubeletRef := &corev1.ObjectReference{
+		Kind: "Node",
+		Name: node.Name,
+		UID:  types.UID(node.Name),
+	}
+	kubelet := c.nodeRecorderFunc(corev1.EventSource{
+		Component: kubeletComponent,
+		Host:      node.Name,
+	})
+
+	if !loaded {
+		kubelet.Event(kubeletRef, corev1.EventTypeNormal, "Starting", "Starting kubelet.")
+
+		controllerRef := &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       node.Name,
+			UID:        node.UID,
+		}
+		c.nodeRecorderFunc(corev1.EventSource{
+			Component: nodeControllerComponent,
+		}).Eventf(controllerRef, corev1.EventTypeNormal, "RegisteredNode", "Node %s event: Registered Node %s in Controller", node.Name, node.Name)
+	} else if last == status {
+		return
+	}
+
+	switch status {
+	case corev1.ConditionTrue:
+		kubelet.Eventf(kubeletRef, corev1.EventTypeNormal, "NodeReady", "Node %s status is now: NodeReady", node.Name)
+	case corev1.ConditionFalse:
+		kubelet.Eventf(kubeletRef, corev1.EventTypeNormal, "NodeNotReady", "Node %s status is now: NodeNotReady", node.Name)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// fakeSourceRecorder records the Events with their sources
+type fakeSourceRecorder struct {
+	source corev1.EventSource
+	events *[]string
+}
+
+func (r fakeSourceRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	ref := object.(*corev1.ObjectReference)
+	*r.events = append(*r.events, fmt.Sprintf("%s/%s %s/%s %s %s %s", r.source.Component, r.source.Host, ref.Name, ref.UID, eventtype, reason, message))
+}
+
+func (r fakeSourceRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r fakeSourceRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func TestNodeController_recordLifecycleEvents(t *testing.T) {
+	var events []string
+	c, err := NewNodeController(NodeControllerConfig{
+		PlayStageParallelism: 1,
+		NodeRecorderFunc: func(source corev1.EventSource) record.EventRecorder {
+			return fakeSourceRecorder{source: source, events: &events}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+			UID:  "uid0",
+		},
+	}
+	withReady := func(status corev1.ConditionStatus) *corev1.Node {
+		node := node.DeepCopy()
+		node.Status.Conditions = []corev1.NodeCondition{
+			{
+				Type:   corev1.NodeReady,
+				Status: status,
+			},
+		}
+		return node
+	}
+
+	c.recordLifecycleEvents(node)
+	c.recordLifecycleEvents(withReady(corev1.ConditionTrue))
+	c.recordLifecycleEvents(withReady(corev1.ConditionTrue))
+	c.recordLifecycleEvents(withReady(corev1.ConditionFalse))
+	c.recordLifecycleEvents(withReady(corev1.ConditionUnknown))
+	c.recordLifecycleEvents(withReady(corev1.ConditionTrue))
+
+	want := []string{
+		"kubelet/node0 node0/node0 Normal Starting Starting kubelet.",
+		"node-controller/ node0/uid0 Normal RegisteredNode Node node0 event: Registered Node node0 in Controller",
+		"kubelet/node0 node0/node0 Normal NodeReady Node node0 status is now: NodeReady",
+		"kubelet/node0 node0/node0 Normal NodeNotReady Node node0 status is now: NodeNotReady",
+		"kubelet/node0 node0/node0 Normal NodeReady Node node0 status is now: NodeReady",
+	}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// nodeNotReady is the Ready condition of the flapping nodes when they are down
+var nodeNotReady = corev1.NodeCondition{
+	Type:    corev1.NodeReady,
+	Status:  corev1.ConditionFalse,
+	Reason:  "KubeletNotReady",
+	Message: "kubelet is down as the node is flapping",
+}
+
+// nodeFlapping flips the Ready condition of the selected nodes between True and False periodically.
+// The periods start at the creation of each node, so the phase of the node is the same after a restart.
+type nodeFlapping struct {
+	selector labels.Selector
+	up       time.Duration
+	down     time.Duration
+}
+
+// newNodeFlapping returns a new nodeFlapping, or nil if the selector is empty
+func newNodeFlapping(selector string, up, down time.Duration) (*nodeFlapping, error) {
+	s, err := labelsParse(selector)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, nil
+	}
+	if up <= 0 || down <= 0 {
+		return nil, fmt.Errorf("the up and down durations of the flapping nodes must be greater than 0")
+	}
+	return &nodeFlapping{
+		selector: s,
+		up:       up,
+		down:     down,
+	}, nil
+}
+
+// match returns whether the node is flapping
+func (f *nodeFlapping) match(node *corev1.Node) bool {
+	return f.selector.Matches(labels.Set(node.Labels))
+}
+
+// phase returns whether the node is Ready at the time, since when, and when it flips next
+func (f *nodeFlapping) phase(node *corev1.Node, now time.Time) (ready bool, since, next time.Time) {
+	base := node.CreationTimestamp.Time
+	elapsed := now.Sub(base)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	cycle := f.up + f.down
+	start := base.Add(elapsed - elapsed%cycle)
+	if elapsed%cycle < f.up {
+		return true, start, start.Add(f.up)
+	}
+	return false, start.Add(f.up), start.Add(cycle)
+}
+
+// nodeFlappingPatch returns the patch of the status of the node to flip its Ready condition,
+// it returns nil if the condition is up to date or not initialized by the stages yet.
+func nodeFlappingPatch(node *corev1.Node, ready bool, since, now time.Time) ([]byte, error) {
+	want := corev1.ConditionFalse
+	if ready {
+		want = corev1.ConditionTrue
+	}
+
+	var current *corev1.NodeCondition
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == corev1.NodeReady {
+			current = &node.Status.Conditions[i]
+			break
+		}
+	}
+	if current == nil || current.Status == want {
+		return nil, nil
+	}
+
+	condition := nodeNotReady
+	if ready {
+		for _, c := range nodeConditions {
+			if c.Type == corev1.NodeReady {
+				condition = c
+				break
+			}
+		}
+	}
+	condition.LastHeartbeatTime = metav1.NewTime(now)
+	condition.LastTransitionTime = metav1.NewTime(since)
+
+	return json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []corev1.NodeCondition{condition},
+		},
+	})
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeFlappingPhase(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	flapping, err := newNodeFlapping("flapping=true", 5*time.Minute, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				"flapping": "true",
+			},
+		},
+	}
+	if !flapping.match(node) {
+		t.Fatalf("match() = false, want true")
+	}
+
+	tests := []struct {
+		name      string
+		after     time.Duration
+		wantReady bool
+		wantSince time.Duration
+		wantNext  time.Duration
+	}{
+		{
+			name:      "created",
+			wantReady: true,
+			wantNext:  5 * time.Minute,
+		},
+		{
+			name:      "down",
+			after:     5 * time.Minute,
+			wantSince: 5 * time.Minute,
+			wantNext:  6 * time.Minute,
+		},
+		{
+			name:      "up again",
+			after:     8 * time.Minute,
+			wantReady: true,
+			wantSince: 6 * time.Minute,
+			wantNext:  11 * time.Minute,
+		},
+		{
+			name:      "down again",
+			after:     11*time.Minute + 30*time.Second,
+			wantSince: 11 * time.Minute,
+			wantNext:  12 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, since, next := flapping.phase(node, created.Add(tt.after))
+			if ready != tt.wantReady {
+				t.Errorf("ready = %v, want %v", ready, tt.wantReady)
+			}
+			if want := created.Add(tt.wantSince); !since.Equal(want) {
+				t.Errorf("since = %v, want %v", since, want)
+			}
+			if want := created.Add(tt.wantNext); !next.Equal(want) {
+				t.Errorf("next = %v, want %v", next, want)
+			}
+		})
+	}
+}
+
+func TestNewNodeFlapping(t *testing.T) {
+	flapping, err := newNodeFlapping("", time.Minute, time.Minute)
+	if err != nil || flapping != nil {
+		t.Errorf("newNodeFlapping() = %v, %v, want nil for the empty selector", flapping, err)
+	}
+	_, err = newNodeFlapping("flapping=true", time.Minute, 0)
+	if err == nil {
+		t.Errorf("newNodeFlapping() want error for the zero down duration")
+	}
+}
+
+func TestNodeFlappingPatch(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC)
+	since := now.Add(-time.Minute)
+	nodeWithReady := func(status corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: status},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		node  *corev1.Node
+		ready bool
+		want  corev1.ConditionStatus
+	}{
+		{
+			name:  "ready already",
+			node:  nodeWithReady(corev1.ConditionTrue),
+			ready: true,
+		},
+		{
+			name: "down",
+			node: nodeWithReady(corev1.ConditionTrue),
+			want: corev1.ConditionFalse,
+		},
+		{
+			name:  "up",
+			node:  nodeWithReady(corev1.ConditionFalse),
+			ready: true,
+			want:  corev1.ConditionTrue,
+		},
+		{
+			name: "conditions not initialized",
+			node: &corev1.Node{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := nodeFlappingPatch(tt.node, tt.ready, since, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if patch != nil {
+					t.Errorf("nodeFlappingPatch() = %s, want nil", patch)
+				}
+				return
+			}
+
+			var got struct {
+				Status corev1.NodeStatus `json:"status"`
+			}
+			err = json.Unmarshal(patch, &got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Status.Conditions) != 1 {
+				t.Fatalf("conditions = %v, want the Ready condition only", got.Status.Conditions)
+			}
+			condition := got.Status.Conditions[0]
+			if condition.Type != corev1.NodeReady || condition.Status != tt.want {
+				t.Errorf("condition = %v, want Ready %s", condition, tt.want)
+			}
+			if !condition.LastTransitionTime.Time.Equal(since) {
+				t.Errorf("lastTransitionTime = %v, want %v", condition.LastTransitionTime, since)
+			}
+		})
+	}
+}
@@ -36,6 +36,10 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
+// nodeHeartbeatIntervalAnnotation is the annotation of the node to override the interval of its heartbeats,
+// in the format of time.ParseDuration, e.g. "2m", to simulate the nodes heartbeating slowly.
+const nodeHeartbeatIntervalAnnotation = "kwok.x-k8s.io/heartbeat-interval"
+
 // NodeLeaseController is responsible for creating and renewing a lease object
 type NodeLeaseController struct {
 	typedClient          clientset.Interface
@@ -56,6 +60,7 @@ type NodeLeaseController struct {
 
 	holderIdentity    string
 	onNodeManagedFunc func(nodeName string)
+	nodeOwnedFunc     func(nodeName string) bool
 }
 
 // NodeLeaseControllerConfig is the configuration for NodeLeaseController
@@ -70,6 +75,7 @@ type NodeLeaseControllerConfig struct {
 	RenewIntervalJitter  float64
 	MutateLeaseFunc      func(*coordinationv1.Lease) error
 	OnNodeManagedFunc    func(nodeName string)
+	NodeOwnedFunc        func(nodeName string) bool
 }
 
 // NewNodeLeaseController constructs and returns a NodeLeaseController
@@ -94,6 +100,7 @@ func NewNodeLeaseController(conf NodeLeaseControllerConfig) (*NodeLeaseControlle
 		delayQueue:           queue.NewDelayingQueue[string](conf.Clock),
 		holderIdentity:       conf.HolderIdentity,
 		onNodeManagedFunc:    conf.OnNodeManagedFunc,
+		nodeOwnedFunc:        conf.NodeOwnedFunc,
 	}
 
 	return c, nil
@@ -142,6 +149,10 @@ func (c *NodeLeaseController) syncWorker(ctx context.Context) {
 				continue
 			}
 		}
+		if c.nodeOwnedFunc != nil && !c.nodeOwnedFunc(nodeName) {
+			// The node is in a shard held by another replica, stop renewing its lease to hand it over
+			continue
+		}
 
 		now := c.clock.Now()
 		c.sync(ctx, nodeName)
@@ -151,7 +162,8 @@ func (c *NodeLeaseController) syncWorker(ctx context.Context) {
 }
 
 func (c *NodeLeaseController) nextTryTime(name string, now time.Time) time.Time {
-	next := now.Add(wait.Jitter(c.renewInterval, c.renewIntervalJitter))
+	renewInterval, overridden := c.renewIntervalFor(name)
+	next := now.Add(wait.Jitter(renewInterval, c.renewIntervalJitter))
 	lease, ok := c.latestLease.Load(name)
 	if !ok || lease == nil ||
 		lease.Spec.HolderIdentity == nil ||
@@ -159,9 +171,34 @@ func (c *NodeLeaseController) nextTryTime(name string, now time.Time) time.Time
 		lease.Spec.RenewTime == nil {
 		return next
 	}
+	// The overridden interval may let the lease held expire, as a node heartbeating slowly does
+	if overridden && *lease.Spec.HolderIdentity == c.holderIdentity {
+		return next
+	}
 	return nextTryTime(lease, c.holderIdentity, next)
 }
 
+// renewIntervalFor returns the renew interval of the lease of the node,
+// and whether it is overridden by the annotation of the node.
+func (c *NodeLeaseController) renewIntervalFor(name string) (time.Duration, bool) {
+	if c.nodeCacheGetter == nil {
+		return c.renewInterval, false
+	}
+	node, ok := c.nodeCacheGetter.Get(name)
+	if !ok {
+		return c.renewInterval, false
+	}
+	value, ok := node.Annotations[nodeHeartbeatIntervalAnnotation]
+	if !ok {
+		return c.renewInterval, false
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return c.renewInterval, false
+	}
+	return interval, true
+}
+
 // TryHold tries to hold a lease for the NodeLeaseController
 func (c *NodeLeaseController) TryHold(name string) {
 	c.delayQueue.Add(name)
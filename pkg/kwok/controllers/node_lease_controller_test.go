@@ -281,3 +281,116 @@ func Test_nextTryTime(t *testing.T) {
 		})
 	}
 }
+
+type fakeNodeGetter map[string]*corev1.Node
+
+func (f fakeNodeGetter) Get(name string) (*corev1.Node, bool) {
+	node, ok := f[name]
+	return node, ok
+}
+
+func (f fakeNodeGetter) GetWithNamespace(name, namespace string) (*corev1.Node, bool) {
+	return f.Get(name)
+}
+
+func (f fakeNodeGetter) List() []*corev1.Node {
+	list := make([]*corev1.Node, 0, len(f))
+	for _, node := range f {
+		list = append(list, node)
+	}
+	return list
+}
+
+func TestNodeLeaseController_renewIntervalFor(t *testing.T) {
+	nodeWithInterval := func(name, interval string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Annotations: map[string]string{
+					nodeHeartbeatIntervalAnnotation: interval,
+				},
+			},
+		}
+	}
+	c := &NodeLeaseController{
+		renewInterval: 10 * time.Second,
+		nodeCacheGetter: fakeNodeGetter{
+			"node0": {ObjectMeta: metav1.ObjectMeta{Name: "node0"}},
+			"node1": nodeWithInterval("node1", "2m"),
+			"node2": nodeWithInterval("node2", "invalid"),
+			"node3": nodeWithInterval("node3", "-1s"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		node           string
+		want           time.Duration
+		wantOverridden bool
+	}{
+		{
+			name: "not found",
+			node: "node-not-found",
+			want: 10 * time.Second,
+		},
+		{
+			name: "without annotation",
+			node: "node0",
+			want: 10 * time.Second,
+		},
+		{
+			name:           "with annotation",
+			node:           "node1",
+			want:           2 * time.Minute,
+			wantOverridden: true,
+		},
+		{
+			name: "invalid annotation",
+			node: "node2",
+			want: 10 * time.Second,
+		},
+		{
+			name: "negative annotation",
+			node: "node3",
+			want: 10 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, overridden := c.renewIntervalFor(tt.node)
+			if got != tt.want || overridden != tt.wantOverridden {
+				t.Errorf("renewIntervalFor() = %v, %v, want %v, %v", got, overridden, tt.want, tt.wantOverridden)
+			}
+		})
+	}
+}
+
+func TestNodeLeaseController_nextTryTimeOverridden(t *testing.T) {
+	now := time.Now()
+	c := &NodeLeaseController{
+		holderIdentity: "test",
+		renewInterval:  10 * time.Second,
+		nodeCacheGetter: fakeNodeGetter{
+			"node0": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node0",
+					Annotations: map[string]string{
+						nodeHeartbeatIntervalAnnotation: "2m",
+					},
+				},
+			},
+		},
+	}
+	c.latestLease.Store("node0", &coordinationv1.Lease{
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       format.Ptr("test"),
+			LeaseDurationSeconds: format.Ptr(int32(40)),
+			RenewTime:            format.Ptr(metav1.NewMicroTime(now)),
+		},
+	})
+
+	// The lease held is allowed to expire before the next renewal
+	if got := c.nextTryTime("node0", now); got.Before(now.Add(2 * time.Minute)) {
+		t.Errorf("nextTryTime() = %v, want not before %v", got, now.Add(2*time.Minute))
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// nodeManageRule is a parsed rule to decide whether the nodes are managed
+type nodeManageRule struct {
+	names              sets.Set[string]
+	labelSelector      labels.Selector
+	annotationSelector labels.Selector
+	exclude            bool
+}
+
+// nodeManageRules is the rules to decide whether the nodes are managed, the first rule matching the node decides it
+type nodeManageRules []nodeManageRule
+
+// newNodeManageRules parses the rules
+func newNodeManageRules(rules []internalversion.ManageNodeRule) (nodeManageRules, error) {
+	out := make(nodeManageRules, 0, len(rules))
+	for i, rule := range rules {
+		if len(rule.Names) == 0 && rule.LabelSelector == "" && rule.AnnotationSelector == "" {
+			return nil, fmt.Errorf("manage node rule %d matches all nodes, set its names or selectors", i)
+		}
+
+		labelSelector, err := labelsParse(rule.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("manage node rule %d: %w", i, err)
+		}
+		annotationSelector, err := labelsParse(rule.AnnotationSelector)
+		if err != nil {
+			return nil, fmt.Errorf("manage node rule %d: %w", i, err)
+		}
+
+		var names sets.Set[string]
+		if len(rule.Names) != 0 {
+			names = sets.New(rule.Names...)
+		}
+		out = append(out, nodeManageRule{
+			names:              names,
+			labelSelector:      labelSelector,
+			annotationSelector: annotationSelector,
+			exclude:            rule.Exclude,
+		})
+	}
+	return out, nil
+}
+
+// Manage returns whether the node is managed by the rules
+func (r nodeManageRules) Manage(node metav1.Object) bool {
+	for _, rule := range r {
+		if rule.match(node) {
+			return !rule.exclude
+		}
+	}
+	return false
+}
+
+func (r nodeManageRule) match(node metav1.Object) bool {
+	if r.names != nil && !r.names.Has(node.GetName()) {
+		return false
+	}
+	if r.labelSelector != nil && !r.labelSelector.Matches(labels.Set(node.GetLabels())) {
+		return false
+	}
+	if r.annotationSelector != nil && !r.annotationSelector.Matches(labels.Set(node.GetAnnotations())) {
+		return false
+	}
+	return true
+}
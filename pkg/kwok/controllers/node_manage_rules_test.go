@@ -0,0 +1,118 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestNodeManageRules(t *testing.T) {
+	rules, err := newNodeManageRules([]internalversion.ManageNodeRule{
+		{
+			Names:   []string{"gpu-0"},
+			Exclude: true,
+		},
+		{
+			LabelSelector: "pool=real",
+			Exclude:       true,
+		},
+		{
+			LabelSelector:      "type=kwok",
+			AnnotationSelector: "simulator=kwok",
+		},
+		{
+			LabelSelector: "pool in (gpu,cpu)",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newNode := func(name string, labels, annotations map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+		}
+	}
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want bool
+	}{
+		{
+			name: "excluded by name",
+			node: newNode("gpu-0", map[string]string{"pool": "gpu"}, nil),
+			want: false,
+		},
+		{
+			name: "excluded by label",
+			node: newNode("real-0", map[string]string{"pool": "real", "type": "kwok"}, map[string]string{"simulator": "kwok"}),
+			want: false,
+		},
+		{
+			name: "label and annotation",
+			node: newNode("kwok-0", map[string]string{"type": "kwok"}, map[string]string{"simulator": "kwok"}),
+			want: true,
+		},
+		{
+			name: "label without annotation",
+			node: newNode("kwok-1", map[string]string{"type": "kwok"}, nil),
+			want: false,
+		},
+		{
+			name: "later rule",
+			node: newNode("gpu-1", map[string]string{"pool": "gpu"}, nil),
+			want: true,
+		},
+		{
+			name: "no rule",
+			node: newNode("other", nil, nil),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.Manage(tt.node); got != tt.want {
+				t.Errorf("Manage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewNodeManageRules_Invalid(t *testing.T) {
+	_, err := newNodeManageRules([]internalversion.ManageNodeRule{
+		{Exclude: true},
+	})
+	if err == nil {
+		t.Errorf("newNodeManageRules() want error for the rule matching all nodes")
+	}
+
+	_, err = newNodeManageRules([]internalversion.ManageNodeRule{
+		{LabelSelector: "pool in ("},
+	})
+	if err == nil {
+		t.Errorf("newNodeManageRules() want error for the invalid selector")
+	}
+}
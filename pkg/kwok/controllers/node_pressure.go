@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nodePressures is the pressure conditions of the node, and the resources whose requests cause them.
+// The number of pods stands for the number of processes, as the fake pods have none.
+var nodePressures = []struct {
+	resource corev1.ResourceName
+	pressure corev1.NodeCondition
+}{
+	{
+		resource: corev1.ResourceMemory,
+		pressure: corev1.NodeCondition{
+			Type:    corev1.NodeMemoryPressure,
+			Status:  corev1.ConditionTrue,
+			Reason:  "KubeletHasInsufficientMemory",
+			Message: "kubelet has insufficient memory available",
+		},
+	},
+	{
+		resource: corev1.ResourceEphemeralStorage,
+		pressure: corev1.NodeCondition{
+			Type:    corev1.NodeDiskPressure,
+			Status:  corev1.ConditionTrue,
+			Reason:  "KubeletHasDiskPressure",
+			Message: "kubelet has disk pressure",
+		},
+	},
+	{
+		resource: corev1.ResourcePods,
+		pressure: corev1.NodeCondition{
+			Type:    corev1.NodePIDPressure,
+			Status:  corev1.ConditionTrue,
+			Reason:  "KubeletHasInsufficientPID",
+			Message: "kubelet has insufficient PID available",
+		},
+	},
+}
+
+// podRequests returns the resources requested by the pod, in the same way as the scheduler,
+// the number of pods is counted as the resource pods.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return nil
+	}
+
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if value, ok := requests[name]; !ok || quantity.Cmp(value) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	addResourceList(requests, pod.Spec.Overhead)
+	requests[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	return requests
+}
+
+// addResourceList adds the resources of the list to the sum
+func addResourceList(sum, list corev1.ResourceList) {
+	for name, quantity := range list {
+		if value, ok := sum[name]; ok {
+			value.Add(quantity)
+			sum[name] = value
+		} else {
+			sum[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// underPressure returns whether the requests of the resource reach the threshold of the allocatable
+func underPressure(allocatable, requests corev1.ResourceList, name corev1.ResourceName, threshold float64) bool {
+	a, ok := allocatable[name]
+	if !ok || a.IsZero() {
+		return false
+	}
+	r, ok := requests[name]
+	if !ok {
+		return false
+	}
+	return r.AsApproximateFloat64() >= a.AsApproximateFloat64()*threshold
+}
+
+// nodePressurePatch returns the patch of the status of the node to flip its pressure conditions by the requests,
+// it returns nil if the conditions are up to date.
+func nodePressurePatch(node *corev1.Node, requests corev1.ResourceList, threshold float64, now time.Time) ([]byte, error) {
+	var conditions []corev1.NodeCondition
+	for _, p := range nodePressures {
+		want := corev1.ConditionFalse
+		if underPressure(node.Status.Allocatable, requests, p.resource, threshold) {
+			want = corev1.ConditionTrue
+		}
+
+		var current *corev1.NodeCondition
+		for i := range node.Status.Conditions {
+			if node.Status.Conditions[i].Type == p.pressure.Type {
+				current = &node.Status.Conditions[i]
+				break
+			}
+		}
+		if current == nil && want == corev1.ConditionFalse {
+			// The conditions are not initialized by the stages yet
+			continue
+		}
+		if current != nil && current.Status == want {
+			continue
+		}
+
+		condition := p.pressure
+		if want == corev1.ConditionFalse {
+			for _, c := range nodeConditions {
+				if c.Type == p.pressure.Type {
+					condition = c
+					break
+				}
+			}
+		}
+		condition.LastHeartbeatTime = metav1.NewTime(now)
+		condition.LastTransitionTime = metav1.NewTime(now)
+		conditions = append(conditions, condition)
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": conditions,
+		},
+	})
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestPodRequests(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("2Gi"),
+						},
+					},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+							corev1.ResourceMemory: resource.MustParse("512Mi"),
+						},
+					},
+				},
+				{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("200m"),
+							corev1.ResourceMemory: resource.MustParse("512Mi"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	want := map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    "300m",
+		corev1.ResourceMemory: "2Gi",
+		corev1.ResourcePods:   "1",
+	}
+	got := podRequests(pod)
+	if len(got) != len(want) {
+		t.Fatalf("podRequests() = %v, want %v", got, want)
+	}
+	for name, value := range want {
+		if q := got[name]; q.Cmp(resource.MustParse(value)) != 0 {
+			t.Errorf("podRequests()[%s] = %s, want %s", name, q.String(), value)
+		}
+	}
+
+	pod.Status.Phase = corev1.PodSucceeded
+	if got := podRequests(pod); got != nil {
+		t.Errorf("podRequests() = %v, want nil for terminated pod", got)
+	}
+}
+
+func TestNodePressurePatch(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	allocatable := corev1.ResourceList{
+		corev1.ResourceMemory:           resource.MustParse("10Gi"),
+		corev1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+		corev1.ResourcePods:             resource.MustParse("110"),
+	}
+	nodeWithConditions := func(memoryPressure corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			Status: corev1.NodeStatus{
+				Allocatable: allocatable,
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeMemoryPressure, Status: memoryPressure},
+					{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+					{Type: corev1.NodePIDPressure, Status: corev1.ConditionFalse},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		node     *corev1.Node
+		requests corev1.ResourceList
+		want     map[corev1.NodeConditionType]corev1.ConditionStatus
+	}{
+		{
+			name: "no pressure",
+			node: nodeWithConditions(corev1.ConditionFalse),
+			requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+				corev1.ResourcePods:   resource.MustParse("1"),
+			},
+		},
+		{
+			name: "memory pressure",
+			node: nodeWithConditions(corev1.ConditionFalse),
+			requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("9Gi"),
+				corev1.ResourcePods:   resource.MustParse("1"),
+			},
+			want: map[corev1.NodeConditionType]corev1.ConditionStatus{
+				corev1.NodeMemoryPressure: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "memory pressure already",
+			node: nodeWithConditions(corev1.ConditionTrue),
+			requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("9Gi"),
+			},
+		},
+		{
+			name: "memory pressure relieved",
+			node: nodeWithConditions(corev1.ConditionTrue),
+			requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			want: map[corev1.NodeConditionType]corev1.ConditionStatus{
+				corev1.NodeMemoryPressure: corev1.ConditionFalse,
+			},
+		},
+		{
+			name: "disk and pid pressure",
+			node: nodeWithConditions(corev1.ConditionFalse),
+			requests: corev1.ResourceList{
+				corev1.ResourceEphemeralStorage: resource.MustParse("95Gi"),
+				corev1.ResourcePods:             resource.MustParse("100"),
+			},
+			want: map[corev1.NodeConditionType]corev1.ConditionStatus{
+				corev1.NodeDiskPressure: corev1.ConditionTrue,
+				corev1.NodePIDPressure:  corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "conditions not initialized",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Allocatable: allocatable,
+				},
+			},
+			requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := nodePressurePatch(tt.node, tt.requests, 0.9, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tt.want) == 0 {
+				if patch != nil {
+					t.Errorf("nodePressurePatch() = %s, want nil", patch)
+				}
+				return
+			}
+
+			var got struct {
+				Status corev1.NodeStatus `json:"status"`
+			}
+			err = json.Unmarshal(patch, &got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Status.Conditions) != len(tt.want) {
+				t.Fatalf("nodePressurePatch() = %s, want %v", patch, tt.want)
+			}
+			for _, condition := range got.Status.Conditions {
+				if condition.Status != tt.want[condition.Type] {
+					t.Errorf("condition %s = %s, want %s", condition.Type, condition.Status, tt.want[condition.Type])
+				}
+				if !condition.LastTransitionTime.Time.Equal(now) {
+					t.Errorf("condition %s lastTransitionTime = %s, want %s", condition.Type, condition.LastTransitionTime, now)
+				}
+			}
+		})
+	}
+}
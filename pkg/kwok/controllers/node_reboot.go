@@ -0,0 +1,173 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+const (
+	// nodeRebootAnnotation is the annotation of the node to reboot it for the duration of its value, e.g. "30s"
+	nodeRebootAnnotation = "kwok.x-k8s.io/reboot"
+	// nodeRebootingUntilAnnotation is the annotation of the node being rebooted, with the time it comes back in RFC3339
+	nodeRebootingUntilAnnotation = "kwok.x-k8s.io/rebooting-until"
+)
+
+// nodeRebooting is the Ready condition of the nodes being rebooted
+var nodeRebooting = corev1.NodeCondition{
+	Type:    corev1.NodeReady,
+	Status:  corev1.ConditionFalse,
+	Reason:  "KubeletNotReady",
+	Message: "kubelet is down as the node is rebooting",
+}
+
+// nodeRebootingUntil returns the time the node being rebooted comes back
+func nodeRebootingUntil(node *corev1.Node) (time.Time, bool) {
+	value, ok := node.Annotations[nodeRebootingUntilAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// nodeRebootAnnotationsPatch returns the patch of the annotations of the node,
+// which replaces the reboot annotation with the time the node comes back, or removes both if until is zero.
+func nodeRebootAnnotationsPatch(until time.Time) ([]byte, error) {
+	var rebootingUntil interface{}
+	if !until.IsZero() {
+		rebootingUntil = until.UTC().Format(time.RFC3339)
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				nodeRebootAnnotation:         nil,
+				nodeRebootingUntilAnnotation: rebootingUntil,
+			},
+		},
+	})
+}
+
+// nodeRebootPatch returns the patch of the status of the node going down or coming back with the new boot ID
+func nodeRebootPatch(up bool, bootID string, now time.Time) ([]byte, error) {
+	condition := nodeRebooting
+	if up {
+		for _, c := range nodeConditions {
+			if c.Type == corev1.NodeReady {
+				condition = c
+				break
+			}
+		}
+	}
+	condition.LastHeartbeatTime = metav1.NewTime(now)
+	condition.LastTransitionTime = metav1.NewTime(now)
+
+	status := map[string]interface{}{
+		"conditions": []corev1.NodeCondition{condition},
+	}
+	if up {
+		status["nodeInfo"] = map[string]interface{}{
+			"bootID": bootID,
+		}
+	}
+	return json.Marshal(map[string]interface{}{
+		"status": status,
+	})
+}
+
+// podRebootPatch returns the patch of the status of the running pod on the node being rebooted,
+// the running containers are terminated once the node goes down,
+// and the terminated containers are restarted once the node comes back, unless the pod never restarts.
+// It returns nil if the pod is not changed.
+func podRebootPatch(pod *corev1.Pod, up bool, now time.Time) ([]byte, error) {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return nil, nil
+	}
+	if up && pod.Spec.RestartPolicy == corev1.RestartPolicyNever {
+		return nil, nil
+	}
+
+	changed := false
+	statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		switch {
+		case !up && status.State.Running != nil:
+			status.State = corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode:    255,
+					Reason:      "Unknown",
+					StartedAt:   status.State.Running.StartedAt,
+					FinishedAt:  metav1.NewTime(now),
+					ContainerID: status.ContainerID,
+				},
+			}
+			status.Ready = false
+			status.Started = nil
+			changed = true
+		case up && status.State.Terminated != nil:
+			status.LastTerminationState = status.State
+			status.State = corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{
+					StartedAt: metav1.NewTime(now),
+				},
+			}
+			status.RestartCount++
+			status.Ready = true
+			status.Started = format.Ptr(true)
+			changed = true
+		}
+		statuses = append(statuses, status)
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	// The reason is removed by null once the pod is ready again
+	condition := func(conditionType corev1.PodConditionType) map[string]interface{} {
+		condition := map[string]interface{}{
+			"type":               conditionType,
+			"status":             corev1.ConditionFalse,
+			"reason":             "ContainersNotReady",
+			"lastTransitionTime": metav1.NewTime(now),
+		}
+		if up {
+			condition["status"] = corev1.ConditionTrue
+			condition["reason"] = nil
+		}
+		return condition
+	}
+	conditions := []map[string]interface{}{
+		condition(corev1.PodReady),
+		condition(corev1.ContainersReady),
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions":        conditions,
+			"containerStatuses": statuses,
+		},
+	})
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestNodeController_syncReboot(t *testing.T) {
+	ctx := context.Background()
+	started := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clocktesting.NewFakeClock(started.Add(time.Hour))
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+			Annotations: map[string]string{
+				nodeRebootAnnotation: "30s",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod0",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: node.Name,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "container0",
+					Ready: true,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{
+							StartedAt: metav1.NewTime(started),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	clientset := fake.NewSimpleClientset(node, pod)
+	nodes := fakeNodeGetter{}
+	refresh := func() (*corev1.Node, *corev1.Pod) {
+		node, err := clientset.CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes[node.Name] = node
+		pod, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return node, pod
+	}
+	refresh()
+
+	pods, err := NewPodController(PodControllerConfig{
+		Clock:                clock,
+		TypedClient:          clientset,
+		PlayStageParallelism: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewNodeController(NodeControllerConfig{
+		Clock:                clock,
+		TypedClient:          clientset,
+		NodeCacheGetter:      nodes,
+		PlayStageParallelism: 1,
+		OnNodeRebootFunc:     pods.Reboot,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.putNodeInfo(node)
+
+	// The node goes down
+	err = c.syncReboot(ctx, node.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotNode, gotPod := refresh()
+	if !rebooting(gotNode) {
+		t.Fatalf("want the node being rebooted, got annotations %v", gotNode.Annotations)
+	}
+	if _, ok := gotNode.Annotations[nodeRebootAnnotation]; ok {
+		t.Errorf("want the reboot annotation removed")
+	}
+	if status := nodeReadyStatus(gotNode); status != corev1.ConditionFalse {
+		t.Errorf("node ready = %s, want False", status)
+	}
+	if state := gotPod.Status.ContainerStatuses[0].State; state.Terminated == nil {
+		t.Errorf("want the container terminated, got %+v", state)
+	}
+
+	// The node is still down
+	clock.Step(10 * time.Second)
+	err = c.syncReboot(ctx, node.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotNode, _ = refresh()
+	if !rebooting(gotNode) {
+		t.Fatalf("want the node still being rebooted")
+	}
+
+	// The node comes back
+	clock.Step(20 * time.Second)
+	err = c.syncReboot(ctx, node.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotNode, gotPod = refresh()
+	if rebooting(gotNode) {
+		t.Errorf("want the node back, got annotations %v", gotNode.Annotations)
+	}
+	if status := nodeReadyStatus(gotNode); status != corev1.ConditionTrue {
+		t.Errorf("node ready = %s, want True", status)
+	}
+	if gotNode.Status.NodeInfo.BootID == "" {
+		t.Errorf("want a new boot ID")
+	}
+	status := gotPod.Status.ContainerStatuses[0]
+	if status.State.Running == nil || !status.Ready || status.RestartCount != 1 {
+		t.Errorf("want the container restarted, got %+v", status)
+	}
+	if status.LastTerminationState.Terminated == nil || !status.LastTerminationState.Terminated.StartedAt.Time.Equal(started) {
+		t.Errorf("want the last termination state of the container, got %+v", status.LastTerminationState)
+	}
+}
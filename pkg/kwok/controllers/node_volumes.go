@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// podClaims returns the PersistentVolumeClaims of the volumes of the pod, including the ephemeral ones,
+// it is empty if the pod is terminated, as the volumes are unmounted then.
+func podClaims(pod *corev1.Pod) []log.ObjectRef {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return nil
+	}
+
+	var claims []log.ObjectRef
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claims = append(claims, log.KRef(pod.Namespace, volume.PersistentVolumeClaim.ClaimName))
+		case volume.Ephemeral != nil:
+			// The claim of the ephemeral volume is named after the pod and the volume
+			claims = append(claims, log.KRef(pod.Namespace, pod.Name+"-"+volume.Name))
+		}
+	}
+	return claims
+}
+
+// volumeUniqueName returns the unique name of the volume as the kubelet reports it,
+// only the CSI volumes are attachable.
+func volumeUniqueName(pv *corev1.PersistentVolume) (corev1.UniqueVolumeName, bool) {
+	if pv.Spec.CSI == nil {
+		return "", false
+	}
+	return corev1.UniqueVolumeName("kubernetes.io/csi/" + pv.Spec.CSI.Driver + "^" + pv.Spec.CSI.VolumeHandle), true
+}
+
+// nodeVolumesPatch returns the patch of the status of the node to report the volumes in use,
+// it returns nil if the volumes are up to date.
+func nodeVolumesPatch(node *corev1.Node, inUse []corev1.UniqueVolumeName) ([]byte, error) {
+	sort.Slice(inUse, func(i, j int) bool {
+		return inUse[i] < inUse[j]
+	})
+	if len(inUse) == len(node.Status.VolumesInUse) {
+		equal := true
+		for i, name := range node.Status.VolumesInUse {
+			if name != inUse[i] {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return nil, nil
+		}
+	}
+
+	if inUse == nil {
+		inUse = []corev1.UniqueVolumeName{}
+	}
+	return json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"volumesInUse": inUse,
+		},
+	})
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+func TestPodClaims(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-0",
+			Namespace: "default",
+		},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-web-0"},
+					},
+				},
+				{
+					Name: "scratch",
+					VolumeSource: corev1.VolumeSource{
+						Ephemeral: &corev1.EphemeralVolumeSource{},
+					},
+				},
+				{
+					Name: "config",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{},
+					},
+				},
+			},
+		},
+	}
+
+	got := podClaims(pod)
+	want := []log.ObjectRef{
+		log.KRef("default", "data-web-0"),
+		log.KRef("default", "web-0-scratch"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("podClaims() = %v, want %v", got, want)
+	}
+
+	pod.Status.Phase = corev1.PodSucceeded
+	if got := podClaims(pod); len(got) != 0 {
+		t.Errorf("podClaims() = %v, want none for the terminated pod", got)
+	}
+}
+
+func TestNodeController_syncVolumes(t *testing.T) {
+	ctx := context.Background()
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+		},
+	}
+	clientset := fake.NewSimpleClientset(
+		node,
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-csi"},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "default"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-local"},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-csi"},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{Driver: "ebs.csi.aws.com", VolumeHandle: "vol-1"},
+				},
+			},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-local"},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					Local: &corev1.LocalVolumeSource{Path: "/mnt"},
+				},
+			},
+		},
+	)
+
+	claims := []log.ObjectRef{
+		log.KRef("default", "data"),
+		log.KRef("default", "local"),
+		log.KRef("default", "pending"),
+		log.KRef("default", "missing"),
+	}
+	c, err := NewNodeController(NodeControllerConfig{
+		TypedClient:          clientset,
+		NodeCacheGetter:      fakeNodeGetter{node.Name: node},
+		PlayStageParallelism: 1,
+		PodClaimsFunc: func(nodeName string) []log.ObjectRef {
+			return claims
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.putNodeInfo(node)
+
+	err = c.syncVolumes(ctx, node.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := clientset.CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []corev1.UniqueVolumeName{"kubernetes.io/csi/ebs.csi.aws.com^vol-1"}
+	if !reflect.DeepEqual(got.Status.VolumesInUse, want) {
+		t.Errorf("volumesInUse = %v, want %v", got.Status.VolumesInUse, want)
+	}
+
+	patch, err := nodeVolumesPatch(got, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch != nil {
+		t.Errorf("nodeVolumesPatch() = %s, want nil for the volumes up to date", patch)
+	}
+}
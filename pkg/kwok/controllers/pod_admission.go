@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// podAdmissionPending returns whether the pod is not admitted by the node yet,
+// which is the pending pod without the IP and the start time set by the stages.
+func podAdmissionPending(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	if pod.Status.Phase != "" && pod.Status.Phase != corev1.PodPending {
+		return false
+	}
+	return pod.Status.PodIP == "" && pod.Status.StartTime == nil
+}
+
+// podAdmissionFailure returns the reason and the message of the rejection of the pod,
+// if its requests do not fit the allocatable of the node on top of the ones used by the other pods,
+// in the same format as the kubelet, e.g. "OutOfcpu".
+// Only the resources given are checked, and the ones the node does not have are ignored.
+func podAdmissionFailure(allocatable, used, requests corev1.ResourceList, resources []corev1.ResourceName) (reason, message string, rejected bool) {
+	for _, name := range resources {
+		capacity, ok := allocatable[name]
+		if !ok {
+			continue
+		}
+		requested, ok := requests[name]
+		if !ok || requested.IsZero() {
+			continue
+		}
+		inUse := used[name]
+
+		total := inUse.DeepCopy()
+		total.Add(requested)
+		if total.Cmp(capacity) <= 0 {
+			continue
+		}
+
+		value := func(q *resource.Quantity) int64 {
+			if name == corev1.ResourceCPU {
+				return q.MilliValue()
+			}
+			return q.Value()
+		}
+		return "OutOf" + string(name),
+			fmt.Sprintf("Pod was rejected: Node didn't have enough resource: %s, requested: %d, used: %d, capacity: %d",
+				name, value(&requested), value(&inUse), value(&capacity)),
+			true
+	}
+	return "", "", false
+}
+
+// podAdmissionPatch returns the patch of the status of the pod rejected by the node
+func podAdmissionPatch(reason, message string) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":   corev1.PodFailed,
+			"reason":  reason,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodAdmissionFailure(t *testing.T) {
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+		corev1.ResourcePods:   resource.MustParse("2"),
+	}
+	resources := []corev1.ResourceName{corev1.ResourcePods, corev1.ResourceCPU}
+
+	tests := []struct {
+		name        string
+		used        corev1.ResourceList
+		requests    corev1.ResourceList
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name: "fit",
+			used: corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("3"),
+				corev1.ResourcePods: resource.MustParse("1"),
+			},
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("1"),
+			},
+		},
+		{
+			name: "out of pods",
+			used: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("2"),
+			},
+			requests: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("1"),
+			},
+			wantReason:  "OutOfpods",
+			wantMessage: "Pod was rejected: Node didn't have enough resource: pods, requested: 1, used: 2, capacity: 2",
+		},
+		{
+			name: "out of cpu",
+			used: corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("3500m"),
+				corev1.ResourcePods: resource.MustParse("1"),
+			},
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("1"),
+				corev1.ResourcePods: resource.MustParse("1"),
+			},
+			wantReason:  "OutOfcpu",
+			wantMessage: "Pod was rejected: Node didn't have enough resource: cpu, requested: 1000, used: 3500, capacity: 4000",
+		},
+		{
+			name: "memory not checked",
+			requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+				corev1.ResourcePods:   resource.MustParse("1"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, message, rejected := podAdmissionFailure(allocatable, tt.used, tt.requests, resources)
+			if rejected != (tt.wantReason != "") {
+				t.Fatalf("rejected = %v, want %v", rejected, tt.wantReason != "")
+			}
+			if reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", reason, tt.wantReason)
+			}
+			if message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestPodController_admit(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("1"),
+			},
+		},
+	}
+	newPod := func(name string, after time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created.Add(after)),
+			},
+			Spec: corev1.PodSpec{
+				NodeName: node.Name,
+			},
+		}
+	}
+	first := newPod("first", 0)
+	second := newPod("second", time.Second)
+
+	clientset := fake.NewSimpleClientset(node, first, second)
+	c, err := NewPodController(PodControllerConfig{
+		TypedClient:          clientset,
+		NodeCacheGetter:      fakeNodeGetter{node.Name: node},
+		PlayStageParallelism: 1,
+		AdmissionResources:   []corev1.ResourceName{corev1.ResourcePods},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The second pod is seen first, but the first pod is admitted first as it is created earlier
+	c.putPodInfo(second)
+	c.putPodInfo(first)
+
+	admitted, err := c.admit(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !admitted {
+		t.Errorf("admit(first) = false, want true")
+	}
+
+	admitted, err = c.admit(ctx, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if admitted {
+		t.Errorf("admit(second) = true, want false")
+	}
+	got, err := clientset.CoreV1().Pods("default").Get(ctx, second.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != corev1.PodFailed || got.Status.Reason != "OutOfpods" {
+		t.Errorf("status = %s %s, want Failed OutOfpods", got.Status.Phase, got.Status.Reason)
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
@@ -56,9 +58,12 @@ type PodController struct {
 	disregardStatusWithAnnotationSelector labels.Selector
 	disregardStatusWithLabelSelector      labels.Selector
 	nodeIP                                string
+	nodeIPs                               []string
 	defaultCIDR                           string
+	defaultCIDRs                          []string
 	nodeGetFunc                           func(nodeName string) (*NodeInfo, bool)
-	ipPools                               maps.SyncMap[string, *ipPool]
+	ipamFunc                              func(cidr string) (IPAM, error)
+	ipPools                               maps.SyncMap[string, IPAM]
 	renderer                              gotpl.Renderer
 	podsSets                              maps.SyncMap[log.ObjectRef, *PodInfo]
 	podsOnNode                            maps.SyncMap[string, *maps.SyncMap[log.ObjectRef, *PodInfo]]
@@ -67,13 +72,27 @@ type PodController struct {
 	lifecycle                             resources.Getter[Lifecycle]
 	delayQueue                            queue.DelayingQueue[resourceStageJob[*corev1.Pod]]
 	delayQueueMapping                     maps.SyncMap[string, resourceStageJob[*corev1.Pod]]
+	stageChains                           *stageChains
+	timeScale                             float64
+	enableStageNextAnnotations            bool
 	recorder                              record.EventRecorder
 	readOnlyFunc                          func(nodeName string) bool
 	enableMetrics                         bool
+	onPodsOnNodeChangedFunc               func(nodeName string)
+	onPodDeletedFunc                      func(pod *corev1.Pod)
+	imagePuller                           *imagePuller
+	ownerCache                            *ownerCache
+	admissionResources                    []corev1.ResourceName
 }
 
 // PodInfo is the collection of necessary pod information
 type PodInfo struct {
+	// Requests is the resources requested by the pod, it is empty if the pod is terminated.
+	Requests corev1.ResourceList
+	// Claims is the PersistentVolumeClaims of the volumes of the pod, it is empty if the pod is terminated.
+	Claims []log.ObjectRef
+	// CreationTimestamp is the creation time of the pod, the pods created earlier are admitted by the node first.
+	CreationTimestamp time.Time
 }
 
 // PodControllerConfig is the configuration for the PodController
@@ -86,14 +105,22 @@ type PodControllerConfig struct {
 	DisregardStatusWithLabelSelector      string
 	NodeIP                                string
 	CIDR                                  string
+	IPAMFunc                              func(cidr string) (IPAM, error)
 	NodeGetFunc                           func(nodeName string) (*NodeInfo, bool)
 	NodeHasMetric                         func(nodeName string) bool
 	Lifecycle                             resources.Getter[Lifecycle]
 	PlayStageParallelism                  uint
+	StageMaxChainDepth                    uint
+	TimeScale                             float64
+	EnableStageNextAnnotations            bool
 	FuncMap                               gotpl.FuncMap
 	Recorder                              record.EventRecorder
 	ReadOnlyFunc                          func(nodeName string) bool
 	EnableMetrics                         bool
+	OnPodsOnNodeChangedFunc               func(nodeName string)
+	OnPodDeletedFunc                      func(pod *corev1.Pod)
+	ImageSimulation                       *internalversion.ImageSimulation
+	AdmissionResources                    []corev1.ResourceName
 }
 
 // NewPodController creates a new fake pods controller
@@ -116,6 +143,13 @@ func NewPodController(conf PodControllerConfig) (*PodController, error) {
 		conf.Clock = clock.RealClock{}
 	}
 
+	if conf.IPAMFunc == nil {
+		conf.IPAMFunc = NewIPAM
+	}
+
+	nodeIPs := splitList(conf.NodeIP)
+	cidrs := splitList(conf.CIDR)
+
 	c := &PodController{
 		clock:                                 conf.Clock,
 		enableCNI:                             conf.EnableCNI,
@@ -123,22 +157,36 @@ func NewPodController(conf PodControllerConfig) (*PodController, error) {
 		nodeCacheGetter:                       conf.NodeCacheGetter,
 		disregardStatusWithAnnotationSelector: disregardStatusWithAnnotationSelector,
 		disregardStatusWithLabelSelector:      disregardStatusWithLabelSelector,
-		nodeIP:                                conf.NodeIP,
-		defaultCIDR:                           conf.CIDR,
+		nodeIP:                                firstOf(nodeIPs),
+		nodeIPs:                               nodeIPs,
+		ipamFunc:                              conf.IPAMFunc,
+		defaultCIDR:                           firstOf(cidrs),
+		defaultCIDRs:                          cidrs,
 		nodeGetFunc:                           conf.NodeGetFunc,
 		delayQueue:                            queue.NewDelayingQueue[resourceStageJob[*corev1.Pod]](conf.Clock),
 		lifecycle:                             conf.Lifecycle,
 		playStageParallelism:                  conf.PlayStageParallelism,
 		preprocessChan:                        make(chan *corev1.Pod),
+		stageChains:                           newStageChains(conf.StageMaxChainDepth, conf.Recorder),
+		timeScale:                             conf.TimeScale,
+		enableStageNextAnnotations:            conf.EnableStageNextAnnotations,
 		recorder:                              conf.Recorder,
 		readOnlyFunc:                          conf.ReadOnlyFunc,
 		enableMetrics:                         conf.EnableMetrics,
+		onPodsOnNodeChangedFunc:               conf.OnPodsOnNodeChangedFunc,
+		onPodDeletedFunc:                      conf.OnPodDeletedFunc,
+		imagePuller:                           newImagePuller(conf.ImageSimulation, conf.Clock, conf.TimeScale),
+		ownerCache:                            newOwnerCache(conf.TypedClient),
+		admissionResources:                    conf.AdmissionResources,
 	}
 	funcMap := maps.Merge(gotpl.FuncMap{
-		"NodeIP":     c.funcNodeIP,
-		"PodIP":      c.funcPodIP,
-		"NodeIPWith": c.funcNodeIPWith,
-		"PodIPWith":  c.funcPodIPWith,
+		"NodeIP":      c.funcNodeIP,
+		"PodIP":       c.funcPodIP,
+		"NodeIPWith":  c.funcNodeIPWith,
+		"PodIPWith":   c.funcPodIPWith,
+		"NodeIPsWith": c.funcNodeIPsWith,
+		"PodIPsWith":  c.funcPodIPsWith,
+		"ImageKnown":  c.funcImageKnown,
 	}, conf.FuncMap)
 	c.renderer = gotpl.NewRenderer(funcMap)
 	return c, nil
@@ -147,6 +195,8 @@ func NewPodController(conf PodControllerConfig) (*PodController, error) {
 // Start starts the fake pod controller
 // It will modify the pods status to we want
 func (c *PodController) Start(ctx context.Context, events <-chan informer.Event[*corev1.Pod]) error {
+	stageDelayQueueLength.Register("Pod", c.delayQueueMapping.Size)
+
 	go c.preprocessWorker(ctx)
 	for i := uint(0); i < c.playStageParallelism; i++ {
 		go c.playStageWorker(ctx)
@@ -186,6 +236,24 @@ func (c *PodController) finalizersModify(ctx context.Context, pod *corev1.Pod, f
 	return result, nil
 }
 
+// annotateStageNext annotates the pod with the next stage planned for it,
+// the patched pod is returned, or the pod itself if it is not patched.
+func (c *PodController) annotateStageNext(ctx context.Context, pod *corev1.Pod, stage string, at time.Time) *corev1.Pod {
+	patch, err := stageNextPatch(pod.Annotations, stage, at)
+	if err != nil || patch == nil {
+		return pod
+	}
+	result, err := c.typedClient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Error("Failed to annotate the next stage", err,
+			"pod", log.KObj(pod),
+		)
+		return pod
+	}
+	return result
+}
+
 // deleteResource deletes a pod
 func (c *PodController) deleteResource(ctx context.Context, pod *corev1.Pod) error {
 	logger := log.FromContext(ctx)
@@ -244,25 +312,90 @@ func (c *PodController) preprocess(ctx context.Context, pod *corev1.Pod) error {
 		"node", pod.Spec.NodeName,
 	)
 
+	if stagePaused(pod.Annotations) {
+		if ok {
+			c.delayQueueMapping.Delete(key)
+			c.delayQueue.Cancel(resourceJob)
+		}
+		logger.Debug("Skip pod",
+			"reason", "stages paused",
+		)
+		return nil
+	}
+
+	if ok && resourceJob.Step != 0 {
+		logger.Debug("Skip pod",
+			"reason", "patches of the stage are being applied",
+		)
+		return nil
+	}
+
+	if len(c.admissionResources) != 0 && podAdmissionPending(pod) {
+		admitted, err := c.admit(ctx, pod)
+		if err != nil {
+			return fmt.Errorf("admit pod: %w", err)
+		}
+		if !admitted {
+			return nil
+		}
+	}
+
 	data, err := expression.ToJSONStandard(pod)
 	if err != nil {
 		return err
 	}
 
 	lifecycle := c.lifecycle.Get()
-	stage, err := lifecycle.Match(pod.Labels, pod.Annotations, data)
+	vars, err := c.stageVariables(ctx, pod, lifecycle)
+	if err != nil {
+		return fmt.Errorf("stage variables: %w", err)
+	}
+	ctx, err = expression.WithVariables(ctx, vars)
+	if err != nil {
+		return err
+	}
+	now := c.clock.Now()
+	ctx, err = expression.WithVariables(ctx, ageVariables(lifecycle, data, now, c.timeScale))
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	stage, err := lifecycle.Match(ctx, pod.Labels, pod.Annotations, data)
+	stageEvaluationDurationSeconds.WithLabelValues("Pod").Observe(time.Since(start).Seconds())
 	if err != nil {
 		return fmt.Errorf("stage match: %w", err)
 	}
 	if stage == nil {
+		if c.enableStageNextAnnotations {
+			pod = c.annotateStageNext(ctx, pod, "", time.Time{})
+		}
+		if wait, ok := nextAgeRecheck(lifecycle, data, now, c.timeScale); ok {
+			// The ages of the resource change without any update, so recheck it once the next threshold is crossed.
+			item := resourceStageJob[*corev1.Pod]{
+				Resource: pod,
+				Key:      key,
+			}
+			if c.delayQueue.AddAfter(item, wait) {
+				c.delayQueueMapping.Store(key, item)
+			}
+		}
 		logger.Debug("Skip pod",
 			"reason", "not match any stages",
 		)
 		return nil
 	}
-
-	now := c.clock.Now()
-	delay, _ := stage.Delay(ctx, data, now)
+	stageMatchTotal.WithLabelValues("Pod", stage.Name()).Inc()
+
+	delay := stageDelay(ctx, stage, data, now, c.timeScale)
+	if c.enableStageNextAnnotations {
+		// The stage played at once is not planned, so the annotations of the previous stage are removed.
+		next, at := "", time.Time{}
+		if delay != 0 {
+			next, at = stage.Name(), now.Add(delay)
+		}
+		pod = c.annotateStageNext(ctx, pod, next, at)
+	}
+	stageStats.Matched("Pod", stage.Name(), delay)
 
 	if delay != 0 {
 		stageName := stage.Name()
@@ -289,18 +422,70 @@ func (c *PodController) preprocess(ctx context.Context, pod *corev1.Pod) error {
 	return nil
 }
 
+// stageVariables returns the related resources of the pod, that can be referenced in the stage expressions.
+// $node is the node the pod is bound to, $owner is the top-level controller that owns the pod,
+// and $imagePull is the pulling of the images of the pod if the image simulation is enabled.
+func (c *PodController) stageVariables(ctx context.Context, pod *corev1.Pod, lifecycle Lifecycle) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+	var node *corev1.Node
+	if c.nodeCacheGetter != nil && (lifecycle.HasVariable("node") || lifecycle.HasVariable(imagePullVariable)) {
+		node, _ = c.nodeCacheGetter.Get(pod.Spec.NodeName)
+	}
+	if node != nil && lifecycle.HasVariable("node") {
+		vars["node"] = node
+	}
+	if c.imagePuller != nil && lifecycle.HasVariable(imagePullVariable) {
+		vars[imagePullVariable] = c.imagePuller.Pull(pod.Spec.NodeName, node, pod)
+	}
+	if lifecycle.HasVariable("owner") {
+		owner, err := c.getOwner(ctx, pod)
+		if err != nil {
+			return nil, err
+		}
+		if owner != nil {
+			vars["owner"] = owner
+		}
+	}
+	return vars, nil
+}
+
+// getOwner returns the top-level controller that owns the pod, e.g. the Deployment of a pod of a ReplicaSet
+func (c *PodController) getOwner(ctx context.Context, pod *corev1.Pod) (interface{}, error) {
+	owner, err := c.ownerCache.GetTopLevel(ctx, pod, pod.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, nil
+	}
+	return owner, nil
+}
+
 // playStageWorker receives the resource from the playStageChan and play the stage
 func (c *PodController) playStageWorker(ctx context.Context) {
 	for ctx.Err() == nil {
 		pod := c.delayQueue.GetOrWait()
+		if pod.Stage == nil {
+			// Recheck the resource against the stages, unless it has been updated since.
+			if job, ok := c.delayQueueMapping.Load(pod.Key); ok && job == pod {
+				c.delayQueueMapping.Delete(pod.Key)
+				c.preprocessChan <- pod.Resource
+			}
+			continue
+		}
 		c.delayQueueMapping.Delete(pod.Key)
-		c.playStage(ctx, pod.Resource, pod.Stage)
+		if pod.Step != 0 {
+			c.playStagePatch(ctx, pod.Resource, pod.Stage, pod.Step)
+		} else {
+			c.playStage(ctx, pod.Resource, pod.Stage)
+		}
 	}
 }
 
 // playStage plays the stage
 func (c *PodController) playStage(ctx context.Context, pod *corev1.Pod, stage *LifecycleStage) {
 	next := stage.Next()
+	stageStats.Played("Pod", stage.Name(), c.clock.Now())
 	logger := log.FromContext(ctx)
 	logger = logger.With(
 		"pod", log.KObj(pod),
@@ -308,32 +493,62 @@ func (c *PodController) playStage(ctx context.Context, pod *corev1.Pod, stage *L
 		"stage", stage.Name(),
 	)
 
-	if next.Event != nil && c.recorder != nil {
-		c.recorder.Event(&corev1.ObjectReference{
-			Kind:      "Pod",
-			UID:       pod.UID,
-			Name:      pod.Name,
-			Namespace: pod.Namespace,
-		}, next.Event.Type, next.Event.Reason, next.Event.Message)
+	ref := &corev1.ObjectReference{
+		Kind:      "Pod",
+		UID:       pod.UID,
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
 	}
+	recordStageEvents(ctx, c.recorder, c.renderer, ref, next, pod)
+	var status []byte
+	if next.Webhook != nil {
+		var err error
+		status, err = callStageWebhook(ctx, stage.Name(), next.Webhook, pod)
+		if err != nil {
+			logger.Error("Failed to call webhook", err)
+			stagePatchFailedTotal.WithLabelValues("Pod", stage.Name()).Inc()
+			return
+		}
+	}
+	// The patches are applied after the next, and the immediate next stage is matched after the last patch.
+	patches := !next.Delete && len(next.Patches) != 0
+	latest := pod
 	if next.Finalizers != nil {
 		result, err := c.finalizersModify(ctx, pod, next.Finalizers)
 		if err != nil {
 			logger.Error("Failed to finalizers", err)
+			stagePatchFailedTotal.WithLabelValues("Pod", stage.Name()).Inc()
 		}
-		if result != nil && stage.ImmediateNextStage() {
-			c.preprocessChan <- result
+		if result != nil {
+			latest = result
+			if !patches && stage.ImmediateNextStage() &&
+				c.stageChains.Next(ctx, ref, pod.ResourceVersion, stage.Name(), result.ResourceVersion) {
+				c.preprocessChan <- result
+			}
+		}
+	}
+	if !next.Delete {
+		for _, subresource := range next.Subresources {
+			err := c.patchSubresource(ctx, pod, subresource)
+			if err != nil {
+				logger.Error("Failed to patch subresource", err,
+					"subresource", subresource.Name,
+				)
+				stagePatchFailedTotal.WithLabelValues("Pod", stage.Name()).Inc()
+			}
 		}
 	}
 	if next.Delete {
 		err := c.deleteResource(ctx, pod)
 		if err != nil {
 			logger.Error("Failed to delete pod", err)
+			stagePatchFailedTotal.WithLabelValues("Pod", stage.Name()).Inc()
 		}
-	} else if next.StatusTemplate != "" {
-		patch, err := c.configureResource(pod, next.StatusTemplate)
+	} else if next.StatusTemplate != "" || status != nil {
+		patch, err := c.configureResource(pod, next.StatusTemplate, status)
 		if err != nil {
 			logger.Error("Failed to configure pod", err)
+			stagePatchFailedTotal.WithLabelValues("Pod", stage.Name()).Inc()
 			return
 		}
 		if patch == nil {
@@ -344,12 +559,85 @@ func (c *PodController) playStage(ctx context.Context, pod *corev1.Pod, stage *L
 			result, err := c.patchResource(ctx, pod, patch)
 			if err != nil {
 				logger.Error("Failed to patch node", err)
+				stagePatchFailedTotal.WithLabelValues("Pod", stage.Name()).Inc()
 			}
-			if result != nil && stage.ImmediateNextStage() {
-				c.preprocessChan <- result
+			if result != nil {
+				latest = result
+				if !patches && stage.ImmediateNextStage() &&
+					c.stageChains.Next(ctx, ref, pod.ResourceVersion, stage.Name(), result.ResourceVersion) {
+					c.preprocessChan <- result
+				}
 			}
 		}
 	}
+	if patches {
+		c.addStagePatch(latest, stage, 1)
+	}
+}
+
+// playStagePatch applies the patch of the stage, and schedules the next patch
+func (c *PodController) playStagePatch(ctx context.Context, pod *corev1.Pod, stage *LifecycleStage, step int) {
+	patches := stage.Next().Patches
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"pod", log.KObj(pod),
+		"node", pod.Spec.NodeName,
+		"stage", stage.Name(),
+		"step", step,
+	)
+
+	patch, err := c.configureResource(pod, patches[step-1].StatusTemplate, nil)
+	if err != nil {
+		logger.Error("Failed to configure pod", err)
+		stagePatchFailedTotal.WithLabelValues("Pod", stage.Name()).Inc()
+		return
+	}
+	latest := pod
+	if patch == nil {
+		logger.Debug("Skip pod",
+			"reason", "do not need to modify",
+		)
+	} else {
+		result, err := c.patchResource(ctx, pod, patch)
+		if err != nil {
+			logger.Error("Failed to patch pod", err)
+			stagePatchFailedTotal.WithLabelValues("Pod", stage.Name()).Inc()
+			return
+		}
+		if result == nil {
+			return
+		}
+		latest = result
+	}
+
+	if step < len(patches) {
+		c.addStagePatch(latest, stage, step+1)
+		return
+	}
+
+	ref := &corev1.ObjectReference{
+		Kind:      "Pod",
+		UID:       pod.UID,
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+	}
+	if latest != pod && stage.ImmediateNextStage() &&
+		c.stageChains.Next(ctx, ref, pod.ResourceVersion, stage.Name(), latest.ResourceVersion) {
+		c.preprocessChan <- latest
+	}
+}
+
+// addStagePatch schedules the patch of the stage to be applied after its delay
+func (c *PodController) addStagePatch(pod *corev1.Pod, stage *LifecycleStage, step int) {
+	item := resourceStageJob[*corev1.Pod]{
+		Resource: pod,
+		Stage:    stage,
+		Key:      log.KObj(pod).String(),
+		Step:     step,
+	}
+	if c.delayQueue.AddAfter(item, scaleDuration(stagePatchDelay(stage, step), c.timeScale)) {
+		c.delayQueueMapping.Store(item.Key, item)
+	}
 }
 
 func (c *PodController) readOnly(nodeName string) bool {
@@ -414,9 +702,12 @@ loop:
 			switch event.Type {
 			case informer.Added, informer.Modified, informer.Sync:
 				pod := event.Object
-				if c.enableMetrics {
+				if c.trackPodInfo() {
 					c.putPodInfo(pod)
 				}
+				if _, ok := c.nodeGetFunc(pod.Spec.NodeName); ok {
+					podStats.Put(pod)
+				}
 				if c.need(pod) {
 					if c.readOnly(pod.Spec.NodeName) {
 						logger.Debug("Skip pod",
@@ -447,9 +738,10 @@ loop:
 				}
 			case informer.Deleted:
 				pod := event.Object
-				if c.enableMetrics {
+				if c.trackPodInfo() {
 					c.deletePodInfo(pod)
 				}
+				podStats.Delete(pod)
 				if c.need(pod) {
 					// Recycling PodIP
 					c.recyclingPodIP(ctx, pod)
@@ -460,6 +752,16 @@ loop:
 					if ok {
 						c.delayQueue.Cancel(resourceJob)
 					}
+
+					c.stageChains.Delete(&corev1.ObjectReference{
+						Kind:      "Pod",
+						Name:      pod.Name,
+						Namespace: pod.Namespace,
+					})
+
+					if c.onPodDeletedFunc != nil {
+						c.onPodDeletedFunc(pod)
+					}
 				}
 			}
 		case <-ctx.Done():
@@ -469,19 +771,18 @@ loop:
 	logger.Info("Stop watch pods")
 }
 
-// ipPool returns the ipPool for the given cidr
-func (c *PodController) ipPool(cidr string) (*ipPool, error) {
+// ipPool returns the IPAM for the given cidr
+func (c *PodController) ipPool(cidr string) (IPAM, error) {
 	pool, ok := c.ipPools.Load(cidr)
 	if ok {
 		return pool, nil
 	}
-	ipnet, err := parseCIDR(cidr)
+	pool, err := c.ipamFunc(cidr)
 	if err != nil {
 		return nil, err
 	}
 
-	pool = newIPPool(ipnet)
-	c.ipPools.Store(cidr, pool)
+	pool, _ = c.ipPools.LoadOrStore(cidr, pool)
 	return pool, nil
 }
 
@@ -500,21 +801,19 @@ func (c *PodController) recyclingPodIP(ctx context.Context, pod *corev1.Pod) {
 
 	logger := log.FromContext(ctx)
 	if !c.enableCNI {
-		if pod.Status.PodIP != "" && c.nodeCacheGetter != nil {
-			cidr := c.defaultCIDR
-			node, ok := c.nodeCacheGetter.Get(pod.Spec.NodeName)
-			if ok {
-				if node.Spec.PodCIDR != "" {
-					cidr = node.Spec.PodCIDR
-				}
+		podIPs := getPodIPs(pod)
+		if len(podIPs) != 0 {
+			for _, cidr := range c.podCIDRs(pod.Spec.NodeName) {
 				pool, err := c.ipPool(cidr)
 				if err != nil {
 					logger.Error("Failed to get ip pool", err,
 						"pod", log.KObj(pod),
 						"node", pod.Spec.NodeName,
 					)
-				} else {
-					pool.Put(pod.Status.PodIP)
+					continue
+				}
+				for _, ip := range podIPs {
+					pool.Release(ip)
 				}
 			}
 		}
@@ -526,27 +825,23 @@ func (c *PodController) recyclingPodIP(ctx context.Context, pod *corev1.Pod) {
 	}
 }
 
-func (c *PodController) configureResource(pod *corev1.Pod, template string) ([]byte, error) {
+func (c *PodController) configureResource(pod *corev1.Pod, template string, status []byte) ([]byte, error) {
 	if !c.enableCNI {
 		// Mark the pod IP that existed before the kubelet was started
-		if _, has := c.nodeGetFunc(pod.Spec.NodeName); has {
-			if pod.Status.PodIP != "" && c.nodeCacheGetter != nil {
-				cidr := c.defaultCIDR
-				node, ok := c.nodeCacheGetter.Get(pod.Spec.NodeName)
-				if ok {
-					if node.Spec.PodCIDR != "" {
-						cidr = node.Spec.PodCIDR
-					}
-					pool, err := c.ipPool(cidr)
-					if err == nil {
-						pool.Use(pod.Status.PodIP)
-					}
+		if podIPs := getPodIPs(pod); len(podIPs) != 0 {
+			for _, cidr := range c.podCIDRs(pod.Spec.NodeName) {
+				pool, err := c.ipPool(cidr)
+				if err != nil {
+					continue
+				}
+				for _, ip := range podIPs {
+					pool.Use(ip)
 				}
 			}
 		}
 	}
 
-	patch, err := c.computePatch(pod, template)
+	patch, err := c.computePatch(pod, template, status)
 	if err != nil {
 		return nil, err
 	}
@@ -559,10 +854,44 @@ func (c *PodController) configureResource(pod *corev1.Pod, template string) ([]b
 	})
 }
 
-func (c *PodController) computePatch(pod *corev1.Pod, tpl string) ([]byte, error) {
-	patch, err := c.renderer.ToJSON(tpl, pod)
+// patchSubresource patches the subresource of the pod
+func (c *PodController) patchSubresource(ctx context.Context, pod *corev1.Pod, subresource internalversion.StageSubresource) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"pod", log.KObj(pod),
+		"node", pod.Spec.NodeName,
+		"subresource", subresource.Name,
+	)
+
+	data, err := c.renderer.ToJSON(subresource.Template, pod)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	_, err = c.typedClient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{}, subresource.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warn("Patch pod subresource",
+				"err", err,
+			)
+			return nil
+		}
+		return err
+	}
+	logger.Info("Patch pod subresource")
+	return nil
+}
+
+// computePatch renders the template and returns the patch of the status,
+// the response is used as the patch instead if it is not nil.
+func (c *PodController) computePatch(pod *corev1.Pod, tpl string, response []byte) ([]byte, error) {
+	patch := response
+	if patch == nil {
+		var err error
+		patch, err = c.renderer.ToJSON(tpl, pod)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	original, err := json.Marshal(pod.Status)
@@ -611,13 +940,13 @@ func (c *PodController) funcNodeIPWith(nodeName string) string {
 	return c.nodeIP
 }
 
-func (c *PodController) funcPodIP() string {
+func (c *PodController) funcPodIP() (string, error) {
 	podCIDR := c.defaultCIDR
 	pool, err := c.ipPool(podCIDR)
 	if err == nil {
-		return pool.Get()
+		return pool.Allocate()
 	}
-	return c.nodeIP
+	return c.nodeIP, nil
 }
 
 func (c *PodController) funcPodIPWith(nodeName string, hostNetwork bool, uid, name, namespace string) (string, error) {
@@ -633,27 +962,112 @@ func (c *PodController) funcPodIPWith(nodeName string, hostNetwork bool, uid, na
 		return ips[0], nil
 	}
 
-	podCIDR := c.defaultCIDR
+	podCIDR := c.podCIDRs(nodeName)[0]
+	pool, err := c.ipPool(podCIDR)
+	if err == nil {
+		return pool.Allocate()
+	}
+	return c.nodeIP, nil
+}
+
+// funcImageKnown returns whether the image is in the registry of the image simulation,
+// all images are known if the image simulation is disabled.
+func (c *PodController) funcImageKnown(image string) bool {
+	if c.imagePuller == nil {
+		return true
+	}
+	return c.imagePuller.Known(image)
+}
+
+// funcNodeIPsWith returns the IPs of the node, one for each IP family
+func (c *PodController) funcNodeIPsWith(nodeName string) []string {
 	_, has := c.nodeGetFunc(nodeName)
 	if has && c.nodeCacheGetter != nil {
 		node, ok := c.nodeCacheGetter.Get(nodeName)
 		if ok {
-			if node.Spec.PodCIDR != "" {
-				podCIDR = node.Spec.PodCIDR
+			hostIPs := getNodeHostIPs(node)
+			if len(hostIPs) != 0 {
+				ips := make([]string, 0, len(hostIPs))
+				for _, ip := range hostIPs {
+					ips = append(ips, ip.String())
+				}
+				return ips
 			}
 		}
 	}
+	if len(c.nodeIPs) == 0 {
+		return []string{c.nodeIP}
+	}
+	return c.nodeIPs
+}
 
-	pool, err := c.ipPool(podCIDR)
-	if err == nil {
-		return pool.Get(), nil
+// funcPodIPsWith returns the IPs of the pod allocated from each pod CIDR of the node, for dual-stack
+func (c *PodController) funcPodIPsWith(nodeName string, hostNetwork bool, uid, name, namespace string) ([]string, error) {
+	if hostNetwork {
+		return c.funcNodeIPsWith(nodeName), nil
 	}
-	return c.nodeIP, nil
+
+	if c.enableCNI {
+		return cni.Setup(context.Background(), uid, name, namespace)
+	}
+
+	podCIDRs := c.podCIDRs(nodeName)
+	ips := make([]string, 0, len(podCIDRs))
+	pools := make([]IPAM, 0, len(podCIDRs))
+	for _, podCIDR := range podCIDRs {
+		pool, err := c.ipPool(podCIDR)
+		if err != nil {
+			continue
+		}
+		ip, err := pool.Allocate()
+		if err != nil {
+			// Release the IPs of the other families, the pod gets all or nothing
+			for i, ip := range ips {
+				pools[i].Release(ip)
+			}
+			return nil, err
+		}
+		ips = append(ips, ip)
+		pools = append(pools, pool)
+	}
+	if len(ips) == 0 {
+		return []string{c.nodeIP}, nil
+	}
+	return ips, nil
+}
+
+// podCIDRs returns the pod CIDRs of the node, or the default CIDRs if the node has none
+func (c *PodController) podCIDRs(nodeName string) []string {
+	_, has := c.nodeGetFunc(nodeName)
+	if has && c.nodeCacheGetter != nil {
+		node, ok := c.nodeCacheGetter.Get(nodeName)
+		if ok {
+			if len(node.Spec.PodCIDRs) != 0 {
+				return node.Spec.PodCIDRs
+			}
+			if node.Spec.PodCIDR != "" {
+				return []string{node.Spec.PodCIDR}
+			}
+		}
+	}
+	if len(c.defaultCIDRs) == 0 {
+		return []string{c.defaultCIDR}
+	}
+	return c.defaultCIDRs
+}
+
+// trackPodInfo returns whether the pod info is needed by the metrics, the admission, the pressure or the volumes of the nodes
+func (c *PodController) trackPodInfo() bool {
+	return c.enableMetrics || len(c.admissionResources) != 0 || c.onPodsOnNodeChangedFunc != nil
 }
 
 // putPodInfo puts pod info
 func (c *PodController) putPodInfo(pod *corev1.Pod) {
-	podInfo := &PodInfo{}
+	podInfo := &PodInfo{
+		Requests:          podRequests(pod),
+		Claims:            podClaims(pod),
+		CreationTimestamp: pod.CreationTimestamp.Time,
+	}
 	key := log.KObj(pod)
 	c.podsSets.Store(key, podInfo)
 	m, ok := c.podsOnNode.Load(pod.Spec.NodeName)
@@ -662,6 +1076,9 @@ func (c *PodController) putPodInfo(pod *corev1.Pod) {
 		c.podsOnNode.Store(pod.Spec.NodeName, m)
 	}
 	m.Store(key, podInfo)
+	if c.onPodsOnNodeChangedFunc != nil && pod.Spec.NodeName != "" {
+		c.onPodsOnNodeChangedFunc(pod.Spec.NodeName)
+	}
 }
 
 // deletePodInfo deletes pod info
@@ -673,6 +1090,9 @@ func (c *PodController) deletePodInfo(pod *corev1.Pod) {
 		return
 	}
 	m.Delete(key)
+	if c.onPodsOnNodeChangedFunc != nil && pod.Spec.NodeName != "" {
+		c.onPodsOnNodeChangedFunc(pod.Spec.NodeName)
+	}
 }
 
 // Get gets pod info
@@ -692,3 +1112,125 @@ func (c *PodController) List(nodeName string) ([]log.ObjectRef, bool) {
 	}
 	return m.Keys(), true
 }
+
+// Requests returns the sum of the resources requested by the pods on the node
+func (c *PodController) Requests(nodeName string) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	m, ok := c.podsOnNode.Load(nodeName)
+	if !ok {
+		return requests
+	}
+	m.Range(func(_ log.ObjectRef, podInfo *PodInfo) bool {
+		addResourceList(requests, podInfo.Requests)
+		return true
+	})
+	return requests
+}
+
+// requestsBefore returns the sum of the resources requested by the other pods on the node created before the pod
+func (c *PodController) requestsBefore(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	m, ok := c.podsOnNode.Load(pod.Spec.NodeName)
+	if !ok {
+		return requests
+	}
+	key := log.KObj(pod)
+	created := pod.CreationTimestamp.Time
+	m.Range(func(ref log.ObjectRef, podInfo *PodInfo) bool {
+		if ref == key {
+			return true
+		}
+		if podInfo.CreationTimestamp.After(created) ||
+			(podInfo.CreationTimestamp.Equal(created) && ref.String() > key.String()) {
+			return true
+		}
+		addResourceList(requests, podInfo.Requests)
+		return true
+	})
+	return requests
+}
+
+// admit rejects the pod if it does not fit the allocatable of the node, as the kubelet does,
+// the rejected pod is failed with the reason like "OutOfcpu", and it returns false then.
+func (c *PodController) admit(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	if c.nodeCacheGetter == nil {
+		return true, nil
+	}
+	node, ok := c.nodeCacheGetter.Get(pod.Spec.NodeName)
+	if !ok {
+		return true, nil
+	}
+
+	reason, message, rejected := podAdmissionFailure(node.Status.Allocatable, c.requestsBefore(pod), podRequests(pod), c.admissionResources)
+	if !rejected {
+		return true, nil
+	}
+
+	patch, err := podAdmissionPatch(reason, message)
+	if err != nil {
+		return false, err
+	}
+	_, err = c.patchResource(ctx, pod, patch)
+	if err != nil {
+		return false, err
+	}
+	if c.recorder != nil {
+		c.recorder.Event(pod, corev1.EventTypeWarning, reason, message)
+	}
+	logger := log.FromContext(ctx)
+	logger.Info("Reject pod",
+		"pod", log.KObj(pod),
+		"node", pod.Spec.NodeName,
+		"reason", reason,
+	)
+	return false, nil
+}
+
+// Claims returns the PersistentVolumeClaims of the volumes of the pods on the node
+func (c *PodController) Claims(nodeName string) []log.ObjectRef {
+	m, ok := c.podsOnNode.Load(nodeName)
+	if !ok {
+		return nil
+	}
+	seen := map[log.ObjectRef]struct{}{}
+	var claims []log.ObjectRef
+	m.Range(func(_ log.ObjectRef, podInfo *PodInfo) bool {
+		for _, claim := range podInfo.Claims {
+			if _, ok := seen[claim]; ok {
+				continue
+			}
+			seen[claim] = struct{}{}
+			claims = append(claims, claim)
+		}
+		return true
+	})
+	return claims
+}
+
+// Reboot terminates the running containers of the pods on the node going down,
+// and restarts them once the node comes back, as the node being rebooted does.
+func (c *PodController) Reboot(ctx context.Context, nodeName string, up bool) error {
+	list, err := c.typedClient.CoreV1().Pods(corev1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	now := c.clock.Now()
+	for i := range list.Items {
+		pod := &list.Items[i]
+		patch, err := podRebootPatch(pod, up, now)
+		if err != nil {
+			return err
+		}
+		if patch == nil {
+			continue
+		}
+		_, err = c.patchResource(ctx, pod, patch)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -369,3 +370,83 @@ func TestPodController(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestPodController_DualStack(t *testing.T) {
+	nodes := fakeNodeGetter{
+		"node0": {
+			ObjectMeta: metav1.ObjectMeta{Name: "node0"},
+			Spec: corev1.NodeSpec{
+				PodCIDR:  "10.100.0.0/24",
+				PodCIDRs: []string{"10.100.0.0/24", "fd00:100::/64"},
+			},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{
+					{Type: corev1.NodeInternalIP, Address: "10.0.0.2"},
+					{Type: corev1.NodeInternalIP, Address: "fd00::2"},
+				},
+			},
+		},
+	}
+	pods, err := NewPodController(PodControllerConfig{
+		NodeIP:          "10.0.0.1,fd00::1",
+		CIDR:            "10.0.0.0/24,fd00::/64",
+		NodeCacheGetter: nodes,
+		NodeGetFunc: func(nodeName string) (*NodeInfo, bool) {
+			_, ok := nodes[nodeName]
+			return &NodeInfo{}, ok
+		},
+		PlayStageParallelism: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		nodeName    string
+		hostNetwork bool
+		wantHostIPs []string
+		wantPodIPs  []string
+	}{
+		{
+			name:        "node with pod cidrs",
+			nodeName:    "node0",
+			wantHostIPs: []string{"10.0.0.2", "fd00::2"},
+			wantPodIPs:  []string{"10.100.0.1", "fd00:100::1"},
+		},
+		{
+			name:        "node with pod cidrs again",
+			nodeName:    "node0",
+			wantHostIPs: []string{"10.0.0.2", "fd00::2"},
+			wantPodIPs:  []string{"10.100.0.2", "fd00:100::2"},
+		},
+		{
+			name:        "host network",
+			nodeName:    "node0",
+			hostNetwork: true,
+			wantHostIPs: []string{"10.0.0.2", "fd00::2"},
+			wantPodIPs:  []string{"10.0.0.2", "fd00::2"},
+		},
+		{
+			name:        "not managed node",
+			nodeName:    "node1",
+			wantHostIPs: []string{"10.0.0.1", "fd00::1"},
+			wantPodIPs:  []string{"10.0.0.1", "fd00::1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hostIPs := pods.funcNodeIPsWith(tt.nodeName)
+			if !reflect.DeepEqual(hostIPs, tt.wantHostIPs) {
+				t.Errorf("funcNodeIPsWith() = %v, want %v", hostIPs, tt.wantHostIPs)
+			}
+			podIPs, err := pods.funcPodIPsWith(tt.nodeName, tt.hostNetwork, "", "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(podIPs, tt.wantPodIPs) {
+				t.Errorf("funcPodIPsWith() = %v, want %v", podIPs, tt.wantPodIPs)
+			}
+		})
+	}
+}
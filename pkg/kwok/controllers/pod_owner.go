@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/kwok/pkg/utils/informer"
+)
+
+// ownerLookup returns the owner with the name in the namespace from a cache.
+type ownerLookup func(name, namespace string) (runtime.Object, bool)
+
+// ownerCache returns the controllers owning the pods from the caches of their kinds,
+// the cache of a kind is started the first time an owner of the kind is needed.
+type ownerCache struct {
+	typedClient kubernetes.Interface
+
+	mut     sync.Mutex
+	lookups map[string]ownerLookup
+}
+
+// newOwnerCache returns a new ownerCache
+func newOwnerCache(typedClient kubernetes.Interface) *ownerCache {
+	return &ownerCache{
+		typedClient: typedClient,
+		lookups:     map[string]ownerLookup{},
+	}
+}
+
+// Get returns the controller referenced by the owner reference in the namespace,
+// it is nil if the kind is not supported or the controller is not found.
+func (o *ownerCache) Get(ctx context.Context, ref *metav1.OwnerReference, namespace string) (runtime.Object, error) {
+	kind := ref.APIVersion + "/" + ref.Kind
+	lookup, err := o.lookup(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	if lookup == nil {
+		return nil, nil
+	}
+	if owner, ok := lookup(ref.Name, namespace); ok {
+		return owner, nil
+	}
+
+	// The owner is not in the cache until the cache is synced, or just after the owner is created.
+	owner, err := o.fetch(ctx, kind, ref.Name, namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return owner, nil
+}
+
+// maxOwnerDepth is the most levels of the controllers walked up from a pod, in case of a cycle of the owner references.
+const maxOwnerDepth = 5
+
+// GetTopLevel returns the top-level controller of the object in the namespace,
+// which is found by walking up the controller references, e.g. the Deployment of a pod of a ReplicaSet,
+// it is nil if the object is not controlled by any supported controller.
+func (o *ownerCache) GetTopLevel(ctx context.Context, obj metav1.Object, namespace string) (runtime.Object, error) {
+	var top runtime.Object
+	for i := 0; i < maxOwnerDepth; i++ {
+		ref := metav1.GetControllerOf(obj)
+		if ref == nil {
+			break
+		}
+		owner, err := o.Get(ctx, ref, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if owner == nil {
+			break
+		}
+		top = owner
+		obj, err = meta.Accessor(owner)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return top, nil
+}
+
+func (o *ownerCache) lookup(ctx context.Context, kind string) (ownerLookup, error) {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+	if lookup, ok := o.lookups[kind]; ok {
+		return lookup, nil
+	}
+
+	var (
+		lookup ownerLookup
+		err    error
+	)
+	switch kind {
+	case "apps/v1/Deployment":
+		lookup, err = watchOwners[*appsv1.Deployment, *appsv1.DeploymentList](ctx, o.typedClient.AppsV1().Deployments(corev1.NamespaceAll))
+	case "apps/v1/ReplicaSet":
+		lookup, err = watchOwners[*appsv1.ReplicaSet, *appsv1.ReplicaSetList](ctx, o.typedClient.AppsV1().ReplicaSets(corev1.NamespaceAll))
+	case "apps/v1/StatefulSet":
+		lookup, err = watchOwners[*appsv1.StatefulSet, *appsv1.StatefulSetList](ctx, o.typedClient.AppsV1().StatefulSets(corev1.NamespaceAll))
+	case "apps/v1/DaemonSet":
+		lookup, err = watchOwners[*appsv1.DaemonSet, *appsv1.DaemonSetList](ctx, o.typedClient.AppsV1().DaemonSets(corev1.NamespaceAll))
+	case "batch/v1/CronJob":
+		lookup, err = watchOwners[*batchv1.CronJob, *batchv1.CronJobList](ctx, o.typedClient.BatchV1().CronJobs(corev1.NamespaceAll))
+	case "batch/v1/Job":
+		lookup, err = watchOwners[*batchv1.Job, *batchv1.JobList](ctx, o.typedClient.BatchV1().Jobs(corev1.NamespaceAll))
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch %s: %w", kind, err)
+	}
+	o.lookups[kind] = lookup
+	return lookup, nil
+}
+
+func (o *ownerCache) fetch(ctx context.Context, kind, name, namespace string) (runtime.Object, error) {
+	opts := metav1.GetOptions{}
+	switch kind {
+	case "apps/v1/Deployment":
+		return o.typedClient.AppsV1().Deployments(namespace).Get(ctx, name, opts)
+	case "apps/v1/ReplicaSet":
+		return o.typedClient.AppsV1().ReplicaSets(namespace).Get(ctx, name, opts)
+	case "apps/v1/StatefulSet":
+		return o.typedClient.AppsV1().StatefulSets(namespace).Get(ctx, name, opts)
+	case "apps/v1/DaemonSet":
+		return o.typedClient.AppsV1().DaemonSets(namespace).Get(ctx, name, opts)
+	case "batch/v1/CronJob":
+		return o.typedClient.BatchV1().CronJobs(namespace).Get(ctx, name, opts)
+	case "batch/v1/Job":
+		return o.typedClient.BatchV1().Jobs(namespace).Get(ctx, name, opts)
+	}
+	return nil, nil
+}
+
+// watchOwners starts the cache of the owners of a kind, only the cache is used and the events are dropped.
+func watchOwners[T runtime.Object, L runtime.Object](ctx context.Context, lw informer.Watcher[T, L]) (ownerLookup, error) {
+	events := make(chan informer.Event[T], 1)
+	getter, err := informer.NewInformer[T, L](lw).WatchWithCache(ctx, informer.Option{}, events)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-events:
+			}
+		}
+	}()
+	return func(name, namespace string) (runtime.Object, bool) {
+		return getter.GetWithNamespace(name, namespace)
+	}, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func TestOwnerCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientset := fake.NewSimpleClientset(&appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	})
+	cache := newOwnerCache(clientset)
+
+	ref := &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web"}
+	owner, err := cache.Get(ctx, ref, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs, ok := owner.(*appsv1.ReplicaSet); !ok || rs.Name != "web" {
+		t.Fatalf("want the replica set web, got %v", owner)
+	}
+
+	// Wait for the cache to be synced, then the owners are not got from the apiserver.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		lookup, err := cache.lookup(ctx, "apps/v1/ReplicaSet")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := lookup("web", "default"); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("want the replica set in the cache")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	clientset.ClearActions()
+	for i := 0; i < 3; i++ {
+		_, err = cache.Get(ctx, ref, "default")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "get" {
+			t.Errorf("want the owner got from the cache, got %s %s", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+
+	owner, err = cache.Get(ctx, &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "missing"}, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner != nil {
+		t.Errorf("want no owner, got %v", owner)
+	}
+
+	owner, err = cache.Get(ctx, &metav1.OwnerReference{APIVersion: "v1", Kind: "Node", Name: "node0"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner != nil {
+		t.Errorf("want no owner of the unsupported kind, got %v", owner)
+	}
+}
+
+func TestOwnerCache_GetTopLevel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	}
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-5d8f",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: format.Ptr(true)},
+			},
+		},
+	}
+	orphan := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "default"},
+	}
+	cache := newOwnerCache(fake.NewSimpleClientset(deploy, rs, orphan))
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want string
+	}{
+		{
+			name: "the deployment of the replica set",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "web-5d8f-x2k9p",
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{
+						{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-5d8f", Controller: format.Ptr(true)},
+					},
+				},
+			},
+			want: "Deployment/web",
+		},
+		{
+			name: "the replica set without deployment",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "orphan-x2k9p",
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{
+						{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "orphan", Controller: format.Ptr(true)},
+					},
+				},
+			},
+			want: "ReplicaSet/orphan",
+		},
+		{
+			name: "no controller",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod0", Namespace: "default"},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, err := cache.GetTopLevel(ctx, tt.pod, tt.pod.Namespace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got string
+			switch o := owner.(type) {
+			case *appsv1.Deployment:
+				got = "Deployment/" + o.Name
+			case *appsv1.ReplicaSet:
+				got = "ReplicaSet/" + o.Name
+			}
+			if got != tt.want {
+				t.Errorf("GetTopLevel() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// schedulingLatencyBuckets are the upper bounds in seconds of the buckets of the scheduling latency
+var schedulingLatencyBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+
+// LatencyBucket is a bucket of the distribution of a latency
+type LatencyBucket struct {
+	// Le is the upper bound in seconds of the bucket.
+	Le float64 `json:"le"`
+	// Count is the number of the observations less than or equal to the upper bound.
+	Count int64 `json:"count"`
+}
+
+// LatencyDistribution is the distribution of a latency
+type LatencyDistribution struct {
+	// Count is the number of the observations.
+	Count int64 `json:"count"`
+	// SumSeconds is the sum of the observations in seconds.
+	SumSeconds float64 `json:"sumSeconds"`
+	// Buckets is the cumulative counts of the observations, the ones above the last bucket are counted in the Count only.
+	Buckets []LatencyBucket `json:"buckets"`
+}
+
+// podStatistics records the phases of the pods on the managed nodes and the latency of their scheduling
+type podStatistics struct {
+	mut    sync.Mutex
+	phases map[log.ObjectRef]corev1.PodPhase
+	counts map[corev1.PodPhase]int64
+
+	schedulingCount   int64
+	schedulingSum     float64
+	schedulingBuckets []int64
+}
+
+var podStats = newPodStatistics()
+
+func newPodStatistics() *podStatistics {
+	return &podStatistics{
+		phases:            map[log.ObjectRef]corev1.PodPhase{},
+		counts:            map[corev1.PodPhase]int64{},
+		schedulingBuckets: make([]int64, len(schedulingLatencyBuckets)),
+	}
+}
+
+// Put records the phase of the pod, and the latency of the scheduling once the pod is seen scheduled first
+func (s *podStatistics) Put(pod *corev1.Pod) {
+	key := log.KObj(pod)
+	phase := pod.Status.Phase
+	if phase == "" {
+		phase = corev1.PodPending
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	old, ok := s.phases[key]
+	if ok {
+		if old == phase {
+			return
+		}
+		s.counts[old]--
+	} else {
+		s.observeScheduling(pod)
+	}
+	s.phases[key] = phase
+	s.counts[phase]++
+}
+
+// Delete forgets the pod
+func (s *podStatistics) Delete(pod *corev1.Pod) {
+	key := log.KObj(pod)
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	old, ok := s.phases[key]
+	if !ok {
+		return
+	}
+	delete(s.phases, key)
+	s.counts[old]--
+}
+
+// observeScheduling observes the time from the creation of the pod to it being scheduled
+func (s *podStatistics) observeScheduling(pod *corev1.Pod) {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type != corev1.PodScheduled || cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		latency := cond.LastTransitionTime.Sub(pod.CreationTimestamp.Time).Seconds()
+		if latency < 0 {
+			latency = 0
+		}
+		s.schedulingCount++
+		s.schedulingSum += latency
+		for i, le := range schedulingLatencyBuckets {
+			if latency <= le {
+				s.schedulingBuckets[i]++
+			}
+		}
+		return
+	}
+}
+
+// Phases returns the number of the pods of each phase
+func (s *podStatistics) Phases() map[corev1.PodPhase]int64 {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	out := make(map[corev1.PodPhase]int64, len(s.counts))
+	for phase, count := range s.counts {
+		if count != 0 {
+			out[phase] = count
+		}
+	}
+	return out
+}
+
+// SchedulingLatency returns the distribution of the latency of the scheduling of the pods
+func (s *podStatistics) SchedulingLatency() LatencyDistribution {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	buckets := make([]LatencyBucket, 0, len(schedulingLatencyBuckets))
+	for i, le := range schedulingLatencyBuckets {
+		buckets = append(buckets, LatencyBucket{
+			Le:    le,
+			Count: s.schedulingBuckets[i],
+		})
+	}
+	return LatencyDistribution{
+		Count:      s.schedulingCount,
+		SumSeconds: s.schedulingSum,
+		Buckets:    buckets,
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodStatistics(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	newPod := func(name string, phase corev1.PodPhase, latency time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(now),
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:               corev1.PodScheduled,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(now.Add(latency)),
+					},
+				},
+			},
+		}
+	}
+
+	stats := newPodStatistics()
+	stats.Put(newPod("pod0", "", 30*time.Millisecond))
+	stats.Put(newPod("pod1", corev1.PodPending, 2*time.Second))
+	stats.Put(newPod("pod1", corev1.PodRunning, 2*time.Second))
+	stats.Put(newPod("pod2", corev1.PodRunning, time.Hour))
+	stats.Put(newPod("pod3", corev1.PodSucceeded, 0))
+	stats.Delete(newPod("pod3", corev1.PodSucceeded, 0))
+
+	wantPhases := map[corev1.PodPhase]int64{
+		corev1.PodPending: 1,
+		corev1.PodRunning: 2,
+	}
+	if got := stats.Phases(); !reflect.DeepEqual(got, wantPhases) {
+		t.Errorf("Phases() got = %v, want %v", got, wantPhases)
+	}
+
+	latency := stats.SchedulingLatency()
+	// The latency of each pod is observed once, when it is seen first
+	if latency.Count != 4 {
+		t.Errorf("want 4 observations, got %d", latency.Count)
+	}
+	if latency.SumSeconds != 3602.03 {
+		t.Errorf("want the sum 3602.03 seconds, got %v", latency.SumSeconds)
+	}
+	wantBuckets := map[float64]int64{
+		0.01: 1,
+		0.05: 2,
+		2.5:  3,
+		600:  3,
+	}
+	for _, b := range latency.Buckets {
+		if want, ok := wantBuckets[b.Le]; ok && b.Count != want {
+			t.Errorf("bucket %v: want %d, got %d", b.Le, want, b.Count)
+		}
+	}
+}
+
+func TestNodeStatus(t *testing.T) {
+	tests := []struct {
+		status corev1.ConditionStatus
+		want   string
+	}{
+		{corev1.ConditionTrue, NodeStatusReady},
+		{corev1.ConditionFalse, NodeStatusNotReady},
+		{corev1.ConditionUnknown, NodeStatusUnknown},
+	}
+	for _, tt := range tests {
+		node := &corev1.Node{
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+					{Type: corev1.NodeReady, Status: tt.status},
+				},
+			},
+		}
+		if got := nodeStatus(node); got != tt.want {
+			t.Errorf("nodeStatus() with Ready %s got = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+	if got := nodeStatus(&corev1.Node{}); got != NodeStatusUnknown {
+		t.Errorf("nodeStatus() without Ready got = %s, want %s", got, NodeStatusUnknown)
+	}
+}
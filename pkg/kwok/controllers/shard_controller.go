@@ -0,0 +1,255 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync/atomic"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
+)
+
+const (
+	// shardLeaseNamespace is the namespace of the leases of the shards
+	shardLeaseNamespace = metav1.NamespaceSystem
+	// shardLeaseDuration is the duration of the leases of the shards, the same as the default of the leader election
+	shardLeaseDuration = 15 * time.Second
+	// shardRenewInterval is the interval to renew the leases of the shards, and to try to acquire the others
+	shardRenewInterval = 2 * time.Second
+)
+
+// ShardController splits the nodes into shards by the hash of their names,
+// and holds the leases of the shards, so the replicas of kwok manage the nodes of the shards they hold,
+// the shards of a replica gone are taken over by the others once their leases expire,
+// or immediately if the replica releases them on shutdown.
+type ShardController struct {
+	clock               clock.Clock
+	typedClient         clientset.Interface
+	holderIdentity      string
+	shards              uint32
+	maxShards           uint32
+	held                []atomic.Bool
+	onShardAcquiredFunc func(shard uint32)
+}
+
+// ShardControllerConfig is the configuration for ShardController
+type ShardControllerConfig struct {
+	Clock               clock.Clock
+	TypedClient         clientset.Interface
+	HolderIdentity      string
+	Shards              uint
+	MaxShards           uint
+	OnShardAcquiredFunc func(shard uint32)
+}
+
+// NewShardController constructs and returns a ShardController
+func NewShardController(conf ShardControllerConfig) (*ShardController, error) {
+	if conf.Shards == 0 {
+		return nil, fmt.Errorf("shards must be greater than 0")
+	}
+	if conf.HolderIdentity == "" {
+		return nil, fmt.Errorf("holder identity is required")
+	}
+	if conf.Clock == nil {
+		conf.Clock = clock.RealClock{}
+	}
+	maxShards := conf.MaxShards
+	if maxShards == 0 || maxShards > conf.Shards {
+		maxShards = conf.Shards
+	}
+
+	c := &ShardController{
+		clock:               conf.Clock,
+		typedClient:         conf.TypedClient,
+		holderIdentity:      conf.HolderIdentity,
+		shards:              uint32(conf.Shards),
+		maxShards:           uint32(maxShards),
+		held:                make([]atomic.Bool, conf.Shards),
+		onShardAcquiredFunc: conf.OnShardAcquiredFunc,
+	}
+	return c, nil
+}
+
+// Start tries to acquire the shards once, and keeps renewing and acquiring them in the background,
+// the held shards are released once the context is done.
+func (c *ShardController) Start(ctx context.Context) error {
+	c.sync(ctx)
+	go c.syncWorker(ctx)
+	return nil
+}
+
+// Owns returns whether the node is in a shard held by the ShardController
+func (c *ShardController) Owns(nodeName string) bool {
+	return c.held[shardOf(nodeName, c.shards)].Load()
+}
+
+// shardOf returns the shard of the node
+func shardOf(nodeName string, shards uint32) uint32 {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(nodeName))
+	return hash.Sum32() % shards
+}
+
+// shardLeaseName returns the name of the lease of the shard
+func shardLeaseName(shard uint32) string {
+	return fmt.Sprintf("kwok-shard-%d", shard)
+}
+
+func (c *ShardController) syncWorker(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			c.release()
+			logger.Debug("Stop shard worker")
+			return
+		case <-c.clock.After(wait.Jitter(shardRenewInterval, 0.04)):
+			c.sync(ctx)
+		}
+	}
+}
+
+// sync renews the held shards and acquires the free ones up to the max shards,
+// starting from the shard of the holder identity, so the replicas prefer the different shards.
+func (c *ShardController) sync(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	var count uint32
+	for i := range c.held {
+		if c.held[i].Load() {
+			count++
+		}
+	}
+
+	start := shardOf(c.holderIdentity, c.shards)
+	for i := uint32(0); i < c.shards; i++ {
+		shard := (start + i) % c.shards
+		held := c.held[shard].Load()
+		if !held && count >= c.maxShards {
+			continue
+		}
+
+		ok, err := c.acquireOrRenew(ctx, shard)
+		if err != nil {
+			logger.Error("Failed to acquire or renew shard", err,
+				"shard", shard,
+			)
+		}
+		switch {
+		case ok && !held:
+			count++
+			c.held[shard].Store(true)
+			logger.Info("Acquired shard",
+				"shard", shard,
+			)
+			if c.onShardAcquiredFunc != nil {
+				c.onShardAcquiredFunc(shard)
+			}
+		case !ok && held:
+			count--
+			c.held[shard].Store(false)
+			logger.Warn("Lost shard",
+				"shard", shard,
+			)
+		}
+	}
+}
+
+// acquireOrRenew returns whether the lease of the shard is held after acquiring or renewing it
+func (c *ShardController) acquireOrRenew(ctx context.Context, shard uint32) (bool, error) {
+	leases := c.typedClient.CoordinationV1().Leases(shardLeaseNamespace)
+	now := metav1.NewMicroTime(c.clock.Now())
+
+	lease, err := leases.Get(ctx, shardLeaseName(shard), metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      shardLeaseName(shard),
+				Namespace: shardLeaseNamespace,
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &c.holderIdentity,
+				LeaseDurationSeconds: format.Ptr(int32(shardLeaseDuration / time.Second)),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		if err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+
+	if !tryAcquireOrRenew(lease, c.holderIdentity, now.Time) {
+		return false, nil
+	}
+
+	if format.ElemOrDefault(lease.Spec.HolderIdentity) != c.holderIdentity {
+		lease.Spec.HolderIdentity = &c.holderIdentity
+		lease.Spec.AcquireTime = &now
+		lease.Spec.LeaseTransitions = format.Ptr(format.ElemOrDefault(lease.Spec.LeaseTransitions) + 1)
+	}
+	lease.Spec.LeaseDurationSeconds = format.Ptr(int32(shardLeaseDuration / time.Second))
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// release releases the held shards, so the other replicas take them over without waiting for the leases to expire
+func (c *ShardController) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), shardRenewInterval)
+	defer cancel()
+
+	leases := c.typedClient.CoordinationV1().Leases(shardLeaseNamespace)
+	for i := range c.held {
+		if !c.held[i].Load() {
+			continue
+		}
+		c.held[i].Store(false)
+
+		lease, err := leases.Get(ctx, shardLeaseName(uint32(i)), metav1.GetOptions{})
+		if err != nil || format.ElemOrDefault(lease.Spec.HolderIdentity) != c.holderIdentity {
+			continue
+		}
+		lease.Spec.HolderIdentity = nil
+		_, _ = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	}
+}
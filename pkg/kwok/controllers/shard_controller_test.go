@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestShardController(t *testing.T) {
+	ctx := context.Background()
+	clock := clocktesting.NewFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	clientset := fake.NewSimpleClientset()
+
+	var acquired []uint32
+	newShardController := func(id string, maxShards uint) *ShardController {
+		c, err := NewShardController(ShardControllerConfig{
+			Clock:          clock,
+			TypedClient:    clientset,
+			HolderIdentity: id,
+			Shards:         4,
+			MaxShards:      maxShards,
+			OnShardAcquiredFunc: func(shard uint32) {
+				acquired = append(acquired, shard)
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	replica0 := newShardController("replica-0", 2)
+	replica1 := newShardController("replica-1", 0)
+
+	owned := func(c *ShardController) (count int) {
+		for i := 0; i != 100; i++ {
+			if c.Owns(fmt.Sprintf("node-%d", i)) {
+				count++
+			}
+		}
+		return count
+	}
+
+	replica0.sync(ctx)
+	replica1.sync(ctx)
+	if len(acquired) != 4 {
+		t.Fatalf("acquired shards = %v, want all of them", acquired)
+	}
+	count0, count1 := owned(replica0), owned(replica1)
+	if count0 == 0 || count1 == 0 || count0+count1 != 100 {
+		t.Errorf("owned nodes = %d and %d, want the nodes split between the replicas", count0, count1)
+	}
+
+	// Renewing keeps the shards
+	clock.Step(shardRenewInterval)
+	replica0.sync(ctx)
+	replica1.sync(ctx)
+	if owned(replica0) != count0 || owned(replica1) != count1 {
+		t.Errorf("owned nodes changed after renewing")
+	}
+
+	// The shards released are taken over
+	replica0.release()
+	if owned(replica0) != 0 {
+		t.Errorf("owned nodes of the replica released = %d, want 0", owned(replica0))
+	}
+	replica1.sync(ctx)
+	if owned(replica1) != 100 {
+		t.Errorf("owned nodes = %d, want all after taking over", owned(replica1))
+	}
+}
+
+func TestShardController_Expired(t *testing.T) {
+	ctx := context.Background()
+	clock := clocktesting.NewFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	clientset := fake.NewSimpleClientset()
+
+	newShardController := func(id string) *ShardController {
+		c, err := NewShardController(ShardControllerConfig{
+			Clock:          clock,
+			TypedClient:    clientset,
+			HolderIdentity: id,
+			Shards:         1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	replica0 := newShardController("replica-0")
+	replica1 := newShardController("replica-1")
+
+	replica0.sync(ctx)
+	replica1.sync(ctx)
+	if !replica0.Owns("node-0") || replica1.Owns("node-0") {
+		t.Fatalf("want the shard held by the first replica")
+	}
+
+	// The replica holding the shard is gone without releasing it
+	clock.Step(shardLeaseDuration + time.Second)
+	replica1.sync(ctx)
+	if !replica1.Owns("node-0") {
+		t.Errorf("want the shard taken over once its lease expires")
+	}
+	replica0.sync(ctx)
+	if replica0.Owns("node-0") {
+		t.Errorf("want the shard lost by the first replica")
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// The variables of the ages of the resource in seconds, that can be referenced in the stage expressions,
+// e.g. `$deletionAge > 300` matches the resource that is terminating for more than 5 minutes.
+const (
+	creationAgeVariable       = "creationAge"
+	deletionAgeVariable       = "deletionAge"
+	lastTransitionAgeVariable = "lastTransitionAge"
+)
+
+// stageAgeResyncPeriod is the period to recheck the resource that matches no stage
+// when the stages compare the ages in a way the thresholds can not be found, e.g. `$creationAge > .spec.ttl`,
+// since the ages change without any update of the resource.
+const stageAgeResyncPeriod = 5 * time.Minute
+
+// stageAgeRecheckMargin is added to the time the threshold is crossed, so that the age is past it when rechecked.
+const stageAgeRecheckMargin = 100 * time.Millisecond
+
+// ageVariableRegexp matches the references of the ages.
+var ageVariableRegexp = regexp.MustCompile(`\$(?:` + creationAgeVariable + `|` + deletionAgeVariable + `|` + lastTransitionAgeVariable + `)\b`)
+
+// ageThresholdRegexp matches the comparisons of the ages with the numbers, e.g. `$deletionAge >= 300` or `300 < $deletionAge`.
+var ageThresholdRegexp = regexp.MustCompile(
+	`\$(` + creationAgeVariable + `|` + deletionAgeVariable + `|` + lastTransitionAgeVariable + `)\s*(?:[<>]=?|[=!]=)\s*(-?[0-9]+(?:\.[0-9]+)?)` +
+		`|(-?[0-9]+(?:\.[0-9]+)?)\s*(?:[<>]=?|[=!]=)\s*\$(` + creationAgeVariable + `|` + deletionAgeVariable + `|` + lastTransitionAgeVariable + `)\b`,
+)
+
+// ageThreshold is an age in seconds that a stage compares the age variable with.
+type ageThreshold struct {
+	variable string
+	seconds  float64
+}
+
+// ageThresholds returns the thresholds of the ages compared in the expression,
+// and whether all the ages referenced by the expression are compared with the numbers.
+func ageThresholds(src string) ([]ageThreshold, bool) {
+	var thresholds []ageThreshold
+	for _, m := range ageThresholdRegexp.FindAllStringSubmatch(src, -1) {
+		variable, value := m[1], m[2]
+		if variable == "" {
+			variable, value = m[4], m[3]
+		}
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		thresholds = append(thresholds, ageThreshold{variable: variable, seconds: seconds})
+	}
+	refs := ageVariableRegexp.FindAllStringIndex(src, -1)
+	return thresholds, len(refs) == len(thresholds)
+}
+
+// hasAgeVariables returns whether any stage references the ages of the resource.
+func hasAgeVariables(lifecycle Lifecycle) bool {
+	return lifecycle.HasVariable(creationAgeVariable) ||
+		lifecycle.HasVariable(deletionAgeVariable) ||
+		lifecycle.HasVariable(lastTransitionAgeVariable)
+}
+
+// nextAgeRecheck returns the duration after which the resource matching no stage is rechecked,
+// that is when its age crosses the next threshold of the stages, and false if no threshold is ahead.
+// The ages without a time in the resource, e.g. the deletion age of the resource not deleting, are skipped,
+// as the resource is updated when the time is set.
+func nextAgeRecheck(lifecycle Lifecycle, data interface{}, now time.Time, timeScale float64) (time.Duration, bool) {
+	if !hasAgeVariables(lifecycle) {
+		return 0, false
+	}
+	ages := ageVariables(lifecycle, data, now, timeScale)
+	if timeScale <= 0 {
+		timeScale = 1
+	}
+
+	var next time.Duration
+	found := false
+	for _, stage := range lifecycle {
+		if !stage.ageThresholdsComplete {
+			next, found = stageAgeResyncPeriod, true
+		}
+		for _, threshold := range stage.ageThresholds {
+			age, ok := ages[threshold.variable].(float64)
+			if !ok || threshold.seconds < age {
+				continue
+			}
+			wait := time.Duration((threshold.seconds-age)/timeScale*float64(time.Second)) + stageAgeRecheckMargin
+			if !found || wait < next {
+				next, found = wait, true
+			}
+		}
+	}
+	return next, found
+}
+
+// ageVariables returns the ages of the resource referenced by the stages,
+// the data is the resource in the standard JSON format,
+// the ages are multiplied by the time scale to keep in step with the scaled delays.
+func ageVariables(lifecycle Lifecycle, data interface{}, now time.Time, timeScale float64) map[string]interface{} {
+	vars := map[string]interface{}{}
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		return vars
+	}
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	if lifecycle.HasVariable(creationAgeVariable) {
+		if t, ok := parseTime(metadata["creationTimestamp"]); ok {
+			vars[creationAgeVariable] = scaleAge(now.Sub(t), timeScale)
+		}
+	}
+	if lifecycle.HasVariable(deletionAgeVariable) {
+		if t, ok := parseTime(metadata["deletionTimestamp"]); ok {
+			vars[deletionAgeVariable] = scaleAge(now.Sub(t), timeScale)
+		}
+	}
+	if lifecycle.HasVariable(lastTransitionAgeVariable) {
+		if t, ok := lastTransitionTime(obj); ok {
+			vars[lastTransitionAgeVariable] = scaleAge(now.Sub(t), timeScale)
+		}
+	}
+	return vars
+}
+
+// scaleAge returns the age in seconds multiplied by the time scale.
+func scaleAge(age time.Duration, timeScale float64) float64 {
+	if timeScale <= 0 {
+		return age.Seconds()
+	}
+	return age.Seconds() * timeScale
+}
+
+// lastTransitionTime returns the latest lastTransitionTime of the conditions in the status.
+func lastTransitionTime(obj map[string]interface{}) (time.Time, bool) {
+	status, _ := obj["status"].(map[string]interface{})
+	conditions, _ := status["conditions"].([]interface{})
+	var last time.Time
+	for _, condition := range conditions {
+		condition, _ := condition.(map[string]interface{})
+		t, ok := parseTime(condition["lastTransitionTime"])
+		if ok && t.After(last) {
+			last = t
+		}
+	}
+	return last, !last.IsZero()
+}
+
+func parseTime(v interface{}) (time.Time, bool) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
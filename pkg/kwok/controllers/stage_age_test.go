@@ -0,0 +1,303 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
+)
+
+func TestAgeVariables(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC)
+	lifecycle, err := NewLifecycle([]*internalversion.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "stuck"},
+			Spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{
+					MatchExpressions: []internalversion.SelectorRequirement{
+						{
+							Key:      "$creationAge > 0 and $lastTransitionAge > 0",
+							Operator: internalversion.SelectorOpIn,
+							Values:   []string{"true"},
+						},
+						{
+							Key:      "$deletionAge >= 300",
+							Operator: internalversion.SelectorOpIn,
+							Values:   []string{"true"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		data      map[string]interface{}
+		timeScale float64
+		want      map[string]interface{}
+		wantMatch bool
+	}{
+		{
+			name: "not deleting",
+			data: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"creationTimestamp": "2023-01-01T00:00:00Z",
+				},
+			},
+			want: map[string]interface{}{
+				creationAgeVariable: float64(600),
+			},
+		},
+		{
+			name: "deleting for 1 minute",
+			data: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"creationTimestamp": "2023-01-01T00:00:00Z",
+					"deletionTimestamp": "2023-01-01T00:09:00Z",
+				},
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"lastTransitionTime": "2023-01-01T00:01:00Z"},
+						map[string]interface{}{"lastTransitionTime": "2023-01-01T00:02:00Z"},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				creationAgeVariable:       float64(600),
+				deletionAgeVariable:       float64(60),
+				lastTransitionAgeVariable: float64(480),
+			},
+		},
+		{
+			name: "deleting for 5 minutes",
+			data: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"creationTimestamp": "2023-01-01T00:00:00Z",
+					"deletionTimestamp": "2023-01-01T00:05:00Z",
+				},
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"lastTransitionTime": "2023-01-01T00:01:00Z"},
+					},
+				},
+			},
+			want: map[string]interface{}{
+				creationAgeVariable:       float64(600),
+				deletionAgeVariable:       float64(300),
+				lastTransitionAgeVariable: float64(540),
+			},
+			wantMatch: true,
+		},
+		{
+			name: "deleting for 5 seconds scaled by 60",
+			data: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"creationTimestamp": "2023-01-01T00:09:50Z",
+					"deletionTimestamp": "2023-01-01T00:09:55Z",
+				},
+			},
+			timeScale: 60,
+			want: map[string]interface{}{
+				creationAgeVariable: float64(600),
+				deletionAgeVariable: float64(300),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ageVariables(lifecycle, tt.data, now, tt.timeScale)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ageVariables() got = %v, want %v", got, tt.want)
+			}
+
+			ctx, err := expression.WithVariables(context.Background(), got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stage, err := lifecycle.Match(ctx, nil, nil, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (stage != nil) != tt.wantMatch {
+				t.Errorf("Match() got = %v, want match %v", stage, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestAgeThresholds(t *testing.T) {
+	tests := []struct {
+		src          string
+		want         []ageThreshold
+		wantComplete bool
+	}{
+		{
+			src:          ".status.phase",
+			wantComplete: true,
+		},
+		{
+			src: "$deletionAge >= 300",
+			want: []ageThreshold{
+				{variable: deletionAgeVariable, seconds: 300},
+			},
+			wantComplete: true,
+		},
+		{
+			src: "$creationAge > 0 and 1.5 < $lastTransitionAge",
+			want: []ageThreshold{
+				{variable: creationAgeVariable, seconds: 0},
+				{variable: lastTransitionAgeVariable, seconds: 1.5},
+			},
+			wantComplete: true,
+		},
+		{
+			src: "$creationAge > .spec.ttl and $deletionAge < 60",
+			want: []ageThreshold{
+				{variable: deletionAgeVariable, seconds: 60},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			got, complete := ageThresholds(tt.src)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ageThresholds() got = %v, want %v", got, tt.want)
+			}
+			if complete != tt.wantComplete {
+				t.Errorf("ageThresholds() complete = %v, want %v", complete, tt.wantComplete)
+			}
+		})
+	}
+}
+
+func TestNextAgeRecheck(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC)
+	newLifecycle := func(keys ...string) Lifecycle {
+		exprs := []internalversion.SelectorRequirement{}
+		for _, key := range keys {
+			exprs = append(exprs, internalversion.SelectorRequirement{
+				Key:      key,
+				Operator: internalversion.SelectorOpIn,
+				Values:   []string{"true"},
+			})
+		}
+		lifecycle, err := NewLifecycle([]*internalversion.Stage{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "stage"},
+				Spec: internalversion.StageSpec{
+					Selector: &internalversion.StageSelector{
+						MatchExpressions: exprs,
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return lifecycle
+	}
+	deleting := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"creationTimestamp": "2023-01-01T00:00:00Z",
+			"deletionTimestamp": "2023-01-01T00:09:00Z",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		lifecycle Lifecycle
+		data      map[string]interface{}
+		timeScale float64
+		want      time.Duration
+		wantOK    bool
+	}{
+		{
+			name:      "without ages",
+			lifecycle: newLifecycle(".status.phase"),
+			data:      deleting,
+		},
+		{
+			name:      "threshold ahead",
+			lifecycle: newLifecycle("$deletionAge >= 300"),
+			data:      deleting,
+			want:      240*time.Second + stageAgeRecheckMargin,
+			wantOK:    true,
+		},
+		{
+			name:      "nearest threshold",
+			lifecycle: newLifecycle("$deletionAge >= 300", "$creationAge > 630"),
+			data:      deleting,
+			want:      30*time.Second + stageAgeRecheckMargin,
+			wantOK:    true,
+		},
+		{
+			name:      "threshold scaled by 60",
+			lifecycle: newLifecycle("$deletionAge >= 300"),
+			data: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"deletionTimestamp": "2023-01-01T00:09:59Z",
+				},
+			},
+			timeScale: 60,
+			want:      4*time.Second + stageAgeRecheckMargin,
+			wantOK:    true,
+		},
+		{
+			name:      "thresholds crossed",
+			lifecycle: newLifecycle("$creationAge > 300"),
+			data:      deleting,
+		},
+		{
+			name:      "not deleting",
+			lifecycle: newLifecycle("$deletionAge >= 300"),
+			data: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"creationTimestamp": "2023-01-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			name:      "threshold not found",
+			lifecycle: newLifecycle("$creationAge > .spec.ttl"),
+			data:      deleting,
+			want:      stageAgeResyncPeriod,
+			wantOK:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := nextAgeRecheck(tt.lifecycle, tt.data, now, tt.timeScale)
+			if ok != tt.wantOK {
+				t.Fatalf("nextAgeRecheck() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("nextAgeRecheck() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
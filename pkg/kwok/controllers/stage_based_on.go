@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// ResolveStages returns the stages with the fields inherited from the stages they are based on,
+// the stages that cannot be resolved are left out and returned in the error.
+func ResolveStages(stages []*internalversion.Stage) ([]*internalversion.Stage, error) {
+	byName := make(map[string]*internalversion.Stage, len(stages))
+	for _, stage := range stages {
+		byName[stage.Name] = stage
+	}
+
+	resolved := map[string]*internalversion.Stage{}
+	out := make([]*internalversion.Stage, 0, len(stages))
+	var errs []error
+	for _, stage := range stages {
+		r, err := resolveStage(stage, byName, resolved, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stage %q: %w", stage.Name, err))
+			continue
+		}
+		out = append(out, r)
+	}
+	return out, errors.Join(errs...)
+}
+
+func resolveStage(stage *internalversion.Stage, byName, resolved map[string]*internalversion.Stage, visiting []string) (*internalversion.Stage, error) {
+	if stage.Spec.BasedOn == "" {
+		return stage, nil
+	}
+	if r, ok := resolved[stage.Name]; ok {
+		return r, nil
+	}
+
+	visiting = append(visiting, stage.Name)
+	if slices.Contains(visiting, stage.Spec.BasedOn) {
+		return nil, fmt.Errorf("circular basedOn: %s -> %s", strings.Join(visiting, " -> "), stage.Spec.BasedOn)
+	}
+	base, ok := byName[stage.Spec.BasedOn]
+	if !ok {
+		return nil, fmt.Errorf("base stage %q not found", stage.Spec.BasedOn)
+	}
+	base, err := resolveStage(base, byName, resolved, visiting)
+	if err != nil {
+		return nil, err
+	}
+
+	r := stage.DeepCopy()
+	r.Spec = mergeStageSpec(base.Spec, stage.Spec)
+	resolved[stage.Name] = r
+	return r, nil
+}
+
+// mergeStageSpec returns the spec with the fields not set inherited from the base.
+func mergeStageSpec(base, spec internalversion.StageSpec) internalversion.StageSpec {
+	out := *base.DeepCopy()
+	spec = *spec.DeepCopy()
+
+	out.ResourceRef = spec.ResourceRef
+	out.BasedOn = spec.BasedOn
+	out.Selector = mergeStageSelector(out.Selector, spec.Selector)
+	if spec.Weight != 0 {
+		out.Weight = spec.Weight
+	}
+	if spec.WeightFrom != nil {
+		out.WeightFrom = spec.WeightFrom
+	}
+	if spec.Delay != nil {
+		out.Delay = spec.Delay
+	}
+	if spec.ImmediateNextStage {
+		out.ImmediateNextStage = true
+	}
+
+	next := spec.Next
+	if next.Event != nil {
+		out.Next.Event = next.Event
+	}
+	if next.Events != nil {
+		out.Next.Events = next.Events
+	}
+	if next.Finalizers != nil {
+		out.Next.Finalizers = next.Finalizers
+	}
+	if next.Delete {
+		out.Next.Delete = true
+	}
+	if next.StatusTemplate != "" {
+		out.Next.StatusTemplate = next.StatusTemplate
+	}
+	if next.Webhook != nil {
+		out.Next.Webhook = next.Webhook
+	}
+	if next.Patches != nil {
+		out.Next.Patches = next.Patches
+	}
+	if next.Subresources != nil {
+		out.Next.Subresources = next.Subresources
+	}
+	return out
+}
+
+// mergeStageSelector merges the labels and annotations of the selectors,
+// and the expressions of the selector replace the ones of the base with the same key.
+func mergeStageSelector(base, selector *internalversion.StageSelector) *internalversion.StageSelector {
+	if base == nil {
+		return selector
+	}
+	if selector == nil {
+		return base
+	}
+
+	if selector.MatchLabels != nil {
+		if base.MatchLabels == nil {
+			base.MatchLabels = map[string]string{}
+		}
+		for k, v := range selector.MatchLabels {
+			base.MatchLabels[k] = v
+		}
+	}
+	if selector.MatchAnnotations != nil {
+		if base.MatchAnnotations == nil {
+			base.MatchAnnotations = map[string]string{}
+		}
+		for k, v := range selector.MatchAnnotations {
+			base.MatchAnnotations[k] = v
+		}
+	}
+	for _, expression := range selector.MatchExpressions {
+		replaced := false
+		for i, e := range base.MatchExpressions {
+			if e.Key == expression.Key {
+				base.MatchExpressions[i] = expression
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			base.MatchExpressions = append(base.MatchExpressions, expression)
+		}
+	}
+	return base
+}
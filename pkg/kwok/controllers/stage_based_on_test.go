@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func TestResolveStages(t *testing.T) {
+	podRef := internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"}
+	base := &internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-ready"},
+		Spec: internalversion.StageSpec{
+			ResourceRef: podRef,
+			Selector: &internalversion.StageSelector{
+				MatchLabels: map[string]string{"app": "web"},
+				MatchExpressions: []internalversion.SelectorRequirement{
+					{Key: ".status.phase", Operator: internalversion.SelectorOpIn, Values: []string{"Pending"}},
+					{Key: ".metadata.deletionTimestamp", Operator: internalversion.SelectorOpDoesNotExist},
+				},
+			},
+			Weight: 1,
+			Delay: &internalversion.StageDelay{
+				DurationMilliseconds: format.Ptr[int64](1000),
+			},
+			Next: internalversion.StageNext{
+				StatusTemplate: "phase: Running",
+				Event:          &internalversion.StageEvent{Type: "Normal", Reason: "Started"},
+			},
+		},
+	}
+	slow := &internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-ready-slow"},
+		Spec: internalversion.StageSpec{
+			ResourceRef: podRef,
+			BasedOn:     "pod-ready",
+			Selector: &internalversion.StageSelector{
+				MatchLabels: map[string]string{"speed": "slow"},
+				MatchExpressions: []internalversion.SelectorRequirement{
+					{Key: ".status.phase", Operator: internalversion.SelectorOpIn, Values: []string{"Pending", "Unknown"}},
+				},
+			},
+			Delay: &internalversion.StageDelay{
+				DurationMilliseconds: format.Ptr[int64](60000),
+			},
+		},
+	}
+	slower := &internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-ready-slower"},
+		Spec: internalversion.StageSpec{
+			ResourceRef: podRef,
+			BasedOn:     "pod-ready-slow",
+			Weight:      2,
+		},
+	}
+	missing := &internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: "missing"},
+		Spec: internalversion.StageSpec{
+			ResourceRef: podRef,
+			BasedOn:     "not-found",
+		},
+	}
+	loopA := &internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: "loop-a"},
+		Spec: internalversion.StageSpec{
+			ResourceRef: podRef,
+			BasedOn:     "loop-b",
+		},
+	}
+	loopB := &internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: "loop-b"},
+		Spec: internalversion.StageSpec{
+			ResourceRef: podRef,
+			BasedOn:     "loop-a",
+		},
+	}
+
+	got, err := ResolveStages([]*internalversion.Stage{slower, slow, base, missing, loopA, loopB})
+	if err == nil {
+		t.Fatal("want error for the unresolved stages")
+	}
+	if len(got) != 3 {
+		t.Fatalf("want 3 resolved stages, got %d", len(got))
+	}
+
+	wantSelector := &internalversion.StageSelector{
+		MatchLabels: map[string]string{"app": "web", "speed": "slow"},
+		MatchExpressions: []internalversion.SelectorRequirement{
+			{Key: ".status.phase", Operator: internalversion.SelectorOpIn, Values: []string{"Pending", "Unknown"}},
+			{Key: ".metadata.deletionTimestamp", Operator: internalversion.SelectorOpDoesNotExist},
+		},
+	}
+	for _, stage := range got[:2] {
+		if !reflect.DeepEqual(stage.Spec.Selector, wantSelector) {
+			t.Errorf("stage %q: want selector %v, got %v", stage.Name, wantSelector, stage.Spec.Selector)
+		}
+		if *stage.Spec.Delay.DurationMilliseconds != 60000 {
+			t.Errorf("stage %q: want delay 60000, got %d", stage.Name, *stage.Spec.Delay.DurationMilliseconds)
+		}
+		if stage.Spec.Next.StatusTemplate != "phase: Running" || stage.Spec.Next.Event == nil {
+			t.Errorf("stage %q: want next inherited, got %v", stage.Name, stage.Spec.Next)
+		}
+	}
+	if got[0].Spec.Weight != 2 || got[1].Spec.Weight != 1 {
+		t.Errorf("want weights 2 and 1, got %d and %d", got[0].Spec.Weight, got[1].Spec.Weight)
+	}
+	if got[2] != base {
+		t.Errorf("want the base stage unchanged")
+	}
+	if len(base.Spec.Selector.MatchLabels) != 1 || base.Spec.Selector.MatchExpressions[0].Values[0] != "Pending" {
+		t.Errorf("want the base stage not modified, got %v", base.Spec.Selector)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+)
+
+// stageChains limits the depth of the stages played one after another by immediateNextStage,
+// so that the stages forming a loop will not spin the controller.
+type stageChains struct {
+	maxDepth uint
+	recorder record.EventRecorder
+	chains   maps.SyncMap[string, stageChain]
+}
+
+type stageChain struct {
+	// from is the resource version of the resource that the last stage is played on
+	from string
+	// to is the resource version of the resource after the last stage is played
+	to     string
+	stages []string
+}
+
+func newStageChains(maxDepth uint, recorder record.EventRecorder) *stageChains {
+	return &stageChains{
+		maxDepth: maxDepth,
+		recorder: recorder,
+	}
+}
+
+func stageChainKey(ref *corev1.ObjectReference) string {
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
+
+// Next records that the stage is played on the resource of the resourceVersion and results in the nextResourceVersion,
+// and returns whether the next stage can be played immediately.
+// If the chain exceeds the max depth, it is broken with an event on the resource.
+func (s *stageChains) Next(ctx context.Context, ref *corev1.ObjectReference, resourceVersion string, stage string, nextResourceVersion string) bool {
+	if s == nil || s.maxDepth == 0 {
+		return true
+	}
+
+	key := stageChainKey(ref)
+	chain, ok := s.chains.Load(key)
+	switch {
+	case ok && chain.from == resourceVersion:
+		// the same stage has already been recorded, e.g. both finalizers and status are modified
+	case ok && chain.to == resourceVersion:
+		chain.from = resourceVersion
+		chain.stages = append(chain.stages, stage)
+	default:
+		chain = stageChain{
+			from:   resourceVersion,
+			stages: []string{stage},
+		}
+	}
+	chain.to = nextResourceVersion
+
+	if uint(len(chain.stages)) <= s.maxDepth {
+		s.chains.Store(key, chain)
+		return true
+	}
+	s.chains.Delete(key)
+
+	stageChainBrokenTotal.WithLabelValues(ref.Kind).Inc()
+
+	message := fmt.Sprintf("Stop playing the next stage immediately, the chain exceeds the max depth %d: %s", s.maxDepth, strings.Join(chain.stages, " -> "))
+	logger := log.FromContext(ctx)
+	logger.Warn("Break the chain of stages",
+		"kind", ref.Kind,
+		"obj", log.KRef(ref.Namespace, ref.Name),
+		"stages", chain.stages,
+	)
+	if s.recorder != nil {
+		s.recorder.Event(ref, corev1.EventTypeWarning, "StageChainBroken", message)
+	}
+	return false
+}
+
+// Delete forgets the chain of the resource
+func (s *stageChains) Delete(ref *corev1.ObjectReference) {
+	if s == nil {
+		return
+	}
+	s.chains.Delete(stageChainKey(ref))
+}
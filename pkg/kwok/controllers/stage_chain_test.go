@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestStageChains(t *testing.T) {
+	type play struct {
+		stage string
+		from  string
+		to    string
+		want  bool
+	}
+	tests := []struct {
+		name       string
+		maxDepth   uint
+		plays      []play
+		wantEvents int
+	}{
+		{
+			name:     "no limit",
+			maxDepth: 0,
+			plays: []play{
+				{stage: "a", from: "1", to: "2", want: true},
+				{stage: "a", from: "2", to: "3", want: true},
+				{stage: "a", from: "3", to: "4", want: true},
+			},
+		},
+		{
+			name:     "loop broken",
+			maxDepth: 2,
+			plays: []play{
+				{stage: "a", from: "1", to: "2", want: true},
+				{stage: "b", from: "2", to: "3", want: true},
+				{stage: "a", from: "3", to: "4", want: false},
+			},
+			wantEvents: 1,
+		},
+		{
+			name:     "same stage modifies finalizers and status",
+			maxDepth: 2,
+			plays: []play{
+				{stage: "a", from: "1", to: "2", want: true},
+				{stage: "a", from: "1", to: "3", want: true},
+				{stage: "b", from: "3", to: "4", want: true},
+			},
+		},
+		{
+			name:     "new chain",
+			maxDepth: 2,
+			plays: []play{
+				{stage: "a", from: "1", to: "2", want: true},
+				{stage: "b", from: "2", to: "3", want: true},
+				{stage: "a", from: "5", to: "6", want: true},
+				{stage: "b", from: "6", to: "7", want: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			chains := newStageChains(tt.maxDepth, recorder)
+			ref := &corev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      "pod0",
+				Namespace: "default",
+			}
+			for i, p := range tt.plays {
+				got := chains.Next(context.Background(), ref, p.from, p.stage, p.to)
+				if got != p.want {
+					t.Fatalf("play %d: want %v, got %v", i, p.want, got)
+				}
+			}
+			if len(recorder.Events) != tt.wantEvents {
+				t.Fatalf("want %d events, got %d", tt.wantEvents, len(recorder.Events))
+			}
+			if tt.wantEvents != 0 {
+				event := <-recorder.Events
+				if !strings.Contains(event, "StageChainBroken") || !strings.Contains(event, "a -> b -> a") {
+					t.Errorf("unexpected event %q", event)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,626 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+	"sigs.k8s.io/kwok/pkg/utils/informer"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+	"sigs.k8s.io/kwok/pkg/utils/queue"
+)
+
+// StageController is a fake resources implementation that can be used to test
+// the lifecycle of any resource, driven by the stages of the resource.
+type StageController struct {
+	clock                      clock.Clock
+	dynamicClient              dynamic.Interface
+	gvr                        schema.GroupVersionResource
+	gvk                        schema.GroupVersionKind
+	renderer                   gotpl.Renderer
+	preprocessChan             chan *unstructured.Unstructured
+	playStageParallelism       uint
+	lifecycle                  resources.Getter[Lifecycle]
+	delayQueue                 queue.DelayingQueue[resourceStageJob[*unstructured.Unstructured]]
+	delayQueueMapping          maps.SyncMap[string, resourceStageJob[*unstructured.Unstructured]]
+	stageChains                *stageChains
+	timeScale                  float64
+	enableStageNextAnnotations bool
+	recorder                   record.EventRecorder
+}
+
+// StageControllerConfig is the configuration for the StageController
+type StageControllerConfig struct {
+	Clock                      clock.Clock
+	DynamicClient              dynamic.Interface
+	GVR                        schema.GroupVersionResource
+	GVK                        schema.GroupVersionKind
+	Lifecycle                  resources.Getter[Lifecycle]
+	PlayStageParallelism       uint
+	StageMaxChainDepth         uint
+	TimeScale                  float64
+	EnableStageNextAnnotations bool
+	FuncMap                    gotpl.FuncMap
+	Recorder                   record.EventRecorder
+}
+
+// NewStageController creates a new fake resources controller
+func NewStageController(conf StageControllerConfig) (*StageController, error) {
+	if conf.PlayStageParallelism <= 0 {
+		return nil, fmt.Errorf("playStageParallelism must be greater than 0")
+	}
+
+	if conf.Clock == nil {
+		conf.Clock = clock.RealClock{}
+	}
+
+	c := &StageController{
+		clock:                      conf.Clock,
+		dynamicClient:              conf.DynamicClient,
+		gvr:                        conf.GVR,
+		gvk:                        conf.GVK,
+		renderer:                   gotpl.NewRenderer(conf.FuncMap),
+		delayQueue:                 queue.NewDelayingQueue[resourceStageJob[*unstructured.Unstructured]](conf.Clock),
+		lifecycle:                  conf.Lifecycle,
+		playStageParallelism:       conf.PlayStageParallelism,
+		preprocessChan:             make(chan *unstructured.Unstructured),
+		stageChains:                newStageChains(conf.StageMaxChainDepth, conf.Recorder),
+		timeScale:                  conf.TimeScale,
+		enableStageNextAnnotations: conf.EnableStageNextAnnotations,
+		recorder:                   conf.Recorder,
+	}
+	return c, nil
+}
+
+// Start starts the fake resources controller
+// It will modify the resources status to we want
+func (c *StageController) Start(ctx context.Context, events <-chan informer.Event[*unstructured.Unstructured]) error {
+	stageDelayQueueLength.Register(c.gvk.Kind, c.delayQueueMapping.Size)
+
+	go c.preprocessWorker(ctx)
+	for i := uint(0); i < c.playStageParallelism; i++ {
+		go c.playStageWorker(ctx)
+	}
+	go c.watchResources(ctx, events)
+	return nil
+}
+
+func (c *StageController) resource(namespace string) dynamic.ResourceInterface {
+	if namespace == "" {
+		return c.dynamicClient.Resource(c.gvr)
+	}
+	return c.dynamicClient.Resource(c.gvr).Namespace(namespace)
+}
+
+// finalizersModify modify the finalizers of the resource
+func (c *StageController) finalizersModify(ctx context.Context, obj *unstructured.Unstructured, finalizers *internalversion.StageFinalizers) (*unstructured.Unstructured, error) {
+	ops := finalizersModify(obj.GetFinalizers(), finalizers)
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"resource", c.gvr.String(),
+		"obj", log.KObj(obj),
+	)
+
+	result, err := c.resource(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warn("Patch resource finalizers",
+				"err", err,
+			)
+			return nil, nil
+		}
+		return nil, err
+	}
+	logger.Info("Patch resource finalizers")
+	return result, nil
+}
+
+// annotateStageNext annotates the resource with the next stage planned for it,
+// the patched resource is returned, or the resource itself if it is not patched.
+func (c *StageController) annotateStageNext(ctx context.Context, obj *unstructured.Unstructured, stage string, at time.Time) *unstructured.Unstructured {
+	patch, err := stageNextPatch(obj.GetAnnotations(), stage, at)
+	if err != nil || patch == nil {
+		return obj
+	}
+	result, err := c.resource(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Error("Failed to annotate the next stage", err,
+			"resource", c.gvr.String(),
+			"obj", log.KObj(obj),
+		)
+		return obj
+	}
+	return result
+}
+
+// deleteResource deletes a resource
+func (c *StageController) deleteResource(ctx context.Context, obj *unstructured.Unstructured) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"resource", c.gvr.String(),
+		"obj", log.KObj(obj),
+	)
+
+	err := c.resource(obj.GetNamespace()).Delete(ctx, obj.GetName(), deleteOpt)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warn("Delete resource",
+				"err", err,
+			)
+			return nil
+		}
+		return err
+	}
+
+	logger.Info("Delete resource")
+	return nil
+}
+
+// preprocessWorker receives the resource from the preprocessChan and preprocess it
+func (c *StageController) preprocessWorker(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Debug("Stop preprocess worker")
+			return
+		case obj := <-c.preprocessChan:
+			err := c.preprocess(ctx, obj)
+			if err != nil {
+				logger.Error("Failed to preprocess resource", err,
+					"resource", c.gvr.String(),
+					"obj", log.KObj(obj),
+				)
+			}
+		}
+	}
+}
+
+// preprocess the resource and send it to the playStageWorker
+func (c *StageController) preprocess(ctx context.Context, obj *unstructured.Unstructured) error {
+	key := log.KObj(obj).String()
+
+	resourceJob, ok := c.delayQueueMapping.Load(key)
+	if ok && resourceJob.Resource.GetResourceVersion() == obj.GetResourceVersion() {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"resource", c.gvr.String(),
+		"obj", key,
+	)
+
+	if stagePaused(obj.GetAnnotations()) {
+		if ok {
+			c.delayQueueMapping.Delete(key)
+			c.delayQueue.Cancel(resourceJob)
+		}
+		logger.Debug("Skip resource",
+			"reason", "stages paused",
+		)
+		return nil
+	}
+
+	if ok && resourceJob.Step != 0 {
+		logger.Debug("Skip resource",
+			"reason", "patches of the stage are being applied",
+		)
+		return nil
+	}
+
+	data, err := expression.ToJSONStandard(obj.Object)
+	if err != nil {
+		return err
+	}
+
+	lifecycle := c.lifecycle.Get()
+	now := c.clock.Now()
+	ctx, err = expression.WithVariables(ctx, ageVariables(lifecycle, data, now, c.timeScale))
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	stage, err := lifecycle.Match(ctx, obj.GetLabels(), obj.GetAnnotations(), data)
+	stageEvaluationDurationSeconds.WithLabelValues(c.gvk.Kind).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return fmt.Errorf("stage match: %w", err)
+	}
+	if stage == nil {
+		if c.enableStageNextAnnotations {
+			obj = c.annotateStageNext(ctx, obj, "", time.Time{})
+		}
+		if wait, ok := nextAgeRecheck(lifecycle, data, now, c.timeScale); ok {
+			// The ages of the resource change without any update, so recheck it once the next threshold is crossed.
+			item := resourceStageJob[*unstructured.Unstructured]{
+				Resource: obj,
+				Key:      key,
+			}
+			if c.delayQueue.AddAfter(item, wait) {
+				c.delayQueueMapping.Store(key, item)
+			}
+		}
+		logger.Debug("Skip resource",
+			"reason", "not match any stages",
+		)
+		return nil
+	}
+	stageMatchTotal.WithLabelValues(c.gvk.Kind, stage.Name()).Inc()
+
+	delay := stageDelay(ctx, stage, data, now, c.timeScale)
+	if c.enableStageNextAnnotations {
+		// The stage played at once is not planned, so the annotations of the previous stage are removed.
+		next, at := "", time.Time{}
+		if delay != 0 {
+			next, at = stage.Name(), now.Add(delay)
+		}
+		obj = c.annotateStageNext(ctx, obj, next, at)
+	}
+	stageStats.Matched(c.gvk.Kind, stage.Name(), delay)
+
+	if delay != 0 {
+		stageName := stage.Name()
+		logger.Debug("Delayed play stage",
+			"delay", delay,
+			"stage", stageName,
+		)
+	}
+
+	item := resourceStageJob[*unstructured.Unstructured]{
+		Resource: obj,
+		Stage:    stage,
+		Key:      key,
+	}
+	ok = c.delayQueue.AddAfter(item, delay)
+	if !ok {
+		logger.Debug("Skip resource",
+			"reason", "delayed",
+		)
+	} else {
+		c.delayQueueMapping.Store(key, item)
+	}
+
+	return nil
+}
+
+// playStageWorker receives the resource from the playStageChan and play the stage
+func (c *StageController) playStageWorker(ctx context.Context) {
+	for ctx.Err() == nil {
+		obj := c.delayQueue.GetOrWait()
+		if obj.Stage == nil {
+			// Recheck the resource against the stages, unless it has been updated since.
+			if job, ok := c.delayQueueMapping.Load(obj.Key); ok && job == obj {
+				c.delayQueueMapping.Delete(obj.Key)
+				c.preprocessChan <- obj.Resource
+			}
+			continue
+		}
+		c.delayQueueMapping.Delete(obj.Key)
+		if obj.Step != 0 {
+			c.playStagePatch(ctx, obj.Resource, obj.Stage, obj.Step)
+		} else {
+			c.playStage(ctx, obj.Resource, obj.Stage)
+		}
+	}
+}
+
+// playStage plays the stage
+func (c *StageController) playStage(ctx context.Context, obj *unstructured.Unstructured, stage *LifecycleStage) {
+	next := stage.Next()
+	stageStats.Played(c.gvk.Kind, stage.Name(), c.clock.Now())
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"resource", c.gvr.String(),
+		"obj", log.KObj(obj),
+		"stage", stage.Name(),
+	)
+
+	ref := c.objectReference(obj)
+	recordStageEvents(ctx, c.recorder, c.renderer, ref, next, obj.Object)
+	var status []byte
+	if next.Webhook != nil {
+		var err error
+		status, err = callStageWebhook(ctx, stage.Name(), next.Webhook, obj.Object)
+		if err != nil {
+			logger.Error("Failed to call webhook", err)
+			stagePatchFailedTotal.WithLabelValues(c.gvk.Kind, stage.Name()).Inc()
+			return
+		}
+	}
+	// The patches are applied after the next, and the immediate next stage is matched after the last patch.
+	patches := !next.Delete && len(next.Patches) != 0
+	latest := obj
+	if next.Finalizers != nil {
+		result, err := c.finalizersModify(ctx, obj, next.Finalizers)
+		if err != nil {
+			logger.Error("Failed to finalizers", err)
+			stagePatchFailedTotal.WithLabelValues(c.gvk.Kind, stage.Name()).Inc()
+		}
+		if result != nil {
+			latest = result
+			if !patches && stage.ImmediateNextStage() &&
+				c.stageChains.Next(ctx, ref, obj.GetResourceVersion(), stage.Name(), result.GetResourceVersion()) {
+				c.preprocessChan <- result
+			}
+		}
+	}
+	if !next.Delete {
+		for _, subresource := range next.Subresources {
+			err := c.patchSubresource(ctx, obj, subresource)
+			if err != nil {
+				logger.Error("Failed to patch subresource", err,
+					"subresource", subresource.Name,
+				)
+				stagePatchFailedTotal.WithLabelValues(c.gvk.Kind, stage.Name()).Inc()
+			}
+		}
+	}
+	if next.Delete {
+		err := c.deleteResource(ctx, obj)
+		if err != nil {
+			logger.Error("Failed to delete resource", err)
+			stagePatchFailedTotal.WithLabelValues(c.gvk.Kind, stage.Name()).Inc()
+		}
+	} else if next.StatusTemplate != "" || status != nil {
+		patch, err := c.computePatch(obj, next.StatusTemplate, status)
+		if err != nil {
+			logger.Error("Failed to configure resource", err)
+			stagePatchFailedTotal.WithLabelValues(c.gvk.Kind, stage.Name()).Inc()
+			return
+		}
+		if patch == nil {
+			logger.Debug("Skip resource",
+				"reason", "do not need to modify",
+			)
+		} else {
+			result, err := c.patchResource(ctx, obj, patch)
+			if err != nil {
+				logger.Error("Failed to patch resource", err)
+				stagePatchFailedTotal.WithLabelValues(c.gvk.Kind, stage.Name()).Inc()
+			}
+			if result != nil {
+				latest = result
+				if !patches && stage.ImmediateNextStage() &&
+					c.stageChains.Next(ctx, ref, obj.GetResourceVersion(), stage.Name(), result.GetResourceVersion()) {
+					c.preprocessChan <- result
+				}
+			}
+		}
+	}
+	if patches {
+		c.addStagePatch(latest, stage, 1)
+	}
+}
+
+// playStagePatch applies the patch of the stage, and schedules the next patch
+func (c *StageController) playStagePatch(ctx context.Context, obj *unstructured.Unstructured, stage *LifecycleStage, step int) {
+	patches := stage.Next().Patches
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"resource", c.gvr.String(),
+		"obj", log.KObj(obj),
+		"stage", stage.Name(),
+		"step", step,
+	)
+
+	patch, err := c.computePatch(obj, patches[step-1].StatusTemplate, nil)
+	if err != nil {
+		logger.Error("Failed to configure resource", err)
+		stagePatchFailedTotal.WithLabelValues(c.gvk.Kind, stage.Name()).Inc()
+		return
+	}
+	latest := obj
+	if patch == nil {
+		logger.Debug("Skip resource",
+			"reason", "do not need to modify",
+		)
+	} else {
+		result, err := c.patchResource(ctx, obj, patch)
+		if err != nil {
+			logger.Error("Failed to patch resource", err)
+			stagePatchFailedTotal.WithLabelValues(c.gvk.Kind, stage.Name()).Inc()
+			return
+		}
+		if result == nil {
+			return
+		}
+		latest = result
+	}
+
+	if step < len(patches) {
+		c.addStagePatch(latest, stage, step+1)
+		return
+	}
+
+	ref := c.objectReference(obj)
+	if latest != obj && stage.ImmediateNextStage() &&
+		c.stageChains.Next(ctx, ref, obj.GetResourceVersion(), stage.Name(), latest.GetResourceVersion()) {
+		c.preprocessChan <- latest
+	}
+}
+
+// addStagePatch schedules the patch of the stage to be applied after its delay
+func (c *StageController) addStagePatch(obj *unstructured.Unstructured, stage *LifecycleStage, step int) {
+	item := resourceStageJob[*unstructured.Unstructured]{
+		Resource: obj,
+		Stage:    stage,
+		Key:      log.KObj(obj).String(),
+		Step:     step,
+	}
+	if c.delayQueue.AddAfter(item, scaleDuration(stagePatchDelay(stage, step), c.timeScale)) {
+		c.delayQueueMapping.Store(item.Key, item)
+	}
+}
+
+// objectReference returns the reference of the resource for the events
+func (c *StageController) objectReference(obj *unstructured.Unstructured) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: c.gvk.GroupVersion().String(),
+		Kind:       c.gvk.Kind,
+		UID:        obj.GetUID(),
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+	}
+}
+
+// patchResource patches the status of the resource
+func (c *StageController) patchResource(ctx context.Context, obj *unstructured.Unstructured, patch []byte) (*unstructured.Unstructured, error) {
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"resource", c.gvr.String(),
+		"obj", log.KObj(obj),
+	)
+
+	result, err := c.resource(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warn("Patch resource",
+				"err", err,
+			)
+			return nil, nil
+		}
+		return nil, err
+	}
+	logger.Info("Patch resource")
+	return result, nil
+}
+
+// patchSubresource patches the subresource of the resource with a merge patch
+func (c *StageController) patchSubresource(ctx context.Context, obj *unstructured.Unstructured, subresource internalversion.StageSubresource) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"resource", c.gvr.String(),
+		"obj", log.KObj(obj),
+		"subresource", subresource.Name,
+	)
+
+	data, err := c.renderer.ToJSON(subresource.Template, obj.Object)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.resource(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.MergePatchType, data, metav1.PatchOptions{}, subresource.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warn("Patch subresource",
+				"err", err,
+			)
+			return nil
+		}
+		return err
+	}
+	logger.Info("Patch subresource")
+	return nil
+}
+
+// computePatch renders the template and returns the merge patch of the status,
+// or nil if the status does not need to be modified.
+// The response is used as the patch instead of the template if it is not nil.
+func (c *StageController) computePatch(obj *unstructured.Unstructured, tpl string, response []byte) ([]byte, error) {
+	patch := response
+	if patch == nil {
+		var err error
+		patch, err = c.renderer.ToJSON(tpl, obj.Object)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	status, ok := obj.Object["status"]
+	if !ok || status == nil {
+		status = map[string]interface{}{}
+	}
+	original, err := json.Marshal(status)
+	if err != nil {
+		return nil, err
+	}
+
+	sum, err := jsonpatch.MergePatch(original, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	if jsonpatch.Equal(original, sum) {
+		return nil, nil
+	}
+
+	return json.Marshal(map[string]json.RawMessage{
+		"status": bytes.TrimSpace(patch),
+	})
+}
+
+// watchResources watch resources and send to preprocessChan
+func (c *StageController) watchResources(ctx context.Context, events <-chan informer.Event[*unstructured.Unstructured]) {
+	logger := log.FromContext(ctx)
+loop:
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				break loop
+			}
+
+			switch event.Type {
+			case informer.Added, informer.Modified, informer.Sync:
+				c.preprocessChan <- event.Object.DeepCopy()
+			case informer.Deleted:
+				// Cancel delay job
+				key := log.KObj(event.Object).String()
+				resourceJob, ok := c.delayQueueMapping.LoadAndDelete(key)
+				if ok {
+					c.delayQueue.Cancel(resourceJob)
+				}
+
+				c.stageChains.Delete(c.objectReference(event.Object))
+			}
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	logger.Info("Stop watch resources",
+		"resource", c.gvr.String(),
+	)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/informer"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
+)
+
+func TestStageController(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshot"}
+	gvr := schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace("default")
+	obj.SetName("snapshot0")
+
+	scheme := runtime.NewScheme()
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		gvr: "VolumeSnapshotList",
+	}, obj)
+
+	lifecycle, err := NewLifecycle([]*internalversion.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "snapshot-ready"},
+			Spec: internalversion.StageSpec{
+				ResourceRef: internalversion.StageResourceRef{
+					APIGroup: gvk.GroupVersion().String(),
+					Kind:     gvk.Kind,
+				},
+				Selector: &internalversion.StageSelector{
+					MatchExpressions: []internalversion.SelectorRequirement{
+						{
+							Key:      ".status.readyToUse",
+							Operator: internalversion.SelectorOpDoesNotExist,
+						},
+					},
+				},
+				Next: internalversion.StageNext{
+					StatusTemplate: "readyToUse: false",
+					Patches: []internalversion.StagePatch{
+						{
+							DelayMilliseconds: format.Ptr[int64](100),
+							StatusTemplate:    "restoreSize: 1Gi",
+						},
+						{
+							DelayMilliseconds: format.Ptr[int64](100),
+							StatusTemplate:    "readyToUse: true",
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctr, err := NewStageController(StageControllerConfig{
+		DynamicClient:        client,
+		GVR:                  gvr,
+		GVK:                  gvk,
+		Lifecycle:            resources.NewStaticGetter(lifecycle),
+		FuncMap:              defaultFuncMap,
+		PlayStageParallelism: 1,
+	})
+	if err != nil {
+		t.Fatal(fmt.Errorf("new stage controller error: %w", err))
+	}
+
+	ctx := context.Background()
+	ctx = log.NewContext(ctx, log.NewLogger(os.Stderr, log.LevelDebug))
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	t.Cleanup(func() {
+		cancel()
+		time.Sleep(time.Second)
+	})
+
+	events := make(chan informer.Event[*unstructured.Unstructured], 1)
+	resourceInformer := informer.NewInformer[*unstructured.Unstructured, *unstructured.UnstructuredList](client.Resource(gvr))
+	err = resourceInformer.Watch(ctx, informer.Option{}, events)
+	if err != nil {
+		t.Fatal(fmt.Errorf("failed to watch: %w", err))
+	}
+
+	err = ctr.Start(ctx, events)
+	if err != nil {
+		t.Fatal(fmt.Errorf("failed to start stage controller: %w", err))
+	}
+
+	err = wait.Poll(ctx, func(ctx context.Context) (done bool, err error) {
+		got, err := client.Resource(gvr).Namespace("default").Get(ctx, "snapshot0", metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		ready, _, _ := unstructured.NestedBool(got.Object, "status", "readyToUse")
+		size, _, _ := unstructured.NestedString(got.Object, "status", "restoreSize")
+		return ready && size == "1Gi", nil
+	}, wait.WithContinueOnError(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStageController_PatchSubresource(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace("default")
+	obj.SetName("deploy0")
+	_ = unstructured.SetNestedField(obj.Object, int64(1), "spec", "replicas")
+
+	scheme := runtime.NewScheme()
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		gvr: "DeploymentList",
+	}, obj)
+
+	lifecycle, err := NewLifecycle(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctr, err := NewStageController(StageControllerConfig{
+		DynamicClient:        client,
+		GVR:                  gvr,
+		GVK:                  gvk,
+		Lifecycle:            resources.NewStaticGetter(lifecycle),
+		FuncMap:              defaultFuncMap,
+		PlayStageParallelism: 1,
+	})
+	if err != nil {
+		t.Fatal(fmt.Errorf("new stage controller error: %w", err))
+	}
+
+	ctx := context.Background()
+	err = ctr.patchSubresource(ctx, obj, internalversion.StageSubresource{
+		Name:     "scale",
+		Template: "spec:\n  replicas: {{ add (toString .spec.replicas) 2 }}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.Resource(gvr).Namespace("default").Get(ctx, "deploy0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	replicas, _, _ := unstructured.NestedInt64(got.Object, "spec", "replicas")
+	if replicas != 3 {
+		t.Errorf("want replicas 3, got %d", replicas)
+	}
+}
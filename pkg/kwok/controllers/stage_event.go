@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+// stageEvents returns the events to be sent when the stage is played, with the messages rendered with the resource
+func stageEvents(ctx context.Context, renderer gotpl.Renderer, next *internalversion.StageNext, obj interface{}) []internalversion.StageEvent {
+	events := make([]internalversion.StageEvent, 0, len(next.Events)+1)
+	if next.Event != nil {
+		events = append(events, *next.Event)
+	}
+	events = append(events, next.Events...)
+
+	for i, event := range events {
+		if !strings.Contains(event.Message, "{{") {
+			continue
+		}
+		message, err := renderer.ToText(event.Message, obj)
+		if err != nil {
+			logger := log.FromContext(ctx)
+			logger.Error("Failed to render event message", err,
+				"reason", event.Reason,
+			)
+			continue
+		}
+		events[i].Message = string(message)
+	}
+	return events
+}
+
+// recordStageEvents sends the events of the stage
+func recordStageEvents(ctx context.Context, recorder record.EventRecorder, renderer gotpl.Renderer, ref *corev1.ObjectReference, next *internalversion.StageNext, obj interface{}) {
+	if recorder == nil || (next.Event == nil && len(next.Events) == 0) {
+		return
+	}
+	for _, event := range stageEvents(ctx, renderer, next, obj) {
+		recorder.Event(ref, event.Type, event.Reason, event.Message)
+	}
+}
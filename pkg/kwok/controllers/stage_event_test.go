@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+func TestStageEvents(t *testing.T) {
+	obj := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "pod0",
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":  "app",
+					"image": "busybox",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		next *internalversion.StageNext
+		want []internalversion.StageEvent
+	}{
+		{
+			name: "no events",
+			next: &internalversion.StageNext{},
+			want: []internalversion.StageEvent{},
+		},
+		{
+			name: "event and events in order",
+			next: &internalversion.StageNext{
+				Event: &internalversion.StageEvent{Type: "Normal", Reason: "Scheduled", Message: "Successfully assigned"},
+				Events: []internalversion.StageEvent{
+					{Type: "Normal", Reason: "Pulled", Message: `Container image "{{ (index .spec.containers 0).image }}" already present on machine`},
+					{Type: "Normal", Reason: "Started", Message: "Started container {{ (index .spec.containers 0).name }}"},
+				},
+			},
+			want: []internalversion.StageEvent{
+				{Type: "Normal", Reason: "Scheduled", Message: "Successfully assigned"},
+				{Type: "Normal", Reason: "Pulled", Message: `Container image "busybox" already present on machine`},
+				{Type: "Normal", Reason: "Started", Message: "Started container app"},
+			},
+		},
+		{
+			name: "invalid template falls back to the message",
+			next: &internalversion.StageNext{
+				Events: []internalversion.StageEvent{
+					{Type: "Warning", Reason: "Failed", Message: "{{ .metadata.name"},
+				},
+			},
+			want: []internalversion.StageEvent{
+				{Type: "Warning", Reason: "Failed", Message: "{{ .metadata.name"},
+			},
+		},
+	}
+	renderer := gotpl.NewRenderer(defaultFuncMap)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stageEvents(context.Background(), renderer, tt.next, obj)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("stageEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
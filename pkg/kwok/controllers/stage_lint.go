@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"net/url"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+// lintRenderer is only used to parse the templates of the stages
+var lintRenderer = gotpl.NewRenderer(previewFuncMap("", ""))
+
+// LintStage checks the stage for the problems that would make it fail at runtime,
+// e.g. invalid expressions, templates, cron expressions and webhooks.
+func LintStage(stage *internalversion.Stage) []error {
+	var errs []error
+	if _, err := NewLifecycleStage(stage); err != nil {
+		errs = append(errs, err)
+	}
+
+	next := stage.Spec.Next
+	lintTemplate := func(field, text string) {
+		if text == "" {
+			return
+		}
+		if err := lintRenderer.Parse(text); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field, err))
+		}
+	}
+	lintTemplate("next.statusTemplate", next.StatusTemplate)
+	for i, patch := range next.Patches {
+		lintTemplate(fmt.Sprintf("next.patches[%d].statusTemplate", i), patch.StatusTemplate)
+	}
+
+	names := map[string]struct{}{}
+	for i, subresource := range next.Subresources {
+		switch subresource.Name {
+		case "":
+			errs = append(errs, fmt.Errorf("next.subresources[%d].name: required", i))
+		case "status":
+			errs = append(errs, fmt.Errorf("next.subresources[%d].name: use next.statusTemplate for the status", i))
+		case "binding":
+			errs = append(errs, fmt.Errorf("next.subresources[%d].name: binding is not supported, the resources are already bound", i))
+		}
+		if _, ok := names[subresource.Name]; ok {
+			errs = append(errs, fmt.Errorf("next.subresources[%d].name: duplicate %q", i, subresource.Name))
+		}
+		names[subresource.Name] = struct{}{}
+		lintTemplate(fmt.Sprintf("next.subresources[%d].template", i), subresource.Template)
+	}
+
+	if next.Event != nil {
+		lintTemplate("next.event.message", next.Event.Message)
+	}
+	for i, event := range next.Events {
+		lintTemplate(fmt.Sprintf("next.events[%d].message", i), event.Message)
+	}
+
+	if next.Webhook != nil {
+		u, err := url.Parse(next.Webhook.URL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("next.webhook.url: %w", err))
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			errs = append(errs, fmt.Errorf("next.webhook.url: unsupported scheme %q", u.Scheme))
+		}
+	}
+	return errs
+}
+
+// LintStageWarnings checks the stage for the problems that don't make it fail at runtime but are likely mistakes,
+// e.g. the stage without a selector is skipped and never applied.
+func LintStageWarnings(stage *internalversion.Stage) []error {
+	var errs []error
+	if stage.Spec.Selector == nil {
+		errs = append(errs, fmt.Errorf("selector is not set, the stage is never applied"))
+	}
+	return errs
+}
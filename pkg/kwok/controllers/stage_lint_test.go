@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
+	nodeheartbeat "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat"
+	nodeheartbeatwithlease "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat-with-lease"
+	poddrain "sigs.k8s.io/kwok/kustomize/stage/pod/drain"
+	podfast "sigs.k8s.io/kwok/kustomize/stage/pod/fast"
+	podjob "sigs.k8s.io/kwok/kustomize/stage/pod/job"
+	volumeattachment "sigs.k8s.io/kwok/kustomize/stage/volume-attachment"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func TestLintStage_Default(t *testing.T) {
+	stages, err := slices.MapWithError([]string{
+		nodefast.DefaultNodeInit,
+		nodeheartbeat.DefaultNodeHeartbeat,
+		nodeheartbeatwithlease.DefaultNodeHeartbeatWithLease,
+		podfast.DefaultPodReady,
+		podfast.DefaultPodComplete,
+		podfast.DefaultPodDelete,
+		podjob.DefaultPodJobComplete,
+		podjob.DefaultPodJobFail,
+		poddrain.DefaultPodDrainDelete,
+		volumeattachment.DefaultVolumeAttachmentAttach,
+		volumeattachment.DefaultVolumeAttachmentDetach,
+	}, config.UnmarshalWithType[*internalversion.Stage, string])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stage := range stages {
+		if errs := LintStage(stage); len(errs) != 0 {
+			t.Errorf("LintStage(%q) = %v, want no errors", stage.Name, errs)
+		}
+	}
+}
+
+func TestLintStage(t *testing.T) {
+	selector := &internalversion.StageSelector{
+		MatchExpressions: []internalversion.SelectorRequirement{
+			{
+				Key:      ".status.phase",
+				Operator: internalversion.SelectorOpIn,
+				Values:   []string{"Running"},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		spec     internalversion.StageSpec
+		wantErrs int
+	}{
+		{
+			name: "valid",
+			spec: internalversion.StageSpec{
+				Selector: selector,
+				Next: internalversion.StageNext{
+					StatusTemplate: `phase: {{ .status.phase | upper }}`,
+					Events: []internalversion.StageEvent{
+						{Type: "Normal", Reason: "Started", Message: "Started {{ .metadata.name }} on {{ NodeIP }}"},
+					},
+				},
+			},
+		},
+		{
+			name: "no selector",
+			spec: internalversion.StageSpec{},
+		},
+		{
+			name: "invalid expression",
+			spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{
+					MatchExpressions: []internalversion.SelectorRequirement{
+						{
+							Key:      ".status.phase ==",
+							Operator: internalversion.SelectorOpIn,
+							Values:   []string{"true"},
+						},
+					},
+				},
+			},
+			wantErrs: 1,
+		},
+		{
+			name: "invalid cron",
+			spec: internalversion.StageSpec{
+				Selector: selector,
+				Delay: &internalversion.StageDelay{
+					Schedule: &internalversion.StageSchedule{Cron: "* * *"},
+				},
+			},
+			wantErrs: 1,
+		},
+		{
+			name: "invalid templates",
+			spec: internalversion.StageSpec{
+				Selector: selector,
+				Next: internalversion.StageNext{
+					StatusTemplate: `phase: {{ .status.phase`,
+					Patches: []internalversion.StagePatch{
+						{StatusTemplate: `phase: {{ Unknown }}`},
+					},
+					Event: &internalversion.StageEvent{Type: "Normal", Reason: "Started", Message: "{{ end }}"},
+				},
+			},
+			wantErrs: 3,
+		},
+		{
+			name: "invalid subresources",
+			spec: internalversion.StageSpec{
+				Selector: selector,
+				Next: internalversion.StageNext{
+					Subresources: []internalversion.StageSubresource{
+						{Name: "status", Template: "{}"},
+						{Name: "scale", Template: "{}"},
+						{Name: "scale", Template: "{}"},
+						{Name: "binding", Template: "{}"},
+					},
+				},
+			},
+			wantErrs: 3,
+		},
+		{
+			name: "invalid webhook",
+			spec: internalversion.StageSpec{
+				Selector: selector,
+				Next: internalversion.StageNext{
+					Webhook: &internalversion.StageWebhook{URL: "ftp://example.com"},
+				},
+			},
+			wantErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage := &internalversion.Stage{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       tt.spec,
+			}
+			if errs := LintStage(stage); len(errs) != tt.wantErrs {
+				t.Errorf("LintStage() = %v, want %d errors", errs, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestLintStageWarnings(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         internalversion.StageSpec
+		wantWarnings int
+	}{
+		{
+			name: "selector",
+			spec: internalversion.StageSpec{
+				Selector: &internalversion.StageSelector{},
+			},
+		},
+		{
+			name:         "no selector",
+			spec:         internalversion.StageSpec{},
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage := &internalversion.Stage{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       tt.spec,
+			}
+			if errs := LintStageWarnings(stage); len(errs) != tt.wantWarnings {
+				t.Errorf("LintStageWarnings() = %v, want %d warnings", errs, tt.wantWarnings)
+			}
+		})
+	}
+}
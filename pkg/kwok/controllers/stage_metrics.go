@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+)
+
+var (
+	stageMatchTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "kwok",
+			Subsystem: "stage",
+			Name:      "match_total",
+			Help:      "Number of the resources matched by the stage",
+		},
+		[]string{"kind", "stage"},
+	)
+
+	stageEvaluationDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "kwok",
+			Subsystem: "stage",
+			Name:      "evaluation_duration_seconds",
+			Help:      "Latency of evaluating the stages against a resource",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 10),
+		},
+		[]string{"kind"},
+	)
+
+	stagePatchFailedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "kwok",
+			Subsystem: "stage",
+			Name:      "patch_failed_total",
+			Help:      "Number of the failures to apply the stage to the resource",
+		},
+		[]string{"kind", "stage"},
+	)
+
+	stageChainBrokenTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "kwok",
+			Subsystem: "stage",
+			Name:      "chain_broken_total",
+			Help:      "Number of the chains of immediateNextStage broken due to exceeding the max depth",
+		},
+		[]string{"kind"},
+	)
+
+	stageDelayQueueLength = newQueueLengthCollector(
+		prometheus.NewDesc(
+			"kwok_stage_delay_queue_length",
+			"Number of the transitions of stages waiting for the delay",
+			[]string{"kind"},
+			nil,
+		),
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		stageMatchTotal,
+		stageEvaluationDurationSeconds,
+		stagePatchFailedTotal,
+		stageChainBrokenTotal,
+		stageDelayQueueLength,
+	)
+}
+
+// queueLengthCollector collects the length of the queues only when scraped
+type queueLengthCollector struct {
+	desc   *prometheus.Desc
+	queues maps.SyncMap[string, func() int]
+}
+
+func newQueueLengthCollector(desc *prometheus.Desc) *queueLengthCollector {
+	return &queueLengthCollector{
+		desc: desc,
+	}
+}
+
+// Register registers the length func of the queue for the kind, replacing the previous one
+func (c *queueLengthCollector) Register(kind string, length func() int) {
+	c.queues.Store(kind, length)
+}
+
+// Describe implements prometheus.Collector
+func (c *queueLengthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector
+func (c *queueLengthCollector) Collect(ch chan<- prometheus.Metric) {
+	c.queues.Range(func(kind string, length func() int) bool {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(length()), kind)
+		return true
+	})
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestQueueLengthCollector(t *testing.T) {
+	collector := newQueueLengthCollector(
+		prometheus.NewDesc("test_queue_length", "Length of the queue", []string{"kind"}, nil),
+	)
+	collector.Register("Pod", func() int { return 1 })
+	collector.Register("Node", func() int { return 2 })
+	// The latest registration replaces the previous one
+	collector.Register("Pod", func() int { return 3 })
+
+	want := `
+# HELP test_queue_length Length of the queue
+# TYPE test_queue_length gauge
+test_queue_length{kind="Node"} 2
+test_queue_length{kind="Pod"} 3
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
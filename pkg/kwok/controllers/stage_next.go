@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+)
+
+// stageNextPatch returns the merge patch to annotate the resource with the next stage planned for it and the time of it,
+// an empty stage removes the annotations, and nil is returned if the annotations are up to date.
+func stageNextPatch(annotations map[string]string, stage string, at time.Time) ([]byte, error) {
+	var patch map[string]interface{}
+	if stage == "" {
+		_, hasStage := annotations[v1alpha1.StageNextAnnotation]
+		_, hasTime := annotations[v1alpha1.StageNextTimeAnnotation]
+		if !hasStage && !hasTime {
+			return nil, nil
+		}
+		patch = map[string]interface{}{
+			v1alpha1.StageNextAnnotation:     nil,
+			v1alpha1.StageNextTimeAnnotation: nil,
+		}
+	} else {
+		t := at.UTC().Format(time.RFC3339)
+		if annotations[v1alpha1.StageNextAnnotation] == stage &&
+			annotations[v1alpha1.StageNextTimeAnnotation] == t {
+			return nil, nil
+		}
+		patch = map[string]interface{}{
+			v1alpha1.StageNextAnnotation:     stage,
+			v1alpha1.StageNextTimeAnnotation: t,
+		}
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": patch,
+		},
+	})
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func TestStageNextPatch(t *testing.T) {
+	at := time.Date(2023, 1, 1, 0, 10, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		stage       string
+		want        string
+	}{
+		{
+			name:  "annotate",
+			stage: "pod-ready",
+			want:  `{"metadata":{"annotations":{"stage.kwok.x-k8s.io/next":"pod-ready","stage.kwok.x-k8s.io/next-time":"2023-01-01T00:10:00Z"}}}`,
+		},
+		{
+			name: "up to date",
+			annotations: map[string]string{
+				"stage.kwok.x-k8s.io/next":      "pod-ready",
+				"stage.kwok.x-k8s.io/next-time": "2023-01-01T00:10:00Z",
+			},
+			stage: "pod-ready",
+		},
+		{
+			name: "remove",
+			annotations: map[string]string{
+				"stage.kwok.x-k8s.io/next":      "pod-ready",
+				"stage.kwok.x-k8s.io/next-time": "2023-01-01T00:10:00Z",
+			},
+			want: `{"metadata":{"annotations":{"stage.kwok.x-k8s.io/next":null,"stage.kwok.x-k8s.io/next-time":null}}}`,
+		},
+		{
+			name: "nothing to remove",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stageNextPatch(tt.annotations, tt.stage, at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("stageNextPatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodController_StageNextAnnotations(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	newStage := func(name, step string, delay time.Duration) *internalversion.Stage {
+		return &internalversion.Stage{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: internalversion.StageSpec{
+				ResourceRef: internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"},
+				Selector: &internalversion.StageSelector{
+					MatchLabels: map[string]string{"step": step},
+				},
+				Delay: &internalversion.StageDelay{
+					DurationMilliseconds: format.Ptr(delay.Milliseconds()),
+				},
+			},
+		}
+	}
+	lifecycle, err := NewLifecycle([]*internalversion.Stage{
+		newStage("delayed", "one", time.Minute),
+		newStage("immediate", "two", 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "pod0",
+			Namespace:       "default",
+			Labels:          map[string]string{"step": "one"},
+			ResourceVersion: "1",
+		},
+		Spec: corev1.PodSpec{NodeName: "node0"},
+	}
+	clientset := fake.NewSimpleClientset(pod)
+	ctr, err := NewPodController(PodControllerConfig{
+		Clock:                      clocktesting.NewFakeClock(now),
+		TypedClient:                clientset,
+		Lifecycle:                  resources.NewStaticGetter(lifecycle),
+		PlayStageParallelism:       1,
+		EnableStageNextAnnotations: true,
+		FuncMap:                    defaultFuncMap,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	getAnnotations := func() map[string]string {
+		got, err := clientset.CoreV1().Pods("default").Get(ctx, "pod0", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got.Annotations
+	}
+
+	err = ctr.preprocess(ctx, pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations := getAnnotations()
+	if annotations[v1alpha1.StageNextAnnotation] != "delayed" {
+		t.Errorf("want the next stage delayed, got %v", annotations)
+	}
+
+	// The next stage is played at once, so the annotations of the delayed stage are stale.
+	pod = pod.DeepCopy()
+	pod.Annotations = annotations
+	pod.Labels["step"] = "two"
+	pod.ResourceVersion = "2"
+	err = ctr.preprocess(ctx, pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations = getAnnotations()
+	if _, ok := annotations[v1alpha1.StageNextAnnotation]; ok {
+		t.Errorf("want the next stage removed, got %v", annotations)
+	}
+	if _, ok := annotations[v1alpha1.StageNextTimeAnnotation]; ok {
+		t.Errorf("want the next time removed, got %v", annotations)
+	}
+}
@@ -0,0 +1,335 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// StagePreviewConfig is the configuration for the StagePreview
+type StagePreviewConfig struct {
+	Clock    clock.Clock
+	Stages   []*internalversion.Stage
+	NodeIP   string
+	PodIP    string
+	MaxSteps int
+}
+
+// StagePreviewStep is one transition that the stages would apply to the resource
+type StagePreviewStep struct {
+	// Stage is the name of the matched stage.
+	Stage string
+	// Step is the index of the ordered patches of the stage, starting from 1, 0 means the next of the stage.
+	Step int
+	// Delay is the delay before the stage is applied.
+	Delay time.Duration
+	// Events is the events that would be sent.
+	Events []internalversion.StageEvent
+	// Webhook is the webhook that would be called, it is not called by the preview.
+	Webhook *internalversion.StageWebhook
+	// Finalizers is the finalizers of the resource after the stage is applied, if they are modified.
+	Finalizers []string
+	// FinalizersModified means that the finalizers of the resource are modified.
+	FinalizersModified bool
+	// Patch is the patch of the status of the resource.
+	Patch []byte
+	// SubresourcePatches is the patches of the subresources of the resource, keyed by the name of the subresource.
+	SubresourcePatches map[string][]byte
+	// Delete means that the resource would be deleted.
+	Delete bool
+}
+
+// StagePreview evaluates the stages against a resource without touching the cluster
+type StagePreview struct {
+	clock    clock.Clock
+	stages   []*internalversion.Stage
+	renderer gotpl.Renderer
+	maxSteps int
+}
+
+// NewStagePreview creates a new StagePreview
+func NewStagePreview(conf StagePreviewConfig) (*StagePreview, error) {
+	if conf.Clock == nil {
+		conf.Clock = clock.RealClock{}
+	}
+	if conf.MaxSteps <= 0 {
+		conf.MaxSteps = 10
+	}
+
+	return &StagePreview{
+		clock:    conf.Clock,
+		stages:   conf.Stages,
+		renderer: gotpl.NewRenderer(previewFuncMap(conf.NodeIP, conf.PodIP)),
+		maxSteps: conf.MaxSteps,
+	}, nil
+}
+
+// previewFuncMap returns the functions used by the templates of the stages,
+// with the fixed IPs instead of the ones allocated by the controllers.
+func previewFuncMap(nodeIP, podIP string) gotpl.FuncMap {
+	nodeIPs := splitList(nodeIP)
+	nodeIP = firstOf(nodeIPs)
+	hostIPs := nodeIPs
+	if len(hostIPs) == 0 {
+		hostIPs = []string{nodeIP}
+	}
+	return maps.Merge(gotpl.FuncMap{
+		"NodeIP": func() string {
+			return nodeIP
+		},
+		"NodeIPs": func() []string {
+			return nodeIPs
+		},
+		"PodIP": func() string {
+			return podIP
+		},
+		"NodeIPWith": func(nodeName string) string {
+			return nodeIP
+		},
+		"PodIPWith": func(nodeName string, hostNetwork bool, uid, name, namespace string) string {
+			if hostNetwork {
+				return nodeIP
+			}
+			return podIP
+		},
+		"NodeIPsWith": func(nodeName string) []string {
+			return hostIPs
+		},
+		"PodIPsWith": func(nodeName string, hostNetwork bool, uid, name, namespace string) []string {
+			if hostNetwork {
+				return hostIPs
+			}
+			return []string{podIP}
+		},
+		"ImageKnown": func(image string) bool {
+			return true
+		},
+		"NodeName": func() string {
+			return ""
+		},
+		"NodePort": func() int {
+			return 0
+		},
+		"NodeConditions": func() interface{} {
+			return nodeConditionsData
+		},
+	}, defaultFuncMap)
+}
+
+// Preview returns the sequence of transitions that would be applied to the resource.
+// The resource is modified locally as each stage is applied,
+// it stops when no stage matches, the resource is deleted or the max steps is reached.
+func (p *StagePreview) Preview(ctx context.Context, obj *unstructured.Unstructured) ([]StagePreviewStep, error) {
+	ref := internalversion.StageResourceRef{
+		APIGroup: obj.GetAPIVersion(),
+		Kind:     obj.GetKind(),
+	}
+	lifecycle, err := NewLifecycle(slices.Filter(p.stages, func(stage *internalversion.Stage) bool {
+		return stageRefMatches(stage.Spec.ResourceRef, ref)
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	if stagePaused(obj.GetAnnotations()) {
+		return nil, nil
+	}
+
+	obj = obj.DeepCopy()
+	now := p.clock.Now()
+
+	var steps []StagePreviewStep
+	for i := 0; i != p.maxSteps; i++ {
+		ctx, err := expression.WithVariables(ctx, ageVariables(lifecycle, obj.Object, now, 1))
+		if err != nil {
+			return nil, err
+		}
+		stage, err := lifecycle.Match(ctx, obj.GetLabels(), obj.GetAnnotations(), obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("stage match: %w", err)
+		}
+		if stage == nil {
+			return steps, nil
+		}
+
+		step := StagePreviewStep{
+			Stage: stage.Name(),
+		}
+		if delay, ok := stage.Delay(ctx, obj.Object, now); ok {
+			step.Delay = delay
+		}
+		now = now.Add(step.Delay)
+
+		next := stage.Next()
+		step.Events = stageEvents(ctx, p.renderer, next, obj.Object)
+		step.Webhook = next.Webhook
+
+		changed := false
+		if next.Finalizers != nil {
+			ops := finalizersModify(obj.GetFinalizers(), next.Finalizers)
+			if len(ops) != 0 {
+				err = p.applyFinalizers(obj, ops)
+				if err != nil {
+					return nil, fmt.Errorf("stage %q: %w", stage.Name(), err)
+				}
+				step.Finalizers = obj.GetFinalizers()
+				step.FinalizersModified = true
+				changed = true
+			}
+		}
+
+		if !next.Delete && len(next.Subresources) != 0 {
+			step.SubresourcePatches = map[string][]byte{}
+			for _, subresource := range next.Subresources {
+				patch, err := p.renderer.ToJSON(subresource.Template, obj.Object)
+				if err != nil {
+					return nil, fmt.Errorf("stage %q subresource %q: %w", stage.Name(), subresource.Name, err)
+				}
+				step.SubresourcePatches[subresource.Name] = bytes.TrimSpace(patch)
+			}
+		}
+
+		if next.Delete {
+			if len(obj.GetFinalizers()) == 0 {
+				step.Delete = true
+				steps = append(steps, step)
+				return steps, nil
+			}
+			if obj.GetDeletionTimestamp() == nil {
+				obj.SetDeletionTimestamp(&metav1.Time{Time: now})
+				changed = true
+			}
+		} else if next.StatusTemplate != "" {
+			patch, err := p.computePatch(obj, ref, next.StatusTemplate)
+			if err != nil {
+				return nil, fmt.Errorf("stage %q: %w", stage.Name(), err)
+			}
+			if patch != nil {
+				step.Patch = patch
+				changed = true
+			}
+		}
+
+		steps = append(steps, step)
+
+		if !next.Delete {
+			for i, patch := range next.Patches {
+				step := StagePreviewStep{
+					Stage: stage.Name(),
+					Step:  i + 1,
+					Delay: stagePatchDelay(stage, i+1),
+				}
+				now = now.Add(step.Delay)
+				step.Patch, err = p.computePatch(obj, ref, patch.StatusTemplate)
+				if err != nil {
+					return nil, fmt.Errorf("stage %q patch %d: %w", stage.Name(), i+1, err)
+				}
+				if step.Patch != nil {
+					changed = true
+				}
+				steps = append(steps, step)
+			}
+		}
+
+		if !changed {
+			// The resource will not be changed anymore, so the same stage would match forever.
+			return steps, nil
+		}
+	}
+	return steps, nil
+}
+
+func (p *StagePreview) applyFinalizers(obj *unstructured.Unstructured, ops []jsonpathOperation) error {
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return err
+	}
+	patch, err := jsonpatch.DecodePatch(data)
+	if err != nil {
+		return err
+	}
+	original, err := json.Marshal(obj.Object)
+	if err != nil {
+		return err
+	}
+	result, err := patch.Apply(original)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(result, &obj.Object)
+}
+
+// computePatch renders the template and merges it into the status of the resource,
+// the patch is returned only if the status is changed.
+func (p *StagePreview) computePatch(obj *unstructured.Unstructured, ref internalversion.StageResourceRef, tpl string) ([]byte, error) {
+	patch, err := p.renderer.ToJSON(tpl, obj.Object)
+	if err != nil {
+		return nil, err
+	}
+	patch = bytes.TrimSpace(patch)
+
+	status, ok := obj.Object["status"]
+	if !ok || status == nil {
+		status = map[string]interface{}{}
+	}
+	original, err := json.Marshal(status)
+	if err != nil {
+		return nil, err
+	}
+
+	var sum []byte
+	switch {
+	case stageRefMatches(ref, podRef):
+		sum, err = strategicpatch.StrategicMergePatch(original, patch, corev1.PodStatus{})
+	case stageRefMatches(ref, nodeRef):
+		sum, err = strategicpatch.StrategicMergePatch(original, patch, corev1.NodeStatus{})
+	default:
+		sum, err = jsonpatch.MergePatch(original, patch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if jsonpatch.Equal(original, sum) {
+		return nil, nil
+	}
+
+	var newStatus interface{}
+	err = json.Unmarshal(sum, &newStatus)
+	if err != nil {
+		return nil, err
+	}
+	obj.Object["status"] = newStatus
+	return patch, nil
+}
@@ -0,0 +1,413 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	podjob "sigs.k8s.io/kwok/kustomize/stage/pod/job"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func TestStagePreview(t *testing.T) {
+	stages := []*internalversion.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready"},
+			Spec: internalversion.StageSpec{
+				ResourceRef: internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"},
+				Selector: &internalversion.StageSelector{
+					MatchExpressions: []internalversion.SelectorRequirement{
+						{
+							Key:      ".metadata.deletionTimestamp",
+							Operator: internalversion.SelectorOpDoesNotExist,
+						},
+						{
+							Key:      ".status.phase",
+							Operator: internalversion.SelectorOpDoesNotExist,
+						},
+					},
+				},
+				Delay: &internalversion.StageDelay{
+					DurationMilliseconds: format.Ptr[int64](1000),
+				},
+				Next: internalversion.StageNext{
+					StatusTemplate: "phase: Running\npodIP: {{ PodIP }}",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "delete"},
+			Spec: internalversion.StageSpec{
+				ResourceRef: internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"},
+				Selector: &internalversion.StageSelector{
+					MatchExpressions: []internalversion.SelectorRequirement{
+						{
+							Key:      ".metadata.deletionTimestamp",
+							Operator: internalversion.SelectorOpExists,
+						},
+					},
+				},
+				Next: internalversion.StageNext{
+					Finalizers: &internalversion.StageFinalizers{
+						Empty: true,
+					},
+					Delete: true,
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec: internalversion.StageSpec{
+				ResourceRef: internalversion.StageResourceRef{APIGroup: "v1", Kind: "Node"},
+				Next: internalversion.StageNext{
+					StatusTemplate: "phase: Running",
+				},
+			},
+		},
+	}
+
+	preview, err := NewStagePreview(StagePreviewConfig{
+		Stages: stages,
+		PodIP:  "10.0.0.2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		obj  map[string]interface{}
+		want []string
+	}{
+		{
+			name: "running pod",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata":   map[string]interface{}{"name": "pod0"},
+			},
+			want: []string{"ready"},
+		},
+		{
+			name: "deleting pod",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"name":              "pod0",
+					"finalizers":        []interface{}{"kwok.x-k8s.io/fake"},
+					"deletionTimestamp": "2023-01-01T00:00:00Z",
+				},
+			},
+			want: []string{"delete"},
+		},
+		{
+			name: "unmatched kind",
+			obj: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]interface{}{"name": "deploy0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := preview.Preview(context.Background(), &unstructured.Unstructured{Object: tt.obj})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, step := range steps {
+				got = append(got, step.Stage)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("want stages %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("want stages %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+
+	steps, err := preview.Preview(context.Background(), &unstructured.Unstructured{Object: tests[0].obj})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if steps[0].Delay != time.Second {
+		t.Errorf("want delay %s, got %s", time.Second, steps[0].Delay)
+	}
+	if string(steps[0].Patch) != `{"phase":"Running","podIP":"10.0.0.2"}` {
+		t.Errorf("unexpected patch %s", steps[0].Patch)
+	}
+
+	steps, err = preview.Preview(context.Background(), &unstructured.Unstructured{Object: tests[1].obj})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !steps[0].Delete || !steps[0].FinalizersModified {
+		t.Errorf("want finalizers emptied and deleted, got %+v", steps[0])
+	}
+}
+
+func TestStagePreview_Patches(t *testing.T) {
+	stages := []*internalversion.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "start"},
+			Spec: internalversion.StageSpec{
+				ResourceRef: internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"},
+				Selector: &internalversion.StageSelector{
+					MatchExpressions: []internalversion.SelectorRequirement{
+						{
+							Key:      ".status.phase",
+							Operator: internalversion.SelectorOpDoesNotExist,
+						},
+					},
+				},
+				Next: internalversion.StageNext{
+					StatusTemplate: "phase: Pending",
+					Patches: []internalversion.StagePatch{
+						{
+							DelayMilliseconds: format.Ptr[int64](1000),
+							StatusTemplate:    "initContainerStatuses: [{name: init, ready: true}]",
+						},
+						{
+							DelayMilliseconds: format.Ptr[int64](2000),
+							StatusTemplate:    "phase: Running",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	preview, err := NewStagePreview(StagePreviewConfig{
+		Stages: stages,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps, err := preview.Preview(context.Background(), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "pod0"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		step  int
+		delay time.Duration
+		patch string
+	}{
+		{0, 0, `{"phase":"Pending"}`},
+		{1, time.Second, `{"initContainerStatuses":[{"name":"init","ready":true}]}`},
+		{2, 2 * time.Second, `{"phase":"Running"}`},
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("want %d steps, got %+v", len(want), steps)
+	}
+	for i, w := range want {
+		if steps[i].Step != w.step || steps[i].Delay != w.delay || string(steps[i].Patch) != w.patch {
+			t.Errorf("want step %d after %s with patch %s, got %+v", w.step, w.delay, w.patch, steps[i])
+		}
+	}
+}
+
+func TestStagePreview_JobStages(t *testing.T) {
+	stages, err := slices.MapWithError([]string{
+		podjob.DefaultPodJobComplete,
+		podjob.DefaultPodJobFail,
+	}, config.UnmarshalWithType[*internalversion.Stage, string])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := NewStagePreview(StagePreviewConfig{
+		Stages: stages,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]interface{}
+		wantStage   string
+		wantDelay   time.Duration
+		wantPhase   string
+		wantCode    int64
+	}{
+		{
+			name:      "default",
+			wantStage: "pod-job-complete",
+			wantDelay: time.Second,
+			wantPhase: "Succeeded",
+		},
+		{
+			name: "complete after duration",
+			annotations: map[string]interface{}{
+				"job.kwok.x-k8s.io/duration": "30s",
+			},
+			wantStage: "pod-job-complete",
+			wantDelay: 30 * time.Second,
+			wantPhase: "Succeeded",
+		},
+		{
+			name: "fail with exit code",
+			annotations: map[string]interface{}{
+				"job.kwok.x-k8s.io/duration":  "1m",
+				"job.kwok.x-k8s.io/exit-code": "2",
+			},
+			wantStage: "pod-job-fail",
+			wantDelay: time.Minute,
+			wantPhase: "Failed",
+			wantCode:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := map[string]interface{}{
+				"name": "job0-abcde",
+				"ownerReferences": []interface{}{
+					map[string]interface{}{"apiVersion": "batch/v1", "kind": "Job", "name": "job0"},
+				},
+			}
+			if tt.annotations != nil {
+				metadata["annotations"] = tt.annotations
+			}
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata":   metadata,
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "job", "image": "busybox"},
+					},
+				},
+				"status": map[string]interface{}{
+					"phase": "Running",
+				},
+			}}
+
+			steps, err := preview.Preview(context.Background(), obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(steps) == 0 || steps[0].Stage != tt.wantStage || steps[0].Delay != tt.wantDelay {
+				t.Fatalf("want stage %q after %s, got %+v", tt.wantStage, tt.wantDelay, steps)
+			}
+
+			var status struct {
+				Phase             string `json:"phase"`
+				ContainerStatuses []struct {
+					State struct {
+						Terminated struct {
+							ExitCode int64 `json:"exitCode"`
+						} `json:"terminated"`
+					} `json:"state"`
+				} `json:"containerStatuses"`
+			}
+			err = json.Unmarshal(steps[0].Patch, &status)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Phase != tt.wantPhase {
+				t.Errorf("want phase %q, got %q", tt.wantPhase, status.Phase)
+			}
+			if len(status.ContainerStatuses) != 1 || status.ContainerStatuses[0].State.Terminated.ExitCode != tt.wantCode {
+				t.Errorf("want exit code %d, got %s", tt.wantCode, steps[0].Patch)
+			}
+		})
+	}
+}
+
+func TestStagePreview_Add1(t *testing.T) {
+	stages := []*internalversion.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "restart"},
+			Spec: internalversion.StageSpec{
+				ResourceRef: internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"},
+				Selector: &internalversion.StageSelector{
+					MatchExpressions: []internalversion.SelectorRequirement{
+						{
+							Key:      ".status.phase",
+							Operator: internalversion.SelectorOpIn,
+							Values:   []string{"Running"},
+						},
+					},
+				},
+				Next: internalversion.StageNext{
+					StatusTemplate: `
+containerStatuses:
+{{ range .status.containerStatuses }}
+- name: {{ .name }}
+  restartCount: {{ add1 (toString .restartCount) }}
+{{ end }}
+`,
+				},
+			},
+		},
+	}
+	preview, err := NewStagePreview(StagePreviewConfig{
+		Stages:   stages,
+		MaxSteps: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": "pod0",
+		},
+		"status": map[string]interface{}{
+			"phase": "Running",
+			"containerStatuses": []interface{}{
+				map[string]interface{}{"name": "app"},
+			},
+		},
+	}}
+	steps, err := preview.Preview(context.Background(), obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 3 {
+		t.Fatalf("want 3 steps, got %d", len(steps))
+	}
+	for i, step := range steps {
+		want := fmt.Sprintf(`{"containerStatuses":[{"name":"app","restartCount":%d}]}`, i+1)
+		if string(step.Patch) != want {
+			t.Errorf("step %d: want patch %s, got %s", i, want, step.Patch)
+		}
+	}
+}
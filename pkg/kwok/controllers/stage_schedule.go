@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/cron"
+)
+
+// stageSchedule is the wall-clock times that a stage is applied at
+type stageSchedule struct {
+	at   *time.Time
+	cron *cron.Schedule
+}
+
+func newStageSchedule(schedule *internalversion.StageSchedule) (*stageSchedule, error) {
+	s := &stageSchedule{}
+	if schedule.At != nil {
+		at := schedule.At.Time
+		s.at = &at
+	}
+	if schedule.AfterStartMilliseconds != nil {
+		// The AfterStartMilliseconds is relative to the time that kwok is started at
+		start, _ := time.Parse(time.RFC3339Nano, startTime)
+		at := start.Add(time.Duration(*schedule.AfterStartMilliseconds) * time.Millisecond)
+		if s.at == nil || at.Before(*s.at) {
+			s.at = &at
+		}
+	}
+	if schedule.Cron != "" {
+		c, err := cron.Parse(schedule.Cron)
+		if err != nil {
+			return nil, err
+		}
+		s.cron = c
+	}
+	return s, nil
+}
+
+// Delay returns the duration from now to the next time of the schedule,
+// false is returned if there is no such time.
+func (s *stageSchedule) Delay(now time.Time) (time.Duration, bool) {
+	var next time.Time
+	if s.at != nil {
+		next = *s.at
+	}
+	if s.cron != nil {
+		t := s.cron.Next(now.UTC())
+		if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	if next.IsZero() {
+		return 0, false
+	}
+	delay := next.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func TestStageSchedule_Delay(t *testing.T) {
+	now := time.Date(2023, 1, 1, 10, 7, 30, 0, time.UTC)
+	start, _ := time.Parse(time.RFC3339Nano, startTime)
+	tests := []struct {
+		name     string
+		schedule internalversion.StageSchedule
+		now      time.Time
+		want     time.Duration
+		wantOk   bool
+	}{
+		{
+			name: "at",
+			schedule: internalversion.StageSchedule{
+				At: &metav1.Time{Time: now.Add(time.Hour)},
+			},
+			now:    now,
+			want:   time.Hour,
+			wantOk: true,
+		},
+		{
+			name: "at passed",
+			schedule: internalversion.StageSchedule{
+				At: &metav1.Time{Time: now.Add(-time.Hour)},
+			},
+			now:    now,
+			want:   0,
+			wantOk: true,
+		},
+		{
+			name: "after start",
+			schedule: internalversion.StageSchedule{
+				AfterStartMilliseconds: format.Ptr[int64](600000),
+			},
+			now:    start,
+			want:   10 * time.Minute,
+			wantOk: true,
+		},
+		{
+			name: "cron",
+			schedule: internalversion.StageSchedule{
+				Cron: "*/10 * * * *",
+			},
+			now:    now,
+			want:   2*time.Minute + 30*time.Second,
+			wantOk: true,
+		},
+		{
+			name: "earliest of at and cron",
+			schedule: internalversion.StageSchedule{
+				At:   &metav1.Time{Time: now.Add(time.Minute)},
+				Cron: "*/10 * * * *",
+			},
+			now:    now,
+			want:   time.Minute,
+			wantOk: true,
+		},
+		{
+			name: "cron never matched",
+			schedule: internalversion.StageSchedule{
+				Cron: "0 0 30 2 *",
+			},
+			now:    now,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newStageSchedule(&tt.schedule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := s.Delay(tt.now)
+			if ok != tt.wantOk {
+				t.Fatalf("Delay() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Errorf("Delay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// StageStatistic is the statistic of a stage played by the controllers
+type StageStatistic struct {
+	// Kind is the kind of the resource of the stage.
+	Kind string `json:"kind"`
+	// Stage is the name of the stage.
+	Stage string `json:"stage"`
+	// Matched is the number of the resources matched by the stage.
+	Matched int64 `json:"matched"`
+	// AverageDelay is the average delay before the stage is played.
+	AverageDelay time.Duration `json:"averageDelay"`
+	// LastPlayed is the last time the stage was played, nil if never.
+	LastPlayed *time.Time `json:"lastPlayed,omitempty"`
+	// Played is the number of the times the stage was played.
+	Played int64 `json:"played"`
+	// PlayedPerSecond is the rate of the stage played over the last minute.
+	PlayedPerSecond float64 `json:"playedPerSecond"`
+}
+
+type stageStatisticKey struct {
+	kind  string
+	stage string
+}
+
+// stageRateWindowSeconds is the window of the rate of the stages played
+const stageRateWindowSeconds = 60
+
+type stageStatistic struct {
+	matched    int64
+	totalDelay time.Duration
+	lastPlayed time.Time
+	played     int64
+
+	// The number of the times played in each second of the window, indexed by the second modulo the window
+	recentPlayed   [stageRateWindowSeconds]int64
+	recentPlayedAt [stageRateWindowSeconds]int64
+}
+
+// stageStatistics records the statistics of the stages
+type stageStatistics struct {
+	mut   sync.Mutex
+	stats map[stageStatisticKey]*stageStatistic
+}
+
+var stageStats = newStageStatistics()
+
+func newStageStatistics() *stageStatistics {
+	return &stageStatistics{
+		stats: map[stageStatisticKey]*stageStatistic{},
+	}
+}
+
+func (s *stageStatistics) get(kind, stage string) *stageStatistic {
+	key := stageStatisticKey{kind: kind, stage: stage}
+	stat, ok := s.stats[key]
+	if !ok {
+		stat = &stageStatistic{}
+		s.stats[key] = stat
+	}
+	return stat
+}
+
+// Matched records that the stage matches a resource with the delay
+func (s *stageStatistics) Matched(kind, stage string, delay time.Duration) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	stat := s.get(kind, stage)
+	stat.matched++
+	stat.totalDelay += delay
+}
+
+// Played records that the stage is played
+func (s *stageStatistics) Played(kind, stage string, now time.Time) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	stat := s.get(kind, stage)
+	stat.lastPlayed = now
+	stat.played++
+
+	sec := now.Unix()
+	i := sec % stageRateWindowSeconds
+	if stat.recentPlayedAt[i] != sec {
+		stat.recentPlayedAt[i] = sec
+		stat.recentPlayed[i] = 0
+	}
+	stat.recentPlayed[i]++
+}
+
+// rate returns the rate of the stage played per second over the window before now
+func (s *stageStatistic) rate(now time.Time) float64 {
+	sec := now.Unix()
+	var sum int64
+	for i, at := range s.recentPlayedAt {
+		if at > sec-stageRateWindowSeconds && at <= sec {
+			sum += s.recentPlayed[i]
+		}
+	}
+	return float64(sum) / stageRateWindowSeconds
+}
+
+// List returns the statistics of the stages sorted by the kind and the name
+func (s *stageStatistics) List(now time.Time) []StageStatistic {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	out := make([]StageStatistic, 0, len(s.stats))
+	for key, stat := range s.stats {
+		item := StageStatistic{
+			Kind:            key.kind,
+			Stage:           key.stage,
+			Matched:         stat.matched,
+			Played:          stat.played,
+			PlayedPerSecond: stat.rate(now),
+		}
+		if stat.matched != 0 {
+			item.AverageDelay = stat.totalDelay / time.Duration(stat.matched)
+		}
+		if !stat.lastPlayed.IsZero() {
+			lastPlayed := stat.lastPlayed
+			item.LastPlayed = &lastPlayed
+		}
+		out = append(out, item)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Stage < out[j].Stage
+	})
+	return out
+}
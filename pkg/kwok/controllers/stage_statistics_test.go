@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStageStatistics(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := newStageStatistics()
+	stats.Matched("Pod", "pod-ready", time.Second)
+	stats.Matched("Pod", "pod-ready", 3*time.Second)
+	stats.Played("Pod", "pod-ready", now)
+	stats.Matched("Node", "node-initialize", 0)
+
+	want := []StageStatistic{
+		{
+			Kind:    "Node",
+			Stage:   "node-initialize",
+			Matched: 1,
+		},
+		{
+			Kind:            "Pod",
+			Stage:           "pod-ready",
+			Matched:         2,
+			AverageDelay:    2 * time.Second,
+			LastPlayed:      &now,
+			Played:          1,
+			PlayedPerSecond: 1.0 / 60,
+		},
+	}
+	got := stats.List(now.Add(time.Second))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List() got = %+v, want %+v", got, want)
+	}
+}
+
+func TestStageStatisticsRate(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := newStageStatistics()
+	for i := 0; i < 120; i++ {
+		stats.Played("Pod", "pod-ready", now.Add(time.Duration(i)*time.Second))
+	}
+
+	got := stats.List(now.Add(119 * time.Second))
+	if len(got) != 1 || got[0].Played != 120 || got[0].PlayedPerSecond != 1 {
+		t.Errorf("want played 120 at 1 per second, got %+v", got)
+	}
+
+	got = stats.List(now.Add(149 * time.Second))
+	if got[0].PlayedPerSecond != 0.5 {
+		t.Errorf("want 0.5 per second half a window later, got %v", got[0].PlayedPerSecond)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+const (
+	defaultStageWebhookTimeout = 10 * time.Second
+	maxStageWebhookResponse    = 4 << 20
+)
+
+// stageWebhookTransport is shared by the calls of the webhooks to reuse the connections.
+var stageWebhookTransport = http.DefaultTransport
+
+type stageWebhookRequest struct {
+	Stage  string      `json:"stage"`
+	Object interface{} `json:"object"`
+}
+
+// callStageWebhook posts the resource to the webhook of the stage,
+// and returns the patch of the status in JSON if the response is used, which is an empty patch for an empty response.
+func callStageWebhook(ctx context.Context, stage string, webhook *internalversion.StageWebhook, obj interface{}) ([]byte, error) {
+	data, err := json.Marshal(stageWebhookRequest{
+		Stage:  stage,
+		Object: obj,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := defaultStageWebhookTimeout
+	if webhook.TimeoutMilliseconds != nil {
+		timeout = time.Duration(*webhook.TimeoutMilliseconds) * time.Millisecond
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// The webhook is called in the worker playing the stages, so the timeout covers reading the response too,
+	// to keep a slow webhook from stalling the other resources.
+	client := &http.Client{
+		Transport: stageWebhookTransport,
+		Timeout:   timeout,
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("webhook %q responded with status code %d", webhook.URL, resp.StatusCode)
+	}
+	if !webhook.UseResponse {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxStageWebhookResponse))
+	if err != nil {
+		return nil, err
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		// An empty response means no change, instead of falling back to the status template.
+		return []byte("{}"), nil
+	}
+	return yaml.YAMLToJSON(body)
+}
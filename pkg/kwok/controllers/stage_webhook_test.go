@@ -0,0 +1,129 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func TestCallStageWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        int
+		response    string
+		useResponse bool
+		timeout     *int64
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:     "ignore response",
+			code:     http.StatusOK,
+			response: `{"phase":"Running"}`,
+		},
+		{
+			name:        "json response",
+			code:        http.StatusOK,
+			response:    `{"phase":"Running"}`,
+			useResponse: true,
+			want:        `{"phase":"Running"}`,
+		},
+		{
+			name:        "yaml response",
+			code:        http.StatusOK,
+			response:    "phase: Running\n",
+			useResponse: true,
+			want:        `{"phase":"Running"}`,
+		},
+		{
+			name:        "empty response",
+			code:        http.StatusNoContent,
+			useResponse: true,
+			want:        `{}`,
+		},
+		{
+			name:    "failed",
+			code:    http.StatusInternalServerError,
+			wantErr: true,
+		},
+		{
+			name:    "timeout",
+			code:    http.StatusOK,
+			timeout: format.Ptr[int64](1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				var req stageWebhookRequest
+				err := json.NewDecoder(r.Body).Decode(&req)
+				if err != nil {
+					t.Errorf("decode request: %v", err)
+				}
+				if req.Stage != "stage" {
+					t.Errorf("want stage %q, got %q", "stage", req.Stage)
+				}
+				if tt.timeout != nil {
+					<-done
+				}
+				rw.WriteHeader(tt.code)
+				_, _ = rw.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+			defer close(done)
+
+			webhook := &internalversion.StageWebhook{
+				URL:                 server.URL,
+				TimeoutMilliseconds: tt.timeout,
+				UseResponse:         tt.useResponse,
+			}
+			got, err := callStageWebhook(context.Background(), "stage", webhook, map[string]interface{}{"kind": "Pod"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("callStageWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("callStageWebhook() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodController_computePatchWithEmptyResponse(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	c := &PodController{}
+	// The status template is not rendered with the response of the webhook, an empty one means no change.
+	patch, err := c.computePatch(pod, "phase: Failed", []byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch != nil {
+		t.Errorf("want no patch, got %s", patch)
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/informer"
+	"sigs.k8s.io/kwok/pkg/utils/queue"
+)
+
+const (
+	// configSourceAnnotation is the annotation of the pod for the source of its manifest, as the kubelet sets it
+	configSourceAnnotation = "kubernetes.io/config.source"
+	// configMirrorAnnotation is the annotation of the mirror pod for the hash of its static pod, as the kubelet sets it
+	configMirrorAnnotation = "kubernetes.io/config.mirror"
+	// configHashAnnotation is the annotation of the pod for the hash of its manifest, as the kubelet sets it
+	configHashAnnotation = "kubernetes.io/config.hash"
+)
+
+// StaticPodController is responsible for creating the mirror pods of the static pods on the managed nodes
+type StaticPodController struct {
+	typedClient     clientset.Interface
+	nodeCacheGetter informer.Getter[*corev1.Node]
+	manifestPath    string
+	syncQueue       queue.Queue[string]
+}
+
+// StaticPodControllerConfig is the configuration for StaticPodController
+type StaticPodControllerConfig struct {
+	TypedClient     clientset.Interface
+	NodeCacheGetter informer.Getter[*corev1.Node]
+	ManifestPath    string
+}
+
+// NewStaticPodController constructs and returns a StaticPodController
+func NewStaticPodController(conf StaticPodControllerConfig) (*StaticPodController, error) {
+	if conf.ManifestPath == "" {
+		return nil, fmt.Errorf("static pod manifest path is required")
+	}
+	if conf.NodeCacheGetter == nil {
+		return nil, fmt.Errorf("node cache getter is required")
+	}
+
+	c := &StaticPodController{
+		typedClient:     conf.TypedClient,
+		nodeCacheGetter: conf.NodeCacheGetter,
+		manifestPath:    conf.ManifestPath,
+		syncQueue:       queue.NewQueue[string](),
+	}
+	return c, nil
+}
+
+// Start starts the StaticPodController
+func (c *StaticPodController) Start(ctx context.Context) error {
+	go c.syncWorker(ctx)
+	return nil
+}
+
+// Sync creates the mirror pods of the static pods on the node later
+func (c *StaticPodController) Sync(nodeName string) {
+	c.syncQueue.Add(nodeName)
+}
+
+// syncWorker receives the node name from the syncQueue and creates the mirror pods on it
+func (c *StaticPodController) syncWorker(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for ctx.Err() == nil {
+		nodeName := c.syncQueue.GetOrWait()
+		err := c.sync(ctx, nodeName)
+		if err != nil {
+			logger.Error("Failed to sync static pods", err,
+				"node", nodeName,
+			)
+		}
+	}
+	logger.Debug("Stop static pod worker")
+}
+
+// sync creates the mirror pods of the static pods on the node which are missing,
+// and deletes the ones out of date, which are created again once they are gone.
+func (c *StaticPodController) sync(ctx context.Context, nodeName string) error {
+	node, ok := c.nodeCacheGetter.Get(nodeName)
+	if !ok {
+		return nil
+	}
+
+	manifests, err := loadStaticPods(c.manifestPath, nodeName)
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	for _, manifest := range manifests {
+		pod, err := mirrorPod(manifest, node)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.typedClient.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+		if err == nil {
+			logger.Info("Create mirror pod",
+				"pod", log.KObj(pod),
+				"node", nodeName,
+			)
+			continue
+		}
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+
+		existing, err := c.typedClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				c.Sync(nodeName)
+				continue
+			}
+			return err
+		}
+		if existing.Annotations[configMirrorAnnotation] == pod.Annotations[configMirrorAnnotation] ||
+			existing.DeletionTimestamp != nil {
+			continue
+		}
+
+		// The manifest is changed, delete the mirror pod to create it again
+		err = c.typedClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOpt)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		logger.Info("Delete mirror pod out of date",
+			"pod", log.KObj(pod),
+			"node", nodeName,
+		)
+	}
+	return nil
+}
+
+// isMirrorPod returns whether the pod is a mirror pod of a static pod
+func isMirrorPod(pod *corev1.Pod) bool {
+	_, ok := pod.Annotations[configMirrorAnnotation]
+	return ok
+}
+
+// loadStaticPods loads the manifests of the static pods of the node,
+// which are the files in the directory for all nodes and the ones in the subdirectory named after the node.
+func loadStaticPods(dir, nodeName string) ([]*corev1.Pod, error) {
+	pods, err := loadStaticPodsInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	nodePods, err := loadStaticPodsInDir(filepath.Join(dir, nodeName))
+	if err != nil {
+		return nil, err
+	}
+	return append(pods, nodePods...), nil
+}
+
+// loadStaticPodsInDir loads the manifests of the static pods in the directory, one pod for each file,
+// the missing directory has no pod.
+func loadStaticPodsInDir(dir string) ([]*corev1.Pod, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	var pods []*corev1.Pod
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		file := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		pod := &corev1.Pod{}
+		err = yaml.UnmarshalStrict(data, pod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse static pod %s: %w", file, err)
+		}
+		if pod.Kind != "Pod" || pod.Name == "" {
+			return nil, fmt.Errorf("invalid static pod %s: want a Pod with a name", file)
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// mirrorPod returns the mirror pod of the static pod on the node,
+// which is named after the node and owned by it, as the kubelet creates it.
+func mirrorPod(manifest *corev1.Pod, node *corev1.Node) (*corev1.Pod, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write(data)
+	_, _ = hash.Write([]byte(node.Name))
+	sum := strconv.FormatUint(uint64(hash.Sum32()), 16)
+
+	pod := manifest.DeepCopy()
+	pod.Name = manifest.Name + "-" + node.Name
+	if pod.Namespace == "" {
+		pod.Namespace = metav1.NamespaceDefault
+	}
+	pod.Spec.NodeName = node.Name
+	pod.Status = corev1.PodStatus{}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[configSourceAnnotation] = "file"
+	pod.Annotations[configHashAnnotation] = sum
+	pod.Annotations[configMirrorAnnotation] = sum
+	pod.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: nodeKind.Version,
+			Kind:       nodeKind.Kind,
+			Name:       node.Name,
+			UID:        node.UID,
+			Controller: format.Ptr(true),
+		},
+	}
+	return pod, nil
+}
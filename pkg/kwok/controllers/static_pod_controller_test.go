@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const staticPodManifest = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  namespace: kube-system
+spec:
+  containers:
+  - name: app
+    image: %s
+`
+
+func writeStaticPod(t *testing.T, dir, name, image string) {
+	t.Helper()
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte(fmt.Sprintf(staticPodManifest, name, image))
+	err = os.WriteFile(filepath.Join(dir, name+".yaml"), data, 0o640)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadStaticPods(t *testing.T) {
+	dir := t.TempDir()
+	writeStaticPod(t, dir, "etcd", "etcd:3.5")
+	writeStaticPod(t, filepath.Join(dir, "node0"), "kube-apiserver", "kube-apiserver:v1.28.0")
+	err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0o640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pods, err := loadStaticPods(dir, "node0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods) != 2 || pods[0].Name != "etcd" || pods[1].Name != "kube-apiserver" {
+		t.Errorf("loadStaticPods(node0) = %v, want etcd and kube-apiserver", pods)
+	}
+
+	pods, err = loadStaticPods(dir, "node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods) != 1 || pods[0].Name != "etcd" {
+		t.Errorf("loadStaticPods(node1) = %v, want etcd", pods)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("kind: Deployment\nmetadata:\n  name: x\n"), 0o640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = loadStaticPods(dir, "node1")
+	if err == nil {
+		t.Errorf("loadStaticPods() want error for the manifest not a Pod")
+	}
+}
+
+func TestStaticPodController(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeStaticPod(t, dir, "etcd", "etcd:3.5")
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+			UID:  "node0-uid",
+		},
+	}
+	clientset := fake.NewSimpleClientset(node)
+	c, err := NewStaticPodController(StaticPodControllerConfig{
+		TypedClient:     clientset,
+		NodeCacheGetter: fakeNodeGetter{node.Name: node},
+		ManifestPath:    dir,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.sync(ctx, node.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod, err := clientset.CoreV1().Pods("kube-system").Get(ctx, "etcd-node0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod.Spec.NodeName != node.Name {
+		t.Errorf("nodeName = %q, want %q", pod.Spec.NodeName, node.Name)
+	}
+	if !isMirrorPod(pod) || pod.Annotations[configSourceAnnotation] != "file" {
+		t.Errorf("annotations = %v, want a mirror pod from file", pod.Annotations)
+	}
+	if len(pod.OwnerReferences) != 1 || pod.OwnerReferences[0].UID != node.UID {
+		t.Errorf("ownerReferences = %v, want the node", pod.OwnerReferences)
+	}
+
+	// Up to date
+	err = c.sync(ctx, node.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = clientset.CoreV1().Pods("kube-system").Get(ctx, "etcd-node0", metav1.GetOptions{})
+	if err != nil {
+		t.Errorf("mirror pod up to date is deleted: %v", err)
+	}
+
+	// Out of date
+	writeStaticPod(t, dir, "etcd", "etcd:3.6")
+	err = c.sync(ctx, node.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = clientset.CoreV1().Pods("kube-system").Get(ctx, "etcd-node0", metav1.GetOptions{})
+	if err == nil {
+		t.Errorf("mirror pod out of date is not deleted")
+	}
+
+	// Created again
+	err = c.sync(ctx, node.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod, err = clientset.CoreV1().Pods("kube-system").Get(ctx, "etcd-node0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := pod.Spec.Containers[0].Image; image != "etcd:3.6" {
+		t.Errorf("image = %q, want %q", image, "etcd:3.6")
+	}
+}
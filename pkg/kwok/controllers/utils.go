@@ -17,11 +17,20 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
 	"net"
+	"strings"
 	"sync"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
 	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
 )
 
@@ -33,25 +42,94 @@ func addIP(ip net.IP, add uint64) net.IP {
 	return utilsnet.AddIP(ip, add)
 }
 
+// IPAM allocates the IPs of the pods from a CIDR
+type IPAM interface {
+	// Allocate returns an IP that is not in use, or an error if the CIDR is exhausted.
+	Allocate() (string, error)
+	// Release reclaims the IP, so it can be allocated again.
+	Release(ip string)
+	// Use marks the IP as in use, e.g. the IP of the pod that existed before kwok was started.
+	Use(ip string)
+}
+
+// NewIPAM returns the default IPAM of the CIDR,
+// which allocates the IPs one after another from the IP of the CIDR, wrapping around at the end of it,
+// so the released IPs are not allocated again until the others are used up.
+func NewIPAM(cidr string) (IPAM, error) {
+	ipnet, err := parseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	return newIPPool(ipnet), nil
+}
+
 type ipPool struct {
-	mut    sync.Mutex
-	used   map[string]struct{}
-	usable map[string]struct{}
-	cidr   *net.IPNet
-	index  uint64
+	mut     sync.Mutex
+	used    map[string]struct{}
+	cidr    *net.IPNet
+	network net.IP
+	start   net.IP
+	offset  uint64
+	size    uint64
+	index   uint64
 }
 
 func newIPPool(cidr *net.IPNet) *ipPool {
+	ones, bits := cidr.Mask.Size()
+	hostBits := bits - ones
+	network := cidr.IP.Mask(cidr.Mask).To16()
+	start := cidr.IP.To16()
+	isIPv4 := bits == 32
+
+	offset := hostOffset(network, start)
+	if offset == 0 && hostBits > 1 {
+		// Skip the network address
+		offset = 1
+		start = addIP(network, 1)
+	}
+
+	var size uint64
+	if hostBits >= 64 {
+		size = math.MaxUint64 - offset
+	} else {
+		size = uint64(1)<<hostBits - offset
+		if isIPv4 && hostBits > 1 {
+			// Skip the broadcast address
+			size--
+		}
+	}
 	return &ipPool{
-		used:   make(map[string]struct{}),
-		usable: make(map[string]struct{}),
-		cidr:   cidr,
+		used:    make(map[string]struct{}),
+		cidr:    cidr,
+		network: network,
+		start:   start,
+		offset:  offset,
+		size:    size,
 	}
 }
 
-func (i *ipPool) new() string {
+// hostOffset returns the offset of the IP from the network address, in the last 64 bits
+func hostOffset(network, ip net.IP) uint64 {
+	if n := network.To4(); n != nil {
+		network = n
+		ip = ip.To4()
+	}
+	var offset uint64
+	for i := len(ip) - 8; i < len(ip); i++ {
+		if i < 0 {
+			continue
+		}
+		offset = offset<<8 | uint64(ip[i]-network[i])
+	}
+	return offset
+}
+
+func (i *ipPool) new() (string, error) {
+	if uint64(len(i.used)) >= i.size {
+		return "", fmt.Errorf("no IP available in CIDR %s", i.cidr)
+	}
 	for {
-		ip := addIP(i.cidr.IP, i.index).String()
+		ip := addIP(i.start, i.index%i.size).String()
 		i.index++
 
 		if _, ok := i.used[ip]; ok {
@@ -59,48 +137,85 @@ func (i *ipPool) new() string {
 		}
 
 		i.used[ip] = struct{}{}
-		i.usable[ip] = struct{}{}
-		return ip
+		return ip, nil
 	}
 }
 
-func (i *ipPool) Get() string {
+func (i *ipPool) Allocate() (string, error) {
 	i.mut.Lock()
 	defer i.mut.Unlock()
-	ip := ""
-	if len(i.usable) != 0 {
-		for s := range i.usable {
-			ip = s
-			break
-		}
+	return i.new()
+}
+
+// allocatable returns the IP in the canonical form if it is in the range of the IPs allocated by the pool,
+// the network address, the broadcast address and the IPs before the IP of the CIDR are out of the range
+func (i *ipPool) allocatable(ip string) (string, bool) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || !i.cidr.Contains(parsed) {
+		return "", false
 	}
-	if ip == "" {
-		ip = i.new()
+	offset := hostOffset(i.network, parsed.To16())
+	if offset < i.offset || offset-i.offset >= i.size {
+		return "", false
 	}
-	delete(i.usable, ip)
-	i.used[ip] = struct{}{}
-	return ip
+	return parsed.String(), true
 }
 
-func (i *ipPool) Put(ip string) {
+func (i *ipPool) Release(ip string) {
 	i.mut.Lock()
 	defer i.mut.Unlock()
-	if !i.cidr.Contains(net.ParseIP(ip)) {
+	ip, ok := i.allocatable(ip)
+	if !ok {
 		return
 	}
 	delete(i.used, ip)
-	i.usable[ip] = struct{}{}
 }
 
 func (i *ipPool) Use(ip string) {
 	i.mut.Lock()
 	defer i.mut.Unlock()
-	if !i.cidr.Contains(net.ParseIP(ip)) {
+	ip, ok := i.allocatable(ip)
+	if !ok {
 		return
 	}
 	i.used[ip] = struct{}{}
 }
 
+// getPodIPs returns the IPs of the pod
+func getPodIPs(pod *corev1.Pod) []string {
+	if len(pod.Status.PodIPs) == 0 {
+		if pod.Status.PodIP == "" {
+			return nil
+		}
+		return []string{pod.Status.PodIP}
+	}
+	ips := make([]string, 0, len(pod.Status.PodIPs))
+	for _, ip := range pod.Status.PodIPs {
+		ips = append(ips, ip.IP)
+	}
+	return ips
+}
+
+// splitList splits the comma-separated list, e.g. the IPs or the CIDRs of the IPv4 and IPv6 families for dual-stack
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// firstOf returns the first item of the list, or empty if the list is empty
+func firstOf(list []string) string {
+	if len(list) == 0 {
+		return ""
+	}
+	return list[0]
+}
+
 func labelsParse(selector string) (labels.Selector, error) {
 	if selector == "" {
 		return nil, nil
@@ -108,8 +223,66 @@ func labelsParse(selector string) (labels.Selector, error) {
 	return labels.Parse(selector)
 }
 
+// containerID returns the ID of the container of the pod in the format of the container runtime,
+// it is derived from the pod and the container, so the status of the container keeps the same ID across the stages.
+func containerID(podUID, containerName string) string {
+	sum := sha256.Sum256([]byte(podUID + "/" + containerName))
+	return "containerd://" + hex.EncodeToString(sum[:])
+}
+
+// imageID returns the digested reference of the image, as the container runtime resolves it,
+// the image already referenced by digest is returned as is.
+func imageID(image string) string {
+	if strings.Contains(image, "@") {
+		return image
+	}
+	name := image
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	sum := sha256.Sum256([]byte(image))
+	return name + "@sha256:" + hex.EncodeToString(sum[:])
+}
+
+// stagePaused returns whether the stages are paused by the annotations of the resource or the Stage
+func stagePaused(annotations map[string]string) bool {
+	return annotations[v1alpha1.StagePausedAnnotation] == "true"
+}
+
 type resourceStageJob[T any] struct {
 	Resource T
-	Stage    *LifecycleStage
-	Key      string
+	// Stage is the stage to be played, nil means that the resource is only rechecked against the stages.
+	Stage *LifecycleStage
+	Key   string
+	// Step is the index of the patch of the stage to be applied, starting from 1,
+	// 0 means the next of the stage.
+	Step int
+}
+
+// scaleDuration divides the delay of the stages by the time scale,
+// a time scale greater than 1 plays the stages faster, and less than 1 slower.
+func scaleDuration(d time.Duration, timeScale float64) time.Duration {
+	if timeScale <= 0 || timeScale == 1 {
+		return d
+	}
+	return time.Duration(float64(d) / timeScale)
+}
+
+// stageDelay returns the delay of the stage divided by the time scale,
+// except the delay until the schedule of the stage, which is the wall-clock time.
+func stageDelay(ctx context.Context, stage *LifecycleStage, data interface{}, now time.Time, timeScale float64) time.Duration {
+	delay, _ := stage.Delay(ctx, data, now)
+	if stage.Scheduled() {
+		return delay
+	}
+	return scaleDuration(delay, timeScale)
+}
+
+// stagePatchDelay returns the delay before the patch of the stage is applied.
+func stagePatchDelay(stage *LifecycleStage, step int) time.Duration {
+	patch := stage.Next().Patches[step-1]
+	if patch.DelayMilliseconds == nil || *patch.DelayMilliseconds <= 0 {
+		return 0
+	}
+	return time.Duration(*patch.DelayMilliseconds) * time.Millisecond
 }
@@ -19,7 +19,9 @@ package controllers
 import (
 	"net"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func Test_parseCIDR(t *testing.T) {
@@ -125,9 +127,172 @@ func Test_ipPool_new(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			pool := newIPPool(tt.fields.cidr)
-			if got := pool.new(); got != tt.want {
+			if got, _ := pool.new(); got != tt.want {
 				t.Errorf("new() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestIPAM(t *testing.T) {
+	tests := []struct {
+		name     string
+		cidr     string
+		used     []string
+		release  int
+		want     []string
+		wantFail bool
+	}{
+		{
+			name: "skip network and broadcast address",
+			cidr: "10.0.0.0/30",
+			want: []string{"10.0.0.1", "10.0.0.2"},
+			// The CIDR is exhausted
+			wantFail: true,
+		},
+		{
+			name: "from the ip of the cidr",
+			cidr: "10.0.0.2/29",
+			want: []string{"10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"},
+			// The CIDR is exhausted
+			wantFail: true,
+		},
+		{
+			name:    "released ip is not reused until the others are used up",
+			cidr:    "10.0.0.0/29",
+			release: 1,
+			want:    []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6", "10.0.0.1"},
+		},
+		{
+			name: "used ips out of the range are not counted",
+			cidr: "10.0.0.2/29",
+			used: []string{"10.0.0.0", "10.0.0.1", "10.0.0.7", "10.0.1.1", "10.0.0.3"},
+			want: []string{"10.0.0.2", "10.0.0.4", "10.0.0.5", "10.0.0.6"},
+			// The CIDR is exhausted
+			wantFail: true,
+		},
+		{
+			name: "ipv6",
+			cidr: "fd00:10::/64",
+			want: []string{"fd00:10::1", "fd00:10::2", "fd00:10::3"},
+		},
+		{
+			name: "ipv6 exhausted",
+			cidr: "fd00:10::/126",
+			want: []string{"fd00:10::1", "fd00:10::2", "fd00:10::3"},
+			// The CIDR is exhausted
+			wantFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ipam, err := NewIPAM(tt.cidr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, ip := range tt.used {
+				ipam.Use(ip)
+			}
+			var got []string
+			for i := range tt.want {
+				ip, err := ipam.Allocate()
+				if err != nil {
+					t.Fatalf("Allocate() %d error = %v", i, err)
+				}
+				got = append(got, ip)
+				if tt.release != 0 && i == len(tt.want)-tt.release-1 {
+					for _, ip := range got[:tt.release] {
+						ipam.Release(ip)
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Allocate() = %v, want %v", got, tt.want)
+			}
+			if tt.wantFail {
+				if ip, err := ipam.Allocate(); err == nil {
+					t.Errorf("Allocate() = %v, want error", ip)
+				}
+			}
+		})
+	}
+}
+
+func Test_scaleDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		duration  time.Duration
+		timeScale float64
+		want      time.Duration
+	}{
+		{
+			name:      "no scale",
+			duration:  time.Hour,
+			timeScale: 0,
+			want:      time.Hour,
+		},
+		{
+			name:      "faster",
+			duration:  time.Hour,
+			timeScale: 60,
+			want:      time.Minute,
+		},
+		{
+			name:      "slower",
+			duration:  time.Minute,
+			timeScale: 0.5,
+			want:      2 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scaleDuration(tt.duration, tt.timeScale); got != tt.want {
+				t.Errorf("scaleDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_containerID(t *testing.T) {
+	id := containerID("uid0", "app")
+	if !strings.HasPrefix(id, "containerd://") || len(id) != len("containerd://")+64 {
+		t.Errorf("containerID() = %q, want containerd:// and 64 hex characters", id)
+	}
+	if got := containerID("uid0", "app"); got != id {
+		t.Errorf("containerID() = %q, want stable %q", got, id)
+	}
+	if got := containerID("uid0", "sidecar"); got == id {
+		t.Errorf("containerID() = %q, want different from the other container", got)
+	}
+}
+
+func Test_imageID(t *testing.T) {
+	tests := []struct {
+		name       string
+		image      string
+		wantPrefix string
+	}{
+		{
+			name:       "tag",
+			image:      "nginx:1.25",
+			wantPrefix: "nginx@sha256:",
+		},
+		{
+			name:       "registry with port",
+			image:      "localhost:5000/app",
+			wantPrefix: "localhost:5000/app@sha256:",
+		},
+		{
+			name:       "digest",
+			image:      "nginx@sha256:0123",
+			wantPrefix: "nginx@sha256:0123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := imageID(tt.image); !strings.HasPrefix(got, tt.wantPrefix) {
+				t.Errorf("imageID() = %q, want prefix %q", got, tt.wantPrefix)
+			}
+		})
+	}
+}
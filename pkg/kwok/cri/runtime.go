@@ -0,0 +1,373 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+// The labels set by the kubelet on the sandboxes and the containers,
+// which are what the tools talking CRI use to find the pods.
+const (
+	podNameLabel       = "io.kubernetes.pod.name"
+	podNamespaceLabel  = "io.kubernetes.pod.namespace"
+	podUIDLabel        = "io.kubernetes.pod.uid"
+	containerNameLabel = "io.kubernetes.container.name"
+)
+
+// nodeService is the read-only CRI of a node, the calls changing the state,
+// e.g. RunPodSandbox and CreateContainer, are unimplemented as the pods are driven by the stages.
+type nodeService struct {
+	runtimeapi.UnimplementedRuntimeServiceServer
+	runtimeapi.UnimplementedImageServiceServer
+
+	nodeName string
+	server   *Server
+}
+
+// sandbox is a pod on the node with its containers
+type sandbox struct {
+	status     *runtimeapi.PodSandboxStatus
+	containers []*runtimeapi.ContainerStatus
+}
+
+// Version returns the runtime name, runtime version and runtime API version.
+func (n *nodeService) Version(ctx context.Context, req *runtimeapi.VersionRequest) (*runtimeapi.VersionResponse, error) {
+	return &runtimeapi.VersionResponse{
+		Version:           "0.1.0",
+		RuntimeName:       "kwok",
+		RuntimeVersion:    consts.Version,
+		RuntimeApiVersion: "v1",
+	}, nil
+}
+
+// Status returns the status of the runtime, which is always ready.
+func (n *nodeService) Status(ctx context.Context, req *runtimeapi.StatusRequest) (*runtimeapi.StatusResponse, error) {
+	return &runtimeapi.StatusResponse{
+		Status: &runtimeapi.RuntimeStatus{
+			Conditions: []*runtimeapi.RuntimeCondition{
+				{Type: runtimeapi.RuntimeReady, Status: true},
+				{Type: runtimeapi.NetworkReady, Status: true},
+			},
+		},
+	}, nil
+}
+
+// ListPodSandbox returns a list of the pods on the node.
+func (n *nodeService) ListPodSandbox(ctx context.Context, req *runtimeapi.ListPodSandboxRequest) (*runtimeapi.ListPodSandboxResponse, error) {
+	filter := req.GetFilter()
+	items := []*runtimeapi.PodSandbox{}
+	for _, sb := range n.sandboxes() {
+		s := sb.status
+		if filter != nil {
+			if filter.Id != "" && filter.Id != s.Id {
+				continue
+			}
+			if filter.State != nil && filter.State.State != s.State {
+				continue
+			}
+			if !matchLabels(filter.LabelSelector, s.Labels) {
+				continue
+			}
+		}
+		items = append(items, &runtimeapi.PodSandbox{
+			Id:          s.Id,
+			Metadata:    s.Metadata,
+			State:       s.State,
+			CreatedAt:   s.CreatedAt,
+			Labels:      s.Labels,
+			Annotations: s.Annotations,
+		})
+	}
+	return &runtimeapi.ListPodSandboxResponse{Items: items}, nil
+}
+
+// PodSandboxStatus returns the status of the pod.
+func (n *nodeService) PodSandboxStatus(ctx context.Context, req *runtimeapi.PodSandboxStatusRequest) (*runtimeapi.PodSandboxStatusResponse, error) {
+	for _, sb := range n.sandboxes() {
+		if sb.status.Id == req.PodSandboxId {
+			return &runtimeapi.PodSandboxStatusResponse{Status: sb.status}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "pod sandbox %q not found", req.PodSandboxId)
+}
+
+// ListContainers lists all containers of the pods on the node.
+func (n *nodeService) ListContainers(ctx context.Context, req *runtimeapi.ListContainersRequest) (*runtimeapi.ListContainersResponse, error) {
+	filter := req.GetFilter()
+	containers := []*runtimeapi.Container{}
+	for _, sb := range n.sandboxes() {
+		if filter != nil && filter.PodSandboxId != "" && filter.PodSandboxId != sb.status.Id {
+			continue
+		}
+		for _, c := range sb.containers {
+			if filter != nil {
+				if filter.Id != "" && filter.Id != c.Id {
+					continue
+				}
+				if filter.State != nil && filter.State.State != c.State {
+					continue
+				}
+				if !matchLabels(filter.LabelSelector, c.Labels) {
+					continue
+				}
+			}
+			containers = append(containers, &runtimeapi.Container{
+				Id:           c.Id,
+				PodSandboxId: sb.status.Id,
+				Metadata:     c.Metadata,
+				Image:        c.Image,
+				ImageRef:     c.ImageRef,
+				State:        c.State,
+				CreatedAt:    c.CreatedAt,
+				Labels:       c.Labels,
+				Annotations:  c.Annotations,
+			})
+		}
+	}
+	return &runtimeapi.ListContainersResponse{Containers: containers}, nil
+}
+
+// ContainerStatus returns status of the container.
+func (n *nodeService) ContainerStatus(ctx context.Context, req *runtimeapi.ContainerStatusRequest) (*runtimeapi.ContainerStatusResponse, error) {
+	for _, sb := range n.sandboxes() {
+		for _, c := range sb.containers {
+			if c.Id == req.ContainerId {
+				return &runtimeapi.ContainerStatusResponse{Status: c}, nil
+			}
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "container %q not found", req.ContainerId)
+}
+
+// ListImages lists the images of the containers on the node.
+func (n *nodeService) ListImages(ctx context.Context, req *runtimeapi.ListImagesRequest) (*runtimeapi.ListImagesResponse, error) {
+	want := req.GetFilter().GetImage().GetImage()
+	images := []*runtimeapi.Image{}
+	for _, image := range n.images() {
+		if want != "" && want != image.Id {
+			continue
+		}
+		images = append(images, image)
+	}
+	return &runtimeapi.ListImagesResponse{Images: images}, nil
+}
+
+// ImageStatus returns the status of the image, the image is nil if it is not on the node.
+func (n *nodeService) ImageStatus(ctx context.Context, req *runtimeapi.ImageStatusRequest) (*runtimeapi.ImageStatusResponse, error) {
+	want := req.GetImage().GetImage()
+	for _, image := range n.images() {
+		if want == image.Id {
+			return &runtimeapi.ImageStatusResponse{Image: image}, nil
+		}
+	}
+	return &runtimeapi.ImageStatusResponse{}, nil
+}
+
+// ImageFsInfo returns information of the filesystem that is used to store images, which is empty.
+func (n *nodeService) ImageFsInfo(ctx context.Context, req *runtimeapi.ImageFsInfoRequest) (*runtimeapi.ImageFsInfoResponse, error) {
+	return &runtimeapi.ImageFsInfoResponse{}, nil
+}
+
+func (n *nodeService) pods() []*corev1.Pod {
+	refs, ok := n.server.dataSource.ListPods(n.nodeName)
+	if !ok {
+		return nil
+	}
+	pods := make([]*corev1.Pod, 0, len(refs))
+	for _, ref := range refs {
+		pod, ok := n.server.podCacheGetter.GetWithNamespace(ref.Name, ref.Namespace)
+		if !ok {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+func (n *nodeService) sandboxes() []sandbox {
+	pods := n.pods()
+	sandboxes := make([]sandbox, 0, len(pods))
+	for _, pod := range pods {
+		sandboxes = append(sandboxes, podToSandbox(pod))
+	}
+	return sandboxes
+}
+
+func (n *nodeService) images() []*runtimeapi.Image {
+	seen := map[string]struct{}{}
+	images := []*runtimeapi.Image{}
+	for _, sb := range n.sandboxes() {
+		for _, c := range sb.containers {
+			image := c.Image.Image
+			if _, ok := seen[image]; ok {
+				continue
+			}
+			seen[image] = struct{}{}
+			images = append(images, &runtimeapi.Image{
+				Id:       image,
+				RepoTags: []string{image},
+				Spec:     c.Image,
+			})
+		}
+	}
+	return images
+}
+
+// podToSandbox converts the pod to the sandbox with the containers which have a status,
+// the containers not created yet are not listed as the real runtime does.
+func podToSandbox(pod *corev1.Pod) sandbox {
+	podLabels := map[string]string{
+		podNameLabel:      pod.Name,
+		podNamespaceLabel: pod.Namespace,
+		podUIDLabel:       string(pod.UID),
+	}
+
+	labels := map[string]string{}
+	for k, v := range pod.Labels {
+		labels[k] = v
+	}
+	for k, v := range podLabels {
+		labels[k] = v
+	}
+
+	state := runtimeapi.PodSandboxState_SANDBOX_READY
+	if pod.DeletionTimestamp != nil ||
+		pod.Status.Phase == corev1.PodSucceeded ||
+		pod.Status.Phase == corev1.PodFailed {
+		state = runtimeapi.PodSandboxState_SANDBOX_NOTREADY
+	}
+
+	s := &runtimeapi.PodSandboxStatus{
+		Id: string(pod.UID),
+		Metadata: &runtimeapi.PodSandboxMetadata{
+			Name:      pod.Name,
+			Uid:       string(pod.UID),
+			Namespace: pod.Namespace,
+		},
+		State:       state,
+		CreatedAt:   pod.CreationTimestamp.UnixNano(),
+		Labels:      labels,
+		Annotations: pod.Annotations,
+	}
+	if pod.Status.PodIP != "" {
+		s.Network = &runtimeapi.PodSandboxNetworkStatus{
+			Ip: pod.Status.PodIP,
+		}
+		for _, ip := range pod.Status.PodIPs {
+			if ip.IP == pod.Status.PodIP {
+				continue
+			}
+			s.Network.AdditionalIps = append(s.Network.AdditionalIps, &runtimeapi.PodIP{Ip: ip.IP})
+		}
+	}
+
+	containers := []*runtimeapi.ContainerStatus{}
+	containers = appendContainers(containers, pod, podLabels, pod.Spec.InitContainers, pod.Status.InitContainerStatuses)
+	containers = appendContainers(containers, pod, podLabels, pod.Spec.Containers, pod.Status.ContainerStatuses)
+	return sandbox{
+		status:     s,
+		containers: containers,
+	}
+}
+
+func appendContainers(containers []*runtimeapi.ContainerStatus, pod *corev1.Pod, podLabels map[string]string, specs []corev1.Container, statuses []corev1.ContainerStatus) []*runtimeapi.ContainerStatus {
+	for _, spec := range specs {
+		var cs *corev1.ContainerStatus
+		for i := range statuses {
+			if statuses[i].Name == spec.Name {
+				cs = &statuses[i]
+				break
+			}
+		}
+		if cs == nil {
+			continue
+		}
+
+		labels := map[string]string{
+			containerNameLabel: spec.Name,
+		}
+		for k, v := range podLabels {
+			labels[k] = v
+		}
+
+		c := &runtimeapi.ContainerStatus{
+			Id: containerID(pod, cs),
+			Metadata: &runtimeapi.ContainerMetadata{
+				Name:    spec.Name,
+				Attempt: uint32(cs.RestartCount),
+			},
+			CreatedAt: pod.CreationTimestamp.UnixNano(),
+			Image:     &runtimeapi.ImageSpec{Image: spec.Image},
+			ImageRef:  cs.ImageID,
+			Labels:    labels,
+		}
+		switch {
+		case cs.State.Running != nil:
+			c.State = runtimeapi.ContainerState_CONTAINER_RUNNING
+			c.StartedAt = cs.State.Running.StartedAt.UnixNano()
+		case cs.State.Terminated != nil:
+			c.State = runtimeapi.ContainerState_CONTAINER_EXITED
+			c.StartedAt = cs.State.Terminated.StartedAt.UnixNano()
+			c.FinishedAt = cs.State.Terminated.FinishedAt.UnixNano()
+			c.ExitCode = cs.State.Terminated.ExitCode
+			c.Reason = cs.State.Terminated.Reason
+			c.Message = cs.State.Terminated.Message
+		case cs.State.Waiting != nil:
+			c.State = runtimeapi.ContainerState_CONTAINER_CREATED
+			c.Reason = cs.State.Waiting.Reason
+			c.Message = cs.State.Waiting.Message
+		default:
+			c.State = runtimeapi.ContainerState_CONTAINER_UNKNOWN
+		}
+		containers = append(containers, c)
+	}
+	return containers
+}
+
+// containerID returns the id of the container in the status without the scheme, e.g. containerd://,
+// or one derived from the pod and the name of the container if it is not set.
+func containerID(pod *corev1.Pod, cs *corev1.ContainerStatus) string {
+	if cs.ContainerID != "" {
+		_, id, ok := strings.Cut(cs.ContainerID, "://")
+		if ok {
+			return id
+		}
+		return cs.ContainerID
+	}
+	sum := sha256.Sum256([]byte(string(pod.UID) + "/" + cs.Name))
+	return hex.EncodeToString(sum[:])
+}
+
+func matchLabels(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cri
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+type fakeDataSource struct {
+	pods []*corev1.Pod
+}
+
+func (f *fakeDataSource) ListNodes() []string {
+	return []string{"node0"}
+}
+
+func (f *fakeDataSource) ListPods(nodeName string) ([]log.ObjectRef, bool) {
+	refs := []log.ObjectRef{}
+	for _, pod := range f.pods {
+		if pod.Spec.NodeName == nodeName {
+			refs = append(refs, log.KObj(pod))
+		}
+	}
+	return refs, true
+}
+
+func (f *fakeDataSource) Get(name string) (*corev1.Pod, bool) {
+	return f.GetWithNamespace(name, "")
+}
+
+func (f *fakeDataSource) GetWithNamespace(name, namespace string) (*corev1.Pod, bool) {
+	for _, pod := range f.pods {
+		if pod.Name == name && pod.Namespace == namespace {
+			return pod, true
+		}
+	}
+	return nil, false
+}
+
+func (f *fakeDataSource) List() []*corev1.Pod {
+	return f.pods
+}
+
+func newTestService() *nodeService {
+	ds := &fakeDataSource{
+		pods: []*corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "web",
+					Namespace: "default",
+					UID:       "uid-web",
+					Labels:    map[string]string{"app": "web"},
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "node0",
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: "nginx:1.25"},
+						{Name: "sidecar", Image: "busybox"},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					PodIP: "10.0.0.2",
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:         "nginx",
+							ContainerID:  "containerd://abc",
+							RestartCount: 1,
+							State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+						},
+						{
+							Name:  "sidecar",
+							State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+						},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job",
+					Namespace: "default",
+					UID:       "uid-job",
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "node0",
+					Containers: []corev1.Container{
+						{Name: "job", Image: "busybox"},
+					},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodSucceeded,
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "other",
+					Namespace: "default",
+					UID:       "uid-other",
+				},
+				Spec: corev1.PodSpec{
+					NodeName: "node1",
+				},
+			},
+		},
+	}
+	return &nodeService{
+		nodeName: "node0",
+		server: &Server{
+			dataSource:     ds,
+			podCacheGetter: ds,
+		},
+	}
+}
+
+func TestListPodSandbox(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+
+	resp, err := svc.ListPodSandbox(ctx, &runtimeapi.ListPodSandboxRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Items) != 2 {
+		t.Fatalf("want 2 sandboxes, got %d", len(resp.Items))
+	}
+
+	resp, err = svc.ListPodSandbox(ctx, &runtimeapi.ListPodSandboxRequest{
+		Filter: &runtimeapi.PodSandboxFilter{
+			State: &runtimeapi.PodSandboxStateValue{State: runtimeapi.PodSandboxState_SANDBOX_READY},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].Id != "uid-web" {
+		t.Fatalf("want the ready sandbox uid-web, got %v", resp.Items)
+	}
+	if resp.Items[0].Labels[podNameLabel] != "web" || resp.Items[0].Labels["app"] != "web" {
+		t.Errorf("want the labels of the pod, got %v", resp.Items[0].Labels)
+	}
+
+	status, err := svc.PodSandboxStatus(ctx, &runtimeapi.PodSandboxStatusRequest{PodSandboxId: "uid-web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status.Network.GetIp() != "10.0.0.2" {
+		t.Errorf("want ip 10.0.0.2, got %v", status.Status.Network)
+	}
+
+	_, err = svc.PodSandboxStatus(ctx, &runtimeapi.PodSandboxStatusRequest{PodSandboxId: "uid-other"})
+	if err == nil {
+		t.Errorf("want not found for the pod on another node")
+	}
+}
+
+func TestListContainers(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+
+	resp, err := svc.ListContainers(ctx, &runtimeapi.ListContainersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Containers) != 2 {
+		t.Fatalf("want 2 containers with status, got %d", len(resp.Containers))
+	}
+
+	resp, err = svc.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+		Filter: &runtimeapi.ContainerFilter{
+			State: &runtimeapi.ContainerStateValue{State: runtimeapi.ContainerState_CONTAINER_RUNNING},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Containers) != 1 {
+		t.Fatalf("want 1 running container, got %d", len(resp.Containers))
+	}
+	c := resp.Containers[0]
+	if c.Id != "abc" || c.PodSandboxId != "uid-web" || c.Metadata.Attempt != 1 {
+		t.Errorf("unexpected container %v", c)
+	}
+
+	resp, err = svc.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+		Filter: &runtimeapi.ContainerFilter{
+			LabelSelector: map[string]string{containerNameLabel: "sidecar"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Containers) != 1 {
+		t.Fatalf("want 1 sidecar container, got %d", len(resp.Containers))
+	}
+
+	status, err := svc.ContainerStatus(ctx, &runtimeapi.ContainerStatusRequest{ContainerId: resp.Containers[0].Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status.State != runtimeapi.ContainerState_CONTAINER_EXITED || status.Status.ExitCode != 1 {
+		t.Errorf("want the exited container with exit code 1, got %v", status.Status)
+	}
+}
+
+func TestListImages(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+
+	resp, err := svc.ListImages(ctx, &runtimeapi.ListImagesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Images) != 2 {
+		t.Fatalf("want 2 images, got %v", resp.Images)
+	}
+
+	status, err := svc.ImageStatus(ctx, &runtimeapi.ImageStatusRequest{Image: &runtimeapi.ImageSpec{Image: "redis"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Image != nil {
+		t.Errorf("want no image, got %v", status.Image)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cri serves a fake CRI endpoint for each of the nodes managed by kwok,
+// which reflects the pods on the node as the sandboxes and the containers.
+package cri
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/informer"
+)
+
+const (
+	// syncInterval is the interval to sync the sockets with the managed nodes
+	syncInterval = 5 * time.Second
+)
+
+// DataSource is the interface that provides the nodes and the pods on them.
+type DataSource interface {
+	ListNodes() []string
+	ListPods(nodeName string) ([]log.ObjectRef, bool)
+}
+
+// Config holds configurations needed by the fake CRI server.
+type Config struct {
+	// SocketDir is the directory of the sockets, the socket of a node is <SocketDir>/<node>.sock
+	SocketDir string
+
+	DataSource     DataSource
+	PodCacheGetter informer.Getter[*corev1.Pod]
+}
+
+// Server serves a fake CRI endpoint for each of the managed nodes.
+type Server struct {
+	socketDir      string
+	dataSource     DataSource
+	podCacheGetter informer.Getter[*corev1.Pod]
+
+	mut     sync.Mutex
+	servers map[string]*grpc.Server
+}
+
+// NewServer creates a new Server.
+func NewServer(conf Config) (*Server, error) {
+	err := os.MkdirAll(conf.SocketDir, 0750)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		socketDir:      conf.SocketDir,
+		dataSource:     conf.DataSource,
+		podCacheGetter: conf.PodCacheGetter,
+		servers:        map[string]*grpc.Server{},
+	}, nil
+}
+
+// Run serves the sockets of the managed nodes until the context is done,
+// the sockets are added and removed as the nodes come and go.
+func (s *Server) Run(ctx context.Context) {
+	defer s.stopAll()
+	for {
+		s.sync(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(syncInterval):
+		}
+	}
+}
+
+// SocketPath returns the path of the socket of the node
+func (s *Server) SocketPath(nodeName string) string {
+	return filepath.Join(s.socketDir, nodeName+".sock")
+}
+
+func (s *Server) sync(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	nodes := map[string]struct{}{}
+	for _, nodeName := range s.dataSource.ListNodes() {
+		nodes[nodeName] = struct{}{}
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	for nodeName, srv := range s.servers {
+		if _, ok := nodes[nodeName]; ok {
+			continue
+		}
+		srv.Stop()
+		delete(s.servers, nodeName)
+		_ = os.Remove(s.SocketPath(nodeName))
+		logger.Info("Stopped CRI endpoint", "node", nodeName)
+	}
+
+	for nodeName := range nodes {
+		if _, ok := s.servers[nodeName]; ok {
+			continue
+		}
+		srv, err := s.serve(ctx, nodeName)
+		if err != nil {
+			logger.Error("Failed to serve CRI endpoint", err, "node", nodeName)
+			continue
+		}
+		s.servers[nodeName] = srv
+		logger.Info("Serving CRI endpoint", "node", nodeName, "socket", s.SocketPath(nodeName))
+	}
+}
+
+func (s *Server) serve(ctx context.Context, nodeName string) (*grpc.Server, error) {
+	socketPath := s.SocketPath(nodeName)
+	// the socket left behind by the last run is removed, or the listen fails
+	_ = os.Remove(socketPath)
+
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := &nodeService{
+		nodeName: nodeName,
+		server:   s,
+	}
+	srv := grpc.NewServer()
+	runtimeapi.RegisterRuntimeServiceServer(srv, svc)
+	runtimeapi.RegisterImageServiceServer(srv, svc)
+
+	go func() {
+		err := srv.Serve(listener)
+		if err != nil {
+			log.FromContext(ctx).Error("Failed to serve CRI endpoint", err, "node", nodeName)
+		}
+	}()
+	return srv, nil
+}
+
+func (s *Server) stopAll() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for nodeName, srv := range s.servers {
+		srv.Stop()
+		_ = os.Remove(s.SocketPath(nodeName))
+	}
+	s.servers = map[string]*grpc.Server{}
+}
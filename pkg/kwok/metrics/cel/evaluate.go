@@ -18,6 +18,7 @@ package cel
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -29,6 +30,7 @@ import (
 	"github.com/wzshiming/easycel"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
 )
 
 // NodeEvaluatorConfig holds configuration for a cel program
@@ -36,8 +38,17 @@ type NodeEvaluatorConfig struct {
 	EnableEvaluatorCache bool
 	EnableResultCache    bool
 
+	// RandSeed is combined with the UID of the object by the SeededRand.
+	RandSeed int64
+
 	Now                    func() time.Time
 	StartedContainersTotal func(nodeName string) int64
+	AllocatedResource      func(nodeName, resourceName string) float64
+
+	ContainerResourceUsage           func(resourceName string, pod *corev1.Pod, containerName string) float64
+	ContainerResourceCumulativeUsage func(resourceName string, pod *corev1.Pod, containerName string) float64
+	NodeResourceUsage                func(resourceName, nodeName string) float64
+	NodeResourceCumulativeUsage      func(resourceName, nodeName string) float64
 }
 
 // NewEnvironment returns a MetricEvaluator that is able to evaluate node metrics
@@ -103,6 +114,9 @@ func (e *Environment) init() error {
 			}
 			return types.Duration{Duration: t.Duration}
 		},
+		func(uid apitypes.UID) types.String {
+			return types.String(uid)
+		},
 	}
 	types := []any{
 		corev1.Node{},
@@ -113,12 +127,15 @@ func (e *Environment) init() error {
 		corev1.PodStatus{},
 		corev1.Container{},
 		metav1.ObjectMeta{},
+		Device{},
 	}
 
 	vars := map[string]any{
 		"node":      corev1.Node{},
 		"pod":       corev1.Pod{},
 		"container": corev1.Container{},
+		"device":    Device{},
+		"le":        float64(0),
 	}
 
 	funcs := map[string][]any{}
@@ -131,7 +148,11 @@ func (e *Environment) init() error {
 
 		nowName                    = "Now"
 		startedContainersTotalName = "StartedContainersTotal"
+		allocatedResourceName      = "AllocatedResource"
+		usageName                  = "Usage"
+		cumulativeUsageName        = "CumulativeUsage"
 		mathRandName               = "Rand"
+		seededRandName             = "SeededRand"
 		sinceSecondName            = "SinceSecond"
 		unixSecondName             = "UnixSecond"
 	)
@@ -145,6 +166,10 @@ func (e *Environment) init() error {
 
 	funcs[mathRandName] = append(funcs[mathRandName], mathRand)
 
+	seededRands := seededRandFuncs(e.conf.RandSeed)
+	methods[seededRandName] = append(methods[seededRandName], seededRands...)
+	funcs[seededRandName] = append(funcs[seededRandName], seededRands...)
+
 	methods[sinceSecondName] = append(methods[sinceSecondName], sinceSecond[*corev1.Node], sinceSecond[*corev1.Pod])
 	funcs[sinceSecondName] = append(funcs[sinceSecondName], sinceSecond[*corev1.Node], sinceSecond[*corev1.Pod])
 
@@ -163,6 +188,27 @@ func (e *Environment) init() error {
 		funcs[startedContainersTotalName] = append(funcs[startedContainersTotalName], startedContainersTotal, startedContainersTotalByNode)
 	}
 
+	if e.conf.AllocatedResource != nil {
+		allocatedResource := e.conf.AllocatedResource
+		allocatedResourceByNode := func(node corev1.Node, resourceName string) float64 {
+			return e.conf.AllocatedResource(node.Name, resourceName)
+		}
+		methods[allocatedResourceName] = append(methods[allocatedResourceName], allocatedResource, allocatedResourceByNode)
+		funcs[allocatedResourceName] = append(funcs[allocatedResourceName], allocatedResource, allocatedResourceByNode)
+	}
+
+	if e.conf.ContainerResourceUsage != nil && e.conf.NodeResourceUsage != nil {
+		usages := resourceUsageFuncs(e.conf.ContainerResourceUsage, e.conf.NodeResourceUsage)
+		methods[usageName] = append(methods[usageName], usages...)
+		funcs[usageName] = append(funcs[usageName], usages...)
+	}
+
+	if e.conf.ContainerResourceCumulativeUsage != nil && e.conf.NodeResourceCumulativeUsage != nil {
+		cumulativeUsages := resourceUsageFuncs(e.conf.ContainerResourceCumulativeUsage, e.conf.NodeResourceCumulativeUsage)
+		methods[cumulativeUsageName] = append(methods[cumulativeUsageName], cumulativeUsages...)
+		funcs[cumulativeUsageName] = append(funcs[cumulativeUsageName], cumulativeUsages...)
+	}
+
 	for _, convert := range conversions {
 		err := e.registry.RegisterConversion(convert)
 		if err != nil {
@@ -244,8 +290,8 @@ type Evaluator struct {
 	program  cel.Program
 }
 
-func resultUniqueKey(node *corev1.Node, pod *corev1.Pod, container *corev1.Container) string {
-	tmp := make([]string, 0, 5)
+func resultUniqueKey(node *corev1.Node, pod *corev1.Pod, container *corev1.Container, device *Device, le float64) string {
+	tmp := make([]string, 0, 7)
 	if node != nil {
 		tmp = append(tmp, string(node.UID), node.ResourceVersion)
 	}
@@ -255,6 +301,12 @@ func resultUniqueKey(node *corev1.Node, pod *corev1.Pod, container *corev1.Conta
 	if container != nil {
 		tmp = append(tmp, container.Name)
 	}
+	if device != nil {
+		tmp = append(tmp, device.UUID)
+	}
+	if le != 0 {
+		tmp = append(tmp, strconv.FormatFloat(le, 'g', -1, 64))
+	}
 	return strings.Join(tmp, "/")
 }
 
@@ -269,7 +321,7 @@ func (e *Evaluator) evaluate(data Data) (ref.Val, error) {
 			e.cacheVer = *e.latestCacheVer
 		}
 
-		key = resultUniqueKey(data.Node, data.Pod, data.Container)
+		key = resultUniqueKey(data.Node, data.Pod, data.Container, data.Device, data.Le)
 		if val, ok := e.cache[key]; ok {
 			return val, nil
 		}
@@ -278,6 +330,8 @@ func (e *Evaluator) evaluate(data Data) (ref.Val, error) {
 		"node":      data.Node,
 		"pod":       data.Pod,
 		"container": data.Container,
+		"device":    data.Device,
+		"le":        data.Le,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate metric expression: %w", err)
@@ -333,4 +387,21 @@ type Data struct {
 	Node      *corev1.Node
 	Pod       *corev1.Pod
 	Container *corev1.Container
+	// Device is the device being evaluated, with the Pod and the Container it is allocated to.
+	Device *Device
+	// Le is the upper bound of the bucket of a histogram being evaluated.
+	Le float64
+}
+
+// Device is a device of a node, e.g. a GPU, of an extended resource advertised by the node.
+type Device struct {
+	// Resource is the extended resource of the device, e.g. nvidia.com/gpu.
+	Resource string `json:"resource"`
+	// Index is the index of the device among the devices of the resource of the node, from 0.
+	Index int64 `json:"index"`
+	// UUID is the identifier of the device derived from the node, which is the same across the scrapes.
+	UUID string `json:"uuid"`
+	// Allocated is the number of the devices of the resource allocated to the container the device is allocated to,
+	// or 0 if the device is free.
+	Allocated int64 `json:"allocated"`
 }
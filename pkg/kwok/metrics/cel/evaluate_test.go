@@ -62,3 +62,214 @@ func TestNodeEvaluation(t *testing.T) {
 		t.Errorf("expected %v, got %v", 17280, actual)
 	}
 }
+
+func TestNodeEvaluationAllocatedResource(t *testing.T) {
+	n := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+		},
+	}
+	exp := `node.AllocatedResource("nvidia.com/gpu") / 8.0`
+
+	env, err := NewEnvironment(NodeEvaluatorConfig{
+		AllocatedResource: func(nodeName, resourceName string) float64 {
+			if nodeName == "node0" && resourceName == "nvidia.com/gpu" {
+				return 2
+			}
+			return 0
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to instantiate node Evaluator: %v", err)
+	}
+
+	eval, err := env.Compile(exp)
+	if err != nil {
+		t.Fatalf("failed to compile expression: %v", err)
+	}
+
+	actual, err := eval.EvaluateFloat64(Data{
+		Node: n,
+	})
+	if err != nil {
+		t.Fatalf("evaluation failed: %v", err)
+	}
+
+	if actual != 0.25 {
+		t.Errorf("expected %v, got %v", 0.25, actual)
+	}
+}
+
+func TestEvaluationUsage(t *testing.T) {
+	n := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+		},
+	}
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod0",
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app"},
+				{Name: "sidecar"},
+			},
+		},
+	}
+
+	env, err := NewEnvironment(NodeEvaluatorConfig{
+		ContainerResourceUsage: func(resourceName string, pod *corev1.Pod, containerName string) float64 {
+			if resourceName != "cpu" {
+				return 0
+			}
+			if containerName == "app" {
+				return 0.5
+			}
+			return 0.25
+		},
+		NodeResourceUsage: func(resourceName, nodeName string) float64 {
+			if nodeName == "node0" && resourceName == "cpu" {
+				return 2
+			}
+			return 0
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to instantiate node Evaluator: %v", err)
+	}
+
+	tests := []struct {
+		exp  string
+		want float64
+	}{
+		{
+			exp:  `pod.Usage("cpu")`,
+			want: 0.75,
+		},
+		{
+			exp:  `Usage(pod, "cpu", "app")`,
+			want: 0.5,
+		},
+		{
+			exp:  `node.Usage("cpu")`,
+			want: 2,
+		},
+		{
+			exp:  `pod.Usage("memory")`,
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		eval, err := env.Compile(tt.exp)
+		if err != nil {
+			t.Fatalf("failed to compile expression %s: %v", tt.exp, err)
+		}
+
+		actual, err := eval.EvaluateFloat64(Data{
+			Node: n,
+			Pod:  p,
+		})
+		if err != nil {
+			t.Fatalf("evaluation of %s failed: %v", tt.exp, err)
+		}
+
+		if actual != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.exp, tt.want, actual)
+		}
+	}
+}
+
+func TestEvaluationLe(t *testing.T) {
+	env, err := NewEnvironment(NodeEvaluatorConfig{
+		EnableResultCache: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to instantiate node Evaluator: %v", err)
+	}
+
+	eval, err := env.Compile("le < 1.0 ? 10.0 : le * 2.0")
+	if err != nil {
+		t.Fatalf("failed to compile expression: %v", err)
+	}
+
+	n := &corev1.Node{}
+	for le, want := range map[float64]float64{0.5: 10, 2: 4, 4: 8} {
+		actual, err := eval.EvaluateFloat64(Data{
+			Node: n,
+			Le:   le,
+		})
+		if err != nil {
+			t.Fatalf("evaluation failed: %v", err)
+		}
+		if actual != want {
+			t.Errorf("le %v: expected %v, got %v", le, want, actual)
+		}
+	}
+}
+
+func TestEvaluationSeededRand(t *testing.T) {
+	n := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+			UID:  "node0-uid",
+		},
+	}
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod0",
+			UID:  "pod0-uid",
+		},
+	}
+
+	evaluate := func(seed int64, exp string) float64 {
+		env, err := NewEnvironment(NodeEvaluatorConfig{
+			RandSeed: seed,
+		})
+		if err != nil {
+			t.Fatalf("failed to instantiate node Evaluator: %v", err)
+		}
+		eval, err := env.Compile(exp)
+		if err != nil {
+			t.Fatalf("failed to compile expression %s: %v", exp, err)
+		}
+		actual, err := eval.EvaluateFloat64(Data{
+			Node: n,
+			Pod:  p,
+		})
+		if err != nil {
+			t.Fatalf("evaluation of %s failed: %v", exp, err)
+		}
+		if actual < 0 || actual >= 1 {
+			t.Fatalf("%s: expected in [0, 1), got %v", exp, actual)
+		}
+		return actual
+	}
+
+	exps := []string{
+		`pod.SeededRand()`,
+		`pod.SeededRand("cpu")`,
+		`pod.SeededRand(1.0)`,
+		`pod.SeededRand("cpu", 1.0)`,
+		`node.SeededRand()`,
+		`SeededRand(node, "cpu", 1.0)`,
+	}
+	values := map[float64]string{}
+	for _, exp := range exps {
+		got := evaluate(1, exp)
+		if again := evaluate(1, exp); again != got {
+			t.Errorf("%s: expected the same value with the same seed, got %v and %v", exp, got, again)
+		}
+		if other := evaluate(2, exp); other == got {
+			t.Errorf("%s: expected a different value with a different seed, got %v", exp, got)
+		}
+		if prev, ok := values[got]; ok {
+			t.Errorf("%s: expected a different value from %s, got %v", exp, prev, got)
+		}
+		values[got] = exp
+	}
+
+	if got, want := evaluate(1, `pod.SeededRand("cpu", 1.9)`), evaluate(1, `pod.SeededRand("cpu", 1.0)`); got != want {
+		t.Errorf("expected the step to be floored, got %v, want %v", got, want)
+	}
+}
@@ -17,10 +17,15 @@ limitations under the License.
 package cel
 
 import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
 	"math/rand"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func timeNow() time.Time {
@@ -43,3 +48,82 @@ func mathRand() float64 {
 	//nolint: gosec
 	return rand.Float64()
 }
+
+// seededRand returns a number in [0, 1) determined by the seed, the uid of the object, the key and the step,
+// the step is floored, so the number changes only when the step reaches the next integer.
+func seededRand(seed int64, uid types.UID, key string, step float64) float64 {
+	var buf [8]byte
+	h := fnv.New64a()
+	binary.LittleEndian.PutUint64(buf[:], uint64(seed))
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write([]byte(uid))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	_, _ = h.Write([]byte{0})
+	binary.LittleEndian.PutUint64(buf[:], uint64(int64(math.Floor(step))))
+	_, _ = h.Write(buf[:])
+
+	// The finalizer of the splitmix64 spreads the close inputs over the whole range.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return float64(x>>11) / (1 << 53)
+}
+
+// seededRandFuncs returns the functions of the numbers determined by the seed and the uid of the pod or the node,
+// with the optional key to tell the numbers of an object apart and the optional step to change the number over time,
+// e.g. pod.SeededRand("cpu", pod.SinceSecond() / 60.0) changes every minute.
+func seededRandFuncs(seed int64) []any {
+	return []any{
+		func(pod corev1.Pod) float64 {
+			return seededRand(seed, pod.UID, "", 0)
+		},
+		func(pod corev1.Pod, key string) float64 {
+			return seededRand(seed, pod.UID, key, 0)
+		},
+		func(pod corev1.Pod, step float64) float64 {
+			return seededRand(seed, pod.UID, "", step)
+		},
+		func(pod corev1.Pod, key string, step float64) float64 {
+			return seededRand(seed, pod.UID, key, step)
+		},
+		func(node corev1.Node) float64 {
+			return seededRand(seed, node.UID, "", 0)
+		},
+		func(node corev1.Node, key string) float64 {
+			return seededRand(seed, node.UID, key, 0)
+		},
+		func(node corev1.Node, step float64) float64 {
+			return seededRand(seed, node.UID, "", step)
+		},
+		func(node corev1.Node, key string, step float64) float64 {
+			return seededRand(seed, node.UID, key, step)
+		},
+	}
+}
+
+// resourceUsageFuncs returns the functions for the usage of the resources of the pod, the container of the pod and the node,
+// the usage of the pod is the sum of the usage of its containers.
+func resourceUsageFuncs(
+	containerUsage func(resourceName string, pod *corev1.Pod, containerName string) float64,
+	nodeUsage func(resourceName, nodeName string) float64,
+) []any {
+	return []any{
+		func(pod corev1.Pod, resourceName string) float64 {
+			var sum float64
+			for _, container := range pod.Spec.Containers {
+				sum += containerUsage(resourceName, &pod, container.Name)
+			}
+			return sum
+		},
+		func(pod corev1.Pod, resourceName, containerName string) float64 {
+			return containerUsage(resourceName, &pod, containerName)
+		},
+		func(node corev1.Node, resourceName string) float64 {
+			return nodeUsage(resourceName, node.Name)
+		},
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/kwok/metrics/cel"
+)
+
+// DeviceData returns the data of the devices of the resource in the allocatable of the node,
+// with the pods and the containers they are allocated to.
+// The devices are allocated to the containers requesting them in the order of the creation of the pods not terminated,
+// as a device plugin would, and the free ones are given an empty pod and container.
+func DeviceData(node *corev1.Node, resourceName string, pods []*corev1.Pod) []cel.Data {
+	quantity, ok := node.Status.Allocatable[corev1.ResourceName(resourceName)]
+	if !ok {
+		return nil
+	}
+	count := quantity.Value()
+	if count <= 0 {
+		return nil
+	}
+
+	nodeID := string(node.UID)
+	if nodeID == "" {
+		nodeID = node.Name
+	}
+	data := make([]cel.Data, 0, count)
+	for i := int64(0); i != count; i++ {
+		data = append(data, cel.Data{
+			Node:      node,
+			Pod:       &corev1.Pod{},
+			Container: &corev1.Container{},
+			Device: &cel.Device{
+				Resource: resourceName,
+				Index:    i,
+				UUID:     deviceUUID(nodeID, resourceName, i),
+			},
+		})
+	}
+
+	pods = append([]*corev1.Pod{}, pods...)
+	sort.SliceStable(pods, func(i, j int) bool {
+		if !pods[i].CreationTimestamp.Equal(&pods[j].CreationTimestamp) {
+			return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+		}
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	next := int64(0)
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for i := range pod.Spec.Containers {
+			container := &pod.Spec.Containers[i]
+			allocated := containerDevices(container, corev1.ResourceName(resourceName))
+			if allocated <= 0 || next+allocated > count {
+				continue
+			}
+			for j := next; j != next+allocated; j++ {
+				data[j].Pod = pod
+				data[j].Container = container
+				data[j].Device.Allocated = allocated
+			}
+			next += allocated
+		}
+	}
+	return data
+}
+
+// containerDevices returns the number of the devices of the resource the container requests,
+// the extended resources are given in the limits, and the requests are the same if they are given.
+func containerDevices(container *corev1.Container, resourceName corev1.ResourceName) int64 {
+	if q, ok := container.Resources.Limits[resourceName]; ok {
+		return q.Value()
+	}
+	if q, ok := container.Resources.Requests[resourceName]; ok {
+		return q.Value()
+	}
+	return 0
+}
+
+// deviceUUID returns the identifier of the device in the format of the UUID, derived from the node, the resource and the index.
+func deviceUUID(nodeID, resourceName string, index int64) string {
+	//nolint:gosec
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s/%s/%d", nodeID, resourceName, index)))
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeviceData(t *testing.T) {
+	const gpu = "nvidia.com/gpu"
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+			UID:  "node0-uid",
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				gpu: resource.MustParse("4"),
+			},
+		},
+	}
+	newPod := func(name string, created time.Time, phase corev1.PodPhase, gpus ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: created},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+			},
+		}
+		for i, n := range gpus {
+			container := corev1.Container{
+				Name: name + "-" + string(rune('a'+i)),
+			}
+			if n != "" {
+				container.Resources.Limits = corev1.ResourceList{
+					gpu: resource.MustParse(n),
+				}
+			}
+			pod.Spec.Containers = append(pod.Spec.Containers, container)
+		}
+		return pod
+	}
+	pods := []*corev1.Pod{
+		newPod("later", now.Add(time.Minute), corev1.PodRunning, "2"),
+		newPod("earlier", now, corev1.PodRunning, "", "1"),
+		newPod("done", now.Add(-time.Minute), corev1.PodSucceeded, "4"),
+		newPod("too-many", now.Add(2*time.Minute), corev1.PodPending, "2"),
+	}
+
+	data := DeviceData(node, gpu, pods)
+	want := []struct {
+		container string
+		allocated int64
+	}{
+		{container: "earlier-b", allocated: 1},
+		{container: "later-a", allocated: 2},
+		{container: "later-a", allocated: 2},
+		{container: "", allocated: 0},
+	}
+	if len(data) != len(want) {
+		t.Fatalf("want %d devices, got %d", len(want), len(data))
+	}
+	uuids := map[string]struct{}{}
+	for i, w := range want {
+		d := data[i]
+		if d.Device.Index != int64(i) || d.Device.Resource != gpu {
+			t.Errorf("device %d: want index %d of %s, got %d of %s", i, i, gpu, d.Device.Index, d.Device.Resource)
+		}
+		if d.Container.Name != w.container || d.Device.Allocated != w.allocated {
+			t.Errorf("device %d: want allocated %d to %q, got %d to %q", i, w.allocated, w.container, d.Device.Allocated, d.Container.Name)
+		}
+		uuids[d.Device.UUID] = struct{}{}
+	}
+	if len(uuids) != len(want) {
+		t.Errorf("want the uuids unique, got %v", uuids)
+	}
+
+	again := DeviceData(node, gpu, pods)
+	if again[0].Device.UUID != data[0].Device.UUID {
+		t.Errorf("want the uuid the same, got %s and %s", data[0].Device.UUID, again[0].Device.UUID)
+	}
+
+	if data := DeviceData(node, "example.com/fpga", pods); len(data) != 0 {
+		t.Errorf("want no devices of the resource not allocatable, got %d", len(data))
+	}
+}
@@ -139,6 +139,12 @@ func (h *histogram) Write(out *dto.Metric) error {
 		sum += le * float64(val)
 	}
 
+	// cumulative count of the remaining buckets
+	for bucketsIndex < len(buckets)-1 {
+		bucketsIndex++
+		buckets[bucketsIndex].CumulativeCount = format.Ptr(*buckets[bucketsIndex].CumulativeCount + count)
+	}
+
 	his := &dto.Histogram{
 		Bucket:      buckets,
 		SampleCount: format.Ptr(count),
@@ -23,6 +23,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	dto "github.com/prometheus/client_model/go"
 
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/utils/format"
 )
 
@@ -94,3 +95,75 @@ func TestHistogramObserve(t *testing.T) {
 		t.Errorf("Histogram mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestHistogramWriteInf(t *testing.T) {
+	his := NewHistogram(HistogramOpts{
+		Name:    "name",
+		Help:    "help",
+		Buckets: []float64{1, 2, 4},
+	})
+	his.Set(1, 3)
+
+	var out dto.Metric
+	if err := his.Write(&out); err != nil {
+		t.Fatalf("Failed to write metric: %v", err)
+	}
+
+	for _, b := range out.Histogram.Bucket {
+		if b.GetCumulativeCount() != 3 {
+			t.Errorf("Bucket le=%v: want cumulative count 3, got %d", b.GetUpperBound(), b.GetCumulativeCount())
+		}
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	tests := []struct {
+		name string
+		from *internalversion.MetricBucketsFrom
+		want []float64
+	}{
+		{
+			name: "no generated buckets",
+			want: []float64{0.1},
+		},
+		{
+			name: "linear",
+			from: &internalversion.MetricBucketsFrom{
+				Type:   internalversion.MetricBucketsTypeLinear,
+				Start:  1,
+				Factor: 2,
+				Count:  3,
+				Value:  "le",
+			},
+			want: []float64{0.1, 1, 3, 5},
+		},
+		{
+			name: "exponential",
+			from: &internalversion.MetricBucketsFrom{
+				Type:   internalversion.MetricBucketsTypeExponential,
+				Start:  0.5,
+				Factor: 2,
+				Count:  4,
+				Value:  "le",
+			},
+			want: []float64{0.1, 0.5, 1, 2, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := histogramBuckets(&internalversion.MetricConfig{
+				Buckets: []internalversion.MetricBucket{
+					{Le: 0.1, Value: "1"},
+				},
+				BucketsFrom: tt.from,
+			})
+			got := make([]float64, 0, len(buckets))
+			for _, b := range buckets {
+				got = append(got, b.Le)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Buckets mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
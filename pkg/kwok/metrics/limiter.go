@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+)
+
+var (
+	// ErrMaxSeries is returned when a scrape exceeds the max number of the series.
+	ErrMaxSeries = errors.New("exceeded the max number of the series")
+	// ErrMaxLabelValues is returned when a label of a metric exceeds the max number of the values.
+	ErrMaxLabelValues = errors.New("exceeded the max number of the values of the label")
+)
+
+// seriesLimiter bounds the series updated in a scrape, 0 means no limit.
+type seriesLimiter struct {
+	ctx            context.Context
+	maxSeries      int
+	maxLabelValues int
+
+	series      map[string]struct{}
+	labelValues map[string]map[string]struct{}
+}
+
+// reset starts a new scrape, which is canceled with the ctx.
+func (l *seriesLimiter) reset(ctx context.Context) {
+	l.ctx = ctx
+	l.series = map[string]struct{}{}
+	l.labelValues = map[string]map[string]struct{}{}
+}
+
+// admit returns an error if the series of the key would exceed the limits or the scrape is canceled,
+// otherwise the series is counted.
+func (l *seriesLimiter) admit(name, key string, labels prometheus.Labels) error {
+	if l.ctx != nil {
+		if err := l.ctx.Err(); err != nil {
+			return fmt.Errorf("failed to evaluate the series of %q in time: %w", name, err)
+		}
+	}
+	if l.series == nil {
+		return nil
+	}
+	if _, ok := l.series[key]; ok {
+		return nil
+	}
+	if l.maxSeries > 0 && len(l.series) >= l.maxSeries {
+		return fmt.Errorf("%w %d by %q", ErrMaxSeries, l.maxSeries, name)
+	}
+
+	if l.maxLabelValues <= 0 {
+		l.series[key] = struct{}{}
+		return nil
+	}
+
+	labelNames := maps.Keys(labels)
+	sort.Strings(labelNames)
+	for _, labelName := range labelNames {
+		values := l.labelValues[name+"/"+labelName]
+		if _, ok := values[labels[labelName]]; ok {
+			continue
+		}
+		if len(values) >= l.maxLabelValues {
+			return fmt.Errorf("%w %q of %q, %d", ErrMaxLabelValues, labelName, name, l.maxLabelValues)
+		}
+	}
+
+	l.series[key] = struct{}{}
+	for _, labelName := range labelNames {
+		k := name + "/" + labelName
+		values, ok := l.labelValues[k]
+		if !ok {
+			values = map[string]struct{}{}
+			l.labelValues[k] = values
+		}
+		values[labels[labelName]] = struct{}{}
+	}
+	return nil
+}
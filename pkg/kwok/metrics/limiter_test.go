@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSeriesLimiter(t *testing.T) {
+	admit := func(l *seriesLimiter, n int) error {
+		for i := 0; i < n; i++ {
+			labels := prometheus.Labels{
+				"pod": fmt.Sprintf("pod%d", i),
+			}
+			err := l.admit("name", uniqueKey("name", "gauge", labels), labels)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	l := &seriesLimiter{}
+	l.reset(context.Background())
+	if err := admit(l, 100); err != nil {
+		t.Errorf("no limit: want no error, got %v", err)
+	}
+
+	l = &seriesLimiter{maxSeries: 10}
+	l.reset(context.Background())
+	if err := admit(l, 10); err != nil {
+		t.Errorf("max series: want no error within the limit, got %v", err)
+	}
+	// The series already counted in the scrape are admitted again
+	if err := admit(l, 10); err != nil {
+		t.Errorf("max series: want no error for the same series, got %v", err)
+	}
+	if err := admit(l, 11); !errors.Is(err, ErrMaxSeries) {
+		t.Errorf("max series: want %v, got %v", ErrMaxSeries, err)
+	}
+	l.reset(context.Background())
+	if err := admit(l, 10); err != nil {
+		t.Errorf("max series: want no error after reset, got %v", err)
+	}
+
+	l = &seriesLimiter{maxLabelValues: 5}
+	l.reset(context.Background())
+	if err := admit(l, 6); !errors.Is(err, ErrMaxLabelValues) {
+		t.Errorf("max label values: want %v, got %v", ErrMaxLabelValues, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l = &seriesLimiter{}
+	l.reset(ctx)
+	if err := admit(l, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled: want %v, got %v", context.Canceled, err)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"net/http"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -44,9 +46,14 @@ type UpdateHandler struct {
 	handler  http.Handler
 	registry *prometheus.Registry
 
+	scrapeTimeout time.Duration
+	limiter       seriesLimiter
+	updateMut     sync.Mutex
+
 	gauges     maps.SyncMap[string, Gauge]
 	counters   maps.SyncMap[string, Counter]
 	histograms maps.SyncMap[string, Histogram]
+	summaries  maps.SyncMap[string, Summary]
 }
 
 // DataSource is the interface for getting data for metrics
@@ -60,13 +67,23 @@ type UpdateHandlerConfig struct {
 	Environment     *cel.Environment
 	NodeCacheGetter informer.Getter[*corev1.Node]
 	PodCacheGetter  informer.Getter[*corev1.Pod]
+
+	// MaxSeries is the max number of the series updated in a scrape, 0 means no limit.
+	MaxSeries int
+	// MaxLabelValues is the max number of the values of a label of a metric in a scrape, 0 means no limit.
+	MaxLabelValues int
+	// ScrapeTimeout is the time a scrape is allowed to evaluate the series for, 0 means no timeout.
+	ScrapeTimeout time.Duration
 }
 
 // NewMetricsUpdateHandler creates new metric update handler based on the config
 func NewMetricsUpdateHandler(conf UpdateHandlerConfig) *UpdateHandler {
 	registry := prometheus.NewRegistry()
 	handler := promhttp.InstrumentMetricHandler(
-		prometheus.DefaultRegisterer, promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}),
+		prometheus.DefaultRegisterer, promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+			Registry: registry,
+			Timeout:  conf.ScrapeTimeout,
+		}),
 	)
 
 	h := &UpdateHandler{
@@ -76,6 +93,11 @@ func NewMetricsUpdateHandler(conf UpdateHandlerConfig) *UpdateHandler {
 		podCacheGetter:  conf.PodCacheGetter,
 		registry:        registry,
 		handler:         handler,
+		scrapeTimeout:   conf.ScrapeTimeout,
+		limiter: seriesLimiter{
+			maxSeries:      conf.MaxSeries,
+			maxLabelValues: conf.MaxLabelValues,
+		},
 	}
 	return h
 }
@@ -141,8 +163,9 @@ func (h *UpdateHandler) getOrRegisterHistogram(metricConfig *internalversion.Met
 		return val, key, nil
 	}
 
-	buckets := make([]float64, 0, len(metricConfig.Buckets))
-	for _, b := range metricConfig.Buckets {
+	metricBuckets := histogramBuckets(metricConfig)
+	buckets := make([]float64, 0, len(metricBuckets))
+	for _, b := range metricBuckets {
 		if b.Hidden {
 			continue
 		}
@@ -166,6 +189,32 @@ func (h *UpdateHandler) getOrRegisterHistogram(metricConfig *internalversion.Met
 	return val, key, nil
 }
 
+func (h *UpdateHandler) getOrRegisterSummary(metricConfig *internalversion.MetricConfig, data cel.Data) (Summary, string, error) {
+	key, labels, err := h.createKeyAndLabels(metricConfig, data)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to evaluate labels: %w", err)
+	}
+	val, ok := h.summaries.Load(key)
+	if ok {
+		return val, key, nil
+	}
+
+	val = NewSummary(
+		SummaryOpts{
+			Name:        metricConfig.Name,
+			Help:        metricConfig.Help,
+			ConstLabels: labels,
+		},
+	)
+	h.summaries.Store(key, val)
+	err = h.registry.Register(val)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to register summary %q: %w", metricConfig.Name, err)
+	}
+
+	return val, key, nil
+}
+
 func (h *UpdateHandler) updateGauge(ctx context.Context, metricConfig *internalversion.MetricConfig, nodeName string) ([]string, error) {
 	eval, err := h.environment.Compile(metricConfig.Value)
 	if err != nil {
@@ -255,6 +304,22 @@ func (h *UpdateHandler) updateGauge(ctx context.Context, metricConfig *internalv
 			}
 		}
 		return keys, nil
+	case internalversion.DimensionDevice:
+		devices := h.deviceData(logger, node, metricConfig.Device)
+		keys := make([]string, 0, len(devices))
+		for _, data := range devices {
+			gauge, key, err := h.getOrRegisterGauge(metricConfig, data)
+			if err != nil {
+				return nil, err
+			}
+			result, err := eval.EvaluateFloat64(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate metric %q: %w", metricConfig.Name, err)
+			}
+			gauge.Set(result)
+			keys = append(keys, key)
+		}
+		return keys, nil
 	default:
 		return nil, fmt.Errorf("unknown dimension %q", metricConfig.Dimension)
 	}
@@ -349,6 +414,22 @@ func (h *UpdateHandler) updateCounter(ctx context.Context, metricConfig *interna
 			}
 		}
 		return keys, nil
+	case internalversion.DimensionDevice:
+		devices := h.deviceData(logger, node, metricConfig.Device)
+		keys := make([]string, 0, len(devices))
+		for _, data := range devices {
+			counter, key, err := h.getOrRegisterCounter(metricConfig, data)
+			if err != nil {
+				return nil, err
+			}
+			result, err := eval.EvaluateFloat64(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate metric %q: %w", metricConfig.Name, err)
+			}
+			counter.Set(result)
+			keys = append(keys, key)
+		}
+		return keys, nil
 	default:
 		return nil, fmt.Errorf("unknown dimension %q", metricConfig.Dimension)
 	}
@@ -373,16 +454,9 @@ func (h *UpdateHandler) updateHistogram(ctx context.Context, metricConfig *inter
 			return nil, err
 		}
 
-		for _, b := range metricConfig.Buckets {
-			eval, err := h.environment.Compile(b.Value)
-			if err != nil {
-				return nil, fmt.Errorf("failed to compile program for Le(%v) %q: %w", b.Le, b.Value, err)
-			}
-			value, err := eval.EvaluateFloat64(data)
-			if err != nil {
-				return nil, fmt.Errorf("failed to evaluate metric with Le(%v): %w", b.Le, err)
-			}
-			histogram.Set(b.Le, uint64(value))
+		err = h.setHistogramBuckets(histogram, metricConfig, data)
+		if err != nil {
+			return nil, err
 		}
 		return []string{key}, nil
 	case internalversion.DimensionPod:
@@ -405,16 +479,112 @@ func (h *UpdateHandler) updateHistogram(ctx context.Context, metricConfig *inter
 				return nil, err
 			}
 
-			for _, b := range metricConfig.Buckets {
-				eval, err := h.environment.Compile(b.Value)
+			err = h.setHistogramBuckets(histogram, metricConfig, data)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		return keys, nil
+	case internalversion.DimensionContainer:
+		pods, ok := h.dataSource.ListPods(nodeName)
+		if !ok {
+			logger.Warn("pods not found")
+			return nil, nil
+		}
+
+		keys := make([]string, 0, len(pods))
+		for _, podInfo := range pods {
+			pod, ok := h.podCacheGetter.GetWithNamespace(podInfo.Name, podInfo.Namespace)
+			if !ok {
+				logger.Warn("pod not found", "pod", podInfo)
+				continue
+			}
+			data.Pod = pod
+			for _, container := range pod.Spec.Containers {
+				container := container
+				data.Container = &container
+				histogram, key, err := h.getOrRegisterHistogram(metricConfig, data)
 				if err != nil {
-					return nil, fmt.Errorf("failed to compile program for Le(%v) %q: %w", b.Le, b.Value, err)
+					return nil, err
 				}
-				value, err := eval.EvaluateFloat64(data)
+
+				err = h.setHistogramBuckets(histogram, metricConfig, data)
 				if err != nil {
-					return nil, fmt.Errorf("failed to evaluate metric with Le(%v): %w", b.Le, err)
+					return nil, err
 				}
-				histogram.Set(b.Le, uint64(value))
+				keys = append(keys, key)
+			}
+		}
+		return keys, nil
+	case internalversion.DimensionDevice:
+		devices := h.deviceData(logger, node, metricConfig.Device)
+		keys := make([]string, 0, len(devices))
+		for _, data := range devices {
+			histogram, key, err := h.getOrRegisterHistogram(metricConfig, data)
+			if err != nil {
+				return nil, err
+			}
+
+			err = h.setHistogramBuckets(histogram, metricConfig, data)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		return keys, nil
+	default:
+		return nil, fmt.Errorf("unknown dimension %q", metricConfig.Dimension)
+	}
+}
+
+func (h *UpdateHandler) updateSummary(ctx context.Context, metricConfig *internalversion.MetricConfig, nodeName string) ([]string, error) {
+	logger := log.FromContext(ctx).With("node", nodeName)
+
+	node, ok := h.nodeCacheGetter.Get(nodeName)
+	if !ok {
+		logger.Warn("node not found")
+		return nil, nil
+	}
+	data := cel.Data{
+		Node: node,
+	}
+
+	switch metricConfig.Dimension {
+	case internalversion.DimensionNode:
+		summary, key, err := h.getOrRegisterSummary(metricConfig, data)
+		if err != nil {
+			return nil, err
+		}
+
+		err = h.setSummaryQuantiles(summary, metricConfig, data)
+		if err != nil {
+			return nil, err
+		}
+		return []string{key}, nil
+	case internalversion.DimensionPod:
+		pods, ok := h.dataSource.ListPods(nodeName)
+		if !ok {
+			logger.Warn("pods not found")
+			return nil, nil
+		}
+
+		keys := make([]string, 0, len(pods))
+		for _, podInfo := range pods {
+			pod, ok := h.podCacheGetter.GetWithNamespace(podInfo.Name, podInfo.Namespace)
+			if !ok {
+				logger.Warn("pod not found", "pod", podInfo)
+				continue
+			}
+			data.Pod = pod
+			summary, key, err := h.getOrRegisterSummary(metricConfig, data)
+			if err != nil {
+				return nil, err
+			}
+
+			err = h.setSummaryQuantiles(summary, metricConfig, data)
+			if err != nil {
+				return nil, err
 			}
 			keys = append(keys, key)
 		}
@@ -437,31 +607,142 @@ func (h *UpdateHandler) updateHistogram(ctx context.Context, metricConfig *inter
 			for _, container := range pod.Spec.Containers {
 				container := container
 				data.Container = &container
-				histogram, key, err := h.getOrRegisterHistogram(metricConfig, data)
+				summary, key, err := h.getOrRegisterSummary(metricConfig, data)
 				if err != nil {
 					return nil, err
 				}
 
-				for _, b := range metricConfig.Buckets {
-					eval, err := h.environment.Compile(b.Value)
-					if err != nil {
-						return nil, fmt.Errorf("failed to compile program for Le(%v) %q: %w", b.Le, b.Value, err)
-					}
-					value, err := eval.EvaluateFloat64(data)
-					if err != nil {
-						return nil, fmt.Errorf("failed to evaluate metric with Le(%v): %w", b.Le, err)
-					}
-					histogram.Set(b.Le, uint64(value))
+				err = h.setSummaryQuantiles(summary, metricConfig, data)
+				if err != nil {
+					return nil, err
 				}
 				keys = append(keys, key)
 			}
 		}
 		return keys, nil
+	case internalversion.DimensionDevice:
+		devices := h.deviceData(logger, node, metricConfig.Device)
+		keys := make([]string, 0, len(devices))
+		for _, data := range devices {
+			summary, key, err := h.getOrRegisterSummary(metricConfig, data)
+			if err != nil {
+				return nil, err
+			}
+
+			err = h.setSummaryQuantiles(summary, metricConfig, data)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		return keys, nil
 	default:
 		return nil, fmt.Errorf("unknown dimension %q", metricConfig.Dimension)
 	}
 }
 
+// histogramBuckets returns the buckets of the histogram metric, including the generated ones.
+func histogramBuckets(metricConfig *internalversion.MetricConfig) []internalversion.MetricBucket {
+	from := metricConfig.BucketsFrom
+	if from == nil || from.Count <= 0 {
+		return metricConfig.Buckets
+	}
+
+	buckets := make([]internalversion.MetricBucket, 0, len(metricConfig.Buckets)+int(from.Count))
+	buckets = append(buckets, metricConfig.Buckets...)
+	le := from.Start
+	for i := int32(0); i != from.Count; i++ {
+		buckets = append(buckets, internalversion.MetricBucket{
+			Le:    le,
+			Value: from.Value,
+		})
+		switch from.Type {
+		case internalversion.MetricBucketsTypeExponential:
+			le *= from.Factor
+		default:
+			le += from.Factor
+		}
+	}
+	return buckets
+}
+
+func (h *UpdateHandler) setHistogramBuckets(histogram Histogram, metricConfig *internalversion.MetricConfig, data cel.Data) error {
+	for _, b := range histogramBuckets(metricConfig) {
+		eval, err := h.environment.Compile(b.Value)
+		if err != nil {
+			return fmt.Errorf("failed to compile program for Le(%v) %q: %w", b.Le, b.Value, err)
+		}
+		data.Le = b.Le
+		value, err := eval.EvaluateFloat64(data)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate metric with Le(%v): %w", b.Le, err)
+		}
+		histogram.Set(b.Le, uint64(value))
+	}
+	return nil
+}
+
+func (h *UpdateHandler) setSummaryQuantiles(summary Summary, metricConfig *internalversion.MetricConfig, data cel.Data) error {
+	for _, q := range metricConfig.Quantiles {
+		eval, err := h.environment.Compile(q.Value)
+		if err != nil {
+			return fmt.Errorf("failed to compile program for Quantile(%v) %q: %w", q.Quantile, q.Value, err)
+		}
+		value, err := eval.EvaluateFloat64(data)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate metric with Quantile(%v): %w", q.Quantile, err)
+		}
+		summary.Set(q.Quantile, value)
+	}
+
+	var count, sum float64
+	if metricConfig.SampleCount != "" {
+		eval, err := h.environment.Compile(metricConfig.SampleCount)
+		if err != nil {
+			return fmt.Errorf("failed to compile metric sample count %s: %w", metricConfig.SampleCount, err)
+		}
+		count, err = eval.EvaluateFloat64(data)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate metric sample count %q: %w", metricConfig.Name, err)
+		}
+	}
+	if metricConfig.SampleSum != "" {
+		eval, err := h.environment.Compile(metricConfig.SampleSum)
+		if err != nil {
+			return fmt.Errorf("failed to compile metric sample sum %s: %w", metricConfig.SampleSum, err)
+		}
+		sum, err = eval.EvaluateFloat64(data)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate metric sample sum %q: %w", metricConfig.Name, err)
+		}
+	}
+	summary.SetSample(uint64(count), sum)
+	return nil
+}
+
+// deviceData returns the data of the devices of the resource of the node, with the pods and the containers they are allocated to.
+func (h *UpdateHandler) deviceData(logger *log.Logger, node *corev1.Node, resourceName string) []cel.Data {
+	if resourceName == "" {
+		logger.Warn("device of the device dimension not given")
+		return nil
+	}
+	refs, ok := h.dataSource.ListPods(node.Name)
+	if !ok {
+		logger.Warn("pods not found")
+		return nil
+	}
+	pods := make([]*corev1.Pod, 0, len(refs))
+	for _, podInfo := range refs {
+		pod, ok := h.podCacheGetter.GetWithNamespace(podInfo.Name, podInfo.Namespace)
+		if !ok {
+			logger.Warn("pod not found", "pod", podInfo)
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return DeviceData(node, resourceName, pods)
+}
+
 func (h *UpdateHandler) updateMetric(ctx context.Context, metricConfig *internalversion.MetricConfig, nodeName string) ([]string, error) {
 	switch metricConfig.Kind {
 	case internalversion.KindGauge:
@@ -470,6 +751,8 @@ func (h *UpdateHandler) updateMetric(ctx context.Context, metricConfig *internal
 		return h.updateCounter(ctx, metricConfig, nodeName)
 	case internalversion.KindHistogram:
 		return h.updateHistogram(ctx, metricConfig, nodeName)
+	case internalversion.KindSummary:
+		return h.updateSummary(ctx, metricConfig, nodeName)
 	default:
 		return nil, fmt.Errorf("unknown metric kind %q", metricConfig.Kind)
 	}
@@ -478,9 +761,15 @@ func (h *UpdateHandler) updateMetric(ctx context.Context, metricConfig *internal
 // createKeyAndLabels creates a key and labels for a metric.
 // The key is used to unregister the metric.
 // The labels are used to set a value to the metric.
+// It returns an error if the series exceeds the limits of the scrape.
 func (h *UpdateHandler) createKeyAndLabels(metricConfig *internalversion.MetricConfig, data cel.Data) (string, prometheus.Labels, error) {
 	if len(metricConfig.Labels) == 0 {
-		return uniqueKey(metricConfig.Name, metricConfig.Kind, nil), nil, nil
+		key := uniqueKey(metricConfig.Name, metricConfig.Kind, nil)
+		err := h.limiter.admit(metricConfig.Name, key, nil)
+		if err != nil {
+			return "", nil, err
+		}
+		return key, nil, nil
 	}
 
 	labels := prometheus.Labels{}
@@ -499,7 +788,12 @@ func (h *UpdateHandler) createKeyAndLabels(metricConfig *internalversion.MetricC
 		labels[key] = value
 	}
 
-	return uniqueKey(metricConfig.Name, metricConfig.Kind, labels), labels, nil
+	key := uniqueKey(metricConfig.Name, metricConfig.Kind, labels)
+	err := h.limiter.admit(metricConfig.Name, key, labels)
+	if err != nil {
+		return "", nil, err
+	}
+	return key, labels, nil
 }
 
 func uniqueKey(name string, kind internalversion.Kind, labels map[string]string) string {
@@ -524,6 +818,16 @@ func uniqueKey(name string, kind internalversion.Kind, labels map[string]string)
 
 // Update updates metrics for a node
 func (h *UpdateHandler) Update(ctx context.Context, nodeName string, metrics []internalversion.MetricConfig) {
+	h.updateMut.Lock()
+	defer h.updateMut.Unlock()
+
+	if h.scrapeTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.scrapeTimeout)
+		defer cancel()
+	}
+	h.limiter.reset(ctx)
+
 	logger := log.FromContext(ctx)
 	has := map[string]struct{}{}
 	// Sync metrics
@@ -541,6 +845,15 @@ func (h *UpdateHandler) Update(ctx context.Context, nodeName string, metrics []i
 		for _, key := range keys {
 			has[key] = struct{}{}
 		}
+
+		// The series not evaluated in time keep their last values
+		if ctx.Err() != nil {
+			logger.Warn("Stopped updating the metrics, the rest keep their last values",
+				"node", nodeName,
+				"err", ctx.Err(),
+			)
+			return
+		}
 	}
 
 	// Remove old metrics
@@ -569,6 +882,14 @@ func (h *UpdateHandler) Update(ctx context.Context, nodeName string, metrics []i
 			}
 		}
 	}
+	for _, key := range h.summaries.Keys() {
+		if _, ok := has[key]; !ok {
+			old, ok := h.summaries.LoadAndDelete(key)
+			if ok {
+				h.registry.Unregister(old)
+			}
+		}
+	}
 }
 
 func (h *UpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+)
+
+// SummaryOpts provides configuration options for Summary.
+type SummaryOpts struct {
+	// Namespace, Subsystem, and Name are components of the fully-qualified
+	// name of the Summary (created by joining these components with
+	// "_"). Only Name is mandatory, the others merely help structuring the
+	// name. Note that the fully-qualified name of the Summary must be a
+	// valid Prometheus metric name.
+	Namespace string
+	Subsystem string
+	Name      string
+
+	// Help provides information about this Summary.
+	//
+	// Metrics with the same fully-qualified name must have the same Help
+	// string.
+	Help string
+
+	// ConstLabels are used to attach fixed labels to this metric. Metrics
+	// with the same fully-qualified name must have the same label names in
+	// their ConstLabels.
+	ConstLabels prometheus.Labels
+}
+
+// summary is custom type emulating prometheus.Summary
+type summary struct {
+	desc *prometheus.Desc
+
+	// stored is a map of quantile -> value
+	stored maps.SyncMap[float64, float64]
+
+	mut   sync.Mutex
+	count uint64
+	sum   float64
+}
+
+// Summary is a metric to track the quantiles of events.
+type Summary interface {
+	prometheus.Metric
+	prometheus.Collector
+	Set(quantile float64, val float64)
+	SetSample(count uint64, sum float64)
+}
+
+// NewSummary creates new Summary based on Summary options
+func NewSummary(opts SummaryOpts) Summary {
+	desc := prometheus.NewDesc(
+		prometheus.BuildFQName(opts.Namespace, opts.Subsystem, opts.Name),
+		opts.Help,
+		nil,
+		opts.ConstLabels,
+	)
+
+	return &summary{
+		desc: desc,
+	}
+}
+
+// Desc returns prometheus.Desc used by every Prometheus Metric.
+func (s *summary) Desc() *prometheus.Desc {
+	return s.desc
+}
+
+// Write writes out summary data to the Metric dto.
+func (s *summary) Write(out *dto.Metric) error {
+	keys := s.stored.Keys()
+	sort.Float64s(keys)
+
+	quantiles := make([]*dto.Quantile, 0, len(keys))
+	for _, quantile := range keys {
+		val, _ := s.stored.Load(quantile)
+		quantiles = append(quantiles, &dto.Quantile{
+			Quantile: format.Ptr(quantile),
+			Value:    format.Ptr(val),
+		})
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	out.Summary = &dto.Summary{
+		Quantile:    quantiles,
+		SampleCount: format.Ptr(s.count),
+		SampleSum:   format.Ptr(s.sum),
+	}
+	return nil
+}
+
+// Describe sends metric description to a channel.
+func (s *summary) Describe(ch chan<- *prometheus.Desc) {
+	ch <- s.desc
+}
+
+// Collect sends summary to a prometheus Metric channel.
+func (s *summary) Collect(ch chan<- prometheus.Metric) {
+	ch <- s
+}
+
+// Set sets value for a given quantile.
+func (s *summary) Set(quantile float64, val float64) {
+	s.stored.Store(quantile, val)
+}
+
+// SetSample sets the count and the sum of the observations.
+func (s *summary) SetSample(count uint64, sum float64) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.count = count
+	s.sum = sum
+}
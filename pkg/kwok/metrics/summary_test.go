@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	dto "github.com/prometheus/client_model/go"
+
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func TestSummaryWrite(t *testing.T) {
+	sum := NewSummary(SummaryOpts{
+		Name: "name",
+		Help: "help",
+	})
+	sum.Set(0.99, 2.5)
+	sum.Set(0.5, 0.1)
+	sum.Set(0.9, 1.2)
+	sum.SetSample(100, 42.5)
+
+	var out dto.Metric
+	if err := sum.Write(&out); err != nil {
+		t.Fatalf("Failed to write metric: %v", err)
+	}
+
+	want := &dto.Summary{
+		SampleCount: format.Ptr[uint64](100),
+		SampleSum:   format.Ptr(42.5),
+		Quantile: []*dto.Quantile{
+			{
+				Quantile: format.Ptr(0.5),
+				Value:    format.Ptr(0.1),
+			},
+			{
+				Quantile: format.Ptr(0.9),
+				Value:    format.Ptr(1.2),
+			},
+			{
+				Quantile: format.Ptr(0.99),
+				Value:    format.Ptr(2.5),
+			},
+		},
+	}
+
+	if diff := cmp.Diff(out.Summary, want, cmpopts.IgnoreUnexported(dto.Summary{}, dto.Quantile{})); diff != "" {
+		t.Errorf("Summary mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// podLabels returns the labels of the pod and the labels of its namespace,
+// they are nil if the pod or the namespace is not found in the caches.
+func (s *Server) podLabels(podName, podNamespace string) (labels, namespaceLabels map[string]string) {
+	if s.podCacheGetter != nil {
+		pod, ok := s.podCacheGetter.GetWithNamespace(podName, podNamespace)
+		if ok {
+			labels = pod.Labels
+		}
+	}
+	return labels, s.namespaceLabels(podNamespace)
+}
+
+// namespaceLabels returns the labels of the namespace, it is nil if the namespace is not found in the cache.
+func (s *Server) namespaceLabels(namespace string) map[string]string {
+	if s.namespaceCacheGetter == nil {
+		return nil
+	}
+	ns, ok := s.namespaceCacheGetter.Get(namespace)
+	if !ok {
+		return nil
+	}
+	return ns.Labels
+}
+
+// matchClusterResources returns the cluster resources whose selector matches the object, in the order of precedence.
+// The resource with the more specific selector comes first, and the ones as specific are ordered by name.
+func matchClusterResources[T metav1.Object](list []T, selectorOf func(T) *internalversion.ObjectSelector, name, namespace string, labels, namespaceLabels map[string]string) []T {
+	matched := make([]T, 0, len(list))
+	for _, item := range list {
+		if selectorOf(item).MatchObject(name, namespace, labels, namespaceLabels) {
+			matched = append(matched, item)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		si, sj := selectorOf(matched[i]).Specificity(), selectorOf(matched[j]).Specificity()
+		if si != sj {
+			return si > sj
+		}
+		return matched[i].GetName() < matched[j].GetName()
+	})
+	return matched
+}
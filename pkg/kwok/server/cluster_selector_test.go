@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config/resources"
+)
+
+func TestGetExecTargetPrecedence(t *testing.T) {
+	newPod := func(name, namespace, app string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": app}},
+		}
+	}
+	newNamespace := func(name, env string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"env": env}},
+		}
+	}
+	newExecTarget := func(workDir string, containers ...string) internalversion.ExecTarget {
+		return internalversion.ExecTarget{
+			Containers: containers,
+			Local:      &internalversion.ExecTargetLocal{WorkDir: workDir},
+		}
+	}
+	newClusterExec := func(name string, selector *internalversion.ObjectSelector, execs ...internalversion.ExecTarget) *internalversion.ClusterExec {
+		return &internalversion.ClusterExec{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       internalversion.ClusterExecSpec{Selector: selector, Execs: execs},
+		}
+	}
+
+	s := &Server{
+		execs: resources.NewStaticGetter([]*internalversion.Exec{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "default"},
+				Spec: internalversion.ExecSpec{
+					Execs: []internalversion.ExecTarget{newExecTarget("/pod", "app")},
+				},
+			},
+		}),
+		clusterExecs: resources.NewStaticGetter([]*internalversion.ClusterExec{
+			newClusterExec("cluster", nil, newExecTarget("/cluster")),
+			newClusterExec("prod", &internalversion.ObjectSelector{
+				MatchNamespaceLabels: map[string]string{"env": "prod"},
+			}, newExecTarget("/prod")),
+			newClusterExec("web", &internalversion.ObjectSelector{
+				MatchLabels: map[string]string{"app": "web"},
+			}, newExecTarget("/web", "app")),
+			newClusterExec("db-0", &internalversion.ObjectSelector{
+				MatchNames: []string{"db-0"},
+			}, newExecTarget("/db-0")),
+			newClusterExec("all", &internalversion.ObjectSelector{}, newExecTarget("/all")),
+		}),
+		podCacheGetter: &fakeGetter[*corev1.Pod]{items: map[string]*corev1.Pod{
+			"default/web-0": newPod("web-0", "default", "web"),
+			"default/db-0":  newPod("db-0", "default", "db"),
+			"prod/web-1":    newPod("web-1", "prod", "web"),
+			"prod/db-1":     newPod("db-1", "prod", "db"),
+		}},
+		namespaceCacheGetter: &fakeGetter[*corev1.Namespace]{items: map[string]*corev1.Namespace{
+			"default": newNamespace("default", "dev"),
+			"prod":    newNamespace("prod", "prod"),
+		}},
+	}
+
+	tests := []struct {
+		name         string
+		podName      string
+		podNamespace string
+		container    string
+		want         string
+	}{
+		{
+			name:         "pod takes precedence",
+			podName:      "web-0",
+			podNamespace: "default",
+			container:    "app",
+			want:         "/pod",
+		},
+		{
+			name:         "pod falls back to cluster",
+			podName:      "web-0",
+			podNamespace: "default",
+			container:    "sidecar",
+			want:         "/all",
+		},
+		{
+			name:         "names over labels",
+			podName:      "db-0",
+			podNamespace: "default",
+			container:    "app",
+			want:         "/db-0",
+		},
+		{
+			name:         "labels over namespace labels",
+			podName:      "web-1",
+			podNamespace: "prod",
+			container:    "app",
+			want:         "/web",
+		},
+		{
+			name:         "namespace labels over empty selector",
+			podName:      "web-1",
+			podNamespace: "prod",
+			container:    "sidecar",
+			want:         "/prod",
+		},
+		{
+			name:         "empty selectors ordered by name",
+			podName:      "unknown",
+			podNamespace: "default",
+			container:    "app",
+			want:         "/all",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := s.getExecTarget(tt.podName, tt.podNamespace, tt.container)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if target.Local.WorkDir != tt.want {
+				t.Errorf("want exec target %q, got %q", tt.want, target.Local.WorkDir)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// InstallClusterStatistics installs the handler of the statistics of the whole fleet of the nodes and the pods,
+// so the benchmarks don't have to list all the objects to compute them.
+func (s *Server) InstallClusterStatistics() {
+	s.restfulCont.Handle("/cluster/statistics", http.HandlerFunc(s.clusterStatistics))
+}
+
+func (s *Server) clusterStatistics(rw http.ResponseWriter, req *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(rw).Encode(s.dataSource.ClusterStatistics())
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
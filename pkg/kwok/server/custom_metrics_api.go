@@ -0,0 +1,467 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/emicklei/go-restful/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwok/metrics"
+	"sigs.k8s.io/kwok/pkg/kwok/metrics/cel"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+const (
+	customMetricsAPIGroupVersion = "custom.metrics.k8s.io/v1beta2"
+	customMetricsAPIPath         = "/apis/" + customMetricsAPIGroupVersion
+
+	externalMetricsAPIGroupVersion = "external.metrics.k8s.io/v1beta1"
+	externalMetricsAPIPath         = "/apis/" + externalMetricsAPIGroupVersion
+)
+
+// customMetricValue is the MetricValue of the custom metrics API.
+type customMetricValue struct {
+	metav1.TypeMeta `json:",inline"`
+	DescribedObject corev1.ObjectReference `json:"describedObject"`
+	Metric          customMetricIdentifier `json:"metric"`
+	Timestamp       metav1.Time            `json:"timestamp"`
+	Value           resource.Quantity      `json:"value"`
+}
+
+// customMetricIdentifier is the MetricIdentifier of the custom metrics API.
+type customMetricIdentifier struct {
+	Name     string                `json:"name"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// customMetricValueList is the MetricValueList of the custom metrics API.
+type customMetricValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []customMetricValue `json:"items"`
+}
+
+// externalMetricValue is the ExternalMetricValue of the external metrics API.
+type externalMetricValue struct {
+	metav1.TypeMeta `json:",inline"`
+	MetricName      string            `json:"metricName"`
+	MetricLabels    map[string]string `json:"metricLabels"`
+	Timestamp       metav1.Time       `json:"timestamp"`
+	Value           resource.Quantity `json:"value"`
+}
+
+// externalMetricValueList is the ExternalMetricValueList of the external metrics API.
+type externalMetricValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []externalMetricValue `json:"items"`
+}
+
+// metricSample is a value of a gauge or a counter of a Metric.
+type metricSample struct {
+	node   *corev1.Node
+	pod    *corev1.Pod
+	labels map[string]string
+	value  float64
+}
+
+// InstallCustomMetricsAPI installs the handlers of the custom metrics API (custom.metrics.k8s.io)
+// and the external metrics API (external.metrics.k8s.io) serving the gauges and the counters of the Metrics,
+// they are registered as APIServices to make the HorizontalPodAutoscalers on the custom and external metrics work.
+func (s *Server) InstallCustomMetricsAPI() error {
+	env, err := cel.NewEnvironment(s.resourceUsageEvaluatorConfig(cel.NodeEvaluatorConfig{
+		EnableEvaluatorCache:   true,
+		StartedContainersTotal: s.dataSource.StartedContainersTotal,
+		AllocatedResource:      s.dataSource.AllocatedResource,
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	ws := new(restful.WebService)
+	ws.Path(customMetricsAPIPath)
+	ws.Produces(restful.MIME_JSON)
+	ws.Route(ws.GET("").To(s.customMetricsAPIResourceList))
+	ws.Route(ws.GET("/nodes/{name}/{metric}").To(s.getNodeCustomMetrics(env)))
+	ws.Route(ws.GET("/namespaces/{namespace}/pods/{name}/{metric}").To(s.getPodCustomMetrics(env)))
+	s.restfulCont.Add(ws)
+
+	ws = new(restful.WebService)
+	ws.Path(externalMetricsAPIPath)
+	ws.Produces(restful.MIME_JSON)
+	ws.Route(ws.GET("").To(s.externalMetricsAPIResourceList))
+	ws.Route(ws.GET("/namespaces/{namespace}/{metric}").To(s.getExternalMetrics(env)))
+	s.restfulCont.Add(ws)
+	return nil
+}
+
+// metricConfigs returns the gauges and the counters of the Metrics.
+func (s *Server) metricConfigs() []*internalversion.MetricConfig {
+	var configs []*internalversion.MetricConfig
+	for _, m := range s.metrics.Get() {
+		for i := range m.Spec.Metrics {
+			mc := &m.Spec.Metrics[i]
+			if mc.Kind != internalversion.KindGauge && mc.Kind != internalversion.KindCounter {
+				continue
+			}
+			configs = append(configs, mc)
+		}
+	}
+	return configs
+}
+
+func (s *Server) customMetricsAPIResourceList(req *restful.Request, resp *restful.Response) {
+	list := &metav1.APIResourceList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "APIResourceList",
+			APIVersion: "v1",
+		},
+		GroupVersion: customMetricsAPIGroupVersion,
+		APIResources: []metav1.APIResource{},
+	}
+	has := map[string]struct{}{}
+	for _, mc := range s.metricConfigs() {
+		name := "nodes/" + mc.Name
+		namespaced := false
+		if mc.Dimension != internalversion.DimensionNode {
+			name = "pods/" + mc.Name
+			namespaced = true
+		}
+		if _, ok := has[name]; ok {
+			continue
+		}
+		has[name] = struct{}{}
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:       name,
+			Kind:       "MetricValueList",
+			Namespaced: namespaced,
+			Verbs:      metav1.Verbs{"get"},
+		})
+	}
+	writeMetricsAPIResponse(req, resp, list)
+}
+
+func (s *Server) externalMetricsAPIResourceList(req *restful.Request, resp *restful.Response) {
+	list := &metav1.APIResourceList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "APIResourceList",
+			APIVersion: "v1",
+		},
+		GroupVersion: externalMetricsAPIGroupVersion,
+		APIResources: []metav1.APIResource{},
+	}
+	has := map[string]struct{}{}
+	for _, mc := range s.metricConfigs() {
+		if _, ok := has[mc.Name]; ok {
+			continue
+		}
+		has[mc.Name] = struct{}{}
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:       mc.Name,
+			Kind:       "ExternalMetricValueList",
+			Namespaced: true,
+			Verbs:      metav1.Verbs{"get"},
+		})
+	}
+	writeMetricsAPIResponse(req, resp, list)
+}
+
+func (s *Server) getNodeCustomMetrics(env *cel.Environment) func(req *restful.Request, resp *restful.Response) {
+	return func(req *restful.Request, resp *restful.Response) {
+		name := req.PathParameter("name")
+		s.writeCustomMetrics(req, resp, env, "nodes", func(sample metricSample, selector labels.Selector) (corev1.ObjectReference, bool) {
+			if sample.pod != nil {
+				return corev1.ObjectReference{}, false
+			}
+			if name == "*" {
+				if !selector.Matches(labels.Set(sample.node.Labels)) {
+					return corev1.ObjectReference{}, false
+				}
+			} else if sample.node.Name != name {
+				return corev1.ObjectReference{}, false
+			}
+			return corev1.ObjectReference{
+				Kind:       "Node",
+				Name:       sample.node.Name,
+				APIVersion: "v1",
+			}, true
+		})
+	}
+}
+
+func (s *Server) getPodCustomMetrics(env *cel.Environment) func(req *restful.Request, resp *restful.Response) {
+	return func(req *restful.Request, resp *restful.Response) {
+		namespace := req.PathParameter("namespace")
+		name := req.PathParameter("name")
+		s.writeCustomMetrics(req, resp, env, "pods", func(sample metricSample, selector labels.Selector) (corev1.ObjectReference, bool) {
+			if sample.pod == nil || sample.pod.Namespace != namespace {
+				return corev1.ObjectReference{}, false
+			}
+			if name == "*" {
+				if !selector.Matches(labels.Set(sample.pod.Labels)) {
+					return corev1.ObjectReference{}, false
+				}
+			} else if sample.pod.Name != name {
+				return corev1.ObjectReference{}, false
+			}
+			return corev1.ObjectReference{
+				Kind:       "Pod",
+				Namespace:  sample.pod.Namespace,
+				Name:       sample.pod.Name,
+				APIVersion: "v1",
+			}, true
+		})
+	}
+}
+
+// writeCustomMetrics writes the values of the metric of the objects described by the samples,
+// the value of an object is the sum of the values of its samples, e.g. the containers of a pod.
+func (s *Server) writeCustomMetrics(req *restful.Request, resp *restful.Response, env *cel.Environment, resourceName string,
+	describe func(sample metricSample, selector labels.Selector) (corev1.ObjectReference, bool)) {
+	name := req.PathParameter("name")
+	metricName := req.PathParameter("metric")
+	selector, err := labels.Parse(req.QueryParameter("labelSelector"))
+	if err != nil {
+		_ = resp.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+	metricSelector, err := labels.Parse(req.QueryParameter("metricLabelSelector"))
+	if err != nil {
+		_ = resp.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+	var labelSelector *metav1.LabelSelector
+	if !metricSelector.Empty() {
+		labelSelector, err = metav1.ParseToLabelSelector(req.QueryParameter("metricLabelSelector"))
+		if err != nil {
+			_ = resp.WriteErrorString(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	values := map[corev1.ObjectReference]float64{}
+	for _, sample := range s.metricSamples(req.Request.Context(), env, metricName) {
+		if !metricSelector.Matches(labels.Set(sample.labels)) {
+			continue
+		}
+		ref, ok := describe(sample, selector)
+		if !ok {
+			continue
+		}
+		values[ref] += sample.value
+	}
+	if name != "*" && len(values) == 0 {
+		_ = resp.WriteErrorString(http.StatusNotFound, fmt.Sprintf("the metric %q of %s %q not found", metricName, resourceName, name))
+		return
+	}
+
+	list := &customMetricValueList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MetricValueList",
+			APIVersion: customMetricsAPIGroupVersion,
+		},
+		Items: make([]customMetricValue, 0, len(values)),
+	}
+	now := metav1.Now()
+	for ref, value := range values {
+		list.Items = append(list.Items, customMetricValue{
+			DescribedObject: ref,
+			Metric: customMetricIdentifier{
+				Name:     metricName,
+				Selector: labelSelector,
+			},
+			Timestamp: now,
+			Value:     metricQuantity(value),
+		})
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		if list.Items[i].DescribedObject.Namespace != list.Items[j].DescribedObject.Namespace {
+			return list.Items[i].DescribedObject.Namespace < list.Items[j].DescribedObject.Namespace
+		}
+		return list.Items[i].DescribedObject.Name < list.Items[j].DescribedObject.Name
+	})
+	writeMetricsAPIResponse(req, resp, list)
+}
+
+func (s *Server) getExternalMetrics(env *cel.Environment) func(req *restful.Request, resp *restful.Response) {
+	return func(req *restful.Request, resp *restful.Response) {
+		namespace := req.PathParameter("namespace")
+		metricName := req.PathParameter("metric")
+		selector, err := labels.Parse(req.QueryParameter("labelSelector"))
+		if err != nil {
+			_ = resp.WriteErrorString(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		list := &externalMetricValueList{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ExternalMetricValueList",
+				APIVersion: externalMetricsAPIGroupVersion,
+			},
+			Items: []externalMetricValue{},
+		}
+		now := metav1.Now()
+		for _, sample := range s.metricSamples(req.Request.Context(), env, metricName) {
+			if sample.pod != nil && sample.pod.Namespace != namespace {
+				continue
+			}
+			if !selector.Matches(labels.Set(sample.labels)) {
+				continue
+			}
+			list.Items = append(list.Items, externalMetricValue{
+				MetricName:   metricName,
+				MetricLabels: sample.labels,
+				Timestamp:    now,
+				Value:        metricQuantity(sample.value),
+			})
+		}
+		writeMetricsAPIResponse(req, resp, list)
+	}
+}
+
+// metricSamples evaluates the gauges and the counters with the name of the Metrics,
+// the same as they are scraped from the paths of the Metrics,
+// the ones of a Metric with the path containing {nodeName} are evaluated for each managed node,
+// and the others are evaluated for the node with the name of the Metric.
+func (s *Server) metricSamples(ctx context.Context, env *cel.Environment, name string) []metricSample {
+	logger := log.FromContext(ctx)
+	var samples []metricSample
+	for _, m := range s.metrics.Get() {
+		nodeNames := []string{m.Name}
+		if strings.Contains(m.Spec.Path, "{nodeName}") {
+			nodeNames = s.dataSource.ListNodes()
+			sort.Strings(nodeNames)
+		}
+		for i := range m.Spec.Metrics {
+			mc := &m.Spec.Metrics[i]
+			if mc.Name != name || (mc.Kind != internalversion.KindGauge && mc.Kind != internalversion.KindCounter) {
+				continue
+			}
+			for _, nodeName := range nodeNames {
+				ss, err := s.evaluateMetricConfig(env, mc, nodeName)
+				if err != nil {
+					logger.Warn("Failed to evaluate metric", "metric", m.Name, "name", mc.Name, "node", nodeName, "err", err)
+					continue
+				}
+				samples = append(samples, ss...)
+			}
+		}
+	}
+	return samples
+}
+
+// evaluateMetricConfig evaluates the metric for the node, or the pods or the containers on it by the dimension.
+func (s *Server) evaluateMetricConfig(env *cel.Environment, mc *internalversion.MetricConfig, nodeName string) ([]metricSample, error) {
+	eval, err := env.Compile(mc.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile metric value %s: %w", mc.Value, err)
+	}
+
+	node, ok := s.nodeCacheGetter.Get(nodeName)
+	if !ok {
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName,
+			},
+		}
+	}
+
+	evaluate := func(data cel.Data) (metricSample, error) {
+		labels := map[string]string{}
+		for _, label := range mc.Labels {
+			eval, err := env.Compile(label.Value)
+			if err != nil {
+				return metricSample{}, fmt.Errorf("failed to compile metric label value %q: %w", label.Value, err)
+			}
+			value, err := eval.EvaluateString(data)
+			if err != nil {
+				return metricSample{}, fmt.Errorf("failed to evaluate metric label %q: %w", label.Name, err)
+			}
+			labels[label.Name] = value
+		}
+		value, err := eval.EvaluateFloat64(data)
+		if err != nil {
+			return metricSample{}, fmt.Errorf("failed to evaluate metric %q: %w", mc.Name, err)
+		}
+		return metricSample{
+			node:   data.Node,
+			pod:    data.Pod,
+			labels: labels,
+			value:  value,
+		}, nil
+	}
+
+	switch mc.Dimension {
+	case internalversion.DimensionNode:
+		sample, err := evaluate(cel.Data{Node: node})
+		if err != nil {
+			return nil, err
+		}
+		return []metricSample{sample}, nil
+	case internalversion.DimensionPod, internalversion.DimensionContainer:
+		var samples []metricSample
+		for _, pod := range s.podsOnNode(nodeName) {
+			if mc.Dimension == internalversion.DimensionPod {
+				sample, err := evaluate(cel.Data{Node: node, Pod: pod})
+				if err != nil {
+					return nil, err
+				}
+				samples = append(samples, sample)
+				continue
+			}
+			for i := range pod.Spec.Containers {
+				sample, err := evaluate(cel.Data{Node: node, Pod: pod, Container: &pod.Spec.Containers[i]})
+				if err != nil {
+					return nil, err
+				}
+				samples = append(samples, sample)
+			}
+		}
+		return samples, nil
+	case internalversion.DimensionDevice:
+		// The devices are the metrics of the pods they are allocated to, and the free ones are of no pod.
+		var samples []metricSample
+		for _, data := range metrics.DeviceData(node, mc.Device, s.podsOnNode(nodeName)) {
+			if data.Device.Allocated == 0 {
+				continue
+			}
+			sample, err := evaluate(data)
+			if err != nil {
+				return nil, err
+			}
+			samples = append(samples, sample)
+		}
+		return samples, nil
+	default:
+		return nil, fmt.Errorf("unknown dimension %q", mc.Dimension)
+	}
+}
+
+// metricQuantity returns the quantity of the value of a metric in the precision of milli.
+func metricQuantity(value float64) resource.Quantity {
+	return *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+func newTestCustomMetricsAPIServer(t *testing.T) *Server {
+	pods := []*corev1.Pod{
+		newTestPod("pod0", "app", "sidecar"),
+		newTestPod("pod1", "app"),
+	}
+	podGetter := &fakeGetter[*corev1.Pod]{items: map[string]*corev1.Pod{}}
+	refs := []log.ObjectRef{}
+	for _, pod := range pods {
+		podGetter.items[pod.Namespace+"/"+pod.Name] = pod
+		refs = append(refs, log.ObjectRef{Name: pod.Name, Namespace: pod.Namespace})
+	}
+	nodeGetter := &fakeGetter[*corev1.Node]{items: map[string]*corev1.Node{
+		"node0": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node0",
+				Labels: map[string]string{
+					"type": "kwok",
+				},
+			},
+		},
+	}}
+
+	svc, err := NewServer(Config{
+		Metrics: []*internalversion.Metric{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "nodes",
+				},
+				Spec: internalversion.MetricSpec{
+					Path: "/metrics/nodes/{nodeName}/custom",
+					Metrics: []internalversion.MetricConfig{
+						{
+							Name:      "requests_per_second",
+							Kind:      internalversion.KindGauge,
+							Dimension: internalversion.DimensionContainer,
+							Labels: []internalversion.MetricLabel{
+								{
+									Name:  "container",
+									Value: "container.name",
+								},
+							},
+							Value: `pod.metadata.name == "pod0" ? 1.5 : 2.0`,
+						},
+						{
+							Name:      "node_load",
+							Kind:      internalversion.KindGauge,
+							Dimension: internalversion.DimensionNode,
+							Value:     "0.25",
+						},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "queue",
+				},
+				Spec: internalversion.MetricSpec{
+					Path: "/metrics/queue",
+					Metrics: []internalversion.MetricConfig{
+						{
+							Name:      "queue_length",
+							Kind:      internalversion.KindGauge,
+							Dimension: internalversion.DimensionNode,
+							Labels: []internalversion.MetricLabel{
+								{
+									Name:  "queue",
+									Value: `"jobs"`,
+								},
+							},
+							Value: "30",
+						},
+					},
+				},
+			},
+		},
+		DataSource: &fakeDataSource{
+			pods: map[string][]log.ObjectRef{
+				"node0": refs,
+			},
+		},
+		NodeCacheGetter: nodeGetter,
+		PodCacheGetter:  podGetter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = svc.InstallCustomMetricsAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestCustomMetricsAPI(t *testing.T) {
+	svc := newTestCustomMetricsAPIServer(t)
+
+	var resources metav1.APIResourceList
+	if code := getMetricsAPI(t, svc, "/apis/custom.metrics.k8s.io/v1beta2", &resources); code != http.StatusOK {
+		t.Fatalf("get resources: want 200, got %d", code)
+	}
+	if len(resources.APIResources) != 3 {
+		t.Errorf("want 3 resources, got %v", resources.APIResources)
+	}
+
+	var pods customMetricValueList
+	if code := getMetricsAPI(t, svc, "/apis/custom.metrics.k8s.io/v1beta2/namespaces/default/pods/*/requests_per_second", &pods); code != http.StatusOK {
+		t.Fatalf("list pods: want 200, got %d", code)
+	}
+	want := map[string]resource.Quantity{
+		"pod0": resource.MustParse("3"),
+		"pod1": resource.MustParse("2"),
+	}
+	if len(pods.Items) != len(want) {
+		t.Fatalf("want %d pods, got %v", len(want), pods.Items)
+	}
+	for _, item := range pods.Items {
+		quantity := want[item.DescribedObject.Name]
+		if item.Value.Cmp(quantity) != 0 {
+			t.Errorf("pod %s: want %s, got %s", item.DescribedObject.Name, quantity.String(), item.Value.String())
+		}
+	}
+
+	if code := getMetricsAPI(t, svc, "/apis/custom.metrics.k8s.io/v1beta2/namespaces/default/pods/pod0/requests_per_second?metricLabelSelector=container%3Dsidecar", &pods); code != http.StatusOK {
+		t.Fatalf("get pod: want 200, got %d", code)
+	}
+	if len(pods.Items) != 1 || pods.Items[0].Value.Cmp(resource.MustParse("1500m")) != 0 {
+		t.Errorf("pod0: want the sidecar 1500m, got %v", pods.Items)
+	}
+
+	if code := getMetricsAPI(t, svc, "/apis/custom.metrics.k8s.io/v1beta2/namespaces/other/pods/pod0/requests_per_second", nil); code != http.StatusNotFound {
+		t.Errorf("get the pod in other namespace: want 404, got %d", code)
+	}
+
+	var nodes customMetricValueList
+	if code := getMetricsAPI(t, svc, "/apis/custom.metrics.k8s.io/v1beta2/nodes/*/node_load?labelSelector=type%3Dkwok", &nodes); code != http.StatusOK {
+		t.Fatalf("list nodes: want 200, got %d", code)
+	}
+	if len(nodes.Items) != 1 || nodes.Items[0].DescribedObject.Name != "node0" || nodes.Items[0].Value.Cmp(resource.MustParse("250m")) != 0 {
+		t.Errorf("want node0 with 250m, got %v", nodes.Items)
+	}
+}
+
+func TestExternalMetricsAPI(t *testing.T) {
+	svc := newTestCustomMetricsAPIServer(t)
+
+	var external externalMetricValueList
+	if code := getMetricsAPI(t, svc, "/apis/external.metrics.k8s.io/v1beta1/namespaces/default/queue_length?labelSelector=queue%3Djobs", &external); code != http.StatusOK {
+		t.Fatalf("get external metric: want 200, got %d", code)
+	}
+	if len(external.Items) != 1 || external.Items[0].Value.Cmp(resource.MustParse("30")) != 0 {
+		t.Errorf("want 30, got %v", external.Items)
+	}
+
+	if code := getMetricsAPI(t, svc, "/apis/external.metrics.k8s.io/v1beta1/namespaces/default/queue_length?labelSelector=queue%3Dother", &external); code != http.StatusOK {
+		t.Fatalf("get external metric: want 200, got %d", code)
+	}
+	if len(external.Items) != 0 {
+		t.Errorf("want no items, got %v", external.Items)
+	}
+
+	if code := getMetricsAPI(t, svc, "/apis/external.metrics.k8s.io/v1beta1/namespaces/other/requests_per_second", &external); code != http.StatusOK {
+		t.Fatalf("get external metric: want 200, got %d", code)
+	}
+	if len(external.Items) != 0 {
+		t.Errorf("want no items of the pods in other namespace, got %v", external.Items)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package server
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -52,7 +53,80 @@ func (s *Server) AttachContainer(ctx context.Context, name string, uid types.UID
 		follow:    true,
 		timestamp: false,
 	}
-	return readLogs(ctx, attach.LogsFile, opts, out, errOut)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logger := log.FromContext(ctx)
+
+	// Drain the resize events, otherwise the client is blocked sending them.
+	if resize != nil {
+		go func() {
+			for size := range resize {
+				logger.Debug("Resized terminal of attach", "width", size.Width, "height", size.Height)
+			}
+		}()
+	}
+
+	// Drain the stdin, otherwise the client is blocked writing it.
+	// The stdin closed by the client is a half-close, which keeps the output attached,
+	// while a failed read means the connection is gone.
+	if in != nil {
+		go func() {
+			_, err := io.Copy(io.Discard, in)
+			if err != nil {
+				cancel()
+			}
+		}()
+	}
+
+	stdout, stderr := attachStreams(out, errOut, tty)
+	err = readLogs(ctx, attach.LogsFile, opts, stdout, stderr)
+	if err != nil && ctx.Err() != nil {
+		// The attach is detached by the client.
+		return nil
+	}
+	return err
+}
+
+// attachStreams returns the writers of the stdout and the stderr of an attach,
+// with a tty, the stderr is merged into the stdout as the terminal does, and the line feeds are written as the carriage returns and the line feeds.
+func attachStreams(out, errOut io.Writer, tty bool) (io.Writer, io.Writer) {
+	if out == nil {
+		out = io.Discard
+	}
+	if tty {
+		w := &ttyWriter{w: out}
+		return w, w
+	}
+	if errOut == nil {
+		errOut = io.Discard
+	}
+	return out, errOut
+}
+
+// ttyWriter writes the line feeds as the carriage returns and the line feeds, as the terminal in the raw mode expects.
+type ttyWriter struct {
+	w    io.Writer
+	last byte
+}
+
+func (t *ttyWriter) Write(p []byte) (int, error) {
+	buf := make([]byte, 0, len(p)+bytes.Count(p, []byte{'\n'}))
+	last := t.last
+	for _, b := range p {
+		if b == '\n' && last != '\r' {
+			buf = append(buf, '\r')
+		}
+		buf = append(buf, b)
+		last = b
+	}
+	_, err := t.w.Write(buf)
+	if err != nil {
+		return 0, err
+	}
+	t.last = last
+	return len(p), nil
 }
 
 func (s *Server) getAttach(req *restful.Request, resp *restful.Response) {
@@ -78,6 +152,8 @@ func (s *Server) getAttach(req *restful.Request, resp *restful.Response) {
 	)
 }
 
+// getPodAttach returns the attach of the container,
+// the Attach of the pod takes precedence over the ClusterAttaches matching the pod.
 func (s *Server) getPodAttach(podName, podNamespace, containerName string) (*internalversion.AttachConfig, error) {
 	a, has := slices.Find(s.attaches.Get(), func(a *internalversion.Attach) bool {
 		return a.Name == podName && a.Namespace == podNamespace
@@ -87,14 +163,13 @@ func (s *Server) getPodAttach(podName, podNamespace, containerName string) (*int
 		if found {
 			return a, nil
 		}
-		return nil, fmt.Errorf("not found log target for container %q in pod %q", containerName, log.KRef(podNamespace, podName))
 	}
 
-	for _, cl := range s.clusterAttaches.Get() {
-		if !cl.Spec.Selector.Match(podName, podNamespace) {
-			continue
-		}
-
+	labels, namespaceLabels := s.podLabels(podName, podNamespace)
+	clusterAttaches := matchClusterResources(s.clusterAttaches.Get(), func(ca *internalversion.ClusterAttach) *internalversion.ObjectSelector {
+		return ca.Spec.Selector
+	}, podName, podNamespace, labels, namespaceLabels)
+	for _, cl := range clusterAttaches {
 		log, found := findAttachInAttaches(containerName, cl.Spec.Attaches)
 		if found {
 			return log, nil
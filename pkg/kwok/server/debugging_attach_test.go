@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	remotecommandclient "k8s.io/client-go/tools/remotecommand"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config/resources"
+)
+
+func TestTTYWriter(t *testing.T) {
+	out := &syncBuffer{}
+	w := &ttyWriter{w: out}
+	for _, p := range []string{"a\nb\r", "\nc\n"} {
+		n, err := w.Write([]byte(p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(p) {
+			t.Errorf("want %d written, got %d", len(p), n)
+		}
+	}
+	if got, want := out.String(), "a\r\nb\r\nc\r\n"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestAttachContainer(t *testing.T) {
+	tests := []struct {
+		name      string
+		tty       bool
+		wantOut   string
+		wantErr   string
+		errStream bool
+	}{
+		{
+			name:      "stdout and stderr",
+			wantOut:   "out\n",
+			wantErr:   "err\n",
+			errStream: true,
+		},
+		{
+			name:    "tty",
+			tty:     true,
+			wantOut: "out\r\nerr\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.log")
+			writeLogsFile(t, path, "")
+			s := &Server{
+				attaches: resources.NewStaticGetter([]*internalversion.Attach{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod0", Namespace: "default"},
+						Spec: internalversion.AttachSpec{
+							Attaches: []internalversion.AttachConfig{
+								{LogsFile: path},
+							},
+						},
+					},
+				}),
+				clusterAttaches: resources.NewStaticGetter([]*internalversion.ClusterAttach{}),
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			inReader, inWriter := io.Pipe()
+			resize := make(chan remotecommandclient.TerminalSize)
+			out := &syncBuffer{}
+			errOut := &syncBuffer{}
+			var errWriter io.WriteCloser
+			if tt.errStream {
+				errWriter = nopWriteCloser{errOut}
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				done <- s.AttachContainer(ctx, "pod0/default", "", "app", inReader, nopWriteCloser{out}, errWriter, tt.tty, resize)
+			}()
+
+			// The resize events and the stdin are consumed, and the stdin is half-closed.
+			resize <- remotecommandclient.TerminalSize{Width: 80, Height: 24}
+			close(resize)
+			_, err := inWriter.Write([]byte("ignored\n"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = inWriter.Close()
+
+			// The lines are written once the attach is started at the end of the logs.
+			deadline := time.Now().Add(5 * time.Second)
+			for !strings.Contains(out.String(), "out") {
+				if time.Now().After(deadline) {
+					t.Fatalf("want the logs attached, got %q", out.String())
+				}
+				f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+				now := time.Now().Format(timeFormatOut)
+				_, _ = fmt.Fprintf(f, "%s stdout F out\n%s stderr F err\n", now, now)
+				_ = f.Close()
+				time.Sleep(100 * time.Millisecond)
+			}
+			time.Sleep(100 * time.Millisecond)
+
+			cancel()
+			err = <-done
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(out.String(), tt.wantOut) {
+				t.Errorf("want stdout %q, got %q", tt.wantOut, out.String())
+			}
+			if !strings.HasPrefix(errOut.String(), tt.wantErr) || (tt.wantErr == "" && errOut.String() != "") {
+				t.Errorf("want stderr %q, got %q", tt.wantErr, errOut.String())
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -48,11 +49,37 @@ func (s *Server) ExecInContainer(ctx context.Context, name string, uid types.UID
 		return err
 	}
 
+	// Play the script instead if one matches the command.
+	if script, ok := findExecScript(execTarget.Scripts, cmd); ok {
+		if resize != nil {
+			go func() {
+				for range resize {
+					// The script is not aware of the size of the terminal.
+				}
+			}()
+		}
+		return s.execScriptInContainer(ctx, script, in, out, errOut, tty)
+	}
+
 	// Currently only support local exec.
 	if execTarget.Local == nil {
 		return fmt.Errorf("not set local exec")
 	}
 
+	// Restrict the command in the sandbox.
+	if execTarget.Local.Sandbox != nil {
+		err = checkExecSandbox(execTarget.Local.Sandbox, cmd)
+		if err != nil {
+			return err
+		}
+		var cleanup func()
+		ctx, cleanup, err = withExecSandbox(ctx, execTarget.Local)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
 	// Set the environment variables.
 	if len(execTarget.Local.Envs) != 0 {
 		envs := slices.Map(execTarget.Local.Envs, func(env internalversion.EnvVar) string {
@@ -76,10 +103,14 @@ func (s *Server) ExecInContainer(ctx context.Context, name string, uid types.UID
 	defer cancel()
 
 	if tty {
-		return s.execInContainerWithTTY(ctx, cmd, in, out, resize)
+		err = s.execInContainerWithTTY(ctx, cmd, in, out, resize)
+	} else {
+		err = s.execInContainer(ctx, cmd, in, out, errOut)
 	}
-
-	return s.execInContainer(ctx, cmd, in, out, errOut)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("command exceeded the timeout of the sandbox: %w", err)
+	}
+	return err
 }
 
 func (s *Server) execInContainer(ctx context.Context, cmd []string, in io.Reader, out, errOut io.WriteCloser) error {
@@ -103,6 +134,8 @@ func (s *Server) execInContainer(ctx context.Context, cmd []string, in io.Reader
 	return nil
 }
 
+// getExecTarget returns the exec target of the container,
+// the Exec of the pod takes precedence over the ClusterExecs matching the pod.
 func (s *Server) getExecTarget(podName, podNamespace string, containerName string) (*internalversion.ExecTarget, error) {
 	e, has := slices.Find(s.execs.Get(), func(pf *internalversion.Exec) bool {
 		return pf.Name == podName && pf.Namespace == podNamespace
@@ -112,14 +145,13 @@ func (s *Server) getExecTarget(podName, podNamespace string, containerName strin
 		if found {
 			return exec, nil
 		}
-		return nil, fmt.Errorf("exec target not found for container %q in pod %q", containerName, log.KRef(podNamespace, podName))
 	}
 
-	for _, ce := range s.clusterExecs.Get() {
-		if !ce.Spec.Selector.Match(podName, podNamespace) {
-			continue
-		}
-
+	labels, namespaceLabels := s.podLabels(podName, podNamespace)
+	clusterExecs := matchClusterResources(s.clusterExecs.Get(), func(ce *internalversion.ClusterExec) *internalversion.ObjectSelector {
+		return ce.Spec.Selector
+	}, podName, podNamespace, labels, namespaceLabels)
+	for _, ce := range clusterExecs {
 		exec, found := findContainerInExecs(containerName, ce.Spec.Execs)
 		if found {
 			return exec, nil
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// execSandboxShells are the shells rejected by the sandbox, as they are able to run any command.
+var execSandboxShells = []string{
+	"sh", "bash", "dash", "ash", "zsh", "ksh", "mksh", "csh", "tcsh", "fish", "busybox",
+	"cmd", "powershell", "pwsh",
+}
+
+// checkExecSandbox returns an error if the cmd is not allowed by the sandbox.
+func checkExecSandbox(sandbox *internalversion.ExecTargetLocalSandbox, cmd []string) error {
+	if len(cmd) == 0 {
+		return fmt.Errorf("empty command is not allowed in the sandbox")
+	}
+
+	// The shells are rejected as any argument too, e.g. "env sh" or "xargs sh" runs a shell as well.
+	for _, arg := range cmd {
+		if isExecSandboxShell(arg) {
+			return fmt.Errorf("shell %q is not allowed in the sandbox", arg)
+		}
+	}
+
+	for _, allowed := range sandbox.AllowedCommands {
+		if len(allowed.Command) != 0 && slices.Equal(cmd, allowed.Command) {
+			return nil
+		}
+	}
+	return fmt.Errorf("command %q is not allowed in the sandbox", strings.Join(cmd, " "))
+}
+
+// isExecSandboxShell returns whether the path or the name is one of the shells.
+func isExecSandboxShell(arg string) bool {
+	name := arg
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+	return slices.Contains(execSandboxShells, name)
+}
+
+// withExecSandbox returns a context executing the commands in the sandbox,
+// the commands don't inherit the environment variables of the kwok except the PATH,
+// and run in a temporary working directory removed by the cleanup if the local has none.
+func withExecSandbox(ctx context.Context, local *internalversion.ExecTargetLocal) (context.Context, func(), error) {
+	sandbox := local.Sandbox
+	var cleanups []func()
+	cleanup := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+
+	// The PATH is kept to look up the commands, it is overridden if set in the envs.
+	ctx = exec.WithIsolateEnv(ctx, true)
+	ctx = exec.WithEnv(ctx, []string{"PATH=" + os.Getenv("PATH")})
+
+	if local.WorkDir == "" {
+		dir, err := os.MkdirTemp("", "kwok-exec-")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create the working directory of the sandbox: %w", err)
+		}
+		cleanups = append(cleanups, func() {
+			_ = os.RemoveAll(dir)
+		})
+		ctx = exec.WithDir(ctx, dir)
+	}
+
+	if sandbox.CPULimitSeconds > 0 {
+		ctx = exec.WithCPULimit(ctx, uint64(sandbox.CPULimitSeconds))
+	}
+
+	if sandbox.TimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(sandbox.TimeoutSeconds)*time.Second)
+		cleanups = append(cleanups, cancel)
+	}
+	return ctx, cleanup, nil
+}
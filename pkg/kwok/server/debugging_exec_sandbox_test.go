@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+)
+
+func TestCheckExecSandbox(t *testing.T) {
+	sandbox := &internalversion.ExecTargetLocalSandbox{
+		AllowedCommands: []internalversion.ExecAllowedCommand{
+			{Command: []string{"ls"}},
+			{Command: []string{"cat", "/etc/hostname"}},
+			{Command: []string{"bash"}},
+			{Command: []string{"env", "sh"}},
+			{},
+		},
+	}
+	tests := []struct {
+		cmd     []string
+		allowed bool
+	}{
+		{cmd: []string{"ls"}, allowed: true},
+		{cmd: []string{"ls", "-l"}},
+		{cmd: []string{"ls", "/"}},
+		{cmd: []string{"cat", "/etc/hostname"}, allowed: true},
+		{cmd: []string{"cat", "/etc/hostname", "/etc/passwd"}},
+		{cmd: []string{"cat", "/etc/passwd"}},
+		{cmd: []string{"rm", "-rf", "/"}},
+		{cmd: []string{"bash"}},
+		{cmd: []string{"/bin/sh", "-c", "ls"}},
+		{cmd: []string{"env", "sh"}},
+		{cmd: []string{"ls", "-l", "/bin/bash"}},
+		{cmd: []string{`C:\Windows\System32\cmd.exe`}},
+		{cmd: []string{}},
+	}
+	for _, tt := range tests {
+		err := checkExecSandbox(sandbox, tt.cmd)
+		if tt.allowed && err != nil {
+			t.Errorf("checkExecSandbox(%q) want allowed, got %v", tt.cmd, err)
+		}
+		if !tt.allowed && err == nil {
+			t.Errorf("checkExecSandbox(%q) want rejected", tt.cmd)
+		}
+	}
+}
+
+func TestWithExecSandbox(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("env and sleep are not available on windows")
+	}
+	t.Setenv("KWOK_TEST_SECRET", "secret")
+
+	local := &internalversion.ExecTargetLocal{
+		Sandbox: &internalversion.ExecTargetLocalSandbox{
+			TimeoutSeconds: 1,
+		},
+	}
+	ctx, cleanup, err := withExecSandbox(context.Background(), local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The envs of the local are set after the sandbox.
+	ctx = exec.WithEnv(ctx, []string{"FOO=bar"})
+
+	dir := exec.GetExecOptions(ctx).Dir
+	if dir == "" {
+		t.Fatalf("want a temporary working directory")
+	}
+
+	out := bytes.NewBuffer(nil)
+	err = exec.Exec(exec.WithWriteTo(ctx, out), "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	envs := strings.Split(strings.TrimSpace(out.String()), "\n")
+	for _, env := range envs {
+		if !strings.HasPrefix(env, "PATH=") && !strings.HasPrefix(env, "FOO=") && !strings.HasPrefix(env, "PWD=") {
+			t.Errorf("want only the PATH and the envs, got %q", env)
+		}
+	}
+
+	err = exec.Exec(ctx, "sleep", "10")
+	if err == nil {
+		t.Errorf("want the command killed after the timeout")
+	}
+
+	cleanup()
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("want the working directory removed, got %v", err)
+	}
+}
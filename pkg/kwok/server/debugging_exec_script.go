@@ -0,0 +1,268 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	utilexec "k8s.io/utils/exec"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// findExecScript returns the first script whose command is the beginning of the cmd.
+func findExecScript(scripts []internalversion.ExecScript, cmd []string) (*internalversion.ExecScript, bool) {
+	for i, script := range scripts {
+		if hasCommandPrefix(cmd, script.Command) {
+			return &scripts[i], true
+		}
+	}
+	return nil, false
+}
+
+// hasCommandPrefix returns true if the prefix is the beginning of the cmd.
+func hasCommandPrefix(cmd []string, prefix []string) bool {
+	if len(prefix) > len(cmd) {
+		return false
+	}
+	for i, arg := range prefix {
+		if cmd[i] != arg {
+			return false
+		}
+	}
+	return true
+}
+
+// execScriptRule is a rule of a script with its compiled match.
+type execScriptRule struct {
+	match *regexp.Regexp
+	rule  *internalversion.ExecScriptRule
+}
+
+// execScriptSession plays a script on the streams of an exec.
+type execScriptSession struct {
+	script *internalversion.ExecScript
+	rules  []execScriptRule
+	in     io.Reader
+	out    io.Writer
+	errOut io.Writer
+	tty    bool
+}
+
+// execScriptInContainer plays the script instead of executing the command,
+// the steps are played first, then the lines of the stdin are matched by the rules until one of them exits or the stdin is closed.
+func (s *Server) execScriptInContainer(ctx context.Context, script *internalversion.ExecScript, in io.Reader, out, errOut io.WriteCloser, tty bool) error {
+	rules := make([]execScriptRule, 0, len(script.Rules))
+	for i := range script.Rules {
+		rule := &script.Rules[i]
+		match, err := regexp.Compile(rule.Match)
+		if err != nil {
+			return fmt.Errorf("failed to compile the match %q of the script: %w", rule.Match, err)
+		}
+		rules = append(rules, execScriptRule{
+			match: match,
+			rule:  rule,
+		})
+	}
+
+	session := &execScriptSession{
+		script: script,
+		rules:  rules,
+		in:     in,
+		out:    out,
+		errOut: errOut,
+		tty:    tty,
+	}
+	// The stderr is merged into the stdout with a tty.
+	if tty || errOut == nil {
+		session.errOut = out
+	}
+	return session.run(ctx)
+}
+
+func (e *execScriptSession) run(ctx context.Context) error {
+	err := e.play(ctx, e.script.Steps)
+	if err != nil {
+		return err
+	}
+
+	if e.in == nil {
+		return execScriptExit(e.script.ExitCode)
+	}
+
+	readLine := e.readLine
+	if e.tty {
+		readLine = e.readLineWithTTY
+	}
+	reader := bufio.NewReader(e.in)
+	for {
+		line, err := readLineWithContext(ctx, readLine, reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return execScriptExit(e.script.ExitCode)
+			}
+			return err
+		}
+
+		for _, r := range e.rules {
+			if !r.match.MatchString(line) {
+				continue
+			}
+			err = e.play(ctx, r.rule.Steps)
+			if err != nil {
+				return err
+			}
+			if r.rule.Exit {
+				return execScriptExit(r.rule.ExitCode)
+			}
+			break
+		}
+	}
+}
+
+// play writes the outputs of the steps after their delays.
+func (e *execScriptSession) play(ctx context.Context, steps []internalversion.ExecScriptStep) error {
+	for _, step := range steps {
+		if step.DelayMilliseconds > 0 {
+			timer := time.NewTimer(time.Duration(step.DelayMilliseconds) * time.Millisecond)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		if step.Stdout != "" {
+			_, err := io.WriteString(e.out, e.format(step.Stdout))
+			if err != nil {
+				return err
+			}
+		}
+		if step.Stderr != "" {
+			_, err := io.WriteString(e.errOut, e.format(step.Stderr))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// format returns the output as a terminal would show it, the line feeds are preceded by the carriage returns with a tty.
+func (e *execScriptSession) format(output string) string {
+	if !e.tty {
+		return output
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(output, "\r\n", "\n"), "\n", "\r\n")
+}
+
+// readLineWithContext reads a line in the background, and gives up when the context is done,
+// the stdin of a stream is not closed until the session returns, so a blocked read would hang forever.
+func readLineWithContext(ctx context.Context, readLine func(*bufio.Reader) (string, error), reader *bufio.Reader) (string, error) {
+	type result struct {
+		line string
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		line, err := readLine(reader)
+		ch <- result{line: line, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case r := <-ch:
+		return r.line, r.err
+	}
+}
+
+// readLine reads a line of the stdin without the line ending.
+func (e *execScriptSession) readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) && line != "" {
+			return strings.TrimSuffix(line, "\r"), nil
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
+
+// readLineWithTTY reads a line of the keys typed on a terminal, echoing them back as a terminal would,
+// the backspace erases the last character, the Ctrl-C interrupts the session and the Ctrl-D closes the stdin on an empty line.
+func (e *execScriptSession) readLineWithTTY(reader *bufio.Reader) (string, error) {
+	var line []rune
+	for {
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(line) != 0 {
+				return string(line), nil
+			}
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			_, err = io.WriteString(e.out, "\r\n")
+			if err != nil {
+				return "", err
+			}
+			return string(line), nil
+		case '\x7f', '\b':
+			if len(line) == 0 {
+				continue
+			}
+			line = line[:len(line)-1]
+			_, err = io.WriteString(e.out, "\b \b")
+		case '\x03':
+			_, err = io.WriteString(e.out, "^C\r\n")
+			if err != nil {
+				return "", err
+			}
+			return "", execScriptExit(130)
+		case '\x04':
+			if len(line) == 0 {
+				return "", io.EOF
+			}
+			continue
+		default:
+			line = append(line, r)
+			_, err = io.WriteString(e.out, string(r))
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// execScriptExit returns the error reporting the exit code to the client, or nil if it is zero.
+func execScriptExit(code int32) error {
+	if code == 0 {
+		return nil
+	}
+	return utilexec.CodeExitError{
+		Err:  fmt.Errorf("command terminated with exit code %d", code),
+		Code: int(code),
+	}
+}
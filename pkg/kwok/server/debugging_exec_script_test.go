@@ -0,0 +1,189 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	utilexec "k8s.io/utils/exec"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestFindExecScript(t *testing.T) {
+	scripts := []internalversion.ExecScript{
+		{Command: []string{"sh", "-c", "ls"}},
+		{Command: []string{"sh"}},
+		{},
+	}
+	tests := []struct {
+		cmd  []string
+		want int
+	}{
+		{cmd: []string{"sh", "-c", "ls"}, want: 0},
+		{cmd: []string{"sh", "-c", "pwd"}, want: 1},
+		{cmd: []string{"sh"}, want: 1},
+		{cmd: []string{"bash"}, want: 2},
+	}
+	for _, tt := range tests {
+		got, ok := findExecScript(scripts, tt.cmd)
+		if !ok || got != &scripts[tt.want] {
+			t.Errorf("findExecScript(%q) want script %d, got %v", tt.cmd, tt.want, got)
+		}
+	}
+
+	_, ok := findExecScript(scripts[:2], []string{"bash"})
+	if ok {
+		t.Errorf("want no script found")
+	}
+}
+
+func TestExecScriptInContainer(t *testing.T) {
+	script := &internalversion.ExecScript{
+		Steps: []internalversion.ExecScriptStep{
+			{Stdout: "welcome\n"},
+			{DelayMilliseconds: 1, Stderr: "warning\n"},
+		},
+		Rules: []internalversion.ExecScriptRule{
+			{
+				Match: "^ls$",
+				Steps: []internalversion.ExecScriptStep{{Stdout: "app.log\n"}},
+			},
+			{
+				Match:    "^exit( [0-9]+)?$",
+				Exit:     true,
+				ExitCode: 3,
+			},
+			{
+				Match: ".*",
+				Steps: []internalversion.ExecScriptStep{{Stderr: "not found\n"}},
+			},
+		},
+		ExitCode: 1,
+	}
+
+	tests := []struct {
+		name       string
+		in         io.Reader
+		tty        bool
+		wantOut    string
+		wantErrOut string
+		wantCode   int
+	}{
+		{
+			name:       "without stdin",
+			wantOut:    "welcome\n",
+			wantErrOut: "warning\n",
+			wantCode:   1,
+		},
+		{
+			name:       "stdin closed",
+			in:         strings.NewReader("ls\r\nfoo\n"),
+			wantOut:    "welcome\napp.log\n",
+			wantErrOut: "warning\nnot found\n",
+			wantCode:   1,
+		},
+		{
+			name:       "exit",
+			in:         strings.NewReader("ls\nexit 3\nls\n"),
+			wantOut:    "welcome\napp.log\n",
+			wantErrOut: "warning\n",
+			wantCode:   3,
+		},
+		{
+			name:     "tty",
+			in:       strings.NewReader("lx\x7fs\rexit\r"),
+			tty:      true,
+			wantOut:  "welcome\r\nwarning\r\nlx\b \bs\r\napp.log\r\nexit\r\n",
+			wantCode: 3,
+		},
+		{
+			name:     "tty interrupted",
+			in:       strings.NewReader("ls\x03ls\r"),
+			tty:      true,
+			wantOut:  "welcome\r\nwarning\r\nls^C\r\n",
+			wantCode: 130,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{}
+			out := bytes.NewBuffer(nil)
+			errOut := bytes.NewBuffer(nil)
+			err := s.execScriptInContainer(context.Background(), script, tt.in, nopWriteCloser{out}, nopWriteCloser{errOut}, tt.tty)
+
+			var code int
+			if err != nil {
+				var exitErr utilexec.CodeExitError
+				if !errors.As(err, &exitErr) {
+					t.Fatalf("want the exit error, got %v", err)
+				}
+				code = exitErr.ExitStatus()
+			}
+			if code != tt.wantCode {
+				t.Errorf("want exit code %d, got %d", tt.wantCode, code)
+			}
+			if out.String() != tt.wantOut {
+				t.Errorf("want stdout %q, got %q", tt.wantOut, out.String())
+			}
+			if errOut.String() != tt.wantErrOut {
+				t.Errorf("want stderr %q, got %q", tt.wantErrOut, errOut.String())
+			}
+		})
+	}
+}
+
+func TestExecScriptInContainerCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The stdin is never closed, the session returns once the context is canceled.
+	in, w := io.Pipe()
+	defer func() {
+		_ = w.Close()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		s := &Server{}
+		out := bytes.NewBuffer(nil)
+		done <- s.execScriptInContainer(ctx, &internalversion.ExecScript{}, in, nopWriteCloser{out}, nil, false)
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("want the canceled error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("want the session returned after the context is canceled")
+	}
+}
@@ -71,7 +71,22 @@ func (s *Server) GetContainerLogs(ctx context.Context, podName, podNamespace, co
 	}
 
 	opts := newLogOptions(logOptions, time.Now())
-	return readLogs(ctx, log.LogsFile, opts, stdout, stderr)
+	logsFile := log.LogsFile
+	if logOptions.Previous {
+		// The previous container is terminated, so its logs are not followed.
+		opts.follow = false
+		if log.Generator == nil {
+			if log.PreviousLogsFile == "" {
+				return fmt.Errorf("previous terminated container %q in pod %q not found", container, podName)
+			}
+			logsFile = log.PreviousLogsFile
+		}
+	}
+
+	if log.Generator != nil {
+		return s.generateLogs(ctx, podName, podNamespace, container, log.Generator, opts, stdout, stderr)
+	}
+	return readLogs(ctx, logsFile, opts, stdout, stderr)
 }
 
 // getContainerLogs handles containerLogs request against the Kubelet
@@ -125,6 +140,8 @@ func (s *Server) getContainerLogs(request *restful.Request, response *restful.Re
 	}
 }
 
+// getPodLogs returns the log of the container,
+// the Logs of the pod takes precedence over the ClusterLogs matching the pod.
 func (s *Server) getPodLogs(podName, podNamespace, containerName string) (*internalversion.Log, error) {
 	l, has := slices.Find(s.logs.Get(), func(l *internalversion.Logs) bool {
 		return l.Name == podName && l.Namespace == podNamespace
@@ -134,14 +151,13 @@ func (s *Server) getPodLogs(podName, podNamespace, containerName string) (*inter
 		if found {
 			return l, nil
 		}
-		return nil, fmt.Errorf("not found log target for container %q in pod %q", containerName, log.KRef(podNamespace, podName))
 	}
 
-	for _, cl := range s.clusterLogs.Get() {
-		if !cl.Spec.Selector.Match(podName, podNamespace) {
-			continue
-		}
-
+	labels, namespaceLabels := s.podLabels(podName, podNamespace)
+	clusterLogs := matchClusterResources(s.clusterLogs.Get(), func(cl *internalversion.ClusterLogs) *internalversion.ObjectSelector {
+		return cl.Spec.Selector
+	}, podName, podNamespace, labels, namespaceLabels)
+	for _, cl := range clusterLogs {
 		log, found := findLogInLogs(containerName, cl.Spec.Logs)
 		if found {
 			return log, nil
@@ -243,6 +259,7 @@ func readLogs(ctx context.Context, logsFile string, opts *logOptions, stdout, st
 	var watcher *fsnotify.Watcher
 	var parse parseFunc
 	var stop bool
+	var reopening bool
 	isNewLine := true
 	found := true
 
@@ -294,14 +311,40 @@ func readLogs(ctx context.Context, logsFile string, opts *logOptions, stdout, st
 					return err
 				}
 
-				if recreated {
+				if !recreated && !reopening {
+					// The log file is truncated in place by a copytruncate rotation, read it from the beginning.
+					truncated, err := isLogsTruncated(f)
+					if err != nil {
+						return fmt.Errorf("failed to check truncation of log file %q: %w", logsFile, err)
+					}
+					if truncated {
+						if _, err := f.Seek(0, io.SeekStart); err != nil {
+							return fmt.Errorf("failed to seek the beginning of log file %q: %w", logsFile, err)
+						}
+						r.Reset(f)
+					}
+				}
+
+				if recreated || reopening {
 					newF, err := os.Open(logsFile)
 					if err != nil {
 						if os.IsNotExist(err) {
+							// The log file is rotated but not created yet,
+							// which is not watched, so retry to open it once the force check period passes.
+							reopening = true
 							continue
 						}
 						return fmt.Errorf("failed to open log file %q: %w", logsFile, err)
 					}
+					reopening = false
+
+					oldInfo, oldErr := f.Stat()
+					newInfo, newErr := newF.Stat()
+					if oldErr == nil && newErr == nil && os.SameFile(oldInfo, newInfo) {
+						// The log file is not rotated, e.g. only its mode is changed.
+						_ = newF.Close()
+						continue
+					}
 
 					defer func(f *os.File) {
 						_ = f.Close()
@@ -364,6 +407,20 @@ func readLogs(ctx context.Context, logsFile string, opts *logOptions, stdout, st
 	}
 }
 
+// isLogsTruncated returns true if the log file is shorter than the offset read,
+// which means it is truncated after it is read.
+func isLogsTruncated(f *os.File) (bool, error) {
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	return info.Size() < offset, nil
+}
+
 // parseFunc is a function parsing one log line to the internal log type.
 // Notice that the caller must make sure logMessage is not nil.
 type parseFunc func([]byte, *logMessage) error
@@ -0,0 +1,235 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+)
+
+const (
+	// defaultLogGeneratorInterval is the interval between the generated log lines if it is not set.
+	defaultLogGeneratorInterval = time.Second
+
+	// defaultLogGeneratorLevel is the level of the generated log lines if the levels are not set.
+	defaultLogGeneratorLevel = "info"
+)
+
+// logGeneratorData is the data given to the templates of the generated log lines.
+type logGeneratorData struct {
+	PodName       string `json:"podName"`
+	PodNamespace  string `json:"podNamespace"`
+	ContainerName string `json:"containerName"`
+	Sequence      int64  `json:"sequence"`
+	Level         string `json:"level"`
+}
+
+// logGeneratorFuncMap returns the functions for the templates of the generated log lines.
+func logGeneratorFuncMap() gotpl.FuncMap {
+	return maps.Merge(gotpl.GenericFuncMap(), gotpl.FuncMap{
+		"randChoice": randChoice,
+	})
+}
+
+// randChoice returns one of the items at random.
+func randChoice(items ...interface{}) interface{} {
+	if len(items) == 0 {
+		return ""
+	}
+	//nolint:gosec
+	return items[rand.Intn(len(items))]
+}
+
+// generateLogs writes the log lines generated by the generator,
+// the history lines are timestamped back from now, then the new lines are written at the interval if it is followed.
+func (s *Server) generateLogs(ctx context.Context, podName, podNamespace, container string, generator *internalversion.LogGenerator, opts *logOptions, stdout, stderr io.Writer) error {
+	for _, field := range generator.Fields {
+		if err := s.logsRenderer.Parse(field.Template); err != nil {
+			return fmt.Errorf("failed to parse the template of the field %q: %w", field.Name, err)
+		}
+	}
+	if err := s.logsRenderer.Parse(generator.Template); err != nil {
+		return fmt.Errorf("failed to parse the template of the logs: %w", err)
+	}
+
+	interval := defaultLogGeneratorInterval
+	if generator.IntervalMilliseconds > 0 {
+		interval = time.Duration(generator.IntervalMilliseconds) * time.Millisecond
+	}
+
+	writer := newLogWriter(stdout, stderr, opts)
+	data := logGeneratorData{
+		PodName:       podName,
+		PodNamespace:  podNamespace,
+		ContainerName: container,
+	}
+	write := func(sequence int64, timestamp time.Time) error {
+		data.Sequence = sequence
+		data.Level = pickLogLevel(generator.Levels)
+		line, err := s.generateLogLine(generator, data, timestamp)
+		if err != nil {
+			return err
+		}
+		msg := &logMessage{
+			timestamp: timestamp,
+			stream:    runtimeapi.Stdout,
+			log:       line,
+		}
+		return writer.write(msg, true)
+	}
+
+	history := generator.HistoryLines
+	skipped := int64(0)
+	if opts.tail >= 0 && opts.tail < history {
+		skipped = history - opts.tail
+	}
+	now := time.Now()
+	for sequence := skipped + 1; sequence <= history; sequence++ {
+		err := write(sequence, now.Add(-time.Duration(history-sequence)*interval))
+		if err != nil {
+			if errors.Is(err, errMaximumWrite) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	if !opts.follow {
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for sequence := history + 1; ; sequence++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case timestamp := <-ticker.C:
+			err := write(sequence, timestamp)
+			if err != nil {
+				if errors.Is(err, errMaximumWrite) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+}
+
+// generateLogLine returns a log line in the format of the generator.
+func (s *Server) generateLogLine(generator *internalversion.LogGenerator, data logGeneratorData, timestamp time.Time) ([]byte, error) {
+	message, err := s.logsRenderer.ToText(generator.Template, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the template of the logs: %w", err)
+	}
+
+	fields := make([][2]string, 0, len(generator.Fields))
+	for _, field := range generator.Fields {
+		value, err := s.logsRenderer.ToText(field.Template, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render the template of the field %q: %w", field.Name, err)
+		}
+		fields = append(fields, [2]string{field.Name, string(value)})
+	}
+
+	buf := bytes.NewBuffer(nil)
+	t := timestamp.UTC().Format(time.RFC3339Nano)
+	switch generator.Format {
+	case internalversion.LogGeneratorFormatJSON:
+		buf.WriteByte('{')
+		writeJSONField(buf, "time", t)
+		buf.WriteByte(',')
+		writeJSONField(buf, "level", data.Level)
+		buf.WriteByte(',')
+		writeJSONField(buf, "msg", string(message))
+		for _, field := range fields {
+			buf.WriteByte(',')
+			writeJSONField(buf, field[0], field[1])
+		}
+		buf.WriteByte('}')
+	case internalversion.LogGeneratorFormatText, "":
+		buf.WriteString(t)
+		buf.WriteByte(' ')
+		buf.WriteString(strings.ToUpper(data.Level))
+		buf.WriteByte(' ')
+		buf.Write(message)
+		for _, field := range fields {
+			buf.WriteByte(' ')
+			buf.WriteString(field[0])
+			buf.WriteByte('=')
+			if strings.ContainsAny(field[1], " \t\n\"=") {
+				buf.WriteString(strconv.Quote(field[1]))
+			} else {
+				buf.WriteString(field[1])
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q of the logs", generator.Format)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// writeJSONField writes the key and the value as a field of a JSON object.
+func writeJSONField(buf *bytes.Buffer, key, value string) {
+	k, _ := json.Marshal(key)
+	v, _ := json.Marshal(value)
+	buf.Write(k)
+	buf.WriteByte(':')
+	buf.Write(v)
+}
+
+// pickLogLevel returns a level at random by the weights of the levels,
+// the levels are picked evenly if none of them is weighted.
+func pickLogLevel(levels []internalversion.LogGeneratorLevel) string {
+	if len(levels) == 0 {
+		return defaultLogGeneratorLevel
+	}
+
+	total := int64(0)
+	for _, level := range levels {
+		total += level.Weight
+	}
+	if total <= 0 {
+		//nolint:gosec
+		return levels[rand.Intn(len(levels))].Level
+	}
+
+	//nolint:gosec
+	n := rand.Int63n(total)
+	for _, level := range levels {
+		if n < level.Weight {
+			return level.Level
+		}
+		n -= level.Weight
+	}
+	return levels[len(levels)-1].Level
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+func TestGenerateLogs(t *testing.T) {
+	s := &Server{
+		logsRenderer: gotpl.NewRenderer(logGeneratorFuncMap()),
+	}
+
+	generator := &internalversion.LogGenerator{
+		Template: `{{ randChoice "GET" "POST" }} /api/{{ .podName }} {{ .sequence }}`,
+		Fields: []internalversion.LogGeneratorField{
+			{Name: "pod", Template: `{{ .podNamespace }}/{{ .podName }}`},
+			{Name: "latency", Template: `{{ randInt 1 10 }}ms`},
+		},
+		Levels: []internalversion.LogGeneratorLevel{
+			{Level: "info", Weight: 1},
+			{Level: "debug"},
+		},
+		HistoryLines: 5,
+	}
+
+	out := bytes.NewBuffer(nil)
+	opts := newLogOptions(&corev1.PodLogOptions{TailLines: format.Ptr[int64](2)}, time.Now())
+	err := s.generateLogs(context.Background(), "pod0", "default", "app", generator, opts, out, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want the 2 tail lines, got %q", lines)
+	}
+	for i, line := range lines {
+		want := regexp.MustCompile(`^\S+ INFO (GET|POST) /api/pod0 ` + []string{"4", "5"}[i] + ` pod=default/pod0 latency=[1-9]ms$`)
+		if !want.MatchString(line) {
+			t.Errorf("want the line %d to match %s, got %q", i, want, line)
+		}
+	}
+
+	generator.Format = internalversion.LogGeneratorFormatJSON
+	generator.IntervalMilliseconds = 10
+	out.Reset()
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	defer cancel()
+	opts = newLogOptions(&corev1.PodLogOptions{Follow: true}, time.Now())
+	err = s.generateLogs(ctx, "pod0", "default", "app", generator, opts, out, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines = strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) <= 5 {
+		t.Fatalf("want the followed lines after the history, got %q", lines)
+	}
+	for _, line := range lines {
+		var entry map[string]string
+		err = json.Unmarshal([]byte(line), &entry)
+		if err != nil {
+			t.Fatalf("want a JSON line, got %q: %v", line, err)
+		}
+		if entry["level"] != "info" || entry["pod"] != "default/pod0" || entry["time"] == "" || !strings.HasPrefix(entry["msg"], "GET") && !strings.HasPrefix(entry["msg"], "POST") {
+			t.Errorf("unexpected line %q", line)
+		}
+	}
+}
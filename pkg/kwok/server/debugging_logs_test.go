@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+// syncBuffer is a buffer safe to read while the logs are written.
+type syncBuffer struct {
+	mut sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return b.buf.String()
+}
+
+func criLogLines(start time.Time, from, to int) string {
+	buf := bytes.NewBuffer(nil)
+	for i := from; i <= to; i++ {
+		fmt.Fprintf(buf, "%s stdout F line %d\n", start.Add(time.Duration(i)*time.Second).Format(timeFormatOut), i)
+	}
+	return buf.String()
+}
+
+func writeLogsFile(t *testing.T, path string, content string) {
+	t.Helper()
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitLogsOutput(t *testing.T, out *syncBuffer, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("want %q in the logs, got %q", want, out.String())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestGetContainerLogs(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := filepath.Join(dir, "current.log")
+	previous := filepath.Join(dir, "previous.log")
+	writeLogsFile(t, current, criLogLines(start, 1, 5))
+	writeLogsFile(t, previous, criLogLines(start, 0, 0))
+
+	s := &Server{
+		logs: resources.NewStaticGetter([]*internalversion.Logs{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod0", Namespace: "default"},
+				Spec: internalversion.LogsSpec{
+					Logs: []internalversion.Log{
+						{Containers: []string{"app"}, LogsFile: current, PreviousLogsFile: previous},
+						{Containers: []string{"sidecar"}, LogsFile: current},
+					},
+				},
+			},
+		}),
+		clusterLogs: resources.NewStaticGetter([]*internalversion.ClusterLogs{}),
+	}
+
+	tests := []struct {
+		name      string
+		container string
+		opts      *corev1.PodLogOptions
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "all",
+			container: "app",
+			opts:      &corev1.PodLogOptions{},
+			want:      "line 1\nline 2\nline 3\nline 4\nline 5\n",
+		},
+		{
+			name:      "tail",
+			container: "app",
+			opts:      &corev1.PodLogOptions{TailLines: format.Ptr[int64](2)},
+			want:      "line 4\nline 5\n",
+		},
+		{
+			name:      "since time",
+			container: "app",
+			opts:      &corev1.PodLogOptions{SinceTime: &metav1.Time{Time: start.Add(3 * time.Second)}},
+			want:      "line 3\nline 4\nline 5\n",
+		},
+		{
+			name:      "previous",
+			container: "app",
+			opts:      &corev1.PodLogOptions{Previous: true, Follow: true},
+			want:      "line 0\n",
+		},
+		{
+			name:      "previous not found",
+			container: "sidecar",
+			opts:      &corev1.PodLogOptions{Previous: true},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			err := s.GetContainerLogs(context.Background(), "pod0", "default", tt.container, tt.opts, out, out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("want %q, got %q", tt.want, out.String())
+			}
+		})
+	}
+}
+
+func TestReadLogsRotation(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := filepath.Join(dir, "app.log")
+	writeLogsFile(t, path, criLogLines(start, 1, 2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+	go func() {
+		done <- readLogs(ctx, path, newLogOptions(&corev1.PodLogOptions{Follow: true}, time.Now()), out, out)
+	}()
+	waitLogsOutput(t, out, "line 2\n")
+
+	// The copytruncate rotation truncates the log file in place.
+	writeLogsFile(t, path, criLogLines(start, 3, 3))
+	waitLogsOutput(t, out, "line 3\n")
+
+	// The rename rotation moves the log file away, and creates a new one later.
+	err := os.Rename(path, path+".1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	writeLogsFile(t, path, criLogLines(start, 4, 4))
+	waitLogsOutput(t, out, "line 4\n")
+
+	cancel()
+	<-done
+	if got, want := out.String(), "line 1\nline 2\nline 3\nline 4\n"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// maxForwardHTTPBodySize is the maximum size of the body of a request given to the templates.
+const maxForwardHTTPBodySize = 1 << 20
+
+// forwardHTTPData is the data given to the templates of the HTTP responses.
+type forwardHTTPData struct {
+	Method       string              `json:"method"`
+	Path         string              `json:"path"`
+	Query        map[string][]string `json:"query"`
+	Headers      map[string]string   `json:"headers"`
+	Body         string              `json:"body"`
+	PodName      string              `json:"podName"`
+	PodNamespace string              `json:"podNamespace"`
+	Port         int32               `json:"port"`
+}
+
+// serveForwardHTTP serves the HTTP requests read from the stream,
+// until the stream is closed or a request asks to close the connection.
+func (s *Server) serveForwardHTTP(ctx context.Context, podName, podNamespace string, port int32, stub *internalversion.ForwardHTTP, stream io.ReadWriter) error {
+	for _, route := range stub.Routes {
+		if err := s.forwardRenderer.Parse(route.Body); err != nil {
+			return fmt.Errorf("failed to parse the body of the route %q: %w", route.Path, err)
+		}
+	}
+
+	r := bufio.NewReader(stream)
+	for {
+		req, err := http.ReadRequest(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read the request: %w", err)
+		}
+
+		resp, err := s.respondForwardHTTP(ctx, podName, podNamespace, port, stub.Routes, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		err = resp.Write(stream)
+		if err != nil {
+			return fmt.Errorf("failed to write the response: %w", err)
+		}
+		if resp.Close {
+			return nil
+		}
+	}
+}
+
+// respondForwardHTTP returns the response of the first route matching the request,
+// or the request echoed back as JSON if none matches.
+func (s *Server) respondForwardHTTP(ctx context.Context, podName, podNamespace string, port int32, routes []internalversion.ForwardHTTPRoute, req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxForwardHTTPBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the body of the request: %w", err)
+	}
+	// Discard the rest of the body so that the next request can be read.
+	_, _ = io.Copy(io.Discard, req.Body)
+	_ = req.Body.Close()
+
+	headers := make(map[string]string, len(req.Header))
+	for key, values := range req.Header {
+		headers[key] = strings.Join(values, ", ")
+	}
+	data := forwardHTTPData{
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		Query:        req.URL.Query(),
+		Headers:      headers,
+		Body:         string(body),
+		PodName:      podName,
+		PodNamespace: podNamespace,
+		Port:         port,
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Request:    req,
+		Header:     http.Header{},
+		Close:      req.Close,
+	}
+
+	route, found := findForwardHTTPRoute(routes, req)
+	if !found {
+		content, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the request: %w", err)
+		}
+		resp.Header.Set("Content-Type", "application/json")
+		setForwardHTTPBody(resp, content)
+		return resp, nil
+	}
+
+	if route.DelayMilliseconds > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(route.DelayMilliseconds) * time.Millisecond):
+		}
+	}
+
+	content, err := s.forwardRenderer.ToText(route.Body, data)
+	if err != nil {
+		resp.StatusCode = http.StatusInternalServerError
+		resp.Header.Set("Content-Type", "text/plain; charset=utf-8")
+		setForwardHTTPBody(resp, []byte(fmt.Sprintf("failed to render the body of the route %q: %v\n", route.Path, err)))
+		return resp, nil
+	}
+
+	if route.StatusCode != 0 {
+		resp.StatusCode = int(route.StatusCode)
+	}
+	for _, header := range route.Headers {
+		resp.Header.Add(header.Name, header.Value)
+	}
+	if resp.Header.Get("Content-Type") == "" {
+		resp.Header.Set("Content-Type", http.DetectContentType(content))
+	}
+	setForwardHTTPBody(resp, content)
+	return resp, nil
+}
+
+// setForwardHTTPBody sets the body of the response.
+func setForwardHTTPBody(resp *http.Response, body []byte) {
+	resp.ContentLength = int64(len(body))
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+}
+
+// findForwardHTTPRoute returns the first route matching the method and the path of the request.
+func findForwardHTTPRoute(routes []internalversion.ForwardHTTPRoute, req *http.Request) (*internalversion.ForwardHTTPRoute, bool) {
+	for i, route := range routes {
+		if route.Method != "" && !strings.EqualFold(route.Method, req.Method) {
+			continue
+		}
+		if route.Path != "" {
+			if strings.HasSuffix(route.Path, "/") {
+				if !strings.HasPrefix(req.URL.Path, route.Path) {
+					continue
+				}
+			} else if req.URL.Path != route.Path {
+				continue
+			}
+		}
+		return &routes[i], true
+	}
+	return nil, false
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+func TestPortForwardHTTP(t *testing.T) {
+	s := &Server{
+		portForwards: resources.NewStaticGetter([]*internalversion.PortForward{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod0", Namespace: "default"},
+				Spec: internalversion.PortForwardSpec{
+					Forwards: []internalversion.Forward{
+						{
+							HTTP: &internalversion.ForwardHTTP{
+								Routes: []internalversion.ForwardHTTPRoute{
+									{
+										Method: "GET",
+										Path:   "/healthz",
+										Body:   "ok",
+									},
+									{
+										Path:       "/api/",
+										StatusCode: http.StatusCreated,
+										Headers: []internalversion.ForwardHTTPHeader{
+											{Name: "Content-Type", Value: "application/json"},
+										},
+										Body: `{"pod":"{{ .podNamespace }}/{{ .podName }}","port":{{ .port }},"path":"{{ .path }}","body":"{{ .body }}"}`,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}),
+		clusterPortForwards: resources.NewStaticGetter([]*internalversion.ClusterPortForward{}),
+		forwardRenderer:     gotpl.NewRenderer(gotpl.GenericFuncMap()),
+	}
+
+	client, server := net.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- s.PortForward(context.Background(), "pod0/default", "", 8080, server)
+	}()
+
+	r := bufio.NewReader(client)
+	do := func(method, path, body string) (*http.Response, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, "http://localhost:8080"+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = req.Write(client)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.ReadResponse(r, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp, string(content)
+	}
+
+	resp, body := do(http.MethodGet, "/healthz", "")
+	if resp.StatusCode != http.StatusOK || body != "ok" {
+		t.Errorf("want 200 ok, got %d %q", resp.StatusCode, body)
+	}
+
+	resp, body = do(http.MethodPost, "/api/items", "foo")
+	want := `{"pod":"default/pod0","port":8080,"path":"/api/items","body":"foo"}`
+	if resp.StatusCode != http.StatusCreated || body != want {
+		t.Errorf("want 201 %q, got %d %q", want, resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("want the header of the route, got %q", got)
+	}
+
+	// The requests not matching any route are echoed back.
+	resp, body = do(http.MethodPost, "/healthz?foo=bar", "baz")
+	var echo forwardHTTPData
+	err := json.Unmarshal([]byte(body), &echo)
+	if err != nil {
+		t.Fatalf("want the request echoed back as JSON, got %q: %v", body, err)
+	}
+	if resp.StatusCode != http.StatusOK || echo.Method != http.MethodPost || echo.Path != "/healthz" ||
+		echo.Query["foo"][0] != "bar" || echo.Body != "baz" || echo.PodName != "pod0" || echo.Port != 8080 {
+		t.Errorf("unexpected echo %d %q", resp.StatusCode, body)
+	}
+
+	_ = client.Close()
+	err = <-done
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -52,6 +52,10 @@ func (s *Server) PortForward(ctx context.Context, name string, uid types.UID, po
 		return err
 	}
 
+	if forward.HTTP != nil {
+		return s.serveForwardHTTP(ctx, podName, podNamespace, port, forward.HTTP, stream)
+	}
+
 	if len(forward.Command) > 0 {
 		return exec.Exec(exec.WithReadWriter(ctx, stream), forward.Command[0], forward.Command[1:]...)
 	}
@@ -77,7 +81,7 @@ func (s *Server) PortForward(ctx context.Context, name string, uid types.UID, po
 		return tunnel(ctx, stream, dial, buf1, buf2)
 	}
 
-	return errors.New("no target or command")
+	return errors.New("no target, command or http")
 }
 
 // getPortForward handles a new restful port forward request. It determines the
@@ -106,6 +110,8 @@ func (s *Server) getPortForward(req *restful.Request, resp *restful.Response) {
 	)
 }
 
+// getPodsForward returns the forward of the port,
+// the PortForward of the pod takes precedence over the ClusterPortForwards matching the pod.
 func (s *Server) getPodsForward(podName, podNamespace string, port int32) (*internalversion.Forward, error) {
 	pf, has := slices.Find(s.portForwards.Get(), func(pf *internalversion.PortForward) bool {
 		return pf.Name == podName && pf.Namespace == podNamespace
@@ -115,14 +121,13 @@ func (s *Server) getPodsForward(podName, podNamespace string, port int32) (*inte
 		if found {
 			return forward, nil
 		}
-		return nil, fmt.Errorf("forward not found for port %d in pod %q", port, log.KRef(podNamespace, podName))
 	}
 
-	for _, cfw := range s.clusterPortForwards.Get() {
-		if !cfw.Spec.Selector.Match(podName, podNamespace) {
-			continue
-		}
-
+	labels, namespaceLabels := s.podLabels(podName, podNamespace)
+	clusterPortForwards := matchClusterResources(s.clusterPortForwards.Get(), func(cfw *internalversion.ClusterPortForward) *internalversion.ObjectSelector {
+		return cfw.Spec.Selector
+	}, podName, podNamespace, labels, namespaceLabels)
+	for _, cfw := range clusterPortForwards {
 		forward, found := findPortInForwards(port, cfw.Spec.Forwards)
 		if found {
 			return forward, nil
@@ -40,11 +40,12 @@ func (s *Server) InstallMetrics(ctx context.Context) error {
 		promHandler.ServeHTTP(resp.ResponseWriter, req.Request)
 	}
 
-	env, err := cel.NewEnvironment(cel.NodeEvaluatorConfig{
+	env, err := cel.NewEnvironment(s.resourceUsageEvaluatorConfig(cel.NodeEvaluatorConfig{
 		EnableEvaluatorCache:   true,
 		EnableResultCache:      true,
 		StartedContainersTotal: s.dataSource.StartedContainersTotal,
-	})
+		AllocatedResource:      s.dataSource.AllocatedResource,
+	}))
 	if err != nil {
 		return fmt.Errorf("failed to create CEL environment: %w", err)
 	}
@@ -112,15 +113,20 @@ func (s *Server) getMetrics(metric *internalversion.Metric, env *cel.Environment
 			nodeName = metric.Name
 		}
 
-		handler, ok := s.metricsUpdateHandler.Load(nodeName)
+		// Each Metric has its own handler for the node, so the Metrics with the different paths don't unregister the metrics of each other.
+		key := metric.Name + "/" + nodeName
+		handler, ok := s.metricsUpdateHandler.Load(key)
 		if !ok {
 			handler = metrics.NewMetricsUpdateHandler(metrics.UpdateHandlerConfig{
 				Environment:     env,
 				DataSource:      s.dataSource,
 				NodeCacheGetter: s.nodeCacheGetter,
 				PodCacheGetter:  s.podCacheGetter,
+				MaxSeries:       s.metricsMaxSeries,
+				MaxLabelValues:  s.metricsMaxLabelValues,
+				ScrapeTimeout:   s.metricsScrapeTimeout,
 			})
-			s.metricsUpdateHandler.Store(nodeName, handler)
+			s.metricsUpdateHandler.Store(key, handler)
 		}
 
 		handler.Update(req.Request.Context(), nodeName, metric.Spec.Metrics)
@@ -0,0 +1,359 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+const (
+	metricsAPIGroupVersion = "metrics.k8s.io/v1beta1"
+	metricsAPIPath         = "/apis/" + metricsAPIGroupVersion
+
+	// metricsAPIWindow is the window of the usage reported, the same as the one of the kubelet.
+	metricsAPIWindow = 15 * time.Second
+)
+
+// metricsAPIResources are the usages of the resources reported by the metrics API.
+var metricsAPIResources = []corev1.ResourceName{
+	corev1.ResourceCPU,
+	corev1.ResourceMemory,
+}
+
+// nodeMetrics is the NodeMetrics of the metrics API.
+type nodeMetrics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Timestamp         metav1.Time         `json:"timestamp"`
+	Window            metav1.Duration     `json:"window"`
+	Usage             corev1.ResourceList `json:"usage"`
+}
+
+// nodeMetricsList is the NodeMetricsList of the metrics API.
+type nodeMetricsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []nodeMetrics `json:"items"`
+}
+
+// podMetrics is the PodMetrics of the metrics API.
+type podMetrics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Timestamp         metav1.Time        `json:"timestamp"`
+	Window            metav1.Duration    `json:"window"`
+	Containers        []containerMetrics `json:"containers"`
+}
+
+// podMetricsList is the PodMetricsList of the metrics API.
+type podMetricsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []podMetrics `json:"items"`
+}
+
+// containerMetrics is the ContainerMetrics of the metrics API.
+type containerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// InstallMetricsAPI installs the handlers of the metrics API (metrics.k8s.io) serving the usages of the resources
+// of the managed nodes and the pods on them, the same as the metrics-server does,
+// it is registered as an APIService to make `kubectl top` and the HorizontalPodAutoscalers work.
+func (s *Server) InstallMetricsAPI() {
+	ws := new(restful.WebService)
+	ws.Path(metricsAPIPath)
+	ws.Produces(restful.MIME_JSON)
+	ws.Route(ws.GET("").To(s.metricsAPIResourceList))
+	ws.Route(ws.GET("/nodes").To(s.listNodeMetrics))
+	ws.Route(ws.GET("/nodes/{name}").To(s.getNodeMetrics))
+	ws.Route(ws.GET("/pods").To(s.listPodMetrics))
+	ws.Route(ws.GET("/namespaces/{namespace}/pods").To(s.listPodMetrics))
+	ws.Route(ws.GET("/namespaces/{namespace}/pods/{name}").To(s.getPodMetrics))
+	s.restfulCont.Add(ws)
+}
+
+func (s *Server) metricsAPIResourceList(req *restful.Request, resp *restful.Response) {
+	verbs := metav1.Verbs{"get", "list"}
+	writeMetricsAPIResponse(req, resp, &metav1.APIResourceList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "APIResourceList",
+			APIVersion: "v1",
+		},
+		GroupVersion: metricsAPIGroupVersion,
+		APIResources: []metav1.APIResource{
+			{
+				Name:       "nodes",
+				Kind:       "NodeMetrics",
+				Namespaced: false,
+				Verbs:      verbs,
+			},
+			{
+				Name:       "pods",
+				Kind:       "PodMetrics",
+				Namespaced: true,
+				Verbs:      verbs,
+			},
+		},
+	})
+}
+
+// metricsAPIListOptions are the options of listing of the metrics API.
+type metricsAPIListOptions struct {
+	selector labels.Selector
+	// limit is the max number of the items returned, 0 means no limit.
+	limit int
+	// continueKey is the key of the last item returned by the previous page.
+	continueKey string
+}
+
+func parseMetricsAPIListOptions(req *restful.Request) (metricsAPIListOptions, error) {
+	opts := metricsAPIListOptions{
+		continueKey: req.QueryParameter("continue"),
+	}
+	selector, err := labels.Parse(req.QueryParameter("labelSelector"))
+	if err != nil {
+		return opts, err
+	}
+	opts.selector = selector
+	if limit := req.QueryParameter("limit"); limit != "" {
+		opts.limit, err = strconv.Atoi(limit)
+		if err != nil || opts.limit < 0 {
+			return opts, fmt.Errorf("invalid limit %q", limit)
+		}
+	}
+	return opts, nil
+}
+
+// paginate returns the keys sorted after the continue key, at most the limit of them,
+// and the continue key of the next page, which is empty if there are no more.
+func (o metricsAPIListOptions) paginate(keys []string) ([]string, string) {
+	sort.Strings(keys)
+	if o.continueKey != "" {
+		keys = keys[sort.SearchStrings(keys, o.continueKey):]
+		if len(keys) != 0 && keys[0] == o.continueKey {
+			keys = keys[1:]
+		}
+	}
+	if o.limit == 0 || len(keys) <= o.limit {
+		return keys, ""
+	}
+	keys = keys[:o.limit]
+	return keys, keys[len(keys)-1]
+}
+
+func (s *Server) listNodeMetrics(req *restful.Request, resp *restful.Response) {
+	opts, err := parseMetricsAPIListOptions(req)
+	if err != nil {
+		_ = resp.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list := &nodeMetricsList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NodeMetricsList",
+			APIVersion: metricsAPIGroupVersion,
+		},
+		Items: []nodeMetrics{},
+	}
+	nodes := map[string]*corev1.Node{}
+	for _, nodeName := range s.dataSource.ListNodes() {
+		node, ok := s.nodeCacheGetter.Get(nodeName)
+		if !ok || !opts.selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		nodes[nodeName] = node
+	}
+	nodeNames, continueKey := opts.paginate(maps.Keys(nodes))
+	list.Continue = continueKey
+	for _, nodeName := range nodeNames {
+		m, err := s.nodeMetrics(nodes[nodeName])
+		if err != nil {
+			logger := log.FromContext(req.Request.Context())
+			logger.Warn("Failed to get the usage of the node", "node", nodeName, "err", err)
+			continue
+		}
+		list.Items = append(list.Items, *m)
+	}
+	writeMetricsAPIResponse(req, resp, list)
+}
+
+func (s *Server) getNodeMetrics(req *restful.Request, resp *restful.Response) {
+	nodeName := req.PathParameter("name")
+	node, ok := s.nodeCacheGetter.Get(nodeName)
+	if !ok || !slices.Contains(s.dataSource.ListNodes(), nodeName) {
+		_ = resp.WriteErrorString(http.StatusNotFound, "nodemetrics \""+nodeName+"\" not found")
+		return
+	}
+	m, err := s.nodeMetrics(node)
+	if err != nil {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+	m.TypeMeta = metav1.TypeMeta{
+		Kind:       "NodeMetrics",
+		APIVersion: metricsAPIGroupVersion,
+	}
+	writeMetricsAPIResponse(req, resp, m)
+}
+
+func (s *Server) listPodMetrics(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("namespace")
+	opts, err := parseMetricsAPIListOptions(req)
+	if err != nil {
+		_ = resp.WriteErrorString(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list := &podMetricsList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodMetricsList",
+			APIVersion: metricsAPIGroupVersion,
+		},
+		Items: []podMetrics{},
+	}
+	// The pods are paginated before their usages are evaluated, so a page costs no more than its pods.
+	pods := map[string]*corev1.Pod{}
+	for _, nodeName := range s.dataSource.ListNodes() {
+		for _, pod := range s.podsOnNode(nodeName) {
+			if namespace != "" && pod.Namespace != namespace {
+				continue
+			}
+			if pod.Status.Phase != corev1.PodRunning || !opts.selector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			pods[pod.Namespace+"/"+pod.Name] = pod
+		}
+	}
+	keys, continueKey := opts.paginate(maps.Keys(pods))
+	list.Continue = continueKey
+	for _, key := range keys {
+		pod := pods[key]
+		m, err := s.podMetrics(pod)
+		if err != nil {
+			logger := log.FromContext(req.Request.Context())
+			logger.Warn("Failed to get the usage of the pod", "pod", log.KObj(pod), "err", err)
+			continue
+		}
+		list.Items = append(list.Items, *m)
+	}
+	writeMetricsAPIResponse(req, resp, list)
+}
+
+func (s *Server) getPodMetrics(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("namespace")
+	name := req.PathParameter("name")
+	pod, ok := s.podCacheGetter.GetWithNamespace(name, namespace)
+	if !ok || pod.Status.Phase != corev1.PodRunning || !slices.Contains(s.dataSource.ListNodes(), pod.Spec.NodeName) {
+		_ = resp.WriteErrorString(http.StatusNotFound, "podmetrics \""+name+"\" not found")
+		return
+	}
+	m, err := s.podMetrics(pod)
+	if err != nil {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+	m.TypeMeta = metav1.TypeMeta{
+		Kind:       "PodMetrics",
+		APIVersion: metricsAPIGroupVersion,
+	}
+	writeMetricsAPIResponse(req, resp, m)
+}
+
+func (s *Server) nodeMetrics(node *corev1.Node) (*nodeMetrics, error) {
+	usage := corev1.ResourceList{}
+	for _, resourceName := range metricsAPIResources {
+		value, err := s.nodeResourceUsage(string(resourceName), node.Name, s.containerResourceUsage)
+		if err != nil {
+			return nil, err
+		}
+		usage[resourceName] = usageQuantity(resourceName, value)
+	}
+	return &nodeMetrics{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              node.Name,
+			Labels:            node.Labels,
+			CreationTimestamp: metav1.Now(),
+		},
+		Timestamp: metav1.Now(),
+		Window:    metav1.Duration{Duration: metricsAPIWindow},
+		Usage:     usage,
+	}, nil
+}
+
+func (s *Server) podMetrics(pod *corev1.Pod) (*podMetrics, error) {
+	containers := make([]containerMetrics, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		usage := corev1.ResourceList{}
+		for _, resourceName := range metricsAPIResources {
+			value, err := s.containerResourceUsage(string(resourceName), pod, container.Name)
+			if err != nil {
+				return nil, err
+			}
+			usage[resourceName] = usageQuantity(resourceName, value)
+		}
+		containers = append(containers, containerMetrics{
+			Name:  container.Name,
+			Usage: usage,
+		})
+	}
+	return &podMetrics{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              pod.Name,
+			Namespace:         pod.Namespace,
+			Labels:            pod.Labels,
+			CreationTimestamp: metav1.Now(),
+		},
+		Timestamp:  metav1.Now(),
+		Window:     metav1.Duration{Duration: metricsAPIWindow},
+		Containers: containers,
+	}, nil
+}
+
+// usageQuantity returns the quantity of the usage, the cpu is in nanocores and the others are in their units.
+func usageQuantity(resourceName corev1.ResourceName, value float64) resource.Quantity {
+	if value < 0 {
+		value = 0
+	}
+	if resourceName == corev1.ResourceCPU {
+		return *resource.NewScaledQuantity(int64(value*1e9), resource.Nano)
+	}
+	return *resource.NewQuantity(int64(value), resource.BinarySI)
+}
+
+func writeMetricsAPIResponse(req *restful.Request, resp *restful.Response, obj any) {
+	err := resp.WriteAsJson(obj)
+	if err != nil {
+		logger := log.FromContext(req.Request.Context())
+		logger.Error("Failed to write", err)
+	}
+}
@@ -0,0 +1,459 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwok/controllers"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+type fakeDataSource struct {
+	pods map[string][]log.ObjectRef
+}
+
+func (f *fakeDataSource) ListPods(nodeName string) ([]log.ObjectRef, bool) {
+	pods, ok := f.pods[nodeName]
+	return pods, ok
+}
+
+func (f *fakeDataSource) ListNodes() []string {
+	nodes := make([]string, 0, len(f.pods))
+	for node := range f.pods {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (f *fakeDataSource) StartedContainersTotal(nodeName string) int64 {
+	return 0
+}
+
+func (f *fakeDataSource) AllocatedResource(nodeName, resourceName string) float64 {
+	return 0
+}
+
+func (f *fakeDataSource) StageStatistics() []controllers.StageStatistic {
+	return nil
+}
+
+func (f *fakeDataSource) ClusterStatistics() controllers.ClusterStatistics {
+	return controllers.ClusterStatistics{}
+}
+
+type fakeGetter[T any] struct {
+	items map[string]T
+}
+
+func (f *fakeGetter[T]) Get(name string) (T, bool) {
+	t, ok := f.items[name]
+	return t, ok
+}
+
+func (f *fakeGetter[T]) GetWithNamespace(name, namespace string) (T, bool) {
+	return f.Get(namespace + "/" + name)
+}
+
+func (f *fakeGetter[T]) List() []T {
+	list := make([]T, 0, len(f.items))
+	for _, t := range f.items {
+		list = append(list, t)
+	}
+	return list
+}
+
+func newTestPod(name string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID("uid-" + name),
+			Labels: map[string]string{
+				"app": name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node0",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Second)},
+		},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name: container,
+		})
+	}
+	return pod
+}
+
+func newTestMetricsAPIServer(t *testing.T) *Server {
+	pods := []*corev1.Pod{
+		newTestPod("pod0", "app", "sidecar"),
+		newTestPod("pod1", "app"),
+		newTestPod("pod2", "app"),
+	}
+	pods[2].Status.Phase = corev1.PodSucceeded
+
+	podGetter := &fakeGetter[*corev1.Pod]{items: map[string]*corev1.Pod{}}
+	refs := []log.ObjectRef{}
+	for _, pod := range pods {
+		podGetter.items[pod.Namespace+"/"+pod.Name] = pod
+		refs = append(refs, log.ObjectRef{Name: pod.Name, Namespace: pod.Namespace})
+	}
+	nodeGetter := &fakeGetter[*corev1.Node]{items: map[string]*corev1.Node{
+		"node0": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node0",
+			},
+		},
+	}}
+
+	svc, err := NewServer(Config{
+		ResourceUsages: []*internalversion.ResourceUsage{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod0",
+					Namespace: "default",
+				},
+				Spec: internalversion.ResourceUsageSpec{
+					Usages: []internalversion.ResourceUsageContainer{
+						{
+							Containers: []string{"sidecar"},
+							Usage: map[string]internalversion.ResourceUsageValue{
+								"cpu": {
+									Value: format.Ptr(resource.MustParse("10m")),
+								},
+							},
+						},
+						{
+							Usage: map[string]internalversion.ResourceUsageValue{
+								"cpu": {
+									Expression: `pod.metadata.name == "pod0" ? 0.5 : 0.0`,
+								},
+								"memory": {
+									Value: format.Ptr(resource.MustParse("64Mi")),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		ClusterResourceUsages: []*internalversion.ClusterResourceUsage{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: internalversion.ClusterResourceUsageSpec{
+					Usages: []internalversion.ResourceUsageContainer{
+						{
+							Usage: map[string]internalversion.ResourceUsageValue{
+								"cpu": {
+									Value: format.Ptr(resource.MustParse("100m")),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSource: &fakeDataSource{
+			pods: map[string][]log.ObjectRef{
+				"node0": refs,
+			},
+		},
+		NodeCacheGetter: nodeGetter,
+		PodCacheGetter:  podGetter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.InstallMetricsAPI()
+	return svc
+}
+
+func getMetricsAPI(t *testing.T, svc *Server, path string, obj any) int {
+	rec := httptest.NewRecorder()
+	svc.restfulCont.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if rec.Code == http.StatusOK && obj != nil {
+		err := json.Unmarshal(rec.Body.Bytes(), obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rec.Code
+}
+
+func TestMetricsAPI(t *testing.T) {
+	svc := newTestMetricsAPIServer(t)
+
+	var resources metav1.APIResourceList
+	if code := getMetricsAPI(t, svc, "/apis/metrics.k8s.io/v1beta1", &resources); code != http.StatusOK {
+		t.Fatalf("get resources: want 200, got %d", code)
+	}
+	if len(resources.APIResources) != 2 {
+		t.Errorf("want 2 resources, got %d", len(resources.APIResources))
+	}
+
+	var pod podMetrics
+	if code := getMetricsAPI(t, svc, "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods/pod0", &pod); code != http.StatusOK {
+		t.Fatalf("get pod: want 200, got %d", code)
+	}
+	want := map[string]corev1.ResourceList{
+		"app": {
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		"sidecar": {
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("0"),
+		},
+	}
+	if len(pod.Containers) != len(want) {
+		t.Fatalf("want %d containers, got %d", len(want), len(pod.Containers))
+	}
+	for _, container := range pod.Containers {
+		for name, quantity := range want[container.Name] {
+			got := container.Usage[name]
+			if got.Cmp(quantity) != 0 {
+				t.Errorf("container %s: want %s %s, got %s", container.Name, name, quantity.String(), got.String())
+			}
+		}
+	}
+
+	var pods podMetricsList
+	if code := getMetricsAPI(t, svc, "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods?labelSelector=app%3Dpod1", &pods); code != http.StatusOK {
+		t.Fatalf("list pods: want 200, got %d", code)
+	}
+	if len(pods.Items) != 1 || pods.Items[0].Name != "pod1" {
+		t.Fatalf("want pod1 selected, got %v", pods.Items)
+	}
+	if cpu := pods.Items[0].Containers[0].Usage[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("100m")) != 0 {
+		t.Errorf("pod1: want cpu 100m of the cluster resource usage, got %s", cpu.String())
+	}
+
+	if code := getMetricsAPI(t, svc, "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods/pod2", nil); code != http.StatusNotFound {
+		t.Errorf("get the succeeded pod: want 404, got %d", code)
+	}
+
+	var node nodeMetrics
+	if code := getMetricsAPI(t, svc, "/apis/metrics.k8s.io/v1beta1/nodes/node0", &node); code != http.StatusOK {
+		t.Fatalf("get node: want 200, got %d", code)
+	}
+	if cpu := node.Usage[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("610m")) != 0 {
+		t.Errorf("node0: want cpu 610m, got %s", cpu.String())
+	}
+}
+
+func TestMetricsAPIPagination(t *testing.T) {
+	svc := newTestMetricsAPIServer(t)
+
+	var first podMetricsList
+	if code := getMetricsAPI(t, svc, "/apis/metrics.k8s.io/v1beta1/pods?limit=1", &first); code != http.StatusOK {
+		t.Fatalf("list the first page: want 200, got %d", code)
+	}
+	if len(first.Items) != 1 || first.Items[0].Name != "pod0" || first.Continue == "" {
+		t.Fatalf("want pod0 and the continue, got %v and %q", first.Items, first.Continue)
+	}
+
+	var second podMetricsList
+	if code := getMetricsAPI(t, svc, "/apis/metrics.k8s.io/v1beta1/pods?limit=1&continue="+url.QueryEscape(first.Continue), &second); code != http.StatusOK {
+		t.Fatalf("list the second page: want 200, got %d", code)
+	}
+	if len(second.Items) != 1 || second.Items[0].Name != "pod1" || second.Continue != "" {
+		t.Fatalf("want pod1 and no continue, got %v and %q", second.Items, second.Continue)
+	}
+
+	if code := getMetricsAPI(t, svc, "/apis/metrics.k8s.io/v1beta1/nodes?limit=-1", nil); code != http.StatusBadRequest {
+		t.Errorf("list with an invalid limit: want 400, got %d", code)
+	}
+}
+
+func TestContainerResourceCumulativeUsage(t *testing.T) {
+	svc := newTestMetricsAPIServer(t)
+	pod, _ := svc.podCacheGetter.GetWithNamespace("pod1", "default")
+
+	first, err := svc.containerResourceCumulativeUsage("cpu", pod, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The pod has been running for 10 seconds at 0.1 cores.
+	if first < 0.9 || first > 1.1 {
+		t.Errorf("want about 1 cpu second, got %v", first)
+	}
+
+	second, err := svc.containerResourceCumulativeUsage("cpu", pod, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second < first {
+		t.Errorf("want the cumulative usage not decreasing, got %v after %v", second, first)
+	}
+}
+
+func TestResourceUsagePattern(t *testing.T) {
+	tests := []struct {
+		name  string
+		value internalversion.ResourceUsageValue
+		since time.Duration
+		want  float64
+		ok    bool
+	}{
+		{
+			name:  "no pattern",
+			value: internalversion.ResourceUsageValue{},
+			ok:    false,
+		},
+		{
+			name: "sinusoidal at quarter period",
+			value: internalversion.ResourceUsageValue{
+				Sinusoidal: &internalversion.ResourceUsageSinusoidal{
+					Base:               resource.MustParse("1"),
+					Amplitude:          resource.MustParse("500m"),
+					PeriodMilliseconds: 4000,
+				},
+			},
+			since: time.Second,
+			want:  1.5,
+			ok:    true,
+		},
+		{
+			name: "sinusoidal never below zero",
+			value: internalversion.ResourceUsageValue{
+				Sinusoidal: &internalversion.ResourceUsageSinusoidal{
+					Base:               resource.MustParse("1"),
+					Amplitude:          resource.MustParse("2"),
+					PeriodMilliseconds: 4000,
+					PhaseMilliseconds:  2000,
+				},
+			},
+			since: time.Second,
+			want:  0,
+			ok:    true,
+		},
+		{
+			name: "ramp halfway",
+			value: internalversion.ResourceUsageValue{
+				Ramp: &internalversion.ResourceUsageRamp{
+					From:                 resource.MustParse("100Mi"),
+					To:                   resource.MustParse("300Mi"),
+					DurationMilliseconds: 10000,
+				},
+			},
+			since: 5 * time.Second,
+			want:  200 * 1024 * 1024,
+			ok:    true,
+		},
+		{
+			name: "ramp ended",
+			value: internalversion.ResourceUsageValue{
+				Ramp: &internalversion.ResourceUsageRamp{
+					From:                 resource.MustParse("0"),
+					To:                   resource.MustParse("2"),
+					DurationMilliseconds: 10000,
+				},
+			},
+			since: time.Minute,
+			want:  2,
+			ok:    true,
+		},
+		{
+			name: "ramp repeated",
+			value: internalversion.ResourceUsageValue{
+				Ramp: &internalversion.ResourceUsageRamp{
+					From:                 resource.MustParse("0"),
+					To:                   resource.MustParse("2"),
+					DurationMilliseconds: 10000,
+					Repeat:               true,
+				},
+			},
+			since: 25 * time.Second,
+			want:  1,
+			ok:    true,
+		},
+		{
+			name: "spike before the first",
+			value: internalversion.ResourceUsageValue{
+				Spike: &internalversion.ResourceUsageSpike{
+					Base:                 resource.MustParse("100m"),
+					Peak:                 resource.MustParse("2"),
+					AfterMilliseconds:    30000,
+					DurationMilliseconds: 5000,
+					IntervalMilliseconds: 60000,
+				},
+			},
+			since: 10 * time.Second,
+			want:  0.1,
+			ok:    true,
+		},
+		{
+			name: "spike repeated",
+			value: internalversion.ResourceUsageValue{
+				Spike: &internalversion.ResourceUsageSpike{
+					Base:                 resource.MustParse("100m"),
+					Peak:                 resource.MustParse("2"),
+					AfterMilliseconds:    30000,
+					DurationMilliseconds: 5000,
+					IntervalMilliseconds: 60000,
+				},
+			},
+			since: 92 * time.Second,
+			want:  2,
+			ok:    true,
+		},
+		{
+			name: "spike only once",
+			value: internalversion.ResourceUsageValue{
+				Spike: &internalversion.ResourceUsageSpike{
+					Base:                 resource.MustParse("100m"),
+					Peak:                 resource.MustParse("2"),
+					DurationMilliseconds: 5000,
+				},
+			},
+			since: 62 * time.Second,
+			want:  0.1,
+			ok:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := resourceUsagePattern(tt.value, tt.since)
+			if ok != tt.ok {
+				t.Fatalf("resourceUsagePattern() ok = %v, want %v", ok, tt.ok)
+			}
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("resourceUsagePattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/kustomize/metrics/cadvisor"
+	metricsdcgm "sigs.k8s.io/kwok/kustomize/metrics/dcgm"
+	metricsenergy "sigs.k8s.io/kwok/kustomize/metrics/energy"
+	metricsresource "sigs.k8s.io/kwok/kustomize/metrics/resource"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func TestInstallMetricsKubelet(t *testing.T) {
+	var metrics []*internalversion.Metric
+	for _, raw := range []string{cadvisor.DefaultMetricsCadvisor, metricsresource.DefaultMetricsResource, metricsenergy.DefaultMetricsEnergy} {
+		m, err := config.UnmarshalWithType[*internalversion.Metric](raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		metrics = append(metrics, m)
+	}
+
+	pod := newTestPod("pod0", "app")
+	pod.Spec.Containers[0].Image = "busybox"
+	usage := func(value string) internalversion.ResourceUsageValue {
+		return internalversion.ResourceUsageValue{
+			Value: format.Ptr(resource.MustParse(value)),
+		}
+	}
+
+	svc, err := NewServer(Config{
+		Metrics: metrics,
+		ClusterResourceUsages: []*internalversion.ClusterResourceUsage{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: internalversion.ClusterResourceUsageSpec{
+					Usages: []internalversion.ResourceUsageContainer{
+						{
+							Usage: map[string]internalversion.ResourceUsageValue{
+								"cpu":               usage("500m"),
+								"memory":            usage("64Mi"),
+								"ephemeral-storage": usage("1Gi"),
+								"fs-reads":          usage("1Ki"),
+								"fs-writes":         usage("2Ki"),
+								"network-receive":   usage("4Ki"),
+								"network-transmit":  usage("8Ki"),
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSource: &fakeDataSource{
+			pods: map[string][]log.ObjectRef{
+				"node0": {
+					{Name: pod.Name, Namespace: pod.Namespace},
+				},
+			},
+		},
+		NodeCacheGetter: &fakeGetter[*corev1.Node]{items: map[string]*corev1.Node{
+			"node0": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node0",
+					Annotations: map[string]string{
+						"power.kwok.x-k8s.io/idle-watts": "50",
+					},
+				},
+			},
+		}},
+		PodCacheGetter: &fakeGetter[*corev1.Pod]{items: map[string]*corev1.Pod{
+			pod.Namespace + "/" + pod.Name: pod,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = svc.InstallMetrics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{
+			path: "/metrics/nodes/node0/metrics/cadvisor",
+			want: []string{
+				`container_cpu_usage_seconds_total{container="app",id="/kubepods/poduid-pod0/app",image="busybox",namespace="default",pod="pod0"}`,
+				`container_cpu_usage_seconds_total{container="",id="/",image="",namespace="",pod=""}`,
+				`container_memory_working_set_bytes{container="app",id="/kubepods/poduid-pod0/app",image="busybox",namespace="default",pod="pod0"} 6.7108864e+07`,
+				`container_fs_usage_bytes{container="app",id="/kubepods/poduid-pod0/app",image="busybox",namespace="default",pod="pod0"} 1.073741824e+09`,
+				`container_fs_reads_bytes_total{container="app"`,
+				`container_network_receive_bytes_total{container="",id="/kubepods/poduid-pod0",image="",interface="eth0",namespace="default",pod="pod0"}`,
+				`container_network_transmit_bytes_total{container="",id="/kubepods/poduid-pod0",image="",interface="eth0",namespace="default",pod="pod0"}`,
+				`container_network_receive_bytes_total{container="",id="/",image="",interface="eth0",namespace="",pod=""}`,
+				`container_network_transmit_bytes_total{container="",id="/",image="",interface="eth0",namespace="",pod=""}`,
+				`container_start_time_seconds{container="app"`,
+			},
+		},
+		{
+			path: "/metrics/nodes/node0/metrics/resource",
+			want: []string{
+				`container_cpu_usage_seconds_total{container="app",namespace="default",pod="pod0"}`,
+				`container_memory_working_set_bytes{container="app",namespace="default",pod="pod0"} 6.7108864e+07`,
+				`node_cpu_usage_seconds_total `,
+				`node_memory_working_set_bytes 6.7108864e+07`,
+				`pod_cpu_usage_seconds_total{namespace="default",pod="pod0"}`,
+				`pod_memory_working_set_bytes{namespace="default",pod="pod0"} 6.7108864e+07`,
+				`scrape_error 0`,
+			},
+		},
+		{
+			path: "/metrics/nodes/node0/metrics/energy",
+			want: []string{
+				`kepler_container_joules_total{container_id="/kubepods/poduid-pod0/app",container_name="app",container_namespace="default",mode="dynamic",pod_name="pod0"}`,
+				`kepler_node_platform_joules_total{mode="idle",source="kwok"}`,
+				`kepler_node_platform_joules_total{mode="dynamic",source="kwok"}`,
+				`node_power_watts 55`,
+				`node_carbon_intensity_grams_per_kwh 400`,
+				`node_carbon_emissions_grams_total `,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			svc.restfulCont.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("want 200, got %d", rec.Code)
+			}
+			body := rec.Body.String()
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("want %q in:\n%s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestInstallMetricsDCGM(t *testing.T) {
+	m, err := config.UnmarshalWithType[*internalversion.Metric](metricsdcgm.DefaultMetricsDCGM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod := newTestPod("pod0", "app")
+	pod.Spec.Containers[0].Resources.Limits = corev1.ResourceList{
+		"nvidia.com/gpu": resource.MustParse("1"),
+	}
+
+	svc, err := NewServer(Config{
+		Metrics: []*internalversion.Metric{m},
+		ClusterResourceUsages: []*internalversion.ClusterResourceUsage{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: internalversion.ClusterResourceUsageSpec{
+					Usages: []internalversion.ResourceUsageContainer{
+						{
+							Usage: map[string]internalversion.ResourceUsageValue{
+								"nvidia.com/gpu": {
+									Value: format.Ptr(resource.MustParse("800m")),
+								},
+								"gpu-memory": {
+									Value: format.Ptr(resource.MustParse("1Gi")),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSource: &fakeDataSource{
+			pods: map[string][]log.ObjectRef{
+				"node0": {
+					{Name: pod.Name, Namespace: pod.Namespace},
+				},
+			},
+		},
+		NodeCacheGetter: &fakeGetter[*corev1.Node]{items: map[string]*corev1.Node{
+			"node0": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node0",
+					Annotations: map[string]string{
+						"gpu.kwok.x-k8s.io/model": "Tesla T4",
+					},
+				},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("2"),
+					},
+				},
+			},
+		}},
+		PodCacheGetter: &fakeGetter[*corev1.Pod]{items: map[string]*corev1.Pod{
+			pod.Namespace + "/" + pod.Name: pod,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = svc.InstallMetrics(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	svc.restfulCont.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics/nodes/node0/metrics/dcgm", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`DCGM_FI_DEV_GPU_UTIL{Hostname="node0",UUID="GPU-`,
+		`container="app",device="nvidia0",gpu="0",modelName="Tesla T4",namespace="default",pod="pod0"} 80`,
+		`container="",device="nvidia1",gpu="1",modelName="Tesla T4",namespace="",pod=""} 0`,
+		`container="app",device="nvidia0",gpu="0",modelName="Tesla T4",namespace="default",pod="pod0"} 1024`,
+		`container="app",device="nvidia0",gpu="0",modelName="Tesla T4",namespace="default",pod="pod0"} 39936`,
+		`container="app",device="nvidia0",gpu="0",modelName="Tesla T4",namespace="default",pod="pod0"} 71`,
+		`container="",device="nvidia1",gpu="1",modelName="Tesla T4",namespace="",pod=""} 60`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("want %q in:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwok/metrics/cel"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// cumulativeUsagePruneInterval is the interval of pruning the cumulative usage of the containers gone.
+const cumulativeUsagePruneInterval = 10 * time.Minute
+
+// cumulativeUsage is the usage of a resource of a container accumulated over time.
+type cumulativeUsage struct {
+	value     float64
+	total     float64
+	updatedAt time.Time
+}
+
+// podResourceUsages returns the usages of the pod,
+// the ResourceUsage of the pod takes precedence over the ClusterResourceUsage matching the pod,
+// and the ClusterResourceUsage with the most specific selector takes precedence over the others.
+func (s *Server) podResourceUsages(pod *corev1.Pod) []internalversion.ResourceUsageContainer {
+	for _, ru := range s.resourceUsages.Get() {
+		if ru.Name == pod.Name && ru.Namespace == pod.Namespace {
+			return ru.Spec.Usages
+		}
+	}
+	clusterResourceUsages := matchClusterResources(s.clusterResourceUsages.Get(), func(cru *internalversion.ClusterResourceUsage) *internalversion.ObjectSelector {
+		return cru.Spec.Selector
+	}, pod.Name, pod.Namespace, pod.Labels, s.namespaceLabels(pod.Namespace))
+	if len(clusterResourceUsages) != 0 {
+		return clusterResourceUsages[0].Spec.Usages
+	}
+	return nil
+}
+
+// containerResourceUsageValue returns the value of the usage of the resource of the container.
+func (s *Server) containerResourceUsageValue(resourceName string, pod *corev1.Pod, containerName string) (internalversion.ResourceUsageValue, bool) {
+	for _, usage := range s.podResourceUsages(pod) {
+		if len(usage.Containers) != 0 && !slices.Contains(usage.Containers, containerName) {
+			continue
+		}
+		value, ok := usage.Usage[resourceName]
+		return value, ok
+	}
+	return internalversion.ResourceUsageValue{}, false
+}
+
+// containerResourceUsage returns the usage of the resource of the container,
+// the usage of the cpu is in cores and the others are in their units, e.g. bytes for memory.
+// It is zero if the pod is not running.
+// The usage is cached until the pod changes or the ttl of the cache expires.
+func (s *Server) containerResourceUsage(resourceName string, pod *corev1.Pod, containerName string) (float64, error) {
+	if pod.Status.Phase != corev1.PodRunning {
+		return 0, nil
+	}
+
+	key := string(pod.UID) + "/" + containerName + "/" + resourceName
+	return s.usageCache.Get(key, pod.ResourceVersion, func() (float64, error) {
+		return s.evaluateContainerResourceUsage(resourceName, pod, containerName)
+	})
+}
+
+// evaluateContainerResourceUsage evaluates the usage of the resource of the running container.
+func (s *Server) evaluateContainerResourceUsage(resourceName string, pod *corev1.Pod, containerName string) (float64, error) {
+	value, ok := s.containerResourceUsageValue(resourceName, pod, containerName)
+	if !ok {
+		return 0, nil
+	}
+	if value.Value != nil {
+		return value.Value.AsApproximateFloat64(), nil
+	}
+	if usage, ok := resourceUsagePattern(value, podRunningDuration(pod)); ok {
+		return usage, nil
+	}
+	if value.Expression == "" {
+		return 0, nil
+	}
+
+	evaluator, err := s.resourceUsageEnvironment.Compile(value.Expression)
+	if err != nil {
+		return 0, err
+	}
+
+	data := cel.Data{
+		Pod: pod,
+	}
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == containerName {
+			data.Container = &pod.Spec.Containers[i]
+			break
+		}
+	}
+	if data.Container == nil {
+		return 0, fmt.Errorf("container %q not found in pod %s/%s", containerName, pod.Namespace, pod.Name)
+	}
+	if s.nodeCacheGetter != nil {
+		node, ok := s.nodeCacheGetter.Get(pod.Spec.NodeName)
+		if ok {
+			data.Node = node
+		}
+	}
+	if data.Node == nil {
+		data.Node = &corev1.Node{}
+	}
+
+	return evaluator.EvaluateFloat64(data)
+}
+
+// podRunningDuration returns the time since the pod started.
+func podRunningDuration(pod *corev1.Pod) time.Duration {
+	startedAt := pod.CreationTimestamp.Time
+	if pod.Status.StartTime != nil {
+		startedAt = pod.Status.StartTime.Time
+	}
+	return time.Since(startedAt)
+}
+
+// resourceUsagePattern returns the usage of the time-based pattern of the value at the time since the pod started,
+// it returns false if the value has no pattern.
+func resourceUsagePattern(value internalversion.ResourceUsageValue, since time.Duration) (float64, bool) {
+	t := float64(since.Milliseconds())
+	switch {
+	case value.Sinusoidal != nil:
+		p := value.Sinusoidal
+		if p.PeriodMilliseconds <= 0 {
+			return p.Base.AsApproximateFloat64(), true
+		}
+		usage := p.Base.AsApproximateFloat64() +
+			p.Amplitude.AsApproximateFloat64()*math.Sin(2*math.Pi*(t+float64(p.PhaseMilliseconds))/float64(p.PeriodMilliseconds))
+		return math.Max(usage, 0), true
+	case value.Ramp != nil:
+		p := value.Ramp
+		from, to := p.From.AsApproximateFloat64(), p.To.AsApproximateFloat64()
+		if p.DurationMilliseconds <= 0 {
+			return to, true
+		}
+		if p.Repeat {
+			t = math.Mod(t, float64(p.DurationMilliseconds))
+		} else if t >= float64(p.DurationMilliseconds) {
+			return to, true
+		}
+		return from + (to-from)*t/float64(p.DurationMilliseconds), true
+	case value.Spike != nil:
+		p := value.Spike
+		t -= float64(p.AfterMilliseconds)
+		if t >= 0 && p.IntervalMilliseconds > 0 {
+			t = math.Mod(t, float64(p.IntervalMilliseconds))
+		}
+		if t >= 0 && t < float64(p.DurationMilliseconds) {
+			return p.Peak.AsApproximateFloat64(), true
+		}
+		return p.Base.AsApproximateFloat64(), true
+	}
+	return 0, false
+}
+
+// containerResourceCumulativeUsage returns the usage of the resource of the container accumulated since the pod started,
+// e.g. the cpu time in seconds.
+func (s *Server) containerResourceCumulativeUsage(resourceName string, pod *corev1.Pod, containerName string) (float64, error) {
+	value, err := s.containerResourceUsage(resourceName, pod, containerName)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	key := string(pod.UID) + "/" + containerName + "/" + resourceName
+
+	s.cumulativeUsagesMut.Lock()
+	defer s.cumulativeUsagesMut.Unlock()
+
+	if now.Sub(s.cumulativeUsagesPrunedAt) > cumulativeUsagePruneInterval {
+		for k, u := range s.cumulativeUsages {
+			if now.Sub(u.updatedAt) > cumulativeUsagePruneInterval {
+				delete(s.cumulativeUsages, k)
+			}
+		}
+		s.cumulativeUsagesPrunedAt = now
+	}
+
+	u, ok := s.cumulativeUsages[key]
+	if !ok {
+		startedAt := now
+		if pod.Status.StartTime != nil {
+			startedAt = pod.Status.StartTime.Time
+		}
+		u = &cumulativeUsage{
+			value:     value,
+			updatedAt: startedAt,
+		}
+		s.cumulativeUsages[key] = u
+	}
+	if elapsed := now.Sub(u.updatedAt); elapsed > 0 {
+		u.total += u.value * elapsed.Seconds()
+	}
+	u.value = value
+	u.updatedAt = now
+	return u.total, nil
+}
+
+// podsOnNode returns the pods on the node.
+func (s *Server) podsOnNode(nodeName string) []*corev1.Pod {
+	if s.podCacheGetter == nil {
+		return nil
+	}
+	refs, ok := s.dataSource.ListPods(nodeName)
+	if !ok {
+		return nil
+	}
+	pods := make([]*corev1.Pod, 0, len(refs))
+	for _, ref := range refs {
+		pod, ok := s.podCacheGetter.GetWithNamespace(ref.Name, ref.Namespace)
+		if !ok {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// podResourceUsage returns the sum of the usage of the resource of the containers of the pod.
+func podResourceUsage(resourceName string, pod *corev1.Pod, containerUsage func(resourceName string, pod *corev1.Pod, containerName string) (float64, error)) (float64, error) {
+	var sum float64
+	for _, container := range pod.Spec.Containers {
+		usage, err := containerUsage(resourceName, pod, container.Name)
+		if err != nil {
+			return 0, err
+		}
+		sum += usage
+	}
+	return sum, nil
+}
+
+// nodeResourceUsage returns the sum of the usage of the resource of the containers of the pods on the node.
+func (s *Server) nodeResourceUsage(resourceName, nodeName string, containerUsage func(resourceName string, pod *corev1.Pod, containerName string) (float64, error)) (float64, error) {
+	var sum float64
+	for _, pod := range s.podsOnNode(nodeName) {
+		usage, err := podResourceUsage(resourceName, pod, containerUsage)
+		if err != nil {
+			return 0, err
+		}
+		sum += usage
+	}
+	return sum, nil
+}
+
+// resourceUsageEvaluatorConfig fills the functions of the usage of the resources and the seed into the config of the CEL environment,
+// the errors are evaluated to zero.
+func (s *Server) resourceUsageEvaluatorConfig(conf cel.NodeEvaluatorConfig) cel.NodeEvaluatorConfig {
+	conf.RandSeed = s.randSeed
+	ignoreError := func(f func(resourceName string, pod *corev1.Pod, containerName string) (float64, error)) func(resourceName string, pod *corev1.Pod, containerName string) float64 {
+		return func(resourceName string, pod *corev1.Pod, containerName string) float64 {
+			usage, _ := f(resourceName, pod, containerName)
+			return usage
+		}
+	}
+	conf.ContainerResourceUsage = ignoreError(s.containerResourceUsage)
+	conf.ContainerResourceCumulativeUsage = ignoreError(s.containerResourceCumulativeUsage)
+	conf.NodeResourceUsage = func(resourceName, nodeName string) float64 {
+		usage, _ := s.nodeResourceUsage(resourceName, nodeName, s.containerResourceUsage)
+		return usage
+	}
+	conf.NodeResourceCumulativeUsage = func(resourceName, nodeName string) float64 {
+		usage, _ := s.nodeResourceUsage(resourceName, nodeName, s.containerResourceCumulativeUsage)
+		return usage
+	}
+	return conf
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/emicklei/go-restful/v3"
@@ -28,13 +29,17 @@ import (
 	"github.com/wzshiming/cmux/pattern"
 	corev1 "k8s.io/api/core/v1"
 	remotecommandconsts "k8s.io/apimachinery/pkg/util/remotecommand"
+	statsv1alpha1 "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kwok/pkg/client/clientset/versioned"
 	"sigs.k8s.io/kwok/pkg/config/resources"
+	"sigs.k8s.io/kwok/pkg/kwok/controllers"
 	"sigs.k8s.io/kwok/pkg/kwok/metrics"
+	"sigs.k8s.io/kwok/pkg/kwok/metrics/cel"
 	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
 	"sigs.k8s.io/kwok/pkg/utils/informer"
 	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/pools"
@@ -59,19 +64,38 @@ type Server struct {
 
 	clusterPortForwards resources.Getter[[]*internalversion.ClusterPortForward]
 	portForwards        resources.Getter[[]*internalversion.PortForward]
+	forwardRenderer     gotpl.Renderer
 	clusterExecs        resources.Getter[[]*internalversion.ClusterExec]
 	execs               resources.Getter[[]*internalversion.Exec]
 	clusterLogs         resources.Getter[[]*internalversion.ClusterLogs]
 	logs                resources.Getter[[]*internalversion.Logs]
+	logsRenderer        gotpl.Renderer
 	clusterAttaches     resources.Getter[[]*internalversion.ClusterAttach]
 	attaches            resources.Getter[[]*internalversion.Attach]
 	metrics             resources.Getter[[]*internalversion.Metric]
 
-	metricsUpdateHandler maps.SyncMap[string, *metrics.UpdateHandler]
+	resourceUsages        resources.Getter[[]*internalversion.ResourceUsage]
+	clusterResourceUsages resources.Getter[[]*internalversion.ClusterResourceUsage]
+
+	metricsUpdateHandler  maps.SyncMap[string, *metrics.UpdateHandler]
+	metricsMaxSeries      int
+	metricsMaxLabelValues int
+	metricsScrapeTimeout  time.Duration
+	randSeed              int64
+
+	usageCache    *versionedCache[float64]
+	podStatsCache *versionedCache[*statsv1alpha1.PodStats]
+
+	resourceUsageEnvironment *cel.Environment
+	cumulativeUsages         map[string]*cumulativeUsage
+	cumulativeUsagesPrunedAt time.Time
+	cumulativeUsagesMut      sync.Mutex
 
 	dataSource      DataSource
 	nodeCacheGetter informer.Getter[*corev1.Node]
 	podCacheGetter  informer.Getter[*corev1.Pod]
+
+	namespaceCacheGetter informer.Getter[*corev1.Namespace]
 }
 
 // DataSource is the interface that provides data for the server handlers.
@@ -79,6 +103,9 @@ type DataSource interface {
 	metrics.DataSource
 	ListNodes() []string
 	StartedContainersTotal(nodeName string) int64
+	AllocatedResource(nodeName, resourceName string) float64
+	StageStatistics() []controllers.StageStatistic
+	ClusterStatistics() controllers.ClusterStatistics
 }
 
 // Config holds configurations needed by the server handlers.
@@ -96,9 +123,20 @@ type Config struct {
 	Attaches            []*internalversion.Attach
 	Metrics             []*internalversion.Metric
 
+	ResourceUsages        []*internalversion.ResourceUsage
+	ClusterResourceUsages []*internalversion.ClusterResourceUsage
+
 	DataSource      DataSource
 	NodeCacheGetter informer.Getter[*corev1.Node]
 	PodCacheGetter  informer.Getter[*corev1.Pod]
+
+	NamespaceCacheGetter informer.Getter[*corev1.Namespace]
+
+	MetricsMaxSeries      int
+	MetricsMaxLabelValues int
+	MetricsScrapeTimeout  time.Duration
+	RandSeed              int64
+	StatsCacheTTL         time.Duration
 }
 
 // NewServer creates a new Server.
@@ -114,23 +152,51 @@ func NewServer(conf Config) (*Server, error) {
 
 		clusterPortForwards: resources.NewStaticGetter(conf.ClusterPortForwards),
 		portForwards:        resources.NewStaticGetter(conf.PortForwards),
+		forwardRenderer:     gotpl.NewRenderer(gotpl.GenericFuncMap()),
 		clusterExecs:        resources.NewStaticGetter(conf.ClusterExecs),
 		execs:               resources.NewStaticGetter(conf.Execs),
 		clusterLogs:         resources.NewStaticGetter(conf.ClusterLogs),
 		logs:                resources.NewStaticGetter(conf.Logs),
+		logsRenderer:        gotpl.NewRenderer(logGeneratorFuncMap()),
 		clusterAttaches:     resources.NewStaticGetter(conf.ClusterAttaches),
 		attaches:            resources.NewStaticGetter(conf.Attaches),
 		metrics:             resources.NewStaticGetter(conf.Metrics),
 
+		resourceUsages:        resources.NewStaticGetter(conf.ResourceUsages),
+		clusterResourceUsages: resources.NewStaticGetter(conf.ClusterResourceUsages),
+		cumulativeUsages:      map[string]*cumulativeUsage{},
+
+		metricsMaxSeries:      conf.MetricsMaxSeries,
+		metricsMaxLabelValues: conf.MetricsMaxLabelValues,
+		metricsScrapeTimeout:  conf.MetricsScrapeTimeout,
+		randSeed:              conf.RandSeed,
+
+		usageCache:    newVersionedCache[float64](conf.StatsCacheTTL),
+		podStatsCache: newVersionedCache[*statsv1alpha1.PodStats](conf.StatsCacheTTL),
+
 		dataSource:      conf.DataSource,
 		podCacheGetter:  conf.PodCacheGetter,
 		nodeCacheGetter: conf.NodeCacheGetter,
 
+		namespaceCacheGetter: conf.NamespaceCacheGetter,
+
 		bufPool: pools.NewPool(func() []byte {
 			return make([]byte, 32*1024)
 		}),
 	}
 
+	// The usages of the resources are not evaluated in their own expressions.
+	env, err := cel.NewEnvironment(cel.NodeEvaluatorConfig{
+		EnableEvaluatorCache:   true,
+		StartedContainersTotal: conf.DataSource.StartedContainersTotal,
+		AllocatedResource:      conf.DataSource.AllocatedResource,
+		RandSeed:               conf.RandSeed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	s.resourceUsageEnvironment = env
+
 	return s, nil
 }
 
@@ -350,6 +416,52 @@ func (s *Server) initWatchCRD(ctx context.Context) ([]resources.Starter, error)
 			)
 			starters = append(starters, metrics)
 			s.metrics = metrics
+		case v1alpha1.ResourceUsageKind:
+			if len(s.resourceUsages.Get()) != 0 {
+				return nil, fmt.Errorf("resource usages already exists, cannot watch CRD")
+			}
+			resourceUsages := resources.NewDynamicGetter[
+				[]*internalversion.ResourceUsage,
+				*v1alpha1.ResourceUsage,
+				*v1alpha1.ResourceUsageList,
+			](
+				cli.KwokV1alpha1().ResourceUsages(""),
+				func(objs []*v1alpha1.ResourceUsage) []*internalversion.ResourceUsage {
+					return slices.FilterAndMap(objs, func(obj *v1alpha1.ResourceUsage) (*internalversion.ResourceUsage, bool) {
+						r, err := internalversion.ConvertToInternalResourceUsage(obj)
+						if err != nil {
+							logger.Error("failed to convert to internal resource usage", err, "obj", obj)
+							return nil, false
+						}
+						return r, true
+					})
+				},
+			)
+			starters = append(starters, resourceUsages)
+			s.resourceUsages = resourceUsages
+		case v1alpha1.ClusterResourceUsageKind:
+			if len(s.clusterResourceUsages.Get()) != 0 {
+				return nil, fmt.Errorf("cluster resource usages already exists, cannot watch CRD")
+			}
+			clusterResourceUsages := resources.NewDynamicGetter[
+				[]*internalversion.ClusterResourceUsage,
+				*v1alpha1.ClusterResourceUsage,
+				*v1alpha1.ClusterResourceUsageList,
+			](
+				cli.KwokV1alpha1().ClusterResourceUsages(),
+				func(objs []*v1alpha1.ClusterResourceUsage) []*internalversion.ClusterResourceUsage {
+					return slices.FilterAndMap(objs, func(obj *v1alpha1.ClusterResourceUsage) (*internalversion.ClusterResourceUsage, bool) {
+						r, err := internalversion.ConvertToInternalClusterResourceUsage(obj)
+						if err != nil {
+							logger.Error("failed to convert to internal cluster resource usage", err, "obj", obj)
+							return nil, false
+						}
+						return r, true
+					})
+				},
+			)
+			starters = append(starters, clusterResourceUsages)
+			s.clusterResourceUsages = clusterResourceUsages
 		}
 	}
 	return starters, nil
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// versionedCacheEntry is a value computed from a version of an object.
+type versionedCacheEntry[T any] struct {
+	version   string
+	value     T
+	expiresAt time.Time
+}
+
+// versionedCache caches the values computed from the objects for the ttl,
+// a value is recomputed once it expires or the version of its object changes,
+// so only the changed objects are recomputed when the others are asked again within the ttl.
+type versionedCache[T any] struct {
+	ttl      time.Duration
+	now      func() time.Time
+	entries  map[string]versionedCacheEntry[T]
+	prunedAt time.Time
+	mut      sync.Mutex
+}
+
+// newVersionedCache returns a versionedCache, or nil which computes the values every time if the ttl is not positive.
+func newVersionedCache[T any](ttl time.Duration) *versionedCache[T] {
+	if ttl <= 0 {
+		return nil
+	}
+	return &versionedCache[T]{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]versionedCacheEntry[T]{},
+	}
+}
+
+// Get returns the value of the key at the version, computing it if it is not cached,
+// the compute is called without holding the lock, so it is allowed to get the other keys.
+func (c *versionedCache[T]) Get(key, version string, compute func() (T, error)) (T, error) {
+	if c == nil {
+		return compute()
+	}
+
+	now := c.now()
+	c.mut.Lock()
+	entry, ok := c.entries[key]
+	c.mut.Unlock()
+	if ok && entry.version == version && now.Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := compute()
+	if err != nil {
+		return value, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if now.Sub(c.prunedAt) > c.ttl {
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.prunedAt = now
+	}
+	c.entries[key] = versionedCacheEntry[T]{
+		version:   version,
+		value:     value,
+		expiresAt: now.Add(c.ttl),
+	}
+	return value, nil
+}
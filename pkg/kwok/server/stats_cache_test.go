@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVersionedCache(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newVersionedCache[int](10 * time.Second)
+	c.now = func() time.Time {
+		return now
+	}
+
+	computed := 0
+	compute := func() (int, error) {
+		computed++
+		return computed, nil
+	}
+
+	steps := []struct {
+		name    string
+		key     string
+		version string
+		after   time.Duration
+		want    int
+	}{
+		{name: "first", key: "a", version: "1", want: 1},
+		{name: "cached", key: "a", version: "1", after: 5 * time.Second, want: 1},
+		{name: "other key", key: "b", version: "1", want: 2},
+		{name: "changed", key: "a", version: "2", want: 3},
+		{name: "changed cached", key: "a", version: "2", after: 5 * time.Second, want: 3},
+		{name: "expired", key: "a", version: "2", after: 5 * time.Second, want: 4},
+	}
+	for _, step := range steps {
+		now = now.Add(step.after)
+		got, err := c.Get(step.key, step.version, compute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != step.want {
+			t.Errorf("%s: want %d, got %d", step.name, step.want, got)
+		}
+	}
+
+	errCompute := errors.New("compute")
+	_, err := c.Get("c", "1", func() (int, error) {
+		return 0, errCompute
+	})
+	if !errors.Is(err, errCompute) {
+		t.Fatalf("want the error of the compute, got %v", err)
+	}
+	if got, _ := c.Get("c", "1", compute); got != 5 {
+		t.Errorf("want the error not cached, got %d", got)
+	}
+
+	var disabled *versionedCache[int]
+	if got, _ := disabled.Get("a", "1", compute); got != 6 {
+		t.Errorf("want computed without the cache, got %d", got)
+	}
+}
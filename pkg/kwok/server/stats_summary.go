@@ -0,0 +1,309 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/emicklei/go-restful/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	statsv1alpha1 "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+const (
+	// statsResourceEphemeralStorage is the usage of the filesystem in bytes.
+	statsResourceEphemeralStorage = string(corev1.ResourceEphemeralStorage)
+	// statsResourceNetworkReceive is the rate of the bytes received by the pod in bytes per second.
+	statsResourceNetworkReceive = "network-receive"
+	// statsResourceNetworkTransmit is the rate of the bytes transmitted by the pod in bytes per second.
+	statsResourceNetworkTransmit = "network-transmit"
+
+	// statsInterfaceName is the name of the network interface of the pods and the nodes.
+	statsInterfaceName = "eth0"
+)
+
+// InstallStatsSummary installs the handler of the summary API of the kubelet (/stats/summary) of the managed nodes,
+// serving the usages of the cpu, the memory, the filesystem and the network of the nodes and the pods on them.
+// The nodes share the server, so the summary of a node is served at /stats/nodes/{nodeName}/summary.
+func (s *Server) InstallStatsSummary() {
+	ws := new(restful.WebService)
+	ws.Path("/stats")
+	ws.Produces(restful.MIME_JSON)
+	ws.Route(ws.GET("/nodes/{nodeName}/summary").To(s.getStatsSummary))
+	s.restfulCont.Add(ws)
+}
+
+func (s *Server) getStatsSummary(req *restful.Request, resp *restful.Response) {
+	nodeName := req.PathParameter("nodeName")
+	node, ok := s.nodeCacheGetter.Get(nodeName)
+	if !ok || !slices.Contains(s.dataSource.ListNodes(), nodeName) {
+		_ = resp.WriteErrorString(http.StatusNotFound, "node \""+nodeName+"\" not found")
+		return
+	}
+
+	summary, err := s.statsSummary(node)
+	if err != nil {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	err = resp.WriteAsJson(summary)
+	if err != nil {
+		logger := log.FromContext(req.Request.Context())
+		logger.Error("Failed to write", err)
+	}
+}
+
+func (s *Server) statsSummary(node *corev1.Node) (*statsv1alpha1.Summary, error) {
+	now := metav1.Now()
+	nodeStats := statsv1alpha1.NodeStats{
+		NodeName:  node.Name,
+		StartTime: node.CreationTimestamp,
+	}
+	nodeUsage := func(resourceName string) (float64, error) {
+		return s.nodeResourceUsage(resourceName, node.Name, s.containerResourceUsage)
+	}
+	nodeCumulativeUsage := func(resourceName string) (float64, error) {
+		return s.nodeResourceUsage(resourceName, node.Name, s.containerResourceCumulativeUsage)
+	}
+
+	cpu, err := statsCPU(now, nodeUsage, nodeCumulativeUsage)
+	if err != nil {
+		return nil, err
+	}
+	nodeStats.CPU = cpu
+
+	memory, err := statsMemory(now, nodeUsage, node.Status.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	nodeStats.Memory = memory
+
+	network, err := statsNetwork(now, nodeCumulativeUsage)
+	if err != nil {
+		return nil, err
+	}
+	nodeStats.Network = network
+
+	fs, err := statsFs(now, nodeUsage, node.Status.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	nodeStats.Fs = fs
+
+	// The stats of the pods not changed are reused within the ttl of the cache.
+	pods := []statsv1alpha1.PodStats{}
+	for _, pod := range s.podsOnNode(node.Name) {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		podStats, err := s.podStatsCache.Get(string(pod.UID), pod.ResourceVersion, func() (*statsv1alpha1.PodStats, error) {
+			return s.statsPod(now, pod)
+		})
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, *podStats)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].PodRef.Namespace != pods[j].PodRef.Namespace {
+			return pods[i].PodRef.Namespace < pods[j].PodRef.Namespace
+		}
+		return pods[i].PodRef.Name < pods[j].PodRef.Name
+	})
+
+	return &statsv1alpha1.Summary{
+		Node: nodeStats,
+		Pods: pods,
+	}, nil
+}
+
+func (s *Server) statsPod(now metav1.Time, pod *corev1.Pod) (*statsv1alpha1.PodStats, error) {
+	startTime := pod.CreationTimestamp
+	if pod.Status.StartTime != nil {
+		startTime = *pod.Status.StartTime
+	}
+	podStats := &statsv1alpha1.PodStats{
+		PodRef: statsv1alpha1.PodReference{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			UID:       string(pod.UID),
+		},
+		StartTime:  startTime,
+		Containers: make([]statsv1alpha1.ContainerStats, 0, len(pod.Spec.Containers)),
+	}
+
+	for _, container := range pod.Spec.Containers {
+		containerName := container.Name
+		containerUsage := func(resourceName string) (float64, error) {
+			return s.containerResourceUsage(resourceName, pod, containerName)
+		}
+		containerCumulativeUsage := func(resourceName string) (float64, error) {
+			return s.containerResourceCumulativeUsage(resourceName, pod, containerName)
+		}
+		cpu, err := statsCPU(now, containerUsage, containerCumulativeUsage)
+		if err != nil {
+			return nil, err
+		}
+		memory, err := statsMemory(now, containerUsage, nil)
+		if err != nil {
+			return nil, err
+		}
+		rootfs, err := statsFs(now, containerUsage, nil)
+		if err != nil {
+			return nil, err
+		}
+		podStats.Containers = append(podStats.Containers, statsv1alpha1.ContainerStats{
+			Name:      containerName,
+			StartTime: startTime,
+			CPU:       cpu,
+			Memory:    memory,
+			Rootfs:    rootfs,
+		})
+	}
+
+	podUsage := func(resourceName string) (float64, error) {
+		return podResourceUsage(resourceName, pod, s.containerResourceUsage)
+	}
+	podCumulativeUsage := func(resourceName string) (float64, error) {
+		return podResourceUsage(resourceName, pod, s.containerResourceCumulativeUsage)
+	}
+
+	cpu, err := statsCPU(now, podUsage, podCumulativeUsage)
+	if err != nil {
+		return nil, err
+	}
+	podStats.CPU = cpu
+
+	memory, err := statsMemory(now, podUsage, nil)
+	if err != nil {
+		return nil, err
+	}
+	podStats.Memory = memory
+
+	network, err := statsNetwork(now, podCumulativeUsage)
+	if err != nil {
+		return nil, err
+	}
+	podStats.Network = network
+
+	ephemeralStorage, err := statsFs(now, podUsage, nil)
+	if err != nil {
+		return nil, err
+	}
+	podStats.EphemeralStorage = ephemeralStorage
+
+	return podStats, nil
+}
+
+// statsCPU returns the stats of the cpu, the usage is in cores and the cumulative usage is in core-seconds.
+func statsCPU(now metav1.Time, usage, cumulativeUsage func(resourceName string) (float64, error)) (*statsv1alpha1.CPUStats, error) {
+	cores, err := usage(string(corev1.ResourceCPU))
+	if err != nil {
+		return nil, err
+	}
+	seconds, err := cumulativeUsage(string(corev1.ResourceCPU))
+	if err != nil {
+		return nil, err
+	}
+	return &statsv1alpha1.CPUStats{
+		Time:                 now,
+		UsageNanoCores:       statsUint64(cores * 1e9),
+		UsageCoreNanoSeconds: statsUint64(seconds * 1e9),
+	}, nil
+}
+
+// statsMemory returns the stats of the memory, the available bytes are only given with the capacity.
+func statsMemory(now metav1.Time, usage func(resourceName string) (float64, error), capacity corev1.ResourceList) (*statsv1alpha1.MemoryStats, error) {
+	bytes, err := usage(string(corev1.ResourceMemory))
+	if err != nil {
+		return nil, err
+	}
+	return &statsv1alpha1.MemoryStats{
+		Time:            now,
+		AvailableBytes:  statsAvailable(capacity, corev1.ResourceMemory, bytes),
+		UsageBytes:      statsUint64(bytes),
+		WorkingSetBytes: statsUint64(bytes),
+		RSSBytes:        statsUint64(bytes),
+	}, nil
+}
+
+// statsFs returns the stats of the filesystem from the usage of the ephemeral-storage,
+// the capacity and the available bytes are only given with the capacity.
+func statsFs(now metav1.Time, usage func(resourceName string) (float64, error), capacity corev1.ResourceList) (*statsv1alpha1.FsStats, error) {
+	bytes, err := usage(statsResourceEphemeralStorage)
+	if err != nil {
+		return nil, err
+	}
+	fs := &statsv1alpha1.FsStats{
+		Time:           now,
+		AvailableBytes: statsAvailable(capacity, corev1.ResourceEphemeralStorage, bytes),
+		UsedBytes:      statsUint64(bytes),
+	}
+	if q, ok := capacity[corev1.ResourceEphemeralStorage]; ok {
+		fs.CapacityBytes = statsUint64(q.AsApproximateFloat64())
+	}
+	return fs, nil
+}
+
+// statsNetwork returns the stats of the network from the rates of the network-receive and network-transmit
+// accumulated over time.
+func statsNetwork(now metav1.Time, cumulativeUsage func(resourceName string) (float64, error)) (*statsv1alpha1.NetworkStats, error) {
+	rx, err := cumulativeUsage(statsResourceNetworkReceive)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := cumulativeUsage(statsResourceNetworkTransmit)
+	if err != nil {
+		return nil, err
+	}
+	iface := statsv1alpha1.InterfaceStats{
+		Name:     statsInterfaceName,
+		RxBytes:  statsUint64(rx),
+		RxErrors: statsUint64(0),
+		TxBytes:  statsUint64(tx),
+		TxErrors: statsUint64(0),
+	}
+	return &statsv1alpha1.NetworkStats{
+		Time:           now,
+		InterfaceStats: iface,
+		Interfaces:     []statsv1alpha1.InterfaceStats{iface},
+	}, nil
+}
+
+// statsAvailable returns the capacity of the resource minus the used, or nil if there is no capacity.
+func statsAvailable(capacity corev1.ResourceList, resourceName corev1.ResourceName, used float64) *uint64 {
+	q, ok := capacity[resourceName]
+	if !ok {
+		return nil
+	}
+	return statsUint64(q.AsApproximateFloat64() - used)
+}
+
+// statsUint64 returns the value as a pointer of uint64, the negative is zero.
+func statsUint64(value float64) *uint64 {
+	if value < 0 {
+		value = 0
+	}
+	v := uint64(value)
+	return &v
+}
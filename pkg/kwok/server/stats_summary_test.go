@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	statsv1alpha1 "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func TestStatsSummary(t *testing.T) {
+	pods := []*corev1.Pod{
+		newTestPod("pod0", "app", "sidecar"),
+		newTestPod("pod1", "app"),
+	}
+	pods[1].Status.Phase = corev1.PodPending
+
+	podGetter := &fakeGetter[*corev1.Pod]{items: map[string]*corev1.Pod{}}
+	refs := []log.ObjectRef{}
+	for _, pod := range pods {
+		podGetter.items[pod.Namespace+"/"+pod.Name] = pod
+		refs = append(refs, log.ObjectRef{Name: pod.Name, Namespace: pod.Namespace})
+	}
+	usage := func(value string) internalversion.ResourceUsageValue {
+		return internalversion.ResourceUsageValue{
+			Value: format.Ptr(resource.MustParse(value)),
+		}
+	}
+
+	svc, err := NewServer(Config{
+		ClusterResourceUsages: []*internalversion.ClusterResourceUsage{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: internalversion.ClusterResourceUsageSpec{
+					Usages: []internalversion.ResourceUsageContainer{
+						{
+							Usage: map[string]internalversion.ResourceUsageValue{
+								"cpu":               usage("250m"),
+								"memory":            usage("64Mi"),
+								"ephemeral-storage": usage("1Gi"),
+								"network-receive":   usage("4Ki"),
+								"network-transmit":  usage("8Ki"),
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSource: &fakeDataSource{
+			pods: map[string][]log.ObjectRef{
+				"node0": refs,
+			},
+		},
+		NodeCacheGetter: &fakeGetter[*corev1.Node]{items: map[string]*corev1.Node{
+			"node0": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node0",
+				},
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						corev1.ResourceMemory:           resource.MustParse("1Gi"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+					},
+				},
+			},
+		}},
+		PodCacheGetter: podGetter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.InstallStatsSummary()
+
+	if code := getMetricsAPI(t, svc, "/stats/nodes/node1/summary", nil); code != http.StatusNotFound {
+		t.Errorf("get the unknown node: want 404, got %d", code)
+	}
+
+	var summary statsv1alpha1.Summary
+	if code := getMetricsAPI(t, svc, "/stats/nodes/node0/summary", &summary); code != http.StatusOK {
+		t.Fatalf("get summary: want 200, got %d", code)
+	}
+
+	node := summary.Node
+	if node.NodeName != "node0" {
+		t.Errorf("want node0, got %q", node.NodeName)
+	}
+	if got := *node.CPU.UsageNanoCores; got != 500_000_000 {
+		t.Errorf("node: want 500000000 nanocores, got %d", got)
+	}
+	if got := *node.Memory.AvailableBytes; got != 1024*1024*1024-128*1024*1024 {
+		t.Errorf("node: want the available memory of the capacity minus the used, got %d", got)
+	}
+	if got := *node.Fs.CapacityBytes; got != 10*1024*1024*1024 {
+		t.Errorf("node: want the capacity of the filesystem 10Gi, got %d", got)
+	}
+	if got := *node.Fs.UsedBytes; got != 2*1024*1024*1024 {
+		t.Errorf("node: want the used filesystem 2Gi, got %d", got)
+	}
+	// The pod has been running for 10 seconds at 4Ki per second of each of the containers.
+	if got := *node.Network.RxBytes; got < 70*1024 || got > 90*1024 {
+		t.Errorf("node: want about 80Ki received, got %d", got)
+	}
+	if node.Network.Name != "eth0" || len(node.Network.Interfaces) != 1 {
+		t.Errorf("node: want the interface eth0, got %v", node.Network)
+	}
+
+	if len(summary.Pods) != 1 {
+		t.Fatalf("want the running pod only, got %d pods", len(summary.Pods))
+	}
+	pod := summary.Pods[0]
+	if pod.PodRef.Name != "pod0" || pod.PodRef.UID != "uid-pod0" {
+		t.Errorf("want pod0, got %v", pod.PodRef)
+	}
+	if len(pod.Containers) != 2 {
+		t.Fatalf("want 2 containers, got %d", len(pod.Containers))
+	}
+	if got := *pod.Containers[0].Rootfs.UsedBytes; got != 1024*1024*1024 {
+		t.Errorf("container: want the used rootfs 1Gi, got %d", got)
+	}
+	if got := *pod.Memory.WorkingSetBytes; got != 128*1024*1024 {
+		t.Errorf("pod: want the working set 128Mi, got %d", got)
+	}
+	if got := *pod.EphemeralStorage.UsedBytes; got != 2*1024*1024*1024 {
+		t.Errorf("pod: want the used ephemeral storage 2Gi, got %d", got)
+	}
+	if got := *pod.Network.TxBytes; got < 140*1024 || got > 180*1024 {
+		t.Errorf("pod: want about 160Ki transmitted, got %d", got)
+	}
+}
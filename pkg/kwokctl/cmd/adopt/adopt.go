@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package adopt contains a command to adopt an existing cluster.
+package adopt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name       string
+	Timeout    time.Duration
+	Wait       time.Duration
+	Kubeconfig string
+	// KindName is the name of the existing kind cluster
+	KindName string
+
+	*internalversion.KwokctlConfiguration
+}
+
+// NewCommand returns a new cobra.Command to adopt an existing cluster
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	flags.KwokctlConfiguration = config.GetKwokctlConfiguration(ctx)
+	flags.Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
+	flags.Options.Runtime = consts.RuntimeTypeKind
+	flags.KindName = "kind"
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "adopt",
+		Short: "Adopts an existing kind cluster and deploys the kwok components into it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Options.Runtime, "runtime", flags.Options.Runtime, fmt.Sprintf("Runtime of the existing cluster (%s, %s)", consts.RuntimeTypeKind, consts.RuntimeTypeKindPodman))
+	cmd.Flags().StringVar(&flags.KindName, "kind-name", flags.KindName, "Name of the existing kind cluster")
+	cmd.Flags().StringVar(&flags.Options.KwokControllerImage, "kwok-controller-image", flags.Options.KwokControllerImage, "Image of kwok-controller, it is loaded into the kind cluster if it exists locally")
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file that the context of the adopted cluster will be added to")
+	cmd.Flags().DurationVar(&flags.Timeout, "timeout", 0, "Timeout for the adoption")
+	cmd.Flags().DurationVar(&flags.Wait, "wait", 0, "Wait for the cluster to be ready")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	if flags.Options.Runtime != consts.RuntimeTypeKind &&
+		flags.Options.Runtime != consts.RuntimeTypeKindPodman {
+		return fmt.Errorf("adopting is only supported by the %s and %s runtime, not %q", consts.RuntimeTypeKind, consts.RuntimeTypeKindPodman, flags.Options.Runtime)
+	}
+	if flags.KindName == "" {
+		return fmt.Errorf("the name of the kind cluster is required")
+	}
+	flags.Options.KindClusterName = flags.KindName
+
+	var err error
+	if flags.Kubeconfig != "" {
+		flags.Kubeconfig, err = path.Expand(flags.Kubeconfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	gctx := ctx
+	if flags.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flags.Timeout)
+		defer cancel()
+	}
+
+	buildRuntime, ok := runtime.DefaultRegistry.Get(flags.Options.Runtime)
+	if !ok {
+		return fmt.Errorf("runtime %q not found", flags.Options.Runtime)
+	}
+	rt, err := buildRuntime(name, workdir)
+	if err != nil {
+		return fmt.Errorf("runtime %v not available: %w", flags.Options.Runtime, err)
+	}
+
+	_, err = rt.Config(ctx)
+	if err == nil {
+		return fmt.Errorf("cluster %q already exists", flags.Name)
+	}
+
+	cleanUp := func() {
+		subCtx := context.Background()
+		err := rt.Uninstall(subCtx)
+		if err != nil {
+			logger.Error("Failed to clean up cluster", err)
+		} else {
+			logger.Info("Cluster is cleaned up")
+		}
+	}
+	err = rt.SetConfig(ctx, flags.KwokctlConfiguration)
+	if err != nil {
+		logger.Error("Failed to set config", err)
+		cleanUp()
+		return err
+	}
+	err = rt.Save(ctx)
+	if err != nil {
+		logger.Error("Failed to save config", err)
+		cleanUp()
+		return err
+	}
+
+	start := time.Now()
+	logger.Info("Cluster is adopting", "kind", flags.KindName)
+	err = rt.Install(ctx)
+	if err != nil {
+		logger.Error("Failed to setup config", err)
+		cleanUp()
+		return err
+	}
+
+	err = rt.Up(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to adopt cluster %q: %w", name, err)
+	}
+
+	if flags.Kubeconfig != "" {
+		err = rt.AddContext(ctx, flags.Kubeconfig)
+		if err != nil {
+			logger.Error("Failed to add context to kubeconfig", err,
+				"kubeconfig", flags.Kubeconfig,
+			)
+		}
+	}
+	logger.Info("Cluster is adopted",
+		"elapsed", time.Since(start),
+	)
+
+	err = rt.InitCRDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to init crds %q: %w", name, err)
+	}
+
+	if flags.Wait > 0 {
+		start = time.Now()
+		logger.Info("Waiting for cluster to be ready")
+		err = rt.WaitReady(gctx, flags.Wait)
+		if err != nil {
+			logger.Error("Failed to wait for cluster to be ready", err,
+				"elapsed", time.Since(start),
+			)
+		} else {
+			logger.Info("Cluster is ready",
+				"elapsed", time.Since(start),
+			)
+		}
+	}
+
+	if log.IsTerminal() && flags.Kubeconfig != "" && !rt.IsDryRun() {
+		_, _ = fmt.Fprintf(os.Stderr, `You can now use your cluster with:
+
+	kubectl cluster-info --context %s
+
+Thanks for using kwok!
+`, name)
+	}
+	return nil
+}
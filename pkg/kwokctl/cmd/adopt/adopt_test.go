@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adopt
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+type fakeRuntime struct {
+	runtime.Runtime
+
+	exists bool
+	conf   *internalversion.KwokctlConfiguration
+	calls  []string
+}
+
+func (f *fakeRuntime) Config(ctx context.Context) (*internalversion.KwokctlConfiguration, error) {
+	if !f.exists {
+		return nil, errors.New("not exists")
+	}
+	return f.conf, nil
+}
+
+func (f *fakeRuntime) SetConfig(ctx context.Context, conf *internalversion.KwokctlConfiguration) error {
+	f.calls = append(f.calls, "SetConfig")
+	f.conf = conf.DeepCopy()
+	return nil
+}
+
+func (f *fakeRuntime) record(name string) error {
+	f.calls = append(f.calls, name)
+	return nil
+}
+
+func (f *fakeRuntime) Save(ctx context.Context) error {
+	return f.record("Save")
+}
+
+func (f *fakeRuntime) Install(ctx context.Context) error {
+	return f.record("Install")
+}
+
+func (f *fakeRuntime) Up(ctx context.Context) error {
+	return f.record("Up")
+}
+
+func (f *fakeRuntime) InitCRDs(ctx context.Context) error {
+	return f.record("InitCRDs")
+}
+
+func (f *fakeRuntime) AddContext(ctx context.Context, kubeconfigPath string) error {
+	return f.record("AddContext")
+}
+
+func (f *fakeRuntime) IsDryRun() bool {
+	return false
+}
+
+func Test_runE(t *testing.T) {
+	var rt *fakeRuntime
+	runtime.DefaultRegistry.Register(consts.RuntimeTypeKind, func(name, workdir string) (runtime.Runtime, error) {
+		return rt, nil
+	})
+
+	tests := []struct {
+		name      string
+		runtime   string
+		kindName  string
+		exists    bool
+		wantErr   bool
+		wantCalls []string
+	}{
+		{
+			name:     "adopt",
+			runtime:  consts.RuntimeTypeKind,
+			kindName: "kind",
+			wantCalls: []string{
+				"SetConfig",
+				"Save",
+				"Install",
+				"Up",
+				"AddContext",
+				"InitCRDs",
+			},
+		},
+		{
+			name:     "unsupported runtime",
+			runtime:  consts.RuntimeTypeBinary,
+			kindName: "kind",
+			wantErr:  true,
+		},
+		{
+			name:    "without the kind name",
+			runtime: consts.RuntimeTypeKind,
+			wantErr: true,
+		},
+		{
+			name:     "already exists",
+			runtime:  consts.RuntimeTypeKind,
+			kindName: "kind",
+			exists:   true,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt = &fakeRuntime{exists: tt.exists}
+			flags := &flagpole{
+				Name:       config.DefaultCluster,
+				KindName:   tt.kindName,
+				Kubeconfig: "/tmp/kubeconfig",
+				KwokctlConfiguration: &internalversion.KwokctlConfiguration{
+					Options: internalversion.KwokctlConfigurationOptions{
+						Runtime: tt.runtime,
+					},
+				},
+			}
+			err := runE(context.Background(), flags)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runE() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantCalls, rt.calls); diff != "" {
+				t.Errorf("unexpected calls (-want +got):\n%s", diff)
+			}
+			if tt.wantErr {
+				return
+			}
+			if rt.conf.Options.KindClusterName != tt.kindName {
+				t.Errorf("want the kind cluster name %q saved, got %q", tt.kindName, rt.conf.Options.KindClusterName)
+			}
+		})
+	}
+}
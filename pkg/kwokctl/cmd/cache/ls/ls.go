@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ls contains a command to list the cache of the binaries.
+package ls
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+type flagpole struct {
+	CacheDir string
+}
+
+// NewCommand returns a new cobra.Command for listing the cache
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	flags.CacheDir = config.GetKwokctlConfiguration(ctx).Options.CacheDir
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "ls",
+		Short: "Lists the cached binaries, the least recently used first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.CacheDir, "cache-dir", flags.CacheDir, "The directory of the cache")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	entries, err := file.ListCache(flags.CacheDir)
+	if err != nil {
+		return err
+	}
+	inUse, err := runtime.CacheInUse()
+	if err != nil {
+		return err
+	}
+	return printTable(os.Stdout, flags.CacheDir, entries, inUse, time.Now())
+}
+
+func printTable(out io.Writer, cacheDir string, entries []file.CacheEntry, inUse sets.Set[string], now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PATH\tSIZE\tLAST USED\tIN USE")
+	var total int64
+	for _, entry := range entries {
+		name, err := filepath.Rel(cacheDir, entry.Path)
+		if err != nil {
+			name = entry.Path
+		}
+		if entry.Partial {
+			name += " (partial)"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%t\n",
+			name,
+			format.HumanSize(entry.Size),
+			duration.HumanDuration(now.Sub(entry.LastUsed)),
+			inUse.Has(entry.Path),
+		)
+		total += entry.Size
+	}
+	_, _ = fmt.Fprintf(w, "TOTAL\t%s\t\t\n", format.HumanSize(total))
+	return w.Flush()
+}
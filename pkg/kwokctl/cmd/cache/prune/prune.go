@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prune contains a command to remove the least recently used cache of the binaries.
+package prune
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+type flagpole struct {
+	CacheDir string
+	MaxSize  string
+}
+
+// NewCommand returns a new cobra.Command for pruning the cache
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	options := config.GetKwokctlConfiguration(ctx).Options
+	flags.CacheDir = options.CacheDir
+	flags.MaxSize = options.CacheMaxSize
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "prune",
+		Short: "Removes the least recently used binaries of the cache not used by the clusters, until the cache is not larger than the maximum size",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.CacheDir, "cache-dir", flags.CacheDir, "The directory of the cache")
+	cmd.Flags().StringVar(&flags.MaxSize, "max-size", flags.MaxSize, "Maximum size of the cache, e.g. 10Gi, all the binaries not used by the clusters are removed if it is empty")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	var maxSize int64
+	if flags.MaxSize != "" {
+		size, err := runtime.ParseCacheMaxSize(flags.MaxSize)
+		if err != nil {
+			return err
+		}
+		maxSize = size
+	}
+
+	entries, err := runtime.CacheToPrune(flags.CacheDir, maxSize)
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	var total int64
+	for _, entry := range entries {
+		if dryrun.DryRun {
+			dryrun.PrintMessage("rm %s", entry.Path)
+			continue
+		}
+		removed, err := file.RemoveCache(entry)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			logger.Info("Skip the cache being downloaded", "path", entry.Path)
+			continue
+		}
+		logger.Debug("Removed cache", "path", entry.Path)
+		total += entry.Size
+	}
+	if !dryrun.DryRun {
+		logger.Info("Pruned cache", "dir", flags.CacheDir, "size", format.HumanSize(total))
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package install implements the component install command
+package install
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+	Port uint32
+}
+
+// NewCommand returns a new cobra.Command for installing an optional component into a cluster
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: runtime.OptionalComponents,
+		Use:       "install [component]",
+		Short:     "Installs one of [" + strings.Join(runtime.OptionalComponents, ", ") + "] into a cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args[0])
+		},
+	}
+	cmd.Flags().Uint32Var(&flags.Port, "port", 0, "Port of the component, a random one if not specified")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, component string) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster is not exists")
+		}
+		return err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+	port, err := runtime.OptionalComponentPort(&conf.Options, component)
+	if err != nil {
+		return err
+	}
+	if flags.Port != 0 {
+		*port = flags.Port
+		err = rt.SetConfig(ctx, conf)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = rt.InstallComponent(ctx, component)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Component is installed",
+		"component", component,
+		"port", *port,
+	)
+	return nil
+}
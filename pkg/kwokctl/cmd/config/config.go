@@ -22,7 +22,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/lint"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/reset"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/set"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/tidy"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/config/view"
 )
@@ -32,13 +34,15 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "config [command]",
-		Short: "Manage [reset, tidy, view] default config",
+		Short: "Manage [lint, reset, set, tidy, view] default config",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 
+	cmd.AddCommand(lint.NewCommand(ctx))
 	cmd.AddCommand(reset.NewCommand(ctx))
+	cmd.AddCommand(set.NewCommand(ctx))
 	cmd.AddCommand(tidy.NewCommand(ctx))
 	cmd.AddCommand(view.NewCommand(ctx))
 	return cmd
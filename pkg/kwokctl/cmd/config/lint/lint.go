@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint provides the kwokctl config lint command.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwok/controllers"
+)
+
+// NewCommand returns a new cobra.Command for config lint
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "lint",
+		Short: "Check the stages in the config file with --config for the problems that would make them fail at runtime",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context, out io.Writer) error {
+	stages := config.FilterWithTypeFromContext[*internalversion.Stage](ctx)
+
+	problems := 0
+	resolved, err := controllers.ResolveStages(stages)
+	if err != nil {
+		_, _ = fmt.Fprintln(out, err)
+		problems += len(stages) - len(resolved)
+	}
+	for _, stage := range resolved {
+		for _, err := range controllers.LintStage(stage) {
+			_, _ = fmt.Fprintf(out, "stage %q: %v\n", stage.Name, err)
+			problems++
+		}
+		for _, err := range controllers.LintStageWarnings(stage) {
+			_, _ = fmt.Fprintf(out, "stage %q: warning: %v\n", stage.Name, err)
+		}
+	}
+	if problems != 0 {
+		return fmt.Errorf("found %d problems in %d stages", problems, len(stages))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package set provides the kwokctl config set command.
+package set
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// NewCommand returns a new cobra.Command for config set
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(2),
+		Use:   "set <option> <value>",
+		Short: "Set an option of the default config file, e.g. kwokctl config set kubeVersion v1.28.0",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), args[0], args[1])
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context, key, value string) error {
+	p := path.Join(config.WorkDir, consts.ConfigName)
+	if dryrun.DryRun {
+		dryrun.PrintMessage("# Setting %s=%s in config file %s", key, value, p)
+		return nil
+	}
+	err := config.SetKwokctlOption(ctx, p, key, value)
+	if err != nil {
+		return err
+	}
+	return nil
+}
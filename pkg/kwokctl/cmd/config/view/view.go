@@ -19,6 +19,7 @@ package view
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -26,28 +27,46 @@ import (
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/consts"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/utils/file"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 )
 
+type flagpole struct {
+	Name    string
+	Cluster bool
+}
+
 // NewCommand returns a new cobra.Command for config view
 func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "view",
-		Short: "Display the default config file with --config",
+		Short: "Display the effective config merged from the defaults, the profiles and the config files with --config",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runE(cmd.Context())
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
 		},
 	}
+	cmd.Flags().BoolVar(&flags.Cluster, "cluster", false, "Display the config the cluster of --name is created with, including the flags given to create it")
 	return cmd
 }
 
-func runE(ctx context.Context) error {
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Cluster {
+		return viewCluster(ctx, flags.Name)
+	}
+
 	p := path.Join(config.WorkDir, consts.ConfigName)
 	if dryrun.DryRun {
 		dryrun.PrintMessage("# Displaying config file %s", p)
 		return nil
 	}
+
+	// Make sure the defaults are displayed even if there is no config file.
+	_ = config.GetKwokctlConfiguration(ctx)
+
 	list := config.GetFromContext(ctx)
 	err := config.SaveTo(ctx, os.Stdout, list)
 	if err != nil {
@@ -55,3 +74,23 @@ func runE(ctx context.Context) error {
 	}
 	return nil
 }
+
+func viewCluster(ctx context.Context, name string) error {
+	p := path.Join(config.ClustersDir, name, consts.ConfigName)
+	if dryrun.DryRun {
+		dryrun.PrintMessage("# Displaying config file %s", p)
+		return nil
+	}
+	if !file.Exists(p) {
+		return fmt.Errorf("cluster %q does not exist", name)
+	}
+	list, err := config.Load(ctx, p)
+	if err != nil {
+		return err
+	}
+	err = config.SaveTo(ctx, os.Stdout, list)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -27,19 +27,28 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/consts"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
 	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
 	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
 	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
 type flagpole struct {
-	Name       string
-	Timeout    time.Duration
-	Wait       time.Duration
-	Kubeconfig string
+	Name    string
+	Timeout time.Duration
+	Wait    time.Duration
+	// WaitComponents is the components to wait for, all the components and the cluster are waited for if it is empty
+	WaitComponents []string
+	Kubeconfig     string
+	// FromSpec is the spec exported by `kwokctl export cluster`, which replaces the configuration of the cluster
+	FromSpec string
 
 	*internalversion.KwokctlConfiguration
 }
@@ -59,19 +68,40 @@ func NewCommand(ctx context.Context) *cobra.Command {
 			return runE(cmd.Context(), flags)
 		},
 	}
+	addFlags(cmd, flags)
+	return cmd
+}
 
+// addFlags adds the flags of the cluster creation to the command
+func addFlags(cmd *cobra.Command, flags *flagpole) {
 	cmd.Flags().Uint32Var(&flags.Options.KubeApiserverPort, "kube-apiserver-port", flags.Options.KubeApiserverPort, `Port of the apiserver (default random)`)
 	cmd.Flags().Uint32Var(&flags.Options.PrometheusPort, "prometheus-port", flags.Options.PrometheusPort, `Port to expose Prometheus metrics`)
 	cmd.Flags().Uint32Var(&flags.Options.JaegerPort, "jaeger-port", flags.Options.JaegerPort, `Port to expose Jaeger UI`)
+	cmd.Flags().Uint32Var(&flags.Options.GrafanaPort, "grafana-port", flags.Options.GrafanaPort, `Port to expose Grafana UI`)
 	cmd.Flags().BoolVar(&flags.Options.SecurePort, "secure-port", flags.Options.SecurePort, `The apiserver port on which to serve HTTPS with authentication and authorization, is not available before Kubernetes 1.13.0`)
 	cmd.Flags().BoolVar(&flags.Options.QuietPull, "quiet-pull", flags.Options.QuietPull, `Pull without printing progress information`)
+	cmd.Flags().BoolVar(&flags.Options.Offline, "offline", flags.Options.Offline, `Create the cluster without the network, the images and binaries must be loaded by 'kwokctl images load' first`)
+	cmd.Flags().StringVar(&flags.Options.CacheMaxSize, "cache-max-size", flags.Options.CacheMaxSize, `Maximum size of the cache of the binaries, e.g. 10Gi, the least recently used ones are removed once the cache is larger`)
 	cmd.Flags().StringVar(&flags.Options.KubeSchedulerConfig, "kube-scheduler-config", flags.Options.KubeSchedulerConfig, `Path to a kube-scheduler configuration file`)
 	cmd.Flags().BoolVar(&flags.Options.DisableKubeScheduler, "disable-kube-scheduler", flags.Options.DisableKubeScheduler, `Disable the kube-scheduler`)
 	cmd.Flags().BoolVar(&flags.Options.DisableKubeControllerManager, "disable-kube-controller-manager", flags.Options.DisableKubeControllerManager, `Disable the kube-controller-manager`)
+	cmd.Flags().BoolVar(&flags.Options.EnableMetricsServer, "enable-metrics-server", flags.Options.EnableMetricsServer, `Serve the metrics API (metrics.k8s.io) from the usages of the resources simulated by the kwok-controller, for kubectl top and the HorizontalPodAutoscalers`)
+	cmd.Flags().BoolVar(&flags.Options.EnableCustomMetrics, "enable-custom-metrics", flags.Options.EnableCustomMetrics, `Serve the custom metrics API (custom.metrics.k8s.io) and the external metrics API (external.metrics.k8s.io) from the Metrics by the kwok-controller, for the HorizontalPodAutoscalers`)
+	cmd.Flags().BoolVar(&flags.Options.EnableGrafana, "enable-grafana", flags.Options.EnableGrafana, `Deploy Grafana pre-wired to the Prometheus with the dashboards of the kwok-controller and the simulated cluster, the ports of them are random if not set, only for docker/podman/nerdctl/kind/kind-podman runtime`)
+	cmd.Flags().Uint32Var(&flags.Options.KubeApiserverReplicas, "kube-apiserver-replicas", flags.Options.KubeApiserverReplicas, `Number of kube-apiserver instances, only for binary and docker/podman/nerdctl runtime`)
+	cmd.Flags().Uint32Var(&flags.Options.KubeControllerManagerReplicas, "kube-controller-manager-replicas", flags.Options.KubeControllerManagerReplicas, `Number of kube-controller-manager instances with leader election, only for binary and docker/podman/nerdctl runtime`)
+	cmd.Flags().Uint32Var(&flags.Options.KubeSchedulerReplicas, "kube-scheduler-replicas", flags.Options.KubeSchedulerReplicas, `Number of kube-scheduler instances with leader election, only for binary and docker/podman/nerdctl runtime`)
 	cmd.Flags().StringVar(&flags.Options.EtcdImage, "etcd-image", flags.Options.EtcdImage, `Image of etcd, only for docker/podman/nerdctl runtime
 '${KWOK_KUBE_IMAGE_PREFIX}/etcd:${KWOK_ETCD_VERSION}'
 `)
 	cmd.Flags().Uint32Var(&flags.Options.EtcdPort, "etcd-port", flags.Options.EtcdPort, `Port of etcd given to the host. The behavior is unstable for kind/kind-podman runtime and may be modified in the future`)
+	cmd.Flags().StringSliceVar(&flags.Options.EtcdServers, "etcd-servers", flags.Options.EtcdServers, `List of the servers of an external etcd, the etcd is not created if it is set, only for binary and docker/podman/nerdctl runtime`)
+	cmd.Flags().StringVar(&flags.Options.EtcdCaFile, "etcd-cafile", flags.Options.EtcdCaFile, `CA file to secure the communication with the external etcd`)
+	cmd.Flags().StringVar(&flags.Options.EtcdCertFile, "etcd-certfile", flags.Options.EtcdCertFile, `Client certificate file of the external etcd`)
+	cmd.Flags().StringVar(&flags.Options.EtcdKeyFile, "etcd-keyfile", flags.Options.EtcdKeyFile, `Client key file of the external etcd`)
+	cmd.Flags().StringVar(&flags.Options.EtcdAutoCompactionMode, "etcd-auto-compaction-mode", flags.Options.EtcdAutoCompactionMode, `Mode of the auto compaction of etcd (periodic, revision)`)
+	cmd.Flags().StringVar(&flags.Options.EtcdAutoCompactionRetention, "etcd-auto-compaction-retention", flags.Options.EtcdAutoCompactionRetention, `Retention of the auto compaction of etcd, a duration, e.g. 1h or 1 for hours, in the periodic mode, or a number of revisions in the revision mode, 0 disables it`)
+	cmd.Flags().StringVar(&flags.Options.EtcdPrefix, "etcd-prefix", flags.Options.EtcdPrefix, `Prefix of the keys of the cluster in etcd, the clusters sharing an external etcd need their own prefixes`)
 	cmd.Flags().StringVar(&flags.Options.KubeApiserverImage, "kube-apiserver-image", flags.Options.KubeApiserverImage, `Image of kube-apiserver, only for docker/podman/nerdctl runtime
 '${KWOK_KUBE_IMAGE_PREFIX}/kube-apiserver:${KWOK_KUBE_VERSION}'
 `)
@@ -91,6 +121,9 @@ func NewCommand(ctx context.Context) *cobra.Command {
 `)
 	cmd.Flags().StringVar(&flags.Options.JaegerImage, "jaeger-image", flags.Options.JaegerImage, `Image of Jaeger, only for docker/podman/nerdctl/kind/kind-podman runtime
 '${KWOK_JAEGER_IMAGE_PREFIX}/all-in-one:${KWOK_JAEGER_VERSION}'
+`)
+	cmd.Flags().StringVar(&flags.Options.GrafanaImage, "grafana-image", flags.Options.GrafanaImage, `Image of Grafana, only for docker/podman/nerdctl/kind/kind-podman runtime
+'${KWOK_GRAFANA_IMAGE_PREFIX}/grafana:${KWOK_GRAFANA_VERSION}'
 `)
 	cmd.Flags().Uint32Var(&flags.Options.KwokControllerPort, "controller-port", flags.Options.KwokControllerPort, `Port of kwok-controller given to the host`)
 	cmd.Flags().StringVar(&flags.Options.KindNodeImage, "kind-node-image", flags.Options.KindNodeImage, `Image of kind node, only for kind/kind-podman runtime
@@ -126,16 +159,25 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVar(&flags.Options.KubeFeatureGates, "kube-feature-gates", flags.Options.KubeFeatureGates, `A set of key=value pairs that describe feature gates for alpha/experimental features of Kubernetes`)
 	cmd.Flags().StringVar(&flags.Options.KubeRuntimeConfig, "kube-runtime-config", flags.Options.KubeRuntimeConfig, `A set of key=value pairs that enable or disable built-in APIs`)
 	cmd.Flags().StringVar(&flags.Options.KubeAuditPolicy, "kube-audit-policy", flags.Options.KubeAuditPolicy, "Path to the file that defines the audit policy configuration")
+	cmd.Flags().StringVar(&flags.Options.KubeAuditWebhook, "kube-audit-webhook", flags.Options.KubeAuditWebhook, "Path to the kubeconfig formatted file that defines the audit webhook configuration, requires --kube-audit-policy")
 	cmd.Flags().BoolVar(&flags.Options.KubeAuthorization, "kube-authorization", flags.Options.KubeAuthorization, "Enable authorization for kube-apiserver, only for non kind/kind-podman runtime")
 	cmd.Flags().BoolVar(&flags.Options.KubeAdmission, "kube-admission", flags.Options.KubeAdmission, "Enable admission for kube-apiserver, only for non kind/kind-podman runtime")
 	cmd.Flags().StringVar(&flags.Options.Runtime, "runtime", flags.Options.Runtime, fmt.Sprintf("Runtime of the cluster (%s)", strings.Join(runtime.DefaultRegistry.List(), " or ")))
 	cmd.Flags().DurationVar(&flags.Timeout, "timeout", 0, "Timeout for waiting for the cluster to be created")
 	cmd.Flags().DurationVar(&flags.Wait, "wait", 0, "Wait for the cluster to be ready")
+	cmd.Flags().StringSliceVar(&flags.WaitComponents, "wait-component", flags.WaitComponents, "Components to wait for to be ready with --wait, all the components by default")
 	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file will be added to the newly created cluster and set to current-context")
+	cmd.Flags().BoolVar(&flags.Options.KubeconfigContext, "kubeconfig-context", flags.Options.KubeconfigContext, "Add the context of the cluster to the kubeconfig of --kubeconfig, otherwise it is added by 'kwokctl use-context'")
+	cmd.Flags().BoolVar(&flags.Options.EnableIPv6, "enable-ipv6", flags.Options.EnableIPv6, "Enable IPv6 in the network of the components, only for docker/podman/nerdctl runtime")
 	cmd.Flags().BoolVar(&flags.Options.DisableQPSLimits, "disable-qps-limits", flags.Options.DisableQPSLimits, "Disable QPS limits for components")
 	cmd.Flags().StringSliceVar(&flags.Options.EnableCRDs, "enable-crds", flags.Options.EnableCRDs, "List of CRDs to enable")
+	cmd.Flags().Uint32Var(&flags.Options.Nodes, "nodes", flags.Options.Nodes, "Number of nodes to create after the cluster is started")
+	cmd.Flags().StringVar(&flags.Options.PortRange, "port-range", flags.Options.PortRange, "Range of the ports picked at random, e.g. 30000-32767, the ports are reserved for the cluster until it is deleted, only for binary and docker/podman/nerdctl runtime (default 10001-32767)")
+	cmd.Flags().StringVar(&flags.Options.ComponentsCPULimit, "components-cpu-limit", flags.Options.ComponentsCPULimit, "CPU limit of each of the components, e.g. 1 or 500m, only for binary and docker/podman/nerdctl runtime")
+	cmd.Flags().StringVar(&flags.Options.ComponentsMemoryLimit, "components-memory-limit", flags.Options.ComponentsMemoryLimit, "Memory limit of each of the components, e.g. 512Mi, only for binary and docker/podman/nerdctl runtime")
+	cmd.Flags().StringVar(&flags.Options.ComponentsRestartBackoff, "components-restart-backoff", flags.Options.ComponentsRestartBackoff, "Maximum backoff of the restarts of the crashed components, e.g. 5m, 0 disables the restarts, only for binary runtime")
+	cmd.Flags().StringVar(&flags.FromSpec, "from-spec", flags.FromSpec, "Path to the spec exported by 'kwokctl export cluster', which replaces the configuration and the flags of the cluster")
 
-	return cmd
 }
 
 func runE(ctx context.Context, flags *flagpole) error {
@@ -154,6 +196,17 @@ func runE(ctx context.Context, flags *flagpole) error {
 		}
 	}
 
+	if flags.FromSpec != "" {
+		ctx, err = loadSpec(ctx, flags)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !flags.Options.KubeconfigContext {
+		flags.Kubeconfig = ""
+	}
+
 	gctx := ctx
 	if flags.Timeout > 0 {
 		var cancel context.CancelFunc
@@ -219,6 +272,18 @@ func runE(ctx context.Context, flags *flagpole) error {
 				logger.Info("Cluster is cleaned up")
 			}
 		}
+		if flags.Options.EnableMetricsServer {
+			flags.Options.EnableCRDs = enableResourceUsageCRDs(ctx, flags.Options.EnableCRDs)
+		}
+		if flags.Options.EnableCustomMetrics &&
+			!slices.Contains(flags.Options.EnableCRDs, v1alpha1.MetricKind) &&
+			len(config.FilterWithTypeFromContext[*internalversion.Metric](ctx)) == 0 {
+			flags.Options.EnableCRDs = append(flags.Options.EnableCRDs, v1alpha1.MetricKind)
+		}
+		err = runtime.ValidateCustomComponents(flags.KwokctlConfiguration.Components)
+		if err != nil {
+			return err
+		}
 		err = rt.SetConfig(ctx, flags.KwokctlConfiguration)
 		if err != nil {
 			logger.Error("Failed to set config", err)
@@ -284,11 +349,18 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return fmt.Errorf("failed to init crds %q: %w", name, err)
 	}
 
+	if flags.Options.Nodes > 0 {
+		err = createNodes(ctx, rt, flags.Options.Nodes)
+		if err != nil {
+			return fmt.Errorf("failed to create nodes %q: %w", name, err)
+		}
+	}
+
 	// Wait for cluster to be ready
 	if flags.Wait > 0 {
 		start = time.Now()
 		logger.Info("Waiting for cluster to be ready")
-		err = rt.WaitReady(gctx, flags.Wait)
+		err = waitReady(gctx, rt, flags.WaitComponents, flags.Wait)
 		if err != nil {
 			logger.Error("Failed to wait for cluster to be ready", err,
 				"elapsed", time.Since(start),
@@ -310,3 +382,134 @@ Thanks for using kwok!
 	}
 	return nil
 }
+
+// createNodes creates the nodes with the node resource in the configuration
+// enableResourceUsageCRDs enables the CRDs of the usages of the resources served by the metrics API,
+// unless the usages are given in the --config.
+func enableResourceUsageCRDs(ctx context.Context, crds []string) []string {
+	if !slices.Contains(crds, v1alpha1.ResourceUsageKind) &&
+		len(config.FilterWithTypeFromContext[*internalversion.ResourceUsage](ctx)) == 0 {
+		crds = append(crds, v1alpha1.ResourceUsageKind)
+	}
+	if !slices.Contains(crds, v1alpha1.ClusterResourceUsageKind) &&
+		len(config.FilterWithTypeFromContext[*internalversion.ClusterResourceUsage](ctx)) == 0 {
+		crds = append(crds, v1alpha1.ClusterResourceUsageKind)
+	}
+	return crds
+}
+
+func createNodes(ctx context.Context, rt runtime.Runtime, nodes uint32) error {
+	kubeconfigPath := rt.GetWorkdirPath(runtime.InHostKubeconfigName)
+	clientset, err := client.NewClientset("", kubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	krc, err := scale.GetResource(ctx, "node")
+	if err != nil {
+		return err
+	}
+
+	parameters, err := scale.NewParameters(ctx, krc.Parameters, nil)
+	if err != nil {
+		return err
+	}
+
+	return scale.Scale(ctx, clientset, scale.Config{
+		Parameters:   parameters,
+		Template:     krc.Template,
+		Name:         "node",
+		Replicas:     int(nodes),
+		SerialLength: 6,
+		DryRun:       rt.IsDryRun(),
+	})
+}
+
+// waitReady waits for the components to be ready and reports the progress of each of them,
+// then waits for the whole cluster if the components are not specified.
+func waitReady(ctx context.Context, rt runtime.Runtime, components []string, timeout time.Duration) error {
+	if rt.IsDryRun() {
+		return nil
+	}
+	start := time.Now()
+
+	waitCluster := len(components) == 0
+	if waitCluster {
+		config, err := rt.Config(ctx)
+		if err != nil {
+			return err
+		}
+		for _, component := range config.Components {
+			components = append(components, component.Name)
+		}
+	} else {
+		for _, name := range components {
+			_, err := rt.GetComponent(ctx, name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	logger := log.FromContext(ctx)
+	pending := components
+	err := wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		notReady := make([]string, 0, len(pending))
+		for _, name := range pending {
+			ready, err := rt.ComponentReady(ctx, name)
+			if err != nil {
+				logger.Debug("Component is not ready",
+					"component", name,
+					"err", err,
+				)
+			}
+			if !ready {
+				notReady = append(notReady, name)
+				continue
+			}
+			logger.Info("Component is ready",
+				"component", name,
+				"elapsed", time.Since(start),
+			)
+		}
+		pending = notReady
+		return len(pending) == 0, nil
+	},
+		wait.WithTimeout(timeout),
+		wait.WithInterval(time.Second/2),
+		wait.WithImmediate(),
+	)
+	if err != nil {
+		return fmt.Errorf("components %s are not ready: %w", strings.Join(pending, ","), err)
+	}
+
+	if !waitCluster {
+		return nil
+	}
+
+	// The components take the whole timeout, there is no time left to wait for the cluster.
+	remaining := timeout - time.Since(start)
+	if remaining <= 0 {
+		return fmt.Errorf("cluster is not ready: timed out after %s", timeout)
+	}
+	return rt.WaitReady(ctx, remaining)
+}
+
+// loadSpec loads the spec of the cluster, the configuration of the spec replaces the one from the flags,
+// and the other objects of the spec, e.g. the stages, replace the ones in the context.
+func loadSpec(ctx context.Context, flags *flagpole) (context.Context, error) {
+	spec, err := path.Expand(flags.FromSpec)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := config.Load(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	configs := config.FilterWithType[*internalversion.KwokctlConfiguration](objs)
+	if len(configs) != 1 {
+		return nil, fmt.Errorf("expected one KwokctlConfiguration in the spec %s, got %d", flags.FromSpec, len(configs))
+	}
+	flags.KwokctlConfiguration = configs[0]
+	return config.NewContext(ctx, objs), nil
+}
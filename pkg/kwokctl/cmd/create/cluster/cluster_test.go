@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+type fakeRuntime struct {
+	runtime.Runtime
+
+	readyAfter  time.Duration
+	waitTimeout time.Duration
+	waitCalled  bool
+}
+
+func (f *fakeRuntime) IsDryRun() bool {
+	return false
+}
+
+func (f *fakeRuntime) Config(ctx context.Context) (*internalversion.KwokctlConfiguration, error) {
+	return &internalversion.KwokctlConfiguration{
+		Components: []internalversion.Component{
+			{Name: "etcd"},
+			{Name: "kube-apiserver"},
+		},
+	}, nil
+}
+
+func (f *fakeRuntime) ComponentReady(ctx context.Context, name string) (bool, error) {
+	time.Sleep(f.readyAfter)
+	return true, nil
+}
+
+func (f *fakeRuntime) WaitReady(ctx context.Context, timeout time.Duration) error {
+	f.waitCalled = true
+	f.waitTimeout = timeout
+	return nil
+}
+
+func Test_waitReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		readyAfter time.Duration
+		timeout    time.Duration
+		wantErr    bool
+		wantWait   bool
+	}{
+		{
+			name:     "wait for the cluster with the remaining time",
+			timeout:  time.Minute,
+			wantWait: true,
+		},
+		{
+			name:       "no time left for the cluster",
+			readyAfter: 100 * time.Millisecond,
+			timeout:    100 * time.Millisecond,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &fakeRuntime{readyAfter: tt.readyAfter}
+			err := waitReady(context.Background(), rt, nil, tt.timeout)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("waitReady() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if rt.waitCalled != tt.wantWait {
+				t.Fatalf("want the cluster waited %v, got %v", tt.wantWait, rt.waitCalled)
+			}
+			if rt.waitCalled && (rt.waitTimeout <= 0 || rt.waitTimeout > tt.timeout) {
+				t.Errorf("want the timeout of the cluster in (0, %s], got %s", tt.timeout, rt.waitTimeout)
+			}
+		})
+	}
+}
+
+func Test_loadSpec(t *testing.T) {
+	spec := filepath.Join(t.TempDir(), "spec.yaml")
+	err := os.WriteFile(spec, []byte(`apiVersion: config.kwok.x-k8s.io/v1alpha1
+kind: KwokctlConfiguration
+options:
+  runtime: binary
+  prometheusPort: 9090
+---
+apiVersion: kwok.x-k8s.io/v1alpha1
+kind: Stage
+metadata:
+  name: pod-ready
+spec:
+  resourceRef:
+    apiGroup: v1
+    kind: Pod
+`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flags := &flagpole{
+		FromSpec: spec,
+		KwokctlConfiguration: &internalversion.KwokctlConfiguration{
+			Options: internalversion.KwokctlConfigurationOptions{
+				Runtime: "docker",
+			},
+		},
+	}
+	ctx, err := loadSpec(context.Background(), flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags.Options.Runtime != "binary" || flags.Options.PrometheusPort != 9090 {
+		t.Errorf("want the options from the spec, got %+v", flags.Options)
+	}
+	stages := config.FilterWithTypeFromContext[*internalversion.Stage](ctx)
+	if len(stages) != 1 || stages[0].Name != "pod-ready" {
+		t.Errorf("want the stage from the spec, got %v", stages)
+	}
+}
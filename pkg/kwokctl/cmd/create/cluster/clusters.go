@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type clustersFlagpole struct {
+	flagpole
+
+	Count      uint
+	NamePrefix string
+}
+
+// NewClustersCommand returns a new cobra.Command for the creation of multiple clusters
+func NewClustersCommand(ctx context.Context) *cobra.Command {
+	flags := &clustersFlagpole{}
+	flags.KwokctlConfiguration = config.GetKwokctlConfiguration(ctx)
+	flags.Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "clusters",
+		Short: "Creates multiple clusters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.NamePrefix == "" {
+				flags.NamePrefix = config.DefaultCluster
+			}
+			return runClustersE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().UintVar(&flags.Count, "count", 2, "Number of clusters to create")
+	cmd.Flags().StringVar(&flags.NamePrefix, "name-prefix", "", "Prefix of the names of the clusters, the clusters are named <prefix>-<index> (default the cluster name)")
+	addFlags(cmd, &flags.flagpole)
+	return cmd
+}
+
+// runClustersE creates the clusters one after another with the same configuration,
+// the contexts of them are added to the same kubeconfig.
+func runClustersE(ctx context.Context, flags *clustersFlagpole) error {
+	if flags.Count == 0 {
+		return fmt.Errorf("count must be greater than 0")
+	}
+	if flags.Count > 1 {
+		ports := fixedPorts(&flags.Options)
+		if len(ports) != 0 {
+			return fmt.Errorf("the ports %s can not be shared by the clusters, leave them random", strings.Join(ports, ","))
+		}
+	}
+
+	for i := uint(0); i != flags.Count; i++ {
+		f := flags.flagpole
+		f.Name = fmt.Sprintf("%s-%d", flags.NamePrefix, i)
+		f.KwokctlConfiguration = flags.KwokctlConfiguration.DeepCopy()
+		err := runE(ctx, &f)
+		if err != nil {
+			return fmt.Errorf("failed to create cluster %q: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// fixedPorts returns the names of the ports given to the host that are set to fixed values.
+func fixedPorts(options *internalversion.KwokctlConfigurationOptions) []string {
+	ports := []struct {
+		name string
+		port uint32
+	}{
+		{"kube-apiserver", options.KubeApiserverPort},
+		{"kube-controller-manager", options.KubeControllerManagerPort},
+		{"kube-scheduler", options.KubeSchedulerPort},
+		{"kwok-controller", options.KwokControllerPort},
+		{"etcd", options.EtcdPort},
+		{"etcd-peer", options.EtcdPeerPort},
+		{"dashboard", options.DashboardPort},
+		{"prometheus", options.PrometheusPort},
+		{"jaeger", options.JaegerPort},
+		{"jaeger-otlp-grpc", options.JaegerOtlpGrpcPort},
+		{"grafana", options.GrafanaPort},
+	}
+	names := []string{}
+	for _, p := range ports {
+		if p.port != 0 {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func Test_runClustersE_fixedPorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		options internalversion.KwokctlConfigurationOptions
+		want    string
+	}{
+		{
+			name:    "kube-apiserver",
+			options: internalversion.KwokctlConfigurationOptions{KubeApiserverPort: 6443},
+			want:    "kube-apiserver",
+		},
+		{
+			name:    "kube-controller-manager",
+			options: internalversion.KwokctlConfigurationOptions{KubeControllerManagerPort: 10257},
+			want:    "kube-controller-manager",
+		},
+		{
+			name:    "kube-scheduler",
+			options: internalversion.KwokctlConfigurationOptions{KubeSchedulerPort: 10259},
+			want:    "kube-scheduler",
+		},
+		{
+			name:    "kwok-controller",
+			options: internalversion.KwokctlConfigurationOptions{KwokControllerPort: 10247},
+			want:    "kwok-controller",
+		},
+		{
+			name:    "etcd",
+			options: internalversion.KwokctlConfigurationOptions{EtcdPort: 2379},
+			want:    "etcd",
+		},
+		{
+			name:    "etcd-peer",
+			options: internalversion.KwokctlConfigurationOptions{EtcdPeerPort: 2380},
+			want:    "etcd-peer",
+		},
+		{
+			name:    "dashboard",
+			options: internalversion.KwokctlConfigurationOptions{DashboardPort: 8000},
+			want:    "dashboard",
+		},
+		{
+			name:    "prometheus",
+			options: internalversion.KwokctlConfigurationOptions{PrometheusPort: 9090},
+			want:    "prometheus",
+		},
+		{
+			name:    "jaeger",
+			options: internalversion.KwokctlConfigurationOptions{JaegerPort: 16686},
+			want:    "jaeger",
+		},
+		{
+			name:    "jaeger-otlp-grpc",
+			options: internalversion.KwokctlConfigurationOptions{JaegerOtlpGrpcPort: 4317},
+			want:    "jaeger-otlp-grpc",
+		},
+		{
+			name:    "grafana",
+			options: internalversion.KwokctlConfigurationOptions{GrafanaPort: 3000},
+			want:    "grafana",
+		},
+		{
+			name:    "multiple",
+			options: internalversion.KwokctlConfigurationOptions{EtcdPort: 2379, DashboardPort: 8000},
+			want:    "etcd,dashboard",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := &clustersFlagpole{Count: 2, NamePrefix: "kwok"}
+			flags.KwokctlConfiguration = &internalversion.KwokctlConfiguration{Options: tt.options}
+			err := runClustersE(context.Background(), flags)
+			if err == nil {
+				t.Fatal("want an error of the fixed ports")
+			}
+			if !strings.Contains(err.Error(), "ports "+tt.want+" can not be shared") {
+				t.Errorf("want the ports %s in the error, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func Test_fixedPorts(t *testing.T) {
+	if got := fixedPorts(&internalversion.KwokctlConfigurationOptions{}); len(got) != 0 {
+		t.Errorf("want no fixed ports, got %v", got)
+	}
+}
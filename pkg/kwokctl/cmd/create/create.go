@@ -30,11 +30,12 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "create [command]",
-		Short: "Creates one of [cluster]",
+		Short: "Creates one of [cluster, clusters]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 	cmd.AddCommand(cluster.NewCommand(ctx))
+	cmd.AddCommand(cluster.NewClustersCommand(ctx))
 	return cmd
 }
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type clustersFlagpole struct {
+	flagpole
+
+	All        bool
+	NamePrefix string
+}
+
+// NewClustersCommand returns a new cobra.Command for the deletion of multiple clusters
+func NewClustersCommand(ctx context.Context) *cobra.Command {
+	flags := &clustersFlagpole{}
+	flags.Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "clusters",
+		Short: "Deletes multiple clusters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClustersE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().BoolVar(&flags.All, "all", flags.All, "Delete all the clusters")
+	cmd.Flags().StringVar(&flags.NamePrefix, "name-prefix", flags.NamePrefix, "Delete the clusters named <prefix>-<index>, e.g. created by 'kwokctl create clusters'")
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file that will remove the deleted clusters")
+	return cmd
+}
+
+// runClustersE deletes the clusters one after another, the failure of one does not stop the others.
+func runClustersE(ctx context.Context, flags *clustersFlagpole) error {
+	if flags.All == (flags.NamePrefix != "") {
+		return fmt.Errorf("one of --all and --name-prefix is required")
+	}
+
+	clusters, err := runtime.ListClusters(ctx, config.ClustersDir)
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	var errs []error
+	for _, name := range clusters {
+		if !flags.All && !strings.HasPrefix(name, flags.NamePrefix+"-") {
+			continue
+		}
+		f := flags.flagpole
+		f.Name = name
+		err = runE(ctx, &f)
+		if err != nil {
+			logger.Error("Failed to delete cluster", err, "cluster", name)
+			errs = append(errs, fmt.Errorf("failed to delete cluster %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
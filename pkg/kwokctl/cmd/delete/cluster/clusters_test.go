@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/config"
+)
+
+func Test_runClustersE(t *testing.T) {
+	defer func(old string) { config.ClustersDir = old }(config.ClustersDir)
+	config.ClustersDir = t.TempDir()
+
+	tests := []struct {
+		name    string
+		flags   clustersFlagpole
+		wantErr bool
+	}{
+		{
+			name:    "neither all nor prefix",
+			wantErr: true,
+		},
+		{
+			name:    "both all and prefix",
+			flags:   clustersFlagpole{All: true, NamePrefix: "kwok"},
+			wantErr: true,
+		},
+		{
+			name:  "all without clusters",
+			flags: clustersFlagpole{All: true},
+		},
+		{
+			name:  "prefix without clusters",
+			flags: clustersFlagpole{NamePrefix: "kwok"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runClustersE(context.Background(), &tt.flags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runClustersE() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
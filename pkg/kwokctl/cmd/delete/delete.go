@@ -30,11 +30,12 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "delete [command]",
-		Short: "Deletes one of [cluster]",
+		Short: "Deletes one of [cluster, clusters]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 	cmd.AddCommand(cluster.NewCommand(ctx))
+	cmd.AddCommand(cluster.NewClustersCommand(ctx))
 	return cmd
 }
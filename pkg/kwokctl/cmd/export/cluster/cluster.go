@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster implements the `export cluster` command
+package cluster
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+type flagpole struct {
+	Name string
+}
+
+// NewCommand returns a new cobra.Command for exporting the cluster spec
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.MaximumNArgs(1),
+		Use:   "cluster [output-file]",
+		Short: "Exports the spec of the cluster to stdout or [output-file] if specified, which is created again by 'kwokctl create cluster --from-spec'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), cmd.OutOrStdout(), flags, args)
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context, out io.Writer, flags *flagpole, args []string) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx).With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	_, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster is not exists")
+		}
+		return err
+	}
+
+	objs, err := config.Load(ctx, path.Join(workdir, runtime.ConfigName))
+	if err != nil {
+		return err
+	}
+
+	spec, err := runtime.ToSpec(objs)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "" {
+		return config.SaveTo(ctx, out, spec)
+	}
+	return config.Save(ctx, args[0], spec)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains a command to generate the Metrics and the ResourceUsages modeling the workloads.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+)
+
+// NewCommand returns a new cobra.Command for generating the metrics
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "metrics",
+		Short: "Generates the Metrics and the ResourceUsages of the --profile, e.g. web-service, batch or ml-training, and the --config",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context())
+		},
+	}
+	return cmd
+}
+
+func runE(ctx context.Context) error {
+	objs := []config.InternalObject{}
+	for _, obj := range config.FilterWithTypeFromContext[*internalversion.ClusterResourceUsage](ctx) {
+		objs = append(objs, obj)
+	}
+	for _, obj := range config.FilterWithTypeFromContext[*internalversion.ResourceUsage](ctx) {
+		objs = append(objs, obj)
+	}
+	for _, obj := range config.FilterWithTypeFromContext[*internalversion.Metric](ctx) {
+		objs = append(objs, obj)
+	}
+	if len(objs) == 0 {
+		return fmt.Errorf("no Metrics or ResourceUsages in the profiles and the config, please give one with --profile, e.g. --profile web-service")
+	}
+	return config.SaveTo(ctx, os.Stdout, objs)
+}
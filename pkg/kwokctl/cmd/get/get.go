@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get/artifacts"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get/clusters"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get/stages"
 )
 
 // NewCommand returns a new cobra.Command for get
@@ -33,7 +34,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "get [command]",
-		Short: "Gets one of [artifacts, clusters, kubeconfig]",
+		Short: "Gets one of [artifacts, clusters, kubeconfig, stages]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
@@ -42,5 +43,6 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(clusters.NewCommand(ctx))
 	cmd.AddCommand(artifacts.NewCommand(ctx))
 	cmd.AddCommand(kubeconfig.NewCommand(ctx))
+	cmd.AddCommand(stages.NewCommand(ctx))
 	return cmd
 }
@@ -27,13 +27,19 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/kwokctl/pki"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
 	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
@@ -45,6 +51,8 @@ type flagpole struct {
 	InsecureSkipTLSVerify bool
 	User                  string
 	Groups                []string
+	Role                  string
+	Namespace             string
 }
 
 // NewCommand returns a new cobra.Command for getting the list of clusters
@@ -70,6 +78,8 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().BoolVar(&flags.InsecureSkipTLSVerify, "insecure-skip-tls-verify", flags.InsecureSkipTLSVerify, "Skip server certificate verification")
 	cmd.Flags().StringVar(&flags.User, "user", flags.User, "Signing certificate with the specified user if modified")
 	cmd.Flags().StringSliceVar(&flags.Groups, "group", flags.Groups, "Signing certificate with the specified groups if modified")
+	cmd.Flags().StringVar(&flags.Role, "role", flags.Role, "Bind the user of --user to the cluster role, e.g. view or edit, instead of the group system:masters, to restrict the kubeconfig")
+	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", flags.Namespace, "Bind the role in the namespace only and set it to the namespace of the context, requires --role")
 	return cmd
 }
 
@@ -81,6 +91,20 @@ func runE(ctx context.Context, flags *flagpole) error {
 	logger = logger.With("cluster", flags.Name)
 	ctx = log.NewContext(ctx, logger)
 
+	if flags.Namespace != "" && flags.Role == "" {
+		return fmt.Errorf("--namespace requires --role")
+	}
+	if flags.Role != "" {
+		if flags.User == pki.DefaultUser {
+			return fmt.Errorf("--role requires --user")
+		}
+		if slices.Equal(pki.DefaultGroups, flags.Groups) {
+			flags.Groups = nil
+		} else if slices.Contains(flags.Groups, "system:masters") {
+			return fmt.Errorf("the group system:masters is not restricted by --role")
+		}
+	}
+
 	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -89,6 +113,13 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
+	if flags.Role != "" {
+		err = bindRole(ctx, rt, flags.User, flags.Role, flags.Namespace)
+		if err != nil {
+			return fmt.Errorf("failed to bind the role %s to user %s: %w", flags.Role, flags.User, err)
+		}
+	}
+
 	kubeconfigPath := rt.GetWorkdirPath(runtime.InHostKubeconfigName)
 
 	kubeConfig, err := clientcmd.LoadFromFile(kubeconfigPath)
@@ -122,6 +153,10 @@ func runE(ctx context.Context, flags *flagpole) error {
 		cluster.CertificateAuthorityData = nil
 	}
 
+	if flags.Namespace != "" {
+		kubeConfig.Contexts[currentContext].Namespace = flags.Namespace
+	}
+
 	userName := kubeConfig.Contexts[currentContext].AuthInfo
 
 	if userName != "" && (!slices.Equal(pki.DefaultGroups, flags.Groups) || flags.User != pki.DefaultUser) {
@@ -171,6 +206,80 @@ func runE(ctx context.Context, flags *flagpole) error {
 	return nil
 }
 
+// bindRole binds the user to the cluster role in the namespace, or in the whole cluster if the namespace is empty.
+func bindRole(ctx context.Context, rt runtime.Runtime, user, role, namespace string) error {
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Options.KubeAuthorization &&
+		conf.Options.Runtime != consts.RuntimeTypeKind &&
+		conf.Options.Runtime != consts.RuntimeTypeKindPodman {
+		return fmt.Errorf("the cluster is created without --kube-authorization, so the role is not enforced")
+	}
+
+	bindingName := "kwokctl:" + user + ":" + role
+	subjects := []rbacv1.Subject{
+		{
+			Kind:     rbacv1.UserKind,
+			APIGroup: rbacv1.GroupName,
+			Name:     user,
+		},
+	}
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     role,
+	}
+
+	if rt.IsDryRun() {
+		if namespace == "" {
+			dryrun.PrintMessage("kubectl create clusterrolebinding %s --clusterrole=%s --user=%s", bindingName, role, user)
+		} else {
+			dryrun.PrintMessage("kubectl create rolebinding %s --namespace=%s --clusterrole=%s --user=%s", bindingName, namespace, role, user)
+		}
+		return nil
+	}
+
+	clientset, err := client.NewClientset("", rt.GetWorkdirPath(runtime.InHostKubeconfigName))
+	if err != nil {
+		return err
+	}
+	typedClient, err := clientset.ToTypedClient()
+	if err != nil {
+		return err
+	}
+
+	if namespace == "" {
+		binding := &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: bindingName,
+			},
+			Subjects: subjects,
+			RoleRef:  roleRef,
+		}
+		_, err = typedClient.RbacV1().ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bindingName,
+			Namespace: namespace,
+		},
+		Subjects: subjects,
+		RoleRef:  roleRef,
+	}
+	_, err = typedClient.RbacV1().RoleBindings(namespace).Create(ctx, binding, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
 func modifyAddress(origin string, address string) (string, error) {
 	u, err := url.Parse(origin)
 	if err != nil {
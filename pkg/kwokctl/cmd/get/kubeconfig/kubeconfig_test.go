@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/pki"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+func Test_runE_role(t *testing.T) {
+	defer func(old string) { config.ClustersDir = old }(config.ClustersDir)
+	config.ClustersDir = t.TempDir()
+
+	tests := []struct {
+		name       string
+		flags      flagpole
+		wantErr    string
+		wantGroups []string
+	}{
+		{
+			name: "namespace without role",
+			flags: flagpole{
+				User:      "alice",
+				Groups:    pki.DefaultGroups,
+				Namespace: "default",
+			},
+			wantErr: "--namespace requires --role",
+		},
+		{
+			name: "role without user",
+			flags: flagpole{
+				User:   pki.DefaultUser,
+				Groups: pki.DefaultGroups,
+				Role:   "view",
+			},
+			wantErr: "--role requires --user",
+		},
+		{
+			name: "role with system:masters",
+			flags: flagpole{
+				User:   "alice",
+				Groups: []string{"dev", "system:masters"},
+				Role:   "view",
+			},
+			wantErr: "the group system:masters is not restricted by --role",
+		},
+		{
+			name: "role drops the default groups",
+			flags: flagpole{
+				User:   "alice",
+				Groups: pki.DefaultGroups,
+				Role:   "view",
+			},
+			wantGroups: nil,
+		},
+		{
+			name: "role keeps the groups",
+			flags: flagpole{
+				User:   "alice",
+				Groups: []string{"dev"},
+				Role:   "view",
+			},
+			wantGroups: []string{"dev"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.flags.Name = "missing"
+			err := runE(context.Background(), &tt.flags)
+			if err == nil {
+				t.Fatal("want an error")
+			}
+			if tt.wantErr != "" {
+				if err.Error() != tt.wantErr {
+					t.Errorf("want the error %q, got %q", tt.wantErr, err)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.wantGroups, tt.flags.Groups); diff != "" {
+				t.Errorf("unexpected groups (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeRuntime struct {
+	runtime.Runtime
+
+	options internalversion.KwokctlConfigurationOptions
+}
+
+func (f *fakeRuntime) Config(ctx context.Context) (*internalversion.KwokctlConfiguration, error) {
+	return &internalversion.KwokctlConfiguration{Options: f.options}, nil
+}
+
+func (f *fakeRuntime) IsDryRun() bool {
+	return true
+}
+
+func Test_bindRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		options internalversion.KwokctlConfigurationOptions
+		wantErr bool
+	}{
+		{
+			name: "authorization",
+			options: internalversion.KwokctlConfigurationOptions{
+				Runtime:           consts.RuntimeTypeBinary,
+				KubeAuthorization: true,
+			},
+		},
+		{
+			name: "without authorization",
+			options: internalversion.KwokctlConfigurationOptions{
+				Runtime: consts.RuntimeTypeBinary,
+			},
+			wantErr: true,
+		},
+		{
+			name: "kind",
+			options: internalversion.KwokctlConfigurationOptions{
+				Runtime: consts.RuntimeTypeKind,
+			},
+		},
+		{
+			name: "kind-podman",
+			options: internalversion.KwokctlConfigurationOptions{
+				Runtime: consts.RuntimeTypeKindPodman,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bindRole(context.Background(), &fakeRuntime{options: tt.options}, "alice", "view", "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bindRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stages contains a command to list the stages of a cluster.
+package stages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwok/controllers"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name  string
+	Stats bool
+}
+
+// NewCommand returns a new cobra.Command for getting the stages
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "stages",
+		Short: "Lists the stages of the cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().BoolVar(&flags.Stats, "stats", flags.Stats, "Show the statistics of the stages played by the kwok-controller, including the stages in the --config")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster is not exists")
+		}
+		return err
+	}
+
+	if !flags.Stats {
+		return rt.KubectlInCluster(exec.WithStdIO(ctx), "get", "stages.kwok.x-k8s.io")
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+	port := conf.Options.KwokControllerPort
+	if port == 0 {
+		return fmt.Errorf("the port of kwok-controller is not exposed to the host, please create the cluster with --controller-port")
+	}
+
+	stats, err := getStageStatistics(ctx, "http://127.0.0.1:"+format.String(port)+"/stages/statistics")
+	if err != nil {
+		return err
+	}
+	return printStageStatistics(os.Stdout, stats, time.Now())
+}
+
+func getStageStatistics(ctx context.Context, url string) ([]controllers.StageStatistic, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get stage statistics: unexpected status code %d", resp.StatusCode)
+	}
+
+	var stats []controllers.StageStatistic
+	err = json.NewDecoder(resp.Body).Decode(&stats)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func printStageStatistics(out io.Writer, stats []controllers.StageStatistic, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "KIND\tSTAGE\tMATCHED\tAVERAGE DELAY\tLAST PLAYED")
+	for _, stat := range stats {
+		lastPlayed := "<never>"
+		if stat.LastPlayed != nil {
+			lastPlayed = now.Sub(*stat.LastPlayed).Truncate(time.Second).String() + " ago"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", stat.Kind, stat.Stage, stat.Matched, stat.AverageDelay, lastPlayed)
+	}
+	return w.Flush()
+}
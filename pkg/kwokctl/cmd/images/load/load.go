@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package load contains a command to load the images and binaries of cluster.
+package load
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+	Path string
+
+	*internalversion.KwokctlConfiguration
+}
+
+// NewCommand returns a new cobra.Command for loading the images and binaries of cluster
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	flags.KwokctlConfiguration = config.GetKwokctlConfiguration(ctx)
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "load",
+		Short: "Load the images and binaries of cluster from a directory saved by 'kwokctl images save'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Options.Runtime, "runtime", flags.Options.Runtime, fmt.Sprintf("Runtime of the cluster (%s)", strings.Join(runtime.DefaultRegistry.List(), " or ")))
+	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the directory to load from")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	buildRuntime, ok := runtime.DefaultRegistry.Get(flags.Options.Runtime)
+	if !ok {
+		return fmt.Errorf("runtime %q not found", flags.Options.Runtime)
+	}
+
+	rt, err := buildRuntime(name, workdir)
+	if err != nil {
+		return err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		err = rt.SetConfig(ctx, flags.KwokctlConfiguration)
+		if err != nil {
+			return err
+		}
+		conf = flags.KwokctlConfiguration
+	}
+
+	archive := path.Join(flags.Path, "images.tar")
+	if file.Exists(archive) {
+		logger.Info("Load images", "archive", archive)
+		err = rt.LoadImages(ctx, archive)
+		if err != nil {
+			return err
+		}
+	}
+
+	cacheDir := path.Join(flags.Path, "cache")
+	if file.Exists(cacheDir) {
+		logger.Info("Load binaries", "cache", conf.Options.CacheDir)
+		if dryrun.DryRun {
+			dryrun.PrintMessage("cp -r %s/. %s", cacheDir, conf.Options.CacheDir)
+		} else {
+			err = copyDir(cacheDir, conf.Options.CacheDir)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	logger.Info("Loaded the images and binaries", "path", flags.Path)
+	return nil
+}
+
+// copyDir copies the files in the src directory to the dest directory and keeps the layout.
+func copyDir(src, dest string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := path.Join(dest, rel)
+		if d.IsDir() {
+			return file.MkdirAll(target)
+		}
+		return file.Copy(p, target)
+	})
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package save contains a command to save the images and binaries of cluster.
+package save
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+	Path string
+
+	*internalversion.KwokctlConfiguration
+}
+
+// NewCommand returns a new cobra.Command for saving the images and binaries of cluster
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	flags.KwokctlConfiguration = config.GetKwokctlConfiguration(ctx)
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "save",
+		Short: "Save the images and binaries of cluster to a directory",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Options.Runtime, "runtime", flags.Options.Runtime, fmt.Sprintf("Runtime of the cluster (%s)", strings.Join(runtime.DefaultRegistry.List(), " or ")))
+	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the directory to save to")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	buildRuntime, ok := runtime.DefaultRegistry.Get(flags.Options.Runtime)
+	if !ok {
+		return fmt.Errorf("runtime %q not found", flags.Options.Runtime)
+	}
+
+	rt, err := buildRuntime(name, workdir)
+	if err != nil {
+		return err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		err = rt.SetConfig(ctx, flags.KwokctlConfiguration)
+		if err != nil {
+			return err
+		}
+		conf = flags.KwokctlConfiguration
+	}
+
+	if dryrun.DryRun {
+		dryrun.PrintMessage("mkdir -p %s", flags.Path)
+	} else {
+		err = file.MkdirAll(flags.Path)
+		if err != nil {
+			return err
+		}
+	}
+
+	images, err := rt.ListImages(ctx)
+	if err != nil {
+		return err
+	}
+	if len(images) != 0 {
+		logger.Info("Save images", "images", images)
+		err = rt.SaveImages(ctx, path.Join(flags.Path, "images.tar"))
+		if err != nil {
+			return err
+		}
+	}
+
+	binaries, err := rt.ListBinaries(ctx)
+	if err != nil {
+		return err
+	}
+	cacheDir := path.Join(flags.Path, "cache")
+	for _, binary := range binaries {
+		if binary == "" {
+			continue
+		}
+		if dryrun.DryRun {
+			dryrun.PrintMessage("# Download %s to %s", binary, cacheDir)
+			continue
+		}
+		_, err = file.DownloadToCache(ctx, cacheDir, binary, 0750, conf.Options.QuietPull, runtime.GetBinaryVerification(&conf.Options, binary))
+		if err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Saved the images and binaries", "path", flags.Path)
+	return nil
+}
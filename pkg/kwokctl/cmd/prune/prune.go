@@ -0,0 +1,172 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prune contains a command to remove the resources left behind by the clusters which no longer exist.
+package prune
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+type flagpole struct {
+	Kubeconfig string
+}
+
+// NewCommand returns a new cobra.Command for pruning the orphaned resources
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	flags.Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "prune",
+		Short: "Removes the containers, networks, data directories, reserved ports and kubeconfig entries left behind by the clusters which no longer exist",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file to remove the orphaned contexts from")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	var err error
+	flags.Kubeconfig, err = path.Expand(flags.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	clusters, err := runtime.ListClusters(ctx, config.ClustersDir)
+	if err != nil {
+		return err
+	}
+	names := slices.Map(clusters, config.ClusterName)
+
+	var errs []error
+	err = pruneWorkdirs(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	err = runtime.ReleaseOrphanPorts(ctx)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if flags.Kubeconfig != "" {
+		err = pruneKubeconfig(ctx, flags.Kubeconfig, names)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	err = runtime.DefaultRegistry.PruneOrphans(ctx, names)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// pruneWorkdirs removes the directories of the clusters without a config file, which are left behind by the crashed creations
+func pruneWorkdirs(ctx context.Context) error {
+	entries, err := os.ReadDir(config.ClustersDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	for _, entry := range entries {
+		workdir := path.Join(config.ClustersDir, entry.Name())
+		if !entry.IsDir() || file.Exists(path.Join(workdir, runtime.ConfigName)) {
+			continue
+		}
+		if dryrun.DryRun {
+			dryrun.PrintMessage("rm -rf %s", workdir)
+			continue
+		}
+		err = file.RemoveAll(workdir)
+		if err != nil {
+			return err
+		}
+		logger.Info("Removed orphan", "kind", "directory", "name", workdir)
+	}
+	return nil
+}
+
+// pruneKubeconfig removes the contexts of the clusters which no longer exist from the kubeconfig,
+// only the contexts named after a cluster and pointing to the local host are removed, which are added by kwokctl.
+func pruneKubeconfig(ctx context.Context, kubeconfigPath string, clusters []string) error {
+	if !file.Exists(kubeconfigPath) {
+		return nil
+	}
+
+	exists := sets.NewString(clusters...)
+	logger := log.FromContext(ctx)
+	return kubeconfig.ModifyContext(kubeconfigPath, func(conf *clientcmdapi.Config) error {
+		for name := range conf.Contexts {
+			if !strings.HasPrefix(name, consts.ProjectName+"-") || exists.Has(name) {
+				continue
+			}
+			cluster, ok := conf.Clusters[name]
+			if !ok || !isLocalServer(cluster.Server) {
+				continue
+			}
+			if dryrun.DryRun {
+				dryrun.PrintMessage("kubectl config delete-context %s", name)
+				continue
+			}
+			delete(conf.Contexts, name)
+			delete(conf.Clusters, name)
+			delete(conf.AuthInfos, name)
+			if conf.CurrentContext == name {
+				conf.CurrentContext = ""
+			}
+			logger.Info("Removed orphan", "kind", "context", "name", name)
+		}
+		return nil
+	})
+}
+
+func isLocalServer(server string) bool {
+	u, err := url.Parse(server)
+	if err != nil {
+		return false
+	}
+	switch u.Hostname() {
+	case net.LocalAddress, net.PublicAddress, "localhost":
+		return true
+	}
+	return false
+}
@@ -23,18 +23,30 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/adopt"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/cache"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/component"
 	conf "sigs.k8s.io/kwok/pkg/kwokctl/cmd/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/create"
 	del "sigs.k8s.io/kwok/pkg/kwokctl/cmd/delete"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/etcd"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/etcdctl"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/export"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/generate"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/images"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/kubectl"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/logs"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/prune"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/scale"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/stage"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/start"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/status"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/stop"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/supervise"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/upgrade"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/usecontext"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/utils/version"
 )
@@ -60,16 +72,28 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(
 		conf.NewCommand(ctx),
 		create.NewCommand(ctx),
+		adopt.NewCommand(ctx),
 		del.NewCommand(ctx),
+		prune.NewCommand(ctx),
 		get.NewCommand(ctx),
+		status.NewCommand(ctx),
+		usecontext.NewCommand(ctx),
 		start.NewCommand(ctx),
 		stop.NewCommand(ctx),
+		upgrade.NewCommand(ctx),
+		component.NewCommand(ctx),
 		kubectl.NewCommand(ctx),
 		etcdctl.NewCommand(ctx),
+		etcd.NewCommand(ctx),
 		logs.NewCommand(ctx),
 		scale.NewCommand(ctx),
 		snapshot.NewCommand(ctx),
 		export.NewCommand(ctx),
+		generate.NewCommand(ctx),
+		images.NewCommand(ctx),
+		cache.NewCommand(ctx),
+		stage.NewCommand(ctx),
+		supervise.NewCommand(ctx),
 	)
 	return cmd
 }
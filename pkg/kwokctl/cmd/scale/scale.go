@@ -20,21 +20,17 @@ package scale
 import (
 	"context"
 	"errors"
-	"fmt"
 	"os"
 	"path"
 
 	"github.com/spf13/cobra"
 
-	"sigs.k8s.io/kwok/kustomize/kwokctl/resource"
-	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
-	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
 
 type flagpole struct {
@@ -93,26 +89,9 @@ func runE(ctx context.Context, flags *flagpole, args []string) error {
 		return err
 	}
 
-	krcs := config.FilterWithTypeFromContext[*internalversion.KwokctlResource](ctx)
-	krc, ok := slices.Find(krcs, func(krc *internalversion.KwokctlResource) bool {
-		return krc.Name == resourceKind
-	})
-	if !ok {
-		var resourceData string
-		switch resourceKind {
-		default:
-			return fmt.Errorf("resource %s is not exists", resourceKind)
-		case "pod":
-			resourceData = resource.DefaultPod
-		case "node":
-			resourceData = resource.DefaultNode
-		}
-
-		logger.Info("No resource found, use default resource", "resource", resourceKind)
-		krc, err = config.UnmarshalWithType[*internalversion.KwokctlResource](resourceData)
-		if err != nil {
-			return err
-		}
+	krc, err := scale.GetResource(ctx, resourceKind)
+	if err != nil {
+		return err
 	}
 
 	parameters, err := scale.NewParameters(ctx, krc.Parameters, flags.Params)
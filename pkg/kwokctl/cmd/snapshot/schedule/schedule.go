@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedule provides a command to save the snapshots of a cluster periodically.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+const (
+	snapshotPrefix     = "snapshot-"
+	snapshotSuffix     = ".db"
+	snapshotTimeLayout = "20060102-150405"
+)
+
+type flagpole struct {
+	Name     string
+	Path     string
+	Interval time.Duration
+	Keep     int
+}
+
+// NewCommand returns a new cobra.Command for saving the snapshots of cluster periodically.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "schedule",
+		Short: "Save the etcd snapshots of the cluster periodically until interrupted",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Path, "path", "", "Directory to save the snapshots to")
+	cmd.Flags().DurationVar(&flags.Interval, "interval", time.Hour, "Interval between the snapshots")
+	cmd.Flags().IntVar(&flags.Keep, "keep", 24, "Number of the latest snapshots to keep, 0 means keeping all of them")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+	if flags.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	if flags.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if flags.Keep < 0 {
+		return fmt.Errorf("keep must not be negative")
+	}
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster is not exists")
+		}
+		return err
+	}
+
+	err = file.MkdirAll(flags.Path)
+	if err != nil {
+		return err
+	}
+
+	for {
+		snapshotPath := path.Join(flags.Path, snapshotPrefix+time.Now().UTC().Format(snapshotTimeLayout)+snapshotSuffix)
+		// A failed snapshot does not stop the schedule, the next one is tried in the next interval
+		err = rt.SnapshotSave(ctx, snapshotPath)
+		if err != nil {
+			logger.Error("Failed to save snapshot", err, "path", snapshotPath)
+		} else {
+			logger.Info("Saved snapshot", "path", snapshotPath)
+			err = pruneSnapshots(ctx, flags.Path, flags.Keep)
+			if err != nil {
+				logger.Error("Failed to prune snapshots", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(flags.Interval):
+		}
+	}
+}
+
+// pruneSnapshots removes the snapshots in the dir but the latest keep ones
+func pruneSnapshots(ctx context.Context, dir string, keep int) error {
+	if keep == 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	snapshots := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || !strings.HasSuffix(name, snapshotSuffix) {
+			continue
+		}
+		snapshots = append(snapshots, name)
+	}
+	if len(snapshots) <= keep {
+		return nil
+	}
+
+	// The names are sorted by the time of the snapshots
+	sort.Strings(snapshots)
+
+	logger := log.FromContext(ctx)
+	for _, name := range snapshots[:len(snapshots)-keep] {
+		p := path.Join(dir, name)
+		err = file.Remove(p)
+		if err != nil {
+			return err
+		}
+		logger.Info("Removed snapshot", "path", p)
+	}
+	return nil
+}
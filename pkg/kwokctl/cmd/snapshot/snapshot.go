@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/export"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/restore"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/save"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/schedule"
 )
 
 // NewCommand returns a new cobra.Command for cluster snapshot
@@ -32,7 +33,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "snapshot [command]",
-		Short: "Snapshot [save, restore, export] one of cluster",
+		Short: "Snapshot [save, restore, export, schedule] one of cluster",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
@@ -40,5 +41,6 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(save.NewCommand(ctx))
 	cmd.AddCommand(restore.NewCommand(ctx))
 	cmd.AddCommand(export.NewCommand(ctx))
+	cmd.AddCommand(schedule.NewCommand(ctx))
 	return cmd
 }
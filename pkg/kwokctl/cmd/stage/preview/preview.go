@@ -0,0 +1,196 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preview evaluates the stages against an object without touching the cluster.
+package preview
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
+	nodeheartbeatwithlease "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat-with-lease"
+	podfast "sigs.k8s.io/kwok/kustomize/stage/pod/fast"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwok/controllers"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+type flagpole struct {
+	File   string
+	Steps  int
+	NodeIP string
+	PodIP  string
+}
+
+// NewCommand returns a new cobra.Command for stage preview
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "preview",
+		Short: "Preview the transitions that the stages would apply to the object, without touching the cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVarP(&flags.File, "file", "f", "", "Path to the file of the object, - for stdin")
+	cmd.Flags().IntVar(&flags.Steps, "steps", 10, "Max number of stages to apply")
+	cmd.Flags().StringVar(&flags.NodeIP, "node-ip", "192.168.0.1", "IP of the node used by the stage templates")
+	cmd.Flags().StringVar(&flags.PodIP, "pod-ip", "10.0.0.1", "IP of the pod used by the stage templates")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, out io.Writer) error {
+	if flags.File == "" {
+		return fmt.Errorf("file is required")
+	}
+
+	objs, err := readObjects(flags.File)
+	if err != nil {
+		return err
+	}
+
+	stages, err := controllers.ResolveStages(config.FilterWithTypeFromContext[*internalversion.Stage](ctx))
+	if err != nil {
+		return err
+	}
+	if len(stages) == 0 {
+		stages, err = getDefaultStages()
+		if err != nil {
+			return err
+		}
+	}
+
+	preview, err := controllers.NewStagePreview(controllers.StagePreviewConfig{
+		Stages:   stages,
+		NodeIP:   flags.NodeIP,
+		PodIP:    flags.PodIP,
+		MaxSteps: flags.Steps,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, obj := range objs {
+		if i != 0 {
+			_, _ = fmt.Fprintln(out, "---")
+		}
+		steps, err := preview.Preview(ctx, obj)
+		if err != nil {
+			return fmt.Errorf("failed to preview %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		err = printSteps(out, obj, steps)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readObjects(path string) ([]*unstructured.Unstructured, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		r = f
+	}
+
+	objs := []*unstructured.Unstructured{}
+	err := yaml.NewDecoder(r).DecodeToUnstructured(func(obj *unstructured.Unstructured) error {
+		objs = append(objs, obj)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("no object found in %q", path)
+	}
+	return objs, nil
+}
+
+func printSteps(out io.Writer, obj *unstructured.Unstructured, steps []controllers.StagePreviewStep) error {
+	_, _ = fmt.Fprintf(out, "%s %s\n", obj.GetKind(), obj.GetName())
+	if len(steps) == 0 {
+		_, _ = fmt.Fprintln(out, "  No stage matched")
+		return nil
+	}
+	for i, step := range steps {
+		if step.Step != 0 {
+			_, _ = fmt.Fprintf(out, "%d. Stage %q patch %d after %s\n", i+1, step.Stage, step.Step, step.Delay)
+		} else {
+			_, _ = fmt.Fprintf(out, "%d. Stage %q after %s\n", i+1, step.Stage, step.Delay)
+		}
+		for _, event := range step.Events {
+			_, _ = fmt.Fprintf(out, "  Event: %s %s %q\n", event.Type, event.Reason, event.Message)
+		}
+		if step.Webhook != nil {
+			_, _ = fmt.Fprintf(out, "  Webhook: %s\n", step.Webhook.URL)
+		}
+		if step.FinalizersModified {
+			_, _ = fmt.Fprintf(out, "  Finalizers: %v\n", step.Finalizers)
+		}
+		if step.Delete {
+			_, _ = fmt.Fprintln(out, "  Delete")
+		}
+		if step.Patch != nil {
+			patch, err := yaml.JSONToYAML(step.Patch)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(out, "  Patch status:\n    %s\n", strings.ReplaceAll(strings.TrimSpace(string(patch)), "\n", "\n    "))
+		}
+		names := maps.Keys(step.SubresourcePatches)
+		sort.Strings(names)
+		for _, name := range names {
+			patch, err := yaml.JSONToYAML(step.SubresourcePatches[name])
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(out, "  Patch %s:\n    %s\n", name, strings.ReplaceAll(strings.TrimSpace(string(patch)), "\n", "\n    "))
+		}
+	}
+	return nil
+}
+
+func getDefaultStages() ([]*internalversion.Stage, error) {
+	return slices.MapWithError([]string{
+		nodefast.DefaultNodeInit,
+		nodeheartbeatwithlease.DefaultNodeHeartbeatWithLease,
+		podfast.DefaultPodReady,
+		podfast.DefaultPodComplete,
+		podfast.DefaultPodDelete,
+	}, config.UnmarshalWithType[*internalversion.Stage, string])
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stage contains a parent command which works with the stages.
+package stage
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/stage/pause"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/stage/preview"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/stage/resume"
+)
+
+// NewCommand returns a new cobra.Command for stage
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "stage [command]",
+		Short: "Works with the stages [preview, pause, resume]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(preview.NewCommand(ctx))
+	cmd.AddCommand(pause.NewCommand(ctx))
+	cmd.AddCommand(resume.NewCommand(ctx))
+	return cmd
+}
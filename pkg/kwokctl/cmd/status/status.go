@@ -0,0 +1,314 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package status contains a command to report the status of clusters.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name   string
+	All    bool
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for the status of clusters
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "status",
+		Short: "Reports the health, versions, ports, uptime, data size and node/pod counts of clusters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().BoolVar(&flags.All, "all", false, "Report all the clusters instead of the one of --name")
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+// ClusterStatus is the status of a cluster.
+type ClusterStatus struct {
+	Name       string            `json:"name"`
+	Runtime    string            `json:"runtime"`
+	Version    string            `json:"version"`
+	Ready      bool              `json:"ready"`
+	DataSize   int64             `json:"dataSize"`
+	Nodes      *int              `json:"nodes,omitempty"`
+	Pods       *int              `json:"pods,omitempty"`
+	Components []ComponentStatus `json:"components"`
+}
+
+// ComponentStatus is the status of a component of a cluster.
+type ComponentStatus struct {
+	Name      string     `json:"name"`
+	Ready     bool       `json:"ready"`
+	Version   string     `json:"version,omitempty"`
+	Ports     []uint32   `json:"ports,omitempty"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	Restarts  int        `json:"restarts"`
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Output != "table" && flags.Output != "json" {
+		return fmt.Errorf("unsupported output format %q", flags.Output)
+	}
+
+	names := []string{flags.Name}
+	if flags.All {
+		clusters, err := runtime.ListClusters(ctx, config.ClustersDir)
+		if err != nil {
+			return err
+		}
+		names = clusters
+	}
+
+	statuses := make([]ClusterStatus, 0, len(names))
+	for _, name := range names {
+		status, err := getStatus(ctx, name)
+		if err != nil {
+			if !flags.All {
+				return err
+			}
+			logger := log.FromContext(ctx)
+			logger.Warn("Failed to get status", "cluster", name, "err", err)
+			continue
+		}
+		statuses = append(statuses, *status)
+	}
+
+	if flags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statuses)
+	}
+	if len(statuses) == 0 {
+		if log.IsTerminal() {
+			_, _ = fmt.Fprintf(os.Stderr, "No clusters found\n")
+		}
+		return nil
+	}
+	return printTable(os.Stdout, statuses)
+}
+
+func getStatus(ctx context.Context, name string) (*ClusterStatus, error) {
+	workdir := path.Join(config.ClustersDir, name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, config.ClusterName(name), workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("cluster %q does not exist", name)
+		}
+		return nil, err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ClusterStatus{
+		Name:    name,
+		Runtime: conf.Options.Runtime,
+		Version: conf.Options.KubeVersion,
+		Ready:   true,
+	}
+
+	for _, component := range conf.Components {
+		cs := ComponentStatus{
+			Name:    component.Name,
+			Version: component.Version,
+		}
+		ready, err := rt.ComponentReady(ctx, component.Name)
+		if err != nil {
+			logger.Debug("Failed to check the component", "component", component.Name, "err", err)
+		}
+		cs.Ready = ready
+		if !ready {
+			status.Ready = false
+		}
+		startedAt, err := rt.ComponentStartedAt(ctx, component.Name)
+		if err != nil {
+			logger.Debug("Failed to get the start time of the component", "component", component.Name, "err", err)
+		} else if !startedAt.IsZero() {
+			cs.StartedAt = &startedAt
+		}
+		restarts, err := rt.ComponentRestarts(ctx, component.Name)
+		if err != nil {
+			logger.Debug("Failed to get the restarts of the component", "component", component.Name, "err", err)
+		}
+		cs.Restarts = restarts
+		for _, port := range component.Ports {
+			if port.HostPort != 0 {
+				cs.Ports = append(cs.Ports, port.HostPort)
+			}
+		}
+		status.Components = append(status.Components, cs)
+	}
+
+	status.DataSize, err = dirSize(workdir)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Ready {
+		nodes, pods, err := countNodesAndPods(ctx, rt.GetWorkdirPath(runtime.InHostKubeconfigName))
+		if err != nil {
+			logger.Warn("Failed to count the nodes and pods", "err", err)
+		} else {
+			status.Nodes = &nodes
+			status.Pods = &pods
+		}
+	}
+	return status, nil
+}
+
+// dirSize returns the total size of the regular files in the directory, the links are not followed.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+func countNodesAndPods(ctx context.Context, kubeconfigPath string) (nodes int, pods int, err error) {
+	clientset, err := client.NewClientset("", kubeconfigPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	typedClient, err := clientset.ToTypedClient()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	nodeList, err := typedClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, 0, err
+	}
+	podList, err := typedClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(nodeList.Items), len(podList.Items), nil
+}
+
+func printTable(out io.Writer, statuses []ClusterStatus) error {
+	now := time.Now()
+	for i, status := range statuses {
+		if i != 0 {
+			_, _ = fmt.Fprintln(out)
+		}
+
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		_, _ = fmt.Fprintln(w, "CLUSTER\tRUNTIME\tVERSION\tREADY\tNODES\tPODS\tDATA")
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\t%s\n",
+			status.Name,
+			status.Runtime,
+			status.Version,
+			status.Ready,
+			formatCount(status.Nodes),
+			formatCount(status.Pods),
+			format.HumanSize(status.DataSize),
+		)
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, "COMPONENT\tREADY\tVERSION\tPORTS\tRESTARTS\tUPTIME")
+		for _, component := range status.Components {
+			ports := make([]string, 0, len(component.Ports))
+			for _, port := range component.Ports {
+				ports = append(ports, format.String(port))
+			}
+			uptime := "<none>"
+			if component.StartedAt != nil {
+				uptime = duration.HumanDuration(now.Sub(*component.StartedAt))
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%t\t%s\t%s\t%d\t%s\n",
+				component.Name,
+				component.Ready,
+				orNone(component.Version),
+				orNone(strings.Join(ports, ",")),
+				component.Restarts,
+				uptime,
+			)
+		}
+		err := w.Flush()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func formatCount(n *int) string {
+	if n == nil {
+		return "<unknown>"
+	}
+	return format.String(*n)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_dirSize(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]int{
+		"kwok.yaml":             100,
+		"etcd/member/snap/db":   2048,
+		"logs/kube-apiserver":   10,
+		"pids/kube-apiserver":   5,
+		"pki/ca.crt":            1000,
+		"etcd/member/wal/0.wal": 4096,
+	}
+	for name, size := range files {
+		p := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(p), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(p, make([]byte, size), 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := os.Symlink(filepath.Join(dir, "etcd"), filepath.Join(dir, "link"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := dirSize(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(100 + 2048 + 10 + 5 + 1000 + 4096); got != want {
+		t.Errorf("dirSize() = %d, want %d", got, want)
+	}
+
+	_, err = dirSize(filepath.Join(dir, "missing"))
+	if err == nil {
+		t.Errorf("want an error for the missing directory")
+	}
+}
+
+func Test_printTable(t *testing.T) {
+	nodes, pods := 3, 10
+	startedAt := time.Now().Add(-2 * time.Hour)
+	statuses := []ClusterStatus{
+		{
+			Name:     "kwok",
+			Runtime:  "binary",
+			Version:  "v1.28.0",
+			Ready:    true,
+			DataSize: 2048,
+			Nodes:    &nodes,
+			Pods:     &pods,
+			Components: []ComponentStatus{
+				{
+					Name:      "kube-apiserver",
+					Ready:     true,
+					Version:   "v1.28.0",
+					Ports:     []uint32{32766},
+					StartedAt: &startedAt,
+					Restarts:  1,
+				},
+				{
+					Name: "kwok-controller",
+				},
+			},
+		},
+		{
+			Name:    "other",
+			Runtime: "docker",
+			Version: "v1.27.0",
+		},
+	}
+
+	out := bytes.NewBuffer(nil)
+	err := printTable(out, statuses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `CLUSTER   RUNTIME   VERSION   READY   NODES   PODS   DATA
+kwok      binary    v1.28.0   true    3       10     2.0KiB
+
+COMPONENT         READY   VERSION   PORTS    RESTARTS   UPTIME
+kube-apiserver    true    v1.28.0   32766    1          120m
+kwok-controller   false   <none>    <none>   0          <none>
+
+CLUSTER   RUNTIME   VERSION   READY   NODES       PODS        DATA
+other     docker    v1.27.0   false   <unknown>   <unknown>   0B
+
+COMPONENT   READY   VERSION   PORTS   RESTARTS   UPTIME
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("unexpected table (-want +got):\n%s", diff)
+	}
+}
+
+func Test_runE(t *testing.T) {
+	err := runE(context.Background(), &flagpole{Name: "kwok", Output: "yaml"})
+	if err == nil {
+		t.Errorf("want an error for the unsupported output format")
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supervise contains a hidden command to supervise the process of a component in the binary runtime.
+package supervise
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+type flagpole struct {
+	StateFile  string
+	MaxBackoff time.Duration
+
+	LivenessProbe runtime.LivenessProbe
+}
+
+// NewCommand returns a new cobra.Command to supervise the process of a component,
+// it is used by the binary runtime to restart the crashed components and not intended to be used directly.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:   cobra.MinimumNArgs(1),
+		Use:    runtime.SuperviseCommand + " -- [command] [args...]",
+		Short:  "Runs the command and restarts it with backoff each time it exits",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var liveness *runtime.LivenessProbe
+			if flags.LivenessProbe.URL != "" {
+				liveness = &flags.LivenessProbe
+			}
+			return runtime.Supervise(cmd.Context(), flags.StateFile, flags.MaxBackoff, liveness, args[0], args[1:]...)
+		},
+	}
+	cmd.Flags().StringVar(&flags.StateFile, "state-file", "", "Path to the file the state of the process is saved to")
+	cmd.Flags().DurationVar(&flags.MaxBackoff, "max-backoff", 5*time.Minute, "Maximum backoff of the restarts")
+	cmd.Flags().StringVar(&flags.LivenessProbe.URL, "liveness-probe-url", "", "URL of the liveness probe, the process is restarted if it fails")
+	cmd.Flags().DurationVar(&flags.LivenessProbe.InitialDelay, "liveness-probe-initial-delay", 10*time.Second, "Delay after the process has started before the liveness probe is initiated")
+	cmd.Flags().DurationVar(&flags.LivenessProbe.Period, "liveness-probe-period", 10*time.Second, "Period of the liveness probe")
+	cmd.Flags().DurationVar(&flags.LivenessProbe.Timeout, "liveness-probe-timeout", time.Second, "Timeout of the liveness probe")
+	cmd.Flags().IntVar(&flags.LivenessProbe.FailureThreshold, "liveness-probe-failure-threshold", 3, "Consecutive failures of the liveness probe for the process to be restarted")
+	_ = cmd.MarkFlagRequired("state-file")
+	return cmd
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster implements the upgrade cluster command
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
+)
+
+type flagpole struct {
+	Name        string
+	KubeVersion string
+	Wait        time.Duration
+}
+
+// NewCommand returns a new cobra.Command for upgrade cluster
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "cluster",
+		Short: "Upgrade the control plane of a cluster in place",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.KubeVersion, "kube-version", "", "Version of Kubernetes to upgrade the control plane to")
+	cmd.Flags().DurationVar(&flags.Wait, "wait", time.Minute, "Wait for each of the upgraded components to be ready before the next one")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.KubeVersion == "" {
+		return fmt.Errorf("--kube-version is required")
+	}
+	kubeVersion := version.AddPrefixV(flags.KubeVersion)
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster is not exists")
+		}
+		return err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldVersion := conf.Options.KubeVersion
+	if oldVersion == kubeVersion {
+		logger.Info("Cluster is already at the version",
+			"version", kubeVersion,
+		)
+		return nil
+	}
+	from, err := version.ParseVersion(oldVersion)
+	if err != nil {
+		return err
+	}
+	to, err := version.ParseVersion(kubeVersion)
+	if err != nil {
+		return err
+	}
+	if to.LT(from) {
+		return fmt.Errorf("downgrading the cluster from %s to %s is not supported", oldVersion, kubeVersion)
+	}
+
+	runtime.UpgradeOptions(&conf.Options, kubeVersion)
+
+	start := time.Now()
+	logger.Info("Cluster is upgrading",
+		"from", oldVersion,
+		"to", kubeVersion,
+	)
+
+	// The control plane is upgraded one component after another, as the kube-apiserver goes first in the version skew policy
+	for _, stage := range runtime.UpgradableComponents {
+		for _, component := range conf.Components {
+			if !runtime.IsReplicaOf(component.Name, stage) {
+				continue
+			}
+			err = upgradeComponent(ctx, rt, component.Name, flags.Wait)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	logger.Info("Cluster is upgraded",
+		"version", kubeVersion,
+		"elapsed", time.Since(start),
+	)
+	return nil
+}
+
+// upgradeComponent upgrades the component and waits for it to be ready
+func upgradeComponent(ctx context.Context, rt runtime.Runtime, name string, timeout time.Duration) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", name)
+
+	start := time.Now()
+	logger.Info("Component is upgrading")
+	err := rt.UpgradeComponent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade %s: %w", name, err)
+	}
+
+	if timeout > 0 && !rt.IsDryRun() {
+		err = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+			ready, err := rt.ComponentReady(ctx, name)
+			if err != nil {
+				logger.Debug("Component is not ready",
+					"err", err,
+				)
+			}
+			return ready, nil
+		},
+			wait.WithTimeout(timeout),
+			wait.WithInterval(time.Second/2),
+			wait.WithImmediate(),
+		)
+		if err != nil {
+			return fmt.Errorf("component %s is not ready after the upgrade: %w", name, err)
+		}
+	}
+
+	logger.Info("Component is upgraded",
+		"elapsed", time.Since(start),
+	)
+	return nil
+}
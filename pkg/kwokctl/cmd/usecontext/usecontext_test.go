@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usecontext
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/config"
+)
+
+func TestNewCommand(t *testing.T) {
+	defer func(old string) { config.ClustersDir = old }(config.ClustersDir)
+	config.ClustersDir = t.TempDir()
+
+	cmd := NewCommand(context.Background())
+	cmd.SetArgs([]string{"a", "b"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.Execute()
+	if err == nil {
+		t.Errorf("want an error for more than one cluster")
+	}
+
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	cmd = NewCommand(context.Background())
+	cmd.SetArgs([]string{"missing", "--kubeconfig", kubeconfig})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err = cmd.ExecuteContext(context.Background())
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want the error of the missing cluster, got %v", err)
+	}
+	if _, err := os.Stat(kubeconfig); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want the kubeconfig untouched for the missing cluster, got %v", err)
+	}
+}
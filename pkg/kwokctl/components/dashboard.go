@@ -38,6 +38,9 @@ type BuildDashboardComponentConfig struct {
 	AdminCertPath  string
 	AdminKeyPath   string
 	KubeconfigPath string
+	ExtraArgs      []internalversion.ExtraArgs
+	ExtraVolumes   []internalversion.Volume
+	ExtraEnvs      []internalversion.Env
 }
 
 // BuildDashboardComponent builds the dashboard component.
@@ -54,10 +57,12 @@ func BuildDashboardComponent(conf BuildDashboardComponentConfig) (component inte
 	if conf.Banner != "" {
 		dashboardArgs = append(dashboardArgs, "--system-banner="+conf.Banner)
 	}
+	dashboardArgs = append(dashboardArgs, extraArgsToStrings(conf.ExtraArgs)...)
 
 	inContainer := conf.Image != ""
 	user := ""
 	var volumes []internalversion.Volume
+	volumes = append(volumes, conf.ExtraVolumes...)
 	var ports []internalversion.Port
 	if inContainer {
 		dashboardArgs = append(dashboardArgs,
@@ -101,6 +106,9 @@ func BuildDashboardComponent(conf BuildDashboardComponentConfig) (component inte
 		)
 	}
 
+	envs := []internalversion.Env{}
+	envs = append(envs, conf.ExtraEnvs...)
+
 	component = internalversion.Component{
 		Name:  consts.ComponentDashboard,
 		Image: conf.Image,
@@ -111,6 +119,7 @@ func BuildDashboardComponent(conf BuildDashboardComponentConfig) (component inte
 		Ports:   ports,
 		Volumes: volumes,
 		Args:    dashboardArgs,
+		Envs:    envs,
 		User:    user,
 	}
 	return component, nil
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func TestBuildDashboardComponentPatches(t *testing.T) {
+	extraVolume := internalversion.Volume{
+		HostPath:  "/tmp/certs",
+		MountPath: "/certs",
+		ReadOnly:  true,
+	}
+	extraEnv := internalversion.Env{Name: "TZ", Value: "UTC"}
+
+	tests := []struct {
+		name  string
+		image string
+	}{
+		{
+			name:  "container",
+			image: "kubernetesui/dashboard:v2.7.0",
+		},
+		{
+			name: "binary",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component, err := BuildDashboardComponent(BuildDashboardComponentConfig{
+				Image:        tt.image,
+				BindAddress:  "0.0.0.0",
+				Port:         8000,
+				ExtraArgs:    []internalversion.ExtraArgs{{Key: "token-ttl", Value: "0"}},
+				ExtraVolumes: []internalversion.Volume{extraVolume},
+				ExtraEnvs:    []internalversion.Env{extraEnv},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Contains(component.Args, "--token-ttl=0") {
+				t.Errorf("want the extra args in %v", component.Args)
+			}
+			if !slices.Contains(component.Volumes, extraVolume) {
+				t.Errorf("want the extra volume in %v", component.Volumes)
+			}
+			if diff := cmp.Diff([]internalversion.Env{extraEnv}, component.Envs); diff != "" {
+				t.Errorf("unexpected envs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -28,15 +28,19 @@ import (
 
 // BuildEtcdComponentConfig is the configuration for building an etcd component.
 type BuildEtcdComponentConfig struct {
-	Binary       string
-	Image        string
-	Version      version.Version
-	DataPath     string
-	Workdir      string
-	BindAddress  string
-	Port         uint32
-	PeerPort     uint32
-	Verbosity    log.Level
+	Binary      string
+	Image       string
+	Version     version.Version
+	DataPath    string
+	Workdir     string
+	BindAddress string
+	Port        uint32
+	PeerPort    uint32
+	Verbosity   log.Level
+
+	AutoCompactionMode      string
+	AutoCompactionRetention string
+
 	ExtraArgs    []internalversion.ExtraArgs
 	ExtraVolumes []internalversion.Volume
 	ExtraEnvs    []internalversion.Env
@@ -61,9 +65,11 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 
 	etcdArgs := []string{
 		"--name=node0",
-		"--auto-compaction-retention=1",
-		"--quota-backend-bytes=8589934592",
 	}
+	etcdArgs = append(etcdArgs, EtcdAutoCompactionArgs(conf.AutoCompactionMode, conf.AutoCompactionRetention)...)
+	etcdArgs = append(etcdArgs,
+		"--quota-backend-bytes=8589934592",
+	)
 	etcdArgs = append(etcdArgs, extraArgsToStrings(conf.ExtraArgs)...)
 
 	inContainer := conf.Image != ""
@@ -127,6 +133,11 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 		}
 	}
 
+	probePort := conf.Port
+	if inContainer {
+		probePort = 2379
+	}
+
 	envs := []internalversion.Env{}
 	if runtime.GOARCH != "amd64" {
 		envs = append(envs, internalversion.Env{
@@ -147,5 +158,23 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 		Image:   conf.Image,
 		WorkDir: conf.Workdir,
 		Envs:    envs,
+
+		ReadinessProbe: httpGetReadinessProbe("/health", probePort, false),
+		LivenessProbe:  httpGetLivenessProbe("/health", probePort, false),
 	}, nil
 }
+
+// EtcdAutoCompactionArgs returns the arguments of the auto compaction of etcd,
+// which is one hour of the periodic mode if not set.
+func EtcdAutoCompactionArgs(mode, retention string) []string {
+	if mode == "" {
+		mode = "periodic"
+	}
+	if retention == "" {
+		retention = "1"
+	}
+	return []string{
+		"--auto-compaction-mode=" + mode,
+		"--auto-compaction-retention=" + retention,
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"path"
+	"sort"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+// BuildGrafanaComponentConfig is the configuration for building a grafana component.
+type BuildGrafanaComponentConfig struct {
+	Image             string
+	Version           version.Version
+	Workdir           string
+	BindAddress       string
+	Port              uint32
+	ProvisioningFiles map[string]string
+	Verbosity         log.Level
+	ExtraArgs         []internalversion.ExtraArgs
+	ExtraVolumes      []internalversion.Volume
+	ExtraEnvs         []internalversion.Env
+}
+
+// BuildGrafanaComponent builds a grafana component, it is only available in the container,
+// the ProvisioningFiles is the host paths of the provisioning files keyed by the paths relative to the provisioning directory.
+func BuildGrafanaComponent(conf BuildGrafanaComponentConfig) (component internalversion.Component, err error) {
+	grafanaArgs := []string{}
+	grafanaArgs = append(grafanaArgs, extraArgsToStrings(conf.ExtraArgs)...)
+
+	var volumes []internalversion.Volume
+	volumes = append(volumes, conf.ExtraVolumes...)
+	// Mount the files one by one, the directory in the host may not be readable by the user of Grafana.
+	provisioningFiles := maps.Keys(conf.ProvisioningFiles)
+	sort.Strings(provisioningFiles)
+	for _, name := range provisioningFiles {
+		volumes = append(volumes,
+			internalversion.Volume{
+				HostPath:  conf.ProvisioningFiles[name],
+				MountPath: path.Join("/etc/grafana/provisioning", name),
+				ReadOnly:  true,
+			},
+		)
+	}
+	ports := []internalversion.Port{
+		{
+			HostPort: conf.Port,
+			Port:     3000,
+		},
+	}
+
+	envs := []internalversion.Env{
+		{
+			Name:  "GF_SERVER_HTTP_ADDR",
+			Value: conf.BindAddress,
+		},
+		{
+			Name:  "GF_SERVER_HTTP_PORT",
+			Value: "3000",
+		},
+		{
+			Name:  "GF_AUTH_ANONYMOUS_ENABLED",
+			Value: "true",
+		},
+		{
+			Name:  "GF_AUTH_ANONYMOUS_ORG_ROLE",
+			Value: "Admin",
+		},
+		{
+			Name:  "GF_AUTH_DISABLE_LOGIN_FORM",
+			Value: "true",
+		},
+		{
+			Name:  "GF_ANALYTICS_REPORTING_ENABLED",
+			Value: "false",
+		},
+		{
+			Name:  "GF_ANALYTICS_CHECK_FOR_UPDATES",
+			Value: "false",
+		},
+	}
+	if conf.Verbosity != log.LevelInfo {
+		envs = append(envs, internalversion.Env{
+			Name:  "GF_LOG_LEVEL",
+			Value: log.ToLogSeverityLevel(conf.Verbosity),
+		})
+	}
+	envs = append(envs, conf.ExtraEnvs...)
+
+	return internalversion.Component{
+		Name:    consts.ComponentGrafana,
+		Version: conf.Version.String(),
+		Links: []string{
+			consts.ComponentPrometheus,
+		},
+		Ports:   ports,
+		Volumes: volumes,
+		Args:    grafanaArgs,
+		Image:   conf.Image,
+		WorkDir: conf.Workdir,
+		Envs:    envs,
+
+		ReadinessProbe: httpGetReadinessProbe("/api/health", 3000, false),
+	}, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func TestBuildGrafanaComponent(t *testing.T) {
+	extraEnv := internalversion.Env{Name: "GF_SECURITY_ADMIN_PASSWORD", Value: "admin"}
+	component, err := BuildGrafanaComponent(BuildGrafanaComponentConfig{
+		Image:       "docker.io/grafana/grafana:10.0.0",
+		BindAddress: "0.0.0.0",
+		Port:        3000,
+		ProvisioningFiles: map[string]string{
+			"dashboards/kwok.yaml":        "/workdir/grafana/dashboards/kwok.yaml",
+			"datasources/prometheus.yaml": "/workdir/grafana/datasources/prometheus.yaml",
+		},
+		Verbosity: log.LevelDebug,
+		ExtraEnvs: []internalversion.Env{extraEnv},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantVolumes := []internalversion.Volume{
+		{
+			HostPath:  "/workdir/grafana/dashboards/kwok.yaml",
+			MountPath: "/etc/grafana/provisioning/dashboards/kwok.yaml",
+			ReadOnly:  true,
+		},
+		{
+			HostPath:  "/workdir/grafana/datasources/prometheus.yaml",
+			MountPath: "/etc/grafana/provisioning/datasources/prometheus.yaml",
+			ReadOnly:  true,
+		},
+	}
+	if diff := cmp.Diff(wantVolumes, component.Volumes); diff != "" {
+		t.Errorf("unexpected volumes (-want +got):\n%s", diff)
+	}
+
+	wantPorts := []internalversion.Port{
+		{
+			HostPort: 3000,
+			Port:     3000,
+		},
+	}
+	if diff := cmp.Diff(wantPorts, component.Ports); diff != "" {
+		t.Errorf("unexpected ports (-want +got):\n%s", diff)
+	}
+
+	for _, env := range []internalversion.Env{
+		{Name: "GF_SERVER_HTTP_ADDR", Value: "0.0.0.0"},
+		{Name: "GF_LOG_LEVEL", Value: "debug"},
+		extraEnv,
+	} {
+		if !slices.Contains(component.Envs, env) {
+			t.Errorf("want the env %v in %v", env, component.Envs)
+		}
+	}
+	if component.Envs[len(component.Envs)-1] != extraEnv {
+		t.Errorf("want the extra env last to override the others, got %v", component.Envs)
+	}
+	if !slices.Contains(component.Links, "prometheus") {
+		t.Errorf("want grafana linked to prometheus, got %v", component.Links)
+	}
+}
@@ -37,6 +37,7 @@ type BuildJaegerComponentConfig struct {
 	Verbosity    log.Level
 	ExtraArgs    []internalversion.ExtraArgs
 	ExtraVolumes []internalversion.Volume
+	ExtraEnvs    []internalversion.Env
 }
 
 // BuildJaegerComponent builds a jaeger component.
@@ -70,14 +71,25 @@ func BuildJaegerComponent(conf BuildJaegerComponentConfig) (component internalve
 		jaegerArgs = append(jaegerArgs, "--log-level="+log.ToLogSeverityLevel(conf.Verbosity))
 	}
 
+	probePort := conf.Port
+	if inContainer {
+		probePort = 16686
+	}
+
+	envs := []internalversion.Env{}
+	envs = append(envs, conf.ExtraEnvs...)
+
 	return internalversion.Component{
 		Name:    consts.ComponentJaeger,
 		Version: conf.Version.String(),
 		Ports:   ports,
 		Volumes: volumes,
 		Args:    jaegerArgs,
+		Envs:    envs,
 		Binary:  conf.Binary,
 		Image:   conf.Image,
 		WorkDir: conf.Workdir,
+
+		ReadinessProbe: httpGetReadinessProbe("/", probePort, false),
 	}, nil
 }
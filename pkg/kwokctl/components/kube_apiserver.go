@@ -37,6 +37,11 @@ type BuildKubeApiserverComponentConfig struct {
 	Port              uint32
 	EtcdAddress       string
 	EtcdPort          uint32
+	EtcdServers       []string
+	EtcdCaFile        string
+	EtcdCertFile      string
+	EtcdKeyFile       string
+	EtcdPrefix        string
 	KubeRuntimeConfig string
 	KubeFeatureGates  string
 	SecurePort        bool
@@ -44,6 +49,7 @@ type BuildKubeApiserverComponentConfig struct {
 	KubeAdmission     bool
 	AuditPolicyPath   string
 	AuditLogPath      string
+	AuditWebhookPath  string
 	CaCertPath        string
 	AdminCertPath     string
 	AdminKeyPath      string
@@ -60,9 +66,15 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 	if conf.EtcdPort == 0 {
 		conf.EtcdPort = 2379
 	}
+	if conf.EtcdPrefix == "" {
+		conf.EtcdPrefix = "/registry"
+	}
+	if conf.AuditWebhookPath != "" && conf.AuditPolicyPath == "" {
+		return component, fmt.Errorf("the audit webhook requires the audit policy")
+	}
 
 	kubeApiserverArgs := []string{
-		"--etcd-prefix=/registry",
+		"--etcd-prefix=" + conf.EtcdPrefix,
 		"--allow-privileged=true",
 	}
 
@@ -122,7 +134,37 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 	volumes = append(volumes, conf.ExtraVolumes...)
 
 	inContainer := conf.Image != ""
-	if inContainer {
+	externalEtcd := len(conf.EtcdServers) != 0
+	if externalEtcd {
+		kubeApiserverArgs = append(kubeApiserverArgs,
+			"--etcd-servers="+strings.Join(conf.EtcdServers, ","),
+		)
+		for _, file := range []struct {
+			flag      string
+			path      string
+			mountPath string
+		}{
+			{"--etcd-cafile", conf.EtcdCaFile, "/etc/kubernetes/pki/etcd/ca.crt"},
+			{"--etcd-certfile", conf.EtcdCertFile, "/etc/kubernetes/pki/etcd/client.crt"},
+			{"--etcd-keyfile", conf.EtcdKeyFile, "/etc/kubernetes/pki/etcd/client.key"},
+		} {
+			if file.path == "" {
+				continue
+			}
+			if inContainer {
+				volumes = append(volumes,
+					internalversion.Volume{
+						HostPath:  file.path,
+						MountPath: file.mountPath,
+						ReadOnly:  true,
+					},
+				)
+				kubeApiserverArgs = append(kubeApiserverArgs, file.flag+"="+file.mountPath)
+			} else {
+				kubeApiserverArgs = append(kubeApiserverArgs, file.flag+"="+file.path)
+			}
+		}
+	} else if inContainer {
 		kubeApiserverArgs = append(kubeApiserverArgs,
 			"--etcd-servers=http://"+conf.EtcdAddress+":2379",
 		)
@@ -224,11 +266,28 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 				"--audit-policy-file=/etc/kubernetes/audit-policy.yaml",
 				"--audit-log-path=/var/log/kubernetes/audit/audit.log",
 			)
+			if conf.AuditWebhookPath != "" {
+				volumes = append(volumes,
+					internalversion.Volume{
+						HostPath:  conf.AuditWebhookPath,
+						MountPath: "/etc/kubernetes/audit-webhook.yaml",
+						ReadOnly:  true,
+					},
+				)
+				kubeApiserverArgs = append(kubeApiserverArgs,
+					"--audit-webhook-config-file=/etc/kubernetes/audit-webhook.yaml",
+				)
+			}
 		} else {
 			kubeApiserverArgs = append(kubeApiserverArgs,
 				"--audit-policy-file="+conf.AuditPolicyPath,
 				"--audit-log-path="+conf.AuditLogPath,
 			)
+			if conf.AuditWebhookPath != "" {
+				kubeApiserverArgs = append(kubeApiserverArgs,
+					"--audit-webhook-config-file="+conf.AuditWebhookPath,
+				)
+			}
 		}
 	}
 
@@ -255,10 +314,26 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 		kubeApiserverArgs = append(kubeApiserverArgs, "--v="+format.String(log.ToKlogLevel(conf.Verbosity)))
 	}
 
+	probePort := conf.Port
+	if inContainer {
+		if conf.SecurePort {
+			probePort = 6443
+		} else {
+			probePort = 8080
+		}
+	}
+	readinessPath, livenessPath := "/healthz", "/healthz"
+	if conf.Version.GE(version.NewVersion(1, 16, 0)) {
+		readinessPath, livenessPath = "/readyz", "/livez"
+	}
+
 	envs := []internalversion.Env{}
 	envs = append(envs, conf.ExtraEnvs...)
 
-	links := []string{consts.ComponentEtcd}
+	var links []string
+	if !externalEtcd {
+		links = append(links, consts.ComponentEtcd)
+	}
 	if conf.TracingConfigPath != "" {
 		links = append(links, consts.ComponentJaeger)
 	}
@@ -275,5 +350,8 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 		Image:   conf.Image,
 		WorkDir: conf.Workdir,
 		Envs:    envs,
+
+		ReadinessProbe: httpGetReadinessProbe(readinessPath, probePort, conf.SecurePort),
+		LivenessProbe:  httpGetLivenessProbe(livenessPath, probePort, conf.SecurePort),
 	}, nil
 }
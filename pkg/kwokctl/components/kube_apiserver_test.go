@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+func TestBuildKubeApiserverComponentAuditWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		conf        BuildKubeApiserverComponentConfig
+		wantErr     bool
+		wantArg     string
+		wantVolume  *internalversion.Volume
+		wantNoAudit bool
+	}{
+		{
+			name: "container",
+			conf: BuildKubeApiserverComponentConfig{
+				Image:            "registry.k8s.io/kube-apiserver:v1.28.0",
+				AuditPolicyPath:  "/workdir/audit-policy.yaml",
+				AuditLogPath:     "/logs/audit.log",
+				AuditWebhookPath: "/workdir/audit-webhook.yaml",
+			},
+			wantArg: "--audit-webhook-config-file=/etc/kubernetes/audit-webhook.yaml",
+			wantVolume: &internalversion.Volume{
+				HostPath:  "/workdir/audit-webhook.yaml",
+				MountPath: "/etc/kubernetes/audit-webhook.yaml",
+				ReadOnly:  true,
+			},
+		},
+		{
+			name: "binary",
+			conf: BuildKubeApiserverComponentConfig{
+				Binary:           "/bin/kube-apiserver",
+				AuditPolicyPath:  "/workdir/audit-policy.yaml",
+				AuditLogPath:     "/logs/audit.log",
+				AuditWebhookPath: "/workdir/audit-webhook.yaml",
+			},
+			wantArg: "--audit-webhook-config-file=/workdir/audit-webhook.yaml",
+		},
+		{
+			name: "without the audit webhook",
+			conf: BuildKubeApiserverComponentConfig{
+				Binary:          "/bin/kube-apiserver",
+				AuditPolicyPath: "/workdir/audit-policy.yaml",
+				AuditLogPath:    "/logs/audit.log",
+			},
+			wantNoAudit: true,
+		},
+		{
+			name: "without the audit policy",
+			conf: BuildKubeApiserverComponentConfig{
+				Binary:           "/bin/kube-apiserver",
+				AuditWebhookPath: "/workdir/audit-webhook.yaml",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.conf.Version = version.NewVersion(1, 28, 0)
+			component, err := BuildKubeApiserverComponent(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildKubeApiserverComponent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantNoAudit {
+				for _, arg := range component.Args {
+					if strings.HasPrefix(arg, "--audit-webhook-config-file=") {
+						t.Errorf("want no audit webhook, got %s", arg)
+					}
+				}
+				return
+			}
+			if !slices.Contains(component.Args, tt.wantArg) {
+				t.Errorf("want the arg %s in %v", tt.wantArg, component.Args)
+			}
+			if tt.wantVolume != nil && !slices.Contains(component.Volumes, *tt.wantVolume) {
+				t.Errorf("want the volume %v in %v", *tt.wantVolume, component.Volumes)
+			}
+		})
+	}
+}
@@ -194,6 +194,20 @@ func BuildKubeControllerManagerComponent(conf BuildKubeControllerManagerComponen
 		kubeControllerManagerArgs = append(kubeControllerManagerArgs, "--v="+format.String(log.ToKlogLevel(conf.Verbosity)))
 	}
 
+	var readinessProbe, livenessProbe *internalversion.Probe
+	probePort := conf.Port
+	if inContainer {
+		if conf.SecurePort {
+			probePort = 10257
+		} else {
+			probePort = 10252
+		}
+	}
+	if probePort != 0 {
+		readinessProbe = httpGetReadinessProbe("/healthz", probePort, conf.SecurePort)
+		livenessProbe = httpGetLivenessProbe("/healthz", probePort, conf.SecurePort)
+	}
+
 	envs := []internalversion.Env{}
 	envs = append(envs, conf.ExtraEnvs...)
 
@@ -211,5 +225,8 @@ func BuildKubeControllerManagerComponent(conf BuildKubeControllerManagerComponen
 		Image:   conf.Image,
 		WorkDir: conf.Workdir,
 		Envs:    envs,
+
+		ReadinessProbe: readinessProbe,
+		LivenessProbe:  livenessProbe,
 	}, nil
 }
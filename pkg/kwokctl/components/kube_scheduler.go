@@ -184,6 +184,20 @@ func BuildKubeSchedulerComponent(conf BuildKubeSchedulerComponentConfig) (compon
 		kubeSchedulerArgs = append(kubeSchedulerArgs, "--v="+format.String(log.ToKlogLevel(conf.Verbosity)))
 	}
 
+	var readinessProbe, livenessProbe *internalversion.Probe
+	probePort := conf.Port
+	if inContainer {
+		if conf.SecurePort {
+			probePort = 10259
+		} else {
+			probePort = 10251
+		}
+	}
+	if probePort != 0 {
+		readinessProbe = httpGetReadinessProbe("/healthz", probePort, conf.SecurePort)
+		livenessProbe = httpGetLivenessProbe("/healthz", probePort, conf.SecurePort)
+	}
+
 	envs := []internalversion.Env{}
 	envs = append(envs, conf.ExtraEnvs...)
 
@@ -201,5 +215,8 @@ func BuildKubeSchedulerComponent(conf BuildKubeSchedulerComponentConfig) (compon
 		Ports:   ports,
 		WorkDir: conf.Workdir,
 		Envs:    envs,
+
+		ReadinessProbe: readinessProbe,
+		LivenessProbe:  livenessProbe,
 	}, nil
 }
@@ -128,6 +128,16 @@ func BuildKwokControllerComponent(conf BuildKwokControllerComponentConfig) (comp
 		kwokControllerArgs = append(kwokControllerArgs, "--enable-crds="+strings.Join(conf.EnableCRDs, ","))
 	}
 
+	var readinessProbe, livenessProbe *internalversion.Probe
+	probePort := conf.Port
+	if inContainer {
+		probePort = 10247
+	}
+	if probePort != 0 {
+		readinessProbe = httpGetReadinessProbe("/readyz", probePort, false)
+		livenessProbe = httpGetLivenessProbe("/livez", probePort, false)
+	}
+
 	envs := []internalversion.Env{}
 	envs = append(envs, conf.ExtraEnvs...)
 
@@ -145,5 +155,8 @@ func BuildKwokControllerComponent(conf BuildKwokControllerComponentConfig) (comp
 		Image:   conf.Image,
 		WorkDir: conf.Workdir,
 		Envs:    envs,
+
+		ReadinessProbe: readinessProbe,
+		LivenessProbe:  livenessProbe,
 	}
 }
@@ -89,6 +89,11 @@ func BuildPrometheusComponent(conf BuildPrometheusComponentConfig) (component in
 		prometheusArgs = append(prometheusArgs, "--log.level="+log.ToLogSeverityLevel(conf.Verbosity))
 	}
 
+	probePort := conf.Port
+	if inContainer {
+		probePort = 9090
+	}
+
 	envs := []internalversion.Env{}
 	envs = append(envs, conf.ExtraEnvs...)
 
@@ -110,5 +115,8 @@ func BuildPrometheusComponent(conf BuildPrometheusComponentConfig) (component in
 		Image:   conf.Image,
 		WorkDir: conf.Workdir,
 		Envs:    envs,
+
+		ReadinessProbe: httpGetReadinessProbe("/-/ready", probePort, false),
+		LivenessProbe:  httpGetLivenessProbe("/-/healthy", probePort, false),
 	}, nil
 }
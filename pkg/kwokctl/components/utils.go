@@ -31,7 +31,11 @@ var (
 )
 
 // GroupByLinks groups stages by links.
+// The links to the components not in the list are ignored, e.g. the etcd is external or the kube-scheduler is disabled.
 func GroupByLinks(components []internalversion.Component) ([][]internalversion.Component, error) {
+	names := sets.NewString(slices.Map(components, func(component internalversion.Component) string {
+		return component.Name
+	})...)
 	had := sets.NewString()
 	next := slices.Clone(components)
 	groups := [][]internalversion.Component{}
@@ -42,7 +46,8 @@ func GroupByLinks(components []internalversion.Component) ([][]internalversion.C
 		group := []internalversion.Component{}
 
 		for _, component := range current {
-			if len(component.Links) != 0 && !had.HasAll(component.Links...) {
+			links := slices.Filter(component.Links, names.Has)
+			if len(links) != 0 && !had.HasAll(links...) {
 				next = append(next, component)
 				continue
 			}
@@ -71,3 +76,38 @@ func extraArgsToStrings(args []internalversion.ExtraArgs) []string {
 		return fmt.Sprintf("--%s=%s", arg.Key, arg.Value)
 	})
 }
+
+// httpGetReadinessProbe returns the readiness probe of the http get to the path on the port the component listens on,
+// the timing is the same as the one of the static pods of kubeadm.
+func httpGetReadinessProbe(path string, port uint32, secure bool) *internalversion.Probe {
+	return &internalversion.Probe{
+		HTTPGet:          httpGetAction(path, port, secure),
+		PeriodSeconds:    1,
+		TimeoutSeconds:   15,
+		FailureThreshold: 3,
+	}
+}
+
+// httpGetLivenessProbe returns the liveness probe of the http get to the path on the port the component listens on,
+// the timing is the same as the one of the static pods of kubeadm.
+func httpGetLivenessProbe(path string, port uint32, secure bool) *internalversion.Probe {
+	return &internalversion.Probe{
+		HTTPGet:             httpGetAction(path, port, secure),
+		InitialDelaySeconds: 10,
+		PeriodSeconds:       10,
+		TimeoutSeconds:      15,
+		FailureThreshold:    8,
+	}
+}
+
+func httpGetAction(path string, port uint32, secure bool) *internalversion.HTTPGetAction {
+	scheme := internalversion.URISchemeHTTP
+	if secure {
+		scheme = internalversion.URISchemeHTTPS
+	}
+	return &internalversion.HTTPGetAction{
+		Path:   path,
+		Port:   port,
+		Scheme: scheme,
+	}
+}
@@ -74,6 +74,41 @@ func TestGroupByLinks(t *testing.T) {
 				{{Name: "prometheus", Links: []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler", "kwok-controller"}}},
 			},
 		},
+		{
+			name: "group by links without the linked components",
+			args: args{
+				components: []internalversion.Component{
+					{
+						Name:  "kube-apiserver",
+						Links: []string{"etcd"},
+					},
+					{
+						Name:  "prometheus",
+						Links: []string{"kube-apiserver", "kube-scheduler"},
+					},
+				},
+			},
+			want: [][]internalversion.Component{
+				{{Name: "kube-apiserver", Links: []string{"etcd"}}},
+				{{Name: "prometheus", Links: []string{"kube-apiserver", "kube-scheduler"}}},
+			},
+		},
+		{
+			name: "group by circular links",
+			args: args{
+				components: []internalversion.Component{
+					{
+						Name:  "a",
+						Links: []string{"b"},
+					},
+					{
+						Name:  "b",
+						Links: []string{"a"},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -18,12 +18,15 @@ package binary
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"os/user"
 	rt "runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nxadm/tail"
@@ -65,8 +68,13 @@ func (c *Cluster) Available(ctx context.Context) error {
 func (c *Cluster) download(ctx context.Context, env *env) error {
 	conf := &env.kwokctlConfig.Options
 
+	err := checkServerBinaries(conf)
+	if err != nil {
+		return err
+	}
+
 	kubeApiserverPath := c.GetBinPath(consts.ComponentKubeApiserver + conf.BinSuffix)
-	err := c.DownloadWithCache(ctx, conf.CacheDir, conf.KubeApiserverBinary, kubeApiserverPath, 0750, conf.QuietPull)
+	err = c.DownloadWithCache(ctx, conf.CacheDir, conf.KubeApiserverBinary, kubeApiserverPath, 0750, conf.QuietPull)
 	if err != nil {
 		return err
 	}
@@ -115,36 +123,81 @@ func (c *Cluster) download(ctx context.Context, env *env) error {
 		}
 	}
 
+	err = c.downloadPrometheus(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	err = c.downloadJaeger(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkServerBinaries checks the binaries of the control plane can be downloaded,
+// as the server binaries of Kubernetes are only released for linux, they have to be given on the other OS.
+func checkServerBinaries(conf *internalversion.KwokctlConfigurationOptions) error {
+	if rt.GOOS == "linux" {
+		return nil
+	}
+
+	binaries := []struct {
+		name     string
+		binary   string
+		disabled bool
+	}{
+		{consts.ComponentKubeApiserver, conf.KubeApiserverBinary, false},
+		{consts.ComponentKubeControllerManager, conf.KubeControllerManagerBinary, conf.DisableKubeControllerManager},
+		{consts.ComponentKubeScheduler, conf.KubeSchedulerBinary, conf.DisableKubeScheduler},
+	}
+	for _, b := range binaries {
+		if b.disabled || !strings.HasPrefix(b.binary, consts.KubeBinaryPrefix+"/") {
+			continue
+		}
+		return fmt.Errorf("the binary of %s is not released for %s, build it and give it with --%s-binary", b.name, rt.GOOS, b.name)
+	}
+	return nil
+}
+
+func (c *Cluster) downloadPrometheus(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+
 	if conf.PrometheusPort != 0 {
 		prometheusPath := c.GetBinPath(consts.ComponentPrometheus + conf.BinSuffix)
 		if conf.PrometheusBinary == "" {
-			err = c.DownloadWithCacheAndExtract(ctx, conf.CacheDir, conf.PrometheusBinaryTar, prometheusPath, consts.ComponentPrometheus+conf.BinSuffix, 0750, conf.QuietPull, true)
+			err := c.DownloadWithCacheAndExtract(ctx, conf.CacheDir, conf.PrometheusBinaryTar, prometheusPath, consts.ComponentPrometheus+conf.BinSuffix, 0750, conf.QuietPull, true)
 			if err != nil {
 				return err
 			}
 		} else {
-			err = c.DownloadWithCache(ctx, conf.CacheDir, conf.PrometheusBinary, prometheusPath, 0750, conf.QuietPull)
+			err := c.DownloadWithCache(ctx, conf.CacheDir, conf.PrometheusBinary, prometheusPath, 0750, conf.QuietPull)
 			if err != nil {
 				return err
 			}
 		}
 	}
+	return nil
+}
+
+func (c *Cluster) downloadJaeger(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
 
 	if conf.JaegerPort != 0 {
 		jaegerPath := c.GetBinPath("jaeger-all-in-one" + conf.BinSuffix)
 		if conf.JaegerBinary == "" {
-			err = c.DownloadWithCacheAndExtract(ctx, conf.CacheDir, conf.JaegerBinaryTar, jaegerPath, "jaeger-all-in-one"+conf.BinSuffix, 0750, conf.QuietPull, true)
+			err := c.DownloadWithCacheAndExtract(ctx, conf.CacheDir, conf.JaegerBinaryTar, jaegerPath, "jaeger-all-in-one"+conf.BinSuffix, 0750, conf.QuietPull, true)
 			if err != nil {
 				return err
 			}
 		} else {
-			err = c.DownloadWithCache(ctx, conf.CacheDir, conf.JaegerBinary, jaegerPath, 0750, conf.QuietPull)
+			err := c.DownloadWithCache(ctx, conf.CacheDir, conf.JaegerBinary, jaegerPath, 0750, conf.QuietPull)
 			if err != nil {
 				return err
 			}
 		}
 	}
-
 	return nil
 }
 
@@ -186,6 +239,14 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 		if err != nil {
 			return err
 		}
+
+		if conf.KubeAuditWebhook != "" {
+			auditWebhookPath := c.GetWorkdirPath(runtime.AuditWebhookName)
+			err = c.CopyFile(conf.KubeAuditWebhook, auditWebhookPath)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	etcdDataPath := c.GetWorkdirPath(runtime.EtcdDataDirName)
@@ -197,10 +258,32 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 	return nil
 }
 
+// setupCustomComponents downloads the binaries of the custom components declared in the configuration
+func (c *Cluster) setupCustomComponents(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+
+	for i, component := range env.kwokctlConfig.Components {
+		if component.Binary == "" {
+			return fmt.Errorf("the binary of the custom component %s is required in the binary runtime", component.Name)
+		}
+		binaryPath := c.GetBinPath(component.Name + conf.BinSuffix)
+		err := c.DownloadWithCache(ctx, conf.CacheDir, component.Binary, binaryPath, 0750, conf.QuietPull)
+		if err != nil {
+			return err
+		}
+		component.Binary = binaryPath
+		if component.WorkDir == "" {
+			component.WorkDir = env.workdir
+		}
+		env.kwokctlConfig.Components[i] = component
+	}
+	return nil
+}
+
 func (c *Cluster) setupPorts(ctx context.Context, ports ...*uint32) error {
 	for _, port := range ports {
 		if port != nil && *port == 0 {
-			p, err := net.GetUnusedPort(ctx)
+			p, err := c.GetUnusedPort(ctx)
 			if err != nil {
 				return err
 			}
@@ -211,19 +294,20 @@ func (c *Cluster) setupPorts(ctx context.Context, ports ...*uint32) error {
 }
 
 type env struct {
-	kwokctlConfig   *internalversion.KwokctlConfiguration
-	verbosity       log.Level
-	kubeconfigPath  string
-	etcdDataPath    string
-	kwokConfigPath  string
-	pkiPath         string
-	auditLogPath    string
-	auditPolicyPath string
-	workdir         string
-	caCertPath      string
-	adminKeyPath    string
-	adminCertPath   string
-	scheme          string
+	kwokctlConfig    *internalversion.KwokctlConfiguration
+	verbosity        log.Level
+	kubeconfigPath   string
+	etcdDataPath     string
+	kwokConfigPath   string
+	pkiPath          string
+	auditLogPath     string
+	auditPolicyPath  string
+	auditWebhookPath string
+	workdir          string
+	caCertPath       string
+	adminKeyPath     string
+	adminCertPath    string
+	scheme           string
 }
 
 func (c *Cluster) env(ctx context.Context) (*env, error) {
@@ -248,29 +332,34 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 	adminCertPath := path.Join(pkiPath, "admin.crt")
 	auditLogPath := ""
 	auditPolicyPath := ""
+	auditWebhookPath := ""
 
 	if config.Options.KubeAuditPolicy != "" {
 		auditLogPath = c.GetLogPath(runtime.AuditLogName)
 		auditPolicyPath = c.GetWorkdirPath(runtime.AuditPolicyName)
+		if config.Options.KubeAuditWebhook != "" {
+			auditWebhookPath = c.GetWorkdirPath(runtime.AuditWebhookName)
+		}
 	}
 
 	logger := log.FromContext(ctx)
 	verbosity := logger.Level()
 
 	return &env{
-		kwokctlConfig:   config,
-		verbosity:       verbosity,
-		kubeconfigPath:  kubeconfigPath,
-		etcdDataPath:    etcdDataPath,
-		kwokConfigPath:  kwokConfigPath,
-		pkiPath:         pkiPath,
-		auditLogPath:    auditLogPath,
-		auditPolicyPath: auditPolicyPath,
-		workdir:         workdir,
-		caCertPath:      caCertPath,
-		adminKeyPath:    adminKeyPath,
-		adminCertPath:   adminCertPath,
-		scheme:          scheme,
+		kwokctlConfig:    config,
+		verbosity:        verbosity,
+		kubeconfigPath:   kubeconfigPath,
+		etcdDataPath:     etcdDataPath,
+		kwokConfigPath:   kwokConfigPath,
+		pkiPath:          pkiPath,
+		auditLogPath:     auditLogPath,
+		auditPolicyPath:  auditPolicyPath,
+		auditWebhookPath: auditWebhookPath,
+		workdir:          workdir,
+		caCertPath:       caCertPath,
+		adminKeyPath:     adminKeyPath,
+		adminCertPath:    adminCertPath,
+		scheme:           scheme,
 	}, nil
 }
 
@@ -303,6 +392,11 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.setupCustomComponents(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.setup(ctx, env)
 	if err != nil {
 		return err
@@ -318,9 +412,11 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
-	err = c.addEtcd(ctx, env)
-	if err != nil {
-		return err
+	if len(env.kwokctlConfig.Options.EtcdServers) == 0 {
+		err = c.addEtcd(ctx, env)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = c.addKubeApiserver(ctx, env)
@@ -374,17 +470,19 @@ func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 
 	etcdComponentPatches := runtime.GetComponentPatches(env.kwokctlConfig, consts.ComponentEtcd)
 	etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
-		Workdir:      env.workdir,
-		Binary:       etcdPath,
-		Version:      etcdVersion,
-		BindAddress:  conf.BindAddress,
-		DataPath:     env.etcdDataPath,
-		Port:         conf.EtcdPort,
-		PeerPort:     conf.EtcdPeerPort,
-		Verbosity:    env.verbosity,
-		ExtraArgs:    etcdComponentPatches.ExtraArgs,
-		ExtraVolumes: etcdComponentPatches.ExtraVolumes,
-		ExtraEnvs:    etcdComponentPatches.ExtraEnvs,
+		Workdir:                 env.workdir,
+		Binary:                  etcdPath,
+		Version:                 etcdVersion,
+		BindAddress:             conf.BindAddress,
+		DataPath:                env.etcdDataPath,
+		Port:                    conf.EtcdPort,
+		PeerPort:                conf.EtcdPeerPort,
+		Verbosity:               env.verbosity,
+		AutoCompactionMode:      conf.EtcdAutoCompactionMode,
+		AutoCompactionRetention: conf.EtcdAutoCompactionRetention,
+		ExtraArgs:               etcdComponentPatches.ExtraArgs,
+		ExtraVolumes:            etcdComponentPatches.ExtraVolumes,
+		ExtraEnvs:               etcdComponentPatches.ExtraEnvs,
 	})
 	if err != nil {
 		return err
@@ -428,36 +526,39 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 	}
 
 	kubeApiserverComponentPatches := runtime.GetComponentPatches(env.kwokctlConfig, consts.ComponentKubeApiserver)
-	kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
-		Workdir:           env.workdir,
-		Binary:            kubeApiserverPath,
-		Version:           kubeApiserverVersion,
-		BindAddress:       conf.BindAddress,
-		Port:              conf.KubeApiserverPort,
-		EtcdAddress:       net.LocalAddress,
-		EtcdPort:          conf.EtcdPort,
-		KubeRuntimeConfig: conf.KubeRuntimeConfig,
-		KubeFeatureGates:  conf.KubeFeatureGates,
-		SecurePort:        conf.SecurePort,
-		KubeAuthorization: conf.KubeAuthorization,
-		KubeAdmission:     conf.KubeAdmission,
-		AuditPolicyPath:   env.auditPolicyPath,
-		AuditLogPath:      env.auditLogPath,
-		CaCertPath:        env.caCertPath,
-		AdminCertPath:     env.adminCertPath,
-		AdminKeyPath:      env.adminKeyPath,
-		Verbosity:         env.verbosity,
-		DisableQPSLimits:  conf.DisableQPSLimits,
-		TracingConfigPath: kubeApiserverTracingConfigPath,
-		ExtraArgs:         kubeApiserverComponentPatches.ExtraArgs,
-		ExtraVolumes:      kubeApiserverComponentPatches.ExtraVolumes,
-		ExtraEnvs:         kubeApiserverComponentPatches.ExtraEnvs,
+	return c.addReplicas(ctx, env, conf.KubeApiserverPort, conf.KubeApiserverReplicas, func(port uint32) (internalversion.Component, error) {
+		return components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
+			Workdir:           env.workdir,
+			Binary:            kubeApiserverPath,
+			Version:           kubeApiserverVersion,
+			BindAddress:       conf.BindAddress,
+			Port:              port,
+			EtcdAddress:       net.LocalAddress,
+			EtcdPort:          conf.EtcdPort,
+			EtcdServers:       conf.EtcdServers,
+			EtcdCaFile:        conf.EtcdCaFile,
+			EtcdCertFile:      conf.EtcdCertFile,
+			EtcdKeyFile:       conf.EtcdKeyFile,
+			EtcdPrefix:        conf.EtcdPrefix,
+			KubeRuntimeConfig: conf.KubeRuntimeConfig,
+			KubeFeatureGates:  conf.KubeFeatureGates,
+			SecurePort:        conf.SecurePort,
+			KubeAuthorization: conf.KubeAuthorization,
+			KubeAdmission:     conf.KubeAdmission,
+			AuditPolicyPath:   env.auditPolicyPath,
+			AuditLogPath:      env.auditLogPath,
+			AuditWebhookPath:  env.auditWebhookPath,
+			CaCertPath:        env.caCertPath,
+			AdminCertPath:     env.adminCertPath,
+			AdminKeyPath:      env.adminKeyPath,
+			Verbosity:         env.verbosity,
+			DisableQPSLimits:  conf.DisableQPSLimits,
+			TracingConfigPath: kubeApiserverTracingConfigPath,
+			ExtraArgs:         kubeApiserverComponentPatches.ExtraArgs,
+			ExtraVolumes:      kubeApiserverComponentPatches.ExtraVolumes,
+			ExtraEnvs:         kubeApiserverComponentPatches.ExtraEnvs,
+		})
 	})
-	if err != nil {
-		return err
-	}
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
-	return nil
 }
 
 func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err error) {
@@ -480,31 +581,32 @@ func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err e
 		}
 
 		kubeControllerManagerPatches := runtime.GetComponentPatches(env.kwokctlConfig, consts.ComponentKubeControllerManager)
-		kubeControllerManagerComponent, err := components.BuildKubeControllerManagerComponent(components.BuildKubeControllerManagerComponentConfig{
-			Workdir:                            env.workdir,
-			Binary:                             kubeControllerManagerPath,
-			Version:                            kubeControllerManagerVersion,
-			BindAddress:                        conf.BindAddress,
-			Port:                               conf.KubeControllerManagerPort,
-			SecurePort:                         conf.SecurePort,
-			CaCertPath:                         env.caCertPath,
-			AdminCertPath:                      env.adminCertPath,
-			AdminKeyPath:                       env.adminKeyPath,
-			KubeAuthorization:                  conf.KubeAuthorization,
-			KubeconfigPath:                     env.kubeconfigPath,
-			KubeFeatureGates:                   conf.KubeFeatureGates,
-			NodeMonitorPeriodMilliseconds:      conf.KubeControllerManagerNodeMonitorPeriodMilliseconds,
-			NodeMonitorGracePeriodMilliseconds: conf.KubeControllerManagerNodeMonitorGracePeriodMilliseconds,
-			Verbosity:                          env.verbosity,
-			DisableQPSLimits:                   conf.DisableQPSLimits,
-			ExtraArgs:                          kubeControllerManagerPatches.ExtraArgs,
-			ExtraVolumes:                       kubeControllerManagerPatches.ExtraVolumes,
-			ExtraEnvs:                          kubeControllerManagerPatches.ExtraEnvs,
+		err = c.addReplicas(ctx, env, conf.KubeControllerManagerPort, conf.KubeControllerManagerReplicas, func(port uint32) (internalversion.Component, error) {
+			return components.BuildKubeControllerManagerComponent(components.BuildKubeControllerManagerComponentConfig{
+				Workdir:                            env.workdir,
+				Binary:                             kubeControllerManagerPath,
+				Version:                            kubeControllerManagerVersion,
+				BindAddress:                        conf.BindAddress,
+				Port:                               port,
+				SecurePort:                         conf.SecurePort,
+				CaCertPath:                         env.caCertPath,
+				AdminCertPath:                      env.adminCertPath,
+				AdminKeyPath:                       env.adminKeyPath,
+				KubeAuthorization:                  conf.KubeAuthorization,
+				KubeconfigPath:                     env.kubeconfigPath,
+				KubeFeatureGates:                   conf.KubeFeatureGates,
+				NodeMonitorPeriodMilliseconds:      conf.KubeControllerManagerNodeMonitorPeriodMilliseconds,
+				NodeMonitorGracePeriodMilliseconds: conf.KubeControllerManagerNodeMonitorGracePeriodMilliseconds,
+				Verbosity:                          env.verbosity,
+				DisableQPSLimits:                   conf.DisableQPSLimits,
+				ExtraArgs:                          kubeControllerManagerPatches.ExtraArgs,
+				ExtraVolumes:                       kubeControllerManagerPatches.ExtraVolumes,
+				ExtraEnvs:                          kubeControllerManagerPatches.ExtraEnvs,
+			})
 		})
 		if err != nil {
 			return err
 		}
-		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeControllerManagerComponent)
 	}
 	return nil
 }
@@ -538,29 +640,54 @@ func (c *Cluster) addKubeScheduler(ctx context.Context, env *env) (err error) {
 		}
 
 		kubeSchedulerComponentPatches := runtime.GetComponentPatches(env.kwokctlConfig, consts.ComponentKubeScheduler)
-		kubeSchedulerComponent, err := components.BuildKubeSchedulerComponent(components.BuildKubeSchedulerComponentConfig{
-			Workdir:          env.workdir,
-			Binary:           kubeSchedulerPath,
-			Version:          kubeSchedulerVersion,
-			BindAddress:      conf.BindAddress,
-			Port:             conf.KubeSchedulerPort,
-			SecurePort:       conf.SecurePort,
-			CaCertPath:       env.caCertPath,
-			AdminCertPath:    env.adminCertPath,
-			AdminKeyPath:     env.adminKeyPath,
-			ConfigPath:       schedulerConfigPath,
-			KubeconfigPath:   env.kubeconfigPath,
-			KubeFeatureGates: conf.KubeFeatureGates,
-			Verbosity:        env.verbosity,
-			DisableQPSLimits: conf.DisableQPSLimits,
-			ExtraArgs:        kubeSchedulerComponentPatches.ExtraArgs,
-			ExtraVolumes:     kubeSchedulerComponentPatches.ExtraVolumes,
-			ExtraEnvs:        kubeSchedulerComponentPatches.ExtraEnvs,
+		err = c.addReplicas(ctx, env, conf.KubeSchedulerPort, conf.KubeSchedulerReplicas, func(port uint32) (internalversion.Component, error) {
+			return components.BuildKubeSchedulerComponent(components.BuildKubeSchedulerComponentConfig{
+				Workdir:          env.workdir,
+				Binary:           kubeSchedulerPath,
+				Version:          kubeSchedulerVersion,
+				BindAddress:      conf.BindAddress,
+				Port:             port,
+				SecurePort:       conf.SecurePort,
+				CaCertPath:       env.caCertPath,
+				AdminCertPath:    env.adminCertPath,
+				AdminKeyPath:     env.adminKeyPath,
+				ConfigPath:       schedulerConfigPath,
+				KubeconfigPath:   env.kubeconfigPath,
+				KubeFeatureGates: conf.KubeFeatureGates,
+				Verbosity:        env.verbosity,
+				DisableQPSLimits: conf.DisableQPSLimits,
+				ExtraArgs:        kubeSchedulerComponentPatches.ExtraArgs,
+				ExtraVolumes:     kubeSchedulerComponentPatches.ExtraVolumes,
+				ExtraEnvs:        kubeSchedulerComponentPatches.ExtraEnvs,
+			})
 		})
 		if err != nil {
 			return err
 		}
-		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeSchedulerComponent)
+	}
+	return nil
+}
+
+// addReplicas adds the component built with the given port, and its replicas built with the random ports,
+// the replicas are named <name>-<index> and run the same binary.
+func (c *Cluster) addReplicas(ctx context.Context, env *env, port uint32, replicas uint32, build func(port uint32) (internalversion.Component, error)) error {
+	component, err := build(port)
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, component)
+
+	for i := uint32(1); i < replicas; i++ {
+		port, err := c.GetUnusedPort(ctx)
+		if err != nil {
+			return err
+		}
+		replica, err := build(port)
+		if err != nil {
+			return err
+		}
+		replica.Name = fmt.Sprintf("%s-%d", replica.Name, i)
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, replica)
 	}
 	return nil
 }
@@ -594,6 +721,7 @@ func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
 		NodeLeaseDurationSeconds: conf.NodeLeaseDurationSeconds,
 		EnableCRDs:               conf.EnableCRDs,
 		ExtraArgs:                kwokControllerComponentPatches.ExtraArgs,
+		ExtraVolumes:             kwokControllerComponentPatches.ExtraVolumes,
 		ExtraEnvs:                kwokControllerComponentPatches.ExtraEnvs,
 	})
 	if err != nil {
@@ -680,6 +808,7 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) error {
 			Verbosity:    env.verbosity,
 			ExtraArgs:    jaegerComponentPatches.ExtraArgs,
 			ExtraVolumes: jaegerComponentPatches.ExtraVolumes,
+			ExtraEnvs:    jaegerComponentPatches.ExtraEnvs,
 		})
 		if err != nil {
 			return err
@@ -732,7 +861,7 @@ func (c *Cluster) Uninstall(ctx context.Context) error {
 }
 
 func (c *Cluster) isRunning(ctx context.Context, component internalversion.Component) bool {
-	return c.ForkExecIsRunning(ctx, component.WorkDir, component.Binary)
+	return c.ForkExecIsRunning(ctx, component.WorkDir, component.Name)
 }
 
 func (c *Cluster) startComponent(ctx context.Context, component internalversion.Component) error {
@@ -743,8 +872,18 @@ func (c *Cluster) startComponent(ctx context.Context, component internalversion.
 		return nil
 	}
 
-	if len(component.Envs) > 0 {
-		ctx = exec.WithEnv(ctx, slices.Map(component.Envs, func(c internalversion.Env) string {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	envs, err := resourceLimitsEnvs(&config.Options, component.Envs)
+	if err != nil {
+		return err
+	}
+	envs = append(envs, component.Envs...)
+
+	if len(envs) > 0 {
+		ctx = exec.WithEnv(ctx, slices.Map(envs, func(c internalversion.Env) string {
 			return fmt.Sprintf("%s=%s", c.Name, c.Value)
 		}))
 	}
@@ -765,8 +904,45 @@ func (c *Cluster) startComponent(ctx context.Context, component internalversion.
 		ctx = exec.WithUser(ctx, &uid, &gid)
 	}
 
+	restartBackoff, err := runtime.GetComponentsRestartBackoff(&config.Options)
+	if err != nil {
+		return err
+	}
+
 	logger.Debug("Starting component")
-	return c.ForkExec(ctx, component.WorkDir, component.Binary, component.Args...)
+	return c.ForkExecWithRestart(ctx, component.WorkDir, component.Name, restartBackoff, runtime.NewLivenessProbe(component), component.Binary, component.Args...)
+}
+
+// resourceLimitsEnvs returns the environment variables limiting the resources of the Go processes of the components,
+// the processes are not capped by the OS, so GOMAXPROCS limits the CPU, and GOMEMLIMIT is the soft limit of the memory.
+// The ones already set by the component are not overridden.
+func resourceLimitsEnvs(conf *internalversion.KwokctlConfigurationOptions, componentEnvs []internalversion.Env) ([]internalversion.Env, error) {
+	cpu, memory, err := runtime.GetComponentsResourceLimits(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	has := func(name string) bool {
+		_, ok := slices.Find(componentEnvs, func(env internalversion.Env) bool {
+			return env.Name == name
+		})
+		return ok
+	}
+
+	envs := []internalversion.Env{}
+	if cpu != 0 && !has("GOMAXPROCS") {
+		envs = append(envs, internalversion.Env{
+			Name:  "GOMAXPROCS",
+			Value: format.String(int(math.Ceil(cpu))),
+		})
+	}
+	if memory != 0 && !has("GOMEMLIMIT") {
+		envs = append(envs, internalversion.Env{
+			Name:  "GOMEMLIMIT",
+			Value: format.String(memory),
+		})
+	}
+	return envs, nil
 }
 
 func (c *Cluster) startComponents(ctx context.Context) error {
@@ -787,7 +963,7 @@ func (c *Cluster) stopComponent(ctx context.Context, component internalversion.C
 		return nil
 	}
 	logger.Debug("Stopping component")
-	return c.ForkExecKill(ctx, component.WorkDir, component.Binary)
+	return c.ForkExecKill(ctx, component.WorkDir, component.Name)
 }
 
 func (c *Cluster) stopComponents(ctx context.Context) error {
@@ -922,6 +1098,141 @@ func (c *Cluster) StopComponent(ctx context.Context, name string) error {
 	return nil
 }
 
+// UpgradeComponent replaces the binary of the component with the one in the options, and restarts it
+func (c *Cluster) UpgradeComponent(ctx context.Context, name string) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &config.Options
+
+	src, _, err := runtime.UpgradeSource(conf, name)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(config.Components, func(component internalversion.Component) bool {
+		return component.Name == name
+	})
+	if index < 0 {
+		return fmt.Errorf("%w: %s", runtime.ErrComponentNotFound, name)
+	}
+	component := config.Components[index]
+
+	err = c.stopComponent(ctx, component)
+	if err != nil {
+		return fmt.Errorf("failed to stop %s: %w", name, err)
+	}
+
+	// The replicas share the binary, the running ones keep the old one until they are restarted
+	err = c.Remove(component.Binary)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	err = c.DownloadWithCache(ctx, conf.CacheDir, src, component.Binary, 0750, conf.QuietPull)
+	if err != nil {
+		return err
+	}
+	component.Version = conf.KubeVersion
+	config.Components[index] = component
+
+	err = c.startComponent(ctx, component)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	return c.Save(ctx)
+}
+
+// InstallComponent adds the optional component to the cluster and starts it
+func (c *Cluster) InstallComponent(ctx context.Context, name string) error {
+	env, err := c.env(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &env.kwokctlConfig.Options
+
+	port, err := runtime.OptionalComponentPort(conf, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.GetComponent(ctx, name)
+	if err == nil {
+		return fmt.Errorf("component %s is already installed", name)
+	}
+	if !errors.Is(err, runtime.ErrComponentNotFound) {
+		return err
+	}
+
+	err = c.setupPorts(ctx, port)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case consts.ComponentPrometheus:
+		err = c.downloadPrometheus(ctx, env)
+		if err != nil {
+			return err
+		}
+		err = c.addPrometheus(ctx, env)
+		if err != nil {
+			return err
+		}
+	case consts.ComponentJaeger:
+		err = c.downloadJaeger(ctx, env)
+		if err != nil {
+			return err
+		}
+		err = c.addJaeger(ctx, env)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("component %s is not supported by the binary runtime", name)
+	}
+
+	err = c.SetConfig(ctx, env.kwokctlConfig)
+	if err != nil {
+		return err
+	}
+	err = c.Save(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.StartComponent(ctx, name)
+}
+
+// UninstallComponent stops the optional component and removes it from the cluster
+func (c *Cluster) UninstallComponent(ctx context.Context, name string) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	port, err := runtime.OptionalComponentPort(&config.Options, name)
+	if err != nil {
+		return err
+	}
+
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = c.stopComponent(ctx, component)
+	if err != nil {
+		return fmt.Errorf("failed to stop %s: %w", name, err)
+	}
+
+	config.Components = slices.Filter(config.Components, func(component internalversion.Component) bool {
+		return component.Name != name
+	})
+	*port = 0
+	return c.Save(ctx)
+}
+
 // Logs returns the logs of the specified component.
 func (c *Cluster) Logs(ctx context.Context, name string, out io.Writer) error {
 	_, err := c.GetComponent(ctx, name)
@@ -1032,7 +1343,7 @@ func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
 	if err != nil {
 		return err
 	}
-	_, err = f.Write([]byte(fmt.Sprintf("%s/%s", rt.GOOS, rt.GOARCH)))
+	_, err = f.Write([]byte(fmt.Sprintf("%s/%s", rt.GOOS, conf.Options.Arch)))
 	if err != nil {
 		return err
 	}
@@ -1058,6 +1369,24 @@ func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
 	return nil
 }
 
+// ComponentStartedAt return the time the component of cluster was started at, zero if it is not running
+func (c *Cluster) ComponentStartedAt(ctx context.Context, name string) (time.Time, error) {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.ForkExecStartedAt(ctx, component.WorkDir, component.Name), nil
+}
+
+// ComponentRestarts return the number of times the component of cluster has been restarted after crashes
+func (c *Cluster) ComponentRestarts(ctx context.Context, name string) (int, error) {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	return c.ForkExecRestarts(ctx, component.WorkDir, component.Name)
+}
+
 // ListBinaries list binaries in the cluster
 func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
 	config, err := c.Config(ctx)
@@ -1082,6 +1411,16 @@ func (c *Cluster) ListImages(ctx context.Context) ([]string, error) {
 	return []string{}, nil
 }
 
+// SaveImages save the images of the cluster to the archive
+func (c *Cluster) SaveImages(ctx context.Context, archive string) error {
+	return fmt.Errorf("binary runtime does not support images")
+}
+
+// LoadImages load the images from the archive
+func (c *Cluster) LoadImages(ctx context.Context, archive string) error {
+	return fmt.Errorf("binary runtime does not support images")
+}
+
 // EtcdctlInCluster implements the ectdctl subcommand
 func (c *Cluster) EtcdctlInCluster(ctx context.Context, args ...string) error {
 	config, err := c.Config(ctx)
@@ -1092,6 +1431,20 @@ func (c *Cluster) EtcdctlInCluster(ctx context.Context, args ...string) error {
 	return c.Etcdctl(ctx, append([]string{"--endpoints", net.LocalAddress + ":" + format.String(conf.EtcdPort)}, args...)...)
 }
 
+// InitCRDs initializes the CRDs and registers the metrics APIs served by the kwok-controller on the host
+func (c *Cluster) InitCRDs(ctx context.Context) error {
+	err := c.Cluster.InitCRDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	return c.InitMetricsAPIService(ctx, "localhost", config.Options.KwokControllerPort)
+}
+
 // Ready returns true if the cluster is ready
 func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 	config, err := c.Config(ctx)
@@ -1108,6 +1461,25 @@ func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 	return c.Cluster.Ready(ctx)
 }
 
+// ComponentReady returns true if the component is ready
+func (c *Cluster) ComponentReady(ctx context.Context, name string) (bool, error) {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if !c.isRunning(ctx, component) {
+		return false, nil
+	}
+	if name == consts.ComponentKubeApiserver {
+		return c.Cluster.Ready(ctx)
+	}
+	err = runtime.ProbeComponent(ctx, component, component.ReadinessProbe)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // WaitReady waits for the cluster to be ready.
 func (c *Cluster) WaitReady(ctx context.Context, timeout time.Duration) error {
 	if c.IsDryRun() {
@@ -18,6 +18,7 @@ package binary
 
 import (
 	"context"
+	"errors"
 
 	"sigs.k8s.io/kwok/pkg/consts"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
@@ -25,9 +26,19 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
+var errExternalEtcdSnapshot = errors.New("the snapshot of the external etcd is not supported")
+
 // SnapshotSave save the snapshot of cluster
 func (c *Cluster) SnapshotSave(ctx context.Context, path string) error {
-	err := c.EtcdctlInCluster(ctx, "snapshot", "save", path)
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if len(config.Options.EtcdServers) != 0 {
+		return errExternalEtcdSnapshot
+	}
+
+	err = c.EtcdctlInCluster(ctx, "snapshot", "save", path)
 	if err != nil {
 		return err
 	}
@@ -37,9 +48,17 @@ func (c *Cluster) SnapshotSave(ctx context.Context, path string) error {
 
 // SnapshotRestore restore the snapshot of cluster
 func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if len(config.Options.EtcdServers) != 0 {
+		return errExternalEtcdSnapshot
+	}
+
 	logger := log.FromContext(ctx)
 
-	err := c.StopComponent(ctx, consts.ComponentEtcd)
+	err = c.StopComponent(ctx, consts.ComponentEtcd)
 	if err != nil {
 		logger.Error("Failed to stop etcd", err)
 	}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func Test_resourceLimitsEnvs(t *testing.T) {
+	tests := []struct {
+		name          string
+		cpu           string
+		memory        string
+		componentEnvs []internalversion.Env
+		want          []internalversion.Env
+		wantErr       bool
+	}{
+		{
+			name: "no limits",
+			want: []internalversion.Env{},
+		},
+		{
+			name:   "limits",
+			cpu:    "1500m",
+			memory: "1Gi",
+			want: []internalversion.Env{
+				{Name: "GOMAXPROCS", Value: "2"},
+				{Name: "GOMEMLIMIT", Value: "1073741824"},
+			},
+		},
+		{
+			name:   "set by the component",
+			cpu:    "2",
+			memory: "1Gi",
+			componentEnvs: []internalversion.Env{
+				{Name: "GOMAXPROCS", Value: "4"},
+			},
+			want: []internalversion.Env{
+				{Name: "GOMEMLIMIT", Value: "1073741824"},
+			},
+		},
+		{
+			name:    "invalid",
+			cpu:     "two",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resourceLimitsEnvs(&internalversion.KwokctlConfigurationOptions{
+				ComponentsCPULimit:    tt.cpu,
+				ComponentsMemoryLimit: tt.memory,
+			}, tt.componentEnvs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resourceLimitsEnvs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected envs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+)
+
+// ParseCacheMaxSize returns the bytes of the maximum size of the cache.
+func ParseCacheMaxSize(s string) (int64, error) {
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid maximum size of the cache %q: %w", s, err)
+	}
+	return q.Value(), nil
+}
+
+// CacheInUse returns the files of the cache linked by the clusters, which are not pruned.
+func CacheInUse() (sets.Set[string], error) {
+	inUse := sets.New[string]()
+	err := filepath.WalkDir(config.ClustersDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+		link, err := os.Readlink(p)
+		if err != nil {
+			return err
+		}
+		inUse.Insert(filepath.Clean(link))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inUse, nil
+}
+
+// CacheToPrune returns the least recently used files of the cache not used by the clusters,
+// which are removed to make the cache not larger than the maxSize.
+func CacheToPrune(cacheDir string, maxSize int64) ([]file.CacheEntry, error) {
+	entries, err := file.ListCache(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	inUse, err := CacheInUse()
+	if err != nil {
+		return nil, err
+	}
+	return file.PruneCache(entries, maxSize, func(entry file.CacheEntry) bool {
+		return inUse.Has(entry.Path)
+	}), nil
+}
+
+// pruneCache removes the least recently used files once the cache is larger than the maximum size.
+func (c *Cluster) pruneCache(ctx context.Context, cacheDir string) error {
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if conf.Options.CacheMaxSize == "" {
+		return nil
+	}
+	maxSize, err := ParseCacheMaxSize(conf.Options.CacheMaxSize)
+	if err != nil {
+		return err
+	}
+	entries, err := CacheToPrune(cacheDir, maxSize)
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	for _, entry := range entries {
+		removed, err := file.RemoveCache(entry)
+		if err != nil {
+			return err
+		}
+		if removed {
+			logger.Debug("Removed the least recently used cache", "path", entry.Path)
+		}
+	}
+	return nil
+}
@@ -60,8 +60,11 @@ var (
 	DashboardDeploy         = "dashboard-deployment.yaml"
 	PrometheusDeploy        = "prometheus-deployment.yaml"
 	JaegerDeploy            = "jaeger-deployment.yaml"
+	GrafanaDeploy           = "grafana-deployment.yaml"
+	GrafanaProvisioningName = "grafana"
 	AuditPolicyName         = "audit.yaml"
 	AuditLogName            = "audit.log"
+	AuditWebhookName        = "audit-webhook.yaml"
 	SchedulerConfigName     = "scheduler.yaml"
 	ApiserverTracingConfig  = "apiserver-tracing-config.yaml"
 
@@ -214,6 +217,16 @@ func (c *Cluster) Save(ctx context.Context) error {
 		objs = appendIntoInternalObjects(objs, stages...)
 	}
 
+	if !slices.Contains(conf.Options.EnableCRDs, v1alpha1.ResourceUsageKind) {
+		resourceUsages := config.FilterWithTypeFromContext[*internalversion.ResourceUsage](ctx)
+		objs = appendIntoInternalObjects(objs, resourceUsages...)
+	}
+
+	if !slices.Contains(conf.Options.EnableCRDs, v1alpha1.ClusterResourceUsageKind) {
+		clusterResourceUsages := config.FilterWithTypeFromContext[*internalversion.ClusterResourceUsage](ctx)
+		objs = appendIntoInternalObjects(objs, clusterResourceUsages...)
+	}
+
 	if !slices.Contains(conf.Options.EnableCRDs, v1alpha1.AttachKind) {
 		stages := config.FilterWithTypeFromContext[*internalversion.Attach](ctx)
 		objs = appendIntoInternalObjects(objs, stages...)
@@ -325,6 +338,13 @@ func (c *Cluster) Install(ctx context.Context) error {
 
 // Uninstall uninstalls the cluster.
 func (c *Cluster) Uninstall(ctx context.Context) error {
+	if !c.IsDryRun() {
+		err := c.releasePorts(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	// cleanup workdir
 	return c.RemoveAll(c.Workdir())
 }
@@ -348,6 +368,12 @@ func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
+// ComponentRestarts returns the number of times the component has been restarted after crashes,
+// it is always zero for the runtimes not restarting the components.
+func (c *Cluster) ComponentRestarts(ctx context.Context, name string) (int, error) {
+	return 0, nil
+}
+
 // WaitReady waits for the cluster to be ready.
 func (c *Cluster) WaitReady(ctx context.Context, timeout time.Duration) error {
 	var (
@@ -565,14 +591,16 @@ func (c *Cluster) InitCRDs(ctx context.Context) error {
 }
 
 var crdDefines = map[string][]byte{
-	v1alpha1.StageKind:              crd.Stage,
-	v1alpha1.AttachKind:             crd.Attach,
-	v1alpha1.ClusterAttachKind:      crd.ClusterAttach,
-	v1alpha1.ExecKind:               crd.Exec,
-	v1alpha1.ClusterExecKind:        crd.ClusterExec,
-	v1alpha1.PortForwardKind:        crd.PortForward,
-	v1alpha1.ClusterPortForwardKind: crd.ClusterPortForward,
-	v1alpha1.LogsKind:               crd.Logs,
-	v1alpha1.ClusterLogsKind:        crd.ClusterLogs,
-	v1alpha1.MetricKind:             crd.Metric,
+	v1alpha1.StageKind:                crd.Stage,
+	v1alpha1.AttachKind:               crd.Attach,
+	v1alpha1.ClusterAttachKind:        crd.ClusterAttach,
+	v1alpha1.ExecKind:                 crd.Exec,
+	v1alpha1.ClusterExecKind:          crd.ClusterExec,
+	v1alpha1.PortForwardKind:          crd.PortForward,
+	v1alpha1.ClusterPortForwardKind:   crd.ClusterPortForward,
+	v1alpha1.LogsKind:                 crd.Logs,
+	v1alpha1.ClusterLogsKind:          crd.ClusterLogs,
+	v1alpha1.MetricKind:               crd.Metric,
+	v1alpha1.ResourceUsageKind:        crd.ResourceUsage,
+	v1alpha1.ClusterResourceUsageKind: crd.ClusterResourceUsage,
 }
@@ -18,13 +18,20 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/containerd/containerd"
+	gocni "github.com/containerd/go-cni"
+	dockerclient "github.com/docker/docker/client"
+
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/consts"
 	"sigs.k8s.io/kwok/pkg/kwokctl/components"
@@ -32,13 +39,17 @@ import (
 	"sigs.k8s.io/kwok/pkg/kwokctl/k8s"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
 	"sigs.k8s.io/kwok/pkg/utils/envs"
 	"sigs.k8s.io/kwok/pkg/utils/exec"
 	"sigs.k8s.io/kwok/pkg/utils/file"
 	"sigs.k8s.io/kwok/pkg/utils/format"
 	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/net"
 	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/version"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 	"sigs.k8s.io/kwok/pkg/utils/yaml"
 )
@@ -54,6 +65,15 @@ type Cluster struct {
 	composeCommands []string
 
 	canNerdctlUnlessStopped *bool
+
+	containerdCli *containerd.Client
+	cni           gocni.CNI
+
+	hostClientset client.Clientset
+
+	dockerCliOnce sync.Once
+	dockerCliOpts []dockerclient.Opt
+	dockerCliErr  error
 }
 
 // NewPodmanCluster creates a new Runtime for podman.
@@ -72,6 +92,22 @@ func NewNerdctlCluster(name, workdir string) (runtime.Runtime, error) {
 	}, nil
 }
 
+// NewContainerdCluster creates a new Runtime for containerd, which is driven by its client instead of nerdctl.
+func NewContainerdCluster(name, workdir string) (runtime.Runtime, error) {
+	return &Cluster{
+		Cluster: runtime.NewCluster(name, workdir),
+		runtime: consts.RuntimeTypeContainerd,
+	}, nil
+}
+
+// NewKubernetesCluster creates a new Runtime for kubernetes, which hosts the components as pods in an existing cluster.
+func NewKubernetesCluster(name, workdir string) (runtime.Runtime, error) {
+	return &Cluster{
+		Cluster: runtime.NewCluster(name, workdir),
+		runtime: consts.RuntimeTypeKubernetes,
+	}, nil
+}
+
 // NewDockerCluster creates a new Runtime for docker.
 func NewDockerCluster(name, workdir string) (runtime.Runtime, error) {
 	return &Cluster{
@@ -101,6 +137,12 @@ func (c *Cluster) isSelfCompose(ctx context.Context, creating bool) bool {
 		return *c.selfCompose
 	}
 
+	// There is no compose for containerd and kubernetes
+	if c.runtime == consts.RuntimeTypeContainerd || c.runtime == consts.RuntimeTypeKubernetes {
+		c.selfCompose = format.Ptr(true)
+		return *c.selfCompose
+	}
+
 	var err error
 	logger := log.FromContext(ctx)
 
@@ -128,9 +170,39 @@ func (c *Cluster) isSelfCompose(ctx context.Context, creating bool) bool {
 
 // Available  checks whether the runtime is available.
 func (c *Cluster) Available(ctx context.Context) error {
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdAvailable(ctx)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesAvailable(ctx)
+	}
 	return c.Exec(ctx, c.runtime, "version")
 }
 
+func (c *Cluster) pullImages(ctx context.Context, images []string, quiet bool) error {
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdPullImages(ctx, images, quiet)
+	}
+	// The images are pulled by the nodes of the host cluster
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return nil
+	}
+	if c.useDockerAPI(ctx) {
+		return c.dockerPullImages(ctx, images, quiet)
+	}
+	return c.PullImages(ctx, c.runtime, images, quiet)
+}
+
+func (c *Cluster) parseVersionFromImage(ctx context.Context, image string, command string) (version.Version, error) {
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdParseVersionFromImage(ctx, image, command)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesParseVersionFromImage(ctx, image, command)
+	}
+	return c.ParseVersionFromImage(ctx, c.runtime, image, command)
+}
+
 func (c *Cluster) pullAllImages(ctx context.Context, env *env) error {
 	conf := &env.kwokctlConfig.Options
 	images := []string{
@@ -153,7 +225,15 @@ func (c *Cluster) pullAllImages(ctx context.Context, env *env) error {
 	if conf.JaegerPort != 0 {
 		images = append(images, conf.JaegerImage)
 	}
-	err := c.PullImages(ctx, c.runtime, images, conf.QuietPull)
+	if conf.GrafanaPort != 0 {
+		images = append(images, conf.GrafanaImage)
+	}
+	for _, component := range env.kwokctlConfig.Components {
+		if component.Image != "" {
+			images = append(images, component.Image)
+		}
+	}
+	err := c.pullImages(ctx, images, conf.QuietPull)
 	if err != nil {
 		return err
 	}
@@ -166,6 +246,9 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 		sans := []string{
 			c.Name() + "-kube-apiserver",
 		}
+		if env.hostAddress != net.LocalAddress {
+			sans = append(sans, env.hostAddress)
+		}
 		ips, err := net.GetAllIPs()
 		if err != nil {
 			logger := log.FromContext(ctx)
@@ -201,6 +284,13 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 		if err != nil {
 			return err
 		}
+
+		if conf.KubeAuditWebhook != "" {
+			err = c.CopyFile(conf.KubeAuditWebhook, env.auditWebhookPath)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	err := c.MkdirAll(env.etcdDataPath)
@@ -211,10 +301,25 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 	return nil
 }
 
+// setupCustomComponents checks the images of the custom components declared in the configuration
+func (c *Cluster) setupCustomComponents(_ context.Context, env *env) (err error) {
+	for i, component := range env.kwokctlConfig.Components {
+		if component.Image == "" {
+			return fmt.Errorf("the image of the custom component %s is required in the %s runtime", component.Name, c.runtime)
+		}
+		component.Volumes, err = runtime.ExpandVolumesHostPaths(component.Volumes)
+		if err != nil {
+			return fmt.Errorf("failed to expand host volumes for %s component: %w", component.Name, err)
+		}
+		env.kwokctlConfig.Components[i] = component
+	}
+	return nil
+}
+
 func (c *Cluster) setupPorts(ctx context.Context, ports ...*uint32) error {
 	for _, port := range ports {
 		if port != nil && *port == 0 {
-			p, err := net.GetUnusedPort(ctx)
+			p, err := c.GetUnusedPort(ctx)
 			if err != nil {
 				return err
 			}
@@ -234,6 +339,7 @@ type env struct {
 	kwokConfigPath                string
 	pkiPath                       string
 	auditLogPath                  string
+	auditWebhookPath              string
 	auditPolicyPath               string
 	workdir                       string
 	caCertPath                    string
@@ -245,6 +351,7 @@ type env struct {
 	inClusterAdminCertPath        string
 	inClusterPort                 uint32
 	scheme                        string
+	hostAddress                   string
 }
 
 func (c *Cluster) env(ctx context.Context) (*env, error) {
@@ -261,9 +368,13 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 	pkiPath := c.GetWorkdirPath(runtime.PkiName)
 	auditLogPath := ""
 	auditPolicyPath := ""
+	auditWebhookPath := ""
 	if config.Options.KubeAuditPolicy != "" {
 		auditLogPath = c.GetLogPath(runtime.AuditLogName)
 		auditPolicyPath = c.GetWorkdirPath(runtime.AuditPolicyName)
+		if config.Options.KubeAuditWebhook != "" {
+			auditWebhookPath = c.GetWorkdirPath(runtime.AuditWebhookName)
+		}
 	}
 
 	workdir := c.Workdir()
@@ -295,6 +406,7 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 		kwokConfigPath:                kwokConfigPath,
 		pkiPath:                       pkiPath,
 		auditLogPath:                  auditLogPath,
+		auditWebhookPath:              auditWebhookPath,
 		auditPolicyPath:               auditPolicyPath,
 		workdir:                       workdir,
 		caCertPath:                    caCertPath,
@@ -306,6 +418,7 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 		inClusterAdminCertPath:        inClusterAdminCertPath,
 		inClusterPort:                 inClusterPort,
 		scheme:                        scheme,
+		hostAddress:                   net.LocalAddress,
 	}, nil
 }
 
@@ -321,6 +434,18 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.checkRootless(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		err = c.kubernetesSetup(ctx, env)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = c.setup(ctx, env)
 	if err != nil {
 		return err
@@ -333,16 +458,28 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
-	err = c.pullAllImages(ctx, env)
+	err = c.SetupGrafanaPorts(ctx, &env.kwokctlConfig.Options)
 	if err != nil {
 		return err
 	}
 
-	err = c.addEtcd(ctx, env)
+	err = c.setupCustomComponents(ctx, env)
 	if err != nil {
 		return err
 	}
 
+	err = c.pullAllImages(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	if len(env.kwokctlConfig.Options.EtcdServers) == 0 {
+		err = c.addEtcd(ctx, env)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = c.addKubeApiserver(ctx, env)
 	if err != nil {
 		return err
@@ -378,6 +515,11 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.addGrafana(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.finishInstall(ctx, env)
 	if err != nil {
 		return err
@@ -390,7 +532,7 @@ func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
 	// Configure the etcd
-	etcdVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.EtcdImage, "etcd")
+	etcdVersion, err := c.parseVersionFromImage(ctx, conf.EtcdImage, "etcd")
 	if err != nil {
 		return err
 	}
@@ -401,16 +543,18 @@ func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 		return fmt.Errorf("failed to expand host volumes for etcd component: %w", err)
 	}
 	etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
-		Workdir:      env.workdir,
-		Image:        conf.EtcdImage,
-		Version:      etcdVersion,
-		BindAddress:  net.PublicAddress,
-		Port:         conf.EtcdPort,
-		DataPath:     env.etcdDataPath,
-		Verbosity:    env.verbosity,
-		ExtraArgs:    etcdComponentPatches.ExtraArgs,
-		ExtraVolumes: etcdComponentPatches.ExtraVolumes,
-		ExtraEnvs:    etcdComponentPatches.ExtraEnvs,
+		Workdir:                 env.workdir,
+		Image:                   conf.EtcdImage,
+		Version:                 etcdVersion,
+		BindAddress:             net.PublicAddress,
+		Port:                    conf.EtcdPort,
+		DataPath:                env.etcdDataPath,
+		Verbosity:               env.verbosity,
+		AutoCompactionMode:      conf.EtcdAutoCompactionMode,
+		AutoCompactionRetention: conf.EtcdAutoCompactionRetention,
+		ExtraArgs:               etcdComponentPatches.ExtraArgs,
+		ExtraVolumes:            etcdComponentPatches.ExtraVolumes,
+		ExtraEnvs:               etcdComponentPatches.ExtraEnvs,
 	})
 	if err != nil {
 		return err
@@ -423,7 +567,7 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
 	// Configure the kube-apiserver
-	kubeApiserverVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.KubeApiserverImage, consts.ComponentKubeApiserver)
+	kubeApiserverVersion, err := c.parseVersionFromImage(ctx, conf.KubeApiserverImage, consts.ComponentKubeApiserver)
 	if err != nil {
 		return err
 	}
@@ -450,36 +594,39 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 		}
 	}
 
-	kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
-		Workdir:           env.workdir,
-		Image:             conf.KubeApiserverImage,
-		Version:           kubeApiserverVersion,
-		BindAddress:       net.PublicAddress,
-		Port:              conf.KubeApiserverPort,
-		KubeRuntimeConfig: conf.KubeRuntimeConfig,
-		KubeFeatureGates:  conf.KubeFeatureGates,
-		SecurePort:        conf.SecurePort,
-		KubeAuthorization: conf.KubeAuthorization,
-		KubeAdmission:     conf.KubeAdmission,
-		AuditPolicyPath:   env.auditPolicyPath,
-		AuditLogPath:      env.auditLogPath,
-		CaCertPath:        env.caCertPath,
-		AdminCertPath:     env.adminCertPath,
-		AdminKeyPath:      env.adminKeyPath,
-		EtcdPort:          conf.EtcdPort,
-		EtcdAddress:       c.Name() + "-etcd",
-		Verbosity:         env.verbosity,
-		DisableQPSLimits:  conf.DisableQPSLimits,
-		TracingConfigPath: kubeApiserverTracingConfigPath,
-		ExtraArgs:         kubeApiserverComponentPatches.ExtraArgs,
-		ExtraVolumes:      kubeApiserverComponentPatches.ExtraVolumes,
-		ExtraEnvs:         kubeApiserverComponentPatches.ExtraEnvs,
+	return c.addReplicas(ctx, env, conf.KubeApiserverPort, conf.KubeApiserverReplicas, func(port uint32) (internalversion.Component, error) {
+		return components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
+			Workdir:           env.workdir,
+			Image:             conf.KubeApiserverImage,
+			Version:           kubeApiserverVersion,
+			BindAddress:       net.PublicAddress,
+			Port:              port,
+			KubeRuntimeConfig: conf.KubeRuntimeConfig,
+			KubeFeatureGates:  conf.KubeFeatureGates,
+			SecurePort:        conf.SecurePort,
+			KubeAuthorization: conf.KubeAuthorization,
+			KubeAdmission:     conf.KubeAdmission,
+			AuditPolicyPath:   env.auditPolicyPath,
+			AuditLogPath:      env.auditLogPath,
+			AuditWebhookPath:  env.auditWebhookPath,
+			CaCertPath:        env.caCertPath,
+			AdminCertPath:     env.adminCertPath,
+			AdminKeyPath:      env.adminKeyPath,
+			EtcdPort:          conf.EtcdPort,
+			EtcdServers:       conf.EtcdServers,
+			EtcdCaFile:        conf.EtcdCaFile,
+			EtcdCertFile:      conf.EtcdCertFile,
+			EtcdKeyFile:       conf.EtcdKeyFile,
+			EtcdPrefix:        conf.EtcdPrefix,
+			EtcdAddress:       c.Name() + "-etcd",
+			Verbosity:         env.verbosity,
+			DisableQPSLimits:  conf.DisableQPSLimits,
+			TracingConfigPath: kubeApiserverTracingConfigPath,
+			ExtraArgs:         kubeApiserverComponentPatches.ExtraArgs,
+			ExtraVolumes:      kubeApiserverComponentPatches.ExtraVolumes,
+			ExtraEnvs:         kubeApiserverComponentPatches.ExtraEnvs,
+		})
 	})
-	if err != nil {
-		return err
-	}
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
-	return nil
 }
 
 func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err error) {
@@ -487,7 +634,7 @@ func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err e
 
 	// Configure the kube-controller-manager
 	if !conf.DisableKubeControllerManager {
-		kubeControllerManagerVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.KubeControllerManagerImage, consts.ComponentKubeControllerManager)
+		kubeControllerManagerVersion, err := c.parseVersionFromImage(ctx, conf.KubeControllerManagerImage, consts.ComponentKubeControllerManager)
 		if err != nil {
 			return err
 		}
@@ -497,31 +644,32 @@ func (c *Cluster) addKubeControllerManager(ctx context.Context, env *env) (err e
 		if err != nil {
 			return fmt.Errorf("failed to expand host volumes for kube controller manager component: %w", err)
 		}
-		kubeControllerManagerComponent, err := components.BuildKubeControllerManagerComponent(components.BuildKubeControllerManagerComponentConfig{
-			Workdir:                            env.workdir,
-			Image:                              conf.KubeControllerManagerImage,
-			Version:                            kubeControllerManagerVersion,
-			BindAddress:                        net.PublicAddress,
-			Port:                               conf.KubeControllerManagerPort,
-			SecurePort:                         conf.SecurePort,
-			CaCertPath:                         env.caCertPath,
-			AdminCertPath:                      env.adminCertPath,
-			AdminKeyPath:                       env.adminKeyPath,
-			KubeAuthorization:                  conf.KubeAuthorization,
-			KubeconfigPath:                     env.inClusterOnHostKubeconfigPath,
-			KubeFeatureGates:                   conf.KubeFeatureGates,
-			Verbosity:                          env.verbosity,
-			DisableQPSLimits:                   conf.DisableQPSLimits,
-			NodeMonitorPeriodMilliseconds:      conf.KubeControllerManagerNodeMonitorPeriodMilliseconds,
-			NodeMonitorGracePeriodMilliseconds: conf.KubeControllerManagerNodeMonitorGracePeriodMilliseconds,
-			ExtraArgs:                          kubeControllerManagerComponentPatches.ExtraArgs,
-			ExtraVolumes:                       kubeControllerManagerComponentPatches.ExtraVolumes,
-			ExtraEnvs:                          kubeControllerManagerComponentPatches.ExtraEnvs,
+		err = c.addReplicas(ctx, env, conf.KubeControllerManagerPort, conf.KubeControllerManagerReplicas, func(port uint32) (internalversion.Component, error) {
+			return components.BuildKubeControllerManagerComponent(components.BuildKubeControllerManagerComponentConfig{
+				Workdir:                            env.workdir,
+				Image:                              conf.KubeControllerManagerImage,
+				Version:                            kubeControllerManagerVersion,
+				BindAddress:                        net.PublicAddress,
+				Port:                               port,
+				SecurePort:                         conf.SecurePort,
+				CaCertPath:                         env.caCertPath,
+				AdminCertPath:                      env.adminCertPath,
+				AdminKeyPath:                       env.adminKeyPath,
+				KubeAuthorization:                  conf.KubeAuthorization,
+				KubeconfigPath:                     env.inClusterOnHostKubeconfigPath,
+				KubeFeatureGates:                   conf.KubeFeatureGates,
+				Verbosity:                          env.verbosity,
+				DisableQPSLimits:                   conf.DisableQPSLimits,
+				NodeMonitorPeriodMilliseconds:      conf.KubeControllerManagerNodeMonitorPeriodMilliseconds,
+				NodeMonitorGracePeriodMilliseconds: conf.KubeControllerManagerNodeMonitorGracePeriodMilliseconds,
+				ExtraArgs:                          kubeControllerManagerComponentPatches.ExtraArgs,
+				ExtraVolumes:                       kubeControllerManagerComponentPatches.ExtraVolumes,
+				ExtraEnvs:                          kubeControllerManagerComponentPatches.ExtraEnvs,
+			})
 		})
 		if err != nil {
 			return err
 		}
-		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeControllerManagerComponent)
 	}
 	return nil
 }
@@ -540,7 +688,7 @@ func (c *Cluster) addKubeScheduler(ctx context.Context, env *env) (err error) {
 			}
 		}
 
-		kubeSchedulerVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.KubeSchedulerImage, consts.ComponentKubeScheduler)
+		kubeSchedulerVersion, err := c.parseVersionFromImage(ctx, conf.KubeSchedulerImage, consts.ComponentKubeScheduler)
 		if err != nil {
 			return err
 		}
@@ -550,29 +698,57 @@ func (c *Cluster) addKubeScheduler(ctx context.Context, env *env) (err error) {
 		if err != nil {
 			return fmt.Errorf("failed to expand host volumes for kube scheduler component: %w", err)
 		}
-		kubeSchedulerComponent, err := components.BuildKubeSchedulerComponent(components.BuildKubeSchedulerComponentConfig{
-			Workdir:          env.workdir,
-			Image:            conf.KubeSchedulerImage,
-			Version:          kubeSchedulerVersion,
-			BindAddress:      net.PublicAddress,
-			Port:             conf.KubeSchedulerPort,
-			SecurePort:       conf.SecurePort,
-			CaCertPath:       env.caCertPath,
-			AdminCertPath:    env.adminCertPath,
-			AdminKeyPath:     env.adminKeyPath,
-			ConfigPath:       schedulerConfigPath,
-			KubeconfigPath:   env.inClusterOnHostKubeconfigPath,
-			KubeFeatureGates: conf.KubeFeatureGates,
-			Verbosity:        env.verbosity,
-			DisableQPSLimits: conf.DisableQPSLimits,
-			ExtraArgs:        kubeSchedulerComponentPatches.ExtraArgs,
-			ExtraVolumes:     kubeSchedulerComponentPatches.ExtraVolumes,
-			ExtraEnvs:        kubeSchedulerComponentPatches.ExtraEnvs,
+		err = c.addReplicas(ctx, env, conf.KubeSchedulerPort, conf.KubeSchedulerReplicas, func(port uint32) (internalversion.Component, error) {
+			return components.BuildKubeSchedulerComponent(components.BuildKubeSchedulerComponentConfig{
+				Workdir:          env.workdir,
+				Image:            conf.KubeSchedulerImage,
+				Version:          kubeSchedulerVersion,
+				BindAddress:      net.PublicAddress,
+				Port:             port,
+				SecurePort:       conf.SecurePort,
+				CaCertPath:       env.caCertPath,
+				AdminCertPath:    env.adminCertPath,
+				AdminKeyPath:     env.adminKeyPath,
+				ConfigPath:       schedulerConfigPath,
+				KubeconfigPath:   env.inClusterOnHostKubeconfigPath,
+				KubeFeatureGates: conf.KubeFeatureGates,
+				Verbosity:        env.verbosity,
+				DisableQPSLimits: conf.DisableQPSLimits,
+				ExtraArgs:        kubeSchedulerComponentPatches.ExtraArgs,
+				ExtraVolumes:     kubeSchedulerComponentPatches.ExtraVolumes,
+				ExtraEnvs:        kubeSchedulerComponentPatches.ExtraEnvs,
+			})
 		})
 		if err != nil {
 			return err
 		}
-		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeSchedulerComponent)
+	}
+	return nil
+}
+
+// addReplicas adds the component built with the given port, and its replicas named <name>-<index>,
+// the replicas are given the random ports on the host unless the port of the component is not given.
+func (c *Cluster) addReplicas(ctx context.Context, env *env, port uint32, replicas uint32, build func(port uint32) (internalversion.Component, error)) error {
+	component, err := build(port)
+	if err != nil {
+		return err
+	}
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, component)
+
+	for i := uint32(1); i < replicas; i++ {
+		var replicaPort uint32
+		if port != 0 {
+			replicaPort, err = c.GetUnusedPort(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		replica, err := build(replicaPort)
+		if err != nil {
+			return err
+		}
+		replica.Name = fmt.Sprintf("%s-%d", replica.Name, i)
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, replica)
 	}
 	return nil
 }
@@ -581,7 +757,7 @@ func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
 	// Configure the kwok-controller
-	kwokControllerVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.KwokControllerImage, "kwok")
+	kwokControllerVersion, err := c.parseVersionFromImage(ctx, conf.KwokControllerImage, "kwok")
 	if err != nil {
 		return err
 	}
@@ -642,7 +818,7 @@ func (c *Cluster) addPrometheus(ctx context.Context, env *env) (err error) {
 			return fmt.Errorf("failed to write prometheus yaml: %w", err)
 		}
 
-		prometheusVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.PrometheusImage, "")
+		prometheusVersion, err := c.parseVersionFromImage(ctx, conf.PrometheusImage, "")
 		if err != nil {
 			return err
 		}
@@ -693,6 +869,9 @@ func (c *Cluster) addDashboard(_ context.Context, env *env) (err error) {
 			AdminKeyPath:   env.adminKeyPath,
 			Port:           conf.DashboardPort,
 			Banner:         fmt.Sprintf("Welcome to %s", c.Name()),
+			ExtraArgs:      dashboardComponentPatches.ExtraArgs,
+			ExtraVolumes:   dashboardComponentPatches.ExtraVolumes,
+			ExtraEnvs:      dashboardComponentPatches.ExtraEnvs,
 		})
 		if err != nil {
 			return err
@@ -707,7 +886,7 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) error {
 
 	// Configure the jaeger
 	if conf.JaegerPort != 0 {
-		jaegerVersion, err := c.ParseVersionFromImage(ctx, c.runtime, conf.JaegerImage, "")
+		jaegerVersion, err := c.parseVersionFromImage(ctx, conf.JaegerImage, "")
 		if err != nil {
 			return err
 		}
@@ -726,6 +905,7 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) error {
 			Verbosity:    env.verbosity,
 			ExtraArgs:    jaegerComponentPatches.ExtraArgs,
 			ExtraVolumes: jaegerComponentPatches.ExtraVolumes,
+			ExtraEnvs:    jaegerComponentPatches.ExtraEnvs,
 		})
 		if err != nil {
 			return err
@@ -735,6 +915,76 @@ func (c *Cluster) addJaeger(ctx context.Context, env *env) error {
 	return nil
 }
 
+func (c *Cluster) addGrafana(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+
+	// Configure the grafana
+	if conf.GrafanaPort != 0 {
+		provisioning, err := runtime.BuildGrafanaProvisioning(runtime.BuildGrafanaProvisioningConfig{
+			PrometheusURL: "http://" + c.Name() + "-prometheus:9090",
+		})
+		if err != nil {
+			return err
+		}
+
+		provisioningPath := c.GetWorkdirPath(runtime.GrafanaProvisioningName)
+		provisioningFiles := map[string]string{}
+		for _, dir := range []struct {
+			name  string
+			files map[string]string
+		}{
+			{"datasources", provisioning.Datasources},
+			{"dashboards", provisioning.Dashboards},
+		} {
+			err = c.MkdirAll(path.Join(provisioningPath, dir.name))
+			if err != nil {
+				return fmt.Errorf("failed to mkdir grafana provisioning path: %w", err)
+			}
+			names := maps.Keys(dir.files)
+			sort.Strings(names)
+			for _, name := range names {
+				filePath := path.Join(provisioningPath, dir.name, name)
+
+				// We don't need to check the permissions of the grafana provisioning files,
+				// because it's working in a non-root container.
+				err = c.WriteFileWithMode(filePath, []byte(dir.files[name]), 0644)
+				if err != nil {
+					return fmt.Errorf("failed to write grafana provisioning file %s: %w", name, err)
+				}
+				provisioningFiles[dir.name+"/"+name] = filePath
+			}
+		}
+
+		grafanaVersion, err := c.parseVersionFromImage(ctx, conf.GrafanaImage, "")
+		if err != nil {
+			return err
+		}
+
+		grafanaComponentPatches := runtime.GetComponentPatches(env.kwokctlConfig, consts.ComponentGrafana)
+		grafanaComponentPatches.ExtraVolumes, err = runtime.ExpandVolumesHostPaths(grafanaComponentPatches.ExtraVolumes)
+		if err != nil {
+			return fmt.Errorf("failed to expand host volumes for grafana component: %w", err)
+		}
+		grafanaComponent, err := components.BuildGrafanaComponent(components.BuildGrafanaComponentConfig{
+			Workdir:           env.workdir,
+			Image:             conf.GrafanaImage,
+			Version:           grafanaVersion,
+			BindAddress:       net.PublicAddress,
+			Port:              conf.GrafanaPort,
+			ProvisioningFiles: provisioningFiles,
+			Verbosity:         env.verbosity,
+			ExtraArgs:         grafanaComponentPatches.ExtraArgs,
+			ExtraVolumes:      grafanaComponentPatches.ExtraVolumes,
+			ExtraEnvs:         grafanaComponentPatches.ExtraEnvs,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, grafanaComponent)
+	}
+	return nil
+}
+
 func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
 	conf := &env.kwokctlConfig.Options
 
@@ -742,7 +992,7 @@ func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
 	kubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
 		ProjectName:  c.Name(),
 		SecurePort:   conf.SecurePort,
-		Address:      env.scheme + "://" + net.LocalAddress + ":" + format.String(conf.KubeApiserverPort),
+		Address:      env.scheme + "://" + env.hostAddress + ":" + format.String(conf.KubeApiserverPort),
 		CACrtPath:    env.caCertPath,
 		AdminCrtPath: env.adminCertPath,
 		AdminKeyPath: env.adminKeyPath,
@@ -766,7 +1016,11 @@ func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
 	isSelfCompose := c.isSelfCompose(ctx, true)
 	if !isSelfCompose {
 		composePath := c.GetWorkdirPath(runtime.ComposeName)
-		compose := convertToCompose(c.Name(), conf.BindAddress, env.kwokctlConfig.Components)
+		cpu, memory, err := runtime.GetComponentsResourceLimits(conf)
+		if err != nil {
+			return err
+		}
+		compose := convertToCompose(c.Name(), conf.BindAddress, env.kwokctlConfig.Components, cpu, memory, conf.EnableIPv6)
 		composeData, err := yaml.Marshal(compose)
 		if err != nil {
 			return err
@@ -966,7 +1220,161 @@ func (c *Cluster) StopComponent(ctx context.Context, componentName string) error
 	return c.stopComponent(ctx, componentName)
 }
 
+// UpgradeComponent replaces the container of the component with the one of the image in the options
+func (c *Cluster) UpgradeComponent(ctx context.Context, name string) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &config.Options
+
+	_, image, err := runtime.UpgradeSource(conf, name)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(config.Components, func(component internalversion.Component) bool {
+		return component.Name == name
+	})
+	if index < 0 {
+		return fmt.Errorf("%w: %s", runtime.ErrComponentNotFound, name)
+	}
+
+	err = c.pullImages(ctx, []string{image}, conf.QuietPull)
+	if err != nil {
+		return err
+	}
+
+	err = c.stopComponent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to stop %s: %w", name, err)
+	}
+	err = c.deleteComponent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", name, err)
+	}
+
+	config.Components[index].Image = image
+	config.Components[index].Version = conf.KubeVersion
+
+	err = c.createComponent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	err = c.startComponent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	return c.Save(ctx)
+}
+
+// InstallComponent adds the optional component to the cluster, creates and starts its container
+func (c *Cluster) InstallComponent(ctx context.Context, name string) error {
+	env, err := c.env(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &env.kwokctlConfig.Options
+
+	port, err := runtime.OptionalComponentPort(conf, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.GetComponent(ctx, name)
+	if err == nil {
+		return fmt.Errorf("component %s is already installed", name)
+	}
+	if !errors.Is(err, runtime.ErrComponentNotFound) {
+		return err
+	}
+
+	err = c.setupPorts(ctx, port)
+	if err != nil {
+		return err
+	}
+
+	err = c.pullAllImages(ctx, env)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case consts.ComponentDashboard:
+		err = c.addDashboard(ctx, env)
+	case consts.ComponentPrometheus:
+		err = c.addPrometheus(ctx, env)
+	case consts.ComponentJaeger:
+		err = c.addJaeger(ctx, env)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = c.SetConfig(ctx, env.kwokctlConfig)
+	if err != nil {
+		return err
+	}
+	err = c.Save(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.createComponent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	err = c.startComponent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	return nil
+}
+
+// UninstallComponent stops and deletes the container of the optional component, and removes it from the cluster
+func (c *Cluster) UninstallComponent(ctx context.Context, name string) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	port, err := runtime.OptionalComponentPort(&config.Options, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	err = c.stopComponent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to stop %s: %w", name, err)
+	}
+	err = c.deleteComponent(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", name, err)
+	}
+
+	config.Components = slices.Filter(config.Components, func(component internalversion.Component) bool {
+		return component.Name != name
+	})
+	*port = 0
+	return c.Save(ctx)
+}
+
 func (c *Cluster) logs(ctx context.Context, name string, out io.Writer, follow bool) error {
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdLogs(ctx, name, out, follow)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesLogs(ctx, name, out, follow)
+	}
+	if c.useDockerAPI(ctx) {
+		return c.dockerLogs(ctx, name, out, follow)
+	}
+
 	args := []string{"logs"}
 	if follow {
 		args = append(args, "-f")
@@ -1027,7 +1435,14 @@ func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
 	}
 
 	infoPath := path.Join(dir, c.runtime+"-info.txt")
-	err = c.WriteToPath(ctx, infoPath, []string{c.runtime, "info"})
+	switch c.runtime {
+	case consts.RuntimeTypeContainerd:
+		err = c.containerdInfo(ctx, infoPath)
+	case consts.RuntimeTypeKubernetes:
+		err = c.kubernetesInfo(ctx, infoPath)
+	default:
+		err = c.WriteToPath(ctx, infoPath, []string{c.runtime, "info"})
+	}
 	if err != nil {
 		return err
 	}
@@ -1077,6 +1492,24 @@ func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
 	return nil
 }
 
+// ComponentStartedAt return the time the component of cluster was started at, zero if it is not running
+func (c *Cluster) ComponentStartedAt(ctx context.Context, name string) (time.Time, error) {
+	_, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdComponentStartedAt(ctx, name)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesComponentStartedAt(ctx, name)
+	}
+	if c.useDockerAPI(ctx) {
+		return c.dockerComponentStartedAt(ctx, name)
+	}
+	return c.ContainerStartedAt(ctx, c.runtime, c.Name()+"-"+name)
+}
+
 // ListBinaries list binaries in the cluster
 func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
 	config, err := c.Config(ctx)
@@ -1108,8 +1541,57 @@ func (c *Cluster) ListImages(ctx context.Context) ([]string, error) {
 	}, nil
 }
 
+// SaveImages save the images of the cluster to the archive
+func (c *Cluster) SaveImages(ctx context.Context, archive string) error {
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return errKubernetesImages
+	}
+
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &config.Options
+
+	images, err := c.ListImages(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.pullImages(ctx, images, conf.QuietPull)
+	if err != nil {
+		return err
+	}
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdSaveImages(ctx, images, archive)
+	}
+	return c.SaveImageArchive(ctx, c.runtime, images, archive)
+}
+
+// LoadImages load the images from the archive
+func (c *Cluster) LoadImages(ctx context.Context, archive string) error {
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return errKubernetesImages
+	}
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdLoadImages(ctx, archive)
+	}
+	return c.LoadImageArchive(ctx, c.runtime, archive)
+}
+
 // EtcdctlInCluster implements the ectdctl subcommand
 func (c *Cluster) EtcdctlInCluster(ctx context.Context, args ...string) error {
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdExecComponent(ctx, consts.ComponentEtcd, []string{"ETCDCTL_API=3"}, append([]string{"etcdctl"}, args...)...)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		// The etcdctl of v3.4 and later uses the v3 API by default
+		return c.kubernetesExecComponent(ctx, consts.ComponentEtcd, append([]string{"etcdctl"}, args...)...)
+	}
+	if c.useDockerAPI(ctx) {
+		return c.dockerExecComponent(ctx, consts.ComponentEtcd, []string{"ETCDCTL_API=3"}, append([]string{"etcdctl"}, args...)...)
+	}
+
 	etcdContainerName := c.Name() + "-etcd"
 
 	// If using versions earlier than v3.4, set `ETCDCTL_API=3` to use v3 API.
@@ -1117,6 +1599,16 @@ func (c *Cluster) EtcdctlInCluster(ctx context.Context, args ...string) error {
 	return c.Exec(ctx, c.runtime, args...)
 }
 
+// InitCRDs initializes the CRDs and registers the metrics APIs served by the kwok-controller in the network
+func (c *Cluster) InitCRDs(ctx context.Context) error {
+	err := c.Cluster.InitCRDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.InitMetricsAPIService(ctx, c.Name()+"-"+consts.ComponentKwokController, 10247)
+}
+
 // Ready returns true if the cluster is ready
 func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 	config, err := c.Config(ctx)
@@ -1133,6 +1625,32 @@ func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 	return c.Cluster.Ready(ctx)
 }
 
+// ComponentReady returns true if the component is ready
+func (c *Cluster) ComponentReady(ctx context.Context, name string) (bool, error) {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		// The probes are run by the kubelet of the host cluster.
+		if !c.kubernetesComponentReady(ctx, name) {
+			return false, nil
+		}
+	} else {
+		if running, _ := c.inspectComponent(ctx, name); !running {
+			return false, nil
+		}
+		err = runtime.ProbeComponent(ctx, component, component.ReadinessProbe)
+		if err != nil {
+			return false, err
+		}
+	}
+	if name == consts.ComponentKubeApiserver {
+		return c.Cluster.Ready(ctx)
+	}
+	return true, nil
+}
+
 // WaitReady waits for the cluster to be ready.
 func (c *Cluster) WaitReady(ctx context.Context, timeout time.Duration) error {
 	if c.IsDryRun() {
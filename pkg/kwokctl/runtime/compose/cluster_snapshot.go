@@ -18,11 +18,14 @@ package compose
 
 import (
 	"context"
+	"errors"
 
 	"sigs.k8s.io/kwok/pkg/consts"
 	"sigs.k8s.io/kwok/pkg/log"
 )
 
+var errExternalEtcdSnapshot = errors.New("the snapshot of the external etcd is not supported")
+
 // SnapshotSave save the snapshot of cluster
 func (c *Cluster) SnapshotSave(ctx context.Context, path string) error {
 	config, err := c.Config(ctx)
@@ -30,6 +33,12 @@ func (c *Cluster) SnapshotSave(ctx context.Context, path string) error {
 		return err
 	}
 	conf := &config.Options
+	if len(conf.EtcdServers) != 0 {
+		return errExternalEtcdSnapshot
+	}
+	if conf.Runtime == consts.RuntimeTypeKubernetes {
+		return errKubernetesSnapshot
+	}
 
 	// Save to /snapshot.db on container
 	tmpFile := "/snapshot.db"
@@ -40,7 +49,11 @@ func (c *Cluster) SnapshotSave(ctx context.Context, path string) error {
 
 	etcdContainerName := c.Name() + "-etcd"
 	// Copy to host path from container
-	err = c.Exec(ctx, conf.Runtime, "cp", etcdContainerName+":"+tmpFile, path)
+	if conf.Runtime == consts.RuntimeTypeContainerd {
+		err = c.containerdCopyFromComponent(ctx, consts.ComponentEtcd, tmpFile, path)
+	} else {
+		err = c.Exec(ctx, conf.Runtime, "cp", etcdContainerName+":"+tmpFile, path)
+	}
 	if err != nil {
 		return err
 	}
@@ -54,6 +67,12 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 		return err
 	}
 	conf := &config.Options
+	if len(conf.EtcdServers) != 0 {
+		return errExternalEtcdSnapshot
+	}
+	if conf.Runtime == consts.RuntimeTypeKubernetes {
+		return errKubernetesSnapshot
+	}
 
 	logger := log.FromContext(ctx)
 	// Restore snapshot to host temporary directory
@@ -84,7 +103,11 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 		}()
 
 		// Copy to container from host temporary directory
-		err = c.Exec(ctx, conf.Runtime, "cp", etcdDataTmp, etcdContainerName+":/")
+		if conf.Runtime == consts.RuntimeTypeContainerd {
+			err = c.containerdCopyToComponent(ctx, consts.ComponentEtcd, etcdDataTmp, "/etcd-data")
+		} else {
+			err = c.Exec(ctx, conf.Runtime, "cp", etcdDataTmp, etcdContainerName+":/")
+		}
 		if err != nil {
 			return err
 		}
@@ -35,9 +35,16 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/format"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
 func (c *Cluster) upCompose(ctx context.Context) error {
+	// The services of the compose file are the components of the cluster,
+	// so they are managed with the docker API instead of the compose.
+	if c.useDockerAPI(ctx) {
+		return c.upComponents(ctx)
+	}
+
 	config, err := c.Config(ctx)
 	if err != nil {
 		return err
@@ -89,6 +96,10 @@ func (c *Cluster) upCompose(ctx context.Context) error {
 }
 
 func (c *Cluster) downCompose(ctx context.Context) error {
+	if c.useDockerAPI(ctx) {
+		return c.downComponents(ctx)
+	}
+
 	args := []string{"down"}
 	commands, err := c.buildComposeCommands(ctx, args...)
 	if err != nil {
@@ -103,6 +114,10 @@ func (c *Cluster) downCompose(ctx context.Context) error {
 }
 
 func (c *Cluster) startCompose(ctx context.Context) error {
+	if c.useDockerAPI(ctx) {
+		return c.start(ctx)
+	}
+
 	// TODO: nerdctl does not support 'compose start' in v1.1.0 or earlier
 	// Support in https://github.com/containerd/nerdctl/pull/1656 merge into the main branch, but there is no release
 	subcommand := []string{"start"}
@@ -141,6 +156,10 @@ func (c *Cluster) startCompose(ctx context.Context) error {
 }
 
 func (c *Cluster) stopCompose(ctx context.Context) error {
+	if c.useDockerAPI(ctx) {
+		return c.stop(ctx)
+	}
+
 	// TODO: nerdctl does not support 'compose stop' in v1.0.0 or earlier
 	subcommand := "stop"
 	if c.runtime == consts.RuntimeTypeNerdctl {
@@ -163,6 +182,46 @@ func (c *Cluster) stopCompose(ctx context.Context) error {
 	return nil
 }
 
+// upComponents creates and starts the components as the compose up does.
+func (c *Cluster) upComponents(ctx context.Context) error {
+	err := c.createNetwork(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		err = c.createComponents(ctx)
+		return err == nil, err
+	},
+		wait.WithContinueOnError(5),
+		wait.WithImmediate(),
+	)
+	if err != nil {
+		return err
+	}
+	return c.start(ctx)
+}
+
+// downComponents stops and deletes the components as the compose down does.
+func (c *Cluster) downComponents(ctx context.Context) error {
+	err := c.stop(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		err := c.deleteComponents(ctx)
+		return err == nil, err
+	},
+		wait.WithContinueOnError(5),
+		wait.WithImmediate(),
+	)
+	if err != nil {
+		return err
+	}
+	return c.deleteNetwork(ctx)
+}
+
 // buildComposeCommands returns the compose commands with given current runtime and args
 func (c *Cluster) buildComposeCommands(ctx context.Context, args ...string) ([]string, error) {
 	if c.composeCommands != nil {
@@ -294,8 +353,8 @@ func (c *Cluster) isRunning(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
-func convertToCompose(name string, hostIP string, cs []internalversion.Component) *types.Config {
-	svcs := convertComponentsToComposeServices(name, hostIP, cs)
+func convertToCompose(name string, hostIP string, cs []internalversion.Component, cpu float64, memory int64, ipv6 bool) *types.Config {
+	svcs := convertComponentsToComposeServices(name, hostIP, cs, cpu, memory)
 	return &types.Config{
 		Extensions: map[string]interface{}{
 			"version": "3",
@@ -303,16 +362,19 @@ func convertToCompose(name string, hostIP string, cs []internalversion.Component
 		Services: svcs,
 		Networks: map[string]types.NetworkConfig{
 			"default": {
-				Name: name,
+				Name:       name,
+				EnableIPv6: ipv6,
 			},
 		},
 	}
 }
 
-func convertComponentsToComposeServices(prefix string, hostIP string, cs []internalversion.Component) (svcs types.Services) {
+func convertComponentsToComposeServices(prefix string, hostIP string, cs []internalversion.Component, cpu float64, memory int64) (svcs types.Services) {
 	svcs = make(types.Services, len(cs))
 	for i, c := range cs {
 		svcs[i] = convertComponentToComposeService(prefix, hostIP, c)
+		svcs[i].CPUS = float32(cpu)
+		svcs[i].MemLimit = types.UnitBytes(memory)
 	}
 	return svcs
 }
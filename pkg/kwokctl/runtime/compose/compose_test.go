@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func Test_convertComponentsToComposeServices_resourceLimits(t *testing.T) {
+	components := []internalversion.Component{
+		{Name: "etcd", Image: "registry.k8s.io/etcd:3.5.9-0"},
+		{Name: "kube-apiserver", Image: "registry.k8s.io/kube-apiserver:v1.28.0"},
+	}
+
+	tests := []struct {
+		name   string
+		cpu    float64
+		memory int64
+	}{
+		{
+			name: "no limits",
+		},
+		{
+			name:   "limits",
+			cpu:    0.5,
+			memory: 1 << 30,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svcs := convertComponentsToComposeServices("kwok-kwok", "", components, tt.cpu, tt.memory)
+			if len(svcs) != len(components) {
+				t.Fatalf("want %d services, got %d", len(components), len(svcs))
+			}
+			for _, svc := range svcs {
+				if svc.CPUS != float32(tt.cpu) || svc.MemLimit != types.UnitBytes(tt.memory) {
+					t.Errorf("want the service %s limited to %v cpus and %v bytes, got %v and %v", svc.Name, tt.cpu, tt.memory, svc.CPUS, svc.MemLimit)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,1024 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/images/archive"
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/oci"
+	"github.com/containerd/containerd/platforms"
+	refdocker "github.com/containerd/containerd/reference/docker"
+	gocni "github.com/containerd/go-cni"
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/envs"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+var (
+	containerdAddress   = envs.GetEnvWithPrefix("CONTAINERD_ADDRESS", "/run/containerd/containerd.sock")
+	containerdNamespace = envs.GetEnvWithPrefix("CONTAINERD_NAMESPACE", "kwok")
+	cniPath             = envs.GetEnvWithPrefix("CNI_PATH", "/opt/cni/bin")
+)
+
+const (
+	// containerdIPLabel is the label of the container keeping the ip address of the running task
+	containerdIPLabel = "kwok.x-k8s.io/ip"
+	// containerdStartedAtLabel is the label of the container keeping the time the task was started at
+	containerdStartedAtLabel = "kwok.x-k8s.io/started-at"
+
+	// containerdNetwork is the CNI network shared by the containers of all clusters,
+	// the containers are named after the cluster so that they are not conflicted.
+	containerdNetwork = `{
+  "cniVersion": "1.0.0",
+  "name": "kwokctl",
+  "plugins": [
+    {
+      "type": "bridge",
+      "bridge": "kwokctl0",
+      "isGateway": true,
+      "ipMasq": true,
+      "hairpinMode": true,
+      "ipam": {
+        "type": "host-local",
+        "ranges": [[{"subnet": "10.5.0.0/16"}]],
+        "routes": [{"dst": "0.0.0.0/0"}]
+      }
+    },
+    {
+      "type": "portmap",
+      "capabilities": {"portMappings": true}
+    },
+    {
+      "type": "firewall"
+    }
+  ]
+}`
+)
+
+func (c *Cluster) containerdClient(ctx context.Context) (*containerd.Client, error) {
+	if c.containerdCli != nil {
+		return c.containerdCli, nil
+	}
+
+	if !file.Exists(containerdAddress) {
+		return nil, fmt.Errorf("the socket of containerd %s is not found, set KWOK_CONTAINERD_ADDRESS to where it is", containerdAddress)
+	}
+	client, err := containerd.New(containerdAddress, containerd.WithDefaultNamespace(containerdNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to containerd at %s: %w", containerdAddress, err)
+	}
+	c.containerdCli = client
+	return client, nil
+}
+
+func (c *Cluster) containerdCNI() (gocni.CNI, error) {
+	if c.cni != nil {
+		return c.cni, nil
+	}
+
+	cni, err := newContainerdCNI()
+	if err != nil {
+		return nil, err
+	}
+	c.cni = cni
+	return cni, nil
+}
+
+func newContainerdCNI() (gocni.CNI, error) {
+	if !file.Exists(path.Join(cniPath, "bridge")) {
+		return nil, fmt.Errorf("the CNI plugins are not found in %s, "+
+			"install them from https://github.com/containernetworking/plugins/releases or set KWOK_CNI_PATH to where they are", cniPath)
+	}
+	return gocni.New(
+		gocni.WithPluginDir([]string{cniPath}),
+		gocni.WithConfListBytes([]byte(containerdNetwork)),
+	)
+}
+
+func (c *Cluster) containerdAvailable(ctx context.Context) error {
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	serving, err := client.IsServing(ctx)
+	if err != nil {
+		return err
+	}
+	if !serving {
+		return fmt.Errorf("containerd at %s is not serving", containerdAddress)
+	}
+	_, err = c.containerdCNI()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Cluster) containerdInfo(ctx context.Context, infoPath string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Save the version of containerd to %s", infoPath)
+		return nil
+	}
+
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	ver, err := client.Version(ctx)
+	if err != nil {
+		return err
+	}
+	info := fmt.Sprintf("Address: %s\nNamespace: %s\nVersion: %s\nRevision: %s\nCNI: %s\n",
+		containerdAddress, containerdNamespace, ver.Version, ver.Revision, cniPath)
+	return c.WriteFile(infoPath, []byte(info))
+}
+
+// containerdImageRef returns the fully qualified reference of the image, e.g. busybox to docker.io/library/busybox:latest
+func containerdImageRef(image string) (string, error) {
+	named, err := refdocker.ParseDockerRef(image)
+	if err != nil {
+		return "", err
+	}
+	return named.String(), nil
+}
+
+func (c *Cluster) containerdPullImages(ctx context.Context, images []string, quiet bool) error {
+	if c.IsDryRun() {
+		for _, image := range images {
+			dryrun.PrintMessage("# Pull image %s", image)
+		}
+		return nil
+	}
+
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	var missing []string
+	for _, image := range images {
+		ref, err := containerdImageRef(image)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetImage(ctx, ref)
+		if err == nil {
+			logger.Debug("Image already exists", "image", image)
+			continue
+		}
+		if !errdefs.IsNotFound(err) {
+			return err
+		}
+		if config.Options.Offline {
+			missing = append(missing, image)
+			continue
+		}
+
+		if !quiet {
+			logger.Info("Pull image", "image", image)
+		}
+		_, err = client.Pull(ctx, ref, containerd.WithPullUnpack)
+		if err != nil {
+			return fmt.Errorf("failed to pull image %s: %w", image, err)
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("images %v not found by containerd, "+
+			"and the network is not used in offline mode, load them with 'kwokctl images load' first", missing)
+	}
+	return nil
+}
+
+func (c *Cluster) containerdSaveImages(ctx context.Context, images []string, archivePath string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Save images %s to %s", strings.Join(images, " "), archivePath)
+		return nil
+	}
+
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts := []archive.ExportOpt{
+		archive.WithPlatform(platforms.DefaultStrict()),
+	}
+	for _, image := range images {
+		ref, err := containerdImageRef(image)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, archive.WithImage(client.ImageService(), ref))
+	}
+
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return client.Export(ctx, f, opts...)
+}
+
+func (c *Cluster) containerdLoadImages(ctx context.Context, archivePath string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Load images from %s", archivePath)
+		return nil
+	}
+
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	imgs, err := client.Import(ctx, f, containerd.WithImportPlatform(platforms.DefaultStrict()))
+	if err != nil {
+		return err
+	}
+	logger := log.FromContext(ctx)
+	for _, img := range imgs {
+		err = containerd.NewImage(client, img).Unpack(ctx, "")
+		if err != nil {
+			return fmt.Errorf("failed to unpack image %s: %w", img.Name, err)
+		}
+		logger.Info("Loaded image", "image", img.Name)
+	}
+	return nil
+}
+
+func (c *Cluster) containerdParseVersionFromImage(ctx context.Context, image string, command string) (version.Version, error) {
+	if c.IsDryRun() {
+		return version.Unknown, nil
+	}
+
+	logger := log.FromContext(ctx)
+
+	// Try to parse the version from the image tag.
+	nameAndTag := strings.SplitN(image, ":", 2)
+	if len(nameAndTag) == 2 {
+		ver, err := semver.ParseTolerant(nameAndTag[1])
+		if err == nil {
+			return ver, nil
+		}
+	}
+
+	// Try to parse the version from the binary in the image.
+	var args []string
+	if command != "" {
+		args = append(args, command)
+	}
+	out := bytes.NewBuffer(nil)
+	err := c.containerdRun(ctx, image, append(args, "--version"), out)
+	if err != nil {
+		// try match jaeger version
+		out.Reset()
+		err = c.containerdRun(ctx, image, append(args, "version"), out)
+		if err != nil {
+			return version.Version{}, err
+		}
+	}
+	content := out.String()
+	ver, err := version.ParseFromOutput(content)
+	if err != nil {
+		logger.Warn("Failed to parse",
+			"image", image,
+			"output", content,
+			"err", err,
+		)
+		return version.Unknown, nil
+	}
+	return ver, nil
+}
+
+// containerdRun runs the image with the args in a temporary container and waits for it to exit.
+func (c *Cluster) containerdRun(ctx context.Context, image string, args []string, out io.Writer) (err error) {
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	ref, err := containerdImageRef(image)
+	if err != nil {
+		return err
+	}
+	img, err := client.GetImage(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	id := c.Name() + "-run-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	container, err := client.NewContainer(ctx, id,
+		containerd.WithImage(img),
+		containerd.WithNewSnapshot(id, img),
+		containerd.WithNewSpec(oci.WithImageConfigArgs(img, args)),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = container.Delete(ctx, containerd.WithSnapshotCleanup)
+	}()
+
+	task, err := container.NewTask(ctx, cio.NewCreator(cio.WithStreams(nil, out, out)))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = task.Delete(ctx, containerd.WithProcessKill)
+	}()
+	return containerdWaitProcess(ctx, task, strings.Join(args, " "))
+}
+
+// containerdWaitProcess starts the process and waits for it to exit successfully.
+func containerdWaitProcess(ctx context.Context, process containerd.Process, command string) error {
+	statusC, err := process.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	err = process.Start(ctx)
+	if err != nil {
+		return err
+	}
+	status := <-statusC
+	code, _, err := status.Result()
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return fmt.Errorf("%s exited with code %d", command, code)
+	}
+	return nil
+}
+
+func (c *Cluster) containerdCreateComponent(ctx context.Context, component internalversion.Component, cpu float64, memory int64) error {
+	id := c.Name() + "-" + component.Name
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Create container %s of image %s", id, component.Image)
+		return nil
+	}
+
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	ref, err := containerdImageRef(component.Image)
+	if err != nil {
+		return err
+	}
+	img, err := client.GetImage(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("failed to get image %s: %w", component.Image, err)
+	}
+
+	// The containers resolve each other by the hosts file which is updated as they are started
+	hostsPath := c.GetWorkdirPath("hosts")
+	if !file.Exists(hostsPath) {
+		err = c.WriteFileWithMode(hostsPath, containerdHosts(nil), 0644)
+		if err != nil {
+			return err
+		}
+	}
+	mounts := []specs.Mount{
+		{
+			Destination: "/etc/hosts",
+			Type:        "bind",
+			Source:      hostsPath,
+			Options:     []string{"rbind", "ro"},
+		},
+	}
+	for _, volume := range component.Volumes {
+		options := []string{"rbind", "rw"}
+		if volume.ReadOnly {
+			options = []string{"rbind", "ro"}
+		}
+		mounts = append(mounts, specs.Mount{
+			Destination: volume.MountPath,
+			Type:        "bind",
+			Source:      volume.HostPath,
+			Options:     options,
+		})
+	}
+
+	specOpts := []oci.SpecOpts{}
+	if len(component.Command) != 0 {
+		specOpts = append(specOpts,
+			oci.WithImageConfig(img),
+			oci.WithProcessArgs(append(component.Command, component.Args...)...),
+		)
+	} else {
+		specOpts = append(specOpts, oci.WithImageConfigArgs(img, component.Args))
+	}
+	specOpts = append(specOpts,
+		oci.WithHostname(id),
+		oci.WithMounts(mounts),
+	)
+	if len(component.Envs) != 0 {
+		envs := make([]string, 0, len(component.Envs))
+		for _, env := range component.Envs {
+			envs = append(envs, env.Name+"="+env.Value)
+		}
+		specOpts = append(specOpts, oci.WithEnv(envs))
+	}
+	if component.User != "" {
+		specOpts = append(specOpts, oci.WithUser(component.User))
+	}
+	if cpu != 0 {
+		const period = 100000
+		specOpts = append(specOpts, oci.WithCPUCFS(int64(cpu*period), period))
+	}
+	if memory != 0 {
+		specOpts = append(specOpts, oci.WithMemoryLimit(uint64(memory)))
+	}
+
+	_, err = client.NewContainer(ctx, id,
+		containerd.WithImage(img),
+		containerd.WithNewSnapshot(id, img),
+		containerd.WithNewSpec(specOpts...),
+		containerd.WithContainerLabels(map[string]string{
+			projectLabel: c.Name(),
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Cluster) containerdInspectComponent(ctx context.Context, componentName string) (running bool, exist bool) {
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return false, false
+	}
+	container, err := client.LoadContainer(ctx, c.Name()+"-"+componentName)
+	if err != nil {
+		return false, false
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return false, true
+	}
+	status, err := task.Status(ctx)
+	if err != nil {
+		return false, true
+	}
+	return status.Status == containerd.Running, true
+}
+
+func (c *Cluster) containerdComponentStartedAt(ctx context.Context, componentName string) (time.Time, error) {
+	if c.IsDryRun() {
+		return time.Time{}, nil
+	}
+	if running, _ := c.containerdInspectComponent(ctx, componentName); !running {
+		return time.Time{}, nil
+	}
+
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	container, err := client.LoadContainer(ctx, c.Name()+"-"+componentName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	labels, err := container.Labels(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, labels[containerdStartedAtLabel])
+}
+
+// containerdPortMappings returns the port mappings of the component for the portmap plugin
+func (c *Cluster) containerdPortMappings(ctx context.Context, componentName string) ([]gocni.PortMapping, error) {
+	component, err := c.GetComponent(ctx, componentName)
+	if err != nil {
+		return nil, err
+	}
+	var mappings []gocni.PortMapping
+	for _, port := range component.Ports {
+		if port.HostPort == 0 {
+			continue
+		}
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = internalversion.ProtocolTCP
+		}
+		mappings = append(mappings, gocni.PortMapping{
+			HostPort:      int32(port.HostPort),
+			ContainerPort: int32(port.Port),
+			Protocol:      strings.ToLower(string(protocol)),
+		})
+	}
+	return mappings, nil
+}
+
+func (c *Cluster) containerdStartComponent(ctx context.Context, componentName string) error {
+	id := c.Name() + "-" + componentName
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Start container %s", id)
+		return nil
+	}
+
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	cni, err := c.containerdCNI()
+	if err != nil {
+		return err
+	}
+	mappings, err := c.containerdPortMappings(ctx, componentName)
+	if err != nil {
+		return err
+	}
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Remove the task exited, only one task is allowed for a container
+	if task, err := container.Task(ctx, nil); err == nil {
+		err = cni.Remove(ctx, id, containerdNetNS(task.Pid()), gocni.WithCapabilityPortMap(mappings))
+		if err != nil {
+			logger := log.FromContext(ctx)
+			logger.Warn("Failed to remove the network", "container", id, "err", err)
+		}
+		_, err = task.Delete(ctx, containerd.WithProcessKill)
+		if err != nil {
+			return err
+		}
+	}
+
+	logPath := c.GetLogPath(componentName + ".log")
+	err = c.MkdirAll(filepath.Dir(logPath))
+	if err != nil {
+		return err
+	}
+	task, err := container.NewTask(ctx, cio.LogFile(logPath))
+	if err != nil {
+		return err
+	}
+
+	// Attach the task to the network before it is started
+	result, err := cni.Setup(ctx, id, containerdNetNS(task.Pid()), gocni.WithCapabilityPortMap(mappings))
+	if err != nil {
+		_, _ = task.Delete(ctx, containerd.WithProcessKill)
+		return fmt.Errorf("failed to setup the network of %s: %w", id, err)
+	}
+	var ip string
+	for _, iface := range result.Interfaces {
+		for _, conf := range iface.IPConfigs {
+			if conf.IP != nil && ip == "" {
+				ip = conf.IP.String()
+			}
+		}
+	}
+	_, err = container.SetLabels(ctx, map[string]string{
+		containerdIPLabel:        ip,
+		containerdStartedAtLabel: time.Now().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return err
+	}
+	err = c.containerdUpdateHosts(ctx)
+	if err != nil {
+		return err
+	}
+
+	return task.Start(ctx)
+}
+
+func (c *Cluster) containerdStopComponent(ctx context.Context, componentName string) error {
+	id := c.Name() + "-" + componentName
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Stop container %s", id)
+		return nil
+	}
+
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		return err
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	err = c.containerdRemoveNetwork(ctx, id, componentName, task.Pid())
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Warn("Failed to remove the network", "container", id, "err", err)
+	}
+
+	statusC, err := task.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	err = task.Kill(ctx, syscall.SIGKILL)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	<-statusC
+	_, err = task.Delete(ctx)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return err
+	}
+	return c.containerdUpdateHosts(ctx)
+}
+
+func (c *Cluster) containerdRemoveNetwork(ctx context.Context, id string, componentName string, pid uint32) error {
+	cni, err := c.containerdCNI()
+	if err != nil {
+		return err
+	}
+	mappings, err := c.containerdPortMappings(ctx, componentName)
+	if err != nil {
+		return err
+	}
+	return cni.Remove(ctx, id, containerdNetNS(pid), gocni.WithCapabilityPortMap(mappings))
+}
+
+func (c *Cluster) containerdDeleteComponent(ctx context.Context, componentName string) error {
+	id := c.Name() + "-" + componentName
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Remove container %s", id)
+		return nil
+	}
+
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if task, err := container.Task(ctx, nil); err == nil {
+		_, err = task.Delete(ctx, containerd.WithProcessKill)
+		if err != nil {
+			return err
+		}
+	}
+	return container.Delete(ctx, containerd.WithSnapshotCleanup)
+}
+
+// containerdNetNS returns the path of the network namespace of the process
+func containerdNetNS(pid uint32) string {
+	return fmt.Sprintf("/proc/%d/ns/net", pid)
+}
+
+// containerdHosts returns the hosts file with the ip addresses of the containers
+func containerdHosts(hosts map[string]string) []byte {
+	names := make([]string, 0, len(hosts))
+	for name := range hosts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString("127.0.0.1\tlocalhost\n")
+	buf.WriteString("::1\tlocalhost ip6-localhost ip6-loopback\n")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(buf, "%s\t%s\n", hosts[name], name)
+	}
+	return buf.Bytes()
+}
+
+// containerdUpdateHosts updates the hosts file with the ip addresses of the containers of which the task is alive,
+// the file is written in place so that it is seen by the containers which have mounted it.
+func (c *Cluster) containerdUpdateHosts(ctx context.Context) error {
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	containers, err := client.Containers(ctx, fmt.Sprintf("labels.%q==%s", projectLabel, c.Name()))
+	if err != nil {
+		return err
+	}
+
+	hosts := map[string]string{}
+	for _, container := range containers {
+		labels, err := container.Labels(ctx)
+		if err != nil {
+			return err
+		}
+		ip := labels[containerdIPLabel]
+		if ip == "" {
+			continue
+		}
+		task, err := container.Task(ctx, nil)
+		if err != nil {
+			continue
+		}
+		status, err := task.Status(ctx)
+		if err != nil {
+			continue
+		}
+		if status.Status != containerd.Running && status.Status != containerd.Created {
+			continue
+		}
+		hosts[container.ID()] = ip
+	}
+	return c.WriteFileWithMode(c.GetWorkdirPath("hosts"), containerdHosts(hosts), 0644)
+}
+
+func (c *Cluster) containerdLogs(ctx context.Context, name string, out io.Writer, follow bool) error {
+	logs := c.GetLogPath(name + ".log")
+	if c.IsDryRun() {
+		if follow {
+			dryrun.PrintMessage("tail -f %s", logs)
+		} else if file, ok := dryrun.IsCatToFileWriter(out); ok {
+			dryrun.PrintMessage("cp %s %s", logs, file)
+		} else {
+			dryrun.PrintMessage("cat %s", logs)
+		}
+		return nil
+	}
+
+	f, err := os.Open(logs)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", logs, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	for {
+		_, err = io.Copy(out, f)
+		if err != nil {
+			return err
+		}
+		if !follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// containerdExecComponent executes the command in the running container of the component.
+func (c *Cluster) containerdExecComponent(ctx context.Context, componentName string, envs []string, args ...string) error {
+	id := c.Name() + "-" + componentName
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Exec %q in container %s", strings.Join(args, " "), id)
+		return nil
+	}
+
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		return err
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("component %s is not running: %w", componentName, err)
+	}
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return err
+	}
+
+	pspec := spec.Process
+	pspec.Terminal = false
+	pspec.Args = args
+	pspec.Env = append(pspec.Env, envs...)
+
+	opt := exec.GetExecOptions(ctx)
+	var stdout, stderr io.Writer = io.Discard, io.Discard
+	if opt.Out != nil {
+		stdout = opt.Out
+	}
+	if opt.ErrOut != nil {
+		stderr = opt.ErrOut
+	}
+
+	execID := "exec-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	process, err := task.Exec(ctx, execID, pspec, cio.NewCreator(cio.WithStreams(nil, stdout, stderr)))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = process.Delete(ctx)
+	}()
+	return containerdWaitProcess(ctx, process, strings.Join(args, " "))
+}
+
+// containerdRootfs calls the fn with the root filesystem of the container,
+// which is the one of the running task, otherwise the snapshot is mounted temporarily.
+func (c *Cluster) containerdRootfs(ctx context.Context, componentName string, fn func(root string) error) error {
+	client, err := c.containerdClient(ctx)
+	if err != nil {
+		return err
+	}
+	container, err := client.LoadContainer(ctx, c.Name()+"-"+componentName)
+	if err != nil {
+		return err
+	}
+	if task, err := container.Task(ctx, nil); err == nil {
+		status, err := task.Status(ctx)
+		if err == nil && status.Status == containerd.Running {
+			return fn(fmt.Sprintf("/proc/%d/root", task.Pid()))
+		}
+	}
+
+	info, err := container.Info(ctx)
+	if err != nil {
+		return err
+	}
+	mounts, err := client.SnapshotService(info.Snapshotter).Mounts(ctx, info.SnapshotKey)
+	if err != nil {
+		return err
+	}
+	return mount.WithTempMount(ctx, mounts, fn)
+}
+
+// containerdCopyFromComponent copies the file in the container of the component to the host
+func (c *Cluster) containerdCopyFromComponent(ctx context.Context, componentName string, src, dest string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Copy %s in container %s-%s to %s", src, c.Name(), componentName, dest)
+		return nil
+	}
+	return c.containerdRootfs(ctx, componentName, func(root string) error {
+		return copyPath(filepath.Join(root, src), dest)
+	})
+}
+
+// containerdCopyToComponent replaces the file or directory in the container of the component with the one on the host
+func (c *Cluster) containerdCopyToComponent(ctx context.Context, componentName string, src, dest string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Copy %s to %s in container %s-%s", src, dest, c.Name(), componentName)
+		return nil
+	}
+	return c.containerdRootfs(ctx, componentName, func(root string) error {
+		target := filepath.Join(root, dest)
+		err := os.RemoveAll(target)
+		if err != nil {
+			return err
+		}
+		return copyPath(src, target)
+	})
+}
+
+// copyPath copies the file or the directory recursively
+func copyPath(src, dest string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, data, info.Mode().Perm())
+		}
+	})
+}
+
+// pruneContainerdOrphans removes the containers of the clusters which no longer exist
+func pruneContainerdOrphans(ctx context.Context, clusters []string) error {
+	if !file.Exists(containerdAddress) {
+		return nil
+	}
+
+	client, err := containerd.New(containerdAddress, containerd.WithDefaultNamespace(containerdNamespace))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	containers, err := client.Containers(ctx, fmt.Sprintf("labels.%q", projectLabel))
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+	cni, err := newContainerdCNI()
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	exists := map[string]bool{}
+	for _, cluster := range clusters {
+		exists[cluster] = true
+	}
+	for _, container := range containers {
+		labels, err := container.Labels(ctx)
+		if err != nil {
+			return err
+		}
+		project := labels[projectLabel]
+		if !strings.HasPrefix(project, consts.ProjectName+"-") || exists[project] {
+			continue
+		}
+		if dryrun.DryRun {
+			dryrun.PrintMessage("# Remove container %s", container.ID())
+			continue
+		}
+		if task, err := container.Task(ctx, nil); err == nil {
+			err = cni.Remove(ctx, container.ID(), containerdNetNS(task.Pid()))
+			if err != nil {
+				logger.Warn("Failed to remove the network", "container", container.ID(), "err", err)
+			}
+			_, err = task.Delete(ctx, containerd.WithProcessKill)
+			if err != nil && !errdefs.IsNotFound(err) {
+				return err
+			}
+		}
+		err = container.Delete(ctx, containerd.WithSnapshotCleanup)
+		if err != nil {
+			return err
+		}
+		logger.Info("Removed orphan", "kind", "container", "name", container.ID())
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_containerdImageRef(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{
+			image: "busybox",
+			want:  "docker.io/library/busybox:latest",
+		},
+		{
+			image: "prom/prometheus:v2.44.0",
+			want:  "docker.io/prom/prometheus:v2.44.0",
+		},
+		{
+			image: "registry.k8s.io/etcd:3.5.9-0",
+			want:  "registry.k8s.io/etcd:3.5.9-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			got, err := containerdImageRef(tt.image)
+			if err != nil {
+				t.Fatalf("containerdImageRef() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("containerdImageRef() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_containerdHosts(t *testing.T) {
+	got := string(containerdHosts(map[string]string{
+		"kwok-kwok-kube-apiserver": "10.5.0.3",
+		"kwok-kwok-etcd":           "10.5.0.2",
+	}))
+	want := "127.0.0.1\tlocalhost\n" +
+		"::1\tlocalhost ip6-localhost ip6-loopback\n" +
+		"10.5.0.2\tkwok-kwok-etcd\n" +
+		"10.5.0.3\tkwok-kwok-kube-apiserver\n"
+	if got != want {
+		t.Errorf("containerdHosts() got = %q, want %q", got, want)
+	}
+}
+
+func Test_copyPath(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "etcd-data")
+	err := os.MkdirAll(filepath.Join(src, "member", "snap"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(src, "member", "snap", "db"), []byte("data"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "etcd-data")
+	err = copyPath(src, dest)
+	if err != nil {
+		t.Fatalf("copyPath() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dest, "member", "snap", "db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "data" {
+		t.Errorf("copyPath() got = %q, want %q", got, "data")
+	}
+}
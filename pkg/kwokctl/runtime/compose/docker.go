@@ -0,0 +1,499 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+// useDockerAPI returns whether the docker is managed with its API instead of the docker command,
+// the dry run prints the equivalent docker commands, and the docker command is the fallback
+// if the API is not reachable, e.g. the host of the docker context is over ssh.
+func (c *Cluster) useDockerAPI(ctx context.Context) bool {
+	if c.runtime != consts.RuntimeTypeDocker || c.IsDryRun() {
+		return false
+	}
+	c.dockerCliOnce.Do(func() {
+		c.dockerCliOpts, c.dockerCliErr = dockerClientOpts(ctx)
+		if c.dockerCliErr != nil {
+			logger := log.FromContext(ctx)
+			logger.Debug("Failed to connect to the docker API, fallback to the docker command", "err", c.dockerCliErr)
+		}
+	})
+	return c.dockerCliErr == nil
+}
+
+// dockerClient returns a client of the docker API for an operation, which is closed by the caller once the operation is done.
+func (c *Cluster) dockerClient() (*dockerclient.Client, error) {
+	return dockerclient.NewClientWithOpts(c.dockerCliOpts...)
+}
+
+// dockerClientOpts returns the options to connect to the docker of DOCKER_HOST or the current docker context,
+// and checks that the docker API is reachable with them.
+func dockerClientOpts(ctx context.Context) ([]dockerclient.Opt, error) {
+	opts := []dockerclient.Opt{
+		dockerclient.FromEnv,
+		dockerclient.WithAPIVersionNegotiation(),
+	}
+	if os.Getenv(dockerclient.EnvOverrideHost) == "" {
+		// The rootless docker and the docker desktop are served at the host of the docker context
+		buf := bytes.NewBuffer(nil)
+		err := exec.Exec(exec.WithWriteTo(ctx, buf), consts.RuntimeTypeDocker, "context", "inspect", "--format={{ .Endpoints.docker.Host }}")
+		if err == nil {
+			host := strings.TrimSpace(buf.String())
+			if strings.HasPrefix(host, "ssh://") {
+				return nil, fmt.Errorf("the docker host %s over ssh is not supported by the API", host)
+			}
+			if host != "" {
+				opts = append(opts, dockerclient.WithHost(host))
+			}
+		}
+	}
+
+	cli, err := dockerclient.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+	_, err = cli.Ping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func (c *Cluster) dockerPullImages(ctx context.Context, images []string, quiet bool) error {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	platform := runtime.GetImagePlatform(&conf.Options)
+	logger := log.FromContext(ctx)
+	var missing []string
+	for _, image := range images {
+		inspect, _, err := cli.ImageInspectWithRaw(ctx, image)
+		if err == nil {
+			got := inspect.Os + "/" + inspect.Architecture
+			if platform == "" || got == platform {
+				logger.Debug("Image already exists", "image", image)
+				continue
+			}
+			logger.Info("Image exists for another platform", "image", image, "platform", got)
+		} else if !errdefs.IsNotFound(err) {
+			return err
+		}
+		if conf.Options.Offline {
+			missing = append(missing, image)
+			continue
+		}
+
+		logger.Info("Pull image", "image", image)
+		err = dockerPullImage(ctx, cli, image, platform, quiet)
+		if err != nil {
+			// The credentials of the registries are only known by the docker command
+			logger.Warn("Failed to pull image with the docker API, retry with the docker command",
+				"image", image,
+				"err", err,
+			)
+			err = exec.PullImages(ctx, c.runtime, []string{image}, platform, quiet)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("images %v not found by %s, and the network is not used in offline mode, load them with 'kwokctl images load' first", missing, c.runtime)
+	}
+	return nil
+}
+
+func dockerPullImage(ctx context.Context, cli *dockerclient.Client, image string, platform string, quiet bool) error {
+	reader, err := cli.ImagePull(ctx, image, types.ImagePullOptions{
+		Platform: platform,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	var out io.Writer = io.Discard
+	if !quiet {
+		out = os.Stderr
+	}
+	fd, isTerminal := dockerTerminal(out)
+	return jsonmessage.DisplayJSONMessagesStream(reader, out, fd, isTerminal, nil)
+}
+
+// dockerTerminal returns the file descriptor of the writer and whether it is a terminal,
+// the progress bars are only drawn on the terminal.
+func dockerTerminal(out io.Writer) (uintptr, bool) {
+	f, ok := out.(*os.File)
+	if !ok {
+		return 0, false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, false
+	}
+	return f.Fd(), fi.Mode()&os.ModeCharDevice != 0
+}
+
+func (c *Cluster) dockerInspectNetwork(ctx context.Context, name string) (exist bool) {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	_, err = cli.NetworkInspect(ctx, name, types.NetworkInspectOptions{})
+	return err == nil
+}
+
+func (c *Cluster) dockerCreateNetwork(ctx context.Context, name string, ipv6 bool) error {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	_, err = cli.NetworkCreate(ctx, name, types.NetworkCreate{
+		CheckDuplicate: true,
+		EnableIPv6:     ipv6,
+		Labels: map[string]string{
+			projectLabel: c.Name(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create network %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Cluster) dockerDeleteNetwork(ctx context.Context, name string) error {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	err = cli.NetworkRemove(ctx, name)
+	if err != nil && !errdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to delete network %s: %w", name, err)
+	}
+	return nil
+}
+
+// dockerContainerConfig returns the configurations of the container which are the same as the ones of docker create.
+func dockerContainerConfig(name string, networkName string, component internalversion.Component, cpu float64, memory int64) (*container.Config, *container.HostConfig, *network.NetworkingConfig) {
+	config := &container.Config{
+		Image: component.Image,
+		Cmd:   component.Args,
+		User:  component.User,
+		Labels: map[string]string{
+			projectLabel: name,
+		},
+	}
+	if len(component.Command) != 0 {
+		config.Entrypoint = component.Command
+	}
+	for _, env := range component.Envs {
+		config.Env = append(config.Env, env.Name+"="+env.Value)
+	}
+
+	hostConfig := &container.HostConfig{
+		NetworkMode: container.NetworkMode(networkName),
+		RestartPolicy: container.RestartPolicy{
+			Name: "unless-stopped",
+		},
+		Resources: container.Resources{
+			NanoCPUs: int64(cpu * 1e9),
+			Memory:   memory,
+		},
+	}
+	if len(component.Ports) != 0 {
+		config.ExposedPorts = nat.PortSet{}
+		hostConfig.PortBindings = nat.PortMap{}
+		for _, port := range component.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = internalversion.ProtocolTCP
+			}
+			p := nat.Port(format.String(port.Port) + "/" + strings.ToLower(string(protocol)))
+			config.ExposedPorts[p] = struct{}{}
+			hostConfig.PortBindings[p] = append(hostConfig.PortBindings[p], nat.PortBinding{
+				HostPort: format.String(port.HostPort),
+			})
+		}
+	}
+	for _, volume := range component.Volumes {
+		if volume.ReadOnly {
+			hostConfig.Binds = append(hostConfig.Binds, volume.HostPath+":"+volume.MountPath+":ro")
+		} else {
+			hostConfig.Binds = append(hostConfig.Binds, volume.HostPath+":"+volume.MountPath)
+		}
+	}
+
+	endpoint := &network.EndpointSettings{
+		Aliases: []string{component.Name},
+	}
+	for _, link := range component.Links {
+		endpoint.Links = append(endpoint.Links, name+"-"+link+":"+name+"-"+link)
+	}
+	networkingConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			networkName: endpoint,
+		},
+	}
+	return config, hostConfig, networkingConfig
+}
+
+func (c *Cluster) dockerCreateComponent(ctx context.Context, component internalversion.Component, cpu float64, memory int64) error {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	id := c.Name() + "-" + component.Name
+	config, hostConfig, networkingConfig := dockerContainerConfig(c.Name(), c.networkName(), component, cpu, memory)
+	_, err = cli.ContainerCreate(ctx, config, hostConfig, networkingConfig, nil, id)
+	if err != nil {
+		return fmt.Errorf("failed to create container %s: %w", id, err)
+	}
+	return nil
+}
+
+func (c *Cluster) dockerInspectComponent(ctx context.Context, componentName string) (running bool, exist bool) {
+	cli, err := c.dockerClient()
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Warn("Failed to inspect component", "component", componentName, "err", err)
+		return false, false
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	info, err := cli.ContainerInspect(ctx, c.Name()+"-"+componentName)
+	if err != nil {
+		if !errdefs.IsNotFound(err) {
+			logger := log.FromContext(ctx)
+			logger.Warn("Failed to inspect component", "component", componentName, "err", err)
+		}
+		return false, false
+	}
+	return info.State != nil && info.State.Running, true
+}
+
+func (c *Cluster) dockerStartComponent(ctx context.Context, componentName string) error {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	id := c.Name() + "-" + componentName
+	err = cli.ContainerStart(ctx, id, types.ContainerStartOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to start container %s: %w", id, err)
+	}
+	return nil
+}
+
+func (c *Cluster) dockerStopComponent(ctx context.Context, componentName string) error {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	id := c.Name() + "-" + componentName
+	err = cli.ContainerStop(ctx, id, container.StopOptions{
+		Timeout: format.Ptr(0),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stop container %s: %w", id, err)
+	}
+	return nil
+}
+
+func (c *Cluster) dockerDeleteComponent(ctx context.Context, componentName string) error {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	id := c.Name() + "-" + componentName
+	err = cli.ContainerRemove(ctx, id, types.ContainerRemoveOptions{
+		Force: true,
+	})
+	if err != nil && !errdefs.IsNotFound(err) {
+		return fmt.Errorf("failed to delete container %s: %w", id, err)
+	}
+	return nil
+}
+
+func (c *Cluster) dockerComponentStartedAt(ctx context.Context, componentName string) (time.Time, error) {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	info, err := cli.ContainerInspect(ctx, c.Name()+"-"+componentName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if info.State == nil || !info.State.Running {
+		return time.Time{}, nil
+	}
+	startedAt, err := time.Parse(time.RFC3339Nano, info.State.StartedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse the start time of %s: %w", componentName, err)
+	}
+	return startedAt, nil
+}
+
+func (c *Cluster) dockerLogs(ctx context.Context, name string, out io.Writer, follow bool) error {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	reader, err := cli.ContainerLogs(ctx, c.Name()+"-"+name, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     follow,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	_, err = stdcopy.StdCopy(out, out, reader)
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// dockerExecComponent executes the command in the running container of the component.
+func (c *Cluster) dockerExecComponent(ctx context.Context, componentName string, envs []string, args ...string) error {
+	cli, err := c.dockerClient()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = cli.Close()
+	}()
+
+	id := c.Name() + "-" + componentName
+	resp, err := cli.ContainerExecCreate(ctx, id, types.ExecConfig{
+		Env:          envs,
+		Cmd:          args,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to exec in container %s: %w", id, err)
+	}
+
+	attach, err := cli.ContainerExecAttach(ctx, resp.ID, types.ExecStartCheck{})
+	if err != nil {
+		return fmt.Errorf("failed to attach to the exec in container %s: %w", id, err)
+	}
+	defer attach.Close()
+
+	opt := exec.GetExecOptions(ctx)
+	var stdout, stderr io.Writer = io.Discard, io.Discard
+	if opt.Out != nil {
+		stdout = opt.Out
+	}
+	if opt.ErrOut != nil {
+		stderr = opt.ErrOut
+	}
+	_, err = stdcopy.StdCopy(stdout, stderr, attach.Reader)
+	if err != nil {
+		return err
+	}
+
+	inspect, err := cli.ContainerExecInspect(ctx, resp.ID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("%q exited in container %s with code %d", strings.Join(args, " "), id, inspect.ExitCode)
+	}
+	return nil
+}
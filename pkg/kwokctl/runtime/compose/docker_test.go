@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func Test_dockerContainerConfig(t *testing.T) {
+	component := internalversion.Component{
+		Name:    "kube-apiserver",
+		Image:   "registry.k8s.io/kube-apiserver:v1.28.0",
+		Command: []string{"kube-apiserver"},
+		Args:    []string{"--etcd-servers=http://kwok-kwok-etcd:2379"},
+		Links:   []string{"etcd"},
+		Ports: []internalversion.Port{
+			{
+				HostPort: 32766,
+				Port:     6443,
+			},
+		},
+		Volumes: []internalversion.Volume{
+			{
+				HostPath:  "/workdir/pki/ca.crt",
+				MountPath: "/etc/kubernetes/pki/ca.crt",
+				ReadOnly:  true,
+			},
+			{
+				HostPath:  "/workdir/logs/audit.log",
+				MountPath: "/var/log/kubernetes/audit/audit.log",
+			},
+		},
+		Envs: []internalversion.Env{
+			{
+				Name:  "GOMAXPROCS",
+				Value: "1",
+			},
+		},
+	}
+	config, hostConfig, networkingConfig := dockerContainerConfig("kwok-kwok", "kwok-kwok", component, 0.5, 1<<30)
+
+	wantConfig := &container.Config{
+		Image:      "registry.k8s.io/kube-apiserver:v1.28.0",
+		Entrypoint: []string{"kube-apiserver"},
+		Cmd:        []string{"--etcd-servers=http://kwok-kwok-etcd:2379"},
+		Env:        []string{"GOMAXPROCS=1"},
+		Labels: map[string]string{
+			"com.docker.compose.project": "kwok-kwok",
+		},
+		ExposedPorts: nat.PortSet{
+			"6443/tcp": struct{}{},
+		},
+	}
+	if !reflect.DeepEqual(config, wantConfig) {
+		t.Errorf("dockerContainerConfig() config = %+v, want %+v", config, wantConfig)
+	}
+
+	wantHostConfig := &container.HostConfig{
+		NetworkMode: "kwok-kwok",
+		RestartPolicy: container.RestartPolicy{
+			Name: "unless-stopped",
+		},
+		Resources: container.Resources{
+			NanoCPUs: 500000000,
+			Memory:   1 << 30,
+		},
+		PortBindings: nat.PortMap{
+			"6443/tcp": []nat.PortBinding{{HostPort: "32766"}},
+		},
+		Binds: []string{
+			"/workdir/pki/ca.crt:/etc/kubernetes/pki/ca.crt:ro",
+			"/workdir/logs/audit.log:/var/log/kubernetes/audit/audit.log",
+		},
+	}
+	if !reflect.DeepEqual(hostConfig, wantHostConfig) {
+		t.Errorf("dockerContainerConfig() hostConfig = %+v, want %+v", hostConfig, wantHostConfig)
+	}
+
+	wantNetworkingConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			"kwok-kwok": {
+				Links:   []string{"kwok-kwok-etcd:kwok-kwok-etcd"},
+				Aliases: []string{"kube-apiserver"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(networkingConfig, wantNetworkingConfig) {
+		t.Errorf("dockerContainerConfig() networkingConfig = %+v, want %+v", networkingConfig, wantNetworkingConfig)
+	}
+}
@@ -25,4 +25,11 @@ func init() {
 	runtime.DefaultRegistry.Register(consts.RuntimeTypeDocker, NewDockerCluster)
 	runtime.DefaultRegistry.Register(consts.RuntimeTypeNerdctl, NewNerdctlCluster)
 	runtime.DefaultRegistry.Register(consts.RuntimeTypePodman, NewPodmanCluster)
+	runtime.DefaultRegistry.Register(consts.RuntimeTypeContainerd, NewContainerdCluster)
+	runtime.DefaultRegistry.Register(consts.RuntimeTypeKubernetes, NewKubernetesCluster)
+
+	runtime.DefaultRegistry.RegisterPruneOrphans(consts.RuntimeTypeDocker, pruneOrphans(consts.RuntimeTypeDocker))
+	runtime.DefaultRegistry.RegisterPruneOrphans(consts.RuntimeTypeNerdctl, pruneOrphans(consts.RuntimeTypeNerdctl))
+	runtime.DefaultRegistry.RegisterPruneOrphans(consts.RuntimeTypePodman, pruneOrphans(consts.RuntimeTypePodman))
+	runtime.DefaultRegistry.RegisterPruneOrphans(consts.RuntimeTypeContainerd, pruneContainerdOrphans)
 }
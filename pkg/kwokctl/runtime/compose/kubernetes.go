@@ -0,0 +1,1001 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	refdocker "github.com/containerd/containerd/reference/docker"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/envs"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
+)
+
+var (
+	kubernetesHostKubeconfig = envs.GetEnvWithPrefix("HOST_KUBECONFIG", "")
+	kubernetesHostContext    = envs.GetEnvWithPrefix("HOST_CONTEXT", "")
+)
+
+const (
+	// kubernetesHostKubeconfigName is the kubeconfig of the host cluster which is frozen as the cluster is created,
+	// so that the cluster is always managed in the same host cluster even if the current context is changed.
+	kubernetesHostKubeconfigName = "host.kubeconfig"
+	// kubernetesComponentLabel is the label of the resources of the component
+	kubernetesComponentLabel = "kwok.x-k8s.io/component"
+	// kubernetesDefaultStorageClassAnnotation marks the default storage class of the host cluster
+	kubernetesDefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	// kubernetesEtcdDataSize is the size of the volume claimed for the data of etcd
+	kubernetesEtcdDataSize = "1Gi"
+)
+
+var (
+	errKubernetesSnapshot = errors.New("the etcd snapshot of the kubernetes runtime is not supported, use --format=k8s instead")
+	errKubernetesImages   = errors.New("the images of the kubernetes runtime are pulled by the nodes of the host cluster, the archive is not supported")
+)
+
+// kubernetesLoadHostKubeconfig loads the kubeconfig of the host cluster,
+// only the context selected by KWOK_HOST_CONTEXT or the current context is kept.
+func kubernetesLoadHostKubeconfig() (*clientcmdapi.Config, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubernetesHostKubeconfig
+	raw, err := rules.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig of the host cluster: %w", err)
+	}
+
+	contextName := kubernetesHostContext
+	if contextName == "" {
+		contextName = raw.CurrentContext
+	}
+	if contextName == "" {
+		return nil, fmt.Errorf("no context of the host cluster is found, set KWOK_HOST_KUBECONFIG or KWOK_HOST_CONTEXT to the one to use")
+	}
+	if _, ok := raw.Contexts[contextName]; !ok {
+		return nil, fmt.Errorf("the context %q of the host cluster is not found", contextName)
+	}
+	raw.CurrentContext = contextName
+
+	err = clientcmdapi.MinifyConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	err = clientcmdapi.FlattenConfig(raw)
+	if err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func (c *Cluster) kubernetesFreezeHostKubeconfig(ctx context.Context) error {
+	hostKubeconfigPath := c.GetWorkdirPath(kubernetesHostKubeconfigName)
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Save the kubeconfig of the host cluster to %s", hostKubeconfigPath)
+		return nil
+	}
+
+	raw, err := kubernetesLoadHostKubeconfig()
+	if err != nil {
+		return err
+	}
+	data, err := clientcmd.Write(*raw)
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("Using the host cluster", "context", raw.CurrentContext)
+	return c.WriteFileWithMode(hostKubeconfigPath, data, 0600)
+}
+
+func (c *Cluster) kubernetesClientset() (client.Clientset, error) {
+	if c.hostClientset != nil {
+		return c.hostClientset, nil
+	}
+
+	hostKubeconfigPath := c.GetWorkdirPath(kubernetesHostKubeconfigName)
+	if !file.Exists(hostKubeconfigPath) {
+		return nil, fmt.Errorf("the kubeconfig of the host cluster %s is not found", hostKubeconfigPath)
+	}
+	clientset, err := client.NewClientset("", hostKubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	c.hostClientset = clientset
+	return clientset, nil
+}
+
+func (c *Cluster) kubernetesClient() (kubernetes.Interface, error) {
+	clientset, err := c.kubernetesClientset()
+	if err != nil {
+		return nil, err
+	}
+	return clientset.ToTypedClient()
+}
+
+func (c *Cluster) kubernetesAvailable(ctx context.Context) error {
+	var typedClient kubernetes.Interface
+	if file.Exists(c.GetWorkdirPath(kubernetesHostKubeconfigName)) {
+		cli, err := c.kubernetesClient()
+		if err != nil {
+			return err
+		}
+		typedClient = cli
+	} else {
+		// The cluster is not created yet, so check the host cluster which is going to be used.
+		raw, err := kubernetesLoadHostKubeconfig()
+		if err != nil {
+			return err
+		}
+		restConfig, err := clientcmd.NewDefaultClientConfig(*raw, nil).ClientConfig()
+		if err != nil {
+			return err
+		}
+		cli, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return err
+		}
+		typedClient = cli
+	}
+
+	_, err := typedClient.Discovery().ServerVersion()
+	if err != nil {
+		return fmt.Errorf("the host cluster is not available: %w", err)
+	}
+	return nil
+}
+
+func (c *Cluster) kubernetesInfo(ctx context.Context, infoPath string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Save the version of the host cluster to %s", infoPath)
+		return nil
+	}
+
+	raw, err := clientcmd.LoadFromFile(c.GetWorkdirPath(kubernetesHostKubeconfigName))
+	if err != nil {
+		return err
+	}
+	server := ""
+	if hostContext, ok := raw.Contexts[raw.CurrentContext]; ok {
+		if cluster, ok := raw.Clusters[hostContext.Cluster]; ok {
+			server = cluster.Server
+		}
+	}
+
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return err
+	}
+	ver, err := typedClient.Discovery().ServerVersion()
+	if err != nil {
+		return err
+	}
+	info := fmt.Sprintf("Context: %s\nServer: %s\nNamespace: %s\nVersion: %s\nPlatform: %s\n",
+		raw.CurrentContext, server, c.networkName(), ver.GitVersion, ver.Platform)
+	return c.WriteFile(infoPath, []byte(info))
+}
+
+// kubernetesSetup prepares the host cluster before the components are configured,
+// the kube-apiserver is exposed first as its address is required by the pki and the kubeconfig.
+func (c *Cluster) kubernetesSetup(ctx context.Context, env *env) error {
+	err := c.kubernetesFreezeHostKubeconfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.createNetwork(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.kubernetesExposeKubeApiserver(ctx, env)
+}
+
+func (c *Cluster) kubernetesLabels(componentName string) map[string]string {
+	labels := map[string]string{
+		projectLabel: c.Name(),
+	}
+	if componentName != "" {
+		labels[kubernetesComponentLabel] = componentName
+	}
+	return labels
+}
+
+func (c *Cluster) kubernetesCreateNamespace(ctx context.Context) error {
+	namespace := c.networkName()
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Create namespace %s in the host cluster", namespace)
+		return nil
+	}
+
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return err
+	}
+	ns, err := typedClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err == nil {
+		if ns.Labels[projectLabel] != c.Name() {
+			return fmt.Errorf("namespace %s already exists in the host cluster and is not managed by kwokctl", namespace)
+		}
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	_, err = typedClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   namespace,
+			Labels: c.kubernetesLabels(""),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Cluster) kubernetesDeleteNamespace(ctx context.Context) error {
+	namespace := c.networkName()
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Delete namespace %s in the host cluster", namespace)
+		return nil
+	}
+
+	// Nothing is created in the host cluster if the creation failed before the kubeconfig is saved.
+	if !file.Exists(c.GetWorkdirPath(kubernetesHostKubeconfigName)) {
+		return nil
+	}
+
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return err
+	}
+	err = typedClient.CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// Wait for the namespace to be terminated, so that the cluster can be recreated immediately.
+	return wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		_, err := typedClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return false, nil
+	},
+		wait.WithTimeout(5*time.Minute),
+	)
+}
+
+// kubernetesNodeAddress returns the address of the nodes to reach the node ports, the external one is preferred.
+func kubernetesNodeAddress(nodes []corev1.Node) (string, error) {
+	for _, addressType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		for _, node := range nodes {
+			for _, address := range node.Status.Addresses {
+				if address.Type == addressType && address.Address != "" {
+					return address.Address, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("no address of the nodes is found")
+}
+
+// kubernetesExposeKubeApiserver exposes the kube-apiserver on the node port,
+// the port and the address of the nodes are used by the kubeconfig on the host.
+func (c *Cluster) kubernetesExposeKubeApiserver(ctx context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+	namespace := c.networkName()
+	name := c.Name() + "-" + consts.ComponentKubeApiserver + "-external"
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Create service %s exposing %s on the node port in namespace %s", name, consts.ComponentKubeApiserver, namespace)
+		return nil
+	}
+
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return err
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    c.kubernetesLabels(consts.ComponentKubeApiserver),
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeNodePort,
+			Selector: map[string]string{
+				kubernetesComponentLabel: consts.ComponentKubeApiserver,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       env.scheme,
+					Port:       int32(env.inClusterPort),
+					TargetPort: intstr.FromInt(int(env.inClusterPort)),
+					NodePort:   int32(conf.KubeApiserverPort),
+				},
+			},
+		},
+	}
+	service, err = typedClient.CoreV1().Services(namespace).Create(ctx, service, metav1.CreateOptions{})
+	if err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to expose %s on the node port: %w", consts.ComponentKubeApiserver, err)
+		}
+		service, err = typedClient.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	conf.KubeApiserverPort = uint32(service.Spec.Ports[0].NodePort)
+
+	logger := log.FromContext(ctx)
+	nodes, err := typedClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Warn("Failed to list the nodes of the host cluster, "+
+			"use kubectl port-forward to reach the kube-apiserver",
+			"err", err,
+		)
+		return nil
+	}
+	address, err := kubernetesNodeAddress(nodes.Items)
+	if err != nil {
+		logger.Warn("Failed to get the address of the nodes of the host cluster, "+
+			"use kubectl port-forward to reach the kube-apiserver",
+			"err", err,
+		)
+		return nil
+	}
+	env.hostAddress = address
+	return nil
+}
+
+func (c *Cluster) kubernetesParseVersionFromImage(ctx context.Context, image string, command string) (version.Version, error) {
+	if c.IsDryRun() {
+		return version.Unknown, nil
+	}
+
+	// The images are pulled by the nodes of the host cluster,
+	// so the version can only be parsed from the image tag.
+	named, err := refdocker.ParseNormalizedNamed(image)
+	if err == nil {
+		if tagged, ok := named.(refdocker.Tagged); ok {
+			ver, err := semver.ParseTolerant(tagged.Tag())
+			if err == nil {
+				return ver, nil
+			}
+		}
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Warn("Failed to parse the version from the image tag, fallback to the latest",
+		"image", image,
+		"command", command,
+	)
+	return version.Unknown, nil
+}
+
+// kubernetesVolumes converts the volumes of the component, as the host paths are not on the nodes of the host cluster,
+// the read-only ones are carried by the secret and the writable ones are backed by the empty dirs.
+func kubernetesVolumes(secretName string, volumes []internalversion.Volume) (map[string][]byte, []corev1.Volume, []corev1.VolumeMount, error) {
+	data := map[string][]byte{}
+	podVolumes := []corev1.Volume{}
+	mounts := []corev1.VolumeMount{}
+	for i, volume := range volumes {
+		volumeName := "volume-" + strconv.Itoa(i)
+		fi, err := os.Stat(volume.HostPath)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		var items []corev1.KeyToPath
+		if volume.ReadOnly {
+			names := []string{}
+			if fi.IsDir() {
+				entries, err := os.ReadDir(volume.HostPath)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				for _, entry := range entries {
+					if entry.Type().IsRegular() {
+						names = append(names, entry.Name())
+					}
+				}
+			} else {
+				names = append(names, filepath.Base(volume.HostPath))
+			}
+			for j, name := range names {
+				hostPath := volume.HostPath
+				if fi.IsDir() {
+					hostPath = filepath.Join(hostPath, name)
+				}
+				content, err := os.ReadFile(hostPath)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				key := fmt.Sprintf("%d-%d", i, j)
+				data[key] = content
+				items = append(items, corev1.KeyToPath{
+					Key:  key,
+					Path: name,
+				})
+			}
+		}
+
+		// The empty secret volume projects all the keys, so the empty dir is used instead.
+		if len(items) == 0 {
+			mountPath := volume.MountPath
+			if !fi.IsDir() {
+				mountPath = filepath.ToSlash(filepath.Dir(mountPath))
+			}
+			podVolumes = append(podVolumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			})
+			mounts = append(mounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: mountPath,
+			})
+			continue
+		}
+
+		podVolumes = append(podVolumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretName,
+					Items:      items,
+				},
+			},
+		})
+		mount := corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: volume.MountPath,
+			ReadOnly:  true,
+		}
+		if !fi.IsDir() {
+			mount.SubPath = items[0].Path
+		}
+		mounts = append(mounts, mount)
+	}
+	return data, podVolumes, mounts, nil
+}
+
+// kubernetesEtcdDataVolume returns the volume keeping the data of etcd while the component is stopped,
+// it is claimed from the default storage class of the host cluster, otherwise the data is lost.
+func (c *Cluster) kubernetesEtcdDataVolume(ctx context.Context, typedClient kubernetes.Interface, name string) (corev1.Volume, error) {
+	logger := log.FromContext(ctx)
+	volume := corev1.Volume{
+		Name: "etcd-data",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+
+	storageClasses, err := typedClient.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Warn("Failed to list the storage classes of the host cluster, "+
+			"the data of etcd will be lost when it is stopped",
+			"err", err,
+		)
+		return volume, nil
+	}
+	hasDefault := false
+	for _, storageClass := range storageClasses.Items {
+		if storageClass.Annotations[kubernetesDefaultStorageClassAnnotation] == "true" {
+			hasDefault = true
+			break
+		}
+	}
+	if !hasDefault {
+		logger.Warn("No default storage class is found in the host cluster, " +
+			"the data of etcd will be lost when it is stopped",
+		)
+		return volume, nil
+	}
+
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.networkName(),
+			Labels:    c.kubernetesLabels(consts.ComponentEtcd),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(kubernetesEtcdDataSize),
+				},
+			},
+		},
+	}
+	_, err = typedClient.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return corev1.Volume{}, err
+	}
+	volume.VolumeSource = corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: name,
+		},
+	}
+	return volume, nil
+}
+
+// kubernetesSecurityContext returns the security context of the user, which is only supported in the form of uid[:gid].
+func kubernetesSecurityContext(user string) (*corev1.SecurityContext, error) {
+	if user == "" {
+		return nil, nil
+	}
+	uidAndGid := strings.SplitN(user, ":", 2)
+	uid, err := strconv.ParseInt(uidAndGid[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("the user %q is not numeric: %w", user, err)
+	}
+	securityContext := &corev1.SecurityContext{
+		RunAsUser: &uid,
+	}
+	if len(uidAndGid) == 2 {
+		gid, err := strconv.ParseInt(uidAndGid[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("the group of user %q is not numeric: %w", user, err)
+		}
+		securityContext.RunAsGroup = &gid
+	}
+	return securityContext, nil
+}
+
+func (c *Cluster) kubernetesCreateComponent(ctx context.Context, component internalversion.Component, cpu float64, memory int64) error {
+	namespace := c.networkName()
+	name := c.Name() + "-" + component.Name
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Create deployment %s of image %s in namespace %s", name, component.Image, namespace)
+		return nil
+	}
+
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	data, volumes, mounts, err := kubernetesVolumes(name, component.Volumes)
+	if err != nil {
+		return fmt.Errorf("failed to convert the volumes of %s: %w", component.Name, err)
+	}
+	if len(data) != 0 {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    c.kubernetesLabels(component.Name),
+			},
+			Data: data,
+		}
+		_, err = typedClient.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+		if err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+			_, err = typedClient.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// The data of etcd is in the container by default, which is gone with the pod.
+	if component.Name == consts.ComponentEtcd {
+		volume, err := c.kubernetesEtcdDataVolume(ctx, typedClient, name)
+		if err != nil {
+			return err
+		}
+		volumes = append(volumes, volume)
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: "/etcd-data",
+		})
+	}
+
+	securityContext, err := kubernetesSecurityContext(component.User)
+	if err != nil {
+		return err
+	}
+
+	container := corev1.Container{
+		Name:            component.Name,
+		Image:           component.Image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         component.Command,
+		Args:            component.Args,
+		VolumeMounts:    mounts,
+		SecurityContext: securityContext,
+		ReadinessProbe:  kubernetesProbe(component.ReadinessProbe),
+		LivenessProbe:   kubernetesProbe(component.LivenessProbe),
+	}
+	for _, env := range component.Envs {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  env.Name,
+			Value: env.Value,
+		})
+	}
+	servicePorts := []corev1.ServicePort{}
+	for _, port := range component.Ports {
+		protocol := corev1.ProtocolTCP
+		if port.Protocol != "" {
+			protocol = corev1.Protocol(port.Protocol)
+		}
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			ContainerPort: int32(port.Port),
+			Protocol:      protocol,
+		})
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       strings.ToLower(string(protocol)) + "-" + format.String(port.Port),
+			Port:       int32(port.Port),
+			TargetPort: intstr.FromInt(int(port.Port)),
+			Protocol:   protocol,
+		})
+	}
+	if cpu != 0 || memory != 0 {
+		container.Resources.Limits = corev1.ResourceList{}
+		if cpu != 0 {
+			container.Resources.Limits[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(cpu*1000), resource.DecimalSI)
+		}
+		if memory != 0 {
+			container.Resources.Limits[corev1.ResourceMemory] = *resource.NewQuantity(memory, resource.BinarySI)
+		}
+	}
+
+	labels := c.kubernetesLabels(component.Name)
+	selector := map[string]string{
+		kubernetesComponentLabel: component.Name,
+	}
+
+	// The component is created stopped, and it is started by scaling up.
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: format.Ptr(int32(0)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{container},
+					Volumes:    volumes,
+					// The components must not talk to the host cluster.
+					AutomountServiceAccountToken: format.Ptr(false),
+					EnableServiceLinks:           format.Ptr(false),
+				},
+			},
+		},
+	}
+	_, err = typedClient.AppsV1().Deployments(namespace).Create(ctx, deployment, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	// The headless service resolves the name of the component to the pod as the network of the container runtimes.
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			Selector:                 selector,
+			Ports:                    servicePorts,
+			PublishNotReadyAddresses: true,
+		},
+	}
+	_, err = typedClient.CoreV1().Services(namespace).Create(ctx, service, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func kubernetesProbe(probe *internalversion.Probe) *corev1.Probe {
+	if probe == nil || probe.HTTPGet == nil {
+		return nil
+	}
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   probe.HTTPGet.Path,
+				Port:   intstr.FromInt(int(probe.HTTPGet.Port)),
+				Scheme: corev1.URIScheme(probe.HTTPGet.Scheme),
+			},
+		},
+		InitialDelaySeconds: probe.InitialDelaySeconds,
+		PeriodSeconds:       probe.PeriodSeconds,
+		TimeoutSeconds:      probe.TimeoutSeconds,
+		FailureThreshold:    probe.FailureThreshold,
+	}
+}
+
+func (c *Cluster) kubernetesInspectComponent(ctx context.Context, componentName string) (running bool, exist bool) {
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return false, false
+	}
+	deployment, err := typedClient.AppsV1().Deployments(c.networkName()).Get(ctx, c.Name()+"-"+componentName, metav1.GetOptions{})
+	if err != nil {
+		return false, false
+	}
+	return deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 0, true
+}
+
+func (c *Cluster) kubernetesComponentReady(ctx context.Context, componentName string) bool {
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return false
+	}
+	deployment, err := typedClient.AppsV1().Deployments(c.networkName()).Get(ctx, c.Name()+"-"+componentName, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return deployment.Status.ReadyReplicas > 0
+}
+
+func (c *Cluster) kubernetesScaleComponent(ctx context.Context, componentName string, replicas int) error {
+	name := c.Name() + "-" + componentName
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Scale deployment %s to %d in namespace %s", name, replicas, c.networkName())
+		return nil
+	}
+
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return err
+	}
+	patch := fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)
+	_, err = typedClient.AppsV1().Deployments(c.networkName()).Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Cluster) kubernetesStartComponent(ctx context.Context, componentName string) error {
+	return c.kubernetesScaleComponent(ctx, componentName, 1)
+}
+
+func (c *Cluster) kubernetesStopComponent(ctx context.Context, componentName string) error {
+	return c.kubernetesScaleComponent(ctx, componentName, 0)
+}
+
+func (c *Cluster) kubernetesDeleteComponent(ctx context.Context, componentName string) error {
+	namespace := c.networkName()
+	name := c.Name() + "-" + componentName
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Delete deployment %s in namespace %s", name, namespace)
+		return nil
+	}
+
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return err
+	}
+	deletes := []func(ctx context.Context, name string, opts metav1.DeleteOptions) error{
+		typedClient.AppsV1().Deployments(namespace).Delete,
+		typedClient.CoreV1().Services(namespace).Delete,
+		typedClient.CoreV1().Secrets(namespace).Delete,
+		typedClient.CoreV1().PersistentVolumeClaims(namespace).Delete,
+	}
+	for _, del := range deletes {
+		err = del(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// kubernetesComponentPod returns the pod of the component, nil if there is no pod.
+func (c *Cluster) kubernetesComponentPod(ctx context.Context, typedClient kubernetes.Interface, componentName string) (*corev1.Pod, error) {
+	pods, err := typedClient.CoreV1().Pods(c.networkName()).List(ctx, metav1.ListOptions{
+		LabelSelector: kubernetesComponentLabel + "=" + componentName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var pod *corev1.Pod
+	for i := range pods.Items {
+		item := &pods.Items[i]
+		if item.DeletionTimestamp != nil {
+			continue
+		}
+		if pod == nil || item.Status.Phase == corev1.PodRunning {
+			pod = item
+		}
+	}
+	return pod, nil
+}
+
+func (c *Cluster) kubernetesComponentStartedAt(ctx context.Context, componentName string) (time.Time, error) {
+	if c.IsDryRun() {
+		return time.Time{}, nil
+	}
+
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return time.Time{}, err
+	}
+	pod, err := c.kubernetesComponentPod(ctx, typedClient, componentName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if pod == nil {
+		return time.Time{}, nil
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == componentName && status.State.Running != nil {
+			return status.State.Running.StartedAt.Time, nil
+		}
+	}
+	return time.Time{}, nil
+}
+
+func (c *Cluster) kubernetesLogs(ctx context.Context, name string, out io.Writer, follow bool) error {
+	deploymentName := c.Name() + "-" + name
+	if c.IsDryRun() {
+		if follow {
+			dryrun.PrintMessage("# Follow the logs of deployment %s in namespace %s", deploymentName, c.networkName())
+		} else if file, ok := dryrun.IsCatToFileWriter(out); ok {
+			dryrun.PrintMessage("# Save the logs of deployment %s in namespace %s to %s", deploymentName, c.networkName(), file)
+		} else {
+			dryrun.PrintMessage("# Get the logs of deployment %s in namespace %s", deploymentName, c.networkName())
+		}
+		return nil
+	}
+
+	typedClient, err := c.kubernetesClient()
+	if err != nil {
+		return err
+	}
+	pod, err := c.kubernetesComponentPod(ctx, typedClient, name)
+	if err != nil {
+		return err
+	}
+	if pod == nil {
+		return fmt.Errorf("component %s is not running", name)
+	}
+
+	stream, err := typedClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: name,
+		Follow:    follow,
+	}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	_, err = io.Copy(out, stream)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// kubernetesExecComponent executes the command in the pod of the component.
+func (c *Cluster) kubernetesExecComponent(ctx context.Context, componentName string, args ...string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Exec %q in deployment %s in namespace %s", strings.Join(args, " "), c.Name()+"-"+componentName, c.networkName())
+		return nil
+	}
+
+	clientset, err := c.kubernetesClientset()
+	if err != nil {
+		return err
+	}
+	typedClient, err := clientset.ToTypedClient()
+	if err != nil {
+		return err
+	}
+	restConfig, err := clientset.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	pod, err := c.kubernetesComponentPod(ctx, typedClient, componentName)
+	if err != nil {
+		return err
+	}
+	if pod == nil {
+		return fmt.Errorf("component %s is not running", componentName)
+	}
+
+	req := typedClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: componentName,
+			Command:   args,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	opt := exec.GetExecOptions(ctx)
+	var stdout, stderr io.Writer = io.Discard, io.Discard
+	if opt.Out != nil {
+		stdout = opt.Out
+	}
+	if opt.ErrOut != nil {
+		stderr = opt.ErrOut
+	}
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
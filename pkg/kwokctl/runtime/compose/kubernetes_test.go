@@ -0,0 +1,190 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func Test_kubernetesVolumes(t *testing.T) {
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.crt")
+	err := os.WriteFile(caPath, []byte("ca"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditLogPath := filepath.Join(dir, "audit.log")
+	err = os.WriteFile(auditLogPath, nil, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDir := filepath.Join(dir, "config")
+	err = os.MkdirAll(filepath.Join(configDir, "skipped"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(configDir, "kwok.yaml"), []byte("kwok"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, volumes, mounts, err := kubernetesVolumes("kwok-kwok-kube-apiserver", []internalversion.Volume{
+		{
+			HostPath:  caPath,
+			MountPath: "/etc/kubernetes/pki/ca.crt",
+			ReadOnly:  true,
+		},
+		{
+			HostPath:  auditLogPath,
+			MountPath: "/var/log/kubernetes/audit/audit.log",
+		},
+		{
+			HostPath:  configDir,
+			MountPath: "/etc/kwok",
+			ReadOnly:  true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("kubernetesVolumes() error = %v", err)
+	}
+
+	wantData := map[string][]byte{
+		"0-0": []byte("ca"),
+		"2-0": []byte("kwok"),
+	}
+	if !reflect.DeepEqual(data, wantData) {
+		t.Errorf("kubernetesVolumes() data = %v, want %v", data, wantData)
+	}
+
+	wantVolumes := []corev1.Volume{
+		{
+			Name: "volume-0",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: "kwok-kwok-kube-apiserver",
+					Items:      []corev1.KeyToPath{{Key: "0-0", Path: "ca.crt"}},
+				},
+			},
+		},
+		{
+			Name: "volume-1",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: "volume-2",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: "kwok-kwok-kube-apiserver",
+					Items:      []corev1.KeyToPath{{Key: "2-0", Path: "kwok.yaml"}},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(volumes, wantVolumes) {
+		t.Errorf("kubernetesVolumes() volumes = %v, want %v", volumes, wantVolumes)
+	}
+
+	wantMounts := []corev1.VolumeMount{
+		{
+			Name:      "volume-0",
+			MountPath: "/etc/kubernetes/pki/ca.crt",
+			SubPath:   "ca.crt",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "volume-1",
+			MountPath: "/var/log/kubernetes/audit",
+		},
+		{
+			Name:      "volume-2",
+			MountPath: "/etc/kwok",
+			ReadOnly:  true,
+		},
+	}
+	if !reflect.DeepEqual(mounts, wantMounts) {
+		t.Errorf("kubernetesVolumes() mounts = %v, want %v", mounts, wantMounts)
+	}
+}
+
+func Test_kubernetesNodeAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []corev1.Node
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "internal",
+			nodes: []corev1.Node{
+				{
+					Status: corev1.NodeStatus{
+						Addresses: []corev1.NodeAddress{
+							{Type: corev1.NodeHostName, Address: "node"},
+							{Type: corev1.NodeInternalIP, Address: "172.18.0.2"},
+						},
+					},
+				},
+			},
+			want: "172.18.0.2",
+		},
+		{
+			name: "external is preferred",
+			nodes: []corev1.Node{
+				{
+					Status: corev1.NodeStatus{
+						Addresses: []corev1.NodeAddress{
+							{Type: corev1.NodeInternalIP, Address: "10.0.0.2"},
+						},
+					},
+				},
+				{
+					Status: corev1.NodeStatus{
+						Addresses: []corev1.NodeAddress{
+							{Type: corev1.NodeInternalIP, Address: "10.0.0.3"},
+							{Type: corev1.NodeExternalIP, Address: "203.0.113.3"},
+						},
+					},
+				},
+			},
+			want: "203.0.113.3",
+		},
+		{
+			name:    "no nodes",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := kubernetesNodeAddress(tt.nodes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("kubernetesNodeAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("kubernetesNodeAddress() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
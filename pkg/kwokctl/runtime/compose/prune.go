@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+)
+
+// projectLabel is the label of the containers and networks of a cluster, see labelArgs
+const projectLabel = "com.docker.compose.project"
+
+// pruneOrphans returns a function to remove the containers and networks of the clusters which no longer exist,
+// e.g. left behind by a creation which crashed.
+func pruneOrphans(command string) runtime.PruneOrphans {
+	return func(ctx context.Context, clusters []string) error {
+		if _, err := exec.LookPath(command); err != nil {
+			return nil
+		}
+
+		logger := log.FromContext(ctx)
+		logger = logger.With("runtime", command)
+		ctx = log.NewContext(ctx, logger)
+
+		exists := sets.NewString(clusters...)
+		isOrphan := func(project string) bool {
+			return strings.HasPrefix(project, consts.ProjectName+"-") && !exists.Has(project)
+		}
+
+		containers, err := listOrphanContainers(ctx, command, isOrphan)
+		if err != nil {
+			return err
+		}
+		for _, container := range containers {
+			err = removeOrphan(ctx, "container", command, "rm", "-f", container)
+			if err != nil {
+				return err
+			}
+		}
+
+		networks, err := listOrphanNetworks(ctx, command, isOrphan)
+		if err != nil {
+			return err
+		}
+		for _, network := range networks {
+			err = removeOrphan(ctx, "network", command, "network", "rm", network)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// listOrphanContainers returns the containers of which the project is orphaned
+func listOrphanContainers(ctx context.Context, command string, isOrphan func(project string) bool) ([]string, error) {
+	names, err := execLines(ctx, command, "ps", "-a", "--filter", "label="+projectLabel, "--format", "{{.Names}}")
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+
+	out := bytes.NewBuffer(nil)
+	err = exec.Exec(exec.WithWriteTo(ctx, out), command, append([]string{"inspect", "--type=container"}, names...)...)
+	if err != nil {
+		return nil, err
+	}
+	var infos []struct {
+		Name   string
+		Config struct {
+			Labels map[string]string
+		}
+	}
+	err = json.Unmarshal(out.Bytes(), &infos)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []string
+	for _, info := range infos {
+		if isOrphan(info.Config.Labels[projectLabel]) {
+			containers = append(containers, strings.TrimPrefix(info.Name, "/"))
+		}
+	}
+	return containers, nil
+}
+
+// listOrphanNetworks returns the networks of which the project is orphaned, the network is named after the project
+func listOrphanNetworks(ctx context.Context, command string, isOrphan func(project string) bool) ([]string, error) {
+	names, err := execLines(ctx, command, "network", "ls", "--filter", "label="+projectLabel, "--format", "{{.Name}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var networks []string
+	for _, name := range names {
+		if isOrphan(name) {
+			networks = append(networks, name)
+		}
+	}
+	return networks, nil
+}
+
+func removeOrphan(ctx context.Context, kind string, command string, args ...string) error {
+	if dryrun.DryRun {
+		dryrun.PrintMessage("%s", runtime.FormatExec(ctx, command, args...))
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	err := exec.Exec(ctx, command, args...)
+	if err != nil {
+		return err
+	}
+	logger.Info("Removed orphan", "kind", kind, "name", args[len(args)-1])
+	return nil
+}
+
+func execLines(ctx context.Context, command string, args ...string) ([]string, error) {
+	out := bytes.NewBuffer(nil)
+	err := exec.Exec(exec.WithWriteTo(ctx, out), command, args...)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out.String()), nil
+}
@@ -0,0 +1,87 @@
+//go:build !windows
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_pruneOrphans(t *testing.T) {
+	dir := t.TempDir()
+	calls := filepath.Join(dir, "calls")
+	command := filepath.Join(dir, "docker")
+	script := `#!/bin/sh
+echo "$@" >> ` + calls + `
+case "$1 $2" in
+"ps -a")
+	echo kwok-kwok-etcd kwok-deleted-etcd other
+	;;
+"inspect --type=container")
+	echo '[
+		{"Name": "/kwok-kwok-etcd", "Config": {"Labels": {"com.docker.compose.project": "kwok-kwok"}}},
+		{"Name": "/kwok-deleted-etcd", "Config": {"Labels": {"com.docker.compose.project": "kwok-deleted"}}},
+		{"Name": "/other", "Config": {"Labels": {"com.docker.compose.project": "other"}}}
+	]'
+	;;
+"network ls")
+	echo kwok-kwok kwok-deleted other
+	;;
+esac
+`
+	err := os.WriteFile(command, []byte(script), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = pruneOrphans(command)(context.Background(), []string{"kwok-kwok"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var removed []string
+	for _, call := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if strings.HasPrefix(call, "rm ") || strings.HasPrefix(call, "network rm ") {
+			removed = append(removed, call)
+		}
+	}
+	want := []string{
+		"rm -f kwok-deleted-etcd",
+		"network rm kwok-deleted",
+	}
+	if diff := cmp.Diff(want, removed); diff != "" {
+		t.Errorf("want only the orphans removed (-want +got):\n%s", diff)
+	}
+}
+
+func Test_pruneOrphansWithoutCommand(t *testing.T) {
+	err := pruneOrphans(filepath.Join(t.TempDir(), "docker"))(context.Background(), nil)
+	if err != nil {
+		t.Errorf("want the runtime skipped without the command, got %v", err)
+	}
+}
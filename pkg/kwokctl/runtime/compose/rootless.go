@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// runtimeInfo is the subset of the container runtime info that matters for rootless
+type runtimeInfo struct {
+	Rootless      bool
+	CgroupVersion string
+	CPULimit      bool
+	MemoryLimit   bool
+}
+
+// parseRuntimeInfo parses the output of `<runtime> info --format '{{ json . }}'`
+func parseRuntimeInfo(runtime string, raw []byte) (*runtimeInfo, error) {
+	if runtime == consts.RuntimeTypePodman {
+		var info struct {
+			Host struct {
+				CgroupVersion     string   `json:"cgroupVersion"`
+				CgroupControllers []string `json:"cgroupControllers"`
+				Security          struct {
+					Rootless bool `json:"rootless"`
+				} `json:"security"`
+			} `json:"host"`
+		}
+		err := json.Unmarshal(raw, &info)
+		if err != nil {
+			return nil, err
+		}
+		return &runtimeInfo{
+			Rootless:      info.Host.Security.Rootless,
+			CgroupVersion: strings.TrimPrefix(info.Host.CgroupVersion, "v"),
+			CPULimit:      slices.Contains(info.Host.CgroupControllers, "cpu"),
+			MemoryLimit:   slices.Contains(info.Host.CgroupControllers, "memory"),
+		}, nil
+	}
+
+	var info struct {
+		SecurityOptions []string `json:"SecurityOptions"`
+		CgroupVersion   string   `json:"CgroupVersion"`
+		CPUCfsQuota     bool     `json:"CpuCfsQuota"`
+		MemoryLimit     bool     `json:"MemoryLimit"`
+	}
+	err := json.Unmarshal(raw, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &runtimeInfo{
+		Rootless:      slices.Contains(info.SecurityOptions, "name=rootless"),
+		CgroupVersion: info.CgroupVersion,
+		CPULimit:      info.CPUCfsQuota,
+		MemoryLimit:   info.MemoryLimit,
+	}, nil
+}
+
+// runtimeInfo returns the info of the container runtime
+func (c *Cluster) runtimeInfo(ctx context.Context) (*runtimeInfo, error) {
+	buf := bytes.NewBuffer(nil)
+	err := c.Exec(exec.WithWriteTo(ctx, buf), c.runtime, "info", "--format={{ json . }}")
+	if err != nil {
+		return nil, err
+	}
+	return parseRuntimeInfo(c.runtime, bytes.TrimSpace(buf.Bytes()))
+}
+
+// defaultUnprivilegedPortStart is the default of net.ipv4.ip_unprivileged_port_start
+const defaultUnprivilegedPortStart = 1024
+
+// unprivilegedPortStart returns the lowest port that a non-root user can bind on the host
+func unprivilegedPortStart() uint32 {
+	raw, err := os.ReadFile("/proc/sys/net/ipv4/ip_unprivileged_port_start")
+	if err != nil {
+		return defaultUnprivilegedPortStart
+	}
+	start, err := strconv.ParseUint(strings.TrimSpace(string(raw)), 10, 32)
+	if err != nil {
+		return defaultUnprivilegedPortStart
+	}
+	return uint32(start)
+}
+
+// checkPrivilegedPorts returns an error if any of the host ports can not be bound by a rootless runtime
+func checkPrivilegedPorts(conf *internalversion.KwokctlConfiguration, start uint32) error {
+	opt := conf.Options
+	ports := []struct {
+		flag string
+		port uint32
+	}{
+		{"--kube-apiserver-port", opt.KubeApiserverPort},
+		{"--etcd-port", opt.EtcdPort},
+		{"--controller-port", opt.KwokControllerPort},
+		{"--prometheus-port", opt.PrometheusPort},
+		{"--jaeger-port", opt.JaegerPort},
+		{"--dashboard-port", opt.DashboardPort},
+		{"--grafana-port", opt.GrafanaPort},
+	}
+	for _, component := range conf.Components {
+		for _, port := range component.Ports {
+			ports = append(ports, struct {
+				flag string
+				port uint32
+			}{
+				fmt.Sprintf("the host port of the component %s", component.Name),
+				port.HostPort,
+			})
+		}
+	}
+
+	for _, p := range ports {
+		if p.port == 0 || p.port >= start {
+			continue
+		}
+		return fmt.Errorf("rootless runtime can not bind the privileged port %d (%s), "+
+			"please use a port greater than or equal to %d, "+
+			"or allow it with `sudo sysctl net.ipv4.ip_unprivileged_port_start=%d`",
+			p.port, p.flag, start, p.port)
+	}
+	return nil
+}
+
+// checkRootless detects a rootless runtime and adjusts the configuration for it
+func (c *Cluster) checkRootless(ctx context.Context, env *env) error {
+	// The client of containerd is not rootless, and nothing is run on the host by kubernetes
+	if c.IsDryRun() || c.runtime == consts.RuntimeTypeContainerd || c.runtime == consts.RuntimeTypeKubernetes {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	info, err := c.runtimeInfo(ctx)
+	if err != nil {
+		logger.Warn("Failed to get runtime info, skip rootless check", "err", err)
+		return nil
+	}
+	if !info.Rootless {
+		return nil
+	}
+	logger.Info("Detected rootless runtime",
+		"runtime", c.runtime,
+		"cgroupVersion", info.CgroupVersion,
+	)
+
+	if goruntime.GOOS == "linux" {
+		err = checkPrivilegedPorts(env.kwokctlConfig, unprivilegedPortStart())
+		if err != nil {
+			return err
+		}
+	}
+
+	conf := &env.kwokctlConfig.Options
+	if (conf.ComponentsCPULimit != "" && !info.CPULimit) ||
+		(conf.ComponentsMemoryLimit != "" && !info.MemoryLimit) {
+		logger.Warn("The resource limits of components are not supported by the rootless runtime, ignore them. "+
+			"Delegate the cgroup v2 controllers to the user to enable them, "+
+			"see https://rootlesscontaine.rs/getting-started/common/cgroup2/",
+			"cgroupVersion", info.CgroupVersion,
+			"cpuLimit", conf.ComponentsCPULimit,
+			"memoryLimit", conf.ComponentsMemoryLimit,
+		)
+		conf.ComponentsCPULimit = ""
+		conf.ComponentsMemoryLimit = ""
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func Test_parseRuntimeInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime string
+		raw     string
+		want    *runtimeInfo
+		wantErr bool
+	}{
+		{
+			name:    "docker rootless",
+			runtime: consts.RuntimeTypeDocker,
+			raw:     `{"SecurityOptions":["name=seccomp,profile=builtin","name=rootless","name=cgroupns"],"CgroupVersion":"2","CpuCfsQuota":true,"MemoryLimit":true}`,
+			want: &runtimeInfo{
+				Rootless:      true,
+				CgroupVersion: "2",
+				CPULimit:      true,
+				MemoryLimit:   true,
+			},
+		},
+		{
+			name:    "docker rootful",
+			runtime: consts.RuntimeTypeDocker,
+			raw:     `{"SecurityOptions":["name=seccomp,profile=builtin"],"CgroupVersion":"1","CpuCfsQuota":true,"MemoryLimit":true}`,
+			want: &runtimeInfo{
+				CgroupVersion: "1",
+				CPULimit:      true,
+				MemoryLimit:   true,
+			},
+		},
+		{
+			name:    "nerdctl rootless cgroup v1",
+			runtime: consts.RuntimeTypeNerdctl,
+			raw:     `{"SecurityOptions":["name=rootless"],"CgroupVersion":"1"}`,
+			want: &runtimeInfo{
+				Rootless:      true,
+				CgroupVersion: "1",
+			},
+		},
+		{
+			name:    "podman rootless",
+			runtime: consts.RuntimeTypePodman,
+			raw:     `{"host":{"cgroupVersion":"v2","cgroupControllers":["cpu","pids"],"security":{"rootless":true}}}`,
+			want: &runtimeInfo{
+				Rootless:      true,
+				CgroupVersion: "2",
+				CPULimit:      true,
+			},
+		},
+		{
+			name:    "invalid",
+			runtime: consts.RuntimeTypePodman,
+			raw:     `Error: unknown flag`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRuntimeInfo(tt.runtime, []byte(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRuntimeInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRuntimeInfo() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_checkPrivilegedPorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *internalversion.KwokctlConfiguration
+		start   uint32
+		wantErr bool
+	}{
+		{
+			name: "unprivileged",
+			conf: &internalversion.KwokctlConfiguration{
+				Options: internalversion.KwokctlConfigurationOptions{
+					KubeApiserverPort: 6443,
+				},
+			},
+			start: 1024,
+		},
+		{
+			name: "privileged apiserver port",
+			conf: &internalversion.KwokctlConfiguration{
+				Options: internalversion.KwokctlConfigurationOptions{
+					KubeApiserverPort: 443,
+				},
+			},
+			start:   1024,
+			wantErr: true,
+		},
+		{
+			name: "privileged port allowed",
+			conf: &internalversion.KwokctlConfiguration{
+				Options: internalversion.KwokctlConfigurationOptions{
+					KubeApiserverPort: 443,
+				},
+			},
+			start: 80,
+		},
+		{
+			name: "privileged component port",
+			conf: &internalversion.KwokctlConfiguration{
+				Components: []internalversion.Component{
+					{
+						Name: "proxy",
+						Ports: []internalversion.Port{
+							{Port: 8080, HostPort: 80},
+						},
+					},
+				},
+			},
+			start:   1024,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPrivilegedPorts(tt.conf, tt.start)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkPrivilegedPorts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/exec"
 	"sigs.k8s.io/kwok/pkg/utils/format"
@@ -38,6 +40,15 @@ func (c *Cluster) networkName() string {
 }
 
 func (c *Cluster) createNetwork(ctx context.Context) error {
+	// The containers of containerd are attached to the CNI network as they are started
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return nil
+	}
+	// The pods of kubernetes are grouped by the namespace
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesCreateNamespace(ctx)
+	}
+
 	network := c.networkName()
 	logger := log.FromContext(ctx)
 	logger = logger.With("network", network)
@@ -48,15 +59,65 @@ func (c *Cluster) createNetwork(ctx context.Context) error {
 			return nil
 		}
 	}
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	ipv6 := conf.Options.EnableIPv6
+
+	logger.Debug("Creating network")
+	if c.useDockerAPI(ctx) {
+		return c.dockerCreateNetwork(ctx, network, ipv6)
+	}
+
 	args := []string{
 		"network", "create", network,
 	}
+	if ipv6 {
+		args = append(args, "--ipv6")
+	}
 	args = append(args, c.labelArgs()...)
-	logger.Debug("Creating network")
-	return c.Exec(ctx, c.runtime, args...)
+	err = c.Exec(ctx, c.runtime, args...)
+	if err != nil {
+		return err
+	}
+
+	if c.runtime == consts.RuntimeTypePodman {
+		c.checkPodmanNetworkDNS(ctx, network)
+	}
+	return nil
+}
+
+// checkPodmanNetworkDNS warns if the DNS of the network of podman is disabled,
+// e.g. the CNI network backend without the dnsname plugin,
+// as the components resolve each other by the names of the containers.
+func (c *Cluster) checkPodmanNetworkDNS(ctx context.Context, network string) {
+	if c.IsDryRun() {
+		return
+	}
+	logger := log.FromContext(ctx)
+	out := bytes.NewBuffer(nil)
+	err := c.Exec(exec.WithWriteTo(ctx, out), c.runtime, "network", "inspect", network, "--format={{.DNSEnabled}}")
+	if err != nil {
+		logger.Debug("Failed to check the DNS of the network", "network", network, "err", err)
+		return
+	}
+	if strings.TrimSpace(out.String()) == "false" {
+		logger.Warn("The DNS of the network is disabled, the components can not resolve each other, "+
+			"switch podman to the netavark network backend or install the dnsname plugin of CNI",
+			"network", network,
+		)
+	}
 }
 
 func (c *Cluster) deleteNetwork(ctx context.Context) error {
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return nil
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesDeleteNamespace(ctx)
+	}
+
 	network := c.networkName()
 	logger := log.FromContext(ctx)
 	logger = logger.With("network", network)
@@ -66,10 +127,14 @@ func (c *Cluster) deleteNetwork(ctx context.Context) error {
 			return nil
 		}
 	}
+	logger.Debug("Deleting network")
+	if c.useDockerAPI(ctx) {
+		return c.dockerDeleteNetwork(ctx, network)
+	}
+
 	args := []string{
 		"network", "rm", network,
 	}
-	logger.Debug("Deleting network")
 	err := c.Exec(ctx, c.runtime, args...)
 	if err != nil {
 		if c.runtime != consts.RuntimeTypeNerdctl {
@@ -106,6 +171,10 @@ func (c *Cluster) deleteNetwork(ctx context.Context) error {
 }
 
 func (c *Cluster) inspectNetwork(ctx context.Context, name string) (exist bool) {
+	if c.useDockerAPI(ctx) {
+		return c.dockerInspectNetwork(ctx, name)
+	}
+
 	err := c.Exec(ctx, c.runtime, "network", "inspect", name)
 	//nolint:gosimple
 	if err != nil {
@@ -198,6 +267,24 @@ func (c *Cluster) createComponent(ctx context.Context, componentName string) err
 		return fmt.Errorf("component %s not found", componentName)
 	}
 
+	cpu, memory, err := runtime.GetComponentsResourceLimits(&conf.Options)
+	if err != nil {
+		return err
+	}
+
+	if c.runtime == consts.RuntimeTypeContainerd {
+		logger.Debug("Creating component")
+		return c.containerdCreateComponent(ctx, component, cpu, memory)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		logger.Debug("Creating component")
+		return c.kubernetesCreateComponent(ctx, component, cpu, memory)
+	}
+	if c.useDockerAPI(ctx) {
+		logger.Debug("Creating component")
+		return c.dockerCreateComponent(ctx, component, cpu, memory)
+	}
+
 	args := []string{"create",
 		"--name=" + c.Name() + "-" + componentName,
 		"--pull=never",
@@ -230,6 +317,14 @@ func (c *Cluster) createComponent(ctx context.Context, componentName string) err
 		// Nerdctl does not support --link and --requires
 	}
 
+	switch c.runtime {
+	case consts.RuntimeTypeDocker, consts.RuntimeTypePodman:
+		// The component is resolved by the name of the service as well in the network, the same as the compose
+		args = append(args, "--network-alias="+componentName)
+	case consts.RuntimeTypeNerdctl:
+		// Nerdctl does not support --network-alias
+	}
+
 	switch c.runtime {
 	case consts.RuntimeTypeDocker, consts.RuntimeTypePodman:
 		args = append(args, "--restart=unless-stopped")
@@ -247,6 +342,13 @@ func (c *Cluster) createComponent(ctx context.Context, componentName string) err
 
 	args = append(args, c.labelArgs()...)
 
+	if cpu != 0 {
+		args = append(args, "--cpus="+strconv.FormatFloat(cpu, 'f', -1, 64))
+	}
+	if memory != 0 {
+		args = append(args, "--memory="+format.String(memory))
+	}
+
 	for _, port := range component.Ports {
 		protocol := port.Protocol
 		if protocol == "" {
@@ -303,12 +405,22 @@ func (c *Cluster) deleteComponent(ctx context.Context, componentName string) err
 		}
 	}
 
+	logger.Debug("Deleting component")
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdDeleteComponent(ctx, componentName)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesDeleteComponent(ctx, componentName)
+	}
+	if c.useDockerAPI(ctx) {
+		return c.dockerDeleteComponent(ctx, componentName)
+	}
+
 	args := []string{"rm",
 		c.Name() + "-" + componentName,
 		"--force",
 	}
 
-	logger.Debug("Deleting component")
 	return c.Exec(ctx, c.runtime, args...)
 }
 
@@ -360,6 +472,16 @@ func checkInspect(raw []byte) (bool, error) {
 }
 
 func (c *Cluster) inspectComponent(ctx context.Context, componentName string) (running bool, exist bool) {
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdInspectComponent(ctx, componentName)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesInspectComponent(ctx, componentName)
+	}
+	if c.useDockerAPI(ctx) {
+		return c.dockerInspectComponent(ctx, componentName)
+	}
+
 	buf := bytes.NewBuffer(nil)
 	args := []string{"inspect", c.Name() + "-" + componentName}
 
@@ -392,12 +514,22 @@ func (c *Cluster) startComponent(ctx context.Context, componentName string) erro
 		}
 	}
 
+	logger.Debug("Starting component")
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdStartComponent(ctx, componentName)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesStartComponent(ctx, componentName)
+	}
+	if c.useDockerAPI(ctx) {
+		return c.dockerStartComponent(ctx, componentName)
+	}
+
 	args := []string{
 		"start",
 		c.Name() + "-" + componentName,
 	}
 
-	logger.Debug("Starting component")
 	err := c.Exec(ctx, c.runtime, args...)
 	if err != nil {
 		// TODO: Remove this after nerdctl fix
@@ -450,12 +582,22 @@ func (c *Cluster) stopComponent(ctx context.Context, componentName string) error
 		}
 	}
 
+	logger.Debug("Stopping component")
+	if c.runtime == consts.RuntimeTypeContainerd {
+		return c.containerdStopComponent(ctx, componentName)
+	}
+	if c.runtime == consts.RuntimeTypeKubernetes {
+		return c.kubernetesStopComponent(ctx, componentName)
+	}
+	if c.useDockerAPI(ctx) {
+		return c.dockerStopComponent(ctx, componentName)
+	}
+
 	args := []string{"stop",
 		c.Name() + "-" + componentName,
 		"--time=0",
 	}
 
-	logger.Debug("Stopping component")
 	return c.Exec(ctx, c.runtime, args...)
 }
 
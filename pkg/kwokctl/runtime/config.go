@@ -62,6 +62,15 @@ type Runtime interface {
 	// StopComponent stop cluster component
 	StopComponent(ctx context.Context, name string) error
 
+	// UpgradeComponent replace the binary or the image of the component with the one in the options, and restart it
+	UpgradeComponent(ctx context.Context, name string) error
+
+	// InstallComponent add the optional component to the cluster and start it
+	InstallComponent(ctx context.Context, name string) error
+
+	// UninstallComponent stop the optional component and remove it from the cluster
+	UninstallComponent(ctx context.Context, name string) error
+
 	// GetComponent return the component if it exists
 	GetComponent(ctx context.Context, name string) (internalversion.Component, error)
 
@@ -71,6 +80,15 @@ type Runtime interface {
 	// WaitReady wait the cluster is ready
 	WaitReady(ctx context.Context, timeout time.Duration) error
 
+	// ComponentReady check the component of cluster is ready
+	ComponentReady(ctx context.Context, name string) (bool, error)
+
+	// ComponentStartedAt return the time the component of cluster was started at, zero if it is not running
+	ComponentStartedAt(ctx context.Context, name string) (time.Time, error)
+
+	// ComponentRestarts return the number of times the component of cluster has been restarted after crashes
+	ComponentRestarts(ctx context.Context, name string) (int, error)
+
 	// AddContext add the context of cluster to kubeconfig
 	AddContext(ctx context.Context, kubeconfigPath string) error
 
@@ -107,6 +125,12 @@ type Runtime interface {
 	// ListImages list images in the cluster
 	ListImages(ctx context.Context) ([]string, error)
 
+	// SaveImages save the images of the cluster to the archive
+	SaveImages(ctx context.Context, archive string) error
+
+	// LoadImages load the images from the archive
+	LoadImages(ctx context.Context, archive string) error
+
 	// SnapshotSave save the snapshot of cluster
 	SnapshotSave(ctx context.Context, path string) error
 
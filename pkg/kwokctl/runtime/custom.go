@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+// builtinComponents is the components which are provided by kwokctl
+var builtinComponents = []string{
+	consts.ComponentEtcd,
+	consts.ComponentKubeApiserver,
+	consts.ComponentKubeControllerManager,
+	consts.ComponentKubeScheduler,
+	consts.ComponentKwokController,
+	consts.ComponentDashboard,
+	consts.ComponentPrometheus,
+	consts.ComponentJaeger,
+	consts.ComponentGrafana,
+}
+
+// ValidateCustomComponents checks the custom components declared in the configuration,
+// which must have unique names not taken by the built-in components or their replicas.
+func ValidateCustomComponents(components []internalversion.Component) error {
+	names := sets.NewString()
+	for _, component := range components {
+		if component.Name == "" {
+			return fmt.Errorf("the name of the custom component is required")
+		}
+		for _, builtin := range builtinComponents {
+			if IsReplicaOf(component.Name, builtin) {
+				return fmt.Errorf("custom component %s conflicts with the built-in component %s", component.Name, builtin)
+			}
+		}
+		if names.Has(component.Name) {
+			return fmt.Errorf("custom component %s is declared more than once", component.Name)
+		}
+		names.Insert(component.Name)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestValidateCustomComponents(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []internalversion.Component
+		wantErr    bool
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "custom components",
+			components: []internalversion.Component{
+				{Name: "metrics-server"},
+				{Name: "kube-state-metrics"},
+			},
+		},
+		{
+			name: "prefixed with a built-in component",
+			components: []internalversion.Component{
+				{Name: "kube-apiserver-proxy"},
+			},
+		},
+		{
+			name: "without the name",
+			components: []internalversion.Component{
+				{Image: "registry.k8s.io/metrics-server/metrics-server:v0.6.4"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "built-in component",
+			components: []internalversion.Component{
+				{Name: "kube-scheduler"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "replica of a built-in component",
+			components: []internalversion.Component{
+				{Name: "kube-scheduler-1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicated",
+			components: []internalversion.Component{
+				{Name: "metrics-server"},
+				{Name: "metrics-server"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCustomComponents(tt.components)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCustomComponents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
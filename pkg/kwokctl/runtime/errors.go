@@ -23,4 +23,7 @@ import (
 var (
 	// ErrComponentNotFound is returned when a component is not found
 	ErrComponentNotFound = fmt.Errorf("component not found")
+
+	// ErrComponentNotOptional is returned when a component can not be installed into or uninstalled from a cluster
+	ErrComponentNotOptional = fmt.Errorf("component is not optional")
 )
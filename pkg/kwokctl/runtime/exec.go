@@ -19,10 +19,12 @@ package runtime
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/log"
@@ -32,10 +34,11 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/version"
 )
 
-// ForkExec forks a new process and execs the given command.
+// ForkExec forks a new process and execs the given command,
+// the pid and the logs of the process are saved by the given name.
 // The process will be terminated when the context is canceled.
-func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, args ...string) error {
-	pidPath := path.Join(dir, "pids", path.OnlyName(name)+".pid")
+func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, command string, args ...string) error {
+	pidPath := path.Join(dir, "pids", name+".pid")
 	if file.Exists(pidPath) {
 		pidData, err := os.ReadFile(pidPath)
 		if err == nil {
@@ -49,7 +52,7 @@ func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, args ..
 	}
 	ctx = exec.WithDir(ctx, dir)
 	ctx = exec.WithFork(ctx, true)
-	logPath := path.Join(dir, "logs", path.OnlyName(name)+".log")
+	logPath := path.Join(dir, "logs", name+".log")
 	logFile, err := c.OpenFile(logPath)
 	if err != nil {
 		return fmt.Errorf("open log file %s: %w", logPath, err)
@@ -61,11 +64,11 @@ func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, args ..
 	})
 
 	if c.IsDryRun() {
-		dryrun.PrintMessage("%s", FormatExec(ctx, name, args...))
+		dryrun.PrintMessage("%s", FormatExec(ctx, command, args...))
 		dryrun.PrintMessage("echo $! >%s", pidPath)
 		return nil
 	}
-	cmd, err := exec.Command(ctx, name, args...)
+	cmd, err := exec.Command(ctx, command, args...)
 	if err != nil {
 		return err
 	}
@@ -77,9 +80,13 @@ func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, args ..
 	return nil
 }
 
-// ForkExecKill kills the process if it is running.
+// terminationGracePeriod is the time the process is given to exit after it is terminated,
+// e.g. for etcd to flush the data, before it is killed
+const terminationGracePeriod = 10 * time.Second
+
+// ForkExecKill terminates the process of the given name if it is running.
 func (c *Cluster) ForkExecKill(ctx context.Context, dir string, name string) error {
-	pidPath := path.Join(dir, "pids", path.OnlyName(name)+".pid")
+	pidPath := path.Join(dir, "pids", name+".pid")
 	if !file.Exists(pidPath) {
 		// No pid file exists, which means the process has been terminated
 		logger := log.FromContext(ctx)
@@ -100,7 +107,11 @@ func (c *Cluster) ForkExecKill(ctx context.Context, dir string, name string) err
 		if err != nil {
 			return fmt.Errorf("parse pid file %s: %w", pidPath, err)
 		}
-		err = exec.KillProcess(pid)
+		err = exec.TerminateProcess(pid, terminationGracePeriod)
+		if err != nil {
+			return err
+		}
+		err = c.killSupervised(ctx, dir, name)
 		if err != nil {
 			return err
 		}
@@ -113,9 +124,9 @@ func (c *Cluster) ForkExecKill(ctx context.Context, dir string, name string) err
 	return nil
 }
 
-// ForkExecIsRunning checks if the process is running.
+// ForkExecIsRunning checks if the process of the given name is running.
 func (c *Cluster) ForkExecIsRunning(ctx context.Context, dir string, name string) bool {
-	pidPath := path.Join(dir, "pids", path.OnlyName(name)+".pid")
+	pidPath := path.Join(dir, "pids", name+".pid")
 	if !file.Exists(pidPath) {
 		logger := log.FromContext(ctx)
 		logger.Debug("Stat file not exists",
@@ -137,16 +148,101 @@ func (c *Cluster) ForkExecIsRunning(ctx context.Context, dir string, name string
 	return exec.IsRunning(pid)
 }
 
+// ForkExecStartedAt returns the time the process of the given name was started at, zero if it is not running.
+func (c *Cluster) ForkExecStartedAt(ctx context.Context, dir string, name string) time.Time {
+	if !c.ForkExecIsRunning(ctx, dir, name) {
+		return time.Time{}
+	}
+
+	// The process is restarted by the supervisor after the pid file is written
+	state, err := readSupervisorState(path.Join(dir, "pids", name+".state"))
+	if err == nil {
+		return state.StartedAt
+	}
+
+	// The pid file is written as the process is started
+	pidPath := path.Join(dir, "pids", name+".pid")
+	fi, err := os.Stat(pidPath)
+	if err != nil {
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
+
+// ContainerStartedAt returns the time the container was started at, zero if it is not running.
+func (c *Cluster) ContainerStartedAt(ctx context.Context, command string, container string) (time.Time, error) {
+	if c.IsDryRun() {
+		return time.Time{}, nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := c.Exec(exec.WithWriteTo(ctx, buf), command, "inspect", container, "--format={{ json .State }}")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var state struct {
+		Running   bool
+		StartedAt time.Time
+	}
+	err = json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &state)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to unmarshal the state of %s: %w", container, err)
+	}
+	if !state.Running {
+		return time.Time{}, nil
+	}
+	return state.StartedAt, nil
+}
+
 // PullImages is a helper function to pull images
 func (c *Cluster) PullImages(ctx context.Context, command string, images []string, quiet bool) error {
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	platform := GetImagePlatform(&conf.Options)
+
 	if c.IsDryRun() {
 		for _, image := range images {
-			dryrun.PrintMessage("%s pull %s", command, image)
+			if platform != "" {
+				dryrun.PrintMessage("%s pull --platform=%s %s", command, platform, image)
+			} else {
+				dryrun.PrintMessage("%s pull %s", command, image)
+			}
+		}
+		return nil
+	}
+
+	if conf.Options.Offline {
+		err = exec.CheckImages(ctx, command, images)
+		if err != nil {
+			return fmt.Errorf("%w, and the network is not used in offline mode, load them with 'kwokctl images load' first", err)
 		}
 		return nil
 	}
 
-	return exec.PullImages(ctx, command, images, quiet)
+	return exec.PullImages(ctx, command, images, platform, quiet)
+}
+
+// SaveImageArchive saves the given images to the archive.
+func (c *Cluster) SaveImageArchive(ctx context.Context, command string, images []string, archive string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("%s save -o %s %s", command, archive, strings.Join(images, " "))
+		return nil
+	}
+
+	return exec.SaveImages(ctx, command, images, archive)
+}
+
+// LoadImageArchive loads the images from the archive.
+func (c *Cluster) LoadImageArchive(ctx context.Context, command string, archive string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("%s load -i %s", command, archive)
+		return nil
+	}
+
+	return exec.LoadImages(ctx, command, archive)
 }
 
 // Exec executes the given command and returns the output.
@@ -0,0 +1,102 @@
+//go:build !windows
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestForkExecStartedAt(t *testing.T) {
+	dir := t.TempDir()
+	c := NewCluster("kwok-test", dir)
+	ctx := context.Background()
+
+	if got := c.ForkExecStartedAt(ctx, dir, "etcd"); !got.IsZero() {
+		t.Errorf("want zero without the pid file, got %v", got)
+	}
+
+	err := os.MkdirAll(filepath.Join(dir, "pids"), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pidPath := filepath.Join(dir, "pids", "etcd.pid")
+	err = os.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	startedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	err = os.Chtimes(pidPath, startedAt, startedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.ForkExecStartedAt(ctx, dir, "etcd"); !got.Equal(startedAt) {
+		t.Errorf("want the process started at %v, got %v", startedAt, got)
+	}
+}
+
+func TestContainerStartedAt(t *testing.T) {
+	dir := t.TempDir()
+	command := filepath.Join(dir, "docker")
+	err := os.WriteFile(command, []byte(`#!/bin/sh
+case "$2" in
+kwok-test-etcd)
+	echo '{"Running": true, "StartedAt": "2023-05-01T10:00:00.123456789Z"}'
+	;;
+kwok-test-kube-apiserver)
+	echo '{"Running": false, "StartedAt": "2023-05-01T10:00:00Z"}'
+	;;
+*)
+	exit 1
+	;;
+esac
+`), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCluster("kwok-test", dir)
+	ctx := context.Background()
+
+	got, err := c.ContainerStartedAt(ctx, command, "kwok-test-etcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2023, 5, 1, 10, 0, 0, 123456789, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("want the container started at %v, got %v", want, got)
+	}
+
+	got, err = c.ContainerStartedAt(ctx, command, "kwok-test-kube-apiserver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.IsZero() {
+		t.Errorf("want zero for the stopped container, got %v", got)
+	}
+
+	_, err = c.ContainerStartedAt(ctx, command, "kwok-test-missing")
+	if err == nil {
+		t.Errorf("want an error for the missing container")
+	}
+}
@@ -18,10 +18,12 @@ package runtime
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"io/fs"
 	"os"
 
+	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/kwokctl/pki"
 	"sigs.k8s.io/kwok/pkg/utils/file"
@@ -33,7 +35,23 @@ func (c *Cluster) DownloadWithCacheAndExtract(ctx context.Context, cacheDir, src
 		dryrun.PrintMessage("# Download %s and extract %s to %s", src, match, dest)
 		return nil
 	}
-	return file.DownloadWithCacheAndExtract(ctx, cacheDir, src, dest, match, mode, quiet, clean)
+	err := c.checkOffline(ctx, cacheDir, src, dest, match)
+	if err != nil {
+		return err
+	}
+	verification, err := c.getBinaryVerification(ctx, src)
+	if err != nil {
+		return err
+	}
+	err = file.DownloadWithCacheAndExtract(ctx, cacheDir, src, dest, match, mode, quiet, clean, verification)
+	if err != nil {
+		return err
+	}
+	err = c.checkPlatform(ctx, dest, mode)
+	if err != nil {
+		return err
+	}
+	return c.pruneCache(ctx, cacheDir)
 }
 
 // DownloadWithCache downloads the src file to the dest file.
@@ -42,7 +60,75 @@ func (c *Cluster) DownloadWithCache(ctx context.Context, cacheDir, src, dest str
 		dryrun.PrintMessage("# Download %s to %s", src, dest)
 		return nil
 	}
-	return file.DownloadWithCache(ctx, cacheDir, src, dest, mode, quiet)
+	err := c.checkOffline(ctx, cacheDir, src, dest, "")
+	if err != nil {
+		return err
+	}
+	verification, err := c.getBinaryVerification(ctx, src)
+	if err != nil {
+		return err
+	}
+	err = file.DownloadWithCache(ctx, cacheDir, src, dest, mode, quiet, verification)
+	if err != nil {
+		return err
+	}
+	err = c.checkPlatform(ctx, dest, mode)
+	if err != nil {
+		return err
+	}
+	return c.pruneCache(ctx, cacheDir)
+}
+
+// getBinaryVerification returns the verification of the src declared in the config.
+func (c *Cluster) getBinaryVerification(ctx context.Context, src string) (*file.Verification, error) {
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return GetBinaryVerification(&conf.Options, src), nil
+}
+
+// checkPlatform returns an error if the executable is built for another platform,
+// rather than failing with the exec format error as it runs.
+func (c *Cluster) checkPlatform(ctx context.Context, name string, mode fs.FileMode) error {
+	if mode&0111 == 0 {
+		return nil
+	}
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	goos, goarch, err := file.Platform(name)
+	if err != nil {
+		return err
+	}
+	if goos == "" {
+		return nil
+	}
+	arch := conf.Options.Arch
+	if arch == "" {
+		arch = config.GOARCH
+	}
+	if goos != config.GOOS || (goarch != "" && goarch != arch) {
+		return fmt.Errorf("%s is built for %s/%s, but %s/%s is required, give the one built for %s/%s or change the architecture with --arch",
+			name, goos, goarch, config.GOOS, arch, config.GOOS, arch)
+	}
+	return nil
+}
+
+// checkOffline returns an error if the cluster is offline and the src file is not got yet.
+func (c *Cluster) checkOffline(ctx context.Context, cacheDir, src, dest string, match string) error {
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Options.Offline {
+		return nil
+	}
+	if file.Exists(dest) || file.IsCached(cacheDir, src, match) {
+		return nil
+	}
+	return fmt.Errorf("%s is not in the cache %s, and the network is not used in offline mode, load it with 'kwokctl images load' first", src, cacheDir)
 }
 
 // GeneratePki generates the pki for kwokctl
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestCheckOffline(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	dest := filepath.Join(dir, "bin", "kube-apiserver")
+	src := "https://dl.k8s.io/v1.28.0/bin/linux/amd64/kube-apiserver"
+	cache := filepath.Join(cacheDir, "https", "dl.k8s.io", "v1.28.0", "bin", "linux", "amd64", "kube-apiserver")
+
+	newCluster := func(offline bool) *Cluster {
+		c := NewCluster("kwok-test", dir)
+		err := c.SetConfig(context.Background(), &internalversion.KwokctlConfiguration{
+			Options: internalversion.KwokctlConfigurationOptions{
+				Offline: offline,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	write := func(p string) {
+		err := os.MkdirAll(filepath.Dir(p), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(p, nil, 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	err := newCluster(false).checkOffline(ctx, cacheDir, src, dest, "")
+	if err != nil {
+		t.Errorf("want the download allowed online, got %v", err)
+	}
+
+	err = newCluster(true).checkOffline(ctx, cacheDir, src, dest, "")
+	if err == nil {
+		t.Errorf("want an error for the missing file offline")
+	}
+
+	write(cache)
+	err = newCluster(true).checkOffline(ctx, cacheDir, src, dest, "")
+	if err != nil {
+		t.Errorf("want the cached file used offline, got %v", err)
+	}
+
+	err = os.Remove(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(dest)
+	err = newCluster(true).checkOffline(ctx, cacheDir, src, dest, "")
+	if err != nil {
+		t.Errorf("want the existing file used offline, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+
+	_ "embed"
+)
+
+//go:embed grafana_datasource.yaml.tpl
+var grafanaDatasourceYamlTpl string
+
+var grafanaDatasourceYamlTemplate = template.Must(template.New("grafana_datasource").Parse(grafanaDatasourceYamlTpl))
+
+//go:embed grafana_dashboards.yaml
+var grafanaDashboardsYaml string
+
+//go:embed grafana_dashboard_kwok_controller.json
+var grafanaDashboardKwokControllerJSON string
+
+//go:embed grafana_dashboard_cluster.json
+var grafanaDashboardClusterJSON string
+
+// GrafanaProvisioning is the files provisioning Grafana.
+type GrafanaProvisioning struct {
+	// Datasources is the files provisioning the datasources, keyed by the file name.
+	Datasources map[string]string
+	// Dashboards is the files provisioning the dashboards, keyed by the file name.
+	Dashboards map[string]string
+}
+
+// BuildGrafanaProvisioningConfig is the configuration for building the provisioning of Grafana.
+type BuildGrafanaProvisioningConfig struct {
+	PrometheusURL string
+}
+
+// BuildGrafanaProvisioning builds the provisioning of Grafana,
+// with the Prometheus as the default datasource and the dashboards of the kwok-controller and the simulated cluster.
+func BuildGrafanaProvisioning(conf BuildGrafanaProvisioningConfig) (*GrafanaProvisioning, error) {
+	buf := bytes.NewBuffer(nil)
+	err := grafanaDatasourceYamlTemplate.Execute(buf, conf)
+	if err != nil {
+		return nil, fmt.Errorf("build grafana datasource error: %w", err)
+	}
+	return &GrafanaProvisioning{
+		Datasources: map[string]string{
+			"prometheus.yaml": buf.String(),
+		},
+		Dashboards: map[string]string{
+			"kwok.yaml":            grafanaDashboardsYaml,
+			"kwok-controller.json": grafanaDashboardKwokControllerJSON,
+			"cluster.json":         grafanaDashboardClusterJSON,
+		},
+	}, nil
+}
+
+// SetupGrafanaPorts gives random ports to Grafana and the Prometheus it is pre-wired to if Grafana is enabled,
+// the ports already set are kept.
+func (c *Cluster) SetupGrafanaPorts(ctx context.Context, conf *internalversion.KwokctlConfigurationOptions) error {
+	if !conf.EnableGrafana {
+		return nil
+	}
+	for _, port := range []*uint32{&conf.PrometheusPort, &conf.GrafanaPort} {
+		if *port != 0 {
+			continue
+		}
+		p, err := c.GetUnusedPort(ctx)
+		if err != nil {
+			return err
+		}
+		*port = p
+	}
+	return nil
+}
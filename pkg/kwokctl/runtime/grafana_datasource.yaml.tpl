@@ -0,0 +1,9 @@
+apiVersion: 1
+datasources:
+- name: Prometheus
+  uid: prometheus
+  type: prometheus
+  access: proxy
+  url: {{ .PrometheusURL }}
+  isDefault: true
+  editable: false
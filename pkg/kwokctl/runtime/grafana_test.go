@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+)
+
+func TestBuildGrafanaProvisioning(t *testing.T) {
+	provisioning, err := BuildGrafanaProvisioning(BuildGrafanaProvisioningConfig{
+		PrometheusURL: "http://kwok-kwok-prometheus:9090",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var datasources struct {
+		Datasources []struct {
+			Name      string `json:"name"`
+			URL       string `json:"url"`
+			IsDefault bool   `json:"isDefault"`
+		} `json:"datasources"`
+	}
+	err = yaml.Unmarshal([]byte(provisioning.Datasources["prometheus.yaml"]), &datasources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(datasources.Datasources) != 1 ||
+		datasources.Datasources[0].URL != "http://kwok-kwok-prometheus:9090" ||
+		!datasources.Datasources[0].IsDefault {
+		t.Errorf("want the prometheus as the default datasource, got %+v", datasources)
+	}
+
+	for name, data := range provisioning.Dashboards {
+		if strings.HasSuffix(name, ".json") {
+			if !json.Valid([]byte(data)) {
+				t.Errorf("want the dashboard %s in valid json", name)
+			}
+			continue
+		}
+		var out map[string]any
+		err = yaml.Unmarshal([]byte(data), &out)
+		if err != nil {
+			t.Errorf("want the dashboard provider %s in valid yaml, got %v", name, err)
+		}
+	}
+	if len(provisioning.Dashboards) != 3 {
+		t.Errorf("want the provider and the two dashboards, got %d files", len(provisioning.Dashboards))
+	}
+}
+
+func TestSetupGrafanaPorts(t *testing.T) {
+	defer func(old string) { config.PortsDir = old }(config.PortsDir)
+	config.PortsDir = t.TempDir()
+
+	ctx := context.Background()
+	c := NewCluster("kwok-test", t.TempDir())
+	err := c.SetConfig(ctx, &internalversion.KwokctlConfiguration{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf := &internalversion.KwokctlConfigurationOptions{}
+	err = c.SetupGrafanaPorts(ctx, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.PrometheusPort != 0 || conf.GrafanaPort != 0 {
+		t.Errorf("want no ports without grafana, got %d and %d", conf.PrometheusPort, conf.GrafanaPort)
+	}
+
+	conf = &internalversion.KwokctlConfigurationOptions{
+		EnableGrafana:  true,
+		PrometheusPort: 9090,
+	}
+	err = c.SetupGrafanaPorts(ctx, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.PrometheusPort != 9090 {
+		t.Errorf("want the prometheus port kept, got %d", conf.PrometheusPort)
+	}
+	if conf.GrafanaPort == 0 {
+		t.Errorf("want a random grafana port")
+	}
+}
@@ -86,6 +86,7 @@ type env struct {
 	verbosity           log.Level
 	inClusterKubeconfig string
 	auditLogPath        string
+	auditWebhookPath    string
 	auditPolicyPath     string
 }
 
@@ -98,9 +99,13 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 	inClusterKubeconfig := "/etc/kubernetes/scheduler.conf"
 	auditLogPath := ""
 	auditPolicyPath := ""
+	auditWebhookPath := ""
 	if config.Options.KubeAuditPolicy != "" {
 		auditLogPath = c.GetLogPath(runtime.AuditLogName)
 		auditPolicyPath = c.GetWorkdirPath(runtime.AuditPolicyName)
+		if config.Options.KubeAuditWebhook != "" {
+			auditWebhookPath = c.GetWorkdirPath(runtime.AuditWebhookName)
+		}
 	}
 
 	logger := log.FromContext(ctx)
@@ -111,6 +116,7 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 		verbosity:           verbosity,
 		inClusterKubeconfig: inClusterKubeconfig,
 		auditLogPath:        auditLogPath,
+		auditWebhookPath:    auditWebhookPath,
 		auditPolicyPath:     auditPolicyPath,
 	}, nil
 }
@@ -127,6 +133,32 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	conf := &env.kwokctlConfig.Options
+	if conf.KubeApiserverReplicas > 1 || conf.KubeControllerManagerReplicas > 1 || conf.KubeSchedulerReplicas > 1 {
+		return fmt.Errorf("the replicas of the control plane components are not supported in kind")
+	}
+	if len(conf.EtcdServers) != 0 {
+		return fmt.Errorf("the external etcd is not supported in kind")
+	}
+	if len(env.kwokctlConfig.Components) != 0 {
+		return fmt.Errorf("the custom components are not supported in kind")
+	}
+	if conf.ComponentsCPULimit != "" || conf.ComponentsMemoryLimit != "" {
+		return fmt.Errorf("the resource limits of the components are not supported in kind")
+	}
+	err = c.SetupGrafanaPorts(ctx, conf)
+	if err != nil {
+		return err
+	}
+	if conf.KindClusterName != "" {
+		if conf.KubeAuditPolicy != "" || conf.KubeSchedulerConfig != "" {
+			return fmt.Errorf("the audit policy and the scheduler config are not supported when adopting the kind cluster %q", conf.KindClusterName)
+		}
+		if conf.DashboardPort != 0 || conf.PrometheusPort != 0 || conf.JaegerPort != 0 || conf.GrafanaPort != 0 || conf.KwokControllerPort != 0 {
+			return fmt.Errorf("the ports given to the host are not supported when adopting the kind cluster %q", conf.KindClusterName)
+		}
+	}
+
 	err = c.addKind(ctx, env)
 	if err != nil {
 		return err
@@ -147,6 +179,11 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	err = c.addGrafana(ctx, env)
+	if err != nil {
+		return err
+	}
+
 	err = c.pullAllImages(ctx, env)
 	if err != nil {
 		return err
@@ -182,6 +219,13 @@ func (c *Cluster) addKind(ctx context.Context, env *env) (err error) {
 		if err != nil {
 			return err
 		}
+
+		if conf.KubeAuditWebhook != "" {
+			err = c.CopyFile(conf.KubeAuditWebhook, env.auditWebhookPath)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	schedulerConfigPath := ""
@@ -230,11 +274,28 @@ func (c *Cluster) addKind(ctx context.Context, env *env) (err error) {
 		len(kubeControllerManagerComponentPatches.ExtraEnvs) > 0 {
 		logger.Warn("extraEnvs config in etcd, kube-apiserver, kube-scheduler or kube-controller-manager is not supported in kind")
 	}
+	if conf.KindClusterName != "" {
+		if len(kwokControllerExtraVolumes) != 0 {
+			return fmt.Errorf("the extra volumes of kwok-controller are not supported when adopting the kind cluster %q", conf.KindClusterName)
+		}
+		// The kind cluster already exists, so only the kwok-controller is deployed into it
+		return c.addKwokControllerPod(ctx, env, kwokControllerComponentPatches, kwokControllerExtraVolumes)
+	}
+
+	etcdExtraArgs := []internalversion.ExtraArgs{}
+	if conf.EtcdAutoCompactionMode != "" {
+		etcdExtraArgs = append(etcdExtraArgs, internalversion.ExtraArgs{Key: "auto-compaction-mode", Value: conf.EtcdAutoCompactionMode})
+	}
+	if conf.EtcdAutoCompactionRetention != "" {
+		etcdExtraArgs = append(etcdExtraArgs, internalversion.ExtraArgs{Key: "auto-compaction-retention", Value: conf.EtcdAutoCompactionRetention})
+	}
+	etcdExtraArgs = append(etcdExtraArgs, etcdComponentPatches.ExtraArgs...)
 	kindYaml, err := BuildKind(BuildKindConfig{
 		BindAddress:                   conf.BindAddress,
 		KubeApiserverPort:             conf.KubeApiserverPort,
 		EtcdPort:                      conf.EtcdPort,
 		JaegerPort:                    conf.JaegerPort,
+		GrafanaPort:                   conf.GrafanaPort,
 		DashboardPort:                 conf.DashboardPort,
 		PrometheusPort:                conf.PrometheusPort,
 		KwokControllerPort:            conf.KwokControllerPort,
@@ -242,11 +303,12 @@ func (c *Cluster) addKind(ctx context.Context, env *env) (err error) {
 		RuntimeConfig:                 runtimeConfig,
 		AuditPolicy:                   env.auditPolicyPath,
 		AuditLog:                      env.auditLogPath,
+		AuditWebhook:                  env.auditWebhookPath,
 		SchedulerConfig:               schedulerConfigPath,
 		ConfigPath:                    configPath,
 		TracingConfigPath:             kubeApiserverTracingConfigPath,
 		Verbosity:                     env.verbosity,
-		EtcdExtraArgs:                 etcdComponentPatches.ExtraArgs,
+		EtcdExtraArgs:                 etcdExtraArgs,
 		EtcdExtraVolumes:              etcdComponentPatches.ExtraVolumes,
 		ApiserverExtraArgs:            kubeApiserverComponentPatches.ExtraArgs,
 		ApiserverExtraVolumes:         kubeApiserverComponentPatches.ExtraVolumes,
@@ -267,6 +329,11 @@ func (c *Cluster) addKind(ctx context.Context, env *env) (err error) {
 		return fmt.Errorf("failed to write %s: %w", runtime.KindName, err)
 	}
 
+	return c.addKwokControllerPod(ctx, env, kwokControllerComponentPatches, kwokControllerExtraVolumes)
+}
+
+func (c *Cluster) addKwokControllerPod(_ context.Context, env *env, kwokControllerComponentPatches internalversion.ComponentPatches, kwokControllerExtraVolumes []internalversion.Volume) error {
+	conf := &env.kwokctlConfig.Options
 	kwokControllerPod, err := BuildKwokControllerPod(BuildKwokControllerPodConfig{
 		KwokControllerImage:      conf.KwokControllerImage,
 		Name:                     c.Name(),
@@ -366,6 +433,40 @@ func (c *Cluster) addJaeger(_ context.Context, env *env) error {
 	return nil
 }
 
+func (c *Cluster) addGrafana(_ context.Context, env *env) error {
+	conf := &env.kwokctlConfig.Options
+
+	if conf.GrafanaPort != 0 {
+		// The prometheus is working in the host network of the control plane
+		provisioning, err := runtime.BuildGrafanaProvisioning(runtime.BuildGrafanaProvisioningConfig{
+			PrometheusURL: "http://localhost:9090",
+		})
+		if err != nil {
+			return err
+		}
+		grafanaPatches := runtime.GetComponentPatches(env.kwokctlConfig, consts.ComponentGrafana)
+		grafanaConf := BuildGrafanaDeploymentConfig{
+			GrafanaImage: conf.GrafanaImage,
+			Name:         c.Name(),
+			Provisioning: provisioning,
+			ExtraVolumes: grafanaPatches.ExtraVolumes,
+			ExtraEnvs:    grafanaPatches.ExtraEnvs,
+		}
+		if env.verbosity != log.LevelInfo {
+			grafanaConf.LogLevel = log.ToLogSeverityLevel(env.verbosity)
+		}
+		grafanaDeploy, err := BuildGrafanaDeployment(grafanaConf)
+		if err != nil {
+			return err
+		}
+		err = c.WriteFile(c.GetWorkdirPath(runtime.GrafanaDeploy), []byte(grafanaDeploy))
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", runtime.GrafanaDeploy, err)
+		}
+	}
+	return nil
+}
+
 // Up starts the cluster.
 func (c *Cluster) Up(ctx context.Context) error {
 	config, err := c.Config(ctx)
@@ -410,6 +511,14 @@ func (c *Cluster) Up(ctx context.Context) error {
 		)
 	}
 
+	if conf.GrafanaPort != 0 {
+		config.Components = append(config.Components,
+			internalversion.Component{
+				Name: consts.ComponentGrafana,
+			},
+		)
+	}
+
 	if !conf.DisableKubeScheduler {
 		config.Components = append(config.Components,
 			internalversion.Component{
@@ -444,31 +553,39 @@ func (c *Cluster) Up(ctx context.Context) error {
 		return err
 	}
 
-	args := []string{
-		"create", "cluster",
-		"--config", c.GetWorkdirPath(runtime.KindName),
-		"--name", c.Name(),
-		"--image", conf.KindNodeImage,
-	}
+	if conf.KindClusterName == "" {
+		args := []string{
+			"create", "cluster",
+			"--config", c.GetWorkdirPath(runtime.KindName),
+			"--name", c.Name(),
+			"--image", conf.KindNodeImage,
+		}
 
-	deadline, ok := ctx.Deadline()
-	if ok {
-		wait := time.Until(deadline)
-		if wait < 0 {
-			wait = time.Minute
+		deadline, ok := ctx.Deadline()
+		if ok {
+			wait := time.Until(deadline)
+			if wait < 0 {
+				wait = time.Minute
+			}
+			args = append(args, "--wait", format.HumanDuration(wait))
+		} else {
+			args = append(args, "--wait", "1m")
 		}
-		args = append(args, "--wait", format.HumanDuration(wait))
-	} else {
-		args = append(args, "--wait", "1m")
-	}
 
-	err = c.Exec(exec.WithAllWriteToErrOut(c.withProviderEnv(ctx)), kindPath, args...)
-	if err != nil {
-		return err
+		err = c.Exec(exec.WithAllWriteToErrOut(c.withProviderEnv(ctx)), kindPath, args...)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Switch the current context to the adopted kind cluster as `kind create cluster` does
+		err = c.Exec(exec.WithAllWriteToErrOut(c.withProviderEnv(ctx)), kindPath, "export", "kubeconfig", "--name", conf.KindClusterName)
+		if err != nil {
+			return err
+		}
 	}
 
 	// TODO: remove this when kind support set server
-	err = c.fillKubeconfigContextServer(conf.BindAddress)
+	err = c.fillKubeconfigContextServer(ctx, conf.BindAddress)
 	if err != nil {
 		return err
 	}
@@ -491,7 +608,18 @@ func (c *Cluster) Up(ctx context.Context) error {
 		return err
 	}
 
-	kindName := c.getClusterName()
+	kindName := c.getClusterName(ctx)
+	if conf.KindClusterName != "" {
+		// The configuration is mounted into the node created by kwokctl, but it has to be copied into the adopted one
+		err = c.Exec(ctx, c.runtime, "exec", kindName, "mkdir", "-p", "/etc/kwok")
+		if err != nil {
+			return err
+		}
+		err = c.Exec(ctx, c.runtime, "cp", c.GetWorkdirPath(runtime.ConfigName), kindName+":/etc/kwok/kwok.yaml")
+		if err != nil {
+			return err
+		}
+	}
 	err = c.Exec(ctx, c.runtime, "cp", c.GetWorkdirPath(runtime.KwokPod), kindName+":/etc/kubernetes/manifests/kwok-controller.yaml")
 	if err != nil {
 		return err
@@ -531,11 +659,20 @@ func (c *Cluster) Up(ctx context.Context) error {
 			return err
 		}
 	}
+	if conf.GrafanaPort != 0 {
+		err = c.Kubectl(exec.WithAllWriteToErrOut(ctx), "apply", "-f", c.GetWorkdirPath(runtime.GrafanaDeploy))
+		if err != nil {
+			return err
+		}
+	}
 
-	// Cordoning the node to prevent fake pods from being scheduled on it
-	err = c.Kubectl(ctx, "cordon", c.getClusterName())
-	if err != nil {
-		logger.Error("Failed cordon node", err)
+	// Cordoning the node to prevent fake pods from being scheduled on it,
+	// the nodes of the adopted kind cluster are left as they are
+	if conf.KindClusterName == "" {
+		err = c.Kubectl(ctx, "cordon", c.getClusterName(ctx))
+		if err != nil {
+			logger.Error("Failed cordon node", err)
+		}
 	}
 
 	if conf.DisableKubeScheduler {
@@ -557,10 +694,12 @@ func (c *Cluster) Up(ctx context.Context) error {
 
 func (c *Cluster) pullAllImages(ctx context.Context, env *env) error {
 	conf := &env.kwokctlConfig.Options
-	images := []string{
-		conf.KindNodeImage,
-		conf.KwokControllerImage,
+	images := []string{}
+	if conf.KindClusterName == "" {
+		images = append(images, conf.KindNodeImage)
 	}
+	// The images existing locally are not pulled, so the locally built kwok-controller image is loaded into kind as it is
+	images = append(images, conf.KwokControllerImage)
 	if conf.DashboardPort != 0 {
 		images = append(images, conf.DashboardImage)
 	}
@@ -570,6 +709,9 @@ func (c *Cluster) pullAllImages(ctx context.Context, env *env) error {
 	if conf.JaegerPort != 0 {
 		images = append(images, conf.JaegerImage)
 	}
+	if conf.GrafanaPort != 0 {
+		images = append(images, conf.GrafanaImage)
+	}
 	err := c.PullImages(ctx, c.runtime, images, conf.QuietPull)
 	if err != nil {
 		return err
@@ -598,11 +740,14 @@ func (c *Cluster) loadAllImages(ctx context.Context) error {
 	if conf.JaegerPort != 0 {
 		images = append(images, conf.JaegerImage)
 	}
+	if conf.GrafanaPort != 0 {
+		images = append(images, conf.GrafanaImage)
+	}
 
 	if c.runtime == consts.RuntimeTypeDocker {
-		err = c.loadDockerImages(ctx, kindPath, c.Name(), images)
+		err = c.loadDockerImages(ctx, kindPath, c.getKindName(ctx), images)
 	} else {
-		err = c.loadArchiveImages(ctx, kindPath, c.Name(), images, c.runtime, conf.CacheDir)
+		err = c.loadArchiveImages(ctx, kindPath, c.getKindName(ctx), images, c.runtime, conf.CacheDir)
 	}
 	if err != nil {
 		return err
@@ -694,6 +839,17 @@ func (c *Cluster) WaitReady(ctx context.Context, timeout time.Duration) error {
 	return nil
 }
 
+// InitCRDs initializes the CRDs and registers the metrics APIs served by the kwok-controller,
+// which runs in the host network of the control plane node as the kube-apiserver
+func (c *Cluster) InitCRDs(ctx context.Context) error {
+	err := c.Cluster.InitCRDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.InitMetricsAPIService(ctx, "localhost", 10247)
+}
+
 // Ready returns true if the cluster is ready
 func (c *Cluster) Ready(ctx context.Context) (bool, error) {
 	ok, err := c.Cluster.Ready(ctx)
@@ -742,6 +898,16 @@ func (c *Cluster) Down(ctx context.Context) error {
 	}
 
 	logger := log.FromContext(ctx)
+	if c.isAdopted(ctx) {
+		// Only the kwok-controller is removed from the adopted kind cluster, which is kept
+		kindName := c.getClusterName(ctx)
+		err = c.Exec(ctx, c.runtime, "exec", kindName, "rm", "-f", "/etc/kubernetes/manifests/kwok-controller.yaml", "/etc/kwok/kwok.yaml")
+		if err != nil {
+			logger.Error("Failed to remove kwok-controller from the kind cluster", err)
+		}
+		return nil
+	}
+
 	err = c.Exec(exec.WithAllWriteToErrOut(c.withProviderEnv(ctx)), kindPath, "delete", "cluster", "--name", c.Name())
 	if err != nil {
 		logger.Error("Failed to delete cluster", err)
@@ -752,7 +918,10 @@ func (c *Cluster) Down(ctx context.Context) error {
 
 // Start starts the cluster
 func (c *Cluster) Start(ctx context.Context) error {
-	err := c.Exec(ctx, c.runtime, "start", c.getClusterName())
+	if c.isAdopted(ctx) {
+		return c.StartComponent(ctx, consts.ComponentKwokController)
+	}
+	err := c.Exec(ctx, c.runtime, "start", c.getClusterName(ctx))
 	if err != nil {
 		return err
 	}
@@ -761,7 +930,10 @@ func (c *Cluster) Start(ctx context.Context) error {
 
 // Stop stops the cluster
 func (c *Cluster) Stop(ctx context.Context) error {
-	err := c.Exec(ctx, c.runtime, "stop", c.getClusterName())
+	if c.isAdopted(ctx) {
+		return c.StopComponent(ctx, consts.ComponentKwokController)
+	}
+	err := c.Exec(ctx, c.runtime, "stop", c.getClusterName(ctx))
 	if err != nil {
 		return err
 	}
@@ -789,7 +961,7 @@ func (c *Cluster) StartComponent(ctx context.Context, name string) error {
 	}
 
 	logger.Debug("Starting component")
-	err := c.Exec(ctx, c.runtime, "exec", c.getClusterName(), "mv", "/etc/kubernetes/"+name+".yaml.bak", "/etc/kubernetes/manifests/"+name+".yaml")
+	err := c.Exec(ctx, c.runtime, "exec", c.getClusterName(ctx), "mv", "/etc/kubernetes/"+name+".yaml.bak", "/etc/kubernetes/manifests/"+name+".yaml")
 	if err != nil {
 		return err
 	}
@@ -799,6 +971,21 @@ func (c *Cluster) StartComponent(ctx context.Context, name string) error {
 	return c.waitComponentReady(ctx, name, true, 120*time.Second)
 }
 
+// UpgradeComponent is not supported in kind, the control plane of which is managed by kind
+func (c *Cluster) UpgradeComponent(ctx context.Context, name string) error {
+	return fmt.Errorf("upgrading the component %s is not supported in kind", name)
+}
+
+// InstallComponent is not supported in kind, the ports of which are mapped when the cluster is created
+func (c *Cluster) InstallComponent(ctx context.Context, name string) error {
+	return fmt.Errorf("installing the component %s is not supported in kind", name)
+}
+
+// UninstallComponent is not supported in kind, the ports of which are mapped when the cluster is created
+func (c *Cluster) UninstallComponent(ctx context.Context, name string) error {
+	return fmt.Errorf("uninstalling the component %s is not supported in kind", name)
+}
+
 // StopComponent stops a component in the cluster
 func (c *Cluster) StopComponent(ctx context.Context, name string) error {
 	logger := log.FromContext(ctx)
@@ -815,7 +1002,7 @@ func (c *Cluster) StopComponent(ctx context.Context, name string) error {
 	}
 
 	logger.Debug("Stopping component")
-	err := c.Exec(ctx, c.runtime, "exec", c.getClusterName(), "mv", "/etc/kubernetes/manifests/"+name+".yaml", "/etc/kubernetes/"+name+".yaml.bak")
+	err := c.Exec(ctx, c.runtime, "exec", c.getClusterName(ctx), "mv", "/etc/kubernetes/manifests/"+name+".yaml", "/etc/kubernetes/"+name+".yaml.bak")
 	if err != nil {
 		return err
 	}
@@ -829,6 +1016,19 @@ func (c *Cluster) StopComponent(ctx context.Context, name string) error {
 	return c.waitComponentReady(ctx, name, false, 120*time.Second)
 }
 
+// ComponentReady returns true if the component is ready
+func (c *Cluster) ComponentReady(ctx context.Context, name string) (bool, error) {
+	_, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	ready, _, err := c.inspectComponent(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return ready, nil
+}
+
 // waitComponentReady waits for a component to be ready
 func (c *Cluster) waitComponentReady(ctx context.Context, name string, wantReady bool, timeout time.Duration) error {
 	var (
@@ -867,7 +1067,7 @@ func (c *Cluster) waitComponentReady(ctx context.Context, name string, wantReady
 
 func (c *Cluster) inspectComponent(ctx context.Context, name string) (ready bool, exist bool, err error) {
 	out := bytes.NewBuffer(nil)
-	err = c.KubectlInCluster(exec.WithWriteTo(ctx, out), "get", "pod", "--namespace=kube-system", "--output=json", c.getComponentName(name))
+	err = c.KubectlInCluster(exec.WithWriteTo(ctx, out), "get", "pod", "--namespace=kube-system", "--output=json", c.getComponentName(ctx, name))
 	if err != nil {
 		if strings.Contains(out.String(), "NotFound") {
 			return false, false, nil
@@ -896,14 +1096,33 @@ func (c *Cluster) inspectComponent(ctx context.Context, name string) (ready bool
 	return true, true, nil
 }
 
-func (c *Cluster) getClusterName() string {
-	return c.Name() + "-control-plane"
+// getKindName returns the name of the kind cluster,
+// which is the name of the adopted kind cluster if there is one.
+func (c *Cluster) getKindName(ctx context.Context) string {
+	config, err := c.Config(ctx)
+	if err == nil && config.Options.KindClusterName != "" {
+		return config.Options.KindClusterName
+	}
+	return c.Name()
+}
+
+// isAdopted returns true if the kind cluster is not created by kwokctl but adopted by `kwokctl adopt`
+func (c *Cluster) isAdopted(ctx context.Context) bool {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return false
+	}
+	return config.Options.KindClusterName != ""
 }
 
-func (c *Cluster) getComponentName(name string) string {
-	clusterName := c.getClusterName()
+func (c *Cluster) getClusterName(ctx context.Context) string {
+	return c.getKindName(ctx) + "-control-plane"
+}
+
+func (c *Cluster) getComponentName(ctx context.Context, name string) string {
+	clusterName := c.getClusterName(ctx)
 	switch name {
-	case consts.ComponentPrometheus:
+	case consts.ComponentPrometheus, consts.ComponentGrafana:
 	default:
 		name = name + "-" + clusterName
 	}
@@ -911,7 +1130,7 @@ func (c *Cluster) getComponentName(name string) string {
 }
 
 func (c *Cluster) logs(ctx context.Context, name string, out io.Writer, follow bool) error {
-	componentName := c.getComponentName(name)
+	componentName := c.getComponentName(ctx, name)
 
 	args := []string{"logs", "-n", "kube-system"}
 	if follow {
@@ -1028,6 +1247,16 @@ func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
 	return nil
 }
 
+// ComponentStartedAt return the time the component of cluster was started at, zero if it is not running,
+// the components run in the node container of kind, so it is the time the node container was started at
+func (c *Cluster) ComponentStartedAt(ctx context.Context, name string) (time.Time, error) {
+	_, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.ContainerStartedAt(ctx, c.runtime, c.getClusterName(ctx))
+}
+
 // ListBinaries list binaries in the cluster
 func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
 	config, err := c.Config(ctx)
@@ -1037,6 +1266,7 @@ func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
 	conf := &config.Options
 
 	return []string{
+		conf.KindBinary,
 		conf.KubectlBinary,
 	}, nil
 }
@@ -1056,9 +1286,34 @@ func (c *Cluster) ListImages(ctx context.Context) ([]string, error) {
 	}, nil
 }
 
+// SaveImages save the images of the cluster to the archive
+func (c *Cluster) SaveImages(ctx context.Context, archive string) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	conf := &config.Options
+
+	images, err := c.ListImages(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.PullImages(ctx, c.runtime, images, conf.QuietPull)
+	if err != nil {
+		return err
+	}
+	return c.SaveImageArchive(ctx, c.runtime, images, archive)
+}
+
+// LoadImages load the images from the archive
+func (c *Cluster) LoadImages(ctx context.Context, archive string) error {
+	return c.LoadImageArchive(ctx, c.runtime, archive)
+}
+
 // EtcdctlInCluster implements the ectdctl subcommand
 func (c *Cluster) EtcdctlInCluster(ctx context.Context, args ...string) error {
-	etcdContainerName := c.getComponentName(consts.ComponentEtcd)
+	etcdContainerName := c.getComponentName(ctx, consts.ComponentEtcd)
 
 	args = append(
 		[]string{
@@ -36,8 +36,8 @@ func (c *Cluster) AddContext(ctx context.Context, kubeconfigPath string) error {
 
 	kubeConfig := &kubeconfig.Config{
 		Context: &clientcmdapi.Context{
-			Cluster:  "kind-" + c.Name(),
-			AuthInfo: "kind-" + c.Name(),
+			Cluster:  "kind-" + c.getKindName(ctx),
+			AuthInfo: "kind-" + c.getKindName(ctx),
 		},
 	}
 	err := kubeconfig.AddContext(kubeconfigPath, c.Name(), kubeConfig)
@@ -63,9 +63,9 @@ func (c *Cluster) RemoveContext(ctx context.Context, kubeconfigPath string) erro
 
 // fillKubeconfigContextServer fill the server of cluster to kubeconfig
 // because the server of cluster not set in kind, so we need to fill it.
-func (c *Cluster) fillKubeconfigContextServer(bindAddress string) error {
+func (c *Cluster) fillKubeconfigContextServer(ctx context.Context, bindAddress string) error {
 	kubeconfigPath := kubeconfig.GetRecommendedKubeconfigPath()
-	name := "kind-" + c.Name()
+	name := "kind-" + c.getKindName(ctx)
 	err := kubeconfig.ModifyContext(kubeconfigPath, withFillContextServer(name, bindAddress))
 	if err != nil {
 		return err
@@ -26,7 +26,7 @@ import (
 
 // SnapshotSave save the snapshot of cluster
 func (c *Cluster) SnapshotSave(ctx context.Context, path string) error {
-	kindName := c.getClusterName()
+	kindName := c.getClusterName(ctx)
 
 	logger := log.FromContext(ctx)
 
@@ -81,7 +81,7 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 		}
 	}()
 
-	kindName := c.getClusterName()
+	kindName := c.getClusterName(ctx)
 	// Copy to kind container from host temporary directory
 	err = c.Exec(ctx, c.runtime, "cp", etcdDataTmp, kindName+":/var/lib/")
 	if err != nil {
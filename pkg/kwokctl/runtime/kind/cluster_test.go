@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+func TestClusterAdoptedNames(t *testing.T) {
+	tests := []struct {
+		name               string
+		kindClusterName    string
+		wantAdopted        bool
+		wantKindName       string
+		wantControllerName string
+		wantPrometheusName string
+	}{
+		{
+			name:               "created",
+			wantKindName:       "kwok-test",
+			wantControllerName: "kwok-controller-kwok-test-control-plane",
+			wantPrometheusName: "prometheus",
+		},
+		{
+			name:               "adopted",
+			kindClusterName:    "kind",
+			wantAdopted:        true,
+			wantKindName:       "kind",
+			wantControllerName: "kwok-controller-kind-control-plane",
+			wantPrometheusName: "prometheus",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			rt, err := NewDockerCluster("kwok-test", t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := rt.(*Cluster)
+			err = c.SetConfig(ctx, &internalversion.KwokctlConfiguration{
+				Options: internalversion.KwokctlConfigurationOptions{
+					KindClusterName: tt.kindClusterName,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := c.isAdopted(ctx); got != tt.wantAdopted {
+				t.Errorf("isAdopted() = %v, want %v", got, tt.wantAdopted)
+			}
+			if got := c.getKindName(ctx); got != tt.wantKindName {
+				t.Errorf("getKindName() = %v, want %v", got, tt.wantKindName)
+			}
+			if got := c.getComponentName(ctx, "kwok-controller"); got != tt.wantControllerName {
+				t.Errorf("getComponentName() = %v, want %v", got, tt.wantControllerName)
+			}
+			if got := c.getComponentName(ctx, "prometheus"); got != tt.wantPrometheusName {
+				t.Errorf("getComponentName() = %v, want %v", got, tt.wantPrometheusName)
+			}
+		})
+	}
+}
+
+func TestClusterWithoutConfig(t *testing.T) {
+	ctx := context.Background()
+	c := &Cluster{Cluster: runtime.NewCluster("kwok-test", t.TempDir())}
+	if c.isAdopted(ctx) {
+		t.Errorf("want the cluster without config not adopted")
+	}
+	if got := c.getKindName(ctx); got != "kwok-test" {
+		t.Errorf("getKindName() = %v, want kwok-test", got)
+	}
+}
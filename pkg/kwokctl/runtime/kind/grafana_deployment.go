@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+
+	_ "embed"
+)
+
+//go:embed grafana_deployment.yaml.tpl
+var grafanaDeploymentYamlTpl string
+
+var grafanaDeploymentYamlTemplate = template.Must(template.New("grafana_deployment").Funcs(template.FuncMap{
+	"indent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		return pad + strings.ReplaceAll(strings.TrimSuffix(s, "\n"), "\n", "\n"+pad)
+	},
+}).Parse(grafanaDeploymentYamlTpl))
+
+// BuildGrafanaDeployment builds the grafana deployment yaml content.
+func BuildGrafanaDeployment(conf BuildGrafanaDeploymentConfig) (string, error) {
+	buf := bytes.NewBuffer(nil)
+
+	var err error
+	conf.ExtraVolumes, err = runtime.ExpandVolumesHostPaths(conf.ExtraVolumes)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand host volume paths: %w", err)
+	}
+
+	err = grafanaDeploymentYamlTemplate.Execute(buf, conf)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute grafana deployment yaml template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// BuildGrafanaDeploymentConfig is the configuration for building the grafana deployment
+type BuildGrafanaDeploymentConfig struct {
+	GrafanaImage string
+	Name         string
+	LogLevel     string
+	Provisioning *runtime.GrafanaProvisioning
+	ExtraVolumes []internalversion.Volume
+	ExtraEnvs    []internalversion.Env
+}
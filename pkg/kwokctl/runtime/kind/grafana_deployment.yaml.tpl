@@ -0,0 +1,82 @@
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: grafana-datasources
+  namespace: kube-system
+data:
+{{- range $name, $data := .Provisioning.Datasources }}
+  {{ $name }}: |
+{{ indent 4 $data }}
+{{- end }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: grafana-dashboards
+  namespace: kube-system
+data:
+{{- range $name, $data := .Provisioning.Dashboards }}
+  {{ $name }}: |
+{{ indent 4 $data }}
+{{- end }}
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: grafana
+  namespace: kube-system
+  labels:
+    app: grafana
+spec:
+  containers:
+  - name: grafana
+    image: {{ .GrafanaImage }}
+    env:
+    - name: GF_SERVER_HTTP_PORT
+      value: "3000"
+    - name: GF_AUTH_ANONYMOUS_ENABLED
+      value: "true"
+    - name: GF_AUTH_ANONYMOUS_ORG_ROLE
+      value: Admin
+    - name: GF_AUTH_DISABLE_LOGIN_FORM
+      value: "true"
+    - name: GF_ANALYTICS_REPORTING_ENABLED
+      value: "false"
+    - name: GF_ANALYTICS_CHECK_FOR_UPDATES
+      value: "false"
+    {{ if .LogLevel }}
+    - name: GF_LOG_LEVEL
+      value: {{ .LogLevel }}
+    {{ end }}
+    {{ range .ExtraEnvs }}
+    - name: {{ .Name }}
+      value: {{ .Value }}
+    {{ end }}
+    volumeMounts:
+    - name: datasources
+      mountPath: /etc/grafana/provisioning/datasources
+      readOnly: true
+    - name: dashboards
+      mountPath: /etc/grafana/provisioning/dashboards
+      readOnly: true
+    {{ range .ExtraVolumes }}
+    - mountPath: {{ .MountPath }}
+      name: {{ .Name }}
+      readOnly: {{ .ReadOnly }}
+    {{ end }}
+  volumes:
+  - name: datasources
+    configMap:
+      name: grafana-datasources
+  - name: dashboards
+    configMap:
+      name: grafana-dashboards
+  {{ range .ExtraVolumes }}
+  - hostPath:
+      path: {{ .HostPath }}
+      type: {{ .PathType }}
+    name: {{ .Name }}
+  {{ end }}
+  restartPolicy: Always
+  hostNetwork: true
+  nodeName: {{ .Name }}-control-plane
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func TestBuildGrafanaDeployment(t *testing.T) {
+	provisioning := &runtime.GrafanaProvisioning{
+		Datasources: map[string]string{
+			"prometheus.yaml": "apiVersion: 1\ndatasources:\n- name: Prometheus\n  url: http://127.0.0.1:9090\n",
+		},
+		Dashboards: map[string]string{
+			"kwok.yaml":    "apiVersion: 1\nproviders:\n- name: kwok\n",
+			"cluster.json": "{\n  \"title\": \"Cluster\"\n}\n",
+		},
+	}
+	extraEnv := internalversion.Env{Name: "GF_SECURITY_ADMIN_PASSWORD", Value: "admin"}
+	got, err := BuildGrafanaDeployment(BuildGrafanaDeploymentConfig{
+		GrafanaImage: "docker.io/grafana/grafana:10.0.0",
+		Name:         "kwok-kwok",
+		LogLevel:     "debug",
+		Provisioning: provisioning,
+		ExtraEnvs:    []internalversion.Env{extraEnv},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(got), 4096)
+	configMaps := map[string]*corev1.ConfigMap{}
+	for i := 0; i != 2; i++ {
+		cm := &corev1.ConfigMap{}
+		err = decoder.Decode(cm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		configMaps[cm.Name] = cm
+	}
+	pod := &corev1.Pod{}
+	err = decoder.Decode(pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = decoder.Decode(&corev1.Pod{})
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("want three objects, got %v", err)
+	}
+
+	for name, files := range map[string]map[string]string{
+		"grafana-datasources": provisioning.Datasources,
+		"grafana-dashboards":  provisioning.Dashboards,
+	} {
+		cm, ok := configMaps[name]
+		if !ok {
+			t.Fatalf("want the config map %s", name)
+		}
+		for file, data := range files {
+			if strings.TrimSpace(cm.Data[file]) != strings.TrimSpace(data) {
+				t.Errorf("want the file %s in the config map %s as\n%s\ngot\n%s", file, name, data, cm.Data[file])
+			}
+		}
+	}
+
+	if pod.Spec.NodeName != "kwok-kwok-control-plane" {
+		t.Errorf("want the pod on the control plane, got %q", pod.Spec.NodeName)
+	}
+	if len(pod.Spec.Containers) != 1 || pod.Spec.Containers[0].Image != "docker.io/grafana/grafana:10.0.0" {
+		t.Fatalf("want the grafana container, got %v", pod.Spec.Containers)
+	}
+	envs := pod.Spec.Containers[0].Env
+	for _, env := range []corev1.EnvVar{
+		{Name: "GF_LOG_LEVEL", Value: "debug"},
+		{Name: extraEnv.Name, Value: extraEnv.Value},
+	} {
+		if !slices.Contains(envs, env) {
+			t.Errorf("want the env %v in %v", env, envs)
+		}
+	}
+}
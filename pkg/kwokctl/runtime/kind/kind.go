@@ -93,6 +93,24 @@ func expendExtrasForBuildKind(conf BuildKindConfig) (BuildKindConfig, error) {
 				},
 			)
 		}
+
+		if conf.AuditWebhook != "" {
+			conf.ApiserverExtraArgs = append(conf.ApiserverExtraArgs,
+				internalversion.ExtraArgs{
+					Key:   "audit-webhook-config-file",
+					Value: "/etc/kubernetes/audit/audit-webhook.yaml",
+				},
+			)
+			conf.ApiserverExtraVolumes = append(conf.ApiserverExtraVolumes,
+				internalversion.Volume{
+					Name:      "audit-webhook-config-file",
+					HostPath:  conf.AuditWebhook,
+					MountPath: "/etc/kubernetes/audit/audit-webhook.yaml",
+					ReadOnly:  true,
+					PathType:  internalversion.HostPathFile,
+				},
+			)
+		}
 	}
 
 	if conf.SchedulerConfig != "" {
@@ -253,13 +271,15 @@ type BuildKindConfig struct {
 	DashboardPort      uint32
 	PrometheusPort     uint32
 	JaegerPort         uint32
+	GrafanaPort        uint32
 	KwokControllerPort uint32
 
 	RuntimeConfig []string
 	FeatureGates  []string
 
-	AuditPolicy string
-	AuditLog    string
+	AuditPolicy  string
+	AuditLog     string
+	AuditWebhook string
 
 	KubeconfigPath    string
 	SchedulerConfig   string
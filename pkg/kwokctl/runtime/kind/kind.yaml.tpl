@@ -8,7 +8,7 @@ networking:
 nodes:
 - role: control-plane
 
-  {{ if or .DashboardPort .PrometheusPort .KwokControllerPort .EtcdPort .JaegerPort}}
+  {{ if or .DashboardPort .PrometheusPort .KwokControllerPort .EtcdPort .JaegerPort .GrafanaPort }}
   extraPortMappings:
   {{ if .DashboardPort }}
   - containerPort: 8000
@@ -25,6 +25,11 @@ nodes:
     hostPort: {{ .JaegerPort }}
     protocol: TCP
   {{ end }}
+  {{ if .GrafanaPort }}
+  - containerPort: 3000
+    hostPort: {{ .GrafanaPort }}
+    protocol: TCP
+  {{ end }}
   {{ if .KwokControllerPort }}
   - containerPort: 10247
     hostPort: {{ .KwokControllerPort }}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+func Test_expendExtrasForBuildKind_auditWebhook(t *testing.T) {
+	webhookArg := internalversion.ExtraArgs{
+		Key:   "audit-webhook-config-file",
+		Value: "/etc/kubernetes/audit/audit-webhook.yaml",
+	}
+	webhookVolume := internalversion.Volume{
+		Name:      "audit-webhook-config-file",
+		HostPath:  "/workdir/audit-webhook.yaml",
+		MountPath: "/etc/kubernetes/audit/audit-webhook.yaml",
+		ReadOnly:  true,
+		PathType:  internalversion.HostPathFile,
+	}
+
+	tests := []struct {
+		name        string
+		conf        BuildKindConfig
+		wantWebhook bool
+	}{
+		{
+			name: "audit webhook",
+			conf: BuildKindConfig{
+				AuditPolicy:  "/workdir/audit-policy.yaml",
+				AuditLog:     "/logs/audit.log",
+				AuditWebhook: "/workdir/audit-webhook.yaml",
+			},
+			wantWebhook: true,
+		},
+		{
+			name: "audit webhook without the audit policy",
+			conf: BuildKindConfig{
+				AuditWebhook: "/workdir/audit-webhook.yaml",
+			},
+		},
+		{
+			name: "audit policy only",
+			conf: BuildKindConfig{
+				AuditPolicy: "/workdir/audit-policy.yaml",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf, err := expendExtrasForBuildKind(tt.conf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := slices.Contains(conf.ApiserverExtraArgs, webhookArg); got != tt.wantWebhook {
+				t.Errorf("want the audit webhook arg %v, got %v", tt.wantWebhook, conf.ApiserverExtraArgs)
+			}
+			if got := slices.Contains(conf.ApiserverExtraVolumes, webhookVolume); got != tt.wantWebhook {
+				t.Errorf("want the audit webhook volume %v, got %v", tt.wantWebhook, conf.ApiserverExtraVolumes)
+			}
+		})
+	}
+}
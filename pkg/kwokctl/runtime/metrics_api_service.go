@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+
+	_ "embed"
+)
+
+//go:embed metrics_api_service.yaml.tpl
+var metricsAPIServiceYamlTpl string
+
+var metricsAPIServiceYamlTemplate = template.Must(template.New("metrics_api_service").Parse(metricsAPIServiceYamlTpl))
+
+// metricsAPIService is the group and the version of an API registered as an APIService.
+type metricsAPIService struct {
+	Group   string
+	Version string
+}
+
+// InitMetricsAPIService registers the metrics API (metrics.k8s.io), the custom metrics API (custom.metrics.k8s.io)
+// and the external metrics API (external.metrics.k8s.io) served by the kwok-controller as APIServices if they are enabled,
+// the kube-apiserver reaches the kwok-controller at the host and port by a Service of the type ExternalName.
+func (c *Cluster) InitMetricsAPIService(ctx context.Context, host string, port uint32) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	apiServices := []metricsAPIService{}
+	if config.Options.EnableMetricsServer {
+		apiServices = append(apiServices, metricsAPIService{Group: "metrics.k8s.io", Version: "v1beta1"})
+	}
+	if config.Options.EnableCustomMetrics {
+		apiServices = append(apiServices,
+			metricsAPIService{Group: "custom.metrics.k8s.io", Version: "v1beta2"},
+			metricsAPIService{Group: "external.metrics.k8s.io", Version: "v1beta1"},
+		)
+	}
+	if len(apiServices) == 0 {
+		return nil
+	}
+
+	if c.IsDryRun() {
+		for _, apiService := range apiServices {
+			dryrun.PrintMessage("# Register the %s/%s API served by the kwok-controller at %s:%d", apiService.Group, apiService.Version, host, port)
+		}
+		return nil
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = metricsAPIServiceYamlTemplate.Execute(buf, struct {
+		Host        string
+		Port        uint32
+		APIServices []metricsAPIService
+	}{
+		Host:        host,
+		Port:        port,
+		APIServices: apiServices,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute metrics api service yaml template: %w", err)
+	}
+
+	clientset, err := c.GetClientset(ctx)
+	if err != nil {
+		return err
+	}
+	return snapshot.Load(ctx, clientset, buf, nil)
+}
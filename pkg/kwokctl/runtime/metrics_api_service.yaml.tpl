@@ -0,0 +1,29 @@
+apiVersion: v1
+kind: Service
+metadata:
+  name: kwok-metrics-server
+  namespace: kube-system
+spec:
+  type: ExternalName
+  externalName: '{{ .Host }}'
+  ports:
+  - name: https
+    port: {{ .Port }}
+    protocol: TCP
+{{ range .APIServices }}
+---
+apiVersion: apiregistration.k8s.io/v1
+kind: APIService
+metadata:
+  name: {{ .Version }}.{{ .Group }}
+spec:
+  group: {{ .Group }}
+  version: {{ .Version }}
+  groupPriorityMinimum: 100
+  versionPriority: 100
+  insecureSkipTLSVerify: true
+  service:
+    name: kwok-metrics-server
+    namespace: kube-system
+    port: {{ $.Port }}
+{{ end }}
\ No newline at end of file
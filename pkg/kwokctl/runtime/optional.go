@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+// OptionalComponents is the components which can be installed into or uninstalled from a created cluster
+var OptionalComponents = []string{
+	consts.ComponentDashboard,
+	consts.ComponentPrometheus,
+	consts.ComponentJaeger,
+}
+
+// OptionalComponentPort returns the port in the options which enables the optional component, it is disabled with 0
+func OptionalComponentPort(conf *internalversion.KwokctlConfigurationOptions, name string) (*uint32, error) {
+	switch name {
+	case consts.ComponentDashboard:
+		return &conf.DashboardPort, nil
+	case consts.ComponentPrometheus:
+		return &conf.PrometheusPort, nil
+	case consts.ComponentJaeger:
+		return &conf.JaegerPort, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrComponentNotOptional, name)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"errors"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func TestOptionalComponentPort(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    func(conf *internalversion.KwokctlConfigurationOptions) *uint32
+		wantErr error
+	}{
+		{
+			name: consts.ComponentDashboard,
+			want: func(conf *internalversion.KwokctlConfigurationOptions) *uint32 { return &conf.DashboardPort },
+		},
+		{
+			name: consts.ComponentPrometheus,
+			want: func(conf *internalversion.KwokctlConfigurationOptions) *uint32 { return &conf.PrometheusPort },
+		},
+		{
+			name: consts.ComponentJaeger,
+			want: func(conf *internalversion.KwokctlConfigurationOptions) *uint32 { return &conf.JaegerPort },
+		},
+		{
+			name:    consts.ComponentKubeApiserver,
+			wantErr: ErrComponentNotOptional,
+		},
+		{
+			name:    "unknown",
+			wantErr: ErrComponentNotOptional,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &internalversion.KwokctlConfigurationOptions{}
+			got, err := OptionalComponentPort(conf, tt.name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OptionalComponentPort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got != tt.want(conf) {
+				t.Errorf("want the port of %s in the options", tt.name)
+			}
+		})
+	}
+}
+
+func TestOptionalComponents(t *testing.T) {
+	for _, name := range OptionalComponents {
+		_, err := OptionalComponentPort(&internalversion.KwokctlConfigurationOptions{}, name)
+		if err != nil {
+			t.Errorf("want the port of the optional component %s, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	goruntime "runtime"
+	"strings"
+	"time"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// Register registers the plugins in the directory to the registry, each of them is the runtime of the name
+// following the ExecutablePrefix, and the runtimes already registered, e.g. the ones in the tree, are not overridden.
+func Register(ctx context.Context, registry *runtime.Registry, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	for _, entry := range entries {
+		name, ok := runtimeName(entry.Name())
+		if !ok {
+			continue
+		}
+		executable := path.Join(dir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || (goruntime.GOOS != "windows" && info.Mode().Perm()&0111 == 0) {
+			logger.Warn("Ignore the plugin which is not an executable file", "plugin", executable)
+			continue
+		}
+		if _, exist := registry.Get(name); exist {
+			logger.Warn("Ignore the plugin of the runtime which already exists", "plugin", executable, "runtime", name)
+			continue
+		}
+		registry.Register(name, NewRuntime(executable))
+	}
+	return nil
+}
+
+// runtimeName returns the name of the runtime of the executable of a plugin
+func runtimeName(filename string) (string, bool) {
+	if goruntime.GOOS == "windows" {
+		if !strings.HasSuffix(filename, ".exe") {
+			return "", false
+		}
+		filename = strings.TrimSuffix(filename, ".exe")
+	}
+	name := strings.TrimPrefix(filename, ExecutablePrefix)
+	if name == filename || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// NewRuntime returns the function to build the runtime of which the operations are done by the executable of the plugin.
+func NewRuntime(executable string) runtime.BuildRuntime {
+	return func(name, workdir string) (runtime.Runtime, error) {
+		return &Client{
+			Cluster:    runtime.NewCluster(name, workdir),
+			executable: executable,
+		}, nil
+	}
+}
+
+// Client is the runtime which runs the executable of the plugin for the operations
+type Client struct {
+	*runtime.Cluster
+
+	executable string
+}
+
+var _ runtime.Runtime = &Client{}
+
+// call runs the executable of the plugin for the method,
+// the output of the plugin is written to the stdout and the stderr unless the context has the writers of the operation.
+func (c *Client) call(ctx context.Context, method string, req Request) (*Response, error) {
+	req.Name = c.Name()
+	req.Workdir = c.Workdir()
+	req.DryRun = c.IsDryRun()
+	reqData, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respFile, err := os.CreateTemp("", "kwokctl-plugin-*.json")
+	if err != nil {
+		return nil, err
+	}
+	respPath := respFile.Name()
+	_ = respFile.Close()
+	defer func() {
+		_ = os.Remove(respPath)
+	}()
+
+	opts := exec.GetExecOptions(ctx)
+	streams := exec.IOStreams{
+		In:     opts.In,
+		Out:    opts.Out,
+		ErrOut: opts.ErrOut,
+	}
+	if streams.Out == nil {
+		streams.Out = os.Stdout
+	}
+	if streams.ErrOut == nil {
+		streams.ErrOut = os.Stderr
+	}
+	ctx = exec.WithIOStreams(ctx, streams)
+	ctx = exec.WithEnv(ctx, []string{
+		envProtocol + "=" + ProtocolVersion,
+		envRequest + "=" + string(reqData),
+		envResponse + "=" + respPath,
+	})
+
+	execErr := exec.Exec(ctx, c.executable, method)
+
+	resp := &Response{}
+	respData, err := os.ReadFile(respPath)
+	if err == nil && len(respData) != 0 {
+		err = json.Unmarshal(respData, resp)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s %s: parse response: %w", c.executable, method, err)
+		}
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s %s: %s", c.executable, method, resp.Error)
+	}
+	if execErr != nil {
+		return nil, fmt.Errorf("plugin %s %s: %w", c.executable, method, execErr)
+	}
+	return resp, nil
+}
+
+// Available checks whether the runtime is available
+func (c *Client) Available(ctx context.Context) error {
+	_, err := c.call(ctx, MethodAvailable, Request{})
+	return err
+}
+
+// Install the cluster
+func (c *Client) Install(ctx context.Context) error {
+	err := c.Cluster.Install(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = c.call(ctx, MethodInstall, Request{})
+	return err
+}
+
+// Uninstall the cluster
+func (c *Client) Uninstall(ctx context.Context) error {
+	_, err := c.call(ctx, MethodUninstall, Request{})
+	if err != nil {
+		return err
+	}
+	return c.Cluster.Uninstall(ctx)
+}
+
+// Up start the cluster
+func (c *Client) Up(ctx context.Context) error {
+	_, err := c.call(ctx, MethodUp, Request{})
+	return err
+}
+
+// Down stop the cluster
+func (c *Client) Down(ctx context.Context) error {
+	_, err := c.call(ctx, MethodDown, Request{})
+	return err
+}
+
+// Start a cluster
+func (c *Client) Start(ctx context.Context) error {
+	_, err := c.call(ctx, MethodStart, Request{})
+	return err
+}
+
+// Stop a cluster
+func (c *Client) Stop(ctx context.Context) error {
+	_, err := c.call(ctx, MethodStop, Request{})
+	return err
+}
+
+// StartComponent start cluster component
+func (c *Client) StartComponent(ctx context.Context, name string) error {
+	_, err := c.call(ctx, MethodStartComponent, Request{Component: name})
+	return err
+}
+
+// StopComponent stop cluster component
+func (c *Client) StopComponent(ctx context.Context, name string) error {
+	_, err := c.call(ctx, MethodStopComponent, Request{Component: name})
+	return err
+}
+
+// UpgradeComponent replace the binary or the image of the component with the one in the options, and restart it
+func (c *Client) UpgradeComponent(ctx context.Context, name string) error {
+	_, err := c.call(ctx, MethodUpgradeComponent, Request{Component: name})
+	return err
+}
+
+// InstallComponent add the optional component to the cluster and start it
+func (c *Client) InstallComponent(ctx context.Context, name string) error {
+	_, err := c.call(ctx, MethodInstallComponent, Request{Component: name})
+	return err
+}
+
+// UninstallComponent stop the optional component and remove it from the cluster
+func (c *Client) UninstallComponent(ctx context.Context, name string) error {
+	_, err := c.call(ctx, MethodUninstallComponent, Request{Component: name})
+	return err
+}
+
+// ComponentReady check the component of cluster is ready
+func (c *Client) ComponentReady(ctx context.Context, name string) (bool, error) {
+	resp, err := c.call(ctx, MethodComponentReady, Request{Component: name})
+	if err != nil {
+		return false, err
+	}
+	return resp.Ready, nil
+}
+
+// ComponentStartedAt return the time the component of cluster was started at, zero if it is not running
+func (c *Client) ComponentStartedAt(ctx context.Context, name string) (time.Time, error) {
+	resp, err := c.call(ctx, MethodComponentStartedAt, Request{Component: name})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if resp.StartedAt == nil {
+		return time.Time{}, nil
+	}
+	return *resp.StartedAt, nil
+}
+
+// AddContext add the context of cluster to kubeconfig, which is the one saved by the plugin to the workdir
+func (c *Client) AddContext(ctx context.Context, kubeconfigPath string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Add context %s to %s", c.Name(), kubeconfigPath)
+		return nil
+	}
+
+	inHost, err := kubeconfig.LoadFromFile(c.GetWorkdirPath(runtime.InHostKubeconfigName))
+	if err != nil {
+		return err
+	}
+	currentContext, ok := inHost.Contexts[inHost.CurrentContext]
+	if !ok {
+		return fmt.Errorf("current context %q not found in %s", inHost.CurrentContext, c.GetWorkdirPath(runtime.InHostKubeconfigName))
+	}
+
+	kubeConfig := &kubeconfig.Config{
+		Cluster: inHost.Clusters[currentContext.Cluster],
+		Context: &clientcmdapi.Context{
+			Cluster: c.Name(),
+		},
+	}
+	if user, ok := inHost.AuthInfos[currentContext.AuthInfo]; ok {
+		kubeConfig.Context.AuthInfo = c.Name()
+		kubeConfig.User = user
+	}
+	return kubeconfig.AddContext(kubeconfigPath, c.Name(), kubeConfig)
+}
+
+// RemoveContext remove the context of cluster from kubeconfig
+func (c *Client) RemoveContext(ctx context.Context, kubeconfigPath string) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Remove context %s from %s", c.Name(), kubeconfigPath)
+		return nil
+	}
+	return kubeconfig.RemoveContext(kubeconfigPath, c.Name())
+}
+
+// EtcdctlInCluster command in cluster
+func (c *Client) EtcdctlInCluster(ctx context.Context, args ...string) error {
+	_, err := c.call(ctx, MethodEtcdctlInCluster, Request{Args: args})
+	return err
+}
+
+// Logs logs of a component
+func (c *Client) Logs(ctx context.Context, name string, out io.Writer) error {
+	_, err := c.call(exec.WithWriteTo(ctx, out), MethodLogs, Request{Component: name})
+	return err
+}
+
+// LogsFollow follow logs of a component with follow
+func (c *Client) LogsFollow(ctx context.Context, name string, out io.Writer) error {
+	_, err := c.call(exec.WithWriteTo(ctx, out), MethodLogs, Request{Component: name, Follow: true})
+	return err
+}
+
+// CollectLogs will populate dir with cluster logs and other debug files
+func (c *Client) CollectLogs(ctx context.Context, dir string) error {
+	_, err := c.call(ctx, MethodCollectLogs, Request{Path: dir})
+	return err
+}
+
+// ListBinaries list binaries in the cluster
+func (c *Client) ListBinaries(ctx context.Context) ([]string, error) {
+	resp, err := c.call(ctx, MethodListBinaries, Request{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// ListImages list images in the cluster
+func (c *Client) ListImages(ctx context.Context) ([]string, error) {
+	resp, err := c.call(ctx, MethodListImages, Request{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// SaveImages save the images of the cluster to the archive
+func (c *Client) SaveImages(ctx context.Context, archive string) error {
+	_, err := c.call(ctx, MethodSaveImages, Request{Path: archive})
+	return err
+}
+
+// LoadImages load the images from the archive
+func (c *Client) LoadImages(ctx context.Context, archive string) error {
+	_, err := c.call(ctx, MethodLoadImages, Request{Path: archive})
+	return err
+}
+
+// SnapshotSave save the snapshot of cluster
+func (c *Client) SnapshotSave(ctx context.Context, path string) error {
+	_, err := c.call(ctx, MethodSnapshotSave, Request{Path: path})
+	return err
+}
+
+// SnapshotRestore restore the snapshot of cluster
+func (c *Client) SnapshotRestore(ctx context.Context, path string) error {
+	_, err := c.call(ctx, MethodSnapshotRestore, Request{Path: path})
+	return err
+}
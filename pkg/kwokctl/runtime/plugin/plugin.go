@@ -0,0 +1,312 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin provides the runtimes out of the tree of kwokctl, e.g. firecracker, LXD or remote VMs,
+// which are executables named kwokctl-runtime-<runtime> in the plugins directory.
+//
+// kwokctl runs the executable with the method as the only argument for each of the operations on a cluster,
+// the request is passed in the env KWOK_PLUGIN_REQUEST as JSON, and the response is written as JSON to the file
+// of the env KWOK_PLUGIN_RESPONSE. The stdio of the executable are the ones of the operation,
+// e.g. the logs of a component are written to the stdout, and etcdctl reads from the stdin.
+//
+// The plugins written in Go implement Runtime and call Serve in the main.
+package plugin
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ProtocolVersion is the version of the protocol between kwokctl and the plugins,
+// it is passed to the plugins in the env KWOK_PLUGIN_PROTOCOL.
+const ProtocolVersion = "v1"
+
+// ExecutablePrefix is the prefix of the names of the executables of the plugins, followed by the name of the runtime.
+const ExecutablePrefix = "kwokctl-runtime-"
+
+const (
+	envProtocol = "KWOK_PLUGIN_PROTOCOL"
+	envRequest  = "KWOK_PLUGIN_REQUEST"
+	envResponse = "KWOK_PLUGIN_RESPONSE"
+)
+
+// The methods of the protocol, each of them is a method of Runtime.
+const (
+	MethodAvailable          = "available"
+	MethodInstall            = "install"
+	MethodUninstall          = "uninstall"
+	MethodUp                 = "up"
+	MethodDown               = "down"
+	MethodStart              = "start"
+	MethodStop               = "stop"
+	MethodStartComponent     = "start-component"
+	MethodStopComponent      = "stop-component"
+	MethodUpgradeComponent   = "upgrade-component"
+	MethodInstallComponent   = "install-component"
+	MethodUninstallComponent = "uninstall-component"
+	MethodComponentReady     = "component-ready"
+	MethodComponentStartedAt = "component-started-at"
+	MethodEtcdctlInCluster   = "etcdctl-in-cluster"
+	MethodLogs               = "logs"
+	MethodCollectLogs        = "collect-logs"
+	MethodListBinaries       = "list-binaries"
+	MethodListImages         = "list-images"
+	MethodSaveImages         = "save-images"
+	MethodLoadImages         = "load-images"
+	MethodSnapshotSave       = "snapshot-save"
+	MethodSnapshotRestore    = "snapshot-restore"
+)
+
+// Request is the request of an operation.
+type Request struct {
+	// Name is the name of the cluster, e.g. kwok-<name>.
+	Name string `json:"name"`
+	// Workdir is the directory of the cluster, which has the configuration of the cluster.
+	Workdir string `json:"workdir"`
+	// DryRun is true if the operation prints what it would do instead of doing it.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Component is the name of the component of the operations on a component.
+	Component string `json:"component,omitempty"`
+	// Path is the path of the file or the directory of the operations on one, e.g. the snapshot.
+	Path string `json:"path,omitempty"`
+	// Args is the arguments of etcdctl.
+	Args []string `json:"args,omitempty"`
+	// Follow is true if the logs are followed.
+	Follow bool `json:"follow,omitempty"`
+}
+
+// Response is the response of an operation.
+type Response struct {
+	// Error is the message of the error of the operation, empty if it succeeded.
+	Error string `json:"error,omitempty"`
+	// Ready is the result of component-ready.
+	Ready bool `json:"ready,omitempty"`
+	// StartedAt is the result of component-started-at, nil if the component is not running.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// Items is the result of list-binaries and list-images.
+	Items []string `json:"items,omitempty"`
+}
+
+// Cluster is the cluster an operation is on.
+type Cluster struct {
+	// Name is the name of the cluster, e.g. kwok-<name>.
+	Name string
+	// Workdir is the directory of the cluster.
+	Workdir string
+	// DryRun is true if the operation prints what it would do instead of doing it.
+	DryRun bool
+}
+
+// Runtime is the stable interface of the runtimes out of the tree, the part of runtime.Runtime
+// which differs between the runtimes, the rest, e.g. the configuration, kubectl and the kubeconfig, is done by kwokctl.
+//
+// The cluster is installed with the configuration saved in the workdir, and the kubeconfig of the cluster is expected
+// to be saved to kubeconfig.yaml in the workdir as it is installed, the same as the runtimes in the tree.
+type Runtime interface {
+	// Available checks whether the runtime is available
+	Available(ctx context.Context, cluster Cluster) error
+
+	// Install the cluster
+	Install(ctx context.Context, cluster Cluster) error
+
+	// Uninstall the cluster
+	Uninstall(ctx context.Context, cluster Cluster) error
+
+	// Up start the cluster as it is created
+	Up(ctx context.Context, cluster Cluster) error
+
+	// Down stop the cluster as it is deleted
+	Down(ctx context.Context, cluster Cluster) error
+
+	// Start a cluster
+	Start(ctx context.Context, cluster Cluster) error
+
+	// Stop a cluster
+	Stop(ctx context.Context, cluster Cluster) error
+
+	// StartComponent start cluster component
+	StartComponent(ctx context.Context, cluster Cluster, component string) error
+
+	// StopComponent stop cluster component
+	StopComponent(ctx context.Context, cluster Cluster, component string) error
+
+	// UpgradeComponent replace the binary or the image of the component with the one in the options, and restart it
+	UpgradeComponent(ctx context.Context, cluster Cluster, component string) error
+
+	// InstallComponent add the optional component to the cluster and start it
+	InstallComponent(ctx context.Context, cluster Cluster, component string) error
+
+	// UninstallComponent stop the optional component and remove it from the cluster
+	UninstallComponent(ctx context.Context, cluster Cluster, component string) error
+
+	// ComponentReady check the component of cluster is ready
+	ComponentReady(ctx context.Context, cluster Cluster, component string) (bool, error)
+
+	// ComponentStartedAt return the time the component of cluster was started at, zero if it is not running
+	ComponentStartedAt(ctx context.Context, cluster Cluster, component string) (time.Time, error)
+
+	// EtcdctlInCluster runs etcdctl in cluster with the stdio of the process
+	EtcdctlInCluster(ctx context.Context, cluster Cluster, args []string) error
+
+	// Logs writes the logs of a component to the stdout of the process
+	Logs(ctx context.Context, cluster Cluster, component string, follow bool) error
+
+	// CollectLogs will populate dir with cluster logs and other debug files
+	CollectLogs(ctx context.Context, cluster Cluster, dir string) error
+
+	// ListBinaries list binaries in the cluster
+	ListBinaries(ctx context.Context, cluster Cluster) ([]string, error)
+
+	// ListImages list images in the cluster
+	ListImages(ctx context.Context, cluster Cluster) ([]string, error)
+
+	// SaveImages save the images of the cluster to the archive
+	SaveImages(ctx context.Context, cluster Cluster, archive string) error
+
+	// LoadImages load the images from the archive
+	LoadImages(ctx context.Context, cluster Cluster, archive string) error
+
+	// SnapshotSave save the snapshot of cluster
+	SnapshotSave(ctx context.Context, cluster Cluster, path string) error
+
+	// SnapshotRestore restore the snapshot of cluster
+	SnapshotRestore(ctx context.Context, cluster Cluster, path string) error
+}
+
+// ErrUnimplemented is returned by the methods the plugin does not implement.
+var ErrUnimplemented = errors.New("not implemented by the runtime plugin")
+
+// UnimplementedRuntime is embedded by the implementations of Runtime,
+// so the plugins keep building as the methods are added to Runtime, which return ErrUnimplemented.
+type UnimplementedRuntime struct{}
+
+var _ Runtime = UnimplementedRuntime{}
+
+// Available returns ErrUnimplemented
+func (UnimplementedRuntime) Available(ctx context.Context, cluster Cluster) error {
+	return ErrUnimplemented
+}
+
+// Install returns ErrUnimplemented
+func (UnimplementedRuntime) Install(ctx context.Context, cluster Cluster) error {
+	return ErrUnimplemented
+}
+
+// Uninstall returns ErrUnimplemented
+func (UnimplementedRuntime) Uninstall(ctx context.Context, cluster Cluster) error {
+	return ErrUnimplemented
+}
+
+// Up returns ErrUnimplemented
+func (UnimplementedRuntime) Up(ctx context.Context, cluster Cluster) error {
+	return ErrUnimplemented
+}
+
+// Down returns ErrUnimplemented
+func (UnimplementedRuntime) Down(ctx context.Context, cluster Cluster) error {
+	return ErrUnimplemented
+}
+
+// Start returns ErrUnimplemented
+func (UnimplementedRuntime) Start(ctx context.Context, cluster Cluster) error {
+	return ErrUnimplemented
+}
+
+// Stop returns ErrUnimplemented
+func (UnimplementedRuntime) Stop(ctx context.Context, cluster Cluster) error {
+	return ErrUnimplemented
+}
+
+// StartComponent returns ErrUnimplemented
+func (UnimplementedRuntime) StartComponent(ctx context.Context, cluster Cluster, component string) error {
+	return ErrUnimplemented
+}
+
+// StopComponent returns ErrUnimplemented
+func (UnimplementedRuntime) StopComponent(ctx context.Context, cluster Cluster, component string) error {
+	return ErrUnimplemented
+}
+
+// UpgradeComponent returns ErrUnimplemented
+func (UnimplementedRuntime) UpgradeComponent(ctx context.Context, cluster Cluster, component string) error {
+	return ErrUnimplemented
+}
+
+// InstallComponent returns ErrUnimplemented
+func (UnimplementedRuntime) InstallComponent(ctx context.Context, cluster Cluster, component string) error {
+	return ErrUnimplemented
+}
+
+// UninstallComponent returns ErrUnimplemented
+func (UnimplementedRuntime) UninstallComponent(ctx context.Context, cluster Cluster, component string) error {
+	return ErrUnimplemented
+}
+
+// ComponentReady returns ErrUnimplemented
+func (UnimplementedRuntime) ComponentReady(ctx context.Context, cluster Cluster, component string) (bool, error) {
+	return false, ErrUnimplemented
+}
+
+// ComponentStartedAt returns ErrUnimplemented
+func (UnimplementedRuntime) ComponentStartedAt(ctx context.Context, cluster Cluster, component string) (time.Time, error) {
+	return time.Time{}, ErrUnimplemented
+}
+
+// EtcdctlInCluster returns ErrUnimplemented
+func (UnimplementedRuntime) EtcdctlInCluster(ctx context.Context, cluster Cluster, args []string) error {
+	return ErrUnimplemented
+}
+
+// Logs returns ErrUnimplemented
+func (UnimplementedRuntime) Logs(ctx context.Context, cluster Cluster, component string, follow bool) error {
+	return ErrUnimplemented
+}
+
+// CollectLogs returns ErrUnimplemented
+func (UnimplementedRuntime) CollectLogs(ctx context.Context, cluster Cluster, dir string) error {
+	return ErrUnimplemented
+}
+
+// ListBinaries returns ErrUnimplemented
+func (UnimplementedRuntime) ListBinaries(ctx context.Context, cluster Cluster) ([]string, error) {
+	return nil, ErrUnimplemented
+}
+
+// ListImages returns ErrUnimplemented
+func (UnimplementedRuntime) ListImages(ctx context.Context, cluster Cluster) ([]string, error) {
+	return nil, ErrUnimplemented
+}
+
+// SaveImages returns ErrUnimplemented
+func (UnimplementedRuntime) SaveImages(ctx context.Context, cluster Cluster, archive string) error {
+	return ErrUnimplemented
+}
+
+// LoadImages returns ErrUnimplemented
+func (UnimplementedRuntime) LoadImages(ctx context.Context, cluster Cluster, archive string) error {
+	return ErrUnimplemented
+}
+
+// SnapshotSave returns ErrUnimplemented
+func (UnimplementedRuntime) SnapshotSave(ctx context.Context, cluster Cluster, path string) error {
+	return ErrUnimplemented
+}
+
+// SnapshotRestore returns ErrUnimplemented
+func (UnimplementedRuntime) SnapshotRestore(ctx context.Context, cluster Cluster, path string) error {
+	return ErrUnimplemented
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	goruntime "runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+type fakeRuntime struct {
+	UnimplementedRuntime
+}
+
+func (fakeRuntime) ListImages(ctx context.Context, cluster Cluster) ([]string, error) {
+	return []string{cluster.Name + "/image"}, nil
+}
+
+func (fakeRuntime) ComponentStartedAt(ctx context.Context, cluster Cluster, component string) (time.Time, error) {
+	if component != "etcd" {
+		return time.Time{}, nil
+	}
+	return time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), nil
+}
+
+func (fakeRuntime) Logs(ctx context.Context, cluster Cluster, component string, follow bool) error {
+	_, err := fmt.Printf("logs of %s follow=%t", component, follow)
+	return err
+}
+
+func (fakeRuntime) StartComponent(ctx context.Context, cluster Cluster, component string) error {
+	return fmt.Errorf("component %s not found", component)
+}
+
+// TestMain runs the test binary as the plugin if it is run by the client
+func TestMain(m *testing.M) {
+	if os.Getenv(envProtocol) != "" {
+		err := Serve(context.Background(), fakeRuntime{})
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	rt, err := NewRuntime(os.Args[0])("kwok-test", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	images, err := rt.ListImages(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"kwok-test/image"}; !reflect.DeepEqual(images, want) {
+		t.Errorf("ListImages() = %v, want %v", images, want)
+	}
+
+	startedAt, err := rt.ComponentStartedAt(ctx, "etcd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC); !startedAt.Equal(want) {
+		t.Errorf("ComponentStartedAt() = %v, want %v", startedAt, want)
+	}
+	startedAt, err = rt.ComponentStartedAt(ctx, "kube-apiserver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !startedAt.IsZero() {
+		t.Errorf("ComponentStartedAt() = %v, want zero", startedAt)
+	}
+
+	out := bytes.NewBuffer(nil)
+	err = rt.LogsFollow(ctx, "etcd", out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "logs of etcd follow=true"; out.String() != want {
+		t.Errorf("LogsFollow() = %q, want %q", out.String(), want)
+	}
+
+	err = rt.StartComponent(ctx, "etcd")
+	if err == nil || !strings.Contains(err.Error(), "component etcd not found") {
+		t.Errorf("StartComponent() error = %v, want the error of the plugin", err)
+	}
+
+	err = rt.Stop(ctx)
+	if err == nil || !strings.Contains(err.Error(), ErrUnimplemented.Error()) {
+		t.Errorf("Stop() error = %v, want %v", err, ErrUnimplemented)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("the executables are named with .exe on windows")
+	}
+
+	dir := t.TempDir()
+	files := map[string]os.FileMode{
+		"kwokctl-runtime-lxd":    0750,
+		"kwokctl-runtime-binary": 0750,
+		"kwokctl-runtime-noexec": 0640,
+		"kwokctl-runtime-":       0750,
+		"kubectl-kwok":           0750,
+	}
+	for name, mode := range files {
+		err := os.WriteFile(filepath.Join(dir, name), nil, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	registry := runtime.NewRegistry()
+	registry.Register("binary", func(name, workdir string) (runtime.Runtime, error) {
+		return nil, nil
+	})
+	err := Register(context.Background(), registry, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"binary", "lxd"}; !reflect.DeepEqual(registry.List(), want) {
+		t.Errorf("List() = %v, want %v", registry.List(), want)
+	}
+
+	buildRuntime, ok := registry.Get("binary")
+	if !ok {
+		t.Fatal("binary not found")
+	}
+	if rt, _ := buildRuntime("kwok-test", dir); rt != nil {
+		t.Errorf("the runtime binary is overridden by the plugin")
+	}
+
+	err = Register(context.Background(), runtime.NewRegistry(), filepath.Join(dir, "not-exist"))
+	if err != nil {
+		t.Errorf("Register() error = %v, want nil for the directory not exists", err)
+	}
+}
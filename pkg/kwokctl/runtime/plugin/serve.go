@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/kwok/pkg/utils/file"
+)
+
+// Serve runs the operation which kwokctl runs the plugin for, it is called in the main of the plugin,
+// and the plugin exits with a non-zero code if an error is returned.
+func Serve(ctx context.Context, rt Runtime) error {
+	if len(os.Args) != 2 {
+		return fmt.Errorf("usage: %s <method>, the plugin is run by kwokctl", os.Args[0])
+	}
+	method := os.Args[1]
+
+	protocol := os.Getenv(envProtocol)
+	if protocol != ProtocolVersion {
+		return fmt.Errorf("unsupported protocol %q, only %q is supported", protocol, ProtocolVersion)
+	}
+
+	var req Request
+	err := json.Unmarshal([]byte(os.Getenv(envRequest)), &req)
+	if err != nil {
+		return fmt.Errorf("parse request: %w", err)
+	}
+
+	resp, err := handle(ctx, rt, method, req)
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	data, writeErr := json.Marshal(resp)
+	if writeErr != nil {
+		return writeErr
+	}
+	writeErr = file.Write(os.Getenv(envResponse), data)
+	if writeErr != nil {
+		return fmt.Errorf("write response: %w", writeErr)
+	}
+	return err
+}
+
+// handle dispatches the request to the method of the runtime
+func handle(ctx context.Context, rt Runtime, method string, req Request) (Response, error) {
+	cluster := Cluster{
+		Name:    req.Name,
+		Workdir: req.Workdir,
+		DryRun:  req.DryRun,
+	}
+
+	var resp Response
+	var err error
+	switch method {
+	case MethodAvailable:
+		err = rt.Available(ctx, cluster)
+	case MethodInstall:
+		err = rt.Install(ctx, cluster)
+	case MethodUninstall:
+		err = rt.Uninstall(ctx, cluster)
+	case MethodUp:
+		err = rt.Up(ctx, cluster)
+	case MethodDown:
+		err = rt.Down(ctx, cluster)
+	case MethodStart:
+		err = rt.Start(ctx, cluster)
+	case MethodStop:
+		err = rt.Stop(ctx, cluster)
+	case MethodStartComponent:
+		err = rt.StartComponent(ctx, cluster, req.Component)
+	case MethodStopComponent:
+		err = rt.StopComponent(ctx, cluster, req.Component)
+	case MethodUpgradeComponent:
+		err = rt.UpgradeComponent(ctx, cluster, req.Component)
+	case MethodInstallComponent:
+		err = rt.InstallComponent(ctx, cluster, req.Component)
+	case MethodUninstallComponent:
+		err = rt.UninstallComponent(ctx, cluster, req.Component)
+	case MethodComponentReady:
+		resp.Ready, err = rt.ComponentReady(ctx, cluster, req.Component)
+	case MethodComponentStartedAt:
+		startedAt, startedAtErr := rt.ComponentStartedAt(ctx, cluster, req.Component)
+		if !startedAt.IsZero() {
+			resp.StartedAt = &startedAt
+		}
+		err = startedAtErr
+	case MethodEtcdctlInCluster:
+		err = rt.EtcdctlInCluster(ctx, cluster, req.Args)
+	case MethodLogs:
+		err = rt.Logs(ctx, cluster, req.Component, req.Follow)
+	case MethodCollectLogs:
+		err = rt.CollectLogs(ctx, cluster, req.Path)
+	case MethodListBinaries:
+		resp.Items, err = rt.ListBinaries(ctx, cluster)
+	case MethodListImages:
+		resp.Items, err = rt.ListImages(ctx, cluster)
+	case MethodSaveImages:
+		err = rt.SaveImages(ctx, cluster, req.Path)
+	case MethodLoadImages:
+		err = rt.LoadImages(ctx, cluster, req.Path)
+	case MethodSnapshotSave:
+		err = rt.SnapshotSave(ctx, cluster, req.Path)
+	case MethodSnapshotRestore:
+		err = rt.SnapshotRestore(ctx, cluster, req.Path)
+	default:
+		// The plugin is older than kwokctl
+		err = fmt.Errorf("method %q: %w", method, ErrUnimplemented)
+	}
+	return resp, err
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// GetUnusedPort returns an unused port in the port range of the cluster,
+// the port is reserved for the cluster until it is uninstalled,
+// so the clusters created in parallel do not get the same port.
+func (c *Cluster) GetUnusedPort(ctx context.Context) (uint32, error) {
+	conf, err := c.Config(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	min, max := net.MinUnusedPort, net.MaxUnusedPort
+	if conf.Options.PortRange != "" {
+		min, max, err = net.ParsePortRange(conf.Options.PortRange)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if c.IsDryRun() {
+		return net.GetUnusedPortInRange(ctx, min, max, nil)
+	}
+
+	err = file.MkdirAll(config.PortsDir)
+	if err != nil {
+		return 0, err
+	}
+	return net.GetUnusedPortInRange(ctx, min, max, func(port uint32) bool {
+		return c.reservePort(ctx, port)
+	})
+}
+
+// reservePort reserves the port by creating a file named after it with the workdir of the cluster,
+// the reservation of the cluster which no longer exists is taken over.
+func (c *Cluster) reservePort(ctx context.Context, port uint32) bool {
+	logger := log.FromContext(ctx)
+	reservation := path.Join(config.PortsDir, format.String(port))
+	for i := 0; i != 2; i++ {
+		f, err := os.OpenFile(reservation, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+		if err == nil {
+			_, err = f.WriteString(c.workdir)
+			_ = f.Close()
+			if err != nil {
+				logger.Error("Failed to reserve port", err, "port", port)
+				_ = file.Remove(reservation)
+				return false
+			}
+			return true
+		}
+		if !errors.Is(err, os.ErrExist) {
+			logger.Error("Failed to reserve port", err, "port", port)
+			return false
+		}
+
+		owner, err := file.Read(reservation)
+		if err != nil {
+			return false
+		}
+		if string(owner) == c.workdir {
+			return true
+		}
+		if !isOrphanReservation(owner) {
+			return false
+		}
+		logger.Debug("Take over port of the deleted cluster", "port", port, "owner", string(owner))
+		_ = file.Remove(reservation)
+	}
+	return false
+}
+
+// releasePorts releases the ports reserved for the cluster.
+func (c *Cluster) releasePorts(ctx context.Context) error {
+	entries, err := os.ReadDir(config.PortsDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	for _, entry := range entries {
+		reservation := path.Join(config.PortsDir, entry.Name())
+		owner, err := file.Read(reservation)
+		if err != nil || string(owner) != c.workdir {
+			continue
+		}
+		err = file.Remove(reservation)
+		if err != nil {
+			logger.Error("Failed to release port", err, "port", entry.Name())
+		}
+	}
+	return nil
+}
+
+// ReleaseOrphanPorts releases the ports reserved for the clusters which no longer exist.
+func ReleaseOrphanPorts(ctx context.Context) error {
+	entries, err := os.ReadDir(config.PortsDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	for _, entry := range entries {
+		reservation := path.Join(config.PortsDir, entry.Name())
+		owner, err := file.Read(reservation)
+		if err != nil || !isOrphanReservation(owner) {
+			continue
+		}
+		if dryrun.DryRun {
+			dryrun.PrintMessage("rm %s", reservation)
+			continue
+		}
+		err = file.Remove(reservation)
+		if err != nil {
+			return err
+		}
+		logger.Info("Released port of the deleted cluster", "port", entry.Name(), "owner", string(owner))
+	}
+	return nil
+}
+
+// isOrphanReservation returns true if the owner of the reservation no longer exists.
+func isOrphanReservation(owner []byte) bool {
+	return len(owner) == 0 || !file.Exists(path.Join(string(owner), ConfigName))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/config"
+)
+
+func TestReleaseOrphanPorts(t *testing.T) {
+	dir := t.TempDir()
+	portsDir := filepath.Join(dir, "ports")
+	defer func(old string) { config.PortsDir = old }(config.PortsDir)
+	config.PortsDir = portsDir
+
+	err := ReleaseOrphanPorts(context.Background())
+	if err != nil {
+		t.Fatalf("want no error without the ports dir, got %v", err)
+	}
+
+	existing := filepath.Join(dir, "clusters", "existing")
+	err = os.MkdirAll(existing, 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(existing, ConfigName), nil, 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.MkdirAll(portsDir, 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reservations := map[string]string{
+		"32001": existing,
+		"32002": filepath.Join(dir, "clusters", "deleted"),
+		"32003": "",
+	}
+	for port, owner := range reservations {
+		err = os.WriteFile(filepath.Join(portsDir, port), []byte(owner), 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = ReleaseOrphanPorts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(portsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "32001" {
+		t.Errorf("want only the port of the existing cluster reserved, got %v", entries)
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+)
+
+// defaultProbeTimeout is the timeout of the probe if it is not set.
+const defaultProbeTimeout = time.Second
+
+// ProbeComponent performs the http get of the probe of the component from the host,
+// the port of the probe is the one the component listens on,
+// which is mapped to the port published to the host if the component runs in a container.
+// It succeeds without the probe if there is no probe or the port is not published to the host.
+func ProbeComponent(ctx context.Context, component internalversion.Component, probe *internalversion.Probe) error {
+	if probe == nil || probe.HTTPGet == nil {
+		return nil
+	}
+	port := probe.HTTPGet.Port
+	if component.Image != "" {
+		port = 0
+		for _, p := range component.Ports {
+			if p.Port == probe.HTTPGet.Port && p.HostPort != 0 {
+				port = p.HostPort
+				break
+			}
+		}
+		if port == 0 {
+			return nil
+		}
+	}
+	return httpGet(ctx, ProbeURL(probe, utilsnet.LocalAddress, port), probeTimeout(probe))
+}
+
+// ProbeURL returns the url of the http get of the probe to the host and port.
+func ProbeURL(probe *internalversion.Probe, host string, port uint32) string {
+	scheme := "http"
+	if probe.HTTPGet.Scheme == internalversion.URISchemeHTTPS {
+		scheme = "https"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(host, format.String(port)),
+		Path:   probe.HTTPGet.Path,
+	}
+	return u.String()
+}
+
+func probeTimeout(probe *internalversion.Probe) time.Duration {
+	if probe.TimeoutSeconds <= 0 {
+		return defaultProbeTimeout
+	}
+	return time.Duration(probe.TimeoutSeconds) * time.Second
+}
+
+var probeClient = &http.Client{
+	Transport: &http.Transport{
+		// The same as the probes of kubelet, the certificate of the component is not verified.
+		TLSClientConfig: &tls.Config{
+			//nolint:gosec
+			InsecureSkipVerify: true,
+		},
+	},
+}
+
+// httpGet performs the http get to the url, which succeeds with the status code in [200, 400).
+func httpGet(ctx context.Context, u string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := probeClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("probe %s: %s", u, resp.Status)
+	}
+	return nil
+}
+
+// LivenessProbe is the liveness probe of the process supervised.
+type LivenessProbe struct {
+	// URL is the url of the http get.
+	URL string
+	// InitialDelay is the delay after the process has started before the probe is initiated.
+	InitialDelay time.Duration
+	// Period is how often to perform the probe.
+	Period time.Duration
+	// Timeout is the timeout of the probe.
+	Timeout time.Duration
+	// FailureThreshold is the consecutive failures for the process to be restarted.
+	FailureThreshold int
+}
+
+// NewLivenessProbe returns the liveness probe of the component running on the host,
+// it is nil if the component has no liveness probe or runs in a container.
+func NewLivenessProbe(component internalversion.Component) *LivenessProbe {
+	probe := component.LivenessProbe
+	if probe == nil || probe.HTTPGet == nil || component.Image != "" {
+		return nil
+	}
+	period := time.Duration(probe.PeriodSeconds) * time.Second
+	if period <= 0 {
+		period = 10 * time.Second
+	}
+	failureThreshold := int(probe.FailureThreshold)
+	if failureThreshold <= 0 {
+		failureThreshold = 3
+	}
+	return &LivenessProbe{
+		URL:              ProbeURL(probe, utilsnet.LocalAddress, probe.HTTPGet.Port),
+		InitialDelay:     time.Duration(probe.InitialDelaySeconds) * time.Second,
+		Period:           period,
+		Timeout:          probeTimeout(probe),
+		FailureThreshold: failureThreshold,
+	}
+}
+
+// watch performs the probe periodically until the stop is closed,
+// and calls the unhealthy once the probe fails the failure threshold times in a row.
+func (p *LivenessProbe) watch(ctx context.Context, stop <-chan struct{}, unhealthy func()) {
+	logger := log.FromContext(ctx)
+	select {
+	case <-stop:
+		return
+	case <-time.After(p.InitialDelay):
+	}
+
+	ticker := time.NewTicker(p.Period)
+	defer ticker.Stop()
+	failures := 0
+	for {
+		err := httpGet(ctx, p.URL, p.Timeout)
+		if err == nil {
+			failures = 0
+		} else {
+			failures++
+			logger.Debug("Liveness probe failed",
+				"url", p.URL,
+				"failures", failures,
+				"err", err,
+			)
+			if failures >= p.FailureThreshold {
+				unhealthy()
+				return
+			}
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestProbeComponent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/readyz" {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, p, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.ParseUint(p, 10, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostPort := uint32(port)
+
+	probe := func(path string, port uint32) *internalversion.Probe {
+		return &internalversion.Probe{
+			HTTPGet: &internalversion.HTTPGetAction{
+				Path:   path,
+				Port:   port,
+				Scheme: internalversion.URISchemeHTTP,
+			},
+			TimeoutSeconds: 1,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		component internalversion.Component
+		probe     *internalversion.Probe
+		wantErr   bool
+	}{
+		{
+			name:  "no probe",
+			probe: nil,
+		},
+		{
+			name:  "binary",
+			probe: probe("/readyz", hostPort),
+		},
+		{
+			name:    "binary failed",
+			probe:   probe("/livez", hostPort),
+			wantErr: true,
+		},
+		{
+			name: "image with published port",
+			component: internalversion.Component{
+				Image: "image",
+				Ports: []internalversion.Port{
+					{
+						Port:     8080,
+						HostPort: hostPort,
+					},
+				},
+			},
+			probe: probe("/readyz", 8080),
+		},
+		{
+			name: "image with unpublished port",
+			component: internalversion.Component{
+				Image: "image",
+				Ports: []internalversion.Port{
+					{
+						Port: 8080,
+					},
+				},
+			},
+			probe: probe("/livez", 8080),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ProbeComponent(context.Background(), tt.component, tt.probe)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProbeComponent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
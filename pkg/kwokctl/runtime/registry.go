@@ -18,6 +18,7 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 )
@@ -25,18 +26,24 @@ import (
 // BuildRuntime is a function to build a runtime
 type BuildRuntime func(name, workdir string) (Runtime, error)
 
+// PruneOrphans is a function to remove the resources of a runtime left behind by the clusters which no longer exist,
+// the clusters are the names of the existing clusters, e.g. kwok-<name>
+type PruneOrphans func(ctx context.Context, clusters []string) error
+
 // DefaultRegistry is the default registry
 var DefaultRegistry = NewRegistry()
 
 // Registry is a registry of runtime
 type Registry struct {
-	items map[string]BuildRuntime
+	items   map[string]BuildRuntime
+	pruners map[string]PruneOrphans
 }
 
 // NewRegistry create a new registry
 func NewRegistry() *Registry {
 	return &Registry{
-		items: map[string]BuildRuntime{},
+		items:   map[string]BuildRuntime{},
+		pruners: map[string]PruneOrphans{},
 	}
 }
 
@@ -45,6 +52,29 @@ func (r *Registry) Register(name string, buildRuntime BuildRuntime) {
 	r.items[name] = buildRuntime
 }
 
+// RegisterPruneOrphans registers the function to remove the orphaned resources of a runtime
+func (r *Registry) RegisterPruneOrphans(name string, pruneOrphans PruneOrphans) {
+	r.pruners[name] = pruneOrphans
+}
+
+// PruneOrphans removes the orphaned resources of all the registered runtime
+func (r *Registry) PruneOrphans(ctx context.Context, clusters []string) error {
+	names := make([]string, 0, len(r.pruners))
+	for name := range r.pruners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		err := r.pruners[name](ctx, clusters)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("prune orphans of runtime %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Get a runtime
 func (r *Registry) Get(name string) (BuildRuntime, bool) {
 	buildRuntime, ok := r.items[name]
@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRegistryPruneOrphans(t *testing.T) {
+	r := NewRegistry()
+
+	var called []string
+	r.RegisterPruneOrphans("podman", func(ctx context.Context, clusters []string) error {
+		called = append(called, "podman")
+		return errors.New("podman is broken")
+	})
+	r.RegisterPruneOrphans("docker", func(ctx context.Context, clusters []string) error {
+		called = append(called, "docker")
+		if diff := cmp.Diff([]string{"kwok-kwok"}, clusters); diff != "" {
+			t.Errorf("unexpected clusters (-want +got):\n%s", diff)
+		}
+		return nil
+	})
+	r.RegisterPruneOrphans("nerdctl", func(ctx context.Context, clusters []string) error {
+		called = append(called, "nerdctl")
+		return nil
+	})
+
+	err := r.PruneOrphans(context.Background(), []string{"kwok-kwok"})
+	if err == nil {
+		t.Fatal("want the error of podman")
+	}
+	if diff := cmp.Diff([]string{"docker", "nerdctl", "podman"}, called); diff != "" {
+		t.Errorf("want every runtime pruned in order (-want +got):\n%s", diff)
+	}
+}
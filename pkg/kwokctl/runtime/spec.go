@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// ToSpec turns the saved configuration of a cluster into a spec to create the same cluster from,
+// the components set up by kwokctl, the status and the ports picked at random are dropped,
+// so they are set up again by the new cluster.
+func ToSpec(objs []config.InternalObject) ([]config.InternalObject, error) {
+	configs := config.FilterWithType[*internalversion.KwokctlConfiguration](objs)
+	if len(configs) != 1 {
+		return nil, fmt.Errorf("expected one KwokctlConfiguration, got %d", len(configs))
+	}
+
+	conf := configs[0].DeepCopy()
+	conf.Status = internalversion.KwokctlConfigurationStatus{}
+	conf.Components = slices.Filter(conf.Components, func(component internalversion.Component) bool {
+		for _, builtin := range builtinComponents {
+			if IsReplicaOf(component.Name, builtin) {
+				return false
+			}
+		}
+		return true
+	})
+
+	for _, port := range []*uint32{
+		&conf.Options.EtcdPeerPort,
+		&conf.Options.EtcdPort,
+		&conf.Options.KubeApiserverPort,
+		&conf.Options.KwokControllerPort,
+		&conf.Options.KubeControllerManagerPort,
+		&conf.Options.KubeSchedulerPort,
+		&conf.Options.JaegerOtlpGrpcPort,
+	} {
+		*port = 0
+	}
+
+	spec := make([]config.InternalObject, 0, len(objs))
+	spec = append(spec, conf)
+	spec = append(spec, config.FilterWithoutType[*internalversion.KwokctlConfiguration](objs)...)
+	return spec, nil
+}
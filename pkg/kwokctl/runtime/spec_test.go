@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+)
+
+func TestToSpec(t *testing.T) {
+	stage := &internalversion.Stage{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-ready"},
+	}
+	conf := &internalversion.KwokctlConfiguration{
+		Options: internalversion.KwokctlConfigurationOptions{
+			Runtime:                   "docker",
+			EtcdPeerPort:              32380,
+			EtcdPort:                  32379,
+			KubeApiserverPort:         32443,
+			KwokControllerPort:        32247,
+			KubeControllerManagerPort: 32257,
+			KubeSchedulerPort:         32259,
+			JaegerOtlpGrpcPort:        32317,
+			PrometheusPort:            9090,
+		},
+		Components: []internalversion.Component{
+			{Name: "etcd"},
+			{Name: "kube-apiserver"},
+			{Name: "kube-scheduler-1"},
+			{Name: "metrics-server"},
+		},
+		Status: internalversion.KwokctlConfigurationStatus{
+			Version: "v0.4.0",
+		},
+	}
+
+	spec, err := ToSpec([]config.InternalObject{conf, stage})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []config.InternalObject{
+		&internalversion.KwokctlConfiguration{
+			Options: internalversion.KwokctlConfigurationOptions{
+				Runtime:        "docker",
+				PrometheusPort: 9090,
+			},
+			Components: []internalversion.Component{
+				{Name: "metrics-server"},
+			},
+		},
+		stage,
+	}
+	if diff := cmp.Diff(want, spec); diff != "" {
+		t.Errorf("unexpected spec (-want +got):\n%s", diff)
+	}
+	if conf.Options.KubeApiserverPort != 32443 || len(conf.Components) != 4 {
+		t.Errorf("want the saved configuration unchanged, got %v", conf)
+	}
+
+	_, err = ToSpec([]config.InternalObject{stage})
+	if err == nil {
+		t.Errorf("want an error without the KwokctlConfiguration")
+	}
+}
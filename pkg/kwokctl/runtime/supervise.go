@@ -0,0 +1,240 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// SuperviseCommand is the hidden command of kwokctl that supervises the process of a component.
+const SuperviseCommand = "supervise"
+
+// initialRestartBackoff is the backoff of the first restart of a crashed process
+const initialRestartBackoff = time.Second
+
+// SupervisorState is the state of the process supervised,
+// it is saved to the state file each time the process is started.
+type SupervisorState struct {
+	// Pid is the pid of the process.
+	Pid int `json:"pid"`
+	// Restarts is the number of times the process has been restarted.
+	Restarts int `json:"restarts"`
+	// StartedAt is the time the process was started at the last time.
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// ForkExecWithRestart forks a supervisor which execs the given command like ForkExec,
+// and restarts the process each time it exits or fails the liveness probe, with a backoff doubling up to maxBackoff.
+// The restarts are disabled if maxBackoff is zero.
+func (c *Cluster) ForkExecWithRestart(ctx context.Context, dir string, name string, maxBackoff time.Duration, liveness *LivenessProbe, command string, args ...string) error {
+	// The supervisor is transparent to the users, so the dry run prints the command of the process directly
+	if maxBackoff == 0 || c.IsDryRun() {
+		return c.ForkExec(ctx, dir, name, command, args...)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("get the path of kwokctl: %w", err)
+	}
+	statePath := path.Join(dir, "pids", name+".state")
+	supervisorArgs := []string{
+		SuperviseCommand,
+		"--state-file=" + statePath,
+		"--max-backoff=" + maxBackoff.String(),
+	}
+	if liveness != nil {
+		supervisorArgs = append(supervisorArgs,
+			"--liveness-probe-url="+liveness.URL,
+			"--liveness-probe-initial-delay="+liveness.InitialDelay.String(),
+			"--liveness-probe-period="+liveness.Period.String(),
+			"--liveness-probe-timeout="+liveness.Timeout.String(),
+			"--liveness-probe-failure-threshold="+format.String(liveness.FailureThreshold),
+		)
+	}
+	supervisorArgs = append(supervisorArgs, "--", command)
+	return c.ForkExec(ctx, dir, name, self, append(supervisorArgs, args...)...)
+}
+
+// ForkExecRestarts returns the number of times the process of the given name has been restarted.
+func (c *Cluster) ForkExecRestarts(ctx context.Context, dir string, name string) (int, error) {
+	state, err := readSupervisorState(path.Join(dir, "pids", name+".state"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return state.Restarts, nil
+}
+
+// killSupervised terminates the process left by the supervisor, the supervisor terminates it before exiting,
+// but it is not the case if the supervisor is killed, e.g. in windows.
+func (c *Cluster) killSupervised(ctx context.Context, dir string, name string) error {
+	statePath := path.Join(dir, "pids", name+".state")
+	state, err := readSupervisorState(statePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if state.Pid != 0 && exec.IsRunning(state.Pid) {
+		logger := log.FromContext(ctx)
+		logger.Debug("Terminating the process left by the supervisor",
+			"pid", state.Pid,
+		)
+		err = exec.TerminateProcess(state.Pid, terminationGracePeriod)
+		if err != nil {
+			return err
+		}
+	}
+	return c.Remove(statePath)
+}
+
+// Supervise runs the command and restarts it each time it exits until the context is canceled,
+// the process is terminated as the context is canceled.
+// The state of the process is saved to the state file, and removed after the process is terminated.
+// The process is restarted as well if the liveness probe fails, which is disabled if it is nil.
+func Supervise(ctx context.Context, statePath string, maxBackoff time.Duration, liveness *LivenessProbe, command string, args ...string) error {
+	logger := log.FromContext(ctx)
+	state := SupervisorState{}
+	var backoff time.Duration
+	for {
+		cmd := osexec.Command(command, args...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err := cmd.Start()
+		if err != nil {
+			return fmt.Errorf("cmd start: %s %s: %w", command, strings.Join(args, " "), err)
+		}
+
+		state.Pid = cmd.Process.Pid
+		state.StartedAt = time.Now()
+		err = writeSupervisorState(statePath, state)
+		if err != nil {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			return err
+		}
+
+		exited := make(chan error, 1)
+		go func() {
+			exited <- cmd.Wait()
+		}()
+
+		stopProbe := make(chan struct{})
+		if liveness != nil {
+			pid := state.Pid
+			go liveness.watch(ctx, stopProbe, func() {
+				logger.Warn("Liveness probe failed, terminating the process",
+					"url", liveness.URL,
+				)
+				err := exec.TerminateProcess(pid, terminationGracePeriod)
+				if err != nil {
+					logger.Error("Failed to terminate the process", err)
+				}
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			close(stopProbe)
+			err = exec.TerminateProcess(state.Pid, terminationGracePeriod)
+			if err != nil {
+				logger.Error("Failed to terminate the process", err)
+			}
+			<-exited
+			return os.Remove(statePath)
+		case err = <-exited:
+			close(stopProbe)
+		}
+
+		backoff = nextRestartBackoff(backoff, maxBackoff, time.Since(state.StartedAt))
+		logger.Warn("Process exited, restarting",
+			"err", err,
+			"restarts", state.Restarts+1,
+			"backoff", backoff,
+		)
+		select {
+		case <-ctx.Done():
+			return os.Remove(statePath)
+		case <-time.After(backoff):
+		}
+		state.Restarts++
+	}
+}
+
+// nextRestartBackoff returns the backoff before restarting the process which ran for the given duration,
+// the backoff doubles after each crash up to maxBackoff,
+// and it is reset if the process ran longer than maxBackoff before crashing.
+func nextRestartBackoff(backoff, maxBackoff, ran time.Duration) time.Duration {
+	if backoff == 0 || ran >= maxBackoff {
+		backoff = initialRestartBackoff
+	} else {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+func readSupervisorState(statePath string) (*SupervisorState, error) {
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, err
+	}
+	state := &SupervisorState{}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("parse state file %s: %w", statePath, err)
+	}
+	return state, nil
+}
+
+// writeSupervisorState writes the state to a temporary file and renames it,
+// so the state is never read half written.
+func writeSupervisorState(statePath string, state SupervisorState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := statePath + ".tmp"
+	err = file.Write(tmp, data)
+	if err != nil {
+		return fmt.Errorf("write state file %s: %w", statePath, err)
+	}
+	err = file.Rename(tmp, statePath)
+	if err != nil {
+		return fmt.Errorf("write state file %s: %w", statePath, err)
+	}
+	return nil
+}
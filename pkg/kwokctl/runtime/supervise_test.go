@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRestartBackoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		backoff    time.Duration
+		maxBackoff time.Duration
+		ran        time.Duration
+		want       time.Duration
+	}{
+		{
+			name:       "first crash",
+			backoff:    0,
+			maxBackoff: 5 * time.Minute,
+			ran:        time.Second,
+			want:       time.Second,
+		},
+		{
+			name:       "doubles",
+			backoff:    4 * time.Second,
+			maxBackoff: 5 * time.Minute,
+			ran:        time.Second,
+			want:       8 * time.Second,
+		},
+		{
+			name:       "capped",
+			backoff:    4 * time.Minute,
+			maxBackoff: 5 * time.Minute,
+			ran:        time.Second,
+			want:       5 * time.Minute,
+		},
+		{
+			name:       "reset after running longer than the max backoff",
+			backoff:    4 * time.Minute,
+			maxBackoff: 5 * time.Minute,
+			ran:        10 * time.Minute,
+			want:       time.Second,
+		},
+		{
+			name:       "max backoff less than the initial backoff",
+			backoff:    0,
+			maxBackoff: 100 * time.Millisecond,
+			ran:        10 * time.Millisecond,
+			want:       100 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextRestartBackoff(tt.backoff, tt.maxBackoff, tt.ran)
+			if got != tt.want {
+				t.Errorf("nextRestartBackoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+// UpgradableComponents is the control plane components which can be upgraded, in the order of the upgrade
+var UpgradableComponents = []string{
+	consts.ComponentKubeApiserver,
+	consts.ComponentKubeControllerManager,
+	consts.ComponentKubeScheduler,
+}
+
+// IsReplicaOf returns true if the component is the given component or one of its replicas named <name>-<index>
+func IsReplicaOf(component string, name string) bool {
+	if component == name {
+		return true
+	}
+	index, ok := strings.CutPrefix(component, name+"-")
+	if !ok {
+		return false
+	}
+	_, err := strconv.ParseUint(index, 10, 32)
+	return err == nil
+}
+
+// UpgradeOptions sets the kube version of the options,
+// the binaries and the images of the control plane are moved to the new version unless they are not of the old version.
+func UpgradeOptions(conf *internalversion.KwokctlConfigurationOptions, kubeVersion string) {
+	oldVersion := conf.KubeVersion
+	conf.KubeVersion = kubeVersion
+	if oldVersion == "" || oldVersion == kubeVersion {
+		return
+	}
+
+	for _, value := range []*string{
+		&conf.KubectlBinary,
+		&conf.KubeApiserverBinary,
+		&conf.KubeControllerManagerBinary,
+		&conf.KubeSchedulerBinary,
+		&conf.KubeApiserverImage,
+		&conf.KubeControllerManagerImage,
+		&conf.KubeSchedulerImage,
+		&conf.KindNodeImage,
+	} {
+		*value = strings.ReplaceAll(*value, oldVersion, kubeVersion)
+	}
+}
+
+// UpgradeSource returns the binary and the image in the options of the control plane component or its replica
+func UpgradeSource(conf *internalversion.KwokctlConfigurationOptions, name string) (binary string, image string, err error) {
+	switch {
+	case IsReplicaOf(name, consts.ComponentKubeApiserver):
+		return conf.KubeApiserverBinary, conf.KubeApiserverImage, nil
+	case IsReplicaOf(name, consts.ComponentKubeControllerManager):
+		return conf.KubeControllerManagerBinary, conf.KubeControllerManagerImage, nil
+	case IsReplicaOf(name, consts.ComponentKubeScheduler):
+		return conf.KubeSchedulerBinary, conf.KubeSchedulerImage, nil
+	}
+	return "", "", fmt.Errorf("component %s can not be upgraded", name)
+}
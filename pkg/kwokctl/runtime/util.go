@@ -20,12 +20,15 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/utils/file"
 	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
@@ -102,6 +105,42 @@ func GetComponentPatches(conf *internalversion.KwokctlConfiguration, componentNa
 	return componentPatches
 }
 
+// GetComponentsResourceLimits returns the CPU in cores and the memory in bytes each of the components is limited to,
+// zero means no limit.
+func GetComponentsResourceLimits(conf *internalversion.KwokctlConfigurationOptions) (cpu float64, memory int64, err error) {
+	if conf.ComponentsCPULimit != "" {
+		q, err := resource.ParseQuantity(conf.ComponentsCPULimit)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid components cpu limit %q: %w", conf.ComponentsCPULimit, err)
+		}
+		cpu = float64(q.MilliValue()) / 1000
+	}
+	if conf.ComponentsMemoryLimit != "" {
+		q, err := resource.ParseQuantity(conf.ComponentsMemoryLimit)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid components memory limit %q: %w", conf.ComponentsMemoryLimit, err)
+		}
+		memory = q.Value()
+	}
+	return cpu, memory, nil
+}
+
+// GetComponentsRestartBackoff returns the maximum backoff of the restarts of the crashed components,
+// zero means the components are not restarted.
+func GetComponentsRestartBackoff(conf *internalversion.KwokctlConfigurationOptions) (time.Duration, error) {
+	if conf.ComponentsRestartBackoff == "" {
+		return 0, nil
+	}
+	backoff, err := time.ParseDuration(conf.ComponentsRestartBackoff)
+	if err != nil {
+		return 0, fmt.Errorf("invalid components restart backoff %q: %w", conf.ComponentsRestartBackoff, err)
+	}
+	if backoff < 0 {
+		return 0, fmt.Errorf("invalid components restart backoff %q: must not be negative", conf.ComponentsRestartBackoff)
+	}
+	return backoff, nil
+}
+
 // ExpandVolumesHostPaths expands relative paths specified in volumes to absolute paths
 func ExpandVolumesHostPaths(volumes []internalversion.Volume) ([]internalversion.Volume, error) {
 	result := make([]internalversion.Volume, 0, len(volumes))
@@ -164,3 +203,31 @@ func GetLogVolumes(ctx context.Context) []internalversion.Volume {
 	}
 	return volumes
 }
+
+// GetImagePlatform returns the platform the images are pulled for,
+// which is empty for the architecture kwokctl is built for, so that the images are pulled as usual.
+func GetImagePlatform(conf *internalversion.KwokctlConfigurationOptions) string {
+	if conf.Arch == "" || conf.Arch == config.GOARCH {
+		return ""
+	}
+	return "linux/" + conf.Arch
+}
+
+// GetBinaryVerification returns the verification of the binary downloaded from the src,
+// which is nil if it is not declared in the config.
+func GetBinaryVerification(conf *internalversion.KwokctlConfigurationOptions, src string) *file.Verification {
+	for _, v := range conf.BinaryVerifications {
+		if v.URL != src {
+			continue
+		}
+		return &file.Verification{
+			Sha256:                v.Sha256,
+			Signature:             v.Signature,
+			Key:                   v.Key,
+			Certificate:           v.Certificate,
+			CertificateIdentity:   v.CertificateIdentity,
+			CertificateOIDCIssuer: v.CertificateOIDCIssuer,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestGetComponentsResourceLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		cpu        string
+		memory     string
+		wantCPU    float64
+		wantMemory int64
+		wantErr    bool
+	}{
+		{
+			name: "no limits",
+		},
+		{
+			name:       "cores and bytes",
+			cpu:        "2",
+			memory:     "1Gi",
+			wantCPU:    2,
+			wantMemory: 1 << 30,
+		},
+		{
+			name:    "millicores",
+			cpu:     "500m",
+			wantCPU: 0.5,
+		},
+		{
+			name:       "decimal memory",
+			memory:     "512M",
+			wantMemory: 512 * 1000 * 1000,
+		},
+		{
+			name:    "invalid cpu",
+			cpu:     "two",
+			wantErr: true,
+		},
+		{
+			name:    "invalid memory",
+			memory:  "1GB",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, memory, err := GetComponentsResourceLimits(&internalversion.KwokctlConfigurationOptions{
+				ComponentsCPULimit:    tt.cpu,
+				ComponentsMemoryLimit: tt.memory,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetComponentsResourceLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cpu != tt.wantCPU || memory != tt.wantMemory {
+				t.Errorf("GetComponentsResourceLimits() = %v, %v, want %v, %v", cpu, memory, tt.wantCPU, tt.wantMemory)
+			}
+		})
+	}
+}
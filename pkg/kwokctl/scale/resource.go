@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/kwok/kustomize/kwokctl/resource"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// GetResource returns the resource of the kind in the configuration,
+// the default resource is used for node and pod if it is not configured.
+func GetResource(ctx context.Context, kind string) (*internalversion.KwokctlResource, error) {
+	krcs := config.FilterWithTypeFromContext[*internalversion.KwokctlResource](ctx)
+	krc, ok := slices.Find(krcs, func(krc *internalversion.KwokctlResource) bool {
+		return krc.Name == kind
+	})
+	if ok {
+		return krc, nil
+	}
+
+	var resourceData string
+	switch kind {
+	default:
+		return nil, fmt.Errorf("resource %s is not exists", kind)
+	case "pod":
+		resourceData = resource.DefaultPod
+	case "node":
+		resourceData = resource.DefaultNode
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("No resource found, use default resource", "resource", kind)
+	return config.UnmarshalWithType[*internalversion.KwokctlResource](resourceData)
+}
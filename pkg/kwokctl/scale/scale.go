@@ -75,6 +75,9 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 			return index
 		},
 		"AddCIDR": utilsnet.AddCIDR,
+		"Pick": func(value any) any {
+			return pick(value, index)
+		},
 	})
 	data, err := renderer.ToJSON(conf.Template, param)
 	if err != nil {
@@ -290,6 +293,16 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 	return nil
 }
 
+// pick returns the element of the list for the index in turn, or the value itself if it is not a list,
+// so the replicas can be given the values in a range, e.g. the versions of the kubelets.
+func pick(value any, index int) any {
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return value
+	}
+	return list[index%len(list)]
+}
+
 // NewParameters parses the parameters.
 func NewParameters(ctx context.Context, raw json.RawMessage, params []string) (any, error) {
 	var param any
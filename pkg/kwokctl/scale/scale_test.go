@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_pick(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		index int
+		want  any
+	}{
+		{
+			name:  "not a list",
+			value: "foo",
+			index: 3,
+			want:  "foo",
+		},
+		{
+			name:  "map",
+			value: map[string]any{"a": "b"},
+			index: 1,
+			want:  map[string]any{"a": "b"},
+		},
+		{
+			name:  "nil",
+			value: nil,
+			index: 1,
+			want:  nil,
+		},
+		{
+			name:  "empty list",
+			value: []any{},
+			index: 2,
+			want:  []any{},
+		},
+		{
+			name:  "first",
+			value: []any{"a", "b", "c"},
+			index: 0,
+			want:  "a",
+		},
+		{
+			name:  "in range",
+			value: []any{"a", "b", "c"},
+			index: 2,
+			want:  "c",
+		},
+		{
+			name:  "in turn",
+			value: []any{"a", "b", "c"},
+			index: 4,
+			want:  "b",
+		},
+		{
+			name:  "single",
+			value: []any{"a"},
+			index: 5,
+			want:  "a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pick(tt.value, tt.index); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pick() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
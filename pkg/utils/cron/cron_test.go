@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	now := time.Date(2023, 1, 1, 10, 7, 30, 0, time.UTC) // Sunday
+	tests := []struct {
+		spec    string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			spec: "* * * * *",
+			want: time.Date(2023, 1, 1, 10, 8, 0, 0, time.UTC),
+		},
+		{
+			spec: "*/10 * * * *",
+			want: time.Date(2023, 1, 1, 10, 10, 0, 0, time.UTC),
+		},
+		{
+			spec: "5,40 9-11 * * *",
+			want: time.Date(2023, 1, 1, 10, 40, 0, 0, time.UTC),
+		},
+		{
+			spec: "@daily",
+			want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			spec: "30 8 * * 1-5",
+			want: time.Date(2023, 1, 2, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			spec: "0 0 15 * 6",
+			want: time.Date(2023, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			spec: "0 12 29 2 *",
+			want: time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			spec: "0 0 30 2 *",
+			want: time.Time{},
+		},
+		{
+			spec:    "* * *",
+			wantErr: true,
+		},
+		{
+			spec:    "60 * * * *",
+			wantErr: true,
+		},
+		{
+			spec:    "*/0 * * * *",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := s.Next(now); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
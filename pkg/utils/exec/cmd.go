@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"syscall"
+	"time"
 )
 
 // KillProcess kills the process with the given pid.
@@ -46,6 +47,31 @@ func KillProcess(pid int) error {
 	return nil
 }
 
+// TerminateProcess terminates the process with the given pid gracefully,
+// and kills it if it is still running after the grace period.
+func TerminateProcess(pid int, gracePeriod time.Duration) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("find process %d: %w", pid, err)
+	}
+	err = terminateProcess(process)
+	if err != nil {
+		if errors.Is(err, os.ErrProcessDone) {
+			return nil
+		}
+		return fmt.Errorf("terminate process: %w", err)
+	}
+
+	deadline := time.Now().Add(gracePeriod)
+	for time.Now().Before(deadline) {
+		if !isRunning(pid) {
+			return nil
+		}
+		time.Sleep(time.Second / 10)
+	}
+	return KillProcess(pid)
+}
+
 // IsRunning returns true if the process is running.
 func IsRunning(pid int) bool {
 	return isRunning(pid)
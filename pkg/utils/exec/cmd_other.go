@@ -50,6 +50,10 @@ func isRunning(pid int) bool {
 	return err == nil
 }
 
+func terminateProcess(process *os.Process) error {
+	return process.Signal(syscall.SIGTERM)
+}
+
 func setUser(cmd *exec.Cmd, uid, gid *int64) error {
 	if uid == nil && gid == nil {
 		return nil
@@ -0,0 +1,79 @@
+//go:build !windows
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestTerminateProcess(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		gracePeriod time.Duration
+		wantKilled  bool
+	}{
+		{
+			name:        "terminated",
+			args:        []string{"sleep", "60"},
+			gracePeriod: 10 * time.Second,
+		},
+		{
+			name:        "killed after the grace period",
+			args:        []string{"sh", "-c", `trap "" TERM; exec sleep 60`},
+			gracePeriod: time.Second,
+			wantKilled:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(tt.args[0], tt.args[1:]...)
+			err := cmd.Start()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Wait for the process in the background, so that it is reaped when it exits.
+			done := make(chan struct{})
+			go func() {
+				_ = cmd.Wait()
+				close(done)
+			}()
+			// Give the shell the time to ignore the signal.
+			time.Sleep(time.Second / 2)
+
+			start := time.Now()
+			err = TerminateProcess(cmd.Process.Pid, tt.gracePeriod)
+			if err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("want the process exited")
+			}
+
+			elapsed := time.Since(start)
+			if killed := elapsed >= tt.gracePeriod; killed != tt.wantKilled {
+				t.Errorf("want killed %v, got the process exited after %s", tt.wantKilled, elapsed)
+			}
+		})
+	}
+}
@@ -44,9 +44,31 @@ func command(ctx context.Context, name string, arg ...string) *exec.Cmd {
 	return cmd
 }
 
+// stillActive is the exit code of the process that has not exited yet
+const stillActive = 259
+
 func isRunning(pid int) bool {
-	_, err := os.FindProcess(pid)
-	return err == nil
+	// The handle of the process is opened even if it has exited as long as
+	// someone still holds a handle, so the exit code is checked instead
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = windows.CloseHandle(handle)
+	}()
+
+	var code uint32
+	err = windows.GetExitCodeProcess(handle, &code)
+	if err != nil {
+		return false
+	}
+	return code == stillActive
+}
+
+// terminateProcess kills the process, as there is no termination signal in windows
+func terminateProcess(process *os.Process) error {
+	return process.Kill()
 }
 
 func setUser(cmd *exec.Cmd, uid, gid *int64) error {
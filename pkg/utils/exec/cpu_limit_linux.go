@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// startWithCPULimit starts the cmd with the CPU time limited, the process is killed once it exceeds the limit.
+// The process is traced to be stopped right after the exec, so that the limit is set before the command runs,
+// and the processes forked by the command inherit it.
+func startWithCPULimit(cmd *exec.Cmd, seconds uint64) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Ptrace = true
+
+	// The ptrace requests have to be made from the thread started the process.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+	pid := cmd.Process.Pid
+
+	err = setCPULimit(pid, seconds)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return err
+	}
+	return nil
+}
+
+// setCPULimit sets the CPU time limit of the process stopped after the exec, and then detaches it to run.
+func setCPULimit(pid int, seconds uint64) error {
+	var status unix.WaitStatus
+	_, err := unix.Wait4(pid, &status, 0, nil)
+	if err != nil {
+		return fmt.Errorf("wait for the exec: %w", err)
+	}
+	if !status.Stopped() {
+		return fmt.Errorf("process is not stopped after the exec: %v", status)
+	}
+
+	limit := &unix.Rlimit{
+		Cur: seconds,
+		Max: seconds,
+	}
+	err = unix.Prlimit(pid, unix.RLIMIT_CPU, limit, nil)
+	if err != nil {
+		return fmt.Errorf("set cpu limit: %w", err)
+	}
+
+	err = unix.PtraceDetach(pid)
+	if err != nil {
+		return fmt.Errorf("detach: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCommandWithCPULimit(t *testing.T) {
+	ctx := context.Background()
+	buf := bytes.NewBuffer(nil)
+	ctx = WithWriteTo(ctx, buf)
+	ctx = WithCPULimit(ctx, 3)
+
+	// The limit is inherited by the processes forked by the command as well.
+	err := Exec(ctx, "sh", "-c", "sh -c 'ulimit -t'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "3" {
+		t.Errorf("want the cpu limit 3, got %q", got)
+	}
+}
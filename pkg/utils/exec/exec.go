@@ -44,6 +44,10 @@ type Options struct {
 	Dir string
 	// Env is the environment variables of the command.
 	Env []string
+	// IsolateEnv is true if the command should not inherit the environment variables of the current process.
+	IsolateEnv bool
+	// CPULimitSeconds is the CPU time in seconds the command is allowed to use, 0 means no limit.
+	CPULimitSeconds uint64
 	// UID is the user id of the command
 	UID *int64
 	// GID is the group id of the command
@@ -58,13 +62,15 @@ type Options struct {
 
 func (e *Options) deepCopy() *Options {
 	return &Options{
-		Dir:       e.Dir,
-		Env:       append([]string(nil), e.Env...),
-		GID:       e.GID,
-		UID:       e.UID,
-		IOStreams: e.IOStreams,
-		PipeStdin: e.PipeStdin,
-		Fork:      e.Fork,
+		Dir:             e.Dir,
+		Env:             append([]string(nil), e.Env...),
+		IsolateEnv:      e.IsolateEnv,
+		CPULimitSeconds: e.CPULimitSeconds,
+		GID:             e.GID,
+		UID:             e.UID,
+		IOStreams:       e.IOStreams,
+		PipeStdin:       e.PipeStdin,
+		Fork:            e.Fork,
 	}
 }
 
@@ -82,6 +88,20 @@ func WithEnv(ctx context.Context, env []string) context.Context {
 	return ctx
 }
 
+// WithIsolateEnv returns a context with the given isolateEnv option.
+func WithIsolateEnv(ctx context.Context, isolateEnv bool) context.Context {
+	ctx, opt := withExecOptions(ctx)
+	opt.IsolateEnv = isolateEnv
+	return ctx
+}
+
+// WithCPULimit returns a context with the given CPU time limit in seconds.
+func WithCPULimit(ctx context.Context, seconds uint64) context.Context {
+	ctx, opt := withExecOptions(ctx)
+	opt.CPULimitSeconds = seconds
+	return ctx
+}
+
 // WithUser returns a context with the given username and group name.
 func WithUser(ctx context.Context, uid, gid *int64) context.Context {
 	ctx, opt := withExecOptions(ctx)
@@ -182,7 +202,9 @@ func Command(ctx context.Context, name string, args ...string) (cmd *exec.Cmd, e
 	} else {
 		cmd = command(ctx, name, args...)
 	}
-	if opt.Env != nil {
+	if opt.IsolateEnv {
+		cmd.Env = append([]string{}, opt.Env...)
+	} else if opt.Env != nil {
 		cmd.Env = append(os.Environ(), opt.Env...)
 	}
 	if err = setUser(cmd, opt.UID, opt.GID); err != nil {
@@ -213,7 +235,11 @@ func Command(ctx context.Context, name string, args ...string) (cmd *exec.Cmd, e
 		cmd.Stderr = buf
 	}
 
-	err = cmd.Start()
+	if opt.CPULimitSeconds != 0 {
+		err = startWithCPULimit(cmd, opt.CPULimitSeconds)
+	} else {
+		err = cmd.Start()
+	}
 	if err != nil {
 		return nil, fmt.Errorf("cmd start: %s %s: %w", name, strings.Join(args, " "), err)
 	}
@@ -17,15 +17,19 @@ limitations under the License.
 package exec
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"sigs.k8s.io/kwok/pkg/log"
 )
 
-// PullImages is a helper function to pull images
-func PullImages(ctx context.Context, command string, images []string, quiet bool) error {
+// PullImages is a helper function to pull images,
+// if the platform is given, the images are pulled for it and the ones existing for another platform are pulled again.
+func PullImages(ctx context.Context, command string, images []string, platform string, quiet bool) error {
 	var out io.Writer = os.Stderr
 	if quiet {
 		out = nil
@@ -38,11 +42,20 @@ func PullImages(ctx context.Context, command string, images []string, quiet bool
 			command, "inspect",
 			image,
 		)
+		if err == nil && platform != "" {
+			if got := imagePlatform(ctx, command, image); got != "" && got != platform {
+				logger.Info("Image exists for another platform", "image", image, "platform", got)
+				err = fmt.Errorf("image %s is for %s", image, got)
+			}
+		}
 		if err != nil {
 			logger.Info("Pull image", "image", image)
-			err = Exec(WithAllWriteTo(ctx, out), command, "pull",
-				image,
-			)
+			args := []string{"pull"}
+			if platform != "" {
+				args = append(args, "--platform="+platform)
+			}
+			args = append(args, image)
+			err = Exec(WithAllWriteTo(ctx, out), command, args...)
 			if err != nil {
 				return err
 			}
@@ -52,3 +65,43 @@ func PullImages(ctx context.Context, command string, images []string, quiet bool
 	}
 	return nil
 }
+
+// imagePlatform returns the platform of the image existing locally, or empty if it is unknown.
+func imagePlatform(ctx context.Context, command string, image string) string {
+	buf := bytes.NewBuffer(nil)
+	err := Exec(WithWriteTo(ctx, buf), command, "image", "inspect", "--format={{.Os}}/{{.Architecture}}", image)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// CheckImages is a helper function to check the images exist without pulling them
+func CheckImages(ctx context.Context, command string, images []string) error {
+	var missing []string
+	for _, image := range images {
+		err := Exec(ctx,
+			command, "inspect",
+			image,
+		)
+		if err != nil {
+			missing = append(missing, image)
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("images %v not found by %s", missing, command)
+	}
+	return nil
+}
+
+// SaveImages is a helper function to save images to an archive
+func SaveImages(ctx context.Context, command string, images []string, archive string) error {
+	args := []string{"save", "-o", archive}
+	args = append(args, images...)
+	return Exec(ctx, command, args...)
+}
+
+// LoadImages is a helper function to load images from an archive
+func LoadImages(ctx context.Context, command string, archive string) error {
+	return Exec(WithAllWriteTo(ctx, os.Stderr), command, "load", "-i", archive)
+}
@@ -0,0 +1,50 @@
+//go:build !windows
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckImages(t *testing.T) {
+	command := filepath.Join(t.TempDir(), "docker")
+	err := os.WriteFile(command, []byte(`#!/bin/sh
+[ "$1" = "inspect" ] && [ "$2" = "registry.k8s.io/etcd:3.5.9-0" ]
+`), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = CheckImages(context.Background(), command, []string{"registry.k8s.io/etcd:3.5.9-0"})
+	if err != nil {
+		t.Errorf("want the image found, got %v", err)
+	}
+
+	err = CheckImages(context.Background(), command, []string{
+		"registry.k8s.io/etcd:3.5.9-0",
+		"registry.k8s.io/kube-apiserver:v1.28.0",
+	})
+	if err == nil || !strings.Contains(err.Error(), "registry.k8s.io/kube-apiserver:v1.28.0") || strings.Contains(err.Error(), "etcd") {
+		t.Errorf("want only the kube-apiserver image missing, got %v", err)
+	}
+}
@@ -20,13 +20,18 @@ import (
 	"context"
 	"encoding/json"
 	"math/big"
+	"regexp"
+	"strings"
 
 	"github.com/itchyny/gojq"
+
+	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
 
 // Query is wrapper of gojq.Query.
 type Query struct {
-	code *gojq.Code
+	code      *gojq.Code
+	variables []string
 }
 
 // NewQuery returns a new Query.
@@ -35,23 +40,55 @@ func NewQuery(src string) (*Query, error) {
 	if err != nil {
 		return nil, err
 	}
-	code, err := gojq.Compile(q)
+	variables := Variables(src)
+	names := make([]string, 0, len(variables))
+	for _, v := range variables {
+		names = append(names, "$"+v)
+	}
+	code, err := gojq.Compile(q, gojq.WithVariables(names))
 	if err != nil {
 		return nil, err
 	}
 	return &Query{
-		code: code,
+		code:      code,
+		variables: variables,
 	}, nil
 }
 
+var variableRegexp = regexp.MustCompile(`\$[A-Za-z_][A-Za-z0-9_]*`)
+
+// Variables returns the names of the variables referenced in the expression, without the leading '$'.
+// The builtin variables $ENV and $__loc__ are excluded.
+func Variables(src string) []string {
+	out := []string{}
+	for _, v := range variableRegexp.FindAllString(src, -1) {
+		name := v[1:]
+		if name == "ENV" || strings.HasPrefix(name, "__") {
+			continue
+		}
+		if !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
 // Execute executes the query with the given value.
 func (q *Query) Execute(ctx context.Context, v interface{}) ([]interface{}, error) {
 	v, err := ToJSONStandard(v)
 	if err != nil {
 		return nil, err
 	}
+	var values []interface{}
+	if len(q.variables) != 0 {
+		vars := variablesFromContext(ctx)
+		values = make([]interface{}, 0, len(q.variables))
+		for _, name := range q.variables {
+			values = append(values, vars[name])
+		}
+	}
 	out := []interface{}{}
-	iter := q.code.RunWithContext(ctx, v)
+	iter := q.code.RunWithContext(ctx, v, values...)
 	for {
 		v, ok := iter.Next()
 		if !ok {
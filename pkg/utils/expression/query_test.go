@@ -162,3 +162,39 @@ func TestQuery_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestQuery_ExecuteWithVariables(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"zone": "a",
+			},
+		},
+	}
+	ctx, err := WithVariables(context.Background(), map[string]interface{}{
+		"node": node,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := NewQuery(`$node.metadata.labels.zone`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := q.Execute(ctx, &corev1.Pod{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []interface{}{"a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Execute() got = %v, want %v", got, want)
+	}
+
+	got, err = q.Execute(context.Background(), &corev1.Pod{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []interface{}{nil}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Execute() without variables got = %v, want %v", got, want)
+	}
+}
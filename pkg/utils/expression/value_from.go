@@ -18,6 +18,7 @@ package expression
 
 import (
 	"context"
+	"strconv"
 	"time"
 )
 
@@ -90,3 +91,73 @@ type duration int64
 func (i duration) Get(ctx context.Context, v interface{}, now time.Time) (time.Duration, bool) {
 	return time.Duration(i), true
 }
+
+// IntGetter is a interface that can be used to get a int64 value.
+type IntGetter interface {
+	// Get returns a int64 value.
+	Get(ctx context.Context, v interface{}) (int64, bool)
+}
+
+type intFrom struct {
+	value *int64
+	query *Query
+}
+
+// NewIntFrom returns a new IntGetter.
+func NewIntFrom(value *int64, src *string) (IntGetter, error) {
+	if value == nil && src == nil {
+		return intNoop{}, nil
+	}
+	if src == nil {
+		return integer(*value), nil
+	}
+	query, err := NewQuery(*src)
+	if err != nil {
+		return nil, err
+	}
+	return &intFrom{
+		value: value,
+		query: query,
+	}, nil
+}
+
+func (i *intFrom) Get(ctx context.Context, v interface{}) (int64, bool) {
+	out, err := i.query.Execute(ctx, v)
+	if err != nil {
+		return 0, false
+	}
+	if len(out) == 0 || out[0] == nil {
+		if i.value != nil {
+			return *i.value, true
+		}
+		return 0, false
+	}
+	switch t := out[0].(type) {
+	case int:
+		return int64(t), true
+	case float64:
+		return int64(t), true
+	case string:
+		n, err := strconv.ParseInt(t, 10, 64)
+		if err == nil {
+			return n, true
+		}
+	}
+	if i.value != nil {
+		return *i.value, true
+	}
+	return 0, false
+}
+
+type intNoop struct {
+}
+
+func (intNoop) Get(ctx context.Context, v interface{}) (int64, bool) {
+	return 0, false
+}
+
+type integer int64
+
+func (i integer) Get(ctx context.Context, v interface{}) (int64, bool) {
+	return int64(i), true
+}
@@ -90,3 +90,85 @@ func TestDurationFrom_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestIntFrom_Get(t *testing.T) {
+	type args struct {
+		value *int64
+		src   *string
+		v     interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+		want    int64
+		wantOk  bool
+	}{
+		{
+			args: args{
+				v: corev1.Pod{},
+			},
+			wantOk: false,
+		},
+		{
+			args: args{
+				value: format.Ptr[int64](1),
+				v:     corev1.Pod{},
+			},
+			want:   1,
+			wantOk: true,
+		},
+		{
+			args: args{
+				value: format.Ptr[int64](1),
+				src:   format.Ptr(`.metadata.annotations["weight"]`),
+				v: corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							"weight": "95",
+						},
+					},
+				},
+			},
+			want:   95,
+			wantOk: true,
+		},
+		{
+			args: args{
+				value: format.Ptr[int64](1),
+				src:   format.Ptr(`.metadata.annotations["weight"]`),
+				v:     corev1.Pod{},
+			},
+			want:   1,
+			wantOk: true,
+		},
+		{
+			args: args{
+				src: format.Ptr(`.spec.priority`),
+				v: corev1.Pod{
+					Spec: corev1.PodSpec{
+						Priority: format.Ptr[int32](5),
+					},
+				},
+			},
+			want:   5,
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, err := NewIntFrom(tt.args.value, tt.args.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewIntFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got, gotOk := i.Get(context.Background(), tt.args.v)
+			if got != tt.want {
+				t.Errorf("Get() got = %v, want %v", got, tt.want)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("Get() gotOk = %v, wantOk %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
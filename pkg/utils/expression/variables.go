@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expression
+
+import (
+	"context"
+)
+
+type variablesCtxKey struct{}
+
+// WithVariables returns a copy of the context with the variables
+// that can be referenced in the expressions as $name.
+func WithVariables(ctx context.Context, vars map[string]interface{}) (context.Context, error) {
+	merged := map[string]interface{}{}
+	for k, v := range variablesFromContext(ctx) {
+		merged[k] = v
+	}
+	for k, v := range vars {
+		v, err := ToJSONStandard(v)
+		if err != nil {
+			return nil, err
+		}
+		merged[k] = v
+	}
+	return context.WithValue(ctx, variablesCtxKey{}, merged), nil
+}
+
+func variablesFromContext(ctx context.Context) map[string]interface{} {
+	vars, _ := ctx.Value(variablesCtxKey{}).(map[string]interface{})
+	return vars
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// lockSuffix is the suffix of the file locking the cache across the processes.
+	lockSuffix = ".lock"
+	// partialSuffix is the suffix of the file being downloaded, which is resumed if the download is interrupted.
+	partialSuffix = ".tmp"
+	// verifiedSuffix is the suffix of the file recording the verification of the cache.
+	verifiedSuffix = ".verified"
+)
+
+// CacheEntry is a file in the cache directory.
+type CacheEntry struct {
+	// Path is the path of the file.
+	Path string
+	// Size is the size of the file.
+	Size int64
+	// LastUsed is the time the file is used last.
+	LastUsed time.Time
+	// Partial is true if the file is partially downloaded.
+	Partial bool
+}
+
+// ListCache returns the files in the cache directory, the least recently used first.
+func ListCache(cacheDir string) ([]CacheEntry, error) {
+	entries := []CacheEntry{}
+	err := filepath.WalkDir(cacheDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(p, lockSuffix) || strings.HasSuffix(p, verifiedSuffix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, CacheEntry{
+			Path:     p,
+			Size:     info.Size(),
+			LastUsed: info.ModTime(),
+			Partial:  strings.HasSuffix(p, partialSuffix),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LastUsed.Before(entries[j].LastUsed)
+	})
+	return entries, nil
+}
+
+// PruneCache returns the least recently used entries to be removed,
+// so that the total size of the entries is not more than the maxSize,
+// the entries in use are kept even if the total size is still more than the maxSize.
+func PruneCache(entries []CacheEntry, maxSize int64, inUse func(entry CacheEntry) bool) []CacheEntry {
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+	}
+
+	sorted := make([]CacheEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].LastUsed.Before(sorted[j].LastUsed)
+	})
+
+	pruned := []CacheEntry{}
+	for _, entry := range sorted {
+		if total <= maxSize {
+			break
+		}
+		if inUse != nil && inUse(entry) {
+			continue
+		}
+		pruned = append(pruned, entry)
+		total -= entry.Size
+	}
+	return pruned
+}
+
+// RemoveCache removes the entry and the verification of it,
+// it returns false if the entry is being downloaded by another process.
+func RemoveCache(entry CacheEntry) (bool, error) {
+	name := strings.TrimSuffix(entry.Path, partialSuffix)
+	if Exists(name + lockSuffix) {
+		unlock, ok, err := tryLock(name + lockSuffix)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+		defer unlock()
+	}
+
+	err := os.Remove(entry.Path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if !entry.Partial {
+		err = os.Remove(name + verifiedSuffix)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPruneCache(t *testing.T) {
+	now := time.Now()
+	entries := []CacheEntry{
+		{Path: "kube-apiserver", Size: 3, LastUsed: now.Add(-3 * time.Hour)},
+		{Path: "kubectl", Size: 2, LastUsed: now.Add(-1 * time.Hour)},
+		{Path: "etcd", Size: 4, LastUsed: now.Add(-2 * time.Hour)},
+	}
+	tests := []struct {
+		name    string
+		maxSize int64
+		inUse   string
+		want    []string
+	}{
+		{
+			name:    "not larger",
+			maxSize: 9,
+			want:    []string{},
+		},
+		{
+			name:    "least recently used",
+			maxSize: 6,
+			want:    []string{"kube-apiserver"},
+		},
+		{
+			name:    "more than one",
+			maxSize: 5,
+			want:    []string{"kube-apiserver", "etcd"},
+		},
+		{
+			name:    "in use",
+			maxSize: 5,
+			inUse:   "kube-apiserver",
+			want:    []string{"etcd"},
+		},
+		{
+			name:    "all",
+			maxSize: 0,
+			want:    []string{"kube-apiserver", "etcd", "kubectl"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pruned := PruneCache(entries, tt.maxSize, func(entry CacheEntry) bool {
+				return entry.Path == tt.inUse
+			})
+			got := []string{}
+			for _, entry := range pruned {
+				got = append(got, entry.Path)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PruneCache() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadResume(t *testing.T) {
+	content := bytes.Repeat([]byte("kwok"), 1024)
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/kwok" {
+			http.NotFound(w, r)
+			return
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "kwok", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	src := server.URL + "/kwok"
+	cacheDir := t.TempDir()
+	cache, err := getCachePath(cacheDir, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.MkdirAll(filepath.Dir(cache), 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(cache+partialSuffix, content[:1000], 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := DownloadToCache(context.Background(), cacheDir, src, 0644, true, nil)
+	if err != nil {
+		t.Fatalf("DownloadToCache() error = %v", err)
+	}
+	data, err := os.ReadFile(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, content) {
+		t.Errorf("the resumed file is not the same as the src")
+	}
+	if len(ranges) != 1 || ranges[0] != "bytes=1000-" {
+		t.Errorf("the ranges requested are %q, want %q", ranges, "bytes=1000-")
+	}
+
+	entries, err := ListCache(cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Path != got || entries[0].Partial {
+		t.Errorf("ListCache() = %v, want the downloaded file only", entries)
+	}
+}
+
+func TestTryLock(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "kwok.lock")
+	unlock, ok, err := tryLock(name)
+	if err != nil || !ok {
+		t.Fatalf("tryLock() = %v, %v, want the lock", ok, err)
+	}
+
+	_, ok, err = tryLock(name)
+	if err != nil || ok {
+		t.Fatalf("tryLock() = %v, %v, want the lock held", ok, err)
+	}
+
+	unlock()
+	unlock, ok, err = tryLock(name)
+	if err != nil || !ok {
+		t.Fatalf("tryLock() = %v, %v, want the lock released", ok, err)
+	}
+	unlock()
+}
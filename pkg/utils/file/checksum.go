@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// checksumFiles returns the files the sha256 checksum of the src may be published in,
+// e.g. kube-apiserver.sha256 of Kubernetes, kind-linux-amd64.sha256sum of kind,
+// SHA256SUMS of etcd and sha256sums.txt of Prometheus.
+func checksumFiles(src string) []string {
+	dir := src[:strings.LastIndex(src, "/")]
+	return []string{
+		src + ".sha256",
+		src + ".sha256sum",
+		dir + "/SHA256SUMS",
+		dir + "/sha256sums.txt",
+	}
+}
+
+// verifyChecksum verifies the sha256 checksum of the file downloaded from the src,
+// it is skipped if the checksum is not published.
+func verifyChecksum(ctx context.Context, src string, sum []byte) error {
+	u, err := url.Parse(src)
+	if err != nil {
+		return err
+	}
+	name := path.Base(u.Path)
+	got := hex.EncodeToString(sum)
+
+	logger := log.FromContext(ctx)
+	for _, checksumFile := range checksumFiles(src) {
+		data, err := getChecksumFile(ctx, checksumFile)
+		if err != nil {
+			logger.Debug("Failed to get checksum", "uri", checksumFile, "err", err)
+			continue
+		}
+		want := parseChecksum(data, name)
+		if want == "" {
+			continue
+		}
+		if !strings.EqualFold(want, got) {
+			return fmt.Errorf("the sha256 checksum of %s is %s, but %s is published in %s", src, got, want, checksumFile)
+		}
+		logger.Debug("Verified checksum", "uri", src, "sha256", got)
+		return nil
+	}
+	logger.Debug("No checksum published", "uri", src)
+	return nil
+}
+
+// maxChecksumFileSize is the maximum size of the checksum files.
+const maxChecksumFileSize = 1 << 20
+
+func getChecksumFile(ctx context.Context, src string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", src, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxChecksumFileSize))
+}
+
+// parseChecksum returns the sha256 checksum of the file from the content of a checksum file,
+// which is either the checksum only, or the lines of the checksum and the name of files.
+func parseChecksum(data []byte, name string) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 1:
+			if isSha256(fields[0]) {
+				return fields[0]
+			}
+		case 2:
+			if isSha256(fields[0]) && path.Base(strings.TrimPrefix(fields[1], "*")) == name {
+				return fields[0]
+			}
+		}
+	}
+	return ""
+}
+
+func isSha256(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
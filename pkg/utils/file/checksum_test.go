@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseChecksum(t *testing.T) {
+	sum := "0bf0c8a59d69c3bcb9bb0a7b4d8a8d3f6b1b7b9e0a4cbbd0d1b1fd2a4ac26e1e"
+	other := "1bf0c8a59d69c3bcb9bb0a7b4d8a8d3f6b1b7b9e0a4cbbd0d1b1fd2a4ac26e1e"
+	tests := []struct {
+		name string
+		file string
+		data string
+		want string
+	}{
+		{
+			name: "checksum only",
+			file: "kind-linux-amd64",
+			data: sum + "\n",
+			want: sum,
+		},
+		{
+			name: "checksum with name",
+			file: "kind-linux-amd64",
+			data: sum + "  kind-linux-amd64\n",
+			want: sum,
+		},
+		{
+			name: "checksums of all architectures",
+			file: "etcd-v3.5.9-linux-amd64.tar.gz",
+			data: other + "  etcd-v3.5.9-linux-arm64.tar.gz\n" + sum + "  etcd-v3.5.9-linux-amd64.tar.gz\n",
+			want: sum,
+		},
+		{
+			name: "checksum in binary mode",
+			file: "kind-linux-amd64",
+			data: sum + " *kind-linux-amd64\n",
+			want: sum,
+		},
+		{
+			name: "no checksum for the file",
+			file: "etcd-v3.5.9-linux-amd64.tar.gz",
+			data: other + "  etcd-v3.5.9-linux-arm64.tar.gz\n",
+			want: "",
+		},
+		{
+			name: "not checksum",
+			file: "kind-linux-amd64",
+			data: "<html>Not Found</html>\n",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseChecksum([]byte(tt.data), tt.file); got != tt.want {
+				t.Errorf("parseChecksum() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadWithChecksum(t *testing.T) {
+	content := []byte("kwok")
+	sum := sha256.Sum256(content)
+	checksums := map[string]string{
+		"/verified/kwok":          "",
+		"/verified/kwok.sha256":   hex.EncodeToString(sum[:]),
+		"/mismatched/kwok":        "",
+		"/mismatched/SHA256SUMS":  hex.EncodeToString(make([]byte, sha256.Size)) + "  kwok\n",
+		"/unpublished/kwok":       "",
+		"/unpublished/SHA256SUMS": hex.EncodeToString(make([]byte, sha256.Size)) + "  kwokctl\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := checksums[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if data == "" {
+			_, _ = w.Write(content)
+			return
+		}
+		_, _ = w.Write([]byte(data))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		dir     string
+		wantErr bool
+	}{
+		{
+			name: "verified",
+			dir:  "verified",
+		},
+		{
+			name:    "mismatched",
+			dir:     "mismatched",
+			wantErr: true,
+		},
+		{
+			name: "unpublished",
+			dir:  "unpublished",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			dest := filepath.Join(tmp, "bin", "kwok")
+			err := DownloadWithCache(context.Background(), filepath.Join(tmp, "cache"), server.URL+"/"+tt.dir+"/kwok", dest, 0644, true, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DownloadWithCache() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			_, err = os.Stat(dest)
+			if exist := err == nil; exist == tt.wantErr {
+				t.Errorf("the existence of the downloaded file is %v, want %v", exist, !tt.wantErr)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package file
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/fs"
@@ -26,13 +27,16 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 )
 
 // DownloadWithCacheAndExtract downloads the src file to the dest file, and extract it to the dest directory.
-func DownloadWithCacheAndExtract(ctx context.Context, cacheDir, src, dest string, match string, mode fs.FileMode, quiet bool, clean bool) error {
+// The src file is verified with the verification if it is not nil.
+func DownloadWithCacheAndExtract(ctx context.Context, cacheDir, src, dest string, match string, mode fs.FileMode, quiet bool, clean bool, verification *Verification) error {
 	if _, err := os.Stat(dest); err == nil {
 		return nil
 	}
@@ -41,9 +45,16 @@ func DownloadWithCacheAndExtract(ctx context.Context, cacheDir, src, dest string
 	if err != nil {
 		return err
 	}
+
+	unlock, err := lockCache(ctx, cacheDir, src)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	cache := path.Join(path.Dir(cacheTar), match)
-	if _, err = os.Stat(cache); err != nil {
-		cacheTar, err = getCacheOrDownload(ctx, cacheDir, src, 0644, quiet)
+	if _, err = os.Stat(cache); err != nil || !isVerified(cacheDir, src, verification) {
+		cacheTar, err = getCacheOrDownloadLocked(ctx, cacheDir, src, 0644, quiet, verification)
 		if err != nil {
 			return err
 		}
@@ -66,6 +77,8 @@ func DownloadWithCacheAndExtract(ctx context.Context, cacheDir, src, dest string
 		if err != nil {
 			return err
 		}
+	} else {
+		touch(ctx, cache)
 	}
 
 	err = MkdirAll(path.Dir(dest))
@@ -82,12 +95,13 @@ func DownloadWithCacheAndExtract(ctx context.Context, cacheDir, src, dest string
 }
 
 // DownloadWithCache downloads the src file to the dest file.
-func DownloadWithCache(ctx context.Context, cacheDir, src, dest string, mode fs.FileMode, quiet bool) error {
+// The src file is verified with the verification if it is not nil.
+func DownloadWithCache(ctx context.Context, cacheDir, src, dest string, mode fs.FileMode, quiet bool, verification *Verification) error {
 	if _, err := os.Stat(dest); err == nil {
 		return nil
 	}
 
-	cache, err := getCacheOrDownload(ctx, cacheDir, src, mode, quiet)
+	cache, err := getCacheOrDownload(ctx, cacheDir, src, mode, quiet, verification)
 	if err != nil {
 		return err
 	}
@@ -105,6 +119,30 @@ func DownloadWithCache(ctx context.Context, cacheDir, src, dest string, mode fs.
 	return nil
 }
 
+// DownloadToCache downloads the src file to the cache directory, and returns the path of the cache.
+// The src file is verified with the verification if it is not nil.
+func DownloadToCache(ctx context.Context, cacheDir, src string, mode fs.FileMode, quiet bool, verification *Verification) (string, error) {
+	return getCacheOrDownload(ctx, cacheDir, src, mode, quiet, verification)
+}
+
+// IsCached returns true if the src file can be got without the network,
+// that is it is a local file or in the cache directory, or the match extracted from it is in the cache directory.
+func IsCached(cacheDir, src string, match string) bool {
+	cache, err := getCachePath(cacheDir, src)
+	if err != nil {
+		return false
+	}
+	if _, err := os.Stat(cache); err == nil {
+		return true
+	}
+	if match != "" {
+		if _, err := os.Stat(path.Join(path.Dir(cache), match)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func getCachePath(cacheDir, src string) (string, error) {
 	u, err := url.Parse(src)
 	if err != nil {
@@ -125,88 +163,208 @@ func getCachePath(cacheDir, src string) (string, error) {
 	}
 }
 
-func getCacheOrDownload(ctx context.Context, cacheDir, src string, mode fs.FileMode, quiet bool) (string, error) {
+// isRemote returns true if the src is downloaded to the cache directory.
+func isRemote(src string) bool {
+	u, err := url.Parse(src)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// lockCache locks the cache of the src across the processes,
+// so that the src is downloaded once by the processes sharing the cache directory.
+func lockCache(ctx context.Context, cacheDir, src string) (unlock func(), err error) {
+	if !isRemote(src) {
+		return func() {}, nil
+	}
+	cache, err := getCachePath(cacheDir, src)
+	if err != nil {
+		return nil, err
+	}
+	return lockFile(ctx, cache+lockSuffix)
+}
+
+// touch updates the modification time of the cache, which is the time it is used last for the eviction.
+func touch(ctx context.Context, cache string) {
+	now := time.Now()
+	err := os.Chtimes(cache, now, now)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Debug("Failed to update the time of cache", "path", cache, "err", err)
+	}
+}
+
+func getCacheOrDownload(ctx context.Context, cacheDir, src string, mode fs.FileMode, quiet bool, verification *Verification) (string, error) {
+	unlock, err := lockCache(ctx, cacheDir, src)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	return getCacheOrDownloadLocked(ctx, cacheDir, src, mode, quiet, verification)
+}
+
+func getCacheOrDownloadLocked(ctx context.Context, cacheDir, src string, mode fs.FileMode, quiet bool, verification *Verification) (string, error) {
 	cache, err := getCachePath(cacheDir, src)
 	if err != nil {
 		return "", err
 	}
 	if _, err := os.Stat(cache); err == nil {
+		if !isVerified(cacheDir, src, verification) {
+			err = verify(ctx, cacheDir, src, cache, nil, verification)
+			if err != nil {
+				return "", err
+			}
+			err = recordVerified(cacheDir, src, verification)
+			if err != nil {
+				return "", err
+			}
+		}
+		if isRemote(src) {
+			touch(ctx, cache)
+		}
 		return cache, nil
 	}
 
-	u, err := url.Parse(src)
+	if !isRemote(src) {
+		return src, nil
+	}
+
+	err = os.MkdirAll(path.Dir(cache), 0750)
 	if err != nil {
 		return "", err
 	}
-	switch u.Scheme {
-	case "http", "https":
 
-		logger := log.FromContext(ctx)
-		logger.Info("Download", "uri", src)
+	tmp := cache + partialSuffix
+	sum, err := download(ctx, src, tmp, mode, quiet)
+	if err != nil {
+		return "", err
+	}
 
-		cli := &http.Client{}
-		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	err = verifyChecksum(ctx, src, sum)
+	if err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+
+	err = verify(ctx, cacheDir, src, tmp, sum, verification)
+	if err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+
+	err = os.Rename(tmp, cache)
+	if err != nil {
+		return "", err
+	}
+
+	err = recordVerified(cacheDir, src, verification)
+	if err != nil {
+		return "", err
+	}
+	return cache, nil
+}
+
+// download downloads the src to the tmp file and returns the sha256 checksum of it,
+// the tmp file left by the interrupted download is resumed if the server supports the range requests.
+func download(ctx context.Context, src, tmp string, mode fs.FileMode, quiet bool) ([]byte, error) {
+	logger := log.FromContext(ctx)
+
+	hash := sha256.New()
+	var offset int64
+	if fi, err := os.Stat(tmp); err == nil && fi.Size() > 0 {
+		f, err := os.Open(tmp)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		req = req.WithContext(ctx)
-		resp, err := cli.Do(req)
+		offset, err = io.Copy(hash, f)
+		_ = f.Close()
 		if err != nil {
-			return "", err
+			return nil, err
 		}
+	}
 
-		defer func() {
-			err = resp.Body.Close()
-			if err != nil {
-				logger.Error("Failed to close body of response", err)
-			}
-		}()
-
-		if resp.StatusCode != http.StatusOK {
-			return "", fmt.Errorf("%s: %s", u.String(), resp.Status)
-		}
+	cli := &http.Client{}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset != 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
 
-		err = os.MkdirAll(path.Dir(cache), 0750)
+	defer func() {
+		err = resp.Body.Close()
 		if err != nil {
-			return "", err
+			logger.Error("Failed to close body of response", err)
 		}
+	}()
 
-		d, err := os.OpenFile(cache+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
-		if err != nil {
-			return "", err
+	flag := os.O_CREATE | os.O_TRUNC | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if offset != 0 {
+			logger.Debug("Resume is not supported, download from the beginning", "uri", src)
+			hash.Reset()
+			offset = 0
 		}
-
-		var srcReader io.Reader = resp.Body
-		if !quiet {
-			pb := newProgressBar()
-			contentLength := resp.Header.Get("Content-Length")
-			contentLengthInt, _ := strconv.Atoi(contentLength)
-			counter := newCounterWriter(func(counter int) {
-				pb.Update(counter, contentLengthInt)
-				pb.Print()
-			})
-			srcReader = io.TeeReader(srcReader, counter)
+		logger.Info("Download", "uri", src)
+	case http.StatusPartialContent:
+		if offset == 0 || !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			return nil, fmt.Errorf("%s: unexpected range %q", src, resp.Header.Get("Content-Range"))
 		}
-
-		_, err = io.Copy(d, srcReader)
-		if err != nil {
-			_ = d.Close()
-			fmt.Println()
-			return "", err
+		logger.Info("Resume download", "uri", src, "offset", offset)
+		flag = os.O_WRONLY | os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		if offset == 0 {
+			return nil, fmt.Errorf("%s: %s", src, resp.Status)
 		}
-		err = d.Close()
+		// The partial file does not match the src anymore, download it from the beginning.
+		err = os.Remove(tmp)
 		if err != nil {
-			logger.Error("Failed to close file", err)
+			return nil, err
 		}
+		return download(ctx, src, tmp, mode, quiet)
+	default:
+		return nil, fmt.Errorf("%s: %s", src, resp.Status)
+	}
 
-		err = os.Rename(cache+".tmp", cache)
-		if err != nil {
-			return "", err
+	d, err := os.OpenFile(tmp, flag, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var srcReader io.Reader = io.TeeReader(resp.Body, hash)
+	if !quiet {
+		pb := newProgressBar()
+		contentLength := resp.Header.Get("Content-Length")
+		contentLengthInt, _ := strconv.Atoi(contentLength)
+		if contentLengthInt != 0 {
+			contentLengthInt += int(offset)
 		}
-		return cache, nil
-	default:
-		return src, nil
+		counter := newCounterWriter(func(counter int) {
+			pb.Update(int(offset)+counter, contentLengthInt)
+			pb.Print()
+		})
+		srcReader = io.TeeReader(srcReader, counter)
+	}
+
+	_, err = io.Copy(d, srcReader)
+	if err != nil {
+		_ = d.Close()
+		fmt.Println()
+		return nil, err
+	}
+	err = d.Close()
+	if err != nil {
+		logger.Error("Failed to close file", err)
 	}
+	return hash.Sum(nil), nil
 }
 
 type counterWriter struct {
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsCached(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	local := filepath.Join(dir, "kube-apiserver")
+
+	files := []string{
+		local,
+		filepath.Join(cacheDir, "https", "dl.k8s.io", "v1.28.0", "bin", "linux", "amd64", "kube-apiserver"),
+		filepath.Join(cacheDir, "https", "github.com", "etcd-io", "etcd", "releases", "download", "v3.5.9", "etcd"),
+	}
+	for _, f := range files {
+		err := os.MkdirAll(filepath.Dir(f), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(f, nil, 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		src   string
+		match string
+		want  bool
+	}{
+		{
+			name: "local file",
+			src:  local,
+			want: true,
+		},
+		{
+			name: "missing local file",
+			src:  filepath.Join(dir, "kube-scheduler"),
+		},
+		{
+			name: "cached",
+			src:  "https://dl.k8s.io/v1.28.0/bin/linux/amd64/kube-apiserver",
+			want: true,
+		},
+		{
+			name: "not cached",
+			src:  "https://dl.k8s.io/v1.28.0/bin/linux/amd64/kube-scheduler",
+		},
+		{
+			name:  "extracted match cached",
+			src:   "https://github.com/etcd-io/etcd/releases/download/v3.5.9/etcd-v3.5.9-linux-amd64.tar.gz",
+			match: "etcd",
+			want:  true,
+		},
+		{
+			name:  "extracted match not cached",
+			src:   "https://github.com/etcd-io/etcd/releases/download/v3.5.9/etcd-v3.5.9-linux-amd64.tar.gz",
+			match: "etcdctl",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCached(cacheDir, tt.src, tt.match); got != tt.want {
+				t.Errorf("IsCached() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// lockRetryInterval is the interval to retry the lock held by another process.
+const lockRetryInterval = 500 * time.Millisecond
+
+// lockFile locks the name across the processes,
+// it waits until the lock is released by another process or the ctx is done.
+func lockFile(ctx context.Context, name string) (unlock func(), err error) {
+	logged := false
+	for {
+		unlock, ok, err := tryLock(name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return unlock, nil
+		}
+		if !logged {
+			logger := log.FromContext(ctx)
+			logger.Info("Waiting for the lock held by another process", "path", name)
+			logged = true
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// tryLock locks the name across the processes without waiting,
+// it returns false if the lock is held by another process.
+func tryLock(name string) (unlock func(), ok bool, err error) {
+	err = os.MkdirAll(path.Dir(name), 0750)
+	if err != nil {
+		return nil, false, err
+	}
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_RDWR, 0640)
+	if err != nil {
+		return nil, false, err
+	}
+	ok, err = tryLockFile(f)
+	if err != nil || !ok {
+		_ = f.Close()
+		return nil, false, err
+	}
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, true, nil
+}
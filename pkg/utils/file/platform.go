@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"io"
+	"os"
+)
+
+var (
+	elfArches = map[elf.Machine]string{
+		elf.EM_X86_64:  "amd64",
+		elf.EM_386:     "386",
+		elf.EM_AARCH64: "arm64",
+		elf.EM_ARM:     "arm",
+		elf.EM_S390:    "s390x",
+		elf.EM_RISCV:   "riscv64",
+	}
+	machoArches = map[macho.Cpu]string{
+		macho.CpuAmd64: "amd64",
+		macho.Cpu386:   "386",
+		macho.CpuArm64: "arm64",
+	}
+	peArches = map[uint16]string{
+		pe.IMAGE_FILE_MACHINE_AMD64: "amd64",
+		pe.IMAGE_FILE_MACHINE_I386:  "386",
+		pe.IMAGE_FILE_MACHINE_ARM64: "arm64",
+	}
+)
+
+// Platform returns the os and the architecture the executable is built for,
+// the os is empty if it is not a known format of executables, e.g. a script,
+// and the architecture is empty if it is unknown or the executable is universal.
+func Platform(name string) (goos, goarch string, err error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	magic := make([]byte, 4)
+	_, err = io.ReadFull(f, magic)
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+
+	switch {
+	case bytes.Equal(magic, []byte(elf.ELFMAG)):
+		e, err := elf.NewFile(f)
+		if err != nil {
+			return "", "", err
+		}
+		arch := elfArches[e.Machine]
+		if e.Machine == elf.EM_PPC64 {
+			arch = "ppc64"
+			if e.Data == elf.ELFDATA2LSB {
+				arch = "ppc64le"
+			}
+		}
+		return "linux", arch, nil
+	case bytes.Equal(magic, []byte{0xca, 0xfe, 0xba, 0xbe}):
+		return "darwin", "", nil
+	case isMachO(magic):
+		m, err := macho.NewFile(f)
+		if err != nil {
+			return "", "", err
+		}
+		return "darwin", machoArches[m.Cpu], nil
+	case bytes.Equal(magic[:2], []byte("MZ")):
+		p, err := pe.NewFile(f)
+		if err != nil {
+			return "", "", err
+		}
+		return "windows", peArches[p.Machine], nil
+	}
+	return "", "", nil
+}
+
+func isMachO(magic []byte) bool {
+	for _, m := range []uint32{macho.Magic32, macho.Magic64} {
+		be := []byte{byte(m >> 24), byte(m >> 16), byte(m >> 8), byte(m)}
+		le := []byte{byte(m), byte(m >> 8), byte(m >> 16), byte(m >> 24)}
+		if bytes.Equal(magic, be) || bytes.Equal(magic, le) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestPlatform(t *testing.T) {
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	goos, goarch, err := Platform(executable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if goos != runtime.GOOS || goarch != runtime.GOARCH {
+		t.Errorf("Platform() = %s/%s, want %s/%s", goos, goarch, runtime.GOOS, runtime.GOARCH)
+	}
+
+	script := filepath.Join(t.TempDir(), "script")
+	err = os.WriteFile(script, []byte("#!/bin/sh\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	goos, goarch, err = Platform(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if goos != "" || goarch != "" {
+		t.Errorf("Platform() = %s/%s, want unknown", goos, goarch)
+	}
+}
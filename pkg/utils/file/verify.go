@@ -0,0 +1,187 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+)
+
+// Verification is the checksum and the signature the downloaded file must match.
+type Verification struct {
+	// Sha256 is the sha256 checksum of the file.
+	Sha256 string
+	// Signature is the url or path of the cosign signature of the file.
+	Signature string
+	// Key is the path of the public key the file is signed with.
+	Key string
+	// Certificate is the url or path of the certificate of the keyless signing.
+	Certificate string
+	// CertificateIdentity is the identity expected in the certificate of the keyless signing.
+	CertificateIdentity string
+	// CertificateOIDCIssuer is the OIDC issuer expected in the certificate of the keyless signing.
+	CertificateOIDCIssuer string
+}
+
+// String returns the verification as a line, which is recorded once the file is verified.
+func (v *Verification) String() string {
+	return strings.Join([]string{
+		strings.ToLower(v.Sha256),
+		v.Signature,
+		v.Key,
+		v.Certificate,
+		v.CertificateIdentity,
+		v.CertificateOIDCIssuer,
+	}, " ")
+}
+
+// verifiedPath returns the path the verification of the src is recorded in,
+// only the files downloaded to the cache directory are recorded.
+func verifiedPath(cacheDir, src string) (string, bool) {
+	u, err := url.Parse(src)
+	if err != nil {
+		return "", false
+	}
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return "", false
+	}
+	cache, err := getCachePath(cacheDir, src)
+	if err != nil {
+		return "", false
+	}
+	return cache + verifiedSuffix, true
+}
+
+// isVerified returns true if the src is already verified with the same verification.
+func isVerified(cacheDir, src string, v *Verification) bool {
+	if v == nil {
+		return true
+	}
+	verified, ok := verifiedPath(cacheDir, src)
+	if !ok {
+		return false
+	}
+	data, err := os.ReadFile(verified)
+	if err != nil {
+		return false
+	}
+	return string(data) == v.String()
+}
+
+// recordVerified records the src is verified, so that the cached file is not verified again.
+func recordVerified(cacheDir, src string, v *Verification) error {
+	if v == nil {
+		return nil
+	}
+	verified, ok := verifiedPath(cacheDir, src)
+	if !ok {
+		return nil
+	}
+	return os.WriteFile(verified, []byte(v.String()), 0640)
+}
+
+// verify verifies the file got from the src matches the checksum and the signature,
+// the sum is the sha256 checksum of the file if it is computed as the file is downloaded.
+func verify(ctx context.Context, cacheDir, src, name string, sum []byte, v *Verification) error {
+	if v == nil {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	if v.Sha256 != "" {
+		if sum == nil {
+			s, err := sha256File(name)
+			if err != nil {
+				return err
+			}
+			sum = s
+		}
+		got := hex.EncodeToString(sum)
+		if !strings.EqualFold(v.Sha256, got) {
+			return fmt.Errorf("the sha256 checksum of %s is %s, but %s is required", src, got, v.Sha256)
+		}
+		logger.Debug("Verified checksum", "uri", src, "sha256", got)
+	}
+
+	if v.Signature != "" {
+		err := verifySignature(ctx, cacheDir, name, v)
+		if err != nil {
+			return fmt.Errorf("failed to verify the signature of %s: %w", src, err)
+		}
+		logger.Debug("Verified signature", "uri", src, "signature", v.Signature)
+	}
+	return nil
+}
+
+// verifySignature verifies the signature of the file with cosign.
+func verifySignature(ctx context.Context, cacheDir, name string, v *Verification) error {
+	cosign, err := exec.LookPath("cosign")
+	if err != nil {
+		return fmt.Errorf("cosign is required to verify the signature, install it first: %w", err)
+	}
+
+	signature, err := getCacheOrDownload(ctx, cacheDir, v.Signature, 0644, true, nil)
+	if err != nil {
+		return err
+	}
+	args := []string{"verify-blob", "--signature", signature}
+	if v.Key != "" {
+		args = append(args, "--key", v.Key)
+	}
+	if v.Certificate != "" {
+		certificate, err := getCacheOrDownload(ctx, cacheDir, v.Certificate, 0644, true, nil)
+		if err != nil {
+			return err
+		}
+		args = append(args, "--certificate", certificate)
+	}
+	if v.CertificateIdentity != "" {
+		args = append(args, "--certificate-identity", v.CertificateIdentity)
+	}
+	if v.CertificateOIDCIssuer != "" {
+		args = append(args, "--certificate-oidc-issuer", v.CertificateOIDCIssuer)
+	}
+	args = append(args, name)
+	return exec.Exec(ctx, cosign, args...)
+}
+
+func sha256File(name string) ([]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	hash := sha256.New()
+	_, err = io.Copy(hash, f)
+	if err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadWithVerification(t *testing.T) {
+	content := []byte("kwok")
+	sum := sha256.Sum256(content)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/kwok" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	src := server.URL + "/kwok"
+	verified := &Verification{Sha256: hex.EncodeToString(sum[:])}
+	mismatched := &Verification{Sha256: hex.EncodeToString(make([]byte, sha256.Size))}
+
+	tmp := t.TempDir()
+	cacheDir := filepath.Join(tmp, "cache")
+
+	_, err := DownloadToCache(context.Background(), cacheDir, src, 0644, true, mismatched)
+	if err == nil {
+		t.Fatalf("DownloadToCache() with the mismatched checksum should fail")
+	}
+	if IsCached(cacheDir, src, "") {
+		t.Fatalf("the mismatched file should not be cached")
+	}
+
+	_, err = DownloadToCache(context.Background(), cacheDir, src, 0644, true, verified)
+	if err != nil {
+		t.Fatalf("DownloadToCache() error = %v", err)
+	}
+	if !isVerified(cacheDir, src, verified) {
+		t.Fatalf("the verification should be recorded")
+	}
+
+	_, err = DownloadToCache(context.Background(), cacheDir, src, 0644, true, mismatched)
+	if err == nil {
+		t.Fatalf("DownloadToCache() with the mismatched checksum of the cached file should fail")
+	}
+	if isVerified(cacheDir, src, mismatched) {
+		t.Fatalf("the mismatched verification should not be recorded")
+	}
+
+	err = DownloadWithCache(context.Background(), cacheDir, src, filepath.Join(tmp, "bin", "kwok"), 0644, true, verified)
+	if err != nil {
+		t.Fatalf("DownloadWithCache() error = %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gotpl
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"net"
+
+	sprig "github.com/go-task/slim-sprig"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// unsafeFuncs are the functions that access the environment of the process or the network,
+// which should not be reachable from the templates.
+var unsafeFuncs = []string{
+	"env",
+	"expandenv",
+	"getHostByName",
+}
+
+// GenericFuncMap returns a curated set of the generic functions for the templates,
+// including the math, string, date, list, dict and random functions of sprig,
+// plus uuidv4, randIP and randAlphaNum.
+func GenericFuncMap() FuncMap {
+	funcMap := sprig.TxtFuncMap()
+	for _, name := range unsafeFuncs {
+		delete(funcMap, name)
+	}
+	funcMap["uuidv4"] = func() string {
+		return string(uuid.NewUUID())
+	}
+	funcMap["randAlphaNum"] = utilrand.String
+	funcMap["randIP"] = randIP
+	return funcMap
+}
+
+// randIP returns a random host IP in the CIDR
+func randIP(cidr string) (string, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	ip := ipnet.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	ones, bits := ipnet.Mask.Size()
+	hostBits := uint(bits - ones)
+	if hostBits == 0 {
+		return ip.String(), nil
+	}
+
+	size := new(big.Int).Lsh(big.NewInt(1), hostBits)
+	offset := new(big.Int)
+	if hostBits > 1 {
+		// Skip the network and broadcast addresses
+		offset = randBigInt(new(big.Int).Sub(size, big.NewInt(2)))
+		offset.Add(offset, big.NewInt(1))
+	} else {
+		//nolint:gosec
+		offset.SetInt64(rand.Int63n(2))
+	}
+
+	n := new(big.Int).SetBytes(ip)
+	n.Add(n, offset)
+	out := n.Bytes()
+	if len(out) > len(ip) {
+		return "", fmt.Errorf("ip overflow of cidr %q", cidr)
+	}
+	result := make(net.IP, len(ip))
+	copy(result[len(ip)-len(out):], out)
+	return result.String(), nil
+}
+
+// randBigInt returns a random number in [0, max) from the package-level source
+func randBigInt(max *big.Int) *big.Int {
+	if max.IsInt64() {
+		//nolint:gosec
+		return big.NewInt(rand.Int63n(max.Int64()))
+	}
+	// One more word than needed keeps the bias of the modulo negligible
+	n := new(big.Int)
+	word := new(big.Int)
+	for i := 0; i <= (max.BitLen()+63)/64; i++ {
+		n.Lsh(n, 64)
+		//nolint:gosec
+		n.Or(n, word.SetUint64(rand.Uint64()))
+	}
+	return n.Mod(n, max)
+}
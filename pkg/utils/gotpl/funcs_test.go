@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gotpl
+
+import (
+	"math/big"
+	"net"
+	"testing"
+)
+
+func Test_randIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		cidr    string
+		wantErr bool
+	}{
+		{
+			name: "ipv4",
+			cidr: "10.0.0.0/24",
+		},
+		{
+			name: "ipv4 two hosts",
+			cidr: "10.0.0.0/31",
+		},
+		{
+			name: "ipv6",
+			cidr: "fd00::/64",
+		},
+		{
+			name:    "invalid",
+			cidr:    "10.0.0.0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ipnet, _ := net.ParseCIDR(tt.cidr)
+			for i := 0; i != 100; i++ {
+				got, err := randIP(tt.cidr)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("randIP() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErr {
+					return
+				}
+				ip := net.ParseIP(got)
+				if ip == nil || !ipnet.Contains(ip) {
+					t.Fatalf("randIP() = %v, not in %s", got, tt.cidr)
+				}
+			}
+		})
+	}
+}
+
+func TestGenericFuncMap(t *testing.T) {
+	funcMap := GenericFuncMap()
+	for _, name := range unsafeFuncs {
+		if _, ok := funcMap[name]; ok {
+			t.Errorf("unsafe func %q should not be in the generic func map", name)
+		}
+	}
+	for _, name := range []string{"uuidv4", "randIP", "randAlphaNum", "randInt", "add", "date", "upper"} {
+		if _, ok := funcMap[name]; !ok {
+			t.Errorf("func %q should be in the generic func map", name)
+		}
+	}
+}
+
+func Test_randBigInt(t *testing.T) {
+	for _, max := range []*big.Int{
+		big.NewInt(1),
+		big.NewInt(254),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(2)),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 120), big.NewInt(2)),
+	} {
+		for i := 0; i < 100; i++ {
+			got := randBigInt(max)
+			if got.Sign() < 0 || got.Cmp(max) >= 0 {
+				t.Fatalf("randBigInt(%s) = %s, not in [0, %s)", max, got, max)
+			}
+		}
+	}
+}
@@ -35,6 +35,7 @@ type FuncMap = template.FuncMap
 // Renderer is a template Renderer interface.
 // It can render a template with the given text and original object.
 type Renderer interface {
+	Parse(text string) error
 	ToText(text string, original interface{}) ([]byte, error)
 	ToJSON(text string, original interface{}) ([]byte, error)
 }
@@ -56,20 +57,34 @@ func NewRenderer(funcMap FuncMap) Renderer {
 	}
 }
 
-func (r *renderer) render(buf *bytes.Buffer, text string, original interface{}) error {
+func (r *renderer) parse(text string) (*template.Template, error) {
 	text = strings.TrimSpace(text)
 	temp, ok := r.cache.Load(text)
 	if !ok {
 		var err error
 		temp, err = template.New("_").Funcs(r.funcMap).Parse(text)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		r.cache.Store(text, temp)
 	}
+	return temp, nil
+}
+
+// Parse checks the syntax of the template and the functions used by it, without rendering it.
+func (r *renderer) Parse(text string) error {
+	_, err := r.parse(text)
+	return err
+}
+
+func (r *renderer) render(buf *bytes.Buffer, text string, original interface{}) error {
+	temp, err := r.parse(text)
+	if err != nil {
+		return err
+	}
 
 	buf.Reset()
-	err := json.NewEncoder(buf).Encode(original)
+	err = json.NewEncoder(buf).Encode(original)
 	if err != nil {
 		return err
 	}
@@ -65,6 +65,13 @@ func TestRenderToJSON(t *testing.T) {
 			templText: `        {"foo":{{ Foo }},"k":{{ .k }}}       `,
 			expected:  `{"foo":"foo","k":"v1"}`,
 		},
+		{
+			name:      "with generic funcMap",
+			funcMap:   GenericFuncMap(),
+			original:  map[string]interface{}{"k": "v1", "n": 3},
+			templText: `{"k":{{ .k | upper | quote }},"n":{{ add (toString .n) 2 }},"ip":{{ randIP "10.0.0.0/32" | quote }}}`,
+			expected:  `{"ip":"10.0.0.0","k":"V1","n":5}`,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -79,3 +86,39 @@ func TestRenderToJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		templText string
+		wantErr   bool
+	}{
+		{
+			name:      "valid",
+			templText: `{"k":{{ .k | Foo }}}`,
+		},
+		{
+			name:      "unclosed action",
+			templText: `{"k":{{ .k }`,
+			wantErr:   true,
+		},
+		{
+			name:      "unknown function",
+			templText: `{"k":{{ Bar }}}`,
+			wantErr:   true,
+		},
+	}
+	r := NewRenderer(template.FuncMap{
+		"Foo": func(s string) string {
+			return s
+		},
+	})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := r.Parse(tc.templText)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -62,6 +62,8 @@ type Option struct {
 	FieldSelector      string
 	AnnotationSelector string
 	annotationSelector labels.Selector
+	// Filter is called with the objects matching the selectors, and the ones it returns false for are skipped.
+	Filter func(obj metav1.Object) bool
 }
 
 func (o *Option) setup(opts *metav1.ListOptions) {
@@ -80,27 +82,31 @@ func (o *Option) toListOptions() metav1.ListOptions {
 }
 
 func (o *Option) filter(obj any) (bool, error) {
-	if o.AnnotationSelector == "" {
+	if o.AnnotationSelector == "" && o.Filter == nil {
 		return true, nil
 	}
 
-	if o.annotationSelector == nil {
-		var err error
-		o.annotationSelector, err = labels.Parse(o.AnnotationSelector)
-		if err != nil {
-			return false, err
-		}
-	}
-
 	accessor, err := meta.Accessor(obj)
 	if err != nil {
 		return false, err
 	}
 
-	annotations := accessor.GetAnnotations()
-	if len(annotations) == 0 {
-		return false, nil
+	if o.AnnotationSelector != "" {
+		if o.annotationSelector == nil {
+			o.annotationSelector, err = labels.Parse(o.AnnotationSelector)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		annotations := accessor.GetAnnotations()
+		if len(annotations) == 0 || !o.annotationSelector.Matches(labels.Set(annotations)) {
+			return false, nil
+		}
 	}
 
-	return o.annotationSelector.Matches(labels.Set(annotations)), nil
+	if o.Filter != nil {
+		return o.Filter(accessor), nil
+	}
+	return true, nil
 }
@@ -18,6 +18,7 @@ package informer
 
 import (
 	"context"
+	"reflect"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -65,7 +66,7 @@ func (i *Informer[T, L]) Sync(ctx context.Context, opt Option, events chan<- Eve
 
 // WatchWithCache starts a goroutine that watches the resource and sends events to the events channel.
 func (i *Informer[T, L]) WatchWithCache(ctx context.Context, opt Option, events chan<- Event[T]) (Getter[T], error) {
-	var t T
+	t := newExpectedType[T]()
 	logger := log.FromContext(ctx)
 	store, contrtoller := cache.NewInformer(
 		&cache.ListWatch{
@@ -119,7 +120,7 @@ func (i *Informer[T, L]) WatchWithCache(ctx context.Context, opt Option, events
 
 // Watch starts a goroutine that watches the resource and sends events to the events channel.
 func (i *Informer[T, L]) Watch(ctx context.Context, opt Option, events chan<- Event[T]) error {
-	var t T
+	t := newExpectedType[T]()
 	informer := cache.NewReflectorWithOptions(
 		&cache.ListWatch{
 			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
@@ -139,6 +140,17 @@ func (i *Informer[T, L]) Watch(ctx context.Context, opt Option, events chan<- Ev
 	return nil
 }
 
+// newExpectedType returns a non-nil object of T,
+// the unstructured type needs it to get the type description.
+func newExpectedType[T runtime.Object]() T {
+	var t T
+	typ := reflect.TypeOf(t)
+	if typ != nil && typ.Kind() == reflect.Pointer {
+		return reflect.New(typ.Elem()).Interface().(T)
+	}
+	return t
+}
+
 func dummyCache[T runtime.Object](ch chan<- Event[T], opt Option) cache.Store {
 	return &cache.FakeCustomStore{
 		AddFunc: func(obj any) error {
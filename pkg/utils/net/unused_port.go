@@ -20,25 +20,79 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// MinUnusedPort is the lower bound of the unused ports by default.
+	MinUnusedPort uint32 = 10001
+	// MaxUnusedPort is the upper bound of the unused ports by default.
+	MaxUnusedPort uint32 = 32767
 )
 
 var (
-	errGetUnusedPort        = fmt.Errorf("unable to get an unused port")
-	lastUsedPort     uint32 = 32767
+	errGetUnusedPort = fmt.Errorf("unable to get an unused port")
+
+	lastUsedPortsMut sync.Mutex
+	// lastUsedPorts is the last used port of the ranges, the next port is looked for below it.
+	lastUsedPorts = map[[2]uint32]uint32{}
 )
 
 // GetUnusedPort returns an unused port on the local machine.
 func GetUnusedPort(ctx context.Context) (uint32, error) {
-	for lastUsedPort > 10000 && ctx.Err() == nil {
+	return GetUnusedPortInRange(ctx, MinUnusedPort, MaxUnusedPort, nil)
+}
+
+// GetUnusedPortInRange returns an unused port in the range [min, max] on the local machine.
+// If reserve is not nil, the port is returned only if it is reserved by reserve,
+// which keeps the other processes from getting the same port before it is listened on.
+func GetUnusedPortInRange(ctx context.Context, min, max uint32, reserve func(port uint32) bool) (uint32, error) {
+	lastUsedPortsMut.Lock()
+	defer lastUsedPortsMut.Unlock()
+
+	key := [2]uint32{min, max}
+	lastUsedPort, ok := lastUsedPorts[key]
+	if !ok {
+		lastUsedPort = max + 1
+	}
+	for lastUsedPort > min && ctx.Err() == nil {
 		lastUsedPort--
-		if isPortUnused(lastUsedPort) {
-			return lastUsedPort, nil
+		if !isPortUnused(lastUsedPort) {
+			continue
+		}
+		if reserve != nil && !reserve(lastUsedPort) {
+			continue
 		}
+		lastUsedPorts[key] = lastUsedPort
+		return lastUsedPort, nil
 	}
+	lastUsedPorts[key] = lastUsedPort
 
 	return 0, errGetUnusedPort
 }
 
+// ParsePortRange parses the port range in the form of <min>-<max>.
+func ParsePortRange(s string) (min, max uint32, err error) {
+	minStr, maxStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid port range %q, expected <min>-<max>", s)
+	}
+	minPort, err := strconv.ParseUint(strings.TrimSpace(minStr), 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port range %q: %w", s, err)
+	}
+	maxPort, err := strconv.ParseUint(strings.TrimSpace(maxStr), 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port range %q: %w", s, err)
+	}
+	if minPort == 0 || minPort > maxPort {
+		return 0, 0, fmt.Errorf("invalid port range %q, expected 0 < min <= max", s)
+	}
+	return uint32(minPort), uint32(maxPort), nil
+}
+
 func isPortUnused(port uint32) bool {
 	return isHostPortUnused(LocalAddress, port) && isHostPortUnused("", port)
 }
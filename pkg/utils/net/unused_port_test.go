@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParsePortRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		wantMin uint32
+		wantMax uint32
+		wantErr bool
+	}{
+		{
+			name:    "range",
+			s:       "30000-32767",
+			wantMin: 30000,
+			wantMax: 32767,
+		},
+		{
+			name:    "single port",
+			s:       "30000-30000",
+			wantMin: 30000,
+			wantMax: 30000,
+		},
+		{
+			name:    "without max",
+			s:       "30000",
+			wantErr: true,
+		},
+		{
+			name:    "reversed",
+			s:       "32767-30000",
+			wantErr: true,
+		},
+		{
+			name:    "out of range",
+			s:       "30000-70000",
+			wantErr: true,
+		},
+		{
+			name:    "zero",
+			s:       "0-100",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMin, gotMax, err := ParsePortRange(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePortRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotMin != tt.wantMin || gotMax != tt.wantMax {
+				t.Errorf("ParsePortRange() = %v-%v, want %v-%v", gotMin, gotMax, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestGetUnusedPortInRange(t *testing.T) {
+	ctx := context.Background()
+	reserved := map[uint32]bool{}
+	reserve := func(port uint32) bool {
+		if reserved[port] {
+			return false
+		}
+		reserved[port] = true
+		return true
+	}
+
+	const min, max = 41000, 41009
+	for i := 0; i != max-min+1; i++ {
+		port, err := GetUnusedPortInRange(ctx, min, max, reserve)
+		if err != nil {
+			// the port may be used by the others on the machine
+			continue
+		}
+		if port < min || port > max {
+			t.Fatalf("GetUnusedPortInRange() = %v, want in %v-%v", port, min, max)
+		}
+	}
+	_, err := GetUnusedPortInRange(ctx, min, max, reserve)
+	if err == nil {
+		t.Fatalf("GetUnusedPortInRange() want error after the range is used up")
+	}
+
+	// the ports reserved by the others are skipped
+	lastUsedPortsMut.Lock()
+	delete(lastUsedPorts, [2]uint32{min, max})
+	lastUsedPortsMut.Unlock()
+	_, err = GetUnusedPortInRange(ctx, min, max, reserve)
+	if err == nil {
+		t.Fatalf("GetUnusedPortInRange() want error as all the ports are reserved")
+	}
+}
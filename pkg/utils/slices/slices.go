@@ -49,6 +49,16 @@ func Find[S ~[]T, T any](s S, f func(T) bool) (t T, ok bool) {
 	return t, false
 }
 
+// IndexFunc returns the index of the first element in the slice that satisfies the predicate f, or -1 if none do.
+func IndexFunc[S ~[]T, T any](s S, f func(T) bool) int {
+	for i, v := range s {
+		if f(v) {
+			return i
+		}
+	}
+	return -1
+}
+
 // Filter returns a new slice containing all elements in the slice that satisfy the predicate f.
 func Filter[S ~[]T, T any](s S, f func(T) bool) []T {
 	out := make([]T, 0, len(s))
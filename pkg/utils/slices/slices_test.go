@@ -126,6 +126,47 @@ func TestFind(t *testing.T) {
 	}
 }
 
+func TestIndexFunc(t *testing.T) {
+	type args[S interface{ ~[]T }, T any] struct {
+		s S
+		f func(T) bool
+	}
+	type testCase[S interface{ ~[]T }, T any] struct {
+		name string
+		args args[S, T]
+		want int
+	}
+	tests := []testCase[[]string, string]{
+		{
+			name: "test index func expect index",
+			args: args[[]string, string]{
+				s: []string{"a", "b", "c"},
+				f: func(s string) bool {
+					return s == "b"
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "test index func expect not found",
+			args: args[[]string, string]{
+				s: []string{"a", "b", "c"},
+				f: func(s string) bool {
+					return s == "d"
+				},
+			},
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IndexFunc(tt.args.s, tt.args.f); got != tt.want {
+				t.Errorf("IndexFunc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMap(t *testing.T) {
 	type args[S interface{ ~[]T }, T any, O any] struct {
 		s S
@@ -79,6 +79,25 @@ function main() {
       echo "ALL"
       echo "------------------------------"
     fi
+    if [[ "${runtime}" == "kind" || "${runtime}" == "kind-podman" ]]; then
+      echo "------------------------------"
+      echo "Testing dryrun adopt on runtime ${runtime}"
+      got="$(kwokctl adopt --name "${name}" --runtime "${runtime}" --kind-name "${name}-kind" --dry-run | clear_testdata "${name}")"
+      want="$(<"${DIR}/testdata/${runtime}/adopt_cluster.txt")"
+      if [[ "${got}" != "${want}" ]]; then
+        echo "------------------------------"
+        diff -u <(echo "${want}") <(echo "${got}")
+        failed+=("adopt-cluster-${runtime}-dry-run")
+        if [[ "${UPDATE_DRY_RUN_TESTDATA}" == "true" ]]; then
+          echo "${got}" >"${DIR}/testdata/${runtime}/adopt_cluster.txt"
+        fi
+        echo "------------------------------"
+        echo "cat <<ALL >${DIR}/testdata/${runtime}/adopt_cluster.txt"
+        echo "${got}"
+        echo "ALL"
+        echo "------------------------------"
+      fi
+    fi
   done
 
   if [[ "${#failed[@]}" -ne 0 ]]; then